@@ -4,6 +4,8 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/Bhinneka/user-service/config/rsa"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
 	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
 	authServices "github.com/Bhinneka/user-service/src/auth/v1/service"
 	authToken "github.com/Bhinneka/user-service/src/auth/v1/token"
@@ -18,7 +20,11 @@ import (
 	"github.com/Bhinneka/user-service/src/service"
 	sessionInfoQuery "github.com/Bhinneka/user-service/src/session/v1/query"
 	sessionInfoRepo "github.com/Bhinneka/user-service/src/session/v1/repo"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
 	sharedRepo "github.com/Bhinneka/user-service/src/shared/repository"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 	shippingAddressRepo "github.com/Bhinneka/user-service/src/shipping_address/v2/repo"
 )
 
@@ -37,20 +43,32 @@ type ServiceRepository struct {
 	CorporateContactAddressRepository  corporateRepo.ContactAddressRepository
 	CorporateContactTempRepository     corporateRepo.ContactTempRepository
 	CorporateLeadsRepository           corporateRepo.LeadsRepository
+	CorporateAccountSAMLRepository     corporateRepo.AccountSAMLRepository
 	MerchantRepository                 merchantRepo.MerchantRepository
 	MerchantDocumentRepository         merchantRepo.MerchantDocumentRepository
 	MerchantBankRepository             merchantRepo.MerchantBankRepository
 	MerchantEmployeeRepository         merchantRepo.MerchantEmployeeRepository
 	MerchantAddressRepository          merchantRepo.MerchantAddressRepository
+	MerchantStatusHistoryRepository    merchantRepo.MerchantStatusHistoryRepository
+	MerchantBankVerificationRepository merchantRepo.MerchantBankVerificationRepository
+	MerchantBankChangeRepository       merchantRepo.MerchantBankChangeRepository
 	ShippingAddressRepository          shippingAddressRepo.ShippingAddressRepository
 	ShippingAddressRedisRepository     shippingAddressRepo.ShippingAddressRepositoryRedis
 	MemberRepository                   memberRepo.MemberRepository
 	MemberMFARepository                memberRepo.MemberMFARepository
+	MemberPasskeyRepository            memberRepo.MemberPasskeyRepository
+	MemberMFARecoveryRepository        memberRepo.MemberMFARecoveryRepository
+	MemberMFAMethodRepository          memberRepo.MemberMFAMethodRepository
+	MemberPasswordHistoryRepository    memberRepo.MemberPasswordHistoryRepository
+	MemberSessionLabelRepository       memberRepo.MemberSessionLabelRepository
+	MemberIdentityRepository           memberRepo.MemberIdentityRepository
 	MemberRedisRepository              memberRepo.MemberRepositoryRedis
 	TokenActivationRepoRedis           memberRepo.TokenActivationRepository
 	AttemptRepositoryRedis             authRepo.AttemptRepository
+	LockoutRepositoryRedis             authRepo.LockoutRepository
 	LoginSessionRepositoryRedis        authRepo.LoginSessionRepository
 	RefreshTokenRepository             authRepo.RefreshTokenRepository
+	AuthorizationCodeRepository        authRepo.AuthorizationCodeRepository
 	SessionInfoRepo                    sessionInfoRepo.SessionInfoRepository
 	DocumentRepository                 documentRepo.DocumentRepository
 	Repository                         *sharedRepo.Repository
@@ -62,6 +80,7 @@ type ServiceRepository struct {
 type ServiceQuery struct {
 	CorporateContactQueryRead    corporateQuery.ContactQuery
 	CorporateAccContactQueryRead corporateQuery.AccountContactQuery
+	CorporateAccountSAMLQuery    corporateQuery.AccountSAMLQuery
 	MemberQueryRead              memberQuery.MemberQuery
 	MemberQueryWrite             memberQuery.MemberQuery
 	MemberMFAQueryRead           memberQuery.MemberMFAQuery
@@ -78,6 +97,9 @@ type ServiceShared struct {
 	QPublisher          service.QPublisher
 	NotificationService service.NotificationServices
 	SendbirdService     service.SendbirdServices
+	SMSService          service.SMSServices
+	BankVerifier        service.BankAccountVerifier
+	MFAOTP              *mfaotp.Issuer
 }
 
 // OAuthService general struct
@@ -105,6 +127,9 @@ type OAuthService struct {
 // MembershipParameters member parameter
 type MembershipParameters struct {
 	Hash                              memberModel.PasswordHasher
+	PasswordPolicy                    *memberModel.PasswordPolicy
+	BreachedPasswords                 memberModel.BreachedPasswordChecker
+	SessionLocator                    memberModel.SessionLocator
 	TokenActivationExpiration         time.Duration
 	ResendActivationAttemptAge        string
 	ResendActivationAttemptAgeRequest string
@@ -113,6 +138,10 @@ type MembershipParameters struct {
 	SturgeonCFUrl                     string
 	B2cCFUrl                          string
 	AccessTokenGenerator              authToken.AccessTokenGenerator
+	WebAuthn                          *webauthn.RelyingParty
+	MFAOTP                            *mfaotp.Issuer
+	Captcha                           *captcha.Guard
+	Connectors                        *connector.Registry
 }
 
 // AuthParameters auth parameter
@@ -121,6 +150,7 @@ type AuthParameters struct {
 	AuthServices           authServices.LDAPService
 	GoogleVerifyCaptchaURL *url.URL
 	AccessTokenGenerator   authToken.AccessTokenGenerator
+	KeySet                 *rsa.KeySet
 	RefreshTokenAge        string
 	B2cCFUrl               string
 	LoginAttemptAge        string
@@ -128,4 +158,13 @@ type AuthParameters struct {
 	IsProductionStage      bool
 	SpecialRefreshTokenAge string
 	EmailSpecialTokenAge   string
+	OIDCIssuer             string
+	WebAuthn               *webauthn.RelyingParty
+	MFAOTP                 *mfaotp.Issuer
+	LockoutPolicies        authModel.LockoutPolicies
+	RiskPolicy             authModel.RiskPolicy
+	GeoLocator             authModel.GeoLocator
+	Captcha                *captcha.Guard
+	Connectors             *connector.Registry
+	SAMLBaseURL            string
 }
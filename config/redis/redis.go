@@ -10,4 +10,5 @@ type Client interface {
 	Ping() (string, error)
 	Expire(key string, exp time.Duration) (bool, error)
 	Keys(key string) ([]string, error)
+	Eval(script string, keys []string, args ...interface{}) (interface{}, error)
 }
@@ -55,6 +55,11 @@ func (r Conn) Keys(key string) ([]string, error) {
 	return r.Client.Keys(key).Result()
 }
 
+// Eval run lua script
+func (r Conn) Eval(script string, keys []string, args ...interface{}) (interface{}, error) {
+	return r.Client.Eval(script, keys, args...).Result()
+}
+
 // GetRedis function
 func GetRedis(redisHost, redisTLS, redisPassword, redisPort, redisDB string) (*redis.Client, error) {
 	//Transport Layer Security config,
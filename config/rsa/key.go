@@ -2,7 +2,6 @@ package rsa
 
 import (
 	"crypto/ecdsa"
-	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
@@ -19,41 +18,6 @@ const (
 	appleKeyPath   = "config/rsa/authKey_apple.p8"
 )
 
-var (
-	// VerifyKey rsa from config
-	VerifyKey *rsa.PublicKey
-
-	signKey *rsa.PrivateKey
-)
-
-// InitPublicKey return *rsa.PublicKey
-func InitPublicKey() (*rsa.PublicKey, error) {
-	verifyBytes, err := ioutil.ReadFile(publicKeyPath)
-	if err != nil {
-		return nil, err
-	}
-
-	VerifyKey, err = jwt.ParseRSAPublicKeyFromPEM(verifyBytes)
-	if err != nil {
-		return nil, err
-	}
-	return VerifyKey, nil
-}
-
-// InitPrivateKey return *rsa.PrivateKey
-func InitPrivateKey() (*rsa.PrivateKey, error) {
-	signBytes, err := ioutil.ReadFile(privateKeyPath)
-	if err != nil {
-		return nil, err
-	}
-
-	signKey, err = jwt.ParseRSAPrivateKeyFromPEM(signBytes)
-	if err != nil {
-		return nil, err
-	}
-	return signKey, nil
-}
-
 // InitAppleClientSecret return clientSecret
 func InitAppleClientSecret(clientID, teamID, keyID string) (string, error) {
 	ctx := "InitAppleClientSecret"
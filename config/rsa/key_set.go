@@ -0,0 +1,363 @@
+package rsa
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// defaultKeysDir directory of rotated signing keys, `<kid>.rsa` for signing keys and `<kid>.rsa.pub` for verify only keys
+	defaultKeysDir    = "config/rsa/keys"
+	privateKeySuffix  = ".rsa"
+	publicKeySuffix   = ".rsa.pub"
+	keyUseSignature   = "sig"
+	keyTypeRSA        = "RSA"
+	signingAlgorithm  = "RS256"
+	errUnknownKeyID   = "unknown signing key id: %s"
+	errNoSigningKey   = "no signing key is configured"
+	errUnexpectedAlgo = "unexpected signing method: %v"
+	errInvalidJWK     = "invalid %s json web key %s"
+)
+
+// JSONWebKey rfc 7517 public key
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JSONWebKeySet rfc 7517 key set served on /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// KeySet collection of token keys identified by `kid`, only the active key signs new tokens
+// while every key in the set is still accepted for verification
+type KeySet struct {
+	mu           sync.RWMutex
+	activeKeyID  string
+	defaultKeyID string
+	signKeys     map[string]*rsa.PrivateKey
+	verifyKeys   map[string]*rsa.PublicKey
+}
+
+// NewKeySet function for initializing empty KeySet
+func NewKeySet() *KeySet {
+	return &KeySet{
+		signKeys:   make(map[string]*rsa.PrivateKey),
+		verifyKeys: make(map[string]*rsa.PublicKey),
+	}
+}
+
+// NewVerifyKeySet function for initializing KeySet from public keys, the key id is the key thumbprint
+func NewVerifyKeySet(keys ...*rsa.PublicKey) *KeySet {
+	ks := NewKeySet()
+	for _, key := range keys {
+		ks.AddVerifyKey(KeyThumbprint(key), key)
+	}
+	return ks
+}
+
+// AddSigningKey function for adding private key, the public part is also used for verification
+func (ks *KeySet) AddSigningKey(keyID string, key *rsa.PrivateKey) {
+	ks.mu.Lock()
+	ks.signKeys[keyID] = key
+	ks.mu.Unlock()
+	ks.AddVerifyKey(keyID, &key.PublicKey)
+}
+
+// AddVerifyKey function for adding public key of retired or external signer.
+// The first key added verifies tokens issued before `kid` header was introduced
+func (ks *KeySet) AddVerifyKey(keyID string, key *rsa.PublicKey) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.verifyKeys[keyID] = key
+	if ks.defaultKeyID == "" {
+		ks.defaultKeyID = keyID
+	}
+}
+
+// SetActiveKeyID function for choosing the key that signs new tokens
+func (ks *KeySet) SetActiveKeyID(keyID string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if _, ok := ks.signKeys[keyID]; !ok {
+		return fmt.Errorf(errUnknownKeyID, keyID)
+	}
+	ks.activeKeyID = keyID
+	return nil
+}
+
+// SetDefaultKeyID function for choosing the key that verifies tokens without `kid` header
+func (ks *KeySet) SetDefaultKeyID(keyID string) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if _, ok := ks.verifyKeys[keyID]; !ok {
+		return fmt.Errorf(errUnknownKeyID, keyID)
+	}
+	ks.defaultKeyID = keyID
+	return nil
+}
+
+// SigningKey function for getting active key id and its private key
+func (ks *KeySet) SigningKey() (string, *rsa.PrivateKey, error) {
+	if ks == nil {
+		return "", nil, errors.New(errNoSigningKey)
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok := ks.signKeys[ks.activeKeyID]
+	if !ok {
+		return "", nil, errors.New(errNoSigningKey)
+	}
+	return ks.activeKeyID, key, nil
+}
+
+// VerifyKey function for getting public key by its id, empty id means token issued before key rotation
+func (ks *KeySet) VerifyKey(keyID string) (*rsa.PublicKey, error) {
+	if ks == nil {
+		return nil, fmt.Errorf(errUnknownKeyID, keyID)
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if keyID == "" {
+		keyID = ks.defaultKeyID
+	}
+	key, ok := ks.verifyKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf(errUnknownKeyID, keyID)
+	}
+	return key, nil
+}
+
+// Keyfunc function for jwt parser, selecting verification key from `kid` header
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf(errUnexpectedAlgo, token.Header["alg"])
+	}
+	keyID, _ := token.Header["kid"].(string)
+	return ks.VerifyKey(keyID)
+}
+
+// JWKS function for getting public keys of the set in rfc 7517 format
+func (ks *KeySet) JWKS() JSONWebKeySet {
+	result := JSONWebKeySet{Keys: []JSONWebKey{}}
+	if ks == nil {
+		return result
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	keyIDs := make([]string, 0, len(ks.verifyKeys))
+	for keyID := range ks.verifyKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	for _, keyID := range keyIDs {
+		result.Keys = append(result.Keys, newJSONWebKey(keyID, ks.verifyKeys[keyID]))
+	}
+	return result
+}
+
+// NewJWKSKeySet function for initializing verify only KeySet from the rfc 7517 key set of another issuer,
+// keys that are not rsa signature keys are skipped
+func NewJWKSKeySet(jwks JSONWebKeySet) *KeySet {
+	ks := NewKeySet()
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != keyUseSignature {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		ks.AddVerifyKey(jwk.KeyID, key)
+	}
+	return ks
+}
+
+// PublicKey function for decoding rsa public key of the json web key
+func (jwk JSONWebKey) PublicKey() (*rsa.PublicKey, error) {
+	if jwk.KeyType != keyTypeRSA {
+		return nil, fmt.Errorf(errInvalidJWK, jwk.KeyType, jwk.KeyID)
+	}
+
+	modulus, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.Modulus, "="))
+	if err != nil || len(modulus) == 0 {
+		return nil, fmt.Errorf(errInvalidJWK, jwk.KeyType, jwk.KeyID)
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.Exponent, "="))
+	if err != nil || len(exponent) == 0 || len(exponent) > 4 {
+		return nil, fmt.Errorf(errInvalidJWK, jwk.KeyType, jwk.KeyID)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+// KeyThumbprint function for computing rfc 7638 thumbprint of a public key
+func KeyThumbprint(key *rsa.PublicKey) string {
+	jwk := newJSONWebKey("", key)
+	// members must be in lexicographic order without whitespace
+	canonical := fmt.Sprintf(`{"e":"%s","kty":"%s","n":"%s"}`, jwk.Exponent, jwk.KeyType, jwk.Modulus)
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func newJSONWebKey(keyID string, key *rsa.PublicKey) JSONWebKey {
+	return JSONWebKey{
+		KeyType:   keyTypeRSA,
+		Use:       keyUseSignature,
+		Algorithm: signingAlgorithm,
+		KeyID:     keyID,
+		Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// InitKeySet function for loading token keys.
+// Keys are read from JWT_KEYS_DIR (default config/rsa/keys) and JWT_ACTIVE_KID chooses the signing key.
+// The legacy config/rsa/app.rsa pair is still loaded, identified by its thumbprint, and keeps verifying
+// tokens issued without `kid` header
+func InitKeySet() (*KeySet, error) {
+	ks := NewKeySet()
+
+	legacyKeyID, err := loadLegacyKeys(ks)
+	if err != nil {
+		return nil, err
+	}
+	hasLegacyKey := ks.defaultKeyID != ""
+
+	keysDir := os.Getenv("JWT_KEYS_DIR")
+	if keysDir == "" {
+		keysDir = defaultKeysDir
+	}
+	if err := loadKeysDir(ks, keysDir); err != nil {
+		return nil, err
+	}
+
+	activeKeyID := os.Getenv("JWT_ACTIVE_KID")
+	if activeKeyID == "" {
+		activeKeyID = legacyKeyID
+	}
+	if activeKeyID == "" && len(ks.signKeys) == 1 {
+		for keyID := range ks.signKeys {
+			activeKeyID = keyID
+		}
+	}
+	if activeKeyID == "" {
+		return nil, errors.New(errNoSigningKey)
+	}
+	if err := ks.SetActiveKeyID(activeKeyID); err != nil {
+		return nil, err
+	}
+
+	if !hasLegacyKey {
+		if err := ks.SetDefaultKeyID(activeKeyID); err != nil {
+			return nil, err
+		}
+	}
+
+	return ks, nil
+}
+
+// loadLegacyKeys loads the single key pair used before rotation was supported
+func loadLegacyKeys(ks *KeySet) (string, error) {
+	signBytes, err := ioutil.ReadFile(privateKeyPath)
+	if err == nil {
+		signKey, err := jwt.ParseRSAPrivateKeyFromPEM(signBytes)
+		if err != nil {
+			return "", err
+		}
+		keyID := KeyThumbprint(&signKey.PublicKey)
+		ks.AddSigningKey(keyID, signKey)
+		return keyID, ks.SetDefaultKeyID(keyID)
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	verifyBytes, err := ioutil.ReadFile(publicKeyPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	verifyKey, err := jwt.ParseRSAPublicKeyFromPEM(verifyBytes)
+	if err != nil {
+		return "", err
+	}
+	keyID := KeyThumbprint(verifyKey)
+	ks.AddVerifyKey(keyID, verifyKey)
+	// only a verify key, it must not become the active key
+	return "", ks.SetDefaultKeyID(keyID)
+}
+
+func loadKeysDir(ks *KeySet, keysDir string) error {
+	files, err := ioutil.ReadDir(keysDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		name := file.Name()
+		content, err := ioutil.ReadFile(filepath.Join(keysDir, name))
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case strings.HasSuffix(name, publicKeySuffix):
+			keyID := strings.TrimSuffix(name, publicKeySuffix)
+			if _, err := ks.VerifyKey(keyID); err == nil {
+				// the private key of this id is already loaded
+				continue
+			}
+			verifyKey, err := jwt.ParseRSAPublicKeyFromPEM(content)
+			if err != nil {
+				return fmt.Errorf("%s: %s", name, err.Error())
+			}
+			ks.AddVerifyKey(keyID, verifyKey)
+		case strings.HasSuffix(name, privateKeySuffix):
+			signKey, err := jwt.ParseRSAPrivateKeyFromPEM(content)
+			if err != nil {
+				return fmt.Errorf("%s: %s", name, err.Error())
+			}
+			ks.AddSigningKey(strings.TrimSuffix(name, privateKeySuffix), signKey)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	return key
+}
+
+func signToken(t *testing.T, keyID string, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "USR123"})
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+	tokenString, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return tokenString
+}
+
+func TestKeySet(t *testing.T) {
+	oldKey := generateKey(t)
+	newKey := generateKey(t)
+	ks := NewKeySet()
+	ks.AddSigningKey("old", oldKey)
+	ks.AddSigningKey("new", newKey)
+	assert.NoError(t, ks.SetActiveKeyID("new"))
+	assert.Error(t, ks.SetActiveKeyID("unknown"))
+
+	t.Run("active key", func(t *testing.T) {
+		keyID, key, err := ks.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "new", keyID)
+		assert.Equal(t, newKey, key)
+	})
+
+	t.Run("verify by kid", func(t *testing.T) {
+		_, err := jwt.Parse(signToken(t, "old", oldKey), ks.Keyfunc)
+		assert.NoError(t, err)
+		_, err = jwt.Parse(signToken(t, "new", newKey), ks.Keyfunc)
+		assert.NoError(t, err)
+		_, err = jwt.Parse(signToken(t, "new", oldKey), ks.Keyfunc)
+		assert.Error(t, err)
+		_, err = jwt.Parse(signToken(t, "unknown", oldKey), ks.Keyfunc)
+		assert.Error(t, err)
+	})
+
+	t.Run("verify without kid uses default key", func(t *testing.T) {
+		_, err := jwt.Parse(signToken(t, "", oldKey), ks.Keyfunc)
+		assert.NoError(t, err)
+		_, err = jwt.Parse(signToken(t, "", newKey), ks.Keyfunc)
+		assert.Error(t, err)
+	})
+
+	t.Run("reject hmac token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "USR123"})
+		tokenString, _ := token.SignedString([]byte("secret"))
+		_, err := jwt.Parse(tokenString, ks.Keyfunc)
+		assert.Error(t, err)
+	})
+
+	t.Run("jwks", func(t *testing.T) {
+		jwks := ks.JWKS()
+		assert.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "new", jwks.Keys[0].KeyID)
+		assert.Equal(t, "old", jwks.Keys[1].KeyID)
+		assert.Equal(t, "AQAB", jwks.Keys[0].Exponent)
+		assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	})
+
+	t.Run("nil key set", func(t *testing.T) {
+		var empty *KeySet
+		_, _, err := empty.SigningKey()
+		assert.Error(t, err)
+		_, err = empty.VerifyKey("new")
+		assert.Error(t, err)
+		assert.Empty(t, empty.JWKS().Keys)
+	})
+}
+
+func TestKeyThumbprint(t *testing.T) {
+	key := generateKey(t)
+	assert.Equal(t, KeyThumbprint(&key.PublicKey), KeyThumbprint(&key.PublicKey))
+	assert.Len(t, KeyThumbprint(&key.PublicKey), 43)
+
+	ks := NewVerifyKeySet(&key.PublicKey)
+	_, err := ks.VerifyKey(KeyThumbprint(&key.PublicKey))
+	assert.NoError(t, err)
+}
+
+func TestNewJWKSKeySet(t *testing.T) {
+	key := generateKey(t)
+	ks := NewKeySet()
+	ks.AddVerifyKey("external", &key.PublicKey)
+
+	jwks := ks.JWKS()
+	jwks.Keys = append(jwks.Keys,
+		JSONWebKey{KeyType: "EC", Use: keyUseSignature, KeyID: "ec"},
+		JSONWebKey{KeyType: keyTypeRSA, Use: "enc", KeyID: "encryption", Modulus: jwks.Keys[0].Modulus, Exponent: jwks.Keys[0].Exponent},
+		JSONWebKey{KeyType: keyTypeRSA, KeyID: "broken", Modulus: "%%", Exponent: "AQAB"},
+	)
+
+	verifyKeys := NewJWKSKeySet(jwks)
+	publicKey, err := verifyKeys.VerifyKey("external")
+	assert.NoError(t, err)
+	assert.Equal(t, key.PublicKey.N, publicKey.N)
+	assert.Equal(t, key.PublicKey.E, publicKey.E)
+
+	for _, keyID := range []string{"ec", "encryption", "broken"} {
+		_, err = verifyKeys.VerifyKey(keyID)
+		assert.Error(t, err)
+	}
+
+	_, err = jwt.Parse(signToken(t, "external", key), verifyKeys.Keyfunc)
+	assert.NoError(t, err)
+}
+
+func TestInitKeySet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keys")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	retiredKey := generateKey(t)
+	currentKey := generateKey(t)
+	nextKey := generateKey(t)
+
+	retiredBytes, _ := x509.MarshalPKIXPublicKey(&retiredKey.PublicKey)
+	files := map[string][]byte{
+		"2020-01.rsa.pub": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: retiredBytes}),
+		"2021-01.rsa":     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(currentKey)}),
+		"2022-01.rsa":     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(nextKey)}),
+		"README":          []byte("ignored"),
+	}
+	for name, content := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), content, 0600))
+	}
+
+	os.Setenv("JWT_KEYS_DIR", dir)
+	defer os.Unsetenv("JWT_KEYS_DIR")
+
+	t.Run("several signing keys need active kid", func(t *testing.T) {
+		_, err := InitKeySet()
+		assert.Error(t, err)
+	})
+
+	t.Run("active kid", func(t *testing.T) {
+		os.Setenv("JWT_ACTIVE_KID", "2021-01")
+		defer os.Unsetenv("JWT_ACTIVE_KID")
+
+		ks, err := InitKeySet()
+		assert.NoError(t, err)
+
+		keyID, _, err := ks.SigningKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "2021-01", keyID)
+		assert.Len(t, ks.JWKS().Keys, 3)
+
+		_, err = jwt.Parse(signToken(t, "2020-01", retiredKey), ks.Keyfunc)
+		assert.NoError(t, err)
+		_, err = jwt.Parse(signToken(t, "", currentKey), ks.Keyfunc)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown active kid", func(t *testing.T) {
+		os.Setenv("JWT_ACTIVE_KID", "2020-01")
+		defer os.Unsetenv("JWT_ACTIVE_KID")
+
+		_, err := InitKeySet()
+		assert.Error(t, err)
+	})
+}
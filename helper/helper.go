@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/md5"
 	"crypto/sha1"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/base64"
 	"encoding/hex"
@@ -437,6 +438,12 @@ func GenerateTokenByString(str string) string {
 	return token
 }
 
+// HashSHA256 function for hashing secret value before storing it, returns hex string
+func HashSHA256(str string) string {
+	sum := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
 var camel = regexp.MustCompile("(^[^A-Z]*|[A-Z]*)([A-Z][^A-Z]+|$)")
 
 // CamelToLowerCase function for convert camelcase to lower
@@ -16,12 +16,10 @@ import (
 	authToken "github.com/Bhinneka/user-service/src/auth/v1/token"
 	clientQuery "github.com/Bhinneka/user-service/src/client/v1/query"
 	corporateRepo "github.com/Bhinneka/user-service/src/corporate/v2/repo"
-	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
 	memberRepo "github.com/Bhinneka/user-service/src/member/v1/repo"
 	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
 	paymentRepo "github.com/Bhinneka/user-service/src/payments/v1/repo"
 	"github.com/Bhinneka/user-service/src/service"
-	"github.com/Bhinneka/user-service/src/shared"
 	sharedRepository "github.com/Bhinneka/user-service/src/shared/repository"
 	shippingAddressRepo "github.com/Bhinneka/user-service/src/shipping_address/v2/repo"
 	"github.com/getsentry/raven-go"
@@ -82,9 +80,9 @@ func main() {
 	redisDB, _ = os.LookupEnv("REDIS_DB")
 	// redis env
 
-	publicKey, err := rsa.InitPublicKey()
+	keySet, err := rsa.InitKeySet()
 	if err != nil {
-		helper.Log(log.ErrorLevel, err.Error(), ctx, "private_key")
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "key_set")
 		os.Exit(1)
 	}
 
@@ -106,13 +104,12 @@ func main() {
 		os.Exit(1)
 	}
 
-	redisConnection, err := redis.ConnectRedis(os.Getenv("REDIS_HOST"), os.Getenv("REDIS_TLS"), os.Getenv("REDIS_PASSWORD"), os.Getenv("REDIS_PORT"), redisDB)
+	redisConnection, err := redis.ConnectRedis(redisHost, redisTLS, redisAuth, redisPort, redisDB)
 	if err != nil {
 		helper.Log(log.ErrorLevel, err.Error(), ctx, "redis_connection")
 		os.Exit(1)
 	}
 
-	privateKey, _ := rsa.InitPrivateKey()
 	tokenAge := golib.GetEnvDurationOrFail(ctx, "parse_token_age", "ACCESS_TOKEN_AGE")
 
 	refreshTokenAge := golib.GetEnvDurationOrFail(ctx, "parse_refresh_token_age", "REFRESH_TOKEN_AGE")
@@ -131,9 +128,6 @@ func main() {
 
 	// initial member repository
 
-	// initial member query
-	memberQueryWrite := memberQuery.NewMemberQueryPostgres(writeDB)
-
 	// initial member query
 	memberRepoWrite := memberRepo.NewMemberRepoPostgres(sRepository)
 
@@ -193,7 +187,7 @@ func main() {
 	loginSessionRedisRepo := authRepo.NewLoginSessionRepositoryRedis(redisConnection)
 
 	// initital jwt generator
-	accessTokenGenerator := authToken.NewJwtGenerator(privateKey, tokenAge, refreshTokenAge, specialTokenAge, specialRefreshTokenAge, loginSessionRedisRepo, emailForSpecialToken)
+	accessTokenGenerator := authToken.NewJwtGenerator(keySet, tokenAge, refreshTokenAge, specialTokenAge, specialRefreshTokenAge, loginSessionRedisRepo, emailForSpecialToken)
 
 	kafkaBroker1 := golib.GetEnvOrFail(ctx, "kafka_find_host_1", "KAFKA_BHINNEKA_BROKER_1")
 	kafkaBroker2 := golib.GetEnvOrFail(ctx, "kafka_find_host_2", "KAFKA_BHINNEKA_BROKER_2")
@@ -201,20 +195,9 @@ func main() {
 	brokers := []string{kafkaBroker1, kafkaBroker2, kafkaBroker3}
 	kafkaMessaging, _ := service.NewKafkaPublisher(kafkaBroker1, kafkaBroker2, kafkaBroker3)
 
-	app := MakeHandler(readDB, writeDB, kafkaMessaging)
+	app := MakeHandler(readDB, writeDB, kafkaMessaging, keySet)
 	clientAppQuery := clientQuery.NewClientAppQuery(readDB)
 
-	// redis subscriber
-	redisPubSubConfig := &shared.RedisPubSubConfig{
-		Host:     redisHost,
-		Password: redisAuth,
-		Port:     redisPort,
-		UseTLS:   redisTLS,
-		UseDB:    redisDB,
-	}
-
-	redisPubSubClient := shared.NewRedisPubSub(redisPubSubConfig)
-
 	pathSchema := "schema/json"
 	jsonschema.Load(pathSchema)
 	serviceRepo := localConfig.ServiceRepository{
@@ -244,13 +227,13 @@ func main() {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		app.HTTPServerMain(publicKey, clientAppQuery)
+		app.HTTPServerMain(keySet, clientAppQuery)
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		app.GRPCServerMain(publicKey, kafkaMessaging)
+		app.GRPCServerMain(keySet, kafkaMessaging)
 	}()
 
 	if enableConsumerDolphin == "true" {
@@ -279,11 +262,29 @@ func main() {
 		consumeKafkaShark(serviceRepo, brokers)
 	}()
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		redisSubscribe(redisPubSubClient, memberQueryWrite)
-	}()
+	if os.Getenv("ENABLE_MERCHANT_DOCUMENT_EXPIRY") == "true" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatchMerchantDocumentExpiry(app, redisConnection)
+		}()
+	}
+
+	if os.Getenv("ENABLE_MERCHANT_BANK_CHANGE") == "true" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatchMerchantBankChange(app, redisConnection)
+		}()
+	}
+
+	if os.Getenv("ENABLE_MERCHANT_BANK_VERIFICATION_POLL") == "true" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dispatchMerchantBankVerification(app, redisConnection)
+		}()
+	}
 
 	// Wait All services to end
 	wg.Wait()
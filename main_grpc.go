@@ -1,12 +1,12 @@
 package main
 
 import (
-	"crypto/rsa"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
 
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	rpc "github.com/Bhinneka/user-service/src/grpc/servers"
 	healthDelivery "github.com/Bhinneka/user-service/src/health/delivery"
@@ -19,7 +19,7 @@ import (
 const GRPCDefaultPort = 8082
 
 // GRPCServerMain function for serving GRPC
-func (s *AppService) GRPCServerMain(publicKey *rsa.PublicKey, kafkaMessaging service.QPublisher) {
+func (s *AppService) GRPCServerMain(keySet *rsa.KeySet, kafkaMessaging service.QPublisher) {
 	ctx := "GRPCServerMain"
 
 	// set GRPC port
@@ -50,7 +50,7 @@ func (s *AppService) GRPCServerMain(publicKey *rsa.PublicKey, kafkaMessaging ser
 	helper.Log(log.InfoLevel, fmt.Sprintf("GRPC server will be running on port %d", port), "grpc_main", "initiate_grpc")
 
 	hGRPCHandler := healthDelivery.NewGRPCHandler(s.HealthUseCase)
-	mGRPCHandler := memberDelivery.NewGRPCHandler(s.MemberUseCase, kafkaMessaging, kafkaUserServiceTopic, publicKey)
+	mGRPCHandler := memberDelivery.NewGRPCHandler(s.MemberUseCase, kafkaMessaging, kafkaUserServiceTopic, keySet)
 
 	grpcServer := rpc.NewGRPCServer(hGRPCHandler, mGRPCHandler)
 
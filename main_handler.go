@@ -1,7 +1,6 @@
 package main
 
 import (
-	"crypto/sha1"
 	"database/sql"
 	"errors"
 	"net/url"
@@ -14,11 +13,13 @@ import (
 	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/service"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
 	log "github.com/sirupsen/logrus"
 
 	healthQuery "github.com/Bhinneka/user-service/src/health/query"
 	healthUseCase "github.com/Bhinneka/user-service/src/health/usecase"
 
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
 	authQuery "github.com/Bhinneka/user-service/src/auth/v1/query"
 	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
 	authToken "github.com/Bhinneka/user-service/src/auth/v1/token"
@@ -47,7 +48,12 @@ import (
 	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
 	merchantUseCase "github.com/Bhinneka/user-service/src/merchant/v2/usecase"
 
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/Bhinneka/user-service/src/shared/geoip"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
 	sharedRepository "github.com/Bhinneka/user-service/src/shared/repository"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 
 	shippingAddressRepository "github.com/Bhinneka/user-service/src/shipping_address/v2/repo"
 	shippingAddressUseCase "github.com/Bhinneka/user-service/src/shipping_address/v2/usecase"
@@ -87,16 +93,9 @@ type AppService struct {
 }
 
 //MakeHandler function, Service's Constructor
-func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *AppService {
+func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher, keySet *rsa.KeySet) *AppService {
 	ctx := "make_handler"
 
-	privateKey, err := rsa.InitPrivateKey()
-
-	if err != nil {
-		helper.Log(log.ErrorLevel, err.Error(), ctx, "private_key")
-		os.Exit(1)
-	}
-
 	redisDB := "0"
 	redisDB, _ = os.LookupEnv("REDIS_DB")
 
@@ -122,6 +121,13 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 
 	loginAttemptAge := golib.GetEnvOrFail(ctx, "parse_login_attempt_age", "LOGIN_ATTEMPT_AGE")
 
+	// lockout policy per member type, optional
+	lockoutPolicies, err := authModel.ParseLockoutPolicies(os.Getenv("LOCKOUT_POLICY"))
+	if err != nil {
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "parse_lockout_policy")
+		os.Exit(1)
+	}
+
 	tokenActivationAge := golib.GetEnvDurationOrFail(ctx, "parse_token_expiration_age", "TOKEN_ACTIVATION_AGE")
 
 	// messaging system
@@ -132,8 +138,60 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 
 	kafkaUserServiceTopic := golib.GetEnvOrFail(ctx, helper.TextFindServerKafkaConfig, "KAFKA_USER_SERVICE_TOPIC")
 
-	// set password hash
-	passwordHasher := memberModel.NewPBKDF2Hasher(memberModel.SaltSize, memberModel.SaltSize, memberModel.IterationsCount, sha1.New)
+	// set password hash, new passwords use PASSWORD_HASH_ALGORITHM and old ones are checked with the algorithm of their salt
+	passwordHasher, err := memberModel.NewDefaultPasswordHashers(os.Getenv("PASSWORD_HASH_ALGORITHM"))
+	if err != nil {
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "parse_password_hash_algorithm")
+		os.Exit(1)
+	}
+
+	// password policy of registration and password changes, optional
+	passwordPolicy, err := memberModel.ParsePasswordPolicy(os.Getenv("PASSWORD_POLICY"))
+	if err != nil {
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "parse_password_policy")
+		os.Exit(1)
+	}
+
+	// breached password corpus, optional file of sha1 hashes
+	var breachedPasswords memberModel.BreachedPasswordChecker
+	if breachedPasswordFile := os.Getenv("BREACHED_PASSWORD_FILE"); breachedPasswordFile != "" {
+		corpus, err := loadBreachedPasswords(breachedPasswordFile)
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "load_breached_password_file")
+			os.Exit(1)
+		}
+		breachedPasswords = corpus
+	}
+
+	// approximate location of session ip addresses, optional file of networks
+	var sessionLocator memberModel.SessionLocator
+	if ipLocationFile := os.Getenv("IP_LOCATION_FILE"); ipLocationFile != "" {
+		locations, err := loadIPLocations(ipLocationFile)
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "load_ip_location_file")
+			os.Exit(1)
+		}
+		sessionLocator = locations
+	}
+
+	// suspicious login detection, the geoip database is optional and also locates sessions without IP_LOCATION_FILE
+	riskPolicy, err := authModel.ParseRiskPolicy(os.Getenv("LOGIN_RISK_POLICY"))
+	if err != nil {
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "parse_login_risk_policy")
+		os.Exit(1)
+	}
+	var geoLocator authModel.GeoLocator
+	if geoIPDatabaseFile := os.Getenv("GEOIP_DATABASE_FILE"); geoIPDatabaseFile != "" {
+		database, err := loadGeoIPDatabase(geoIPDatabaseFile)
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "load_geoip_database_file")
+			os.Exit(1)
+		}
+		geoLocator = database
+		if sessionLocator == nil {
+			sessionLocator = database
+		}
+	}
 
 	// set OAuth social media url
 	azureLoginBaseURL := golib.GetEnvOrFail(ctx, "find_azure_config_login_url", "AD_LOGIN_URL")
@@ -244,6 +302,15 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 
 	resendActivationAttemptAgeRequest := golib.GetEnvOrFail(ctx, "parse_resend_activation_attempt_age_request", "RESEND_ACTIVATION_ATTEMPT_AGE_REQUEST")
 
+	oidcIssuer := golib.GetEnvOrFail(ctx, "find_oidc_issuer_config", "OIDC_ISSUER")
+
+	// public url of this service, the entity id and assertion consumer service of corporate saml are below it
+	samlBaseURL := golib.GetEnvOrFail(ctx, "find_saml_config", "SAML_SP_BASE_URL")
+
+	// passkeys are bound to the registrable domain, ceremonies are accepted from the listed origins only
+	webAuthn := webauthn.NewRelyingParty(golib.GetEnvOrFail(ctx, "find_webauthn_config", "WEBAUTHN_RP_ID"),
+		os.Getenv("WEBAUTHN_RP_NAME"), golib.GetEnvOrFail(ctx, "find_webauthn_config", "WEBAUTHN_ORIGINS"))
+
 	//service
 	//nsqDispatcher := service.NewNSQDispatcher()
 
@@ -321,10 +388,18 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 
 	aQueryOAuth := authQuery.NewAuthQueryOAuth(oAuthConfig)
 	refreshTokenRepo := authRepo.NewRefreshTokenRepositoryRedis(redisConnection)
+	authorizationCodeRepo := authRepo.NewAuthorizationCodeRepositoryRedis(redisConnection)
 	tokenActivationRepo := memberRepo.NewTokenActivationRepoRedis(redisConnection)
 	attemptRepo := authRepo.NewAttemptRepositoryRedis(redisConnection)
+	lockoutRepo := authRepo.NewLockoutRepositoryRedis(redisConnection)
 	mRepo := memberRepo.NewMemberRepoPostgres(sRepository)
 	mMFARepo := memberRepo.NewMemberMFARepoPostgres(sRepository)
+	mPasskeyRepo := memberRepo.NewMemberPasskeyRepoPostgres(sRepository)
+	mMFARecoveryRepo := memberRepo.NewMemberMFARecoveryRepoPostgres(sRepository)
+	mMFAMethodRepo := memberRepo.NewMemberMFAMethodRepoPostgres(sRepository)
+	mPasswordHistoryRepo := memberRepo.NewMemberPasswordHistoryRepoPostgres(sRepository)
+	mSessionLabelRepo := memberRepo.NewMemberSessionLabelRepoPostgres(sRepository)
+	mIdentityRepo := memberRepo.NewMemberIdentityRepoPostgres(sRepository)
 	mRepoRedis := memberRepo.NewMemberRepoRedis(redisConnection)
 	mAdditionalRepo := memberRepo.NewMemberAdditionalInfoRepoPostgres(sRepository)
 	mQueryRead := memberQuery.NewMemberQueryPostgres(readDB)
@@ -333,7 +408,9 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 
 	cContactQueryRead := corporateQuery.NewContactQueryPostgres(readDB)
 	cAccountContactQueryRead := corporateQuery.NewAccountContactQueryPostgres(readDB)
+	cAccountSAMLQueryRead := corporateQuery.NewAccountSAMLQueryPostgres(readDB)
 	contactRepo := corporateRepository.NewContactRepoPostgres(sRepository)
+	accountSAMLRepo := corporateRepository.NewAccountSAMLRepoPostgres(sRepository)
 
 	aRepoSessionInfo := sessionInfoRepository.NewSessionInfoRepoPostgres(writeDB)
 	aServiceLdap, _ := authServices.NewLDAPService(os.Getenv("LDAP_SERVER"),
@@ -350,41 +427,100 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 	merchantBankRepository := merchantRepo.NewMerchantBankRepoPostgres(sRepository)
 	merchantEmployeeRepository := merchantRepo.NewMerchantEmployeeRepoPostgres(sRepository)
 	merchantDocumentRepository := merchantRepo.NewMerchantDocumentRepoPostgres(sRepository)
+	merchantStatusHistoryRepository := merchantRepo.NewMerchantStatusHistoryRepoPostgres(sRepository)
+	merchantBankVerificationRepository := merchantRepo.NewMerchantBankVerificationRepoPostgres(sRepository)
+	merchantBankChangeRepository := merchantRepo.NewMerchantBankChangeRepoPostgres(sRepository)
 	merchantAddressRepository := merchantRepo.NewMerchantAddressRepoPostgres(sRepository)
 	shippingAddressRepo := shippingAddressRepository.NewShippingAddressRepoPostgres(sRepository)
 	shippingAddressRedisRepo := shippingAddressRepository.NewShippingAddressRepoRedis(redisConnection)
 	applicationRepo := applicationRepository.NewApplicationRepoPostgres(sRepository)
 	documentRepo := documentRepository.NewDocumentRepoPostgres(sRepository, uploadService)
 	documentTypeRepo := documentRepository.NewDocumentTypeRepoPostgres(sRepository)
-	jwtGenerator := authToken.NewJwtGenerator(privateKey, tokenAge, refreshTokenAge, specialTokenAge, specialRefreshTokenAge, loginSessionRedisRepo, emailForSpecialToken)
+	jwtGenerator := authToken.NewJwtGenerator(keySet, tokenAge, refreshTokenAge, specialTokenAge, specialRefreshTokenAge, loginSessionRedisRepo, emailForSpecialToken)
 	notificationService := service.NewNotificationService(jwtGenerator)
+	smsService := service.NewSMSFakeService()
+	mfaOTPIssuer := mfaotp.NewIssuer(loginSessionRedisRepo, attemptRepo, notificationService, smsService)
+
+	// captcha of password logins and self registration, optional
+	var captchaGuard *captcha.Guard
+	if captchaProvider := os.Getenv("CAPTCHA_PROVIDER"); captchaProvider != "" {
+		captchaVerifier, err := service.NewCaptchaVerifier(captchaProvider, os.Getenv("CAPTCHA_SECRET"), os.Getenv("CAPTCHA_VERIFY_URL"))
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "init_captcha_verifier")
+			os.Exit(1)
+		}
+		captchaPolicy, err := captcha.ParsePolicy(os.Getenv("CAPTCHA_POLICY"))
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "parse_captcha_policy")
+			os.Exit(1)
+		}
+		captchaGuard = captcha.NewGuard(captchaVerifier, attemptRepo, captchaPolicy)
+	}
+
+	// name inquiry of merchant bank accounts, the local fake only compares the stated holder name
+	// and would verify any account, it is never allowed in production
+	bankVerifierProvider := os.Getenv("BANK_VERIFIER_PROVIDER")
+	if bankVerifierProvider == "" {
+		helper.Log(log.ErrorLevel, "please specify BANK_VERIFIER_PROVIDER", ctx, "init_bank_verifier")
+		os.Exit(1)
+	}
+	if bankVerifierProvider == serviceModel.BankVerifierProviderFake && os.Getenv("ENV") == helper.EnvProd {
+		helper.Log(log.ErrorLevel, "fake bank verifier cannot be used in production", ctx, "init_bank_verifier")
+		os.Exit(1)
+	}
+	bankVerifier, err := service.NewBankAccountVerifier(bankVerifierProvider, os.Getenv("BANK_VERIFIER_URL"), os.Getenv("BANK_VERIFIER_SECRET"))
+	if err != nil {
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "init_bank_verifier")
+		os.Exit(1)
+	}
+
+	// identity providers added as oidc connectors, optional
+	connectorConfigs, err := connector.ParseConfigs(os.Getenv("OIDC_CONNECTORS"))
+	if err != nil {
+		helper.Log(log.ErrorLevel, err.Error(), ctx, "parse_oidc_connectors")
+		os.Exit(1)
+	}
+	connectors := connector.NewRegistry(connectorConfigs, loginSessionRedisRepo)
 
 	serviceRepo := localConfig.ServiceRepository{
-		ClientAppRepoRead:              aRepoRead,
-		ClientAppRepoWrite:             aRepoWrite,
-		DocumentRepository:             documentRepo,
-		MerchantRepository:             merchantRepository,
-		MerchantDocumentRepository:     merchantDocumentRepository,
-		MerchantBankRepository:         merchantBankRepository,
-		MerchantEmployeeRepository:     merchantEmployeeRepository,
-		MerchantAddressRepository:      merchantAddressRepository,
-		ShippingAddressRepository:      shippingAddressRepo,
-		ShippingAddressRedisRepository: shippingAddressRedisRepo,
-		MemberRepository:               mRepo,
-		MemberMFARepository:            mMFARepo,
-		MemberRedisRepository:          mRepoRedis,
-		TokenActivationRepoRedis:       tokenActivationRepo,
-		AttemptRepositoryRedis:         attemptRepo,
-		LoginSessionRepositoryRedis:    loginSessionRedisRepo,
-		Repository:                     sRepository,
-		RefreshTokenRepository:         refreshTokenRepo,
-		SessionInfoRepo:                aRepoSessionInfo,
-		PaymentsRepository:             paymentRepo,
+		ClientAppRepoRead:                  aRepoRead,
+		ClientAppRepoWrite:                 aRepoWrite,
+		DocumentRepository:                 documentRepo,
+		MerchantRepository:                 merchantRepository,
+		MerchantDocumentRepository:         merchantDocumentRepository,
+		MerchantBankRepository:             merchantBankRepository,
+		MerchantEmployeeRepository:         merchantEmployeeRepository,
+		MerchantAddressRepository:          merchantAddressRepository,
+		MerchantStatusHistoryRepository:    merchantStatusHistoryRepository,
+		MerchantBankVerificationRepository: merchantBankVerificationRepository,
+		MerchantBankChangeRepository:       merchantBankChangeRepository,
+		ShippingAddressRepository:          shippingAddressRepo,
+		ShippingAddressRedisRepository:     shippingAddressRedisRepo,
+		MemberRepository:                   mRepo,
+		MemberMFARepository:                mMFARepo,
+		MemberPasskeyRepository:            mPasskeyRepo,
+		MemberMFARecoveryRepository:        mMFARecoveryRepo,
+		MemberMFAMethodRepository:          mMFAMethodRepo,
+		MemberPasswordHistoryRepository:    mPasswordHistoryRepo,
+		MemberSessionLabelRepository:       mSessionLabelRepo,
+		MemberIdentityRepository:           mIdentityRepo,
+		MemberRedisRepository:              mRepoRedis,
+		TokenActivationRepoRedis:           tokenActivationRepo,
+		AttemptRepositoryRedis:             attemptRepo,
+		LockoutRepositoryRedis:             lockoutRepo,
+		LoginSessionRepositoryRedis:        loginSessionRedisRepo,
+		Repository:                         sRepository,
+		RefreshTokenRepository:             refreshTokenRepo,
+		AuthorizationCodeRepository:        authorizationCodeRepo,
+		SessionInfoRepo:                    aRepoSessionInfo,
+		PaymentsRepository:                 paymentRepo,
+		CorporateAccountSAMLRepository:     accountSAMLRepo,
 	}
 
 	serviceQuery := localConfig.ServiceQuery{
 		CorporateContactQueryRead:    cContactQueryRead,
 		CorporateAccContactQueryRead: cAccountContactQueryRead,
+		CorporateAccountSAMLQuery:    cAccountSAMLQueryRead,
 		MemberQueryRead:              mQueryRead,
 		MemberQueryWrite:             mQueryWrite,
 		MemberMFAQueryRead:           mMFAQueryRead,
@@ -400,12 +536,18 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 		QPublisher:          kafkaMessaging,
 		NotificationService: notificationService,
 		SendbirdService:     sendbirdService,
+		SMSService:          smsService,
+		BankVerifier:        bankVerifier,
+		MFAOTP:              mfaOTPIssuer,
 	}
 	// all usecase
 	hUseCase := healthUseCase.NewHealthUseCase(hQuery)
 
 	membershipParameters := localConfig.MembershipParameters{
 		Hash:                              passwordHasher,
+		PasswordPolicy:                    &passwordPolicy,
+		BreachedPasswords:                 breachedPasswords,
+		SessionLocator:                    sessionLocator,
 		TokenActivationExpiration:         tokenActivationAge,
 		ResendActivationAttemptAge:        resendActivationAttemptAge,
 		ResendActivationAttemptAgeRequest: resendActivationAttemptAgeRequest,
@@ -414,6 +556,10 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 		SturgeonCFUrl:                     sturgeonCFUrl,
 		B2cCFUrl:                          b2cCFUrl,
 		AccessTokenGenerator:              jwtGenerator,
+		WebAuthn:                          webAuthn,
+		MFAOTP:                            mfaOTPIssuer,
+		Captcha:                           captchaGuard,
+		Connectors:                        connectors,
 	}
 
 	authParameters := localConfig.AuthParameters{
@@ -421,6 +567,7 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 		AuthServices:           aServiceLdap,
 		GoogleVerifyCaptchaURL: googleVerifyCaptchaParsedURL,
 		AccessTokenGenerator:   jwtGenerator,
+		KeySet:                 keySet,
 		RefreshTokenAge:        refreshTokenAgeStr,
 		B2cCFUrl:               b2cCFUrl,
 		LoginAttemptAge:        loginAttemptAge,
@@ -428,6 +575,15 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 		IsProductionStage:      isProductionStage,
 		EmailSpecialTokenAge:   emailForSpecialToken,
 		SpecialRefreshTokenAge: specialRefreshTokenAgeString,
+		OIDCIssuer:             oidcIssuer,
+		WebAuthn:               webAuthn,
+		MFAOTP:                 mfaOTPIssuer,
+		LockoutPolicies:        lockoutPolicies,
+		RiskPolicy:             riskPolicy,
+		GeoLocator:             geoLocator,
+		Captcha:                captchaGuard,
+		Connectors:             connectors,
+		SAMLBaseURL:            samlBaseURL,
 	}
 
 	aUseCase := authUseCase.NewAuthUseCase(serviceRepo, serviceQuery, serviceShared, authParameters, aQueryOAuth, aQuery)
@@ -438,7 +594,7 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 	merchantAddressUseCase := merchantUseCase.NewMerchantAddressUseCase(serviceRepo, serviceShared)
 	shippingAddressUseCase := shippingAddressUseCase.NewShippingAddressUseCase(serviceRepo, serviceShared)
 	documentUseCase := documentUseCase.NewDocumentUseCase(documentRepo, documentTypeRepo, mRepo, sRepository, "DOCUMENTS_JSON")
-	corporateUseCase := corporateUseCase.NewCorporateUseCase(contactRepo, cContactQueryRead, serviceShared)
+	corporateUseCase := corporateUseCase.NewCorporateUseCase(contactRepo, cContactQueryRead, accountSAMLRepo, cAccountSAMLQueryRead, samlBaseURL, serviceShared)
 	clientUseCase := clientUseCase.NewClientUsecase(loginSessionRedisRepo, refreshTokenRepo, mQueryRead)
 	clientV2UseCase := clientV2UseCase.NewClientUsecase(loginSessionRedisRepo, refreshTokenRepo, mQueryRead, cContactQueryRead)
 	logUsecase := logUseCase.NewLogUsecase(serviceShared)
@@ -464,3 +620,36 @@ func MakeHandler(readDB, writeDB *sql.DB, kafkaMessaging service.QPublisher) *Ap
 		PaymentsUseCase:        paymentUseCase,
 	}
 }
+
+// loadBreachedPasswords function for loading the breached password corpus from a file
+func loadBreachedPasswords(path string) (*memberModel.BreachedPasswordCorpus, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return memberModel.LoadBreachedPasswordCorpus(file)
+}
+
+// loadIPLocations function for loading the ip locations from a file
+func loadIPLocations(path string) (*memberModel.IPLocations, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return memberModel.LoadIPLocations(file)
+}
+
+// loadGeoIPDatabase function for loading the geoip database from a file
+func loadGeoIPDatabase(path string) (*geoip.Database, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return geoip.Load(file)
+}
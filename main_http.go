@@ -1,19 +1,22 @@
 package main
 
 import (
-	"crypto/rsa"
+	"expvar"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Bhinneka/golib/tracer"
 	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/middleware"
 	applicationsDelivery "github.com/Bhinneka/user-service/src/applications/v1/delivery"
 	applicationsDeliveryV2 "github.com/Bhinneka/user-service/src/applications/v2/delivery"
 	authDelivery "github.com/Bhinneka/user-service/src/auth/v1/delivery"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
 	authDeliveryV2 "github.com/Bhinneka/user-service/src/auth/v2/delivery"
 	authDeliveryV3 "github.com/Bhinneka/user-service/src/auth/v3/delivery"
 	clientDeliveryV1 "github.com/Bhinneka/user-service/src/client/v1/delivery"
@@ -45,7 +48,7 @@ const (
 )
 
 // HTTPServerMain main function for serving services over http
-func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.ClientQuery) {
+func (s *AppService) HTTPServerMain(keySet *rsa.KeySet, cq clientQuery.ClientQuery) {
 	// construct Echo
 	e := echo.New()
 	e.Use(middleware.ServerHeader, middleware.Logger)
@@ -87,6 +90,33 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 
 	basicAuthConfig := middleware.NewConfig(basicAuthUsername, basicAuthPassword)
 
+	// rate limit of the login and registration endpoints per ip, client id and route, and per ip.
+	// Only an OAuth client authenticated with its Basic credentials or a client credentials token is counted apart,
+	// X-Forwarded-For is only read from TRUSTED_PROXIES
+	trustedProxies, err := middleware.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("rate limit error : %s", err.Error())
+	}
+	rateLimitConfig := middleware.RateLimitConfig{
+		ClientAuth:     middleware.OAuthClientAuth(cq, keySet),
+		TrustedProxies: trustedProxies,
+	}
+	// handlers read the client ip with middleware.ClientIP, e.g. for the registration captcha
+	e.Use(middleware.TrustedClientIP(trustedProxies))
+	e.Use(middleware.RateLimit(redisConnection, rateLimitConfig,
+		rateLimitRule("auth", 20, 60, time.Minute,
+			uriV1+"/auth", uriV2+"/auth", uriV3+"/auth", uriV2+"/oauth/token",
+			uriV2+"/auth/check-email", uriV3+"/auth/check-email", uriV2+"/auth/verify-captcha",
+			uriV2+"/auth/mfa/otp", uriV2+"/auth/passkey/options", "/v1/client/login", "/v2/client/login",
+			uriV2+"/auth/saml/token", uriV2+"/auth/saml/:accountID/authorize", uriV2+"/auth/saml/:accountID/acs"),
+		rateLimitRule("registration", 5, 20, 10*time.Minute,
+			uriV1+"/register", uriV2+"/register", uriV3+"/register",
+			uriV1+"/forgot-password", uriV2+"/forgot-password", uriV3+"/forgot-password",
+			uriV2+"/resend-activation", uriV2+"/auth/passwordless"),
+	))
+	// rate limit rejections and runtime metrics
+	e.GET("/debug/vars", echo.WrapHandler(expvar.Handler()), middleware.BasicAuth(basicAuthConfig))
+
 	authHandler := authDelivery.NewHTTPHandler(s.AuthUseCase)
 	authGroup := e.Group("/api/auth")
 	authHandler.Mount(authGroup)
@@ -105,18 +135,22 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 
 	// member endpoints
 	meGroup := e.Group("/api/me")
-	meGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	meGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	memberHandler.MountMe(meGroup)
 
 	// member endpoints /member
 	memberGroup := e.Group("/api/member")
-	memberGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	memberGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	memberHandler.MountMember(memberGroup)
 
 	memberGroupAuthorized := e.Group(uriV1)
-	memberGroupAuthorized.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	memberGroupAuthorized.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMemberManage))
 	memberHandler.MountAdmin(memberGroupAuthorized)
 
+	memberImportGroup := e.Group(uriV1)
+	memberImportGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMemberImport))
+	memberHandler.MountImport(memberImportGroup)
+
 	// auth v2 endpoints
 	googleAuthRedirectURL := os.Getenv("GOOGLE_OAUTH_REDIRECT_URI")
 	authHandlerV2 := authDeliveryV2.NewHTTPHandler(s.AuthUseCase, googleAuthRedirectURL)
@@ -125,13 +159,24 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 
 	authHandlerAdminV2 := authDeliveryV2.NewHTTPHandler(s.AuthUseCase, googleAuthRedirectURL)
 	authGroupV2Admin := e.Group(uriV2)
-	authGroupV2Admin.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	authGroupV2Admin.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionAuthImpersonate))
 	authHandlerAdminV2.MountAdmin(authGroupV2Admin)
 
+	lockoutGroup := e.Group("/api/v2/lockouts")
+	lockoutGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionAuthLockout))
+	authHandlerAdminV2.MountLockout(lockoutGroup)
+
 	authHandlerV3 := authDeliveryV3.NewHTTPHandler(s.AuthUseCase, googleAuthRedirectURL)
 	authGroupV3 := e.Group("/api/v3/auth")
 	authHandlerV3.MountRoute(authGroupV3)
 
+	// oauth2 / openid connect provider endpoints, OIDC_ISSUER should point to this group.
+	// the key set is also published on the root for services that only verify tokens
+	oidcHandler := authDeliveryV2.NewHTTPOIDCHandler(s.AuthUseCase, os.Getenv("OIDC_LOGIN_URL"))
+	oidcGroup := e.Group("/api/v2/oauth")
+	oidcHandler.Mount(oidcGroup)
+	e.GET(authModel.JWKSPath, oidcHandler.JSONWebKeySet)
+
 	// member v2 module handle
 	memberHandlerV2 := memberDeliveryV2.NewHTTPHandler(s.MemberUseCase)
 
@@ -144,40 +189,44 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 	memberHandlerV3.Mount(memberNoTokenV3)
 
 	memberSendirdGroupV3 := e.Group("/api/v3/sendbird")
-	memberSendirdGroupV3.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	memberSendirdGroupV3.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	memberHandlerV3.MountSendbird(memberSendirdGroupV3)
 
 	memberHandlerV4 := memberDeliveryV4.NewHTTPHandlerV4(s.MemberUseCase, s.AuthUseCase)
 	memberSendbirdGroupV4 := e.Group("/api/v4/sendbird")
-	memberSendbirdGroupV4.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	memberSendbirdGroupV4.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	memberHandlerV4.MountSendbird(memberSendbirdGroupV4)
 
 	// member v2 endpoints /me
 	meGroup2 := e.Group("/api/v2/me")
-	meGroup2.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	meGroup2.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	memberHandlerV2.MountMe(meGroup2)
 
 	meGroup3 := e.Group("/api/v3/me")
-	meGroup3.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	meGroup3.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	memberHandlerV3.MountMeV3(meGroup3)
 
 	// member v2 endpoints /member
 	memberGroupV2 := e.Group("/api/v2/member")
-	memberGroupV2.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	memberGroupV2.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMemberManage))
 	memberHandlerV2.MountMember(memberGroupV2)
 
 	// merchant v2 endpoints
 	merchantHandlerV2 := merchantDeliveryV2.NewHTTPHandler(s.MerchantUseCase, s.MerchantAddressUseCase)
 	merchantCMS := e.Group("/api/v2/merchant")
-	merchantCMS.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	merchantCMS.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMerchantManage))
 	merchantHandlerV2.MountCMS(merchantCMS)
 
+	merchantRejectCMS := e.Group("/api/v2/merchant")
+	merchantRejectCMS.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMerchantReject))
+	merchantHandlerV2.MountCMSReject(merchantRejectCMS)
+
 	merchantGroup := e.Group("/api/v2/merchant/me")
-	merchantGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	merchantGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	merchantHandlerV2.MountMe(merchantGroup)
 
 	merchantGroup2 := e.Group(uriV2)
-	merchantGroup2.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	merchantGroup2.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	merchantHandlerV2.MountMerchant(merchantGroup2)
 
 	merchantPublicV2 := e.Group(uriV2)
@@ -187,56 +236,60 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 	// shippingAddress v2 endpoints
 	shippingAddressHandlerV2 := shippingAddressDeliveryV2.NewHTTPHandler(s.ShippingAddressUseCase)
 	shippingAddressGroup := e.Group("/api/v2/shipping-address")
-	shippingAddressGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	shippingAddressGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	shippingAddressHandlerV2.MountMe(shippingAddressGroup)
 
 	shippingAddressGroup2 := e.Group("/api/v2/shipping-address")
-	shippingAddressGroup2.Use(middleware.BearerVerify(publicKey, redisConnection, false, false))
+	shippingAddressGroup2.Use(middleware.BearerVerify(keySet, redisConnection, false, false))
 	shippingAddressHandlerV2.MountShippingAddress(shippingAddressGroup2)
 
 	// document v2 endpoints
 	documentHandlerV2 := documentDeliveryV2.NewHTTPHandler(s.DocumentUseCase)
 	documentGroup := e.Group("/api/v2/document")
-	documentGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, false))
+	documentGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, false))
 	documentHandlerV2.MountMe(documentGroup)
 
 	documentTypeGroup := e.Group("/api/v2/document-type")
-	documentTypeGroup.Use(middleware.BearerVerify(publicKey, redisConnection, false, true))
+	documentTypeGroup.Use(middleware.BearerVerify(keySet, redisConnection, false, true), middleware.RequirePermission(authModel.PermissionDocumentTypeManage))
 	documentHandlerV2.MountDocumentType(documentTypeGroup)
 
 	// admin endpoints
 	memberGroupAuthorizedV2 := e.Group(uriV2)
-	memberGroupAuthorizedV2.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	memberGroupAuthorizedV2.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMemberManage))
 	memberHandlerV2.MountAdmin(memberGroupAuthorizedV2)
 
+	memberImportGroupV2 := e.Group(uriV2)
+	memberImportGroupV2.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionMemberImport))
+	memberHandlerV2.MountImport(memberImportGroupV2)
+
 	// phone area endpoints
 	phoneAreaHandler := phoneAreaDelivery.NewHTTPHandler(s.PhoneAreaUseCase)
 	phoneAreaGroup := e.Group("/api/phone-area")
-	phoneAreaGroup.Use(middleware.BasicAuth(basicAuthConfig))
+	phoneAreaGroup.Use(middleware.ServiceAuth(middleware.BasicAuth(basicAuthConfig), keySet, redisConnection, authModel.ScopePhoneAreaRead))
 	phoneAreaHandler.MountPhoneArea(phoneAreaGroup)
 
 	// phone area v2 endpoints
 	phoneAreaHandlerV2 := phoneAreaDeliveryV2.NewHTTPHandler(s.PhoneAreaUseCase)
 	phoneAreaGroupV2 := e.Group("/api/v2/phone-area")
-	phoneAreaGroupV2.Use(middleware.BasicAuthWithConfig(cq))
+	phoneAreaGroupV2.Use(middleware.ServiceAuth(middleware.BasicAuthWithConfig(cq), keySet, redisConnection, authModel.ScopePhoneAreaRead))
 	phoneAreaHandlerV2.MountPhoneArea(phoneAreaGroupV2)
 
 	// session info v1 endpoints
 	sessionInfoHanlder := sessionInfoDelivery.NewHTTPHandler(s.SessionInfoUseCase)
 	sessionInfoGroup := e.Group("/api/v1/session")
-	sessionInfoGroup.Use(middleware.BasicAuth(basicAuthConfig))
+	sessionInfoGroup.Use(middleware.ServiceAuth(middleware.BasicAuth(basicAuthConfig), keySet, redisConnection, authModel.ScopeSessionRead))
 	sessionInfoHanlder.MountInfo(sessionInfoGroup)
 
 	// applications v1 endpoints
 	applicationsHandler := applicationsDelivery.NewHTTPHandler(s.ApplicationsUseCase)
 	applicationsGroup := e.Group("/api/v1/applications")
-	applicationsGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	applicationsGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionApplicationManage))
 	applicationsHandler.MountInfo(applicationsGroup)
 
 	// applications v2 endpoints
 	applicationsHandlerV2 := applicationsDeliveryV2.NewHTTPHandler(s.ApplicationsUseCase)
 	applicationsGroupV2 := e.Group("/api/v2/applications")
-	applicationsGroupV2.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	applicationsGroupV2.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionApplicationManage))
 	applicationsHandlerV2.MountInfo(applicationsGroupV2)
 
 	// payment v1 endpoints
@@ -248,8 +301,12 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 	// corporate v2 endpoints
 	corporateHandlerV2 := corporateDeliveryV2.NewHTTPHandler(s.CorporateUseCase)
 	corporateGroup2 := e.Group("/api/v2/corporate")
-	corporateGroup2.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	corporateGroup2.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionCorporateContact))
 	corporateHandlerV2.MountCorporate(corporateGroup2)
+
+	corporateAccountGroup := e.Group("/api/v2/corporate/accounts")
+	corporateAccountGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionCorporateSAML))
+	corporateHandlerV2.MountAccountSAML(corporateAccountGroup)
 	activityService := service.NewActivityService("v2")
 	// client endpoint
 
@@ -263,7 +320,7 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 
 	logHandlerV1 := logDelivery.NewHTTPHandler(activityService, s.LogUseCase)
 	logGroup := e.Group("v1/log")
-	logGroup.Use(middleware.BearerVerify(publicKey, redisConnection, true, true))
+	logGroup.Use(middleware.BearerVerify(keySet, redisConnection, true, true), middleware.RequirePermission(authModel.PermissionLogRead))
 	logHandlerV1.Mount(logGroup)
 
 	// set REST port
@@ -282,3 +339,24 @@ func (s *AppService) HTTPServerMain(publicKey *rsa.PublicKey, cq clientQuery.Cli
 	listenerPort := fmt.Sprintf(":%d", port)
 	e.Logger.Fatal(e.Start(listenerPort))
 }
+
+// rateLimitRule function for creating the rate limit of a route group, RATE_LIMIT_<GROUP> and RATE_LIMIT_<GROUP>_IP
+// override the default limits in `<requests>/<window>` format, e.g. RATE_LIMIT_AUTH=20/1m and RATE_LIMIT_AUTH_IP=60/1m
+func rateLimitRule(group string, limit, ipLimit int, window time.Duration, routes ...string) middleware.RateLimitRule {
+	rule := middleware.RateLimitRule{Group: group, Limit: limit, Window: window, IPLimit: ipLimit, IPWindow: window, Routes: routes}
+	if raw, ok := os.LookupEnv("RATE_LIMIT_" + strings.ToUpper(group)); ok && raw != "" {
+		var err error
+		rule.Limit, rule.Window, err = middleware.ParseRateLimit(raw)
+		if err != nil {
+			log.Fatalf("rate limit error : %s", err.Error())
+		}
+	}
+	if raw, ok := os.LookupEnv("RATE_LIMIT_" + strings.ToUpper(group) + "_IP"); ok && raw != "" {
+		var err error
+		rule.IPLimit, rule.IPWindow, err = middleware.ParseRateLimit(raw)
+		if err != nil {
+			log.Fatalf("rate limit error : %s", err.Error())
+		}
+	}
+	return rule
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultBankChangeInterval = time.Hour
+
+// dispatchMerchantBankChange periodically cancels the merchant bank account changes the owner did not confirm in time
+// and applies the approved ones whose cooling-off period ended
+func dispatchMerchantBankChange(appService *AppService, redisClient redis.Client) {
+	ctx := "merchant_bank_change"
+
+	interval := defaultBankChangeInterval
+	if value, err := time.ParseDuration(os.Getenv("MERCHANT_BANK_CHANGE_INTERVAL")); err == nil && value > 0 {
+		interval = value
+	}
+
+	helper.Log(log.InfoLevel, fmt.Sprintf("merchant bank account change will run every %s", interval), ctx, "initiate_scheduler")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		claimed, err := claimSchedulerRun(redisClient, ctx, now, interval)
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "claim_scheduler_run")
+		} else if claimed {
+			result := <-appService.MerchantUseCase.ProcessBankChanges(context.Background(), now)
+			if result.Error != nil {
+				helper.Log(log.ErrorLevel, result.Error.Error(), ctx, "process_bank_changes")
+			} else if summary, ok := result.Result.(model.MerchantBankChangeResult); ok {
+				message := fmt.Sprintf("applied: %d, rejected: %d, failed: %d, expired: %d", summary.Applied, summary.Rejected, summary.Failed, summary.Expired)
+				helper.Log(log.InfoLevel, message, ctx, "process_bank_changes")
+			}
+		}
+		<-ticker.C
+	}
+}
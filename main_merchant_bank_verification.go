@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultBankVerificationPollInterval = 15 * time.Minute
+
+// dispatchMerchantBankVerification periodically asks the bank verifier again about the bank account checks
+// it answered as pending, a check is polled once it has been pending for a whole interval
+func dispatchMerchantBankVerification(appService *AppService, redisClient redis.Client) {
+	ctx := "merchant_bank_verification"
+
+	interval := defaultBankVerificationPollInterval
+	if value, err := time.ParseDuration(os.Getenv("MERCHANT_BANK_VERIFICATION_POLL_INTERVAL")); err == nil && value > 0 {
+		interval = value
+	}
+
+	helper.Log(log.InfoLevel, fmt.Sprintf("merchant bank verification poll will run every %s", interval), ctx, "initiate_scheduler")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		claimed, err := claimSchedulerRun(redisClient, ctx, now, interval)
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "claim_scheduler_run")
+		} else if claimed {
+			result := <-appService.MerchantUseCase.PollPendingBankVerifications(context.Background(), now.Add(-interval))
+			if result.Error != nil {
+				helper.Log(log.ErrorLevel, result.Error.Error(), ctx, "poll_bank_verifications")
+			} else if summary, ok := result.Result.(model.MerchantBankVerificationPollResult); ok {
+				message := fmt.Sprintf("queued: %d, failed: %d", summary.Queued, summary.Failed)
+				helper.Log(log.InfoLevel, message, ctx, "poll_bank_verifications")
+			}
+		}
+		<-ticker.C
+	}
+}
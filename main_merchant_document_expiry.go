@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultDocumentExpiryInterval     = 24 * time.Hour
+	defaultDocumentExpiryReminderDays = 30
+)
+
+// dispatchMerchantDocumentExpiry periodically reminds merchants of expiring documents
+// and restricts merchants whose mandatory documents lapsed
+func dispatchMerchantDocumentExpiry(appService *AppService, redisClient redis.Client) {
+	ctx := "merchant_document_expiry"
+
+	interval := defaultDocumentExpiryInterval
+	if value, err := time.ParseDuration(os.Getenv("MERCHANT_DOCUMENT_EXPIRY_INTERVAL")); err == nil && value > 0 {
+		interval = value
+	}
+	reminderDays := defaultDocumentExpiryReminderDays
+	if value, err := strconv.Atoi(os.Getenv("MERCHANT_DOCUMENT_EXPIRY_REMINDER_DAYS")); err == nil && value > 0 {
+		reminderDays = value
+	}
+	reminder := time.Duration(reminderDays) * 24 * time.Hour
+
+	helper.Log(log.InfoLevel, fmt.Sprintf("merchant document expiry will run every %s", interval), ctx, "initiate_scheduler")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		claimed, err := claimSchedulerRun(redisClient, ctx, now, interval)
+		if err != nil {
+			helper.Log(log.ErrorLevel, err.Error(), ctx, "claim_scheduler_run")
+		} else if claimed {
+			result := <-appService.MerchantUseCase.ProcessDocumentExpiry(context.Background(), now, reminder)
+			if result.Error != nil {
+				helper.Log(log.ErrorLevel, result.Error.Error(), ctx, "process_document_expiry")
+			} else if summary, ok := result.Result.(model.MerchantDocumentExpiryResult); ok {
+				message := fmt.Sprintf("reminded: %d, expired: %d, restricted: %d, failed: %d", summary.Reminded, summary.Expired, summary.Restricted, summary.Failed)
+				helper.Log(log.InfoLevel, message, ctx, "process_document_expiry")
+			}
+		}
+		<-ticker.C
+	}
+}
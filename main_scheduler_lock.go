@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+)
+
+// claimSchedulerRunScript sets KEYS[1] to ARGV[2] unless it exists, the key expires ARGV[1] milliseconds later
+const claimSchedulerRunScript = `
+if redis.call('SET', KEYS[1], ARGV[2], 'NX', 'PX', ARGV[1]) then
+	return 1
+end
+return 0
+`
+
+// claimSchedulerRun claims the run of a scheduler for the interval now falls in, so among the replicas
+// only the first one ticking in an interval runs it, whatever the moment each replica started
+func claimSchedulerRun(client redis.Client, name string, now time.Time, interval time.Duration) (bool, error) {
+	key := fmt.Sprintf("scheduler:%s:%d", name, now.Truncate(interval).Unix())
+	owner, _ := os.Hostname()
+
+	result, err := client.Eval(claimSchedulerRunScript, []string{key}, (2 * interval).Milliseconds(), owner)
+	if err != nil {
+		return false, err
+	}
+	claimed, _ := result.(int64)
+	return claimed == 1, nil
+}
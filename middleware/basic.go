@@ -16,18 +16,15 @@ func NewConfig(username, password string) *Config {
 	return &Config{username: username, password: password}
 }
 
+// Validate function for checking the basic auth credentials against the config
+func (config *Config) Validate(username, password string) bool {
+	return config.username == username && config.password == password
+}
+
 // BasicAuth function basic auth
 func BasicAuth(config *Config) echo.MiddlewareFunc {
 	return middleware.BasicAuth(func(username, password string, _ echo.Context) (bool, error) {
-
-		validate := func(username, password string) bool {
-			if config.username == username && config.password == password {
-				return true
-			}
-			return false
-		}
-
-		if validate(username, password) {
+		if config.Validate(username, password) {
 			return true, nil
 		}
 		return false, nil
@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"context"
-	"crypto/rsa"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/Bhinneka/golib/tracer"
 	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/config/rsa"
 	jwt "github.com/golang-jwt/jwt"
 	"github.com/labstack/echo"
 )
@@ -22,17 +22,19 @@ const (
 
 // BearerClaims data structure for claims
 type BearerClaims struct {
-	Adm            bool   `json:"adm"`
-	DeviceID       string `json:"did"`
-	DeviceLogin    string `json:"dli"`
-	Email          string `json:"email"`
-	UserAuthorized bool   `json:"authorised"`
-	JTI            string `json:"jti"`
+	Adm            bool     `json:"adm"`
+	DeviceID       string   `json:"did"`
+	DeviceLogin    string   `json:"dli"`
+	Email          string   `json:"email"`
+	UserAuthorized bool     `json:"authorised"`
+	JTI            string   `json:"jti"`
+	Scope          string   `json:"scope,omitempty"`
+	Permissions    []string `json:"perm,omitempty"`
 	jwt.StandardClaims
 }
 
-// BearerVerify function to verify token
-func BearerVerify(rsaPublicKey *rsa.PublicKey, cl redis.Client, mustAuthorized bool, mustAdmin bool) echo.MiddlewareFunc {
+// BearerVerify function to verify token, the verification key is selected by `kid` header
+func BearerVerify(keys *rsa.KeySet, cl redis.Client, mustAuthorized bool, mustAdmin bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if os.Getenv("NO_TOKEN") == "1" {
@@ -69,7 +71,7 @@ func BearerVerify(rsaPublicKey *rsa.PublicKey, cl redis.Client, mustAuthorized b
 				return err
 			}
 
-			token, err := parseToken(ctxReq, rsaPublicKey, tokenStr)
+			token, err := parseToken(ctxReq, keys, tokenStr)
 
 			if err != nil {
 				responseBody = getTokenError(tokenStr, err)
@@ -82,15 +84,15 @@ func BearerVerify(rsaPublicKey *rsa.PublicKey, cl redis.Client, mustAuthorized b
 	}
 }
 
-func parseToken(ctxReq context.Context, rsaPublicKey *rsa.PublicKey, tokenStr string) (*jwt.Token, error) {
+func parseToken(ctxReq context.Context, keys *rsa.KeySet, tokenStr string) (*jwt.Token, error) {
 	return jwt.ParseWithClaims(tokenStr, &BearerClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			err := fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		key, err := keys.Keyfunc(token)
+		if err != nil {
 			responseBody := echo.NewHTTPError(http.StatusUnauthorized, err)
 			tracer.Log(ctxReq, eventErrorToken, responseBody)
 			return nil, err
 		}
-		return rsaPublicKey, nil
+		return key, nil
 	})
 }
 func getTokenString(auth string) (tokenStr string, err error) {
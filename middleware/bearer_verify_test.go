@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	rsaKey "github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/src/shared/mocks"
 	jwt "github.com/golang-jwt/jwt"
 	"github.com/labstack/echo"
@@ -127,7 +128,7 @@ func TestBearerVerify(t *testing.T) {
 			verifyKey, _ := getPublicKey(ValidPublicKey)
 			handler := setupHandler()
 			fredis := mocks.InitFakeRedis()
-			mw := BearerVerify(verifyKey, fredis, true, false)(handler)
+			mw := BearerVerify(rsaKey.NewVerifyKeySet(verifyKey), fredis, true, false)(handler)
 			err := mw(c)
 			if tt.wantError {
 				assert.Error(t, err)
@@ -144,7 +145,7 @@ func TestBearerVerify(t *testing.T) {
 		verifyKey, _ := getPublicKey(ValidPublicKey)
 		handler := setupHandler()
 		fredis := mocks.InitFakeRedis()
-		mw := BearerVerify(verifyKey, fredis, true, false)(handler)
+		mw := BearerVerify(rsaKey.NewVerifyKeySet(verifyKey), fredis, true, false)(handler)
 		assert.NoError(t, mw(c))
 	})
 }
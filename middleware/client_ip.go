@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net"
+
+	"github.com/labstack/echo"
+)
+
+// contextKeyTrustedClientIP echo context key of the client ip read by TrustedClientIP
+const contextKeyTrustedClientIP = "trustedClientIP"
+
+// TrustedClientIP function for reading the client ip of every request the way the rate limit does,
+// X-Forwarded-For and X-Real-IP are only read from the trusted proxies. Handlers get it with ClientIP
+func TrustedClientIP(trustedProxies []*net.IPNet) echo.MiddlewareFunc {
+	config := RateLimitConfig{TrustedProxies: trustedProxies}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(contextKeyTrustedClientIP, config.clientIP(c.Request()))
+			return next(c)
+		}
+	}
+}
+
+// ClientIP function for getting the client ip of a request read by TrustedClientIP, unlike echo RealIP a forwarded header
+// sent by the client itself is not taken. Without the middleware the remote address is returned
+func ClientIP(c echo.Context) string {
+	if ip, ok := c.Get(contextKeyTrustedClientIP).(string); ok && ip != "" {
+		return ip
+	}
+	return RateLimitConfig{}.clientIP(c.Request())
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		middleware bool
+		remote     string
+		forwarded  string
+		want       string
+	}{
+		{
+			name:       "Case 1: Forwarded header from a trusted proxy",
+			middleware: true,
+			remote:     "10.0.0.5:4000",
+			forwarded:  "198.51.100.1",
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "Case 2: Forwarded header sent by the client",
+			middleware: true,
+			remote:     "203.0.113.7:4000",
+			forwarded:  "198.51.100.1",
+			want:       "203.0.113.7",
+		},
+		{
+			name:      "Case 3: Without the middleware",
+			remote:    "10.0.0.5:4000",
+			forwarded: "198.51.100.1",
+			want:      "10.0.0.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(echo.POST, "/api/v2/register", nil)
+			req.RemoteAddr = tt.remote
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwarded)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var got string
+			handler := func(c echo.Context) error {
+				got = ClientIP(c)
+				return c.NoContent(http.StatusOK)
+			}
+			if tt.middleware {
+				handler = TrustedClientIP(proxies)(handler)
+			}
+			assert.NoError(t, handler(c))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
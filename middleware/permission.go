@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo"
+)
+
+// RequirePermission function to allow only members whose roles grant every given permission,
+// it must run after BearerVerify. Permissions are embedded in the `perm` claim when the token is issued
+func RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, err := bearerClaimsFromContext(c)
+			if err != nil {
+				return err
+			}
+
+			for _, permission := range permissions {
+				if !containsValue(claims.Permissions, permission) {
+					return echo.NewHTTPError(http.StatusForbidden, "permission "+permission+" is required")
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
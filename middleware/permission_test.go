@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       interface{}
+		permissions []string
+		wantError   bool
+	}{
+		{
+			name:        "no token",
+			permissions: []string{"merchant:reject"},
+			wantError:   true,
+		},
+		{
+			name:        "token with permission",
+			token:       &jwt.Token{Claims: &BearerClaims{Adm: true, Permissions: []string{"merchant:manage", "merchant:reject"}}, Valid: true},
+			permissions: []string{"merchant:manage", "merchant:reject"},
+		},
+		{
+			name:        "admin without permission",
+			token:       jwtTokenValidAdmTest,
+			permissions: []string{"merchant:reject"},
+			wantError:   true,
+		},
+		{
+			name:        "token missing one permission",
+			token:       &jwt.Token{Claims: &BearerClaims{Permissions: []string{"merchant:manage"}}, Valid: true},
+			permissions: []string{"merchant:manage", "merchant:reject"},
+			wantError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupRequest(map[string]string{})
+			if tt.token != nil {
+				c.Set("token", tt.token)
+			}
+			err := RequirePermission(tt.permissions...)(setupHandler())(c)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"expvar"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/config/rsa"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	clientQuery "github.com/Bhinneka/user-service/src/client/v1/query"
+	"github.com/labstack/echo"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RateLimitKeyRedis redis key prefix of the requests of a window, format: `RATELIMIT:auth:10.0.0.1:client:POST /api/v2/auth`
+	// per ip, client and route, and `RATELIMIT:auth:10.0.0.1` per ip
+	RateLimitKeyRedis = "RATELIMIT"
+
+	// HeaderRateLimitLimit maximum requests of the window
+	HeaderRateLimitLimit = "X-RateLimit-Limit"
+	// HeaderRateLimitRemaining requests left in the window
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	// HeaderRateLimitReset seconds until the oldest counted request leaves the window
+	HeaderRateLimitReset = "X-RateLimit-Reset"
+	// HeaderRetryAfter seconds to wait before a rejected request can be sent again
+	HeaderRetryAfter = "Retry-After"
+
+	msgRateLimited = "too many requests, please try again later"
+
+	// rateLimitScript counts the request in a sorted set scored by time in milliseconds, older requests are
+	// removed first. Returns whether the request is allowed, the requests in the window and the oldest one
+	rateLimitScript = `
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	redis.call('PEXPIRE', KEYS[1], window)
+	count = count + 1
+	allowed = 1
+end
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local oldestScore = now
+if oldest[2] then
+	oldestScore = tonumber(oldest[2])
+end
+return {allowed, count, oldestScore}
+`
+)
+
+// RateLimitRejections number of rejected requests per route group, published in `/debug/vars`
+var RateLimitRejections = expvar.NewMap("rate_limit_rejections")
+
+// rateLimitSequence keeps members of the sorted set unique within the same millisecond
+var rateLimitSequence uint64
+
+// RateLimitRule limit of the routes of a group, requests are counted per ip, client id and route,
+// and per ip on every route of the group whatever the client
+type RateLimitRule struct {
+	// Group name of the routes in redis keys and metrics
+	Group  string
+	Limit  int
+	Window time.Duration
+	// IPLimit requests of an ip on the routes of the group within IPWindow, not limited when zero
+	IPLimit  int
+	IPWindow time.Duration
+	// Routes echo route paths, e.g. `/api/v2/auth`
+	Routes []string
+}
+
+// RateLimitConfig tells the client and the ip a request is counted for
+type RateLimitConfig struct {
+	// ClientAuth returns the client of a request with valid credentials, other requests share the `-` client,
+	// e.g. OAuthClientAuth
+	ClientAuth func(req *http.Request) (string, bool)
+	// TrustedProxies networks of the proxies whose X-Forwarded-For and X-Real-IP headers are read,
+	// the ip of a request from anywhere else is its remote address
+	TrustedProxies []*net.IPNet
+}
+
+// ParseTrustedProxies function for reading comma separated ips or networks, e.g. `10.0.0.0/8,192.168.1.10`
+func ParseTrustedProxies(raw string) ([]*net.IPNet, error) {
+	proxies := []*net.IPNet{}
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s", value)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// ParseRateLimit function for reading a limit in `<requests>/<window>` format, e.g. `20/1m`
+func ParseRateLimit(raw string) (int, time.Duration, error) {
+	split := strings.SplitN(strings.TrimSpace(raw), "/", 2)
+	if len(split) != 2 {
+		return 0, 0, fmt.Errorf("invalid rate limit %s", raw)
+	}
+
+	limit, err := strconv.Atoi(split[0])
+	if err != nil || limit <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate limit %s", raw)
+	}
+	window, err := time.ParseDuration(split[1])
+	if err != nil || window < time.Second {
+		return 0, 0, fmt.Errorf("invalid rate limit %s", raw)
+	}
+	return limit, window, nil
+}
+
+// RateLimit function for limiting the routes of the rules with a sliding window kept in redis,
+// routes without rule are not limited and a redis error lets the request through
+func RateLimit(cl redis.Client, config RateLimitConfig, rules ...RateLimitRule) echo.MiddlewareFunc {
+	routes := map[string]RateLimitRule{}
+	for _, rule := range rules {
+		for _, route := range rule.Routes {
+			routes[route] = rule
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			rule, ok := routes[c.Path()]
+			if !ok || rule.Limit <= 0 {
+				return next(c)
+			}
+
+			req := c.Request()
+			ip := config.clientIP(req)
+			now := time.Now()
+
+			// the ip limit holds whatever client the request claims
+			if rule.IPLimit > 0 {
+				key := strings.Join([]string{RateLimitKeyRedis, rule.Group, ip}, ":")
+				allowed, _, reset, err := allowRequest(cl, key, rule.IPLimit, rule.IPWindow, now)
+				if err != nil {
+					log.WithField("key", key).WithError(err).Error("rate limit redis error")
+					return next(c)
+				}
+				if !allowed {
+					return rejectRequest(c, rule, key, rule.IPLimit, reset)
+				}
+			}
+
+			key := strings.Join([]string{RateLimitKeyRedis, rule.Group, ip, config.clientID(req), req.Method + " " + c.Path()}, ":")
+			allowed, count, reset, err := allowRequest(cl, key, rule.Limit, rule.Window, now)
+			if err != nil {
+				log.WithField("key", key).WithError(err).Error("rate limit redis error")
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(rule.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(rule.Limit-count))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(reset))
+			if !allowed {
+				return rejectRequest(c, rule, key, rule.Limit, reset)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// rejectRequest function for answering a request over the limit
+func rejectRequest(c echo.Context, rule RateLimitRule, key string, limit, reset int) error {
+	header := c.Response().Header()
+	header.Set(HeaderRateLimitLimit, strconv.Itoa(limit))
+	header.Set(HeaderRateLimitRemaining, "0")
+	header.Set(HeaderRateLimitReset, strconv.Itoa(reset))
+	header.Set(HeaderRetryAfter, strconv.Itoa(reset))
+	RateLimitRejections.Add(rule.Group, 1)
+	tracer.Log(c.Request().Context(), "rate_limited", key)
+	return echo.NewHTTPError(http.StatusTooManyRequests, msgRateLimited)
+}
+
+// allowRequest function for counting a request in the window of key, returns whether it is allowed,
+// the requests in the window and the seconds until the oldest one leaves it
+func allowRequest(cl redis.Client, key string, limit int, window time.Duration, now time.Time) (bool, int, int, error) {
+	nowMillis := now.UnixNano() / int64(time.Millisecond)
+	windowMillis := int64(window / time.Millisecond)
+	member := strconv.FormatInt(nowMillis, 10) + "-" + strconv.FormatUint(atomic.AddUint64(&rateLimitSequence, 1), 10)
+
+	result, err := cl.Eval(rateLimitScript, []string{key}, nowMillis, windowMillis, limit, member)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 3 {
+		return false, 0, 0, fmt.Errorf("unexpected rate limit result %v", result)
+	}
+	allowed, _ := values[0].(int64)
+	count, _ := values[1].(int64)
+	oldest, _ := values[2].(int64)
+
+	reset := int(math.Ceil(float64(oldest+windowMillis-nowMillis) / 1000))
+	if reset < 1 {
+		reset = 1
+	}
+	return allowed == 1, int(count), reset, nil
+}
+
+// clientID function for getting the client of a request from its verified credentials, a client id
+// the caller only claims, e.g. the X-Client-ID header, is not used as it would open a window per value
+func (config RateLimitConfig) clientID(req *http.Request) string {
+	if config.ClientAuth != nil {
+		if clientID, ok := config.ClientAuth(req); ok {
+			return clientID
+		}
+	}
+	return "-"
+}
+
+// OAuthClientAuth function for counting the requests of an OAuth client apart, the client is the client_id
+// of valid Basic credentials of a client app, or the subject of a valid client credentials token
+func OAuthClientAuth(query clientQuery.ClientQuery, keys *rsa.KeySet) func(req *http.Request) (string, bool) {
+	return func(req *http.Request) (string, bool) {
+		if clientID, clientSecret, ok := req.BasicAuth(); ok {
+			valid, err := query.Validate(req.Context(), clientID, clientSecret)
+			return clientID, err == nil && valid
+		}
+
+		tokenStr, err := getTokenString(req.Header.Get(echo.HeaderAuthorization))
+		if err != nil {
+			return "", false
+		}
+		token, err := parseToken(req.Context(), keys, tokenStr)
+		if err != nil || !token.Valid {
+			return "", false
+		}
+		claims, ok := token.Claims.(*BearerClaims)
+		if !ok || claims.DeviceLogin != authModel.ClientCredentialsDeviceLogin || claims.Subject == "" {
+			return "", false
+		}
+		return claims.Subject, true
+	}
+}
+
+// clientIP function for getting the ip of a request, the forwarded headers are only read from a trusted proxy
+// and the first ip of X-Forwarded-For from the right that is not a trusted proxy is the client
+func (config RateLimitConfig) clientIP(req *http.Request) string {
+	remote, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		remote = req.RemoteAddr
+	}
+	if !config.isTrustedProxy(remote) {
+		return remote
+	}
+
+	if forwarded := req.Header.Get(echo.HeaderXForwardedFor); forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if net.ParseIP(hop) == nil {
+				break
+			}
+			if !config.isTrustedProxy(hop) || i == 0 {
+				return hop
+			}
+		}
+	}
+	if realIP := strings.TrimSpace(req.Header.Get(echo.HeaderXRealIP)); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return remote
+}
+
+func (config RateLimitConfig) isTrustedProxy(raw string) bool {
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return false
+	}
+	for _, network := range config.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
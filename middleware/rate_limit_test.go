@@ -0,0 +1,325 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	rsaKey "github.com/Bhinneka/user-service/config/rsa"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	clientQueryMock "github.com/Bhinneka/user-service/src/client/v1/query/mocks"
+	"github.com/Bhinneka/user-service/src/shared/mocks"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis"
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	limit, window, err := ParseRateLimit("20/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, limit)
+	assert.Equal(t, time.Minute, window)
+
+	for _, invalid := range []string{"", "20", "x/1m", "0/1m", "20/x", "20/10ms"} {
+		_, _, err = ParseRateLimit(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+const rateLimitSecret = "secret"
+
+func setupRateLimit(cl redis.Client) *echo.Echo {
+	config := RateLimitConfig{
+		ClientAuth: func(req *http.Request) (string, bool) {
+			username, password, ok := req.BasicAuth()
+			return username, ok && password == rateLimitSecret
+		},
+	}
+	e := echo.New()
+	e.Use(RateLimit(cl, config, RateLimitRule{Group: "auth", Limit: 2, Window: time.Minute, IPLimit: 5, IPWindow: time.Minute, Routes: []string{"/api/v2/auth"}}))
+	e.POST("/api/v2/auth", setupHandler())
+	e.POST("/api/v2/auth/verify", setupHandler())
+	return e
+}
+
+func serveRateLimit(e *echo.Echo, path, clientID, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(echo.POST, path, nil)
+	if clientID != "" {
+		req.SetBasicAuth(clientID, password)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+
+	e := setupRateLimit(redis.Conn{Client: goredis.NewClient(&goredis.Options{Addr: mr.Addr()})})
+	rejections := func() int64 {
+		if v, ok := RateLimitRejections.Get("auth").(interface{ Value() int64 }); ok {
+			return v.Value()
+		}
+		return 0
+	}
+	before := rejections()
+
+	tests := []struct {
+		name          string
+		path          string
+		clientID      string
+		password      string
+		wantCode      int
+		wantLimit     string
+		wantRemaining string
+		wantRetry     bool
+	}{
+		{
+			name:          "Case 1: First request",
+			path:          "/api/v2/auth",
+			clientID:      "sturgeon",
+			password:      rateLimitSecret,
+			wantCode:      http.StatusOK,
+			wantRemaining: "1",
+		},
+		{
+			name:          "Case 2: Last request of the window",
+			path:          "/api/v2/auth",
+			clientID:      "sturgeon",
+			password:      rateLimitSecret,
+			wantCode:      http.StatusOK,
+			wantRemaining: "0",
+		},
+		{
+			name:          "Case 3: Limit reached",
+			path:          "/api/v2/auth",
+			clientID:      "sturgeon",
+			password:      rateLimitSecret,
+			wantCode:      http.StatusTooManyRequests,
+			wantRemaining: "0",
+			wantRetry:     true,
+		},
+		{
+			name:          "Case 4: Another client is counted apart",
+			path:          "/api/v2/auth",
+			clientID:      "dolphin",
+			password:      rateLimitSecret,
+			wantCode:      http.StatusOK,
+			wantRemaining: "1",
+		},
+		{
+			name:     "Case 5: Route without limit",
+			path:     "/api/v2/auth/verify",
+			clientID: "sturgeon",
+			password: rateLimitSecret,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:          "Case 6: Unverified client shares the anonymous window",
+			path:          "/api/v2/auth",
+			clientID:      "mallory",
+			password:      "guess",
+			wantCode:      http.StatusOK,
+			wantRemaining: "1",
+		},
+		{
+			name:          "Case 7: Ip limit reached whatever the client",
+			path:          "/api/v2/auth",
+			clientID:      "mallory-2",
+			password:      "guess",
+			wantCode:      http.StatusTooManyRequests,
+			wantLimit:     "5",
+			wantRemaining: "0",
+			wantRetry:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveRateLimit(e, tt.path, tt.clientID, tt.password)
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantRemaining, rec.Header().Get(HeaderRateLimitRemaining))
+			assert.Equal(t, tt.wantRetry, rec.Header().Get(HeaderRetryAfter) != "")
+			if tt.wantRemaining != "" {
+				wantLimit := tt.wantLimit
+				if wantLimit == "" {
+					wantLimit = "2"
+				}
+				assert.Equal(t, wantLimit, rec.Header().Get(HeaderRateLimitLimit))
+				assert.Equal(t, "60", rec.Header().Get(HeaderRateLimitReset))
+			}
+		})
+	}
+	assert.Equal(t, before+2, rejections())
+	assert.Equal(t, 4, len(mr.Keys()))
+}
+
+func TestRateLimitClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.10")
+	assert.NoError(t, err)
+	_, err = ParseTrustedProxies("10.0.0.0/99")
+	assert.Error(t, err)
+
+	tests := []struct {
+		name      string
+		proxies   []*net.IPNet
+		remote    string
+		forwarded string
+		realIP    string
+		want      string
+	}{
+		{
+			name:      "Case 1: Forwarded header without trusted proxy",
+			remote:    "203.0.113.7:4000",
+			forwarded: "198.51.100.1",
+			want:      "203.0.113.7",
+		},
+		{
+			name:      "Case 2: Forwarded header from an untrusted remote",
+			proxies:   proxies,
+			remote:    "203.0.113.7:4000",
+			forwarded: "198.51.100.1",
+			realIP:    "198.51.100.2",
+			want:      "203.0.113.7",
+		},
+		{
+			name:      "Case 3: Client behind trusted proxies",
+			proxies:   proxies,
+			remote:    "10.0.0.5:4000",
+			forwarded: "198.51.100.1, 192.168.1.10",
+			want:      "198.51.100.1",
+		},
+		{
+			name:      "Case 4: Forged hop before the client",
+			proxies:   proxies,
+			remote:    "10.0.0.5:4000",
+			forwarded: "1.2.3.4, 198.51.100.1",
+			want:      "198.51.100.1",
+		},
+		{
+			name:    "Case 5: Real ip header from a trusted proxy",
+			proxies: proxies,
+			remote:  "10.0.0.5:4000",
+			realIP:  "198.51.100.2",
+			want:    "198.51.100.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(echo.POST, "/api/v2/auth", nil)
+			req.RemoteAddr = tt.remote
+			if tt.forwarded != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, tt.forwarded)
+			}
+			if tt.realIP != "" {
+				req.Header.Set(echo.HeaderXRealIP, tt.realIP)
+			}
+			assert.Equal(t, tt.want, RateLimitConfig{TrustedProxies: tt.proxies}.clientIP(req))
+		})
+	}
+}
+
+func TestRateLimitRedisError(t *testing.T) {
+	e := setupRateLimit(&mocks.FakeRedis{EvalFunc: func(string, []string, ...interface{}) (interface{}, error) {
+		return nil, errors.New("connection refused")
+	}})
+
+	rec := serveRateLimit(e, "/api/v2/auth", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "", rec.Header().Get(HeaderRateLimitLimit))
+}
+
+func TestOAuthClientAuth(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keys := rsaKey.NewKeySet()
+	keys.AddSigningKey("rate-limit-test", signingKey)
+
+	signToken := func(deviceLogin string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, &BearerClaims{
+			DeviceID:    "CLIENT-partner",
+			DeviceLogin: deviceLogin,
+			StandardClaims: jwt.StandardClaims{
+				Subject:   "partner",
+				ExpiresAt: time.Now().Add(time.Minute).Unix(),
+			},
+		})
+		token.Header["kid"] = "rate-limit-test"
+		tokenStr, err := token.SignedString(signingKey)
+		assert.NoError(t, err)
+		return "Bearer " + tokenStr
+	}
+
+	query := new(clientQueryMock.ClientQuery)
+	query.On("Validate", mock.Anything, "partner", rateLimitSecret).Return(true, nil)
+	query.On("Validate", mock.Anything, "partner", "wrong").Return(false, nil)
+	query.On("Validate", mock.Anything, "unknown", mock.Anything).Return(false, errors.New("client not found"))
+	clientAuth := OAuthClientAuth(query, keys)
+
+	tests := []struct {
+		name         string
+		basic        []string
+		auth         string
+		wantClientID string
+		wantOK       bool
+	}{
+		{
+			name:         "client app basic credentials",
+			basic:        []string{"partner", rateLimitSecret},
+			wantClientID: "partner",
+			wantOK:       true,
+		},
+		{
+			name:  "wrong client secret",
+			basic: []string{"partner", "wrong"},
+		},
+		{
+			name:  "unknown client",
+			basic: []string{"unknown", rateLimitSecret},
+		},
+		{
+			name:         "client credentials token",
+			auth:         signToken(authModel.ClientCredentialsDeviceLogin),
+			wantClientID: "partner",
+			wantOK:       true,
+		},
+		{
+			name: "member token",
+			auth: signToken("WEB"),
+		},
+		{
+			name: "invalid token",
+			auth: "Bearer invalid",
+		},
+		{
+			name: "no credentials",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(echo.POST, "/api/v2/oauth/token", nil)
+			if len(tt.basic) == 2 {
+				req.SetBasicAuth(tt.basic[0], tt.basic[1])
+			}
+			if tt.auth != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.auth)
+			}
+
+			clientID, ok := clientAuth(req)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantClientID, clientID)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	rsaKey "github.com/Bhinneka/user-service/config/rsa"
+	"github.com/Bhinneka/user-service/src/shared/mocks"
+	jwt "github.com/golang-jwt/jwt"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     interface{}
+		scopes    []string
+		wantError bool
+	}{
+		{
+			name:      "no token",
+			scopes:    []string{"member:read"},
+			wantError: true,
+		},
+		{
+			name:   "token with scope",
+			token:  &jwt.Token{Claims: &BearerClaims{Scope: "member:read merchant:read"}, Valid: true},
+			scopes: []string{"member:read", "merchant:read"},
+		},
+		{
+			name:      "token missing scope",
+			token:     &jwt.Token{Claims: &BearerClaims{Scope: "member:read"}, Valid: true},
+			scopes:    []string{"member:write"},
+			wantError: true,
+		},
+		{
+			name:      "token without scope",
+			token:     jwtTokenValidTest,
+			scopes:    []string{"member:read"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupRequest(map[string]string{})
+			if tt.token != nil {
+				c.Set("token", tt.token)
+			}
+			err := RequireScope(tt.scopes...)(setupHandler())(c)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServiceAuth(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keys := rsaKey.NewKeySet()
+	keys.AddSigningKey("service-test", signingKey)
+
+	signToken := func(scope string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, &BearerClaims{
+			DeviceID: "CLIENT-partner",
+			Scope:    scope,
+			StandardClaims: jwt.StandardClaims{
+				Subject:   "partner",
+				ExpiresAt: time.Now().Add(time.Minute).Unix(),
+			},
+		})
+		token.Header["kid"] = "service-test"
+		tokenStr, err := token.SignedString(signingKey)
+		assert.NoError(t, err)
+		return "Bearer " + tokenStr
+	}
+
+	e := echo.New()
+	basic := BasicAuth(NewConfig("bhinneka", "da1c25d8-37c8-41b1-afe2-42dd4825bfea"))
+	group := e.Group("/api/phone-area")
+	group.Use(ServiceAuth(basic, keys, mocks.InitFakeRedis(), "phone-area:read"))
+	group.GET("", func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+
+	tests := []struct {
+		name       string
+		auth       string
+		wantStatus int
+	}{
+		{
+			name:       "token with scope",
+			auth:       signToken("phone-area:read"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "token missing scope",
+			auth:       signToken("session:read"),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid token",
+			auth:       "Bearer invalid",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "basic credentials",
+			auth:       getAuth(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no credentials",
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/phone-area", nil)
+			if tt.auth != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
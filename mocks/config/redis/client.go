@@ -34,6 +34,32 @@ func (_m *Client) Del(key string) (int64, error) {
 	return r0, r1
 }
 
+// Eval provides a mock function with given fields: script, keys, args
+func (_m *Client) Eval(script string, keys []string, args ...interface{}) (interface{}, error) {
+	var _ca []interface{}
+	_ca = append(_ca, script, keys)
+	_ca = append(_ca, args...)
+	ret := _m.Called(_ca...)
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func(string, []string, ...interface{}) interface{}); ok {
+		r0 = rf(script, keys, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string, ...interface{}) error); ok {
+		r1 = rf(script, keys, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Expire provides a mock function with given fields: key, exp
 func (_m *Client) Expire(key string, exp time.Duration) (bool, error) {
 	ret := _m.Called(key, exp)
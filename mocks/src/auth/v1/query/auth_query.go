@@ -30,6 +30,22 @@ func (_m *AuthQuery) GetAccountId(ctxReq context.Context, contactId int) <-chan
 	return r0
 }
 
+// GetMemberPermissions provides a mock function with given fields: ctxReq, memberID
+func (_m *AuthQuery) GetMemberPermissions(ctxReq context.Context, memberID string) <-chan query.ResultQuery {
+	ret := _m.Called(ctxReq, memberID)
+
+	var r0 <-chan query.ResultQuery
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan query.ResultQuery); ok {
+		r0 = rf(ctxReq, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan query.ResultQuery)
+		}
+	}
+
+	return r0
+}
+
 // UpdateLastLogin provides a mock function with given fields: uid
 func (_m *AuthQuery) UpdateLastLogin(uid string) <-chan query.ResultQuery {
 	ret := _m.Called(uid)
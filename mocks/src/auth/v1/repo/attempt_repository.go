@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	model "github.com/Bhinneka/user-service/src/auth/v1/model"
 	mock "github.com/stretchr/testify/mock"
@@ -32,6 +33,22 @@ func (_m *AttemptRepository) Delete(ctxReq context.Context, key string) <-chan r
 	return r0
 }
 
+// Increment provides a mock function with given fields: ctxReq, key, age
+func (_m *AttemptRepository) Increment(ctxReq context.Context, key string, age time.Duration) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, key, age)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, key, age)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // Load provides a mock function with given fields: ctxReq, key
 func (_m *AttemptRepository) Load(ctxReq context.Context, key string) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, key)
@@ -32,6 +32,22 @@ func (_m *RefreshTokenRepository) Delete(ctxReq context.Context, key string) <-c
 	return r0
 }
 
+// FindByToken provides a mock function with given fields: ctxReq, token
+func (_m *RefreshTokenRepository) FindByToken(ctxReq context.Context, token string) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, token)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // Load provides a mock function with given fields: ctxReq, key
 func (_m *RefreshTokenRepository) Load(ctxReq context.Context, key string) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, key)
@@ -48,6 +64,38 @@ func (_m *RefreshTokenRepository) Load(ctxReq context.Context, key string) <-cha
 	return r0
 }
 
+// RevokeFamily provides a mock function with given fields: ctxReq, owner
+func (_m *RefreshTokenRepository) RevokeFamily(ctxReq context.Context, owner *model.RefreshTokenOwner) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, owner)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, *model.RefreshTokenOwner) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
+// Rotate provides a mock function with given fields: ctxReq, token
+func (_m *RefreshTokenRepository) Rotate(ctxReq context.Context, token string) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, token)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctxReq, refreshToken
 func (_m *RefreshTokenRepository) Save(ctxReq context.Context, refreshToken *model.RefreshToken) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, refreshToken)
@@ -9,8 +9,8 @@ type PasswordHasher struct {
 	mock.Mock
 }
 
-// GenerateSalt provides a mock function with given fields:
-func (_m *PasswordHasher) GenerateSalt() string {
+// Current provides a mock function with given fields:
+func (_m *PasswordHasher) Current() string {
 	ret := _m.Called()
 
 	var r0 string
@@ -23,31 +23,57 @@ func (_m *PasswordHasher) GenerateSalt() string {
 	return r0
 }
 
-// Hash provides a mock function with given fields: data
-func (_m *PasswordHasher) Hash(data []byte) []byte {
-	ret := _m.Called(data)
+// HashPassword provides a mock function with given fields: password
+func (_m *PasswordHasher) HashPassword(password string) (string, string, error) {
+	ret := _m.Called(password)
 
-	var r0 []byte
-	if rf, ok := ret.Get(0).(func([]byte) []byte); ok {
-		r0 = rf(data)
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(password)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]byte)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	return r0
+	var r1 string
+	if rf, ok := ret.Get(1).(func(string) string); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(password)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// ParseSalt provides a mock function with given fields: salt
-func (_m *PasswordHasher) ParseSalt(salt string) error {
+// NeedsRehash provides a mock function with given fields: salt
+func (_m *PasswordHasher) NeedsRehash(salt string) bool {
 	ret := _m.Called(salt)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
 		r0 = rf(salt)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// VerifyPassword provides a mock function with given fields: password, salt, hashed
+func (_m *PasswordHasher) VerifyPassword(password string, salt string, hashed string) bool {
+	ret := _m.Called(password, salt, hashed)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
+		r0 = rf(password, salt, hashed)
+	} else {
+		r0 = ret.Get(0).(bool)
 	}
 
 	return r0
@@ -126,6 +126,22 @@ func (_m *MemberRepository) UpdateFlagIsSyncMember(ctxReq context.Context, membe
 	return r0
 }
 
+// UpdatePasswordHash provides a mock function with given fields: ctxReq, memberID, oldSalt, salt, password
+func (_m *MemberRepository) UpdatePasswordHash(ctxReq context.Context, memberID string, oldSalt string, salt string, password string) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, memberID, oldSalt, salt, password)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, memberID, oldSalt, salt, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // UpdatePasswordMemberByEmail provides a mock function with given fields: ctxReq, member
 func (_m *MemberRepository) UpdatePasswordMemberByEmail(ctxReq context.Context, member model.Member) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, member)
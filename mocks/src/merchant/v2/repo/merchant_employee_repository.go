@@ -16,6 +16,20 @@ type MerchantEmployeeRepository struct {
 	mock.Mock
 }
 
+// ChangeRole provides a mock function with given fields: ctxReq, params
+func (_m *MerchantEmployeeRepository) ChangeRole(ctxReq context.Context, params model.B2CMerchantEmployee) error {
+	ret := _m.Called(ctxReq, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.B2CMerchantEmployee) error); ok {
+		r0 = rf(ctxReq, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ChangeStatus provides a mock function with given fields: ctxReq, params
 func (_m *MerchantEmployeeRepository) ChangeStatus(ctxReq context.Context, params model.B2CMerchantEmployee) error {
 	ret := _m.Called(ctxReq, params)
@@ -28,3 +28,19 @@ func (_m *SessionInfoRepository) SaveSessionInfo(params *model.SessionInfoReques
 
 	return r0
 }
+
+// UpdateLastUsed provides a mock function with given fields: params
+func (_m *SessionInfoRepository) UpdateLastUsed(params *model.SessionInfoRequest) <-chan repo.ResultRepository {
+	ret := _m.Called(params)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(*model.SessionInfoRequest) <-chan repo.ResultRepository); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,8 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- space separated list of redirect uris allowed for the oauth2 authorization code flow
+ALTER TABLE client_app ADD COLUMN IF NOT EXISTS "redirectUris" text;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE client_app DROP COLUMN IF EXISTS "redirectUris";
@@ -0,0 +1,8 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- space separated list of scopes a client app may request with the client_credentials grant
+ALTER TABLE client_app ADD COLUMN IF NOT EXISTS "scopes" text;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE client_app DROP COLUMN IF EXISTS "scopes";
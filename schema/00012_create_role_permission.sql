@@ -0,0 +1,63 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS permission (
+    id character varying(50) NOT NULL,
+    description character varying(255),
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT permission_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS role (
+    id character varying(50) NOT NULL,
+    name character varying(100) NOT NULL,
+    description character varying(255),
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    "lastModified" timestamp with time zone,
+    CONSTRAINT role_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS role_permission (
+    "roleId" character varying(50) NOT NULL REFERENCES role (id) ON DELETE CASCADE,
+    "permissionId" character varying(50) NOT NULL REFERENCES permission (id) ON DELETE CASCADE,
+    CONSTRAINT role_permission_pkey PRIMARY KEY ("roleId", "permissionId")
+);
+
+CREATE TABLE IF NOT EXISTS member_role (
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    "roleId" character varying(50) NOT NULL REFERENCES role (id) ON DELETE CASCADE,
+    "createdBy" character varying,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT member_role_pkey PRIMARY KEY ("memberId", "roleId")
+);
+
+INSERT INTO permission (id, description) VALUES
+    ('member:manage', 'list, create and update members from CMS'),
+    ('member:import', 'import members from file'),
+    ('merchant:manage', 'create, update and delete merchants from CMS'),
+    ('merchant:reject', 'reject merchant registration and upgrade'),
+    ('corporate:contact', 'list and import corporate contacts'),
+    ('document-type:manage', 'manage document types'),
+    ('application:manage', 'manage applications'),
+    ('log:read', 'read audit trail logs'),
+    ('auth:impersonate', 'issue access token on behalf of a member')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role (id, name, description) VALUES
+    ('administrator', 'Administrator', 'every permission, assigned to existing admins on migration')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permission ("roleId", "permissionId")
+    SELECT 'administrator', id FROM permission
+ON CONFLICT DO NOTHING;
+
+-- keep existing admins working until they are given narrower roles
+INSERT INTO member_role ("memberId", "roleId", "createdBy")
+    SELECT id, 'administrator', 'migration' FROM member WHERE "isAdmin" = true
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_role;
+DROP TABLE IF EXISTS role_permission;
+DROP TABLE IF EXISTS role;
+DROP TABLE IF EXISTS permission;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- webauthn credentials (passkeys) registered by a member
+CREATE TABLE IF NOT EXISTS member_passkey (
+    id character varying(50) NOT NULL,
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    "credentialId" character varying(1024) NOT NULL,
+    "publicKey" bytea NOT NULL,
+    algorithm integer NOT NULL,
+    "signCount" bigint DEFAULT 0 NOT NULL,
+    aaguid character varying(36),
+    transports text,
+    name character varying(100) NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    "lastModified" timestamp with time zone,
+    "lastUsed" timestamp with time zone,
+    CONSTRAINT member_passkey_pkey PRIMARY KEY (id),
+    CONSTRAINT member_passkey_credential_id_key UNIQUE ("credentialId")
+);
+
+CREATE INDEX IF NOT EXISTS member_passkey_member_id_idx ON member_passkey ("memberId");
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_passkey;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- one time codes for signing in when the authenticator app is lost, only the sha256 digest is stored
+CREATE TABLE IF NOT EXISTS member_mfa_recovery_code (
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    "codeHash" character varying(64) NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    "usedAt" timestamp with time zone,
+    CONSTRAINT member_mfa_recovery_code_pkey PRIMARY KEY ("memberId", "codeHash")
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_mfa_recovery_code;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- second factors sending a one time code, the authenticator app stays in member."mfaKey"
+CREATE TABLE IF NOT EXISTS member_mfa_method (
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    method character varying(10) NOT NULL,
+    target character varying(255) NOT NULL,
+    "isDefault" boolean DEFAULT false NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    "lastModified" timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT member_mfa_method_pkey PRIMARY KEY ("memberId", method)
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_mfa_method;
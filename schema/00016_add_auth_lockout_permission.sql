@@ -0,0 +1,39 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+INSERT INTO permission (id, description) VALUES
+    ('auth:lockout', 'view and clear failed login lockouts')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permission ("roleId", "permissionId") VALUES
+    ('administrator', 'auth:lockout')
+ON CONFLICT DO NOTHING;
+
+-- failed logins no longer block the member row, the lock lives in redis and expires by itself.
+-- members blocked by the old counter would otherwise wait for a key expiry nobody listens to anymore.
+-- only the old counter set "lastBlocked" and it left "lastModified" as it was, a block by an admin saves
+-- the member and moves "lastModified" past it. unblocked members are kept so the rollback can block them again
+CREATE TABLE IF NOT EXISTS member_lockout_unblocked (
+    "memberId" character varying PRIMARY KEY,
+    "lastBlocked" timestamp with time zone NOT NULL
+);
+
+INSERT INTO member_lockout_unblocked ("memberId", "lastBlocked")
+    SELECT id, "lastBlocked" FROM member
+    WHERE status = 'BLOCKED' AND "lastBlocked" > NOW() - INTERVAL '1 day' AND "lastBlocked" > COALESCE("lastModified", created)
+ON CONFLICT DO NOTHING;
+
+UPDATE member SET status = 'ACTIVE'
+    FROM member_lockout_unblocked
+    WHERE member.id = member_lockout_unblocked."memberId" AND member.status = 'BLOCKED';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+-- members unblocked above and still active go back to the old counter
+UPDATE member SET status = 'BLOCKED'
+    FROM member_lockout_unblocked
+    WHERE member.id = member_lockout_unblocked."memberId" AND member.status = 'ACTIVE';
+
+DROP TABLE IF EXISTS member_lockout_unblocked;
+
+DELETE FROM role_permission WHERE "permissionId" = 'auth:lockout';
+DELETE FROM permission WHERE id = 'auth:lockout';
@@ -0,0 +1,16 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- previous password hashes of a member, the current one stays in member.password
+CREATE TABLE IF NOT EXISTS member_password_history (
+    id bigserial PRIMARY KEY,
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    salt character varying(255) NOT NULL,
+    password character varying(255) NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS member_password_history_member_idx ON member_password_history ("memberId", created DESC);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_password_history;
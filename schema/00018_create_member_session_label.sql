@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- name given by a member to the login session of a device, kept after the session ends
+CREATE TABLE IF NOT EXISTS member_session_label (
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    "deviceId" character varying(255) NOT NULL,
+    "deviceLogin" character varying(50) NOT NULL,
+    label character varying(50) NOT NULL,
+    modified timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY ("memberId", "deviceId", "deviceLogin")
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_session_label;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- identity provider accounts linked to a member, replacing the facebookId, googleId, appleId, azureId and ldapId columns
+CREATE TABLE IF NOT EXISTS member_identities (
+    id character varying(50) NOT NULL,
+    "memberId" character varying NOT NULL REFERENCES member (id) ON DELETE CASCADE,
+    provider character varying(50) NOT NULL,
+    subject character varying(255) NOT NULL,
+    email character varying(255),
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    "lastUsed" timestamp with time zone,
+    CONSTRAINT member_identities_pkey PRIMARY KEY (id),
+    CONSTRAINT member_identities_provider_subject_key UNIQUE (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS member_identities_member_id_idx ON member_identities ("memberId");
+
+-- identities already linked through the social media columns, the oldest member keeps a subject used twice
+INSERT INTO member_identities (id, "memberId", provider, subject, email, created)
+SELECT DISTINCT ON (linked.provider, linked.subject)
+    md5(linked.provider || ':' || linked.subject), linked."memberId", linked.provider, linked.subject, linked.email,
+    COALESCE(linked.created, now())
+FROM (
+    SELECT id AS "memberId", 'facebook' AS provider, "facebookId" AS subject, email, created FROM member
+    UNION ALL
+    SELECT id, 'google', "googleId", email, created FROM member
+    UNION ALL
+    SELECT id, 'apple', "appleId", email, created FROM member
+    UNION ALL
+    SELECT id, 'azure', "azureId", email, created FROM member
+    UNION ALL
+    SELECT id, 'ldap', "ldapId", email, created FROM member
+) linked
+WHERE linked.subject IS NOT NULL AND linked.subject <> ''
+ORDER BY linked.provider, linked.subject, linked.created
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS member_identities;
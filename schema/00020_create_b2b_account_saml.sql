@@ -0,0 +1,34 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- SAML 2.0 single sign on of a corporate account, metadata is the xml metadata uploaded from the identity provider
+CREATE TABLE IF NOT EXISTS b2b_account_saml (
+    account_id character varying(50) NOT NULL,
+    entity_id character varying(255) NOT NULL,
+    sso_url text NOT NULL,
+    metadata text NOT NULL,
+    attribute_mapping jsonb DEFAULT '{}'::jsonb NOT NULL,
+    jit_provisioning boolean DEFAULT false NOT NULL,
+    enabled boolean DEFAULT false NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    modified timestamp with time zone DEFAULT now() NOT NULL,
+    created_by character varying(50),
+    modified_by character varying(50),
+    CONSTRAINT b2b_account_saml_pkey PRIMARY KEY (account_id)
+);
+
+-- ids of contacts provisioned by a first single sign on, kept above the ids synced from shark
+CREATE SEQUENCE IF NOT EXISTS b2b_saml_provision_id_seq START WITH 900000000;
+
+INSERT INTO permission (id, description) VALUES
+    ('corporate:saml', 'manage single sign on of corporate accounts')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO role_permission ("roleId", "permissionId") VALUES
+    ('administrator', 'corporate:saml')
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DELETE FROM permission WHERE id = 'corporate:saml';
+DROP SEQUENCE IF EXISTS b2b_saml_provision_id_seq;
+DROP TABLE IF EXISTS b2b_account_saml;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- every move of a merchant status or upgrade status, written in the same transaction as the merchant
+CREATE TABLE IF NOT EXISTS merchant_status_history (
+    id bigserial PRIMARY KEY,
+    "merchantId" character varying(50) NOT NULL,
+    field character varying(20) NOT NULL,
+    event character varying(50) NOT NULL,
+    "fromStatus" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "toStatus" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "actorId" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "actorIp" character varying(50) DEFAULT ''::character varying NOT NULL,
+    reason text DEFAULT ''::text NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS merchant_status_history_merchant_idx ON merchant_status_history ("merchantId", created DESC);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS merchant_status_history;
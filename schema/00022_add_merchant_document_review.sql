@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- review state of merchant documents, KTP and NPWP rows are created on their first review
+ALTER TABLE b2c_merchantdocument
+    ADD COLUMN IF NOT EXISTS "status" character varying(20) DEFAULT 'PENDING'::character varying NOT NULL,
+    ADD COLUMN IF NOT EXISTS "rejectReason" text DEFAULT ''::text NOT NULL,
+    ADD COLUMN IF NOT EXISTS "reviewerId" character varying(50) DEFAULT ''::character varying NOT NULL,
+    ADD COLUMN IF NOT EXISTS "reviewed" timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS "expiryNotified" timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS b2c_merchantdocument_expiry_idx ON b2c_merchantdocument ("status", "documentExpirationDate");
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS b2c_merchantdocument_expiry_idx;
+
+ALTER TABLE b2c_merchantdocument
+    DROP COLUMN IF EXISTS "expiryNotified",
+    DROP COLUMN IF EXISTS "reviewed",
+    DROP COLUMN IF EXISTS "reviewerId",
+    DROP COLUMN IF EXISTS "rejectReason",
+    DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,24 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- every check of a merchant bank account, the latest row counts only while it matches the stored bank account
+CREATE TABLE IF NOT EXISTS merchant_bank_verification (
+    id bigserial PRIMARY KEY,
+    "merchantId" character varying(50) NOT NULL,
+    "bankId" bigint NOT NULL,
+    "bankCode" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "accountNumber" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "accountHolderName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "inquiryName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "matchedName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    score double precision DEFAULT 0 NOT NULL,
+    status character varying(20) NOT NULL,
+    reason text DEFAULT ''::text NOT NULL,
+    "actorId" character varying(50) DEFAULT ''::character varying NOT NULL,
+    created timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS merchant_bank_verification_merchant_idx ON merchant_bank_verification ("merchantId", created DESC);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS merchant_bank_verification;
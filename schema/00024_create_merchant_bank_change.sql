@@ -0,0 +1,36 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- bank account changes asked by merchant owners, applied only once confirmed with MFA, approved by an admin
+-- and past "effectiveAt", the end of the cooling-off period
+CREATE TABLE IF NOT EXISTS merchant_bank_change (
+    id bigserial PRIMARY KEY,
+    "merchantId" character varying(50) NOT NULL,
+    "requesterId" character varying(50) NOT NULL,
+    "oldBankId" bigint DEFAULT 0 NOT NULL,
+    "oldBankName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "oldBankBranch" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "oldAccountNumber" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "oldAccountHolderName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "newBankId" bigint DEFAULT 0 NOT NULL,
+    "newBankName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "newBankBranch" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "newAccountNumber" character varying(50) DEFAULT ''::character varying NOT NULL,
+    "newAccountHolderName" character varying(255) DEFAULT ''::character varying NOT NULL,
+    "notifyEmail" character varying(255) DEFAULT ''::character varying NOT NULL,
+    status character varying(20) NOT NULL,
+    "reviewerId" character varying(50) DEFAULT ''::character varying NOT NULL,
+    reason text DEFAULT ''::text NOT NULL,
+    "mfaVerified" timestamp with time zone,
+    reviewed timestamp with time zone,
+    "effectiveAt" timestamp with time zone NOT NULL,
+    applied timestamp with time zone,
+    created timestamp with time zone DEFAULT now() NOT NULL,
+    "lastModified" timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS merchant_bank_change_merchant_idx ON merchant_bank_change ("merchantId", created DESC);
+CREATE INDEX IF NOT EXISTS merchant_bank_change_due_idx ON merchant_bank_change ("effectiveAt") WHERE status = 'APPROVED';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS merchant_bank_change;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- role of merchant employees, employees invited before roles existed had the access of the owner and become ADMIN,
+-- new employees default to VIEWER until the owner assigns a role
+ALTER TABLE b2c_merchant_employees
+    ADD COLUMN IF NOT EXISTS "role" character varying(20) DEFAULT 'ADMIN'::character varying NOT NULL;
+
+ALTER TABLE b2c_merchant_employees
+    ALTER COLUMN "role" SET DEFAULT 'VIEWER'::character varying;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE b2c_merchant_employees
+    DROP COLUMN IF EXISTS "role";
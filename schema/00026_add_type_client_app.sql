@@ -0,0 +1,10 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- oauth2 client type (rfc 6749 section 2.1), confidential clients authenticate with their secret,
+-- public clients such as mobile or single page apps cannot keep one and are bound by PKCE.
+-- existing clients were all issued a secret and stay confidential
+ALTER TABLE client_app ADD COLUMN IF NOT EXISTS "type" character varying NOT NULL DEFAULT 'CONFIDENTIAL';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE client_app DROP COLUMN IF EXISTS "type";
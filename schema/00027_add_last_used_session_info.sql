@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- last time the login of the row issued a token, set at login and moved on every token refresh.
+-- rows saved before stay empty and fall back to "createdAt"
+ALTER TABLE session_info ADD COLUMN IF NOT EXISTS "lastUsedAt" timestamp with time zone;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE session_info DROP COLUMN IF EXISTS "lastUsedAt";
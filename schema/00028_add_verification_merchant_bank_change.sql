@@ -0,0 +1,16 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- name inquiry of the new bank account run when the owner confirms the change, and the reason of an admin
+-- approving a change whose new account is not verified
+ALTER TABLE merchant_bank_change ADD COLUMN IF NOT EXISTS "verificationStatus" character varying(20) DEFAULT ''::character varying NOT NULL;
+ALTER TABLE merchant_bank_change ADD COLUMN IF NOT EXISTS "verificationReason" text DEFAULT ''::text NOT NULL;
+ALTER TABLE merchant_bank_change ADD COLUMN IF NOT EXISTS "overrideReason" text DEFAULT ''::text NOT NULL;
+
+CREATE INDEX IF NOT EXISTS merchant_bank_change_awaiting_idx ON merchant_bank_change (created) WHERE status = 'AWAITING_MFA';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS merchant_bank_change_awaiting_idx;
+ALTER TABLE merchant_bank_change DROP COLUMN IF EXISTS "overrideReason";
+ALTER TABLE merchant_bank_change DROP COLUMN IF EXISTS "verificationReason";
+ALTER TABLE merchant_bank_change DROP COLUMN IF EXISTS "verificationStatus";
@@ -74,7 +74,7 @@ func (h *HTTPAuthHandler) GetAccessToken(c echo.Context) error {
 
 	mode := data.Mode
 
-	tokenResult := <-h.AuthUseCase.GenerateToken(context.WithValue(c.Request().Context(), middleware.ContextKeyClientIP, c.RealIP()), mode, data)
+	tokenResult := <-h.AuthUseCase.GenerateToken(context.WithValue(c.Request().Context(), middleware.ContextKeyClientIP, middleware.ClientIP(c)), mode, data)
 
 	if tokenResult.Error != nil {
 		if tokenResult.HTTPStatus == http.StatusForbidden {
@@ -153,7 +153,7 @@ func (h *HTTPAuthHandler) ValidateData(c echo.Context, data model.RequestToken,
 	data.Audience = clientID
 	data.DeviceID = strings.Trim(data.DeviceID, " ")
 	data.Email = strings.Trim(data.Username, " ")
-	data.IP = c.RealIP()
+	data.IP = middleware.ClientIP(c)
 	data.UserAgent = c.Request().UserAgent()
 
 	// for refreshing token needs old token and old refresh token
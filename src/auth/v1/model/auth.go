@@ -4,6 +4,7 @@ import (
 	"time"
 
 	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 )
 
 const (
@@ -33,6 +34,14 @@ const (
 	AuthTypeVerifyMFANarwhal = "mfaotp-narwhal"
 	// AuthTypeGoogleBackend bypass incognito
 	AuthTypeGoogleBackend = "google-backend"
+	// AuthTypePasskey for passwordless authentication with a webauthn passkey
+	AuthTypePasskey = "passkey"
+	// AuthTypePasswordless for user authentication with a magic link or code sent to the email
+	AuthTypePasswordless = "passwordless"
+	// AuthTypeOIDC for user authentication with an identity provider added as oidc connector
+	AuthTypeOIDC = "oidc"
+	// AuthTypeSAML for corporate contact authentication with the saml identity provider of the account
+	AuthTypeSAML = "saml"
 
 	// Bhinneka for issuer
 	Bhinneka = "bhinneka.com"
@@ -69,6 +78,16 @@ const (
 	//ErrorEmailAlreadyRegisteredUsingSocialLogin error message for email already registered using social login
 	ErrorEmailAlreadyRegisteredUsingSocialLogin = "Alamat email yang Anda masukan sudah terdaftar untuk %s login, silakan login dengan akun %s Anda untuk mengakses Bhinneka.com."
 
+	// ErrorIdentityLinkConfirmation error message for social login to an email registered without the account, the link is confirmed by email
+	ErrorIdentityLinkConfirmation = "Alamat email yang Anda masukan sudah terdaftar. Kami telah mengirim email ke alamat tersebut untuk menghubungkan akun %s Anda, silakan periksa email Anda."
+
+	// SubjectIdentityLink email subject of a link confirmation
+	SubjectIdentityLink = "Konfirmasi Penghubungan Akun Bhinneka Anda"
+	// ContentIdentityLink email content of a link confirmation: name, provider, provider, url, url and hours
+	ContentIdentityLink = "<p>Halo %s,</p><p>Seseorang mencoba masuk ke akun Bhinneka Anda menggunakan akun %s dengan alamat email yang sama.</p>" +
+		"<p>Jika itu Anda, hubungkan akun %s dengan membuka tautan berikut: <a href=\"%s\">%s</a>. Tautan berlaku selama %d jam.</p>" +
+		"<p>Jika bukan Anda, abaikan email ini. Akun Anda tetap aman.</p>"
+
 	//ErrorRefreshToken error message
 	ErrorRefreshToken = "refresh token is invalid"
 
@@ -112,6 +131,22 @@ const (
 	ErrorUserLKPPBelaNotFoundBahasa = "Akun anda belum terdaftar, silakan menghubungi pihak BELA LKPP untuk melanjutkan proses pendaftaran akun."
 	// NarwhalMFATokenKeyRedis specific for narwhal
 	NarwhalMFATokenKeyRedis = "mfa-otp-admin"
+	// PasskeyLoginKeyRedis redis key prefix of passwordless login challenge, format: `passkey-login-<challenge>`
+	PasskeyLoginKeyRedis = "passkey-login"
+	// PasskeyMFAKeyRedis redis key prefix of second factor challenge, format: `passkey-mfa-USR123-ABC-WEB`
+	PasskeyMFAKeyRedis = "passkey-mfa"
+	// MFAMethodTOTP second factor using authenticator app
+	MFAMethodTOTP = "totp"
+	// MFAMethodPasskey second factor using passkey
+	MFAMethodPasskey = "passkey"
+	// MFAMethodEmail second factor using one time code sent by email
+	MFAMethodEmail = "email"
+	// MFAMethodSMS second factor using one time code sent by sms
+	MFAMethodSMS = "sms"
+	// ErrorPasskeyLogin error message for failed passkey assertion
+	ErrorPasskeyLogin = "passkey is invalid or has expired, please try again"
+	// ErrorConnectorEmail error message when the identity provider gives no verified email for a new identity
+	ErrorConnectorEmail = "cannot login, the email of your account is not verified"
 	// ErrorIncorrectMemberTypeMicrosite specific for microsite
 	ErrorIncorrectMemberTypeMicrosite = "akun tidak terdaftar sebagai pengguna %s"
 
@@ -154,10 +189,44 @@ type RequestToken struct {
 	RequestFrom       string `json:"requestFrom,omitempty" form:"requestFrom"`
 	Version           string
 	NarwhalMFAEnabled bool
-	TransactionType   string `json:"transactionType"`
-	AccountID         string `json:"accountId,omitempty"`
-	LpseID            string `json:"lpseId"`
-	TokenBela         string `json:"tokenBela,omitempty"`
+	TransactionType   string    `json:"transactionType"`
+	AccountID         string    `json:"accountId,omitempty"`
+	LpseID            string    `json:"lpseId"`
+	TokenBela         string    `json:"tokenBela,omitempty"`
+	CodeVerifier      string    `json:"-"`
+	Scope             string    `json:"-"`
+	Nonce             string    `json:"-"`
+	AuthTime          time.Time `json:"-"`
+
+	RefreshTokenFamily     string `json:"-"`
+	RefreshTokenGeneration int    `json:"-"`
+
+	// webauthn assertion for the passkey grant or as second factor
+	Passkey *webauthn.AssertionResponse `json:"passkey,omitempty"`
+
+	// trust this device for the second factor, the token is given once after mfa verification
+	TrustDevice              bool   `json:"trustDevice,omitempty" form:"trustDevice"`
+	TrustedDeviceToken       string `json:"trustedDeviceToken,omitempty" form:"trustedDeviceToken"`
+	IssuedTrustedDeviceToken string `json:"-"`
+
+	// email or sms when the second factor is a one time code sent to the member
+	MFAMethod string `json:"mfaMethod,omitempty" form:"mfaMethod"`
+
+	// oidc connector of the oidc grant and the state given when the login with the provider started
+	Provider string `json:"provider,omitempty" form:"provider"`
+	State    string `json:"state,omitempty" form:"state"`
+
+	// captcha token of password logins, required after failed logins
+	CaptchaResponse string `json:"captchaResponse,omitempty" form:"captchaResponse"`
+
+	// wrong passwords before this login and whether the risk evaluator asks a second factor
+	LoginFailures int  `json:"-"`
+	RiskStepUp    bool `json:"-"`
+}
+
+// ConnectorAuthorizeRequest data structure for starting a login with an oidc connector
+type ConnectorAuthorizeRequest struct {
+	RedirectURI string `json:"redirectUri" form:"redirectUri"`
 }
 
 type Logout struct {
@@ -183,6 +252,8 @@ type AccessTokenResponse struct {
 	Mobile       string `jsonapi:"attr,mobile,omitempty" json:"mobile,omitempty"`
 	AccountID    string `json:"accountId,omitempty"`
 	CustomToken  string `jsonapi:"attr,customToken" json:"customToken,omitempty"`
+
+	TrustedDeviceToken string `json:"trustedDeviceToken,omitempty"`
 }
 
 // RefreshToken data structure
@@ -190,6 +261,27 @@ type RefreshToken struct {
 	ID              string
 	Token           string
 	RefreshTokenAge time.Duration
+	Owner           *RefreshTokenOwner
+}
+
+// RefreshTokenOwner data structure of the session a refresh token belongs to,
+// used for looking up refresh token without the previous access token
+type RefreshTokenOwner struct {
+	Key         string `json:"key"`
+	Subject     string `json:"sub"`
+	DeviceID    string `json:"did"`
+	DeviceLogin string `json:"dli"`
+	MemberType  string `json:"memberType"`
+	IsAdmin     bool   `json:"adm"`
+	Audience    string `json:"aud,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	IssuedAt    int64  `json:"iat,omitempty"`
+	ExpiresAt   int64  `json:"exp,omitempty"`
+
+	// lineage, every refresh token rotated from the same login shares one family
+	FamilyID   string `json:"fid,omitempty"`
+	Generation int    `json:"gen,omitempty"`
+	RotatedAt  int64  `json:"rat,omitempty"`
 }
 
 // LoginAttempt data structure
@@ -219,6 +311,11 @@ func (rt *RefreshToken) Match(refreshToken string) bool {
 	return rt.Token == refreshToken
 }
 
+// IsRotated function for checking the refresh token has already been exchanged for a newer one
+func (o *RefreshTokenOwner) IsRotated() bool {
+	return o != nil && o.RotatedAt != 0
+}
+
 // NewRefreshToken function for generating refresh token
 func NewRefreshToken(id, token string, age time.Duration) *RefreshToken {
 	return &RefreshToken{
@@ -247,35 +344,43 @@ type CheckEmailResponse struct {
 	Users []Users `json:"users"`
 }
 
+// MerchantRole data structure of the role a member holds on a merchant
+type MerchantRole struct {
+	MerchantID  string   `json:"merchantId"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
 // VerifyResponse data structure
 type VerifyResponse struct {
-	Adm          bool      `json:"adm"`
-	Aud          string    `json:"aud"`
-	Authorised   bool      `json:"authorised"`
-	Did          string    `json:"did"`
-	Dli          string    `json:"dli"`
-	Email        string    `json:"email"`
-	Exp          float64   `json:"exp"`
-	Iat          float64   `json:"iat"`
-	Iss          string    `json:"iss"`
-	Jti          string    `json:"jti"`
-	MemberType   string    `json:"memberType"`
-	Staff        bool      `json:"staff"`
-	Sub          string    `json:"sub"`
-	UserID       string    `json:"userId"`
-	FirstName    string    `json:"firstName"`
-	LastName     string    `json:"lastName"`
-	Token        string    `json:"token"`
-	RefreshToken string    `json:"refreshToken"`
-	ExpiredTime  time.Time `json:"expiredTime"`
-	Mobile       string    `json:"mobile"`
-	IsMerchant   bool      `json:"isMerchant"`
-	MerchantID   string    `json:"merchantId"`
-	MerchantIDs  []string  `json:"merchantIds"`
-	HasPassword  bool      `json:"hasPassword"`
-	SignUpFrom   string    `json:"signUpFrom"`
-	AccountID    string    `json:"accountId"`
-	CustomToken  string    `json:"customToken"`
+	Adm           bool           `json:"adm"`
+	Aud           string         `json:"aud"`
+	Authorised    bool           `json:"authorised"`
+	Did           string         `json:"did"`
+	Dli           string         `json:"dli"`
+	Email         string         `json:"email"`
+	Exp           float64        `json:"exp"`
+	Iat           float64        `json:"iat"`
+	Iss           string         `json:"iss"`
+	Jti           string         `json:"jti"`
+	MemberType    string         `json:"memberType"`
+	Staff         bool           `json:"staff"`
+	Sub           string         `json:"sub"`
+	UserID        string         `json:"userId"`
+	FirstName     string         `json:"firstName"`
+	LastName      string         `json:"lastName"`
+	Token         string         `json:"token"`
+	RefreshToken  string         `json:"refreshToken"`
+	ExpiredTime   time.Time      `json:"expiredTime"`
+	Mobile        string         `json:"mobile"`
+	IsMerchant    bool           `json:"isMerchant"`
+	MerchantID    string         `json:"merchantId"`
+	MerchantIDs   []string       `json:"merchantIds"`
+	MerchantRoles []MerchantRole `json:"merchantRoles"`
+	HasPassword   bool           `json:"hasPassword"`
+	SignUpFrom    string         `json:"signUpFrom"`
+	AccountID     string         `json:"accountId"`
+	CustomToken   string         `json:"customToken"`
 }
 
 type GoogleCaptcha struct {
@@ -311,8 +416,18 @@ type InactiveResponse struct {
 
 // MFAResponse data structure for response inactive status
 type MFAResponse struct {
-	MFARequired bool   `jsonapi:"attr,mfaRequired" json:"mfaRequired"`
-	MFAToken    string `jsonapi:"attr,mfaToken" json:"mfaToken"`
+	MFARequired    bool                     `jsonapi:"attr,mfaRequired" json:"mfaRequired"`
+	MFAToken       string                   `jsonapi:"attr,mfaToken" json:"mfaToken"`
+	Methods        []string                 `jsonapi:"attr,methods,omitempty" json:"methods,omitempty"`
+	PasskeyOptions *webauthn.RequestOptions `jsonapi:"attr,passkeyOptions,omitempty" json:"passkeyOptions,omitempty"`
+	DefaultMethod  string                   `jsonapi:"attr,defaultMethod,omitempty" json:"defaultMethod,omitempty"`
+	Destinations   map[string]string        `jsonapi:"attr,destinations,omitempty" json:"destinations,omitempty"`
+}
+
+// MFAOTPSent response of one time code sent for the second factor
+type MFAOTPSent struct {
+	Method      string `json:"method"`
+	Destination string `json:"destination"`
 }
 
 // ClientResponse response for client login
@@ -15,6 +15,13 @@ func TestRefreshTokenModel(t *testing.T) {
 		assert.True(t, refreshToken.Match("788888"))
 		assert.False(t, refreshToken.Match("788881"))
 	})
+
+	t.Run("Refresh Token Rotated", func(t *testing.T) {
+		var owner *RefreshTokenOwner
+		assert.False(t, owner.IsRotated())
+		assert.False(t, (&RefreshTokenOwner{FamilyID: "fam-1"}).IsRotated())
+		assert.True(t, (&RefreshTokenOwner{FamilyID: "fam-1", RotatedAt: 1600000000}).IsRotated())
+	})
 }
 
 func TestValidBhinnekaEmail(t *testing.T) {
@@ -28,6 +28,13 @@ const (
 	invited  = "INVITED"
 )
 
+const (
+	// ClientTypeConfidential client able to keep its secret, e.g. a backend service
+	ClientTypeConfidential = "CONFIDENTIAL"
+	// ClientTypePublic client unable to keep a secret, e.g. a mobile or single page app
+	ClientTypePublic = "PUBLIC"
+)
+
 // String function for converting user status
 func (s Status) String() string {
 	switch s {
@@ -70,6 +77,9 @@ type ClientApp struct {
 	LastModified time.Time `json:"lastModified"`
 	Version      int       `json:"version"`
 	Secret       string    `json:"Secret"`
+	RedirectURIs []string  `json:"redirectUris"`
+	Scopes       []string  `json:"scopes"`
+	Type         string    `json:"type"`
 }
 
 // NewClientApp function
@@ -83,6 +93,7 @@ func NewClientApp(name string) *ClientApp {
 		Created:      time.Now(),
 		LastModified: time.Now(),
 		Version:      0,
+		Type:         ClientTypeConfidential,
 	}
 }
 
@@ -95,3 +106,38 @@ func (c *ClientApp) Authenticate(secret string) bool {
 func (c *ClientApp) IsActive() bool {
 	return c.Status == Active
 }
+
+// IsPublic check if client cannot keep a secret and authenticates with PKCE only
+func (c *ClientApp) IsPublic() bool {
+	return c.Type == ClientTypePublic
+}
+
+// AllowRedirectURI check if redirect uri is registered for the client, exact match only
+func (c *ClientApp) AllowRedirectURI(uri string) bool {
+	if uri == "" {
+		return false
+	}
+	for _, registered := range c.RedirectURIs {
+		if registered == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantScope function for resolving the scope of a client credentials token,
+// an empty request is granted every allowed scope
+func (c *ClientApp) GrantScope(requested string) (string, bool) {
+	if len(c.Scopes) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(requested) == "" {
+		return strings.Join(c.Scopes, " "), true
+	}
+
+	scopes := ParseScope(requested)
+	if FilterScope(requested, c.Scopes) != strings.Join(scopes, " ") {
+		return "", false
+	}
+	return strings.Join(scopes, " "), true
+}
@@ -44,3 +44,39 @@ func TestAuthenticate(t *testing.T) {
 	randString := clientApp.Authenticate("ABC")
 	assert.EqualValues(t, false, randString)
 }
+
+func TestAllowRedirectURI(t *testing.T) {
+	clientApp := NewClientApp("partner")
+	clientApp.RedirectURIs = []string{"https://partner.example.com/callback"}
+	assert.True(t, clientApp.AllowRedirectURI("https://partner.example.com/callback"))
+	assert.False(t, clientApp.AllowRedirectURI("https://partner.example.com/callback/evil"))
+	assert.False(t, clientApp.AllowRedirectURI(""))
+}
+
+func TestGrantScope(t *testing.T) {
+	clientApp := NewClientApp("order-service")
+
+	t.Run("client without scopes", func(t *testing.T) {
+		_, ok := clientApp.GrantScope("")
+		assert.False(t, ok)
+	})
+
+	clientApp.Scopes = []string{"member:read", "merchant:read"}
+
+	t.Run("empty request grants every allowed scope", func(t *testing.T) {
+		scope, ok := clientApp.GrantScope("")
+		assert.True(t, ok)
+		assert.Equal(t, "member:read merchant:read", scope)
+	})
+
+	t.Run("subset of allowed scopes", func(t *testing.T) {
+		scope, ok := clientApp.GrantScope("merchant:read")
+		assert.True(t, ok)
+		assert.Equal(t, "merchant:read", scope)
+	})
+
+	t.Run("scope outside allowed list", func(t *testing.T) {
+		_, ok := clientApp.GrantScope("member:read member:write")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// LockoutKeyRedis redis key prefix of login failures and lock state, format: `LOCKOUT:john@bhinneka.com`
+	LockoutKeyRedis = "LOCKOUT"
+
+	// DefaultLockoutAttemptWindow how long failed logins are counted when LOGIN_ATTEMPT_AGE is not valid
+	DefaultLockoutAttemptWindow = 24 * time.Hour
+
+	// LockoutPolicyPersonal policy of personal members
+	LockoutPolicyPersonal = "personal"
+	// LockoutPolicyCorporate policy of corporate contacts
+	LockoutPolicyCorporate = "corporate"
+	// LockoutPolicyMicrosite policy of microsite contacts
+	LockoutPolicyMicrosite = "microsite"
+
+	// ErrorAccountLockedBahasa error message for locked account
+	ErrorAccountLockedBahasa = "Akun Anda dikunci sementara karena terlalu banyak percobaan login. Silakan coba kembali setelah %d menit"
+	// ErrorLoginDelayBahasa error message while the next attempt has to wait
+	ErrorLoginDelayBahasa = "Terlalu banyak percobaan login. Silakan coba kembali dalam %d detik"
+	// ErrorLockoutNotFound error message when the email has no failed login
+	ErrorLockoutNotFound = "lockout not found"
+	// ErrorLockoutPolicy error message for invalid LOCKOUT_POLICY
+	ErrorLockoutPolicy = "invalid lockout policy %s: %s"
+)
+
+// LockoutPolicy thresholds of failed logins, every delay and lock doubles until its cap
+type LockoutPolicy struct {
+	// DelayAfter failures before every further attempt has to wait, zero disables the delay
+	DelayAfter int
+	// Delay wait after the DelayAfter-th failure
+	Delay time.Duration
+	// MaxAttempts failures before the account is locked
+	MaxAttempts int
+	// LockDuration of the first lock
+	LockDuration time.Duration
+	// MaxLockDuration cap of a repeated lock
+	MaxLockDuration time.Duration
+	// ResetAfter how long previous locks are remembered
+	ResetAfter time.Duration
+}
+
+// LockoutPolicies policy of each member type
+type LockoutPolicies map[string]LockoutPolicy
+
+// DefaultLockoutPolicies policies used when LOCKOUT_POLICY is not set,
+// corporate and microsite accounts buy on credit so they are locked sooner
+var DefaultLockoutPolicies = LockoutPolicies{
+	LockoutPolicyPersonal: {
+		DelayAfter:      5,
+		Delay:           2 * time.Second,
+		MaxAttempts:     10,
+		LockDuration:    5 * time.Minute,
+		MaxLockDuration: 24 * time.Hour,
+		ResetAfter:      24 * time.Hour,
+	},
+	LockoutPolicyCorporate: {
+		DelayAfter:      3,
+		Delay:           5 * time.Second,
+		MaxAttempts:     5,
+		LockDuration:    15 * time.Minute,
+		MaxLockDuration: 24 * time.Hour,
+		ResetAfter:      24 * time.Hour,
+	},
+	LockoutPolicyMicrosite: {
+		DelayAfter:      3,
+		Delay:           5 * time.Second,
+		MaxAttempts:     5,
+		LockDuration:    15 * time.Minute,
+		MaxLockDuration: 24 * time.Hour,
+		ResetAfter:      24 * time.Hour,
+	},
+}
+
+// Lockout data structure of failed logins of an email, stored as json in redis
+type Lockout struct {
+	Email       string    `json:"email"`
+	MemberType  string    `json:"memberType"`
+	Failures    int       `json:"failures"`
+	Locks       int       `json:"locks"`
+	LastFailure time.Time `json:"lastFailure"`
+	RetryAfter  time.Time `json:"retryAfter"`
+	LockedUntil time.Time `json:"lockedUntil"`
+	// Locked is filled when the record is shown to an admin
+	Locked bool `json:"locked"`
+}
+
+// lockoutPolicyConfig json form of LockoutPolicy, durations use time.ParseDuration format
+type lockoutPolicyConfig struct {
+	DelayAfter      int    `json:"delayAfter"`
+	Delay           string `json:"delay"`
+	MaxAttempts     int    `json:"maxAttempts"`
+	LockDuration    string `json:"lockDuration"`
+	MaxLockDuration string `json:"maxLockDuration"`
+	ResetAfter      string `json:"resetAfter"`
+}
+
+// ParseLockoutPolicies function for reading LOCKOUT_POLICY, e.g. `{"corporate":{"maxAttempts":3,"lockDuration":"30m"}}`,
+// fields left out keep the default of the member type
+func ParseLockoutPolicies(raw string) (LockoutPolicies, error) {
+	policies := LockoutPolicies{}
+	for name, policy := range DefaultLockoutPolicies {
+		policies[name] = policy
+	}
+	if strings.TrimSpace(raw) == "" {
+		return policies, nil
+	}
+
+	configs := map[string]lockoutPolicyConfig{}
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf(ErrorLockoutPolicy, "json", err.Error())
+	}
+
+	for name, config := range configs {
+		policy, ok := policies[name]
+		if !ok {
+			return nil, fmt.Errorf(ErrorLockoutPolicy, name, "unknown member type")
+		}
+		if config.DelayAfter > 0 {
+			policy.DelayAfter = config.DelayAfter
+		}
+		if config.MaxAttempts > 0 {
+			policy.MaxAttempts = config.MaxAttempts
+		}
+
+		durations := []struct {
+			value  string
+			target *time.Duration
+		}{
+			{config.Delay, &policy.Delay},
+			{config.LockDuration, &policy.LockDuration},
+			{config.MaxLockDuration, &policy.MaxLockDuration},
+			{config.ResetAfter, &policy.ResetAfter},
+		}
+		for _, duration := range durations {
+			if duration.value == "" {
+				continue
+			}
+			parsed, err := time.ParseDuration(duration.value)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf(ErrorLockoutPolicy, name, "invalid duration "+duration.value)
+			}
+			*duration.target = parsed
+		}
+		policies[name] = policy
+	}
+
+	return policies, nil
+}
+
+// Policy function for getting a policy by its name, unknown names use the personal policy
+func (p LockoutPolicies) Policy(name string) LockoutPolicy {
+	if len(p) == 0 {
+		p = DefaultLockoutPolicies
+	}
+	if policy, ok := p[name]; ok {
+		return policy
+	}
+	return p[LockoutPolicyPersonal]
+}
+
+// GetLockoutKey redis key format: `LOCKOUT:john@bhinneka.com`
+func GetLockoutKey(email string) string {
+	return LockoutKeyRedis + ":" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// Wait function for getting how long the next login has to wait, zero when it may try now
+func (l *Lockout) Wait(now time.Time) time.Duration {
+	until := l.RetryAfter
+	if l.LockedUntil.After(until) {
+		until = l.LockedUntil
+	}
+	if !until.After(now) {
+		return 0
+	}
+	return until.Sub(now)
+}
+
+// IsLocked function for checking whether the account is locked at the given time
+func (l *Lockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil.After(now)
+}
+
+// RegisterFailure function for counting a failed login, failures older than the attempt window are forgotten.
+// Returns true when this failure locks the account
+func (l *Lockout) RegisterFailure(policy LockoutPolicy, attemptWindow time.Duration, now time.Time) bool {
+	if !l.LastFailure.IsZero() && now.Sub(l.LastFailure) > attemptWindow {
+		l.Failures = 0
+	}
+	if !l.LastFailure.IsZero() && now.Sub(l.LastFailure) > policy.ResetAfter {
+		l.Locks = 0
+	}
+	l.Failures++
+	l.LastFailure = now
+
+	if l.Failures >= policy.MaxAttempts {
+		l.Locks++
+		l.Failures = 0
+		l.RetryAfter = time.Time{}
+		l.LockedUntil = now.Add(backoff(policy.LockDuration, l.Locks-1, policy.MaxLockDuration))
+		return true
+	}
+
+	if policy.DelayAfter > 0 && l.Failures >= policy.DelayAfter {
+		l.RetryAfter = now.Add(backoff(policy.Delay, l.Failures-policy.DelayAfter, policy.LockDuration))
+	}
+	return false
+}
+
+// Age function for getting how long the record has to be kept in redis
+func (l *Lockout) Age(policy LockoutPolicy, attemptWindow time.Duration, now time.Time) time.Duration {
+	age := attemptWindow
+	if l.Locks > 0 && policy.ResetAfter > age {
+		age = policy.ResetAfter
+	}
+	if wait := l.Wait(now); wait > age {
+		age = wait
+	}
+	return age
+}
+
+// backoff doubles base for every step until max
+func backoff(base time.Duration, steps int, max time.Duration) time.Duration {
+	duration := base
+	for i := 0; i < steps && duration < max; i++ {
+		duration *= 2
+	}
+	if duration > max {
+		return max
+	}
+	return duration
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLockoutPolicies(t *testing.T) {
+	t.Run("empty keeps defaults", func(t *testing.T) {
+		policies, err := ParseLockoutPolicies("")
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultLockoutPolicies, policies)
+	})
+
+	t.Run("override one member type", func(t *testing.T) {
+		policies, err := ParseLockoutPolicies(`{"corporate":{"maxAttempts":3,"lockDuration":"30m"}}`)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, policies.Policy(LockoutPolicyCorporate).MaxAttempts)
+		assert.Equal(t, 30*time.Minute, policies.Policy(LockoutPolicyCorporate).LockDuration)
+		assert.Equal(t, DefaultLockoutPolicies[LockoutPolicyCorporate].Delay, policies.Policy(LockoutPolicyCorporate).Delay)
+		assert.Equal(t, DefaultLockoutPolicies[LockoutPolicyPersonal], policies.Policy(LockoutPolicyPersonal))
+		assert.Equal(t, 5, DefaultLockoutPolicies[LockoutPolicyCorporate].MaxAttempts)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, raw := range []string{`{`, `{"staff":{}}`, `{"personal":{"delay":"soon"}}`, `{"personal":{"delay":"-1s"}}`} {
+			_, err := ParseLockoutPolicies(raw)
+			assert.Error(t, err, raw)
+		}
+	})
+
+	t.Run("unknown policy name is personal", func(t *testing.T) {
+		var policies LockoutPolicies
+		assert.Equal(t, DefaultLockoutPolicies[LockoutPolicyPersonal], policies.Policy("staff"))
+	})
+}
+
+func TestLockoutRegisterFailure(t *testing.T) {
+	policy := LockoutPolicy{
+		DelayAfter:      2,
+		Delay:           time.Second,
+		MaxAttempts:     4,
+		LockDuration:    time.Minute,
+		MaxLockDuration: 3 * time.Minute,
+		ResetAfter:      time.Hour,
+	}
+	window := 10 * time.Minute
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("delay then lock", func(t *testing.T) {
+		lockout := Lockout{}
+		assert.False(t, lockout.RegisterFailure(policy, window, now))
+		assert.Equal(t, time.Duration(0), lockout.Wait(now))
+
+		assert.False(t, lockout.RegisterFailure(policy, window, now))
+		assert.Equal(t, time.Second, lockout.Wait(now))
+
+		assert.False(t, lockout.RegisterFailure(policy, window, now))
+		assert.Equal(t, 2*time.Second, lockout.Wait(now))
+		assert.False(t, lockout.IsLocked(now))
+
+		assert.True(t, lockout.RegisterFailure(policy, window, now))
+		assert.True(t, lockout.IsLocked(now))
+		assert.Equal(t, time.Minute, lockout.Wait(now))
+		assert.Equal(t, 0, lockout.Failures)
+		assert.Equal(t, 1, lockout.Locks)
+		assert.Equal(t, time.Hour, lockout.Age(policy, window, now))
+	})
+
+	t.Run("repeated locks double until max", func(t *testing.T) {
+		lockout := Lockout{}
+		want := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute}
+		for _, duration := range want {
+			for i := 0; i < policy.MaxAttempts; i++ {
+				lockout.RegisterFailure(policy, window, now)
+			}
+			assert.Equal(t, duration, lockout.Wait(now))
+		}
+	})
+
+	t.Run("old failures are forgotten", func(t *testing.T) {
+		lockout := Lockout{Failures: 3, Locks: 2, LastFailure: now.Add(-2 * time.Hour)}
+		assert.False(t, lockout.RegisterFailure(policy, window, now))
+		assert.Equal(t, 1, lockout.Failures)
+		assert.Equal(t, 0, lockout.Locks)
+		assert.Equal(t, window, lockout.Age(policy, window, now))
+	})
+}
+
+func TestGetLockoutKey(t *testing.T) {
+	assert.Equal(t, "LOCKOUT:john@bhinneka.com", GetLockoutKey(" John@Bhinneka.com"))
+}
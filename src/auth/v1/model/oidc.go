@@ -0,0 +1,290 @@
+package model
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	// AuthTypeAuthorizationCode for exchanging an oauth2 authorization code
+	AuthTypeAuthorizationCode = "authorization_code"
+	// OAuthGrantRefreshToken standard oauth2 name of the refresh token grant
+	OAuthGrantRefreshToken = "refresh_token"
+	// AuthTypeClientCredentials for service to service tokens of a client app
+	AuthTypeClientCredentials = "client_credentials"
+
+	// ResponseTypeCode the only response type supported by /authorize
+	ResponseTypeCode = "code"
+	// CodeChallengeMethodS256 PKCE challenge method
+	CodeChallengeMethodS256 = "S256"
+	// TokenTypeBearer token type returned by /token
+	TokenTypeBearer = "Bearer"
+	// OIDCDeviceLogin device login claim used by tokens issued to oauth2 clients
+	OIDCDeviceLogin = "WEB"
+	// OIDCDevicePrefix prefix of device id claim used by tokens issued to oauth2 clients, every login gets its own device id
+	OIDCDevicePrefix = "OIDC-"
+	// ClientCredentialsDeviceLogin device login claim used by client credentials tokens
+	ClientCredentialsDeviceLogin = "SERVICE"
+	// ClientCredentialsDevicePrefix prefix of device id claim used by client credentials tokens
+	ClientCredentialsDevicePrefix = "CLIENT-"
+
+	// TokenTypeHintAccessToken rfc 7009 token type hint
+	TokenTypeHintAccessToken = "access_token"
+	// TokenTypeHintRefreshToken rfc 7009 token type hint
+	TokenTypeHintRefreshToken = "refresh_token"
+
+	// ScopeOpenID scope for requesting an id token
+	ScopeOpenID = "openid"
+	// ScopeProfile scope for name claims
+	ScopeProfile = "profile"
+	// ScopeEmail scope for email claims
+	ScopeEmail = "email"
+	// ScopePhone scope for phone claims
+	ScopePhone = "phone"
+
+	// ScopePhoneAreaRead scope of service tokens reading phone areas
+	ScopePhoneAreaRead = "phone-area:read"
+	// ScopeSessionRead scope of service tokens reading session info
+	ScopeSessionRead = "session:read"
+
+	// OAuthErrorInvalidRequest rfc 6749 error code
+	OAuthErrorInvalidRequest = "invalid_request"
+	// OAuthErrorInvalidClient rfc 6749 error code
+	OAuthErrorInvalidClient = "invalid_client"
+	// OAuthErrorInvalidGrant rfc 6749 error code
+	OAuthErrorInvalidGrant = "invalid_grant"
+	// OAuthErrorUnauthorizedClient rfc 6749 error code
+	OAuthErrorUnauthorizedClient = "unauthorized_client"
+	// OAuthErrorUnsupportedGrantType rfc 6749 error code
+	OAuthErrorUnsupportedGrantType = "unsupported_grant_type"
+	// OAuthErrorUnsupportedResponseType rfc 6749 error code
+	OAuthErrorUnsupportedResponseType = "unsupported_response_type"
+	// OAuthErrorInvalidScope rfc 6749 error code
+	OAuthErrorInvalidScope = "invalid_scope"
+	// OAuthErrorLoginRequired openid connect error code
+	OAuthErrorLoginRequired = "login_required"
+	// OAuthErrorInvalidToken rfc 6750 error code
+	OAuthErrorInvalidToken = "invalid_token"
+	// OAuthErrorServerError rfc 6749 error code
+	OAuthErrorServerError = "server_error"
+
+	// JWKSPath path of the public signing keys, relative to the issuer
+	JWKSPath = "/.well-known/jwks.json"
+
+	// AuthorizationCodeKeyRedis redis key prefix of authorization codes
+	AuthorizationCodeKeyRedis = "OIDC-CODE"
+	// AuthorizationCodeAge lifetime of an authorization code
+	AuthorizationCodeAge = 5 * time.Minute
+	// ClientCredentialsTokenAge lifetime of a client credentials access token
+	ClientCredentialsTokenAge = 15 * time.Minute
+)
+
+// IsClientBoundDevice function for checking the device id claim of a token issued to an oauth2 client
+func IsClientBoundDevice(deviceID string) bool {
+	return strings.HasPrefix(deviceID, OIDCDevicePrefix) || strings.HasPrefix(deviceID, ClientCredentialsDevicePrefix)
+}
+
+// SupportedScopes scopes advertised by the discovery document
+var SupportedScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail, ScopePhone}
+
+// OAuthError rfc 6749 section 5.2 error body
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	HTTPStatus  int    `json:"-"`
+}
+
+// Error function to satisfy error interface
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// NewOAuthError function for initializing oauth2 error
+func NewOAuthError(code, description string, httpStatus int) *OAuthError {
+	return &OAuthError{Code: code, Description: description, HTTPStatus: httpStatus}
+}
+
+// AuthorizeRequest data structure of /authorize parameters
+type AuthorizeRequest struct {
+	ResponseType        string `json:"response_type" form:"response_type" query:"response_type"`
+	ClientID            string `json:"client_id" form:"client_id" query:"client_id"`
+	RedirectURI         string `json:"redirect_uri" form:"redirect_uri" query:"redirect_uri"`
+	Scope               string `json:"scope" form:"scope" query:"scope"`
+	State               string `json:"state" form:"state" query:"state"`
+	Nonce               string `json:"nonce" form:"nonce" query:"nonce"`
+	CodeChallenge       string `json:"code_challenge" form:"code_challenge" query:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method" form:"code_challenge_method" query:"code_challenge_method"`
+	AccessToken         string `json:"-"`
+}
+
+// AuthorizeResponse data structure of a successful /authorize call
+type AuthorizeResponse struct {
+	RedirectURI string `json:"redirectUri"`
+	Code        string `json:"code,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
+// AuthorizationCode data structure stored in redis until the code is exchanged
+type AuthorizationCode struct {
+	Code                string    `json:"-"`
+	ClientID            string    `json:"clientId"`
+	RedirectURI         string    `json:"redirectUri"`
+	Scope               string    `json:"scope"`
+	Nonce               string    `json:"nonce,omitempty"`
+	CodeChallenge       string    `json:"codeChallenge"`
+	CodeChallengeMethod string    `json:"codeChallengeMethod"`
+	Subject             string    `json:"subject"`
+	AuthTime            time.Time `json:"authTime"`
+}
+
+// OIDCTokenRequest data structure of /token parameters
+type OIDCTokenRequest struct {
+	GrantType    string `json:"grant_type" form:"grant_type"`
+	Code         string `json:"code" form:"code"`
+	RedirectURI  string `json:"redirect_uri" form:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier" form:"code_verifier"`
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
+	Scope        string `json:"scope" form:"scope"`
+	ClientID     string `json:"client_id" form:"client_id"`
+	ClientSecret string `json:"client_secret" form:"client_secret"`
+	IP           string `json:"-"`
+	UserAgent    string `json:"-"`
+}
+
+// OIDCTokenResponse rfc 6749 section 5.1 token response
+type OIDCTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// TokenHintRequest data structure of /introspect and /revoke parameters
+type TokenHintRequest struct {
+	Token         string `json:"token" form:"token"`
+	TokenTypeHint string `json:"token_type_hint" form:"token_type_hint"`
+	ClientID      string `json:"client_id" form:"client_id"`
+	ClientSecret  string `json:"client_secret" form:"client_secret"`
+}
+
+// IntrospectionResponse rfc 7662 section 2.2 introspection response, only `active` is set for inactive tokens
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	JTI       string `json:"jti,omitempty"`
+}
+
+// UserInfo openid connect standard claims returned by /userinfo
+type UserInfo struct {
+	Subject       string `json:"sub"`
+	Name          string `json:"name,omitempty"`
+	GivenName     string `json:"given_name,omitempty"`
+	FamilyName    string `json:"family_name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	PhoneNumber   string `json:"phone_number,omitempty"`
+}
+
+// OpenIDConfiguration openid connect discovery document
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// NewOpenIDConfiguration function for building discovery document of an issuer
+func NewOpenIDConfiguration(issuer string) OpenIDConfiguration {
+	issuer = strings.TrimSuffix(issuer, "/")
+	return OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/authorize",
+		TokenEndpoint:                     issuer + "/token",
+		UserInfoEndpoint:                  issuer + "/userinfo",
+		JWKSURI:                           issuer + JWKSPath,
+		IntrospectionEndpoint:             issuer + "/introspect",
+		RevocationEndpoint:                issuer + "/revoke",
+		ResponseTypesSupported:            []string{ResponseTypeCode},
+		GrantTypesSupported:               []string{AuthTypeAuthorizationCode, OAuthGrantRefreshToken, AuthTypeClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		ScopesSupported:                   SupportedScopes,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{CodeChallengeMethodS256},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "at_hash",
+			"name", "given_name", "family_name", "email", "email_verified", "phone_number",
+		},
+	}
+}
+
+// VerifyCodeChallenge function for checking PKCE code verifier against the stored challenge (RFC 7636)
+func VerifyCodeChallenge(verifier, challenge, method string) bool {
+	// RFC 7636 section 4.1: verifier length is between 43 and 128 characters
+	if len(verifier) < 43 || len(verifier) > 128 || challenge == "" {
+		return false
+	}
+	if method != CodeChallengeMethodS256 {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// ParseScope function for splitting space delimited scope parameter
+func ParseScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// HasScope function for checking whether a space delimited scope contains the given value
+func HasScope(scope, value string) bool {
+	for _, s := range ParseScope(scope) {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterScope function for dropping scopes outside of allowed list, keeping the requested order
+func FilterScope(scope string, allowed []string) string {
+	var result []string
+	for _, s := range ParseScope(scope) {
+		for _, a := range allowed {
+			if s == a {
+				result = append(result, s)
+				break
+			}
+		}
+	}
+	return strings.Join(result, " ")
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	// example from RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	tests := []struct {
+		name      string
+		verifier  string
+		challenge string
+		method    string
+		want      bool
+	}{
+		{name: "Case 1: Success S256", verifier: verifier, challenge: challenge, method: CodeChallengeMethodS256, want: true},
+		{name: "Case 2: Error wrong verifier", verifier: verifier + "x", challenge: challenge, method: CodeChallengeMethodS256, want: false},
+		{name: "Case 3: Error plain method", verifier: verifier, challenge: verifier, method: "plain", want: false},
+		{name: "Case 4: Error short verifier", verifier: "short", challenge: challenge, method: CodeChallengeMethodS256, want: false},
+		{name: "Case 5: Error empty challenge", verifier: verifier, challenge: "", method: CodeChallengeMethodS256, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, VerifyCodeChallenge(tt.verifier, tt.challenge, tt.method))
+		})
+	}
+}
+
+func TestScope(t *testing.T) {
+	assert.True(t, HasScope("openid email", ScopeEmail))
+	assert.False(t, HasScope("openid email", ScopeProfile))
+	assert.Equal(t, "openid phone", FilterScope("openid  admin phone", SupportedScopes))
+	assert.Equal(t, "", FilterScope("admin", SupportedScopes))
+}
+
+func TestOAuthError(t *testing.T) {
+	err := NewOAuthError(OAuthErrorInvalidGrant, "code expired", http.StatusBadRequest)
+	assert.Equal(t, "invalid_grant: code expired", err.Error())
+	assert.Equal(t, OAuthErrorInvalidClient, NewOAuthError(OAuthErrorInvalidClient, "", http.StatusUnauthorized).Error())
+}
+
+func TestNewOpenIDConfiguration(t *testing.T) {
+	conf := NewOpenIDConfiguration("https://accounts.bhinneka.com/")
+	assert.Equal(t, "https://accounts.bhinneka.com", conf.Issuer)
+	assert.Equal(t, "https://accounts.bhinneka.com/token", conf.TokenEndpoint)
+	assert.Equal(t, "https://accounts.bhinneka.com/.well-known/jwks.json", conf.JWKSURI)
+	assert.Equal(t, "https://accounts.bhinneka.com/introspect", conf.IntrospectionEndpoint)
+	assert.Contains(t, conf.CodeChallengeMethodsSupported, CodeChallengeMethodS256)
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// PasswordlessModeLink the member gets a link that logs in when opened
+	PasswordlessModeLink = "link"
+	// PasswordlessModeCode the member gets a code typed on the login page
+	PasswordlessModeCode = "code"
+
+	// PasswordlessCodeKeyRedis redis key prefix of the hashed login code, format: `passwordless-code-<email>`
+	PasswordlessCodeKeyRedis = "passwordless-code"
+	// PasswordlessLinkKeyRedis redis key prefix of the hashed login link token, format: `passwordless-link-<hash>`
+	PasswordlessLinkKeyRedis = "passwordless-link"
+	// PasswordlessCodeLength digits of the login code
+	PasswordlessCodeLength = 6
+	// PasswordlessAge how long a login code or link can be used
+	PasswordlessAge = 10 * time.Minute
+	// PasswordlessPath page of the web that redeems the login link
+	PasswordlessPath = "/login/passwordless"
+
+	// ErrorPasswordlessMode error message for an unknown passwordless mode
+	ErrorPasswordlessMode = "mode must be link or code"
+	// ErrorPasswordlessMemberType error message for passwordless login of a member type other than personal
+	ErrorPasswordlessMemberType = "passwordless login is only for personal account"
+	// ErrorPasswordlessBahasa error message for a wrong, used or expired login code or link
+	ErrorPasswordlessBahasa = "Kode atau tautan masuk tidak valid atau sudah kedaluwarsa, silakan minta yang baru"
+	// SuccessPasswordlessSend message for a passwordless request, it is the same whether the email is registered or not
+	SuccessPasswordlessSend = "Jika email terdaftar, kami telah mengirimkan cara masuk ke email tersebut"
+
+	// SubjectPasswordless email subject of a login code or link
+	SubjectPasswordless = "Masuk ke Akun Bhinneka Anda"
+	// ContentPasswordlessCode email content of a login code: name, code and minutes
+	ContentPasswordlessCode = "<p>Halo %s,</p><p>Gunakan kode berikut untuk masuk ke akun Bhinneka Anda: <b>%s</b>. Kode berlaku selama %d menit dan hanya dapat digunakan sekali.</p>" +
+		"<p>Jangan berikan kode ini kepada siapa pun. Jika Anda tidak meminta kode ini, abaikan email ini.</p>"
+	// ContentPasswordlessLink email content of a login link: name, url, url and minutes
+	ContentPasswordlessLink = "<p>Halo %s,</p><p>Buka tautan berikut untuk masuk ke akun Bhinneka Anda: <a href=\"%s\">%s</a>. Tautan berlaku selama %d menit dan hanya dapat digunakan sekali.</p>" +
+		"<p>Jika Anda tidak meminta tautan ini, abaikan email ini.</p>"
+)
+
+// PasswordlessRequest data structure of a request for a login code or link
+type PasswordlessRequest struct {
+	Email string `json:"email" form:"email"`
+	Mode  string `json:"mode" form:"mode"`
+}
+
+// PasswordlessSent data structure of a passwordless request response
+type PasswordlessSent struct {
+	Mode        string `json:"mode"`
+	Destination string `json:"destination"`
+	ExpiresIn   int    `json:"expiresIn"`
+}
+
+// GetPasswordlessCodeKey function for getting redis key of the login code of an email
+func GetPasswordlessCodeKey(email string) string {
+	return strings.Join([]string{PasswordlessCodeKeyRedis, strings.ToLower(email)}, "-")
+}
+
+// GetPasswordlessLinkKey function for getting redis key of a login link from the hash of its token
+func GetPasswordlessLinkKey(tokenHash string) string {
+	return strings.Join([]string{PasswordlessLinkKeyRedis, tokenHash}, "-")
+}
@@ -0,0 +1,34 @@
+package model
+
+const (
+	// PermissionMemberManage permission for member CMS endpoints
+	PermissionMemberManage = "member:manage"
+	// PermissionMemberImport permission for importing members
+	PermissionMemberImport = "member:import"
+	// PermissionMerchantManage permission for merchant CMS endpoints
+	PermissionMerchantManage = "merchant:manage"
+	// PermissionMerchantReject permission for rejecting merchant registration and upgrade
+	PermissionMerchantReject = "merchant:reject"
+	// PermissionCorporateContact permission for corporate contact CMS endpoints
+	PermissionCorporateContact = "corporate:contact"
+	// PermissionCorporateSAML permission for managing single sign on of corporate accounts
+	PermissionCorporateSAML = "corporate:saml"
+	// PermissionDocumentTypeManage permission for managing document types
+	PermissionDocumentTypeManage = "document-type:manage"
+	// PermissionApplicationManage permission for managing applications
+	PermissionApplicationManage = "application:manage"
+	// PermissionLogRead permission for reading audit trail
+	PermissionLogRead = "log:read"
+	// PermissionAuthImpersonate permission for issuing access token on behalf of a member
+	PermissionAuthImpersonate = "auth:impersonate"
+	// PermissionAuthLockout permission for viewing and clearing failed login lockouts
+	PermissionAuthLockout = "auth:lockout"
+)
+
+// Role data structure, a named set of permissions assigned to members
+type Role struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
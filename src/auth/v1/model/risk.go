@@ -0,0 +1,279 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/user-service/src/shared/geoip"
+)
+
+const (
+	// RiskSignalNewDevice the device id is not in the recent logins
+	RiskSignalNewDevice = "newDevice"
+	// RiskSignalNewIPRange the ip range, /24 for ipv4 and /48 for ipv6, is not in the recent logins
+	RiskSignalNewIPRange = "newIpRange"
+	// RiskSignalImpossibleTravel the distance from the previous login can not be travelled in the time between them
+	RiskSignalImpossibleTravel = "impossibleTravel"
+	// RiskSignalLoginFailures many wrong passwords before the successful one
+	RiskSignalLoginFailures = "loginFailures"
+
+	// RiskActionAllow the login goes on silently
+	RiskActionAllow = "allow"
+	// RiskActionNotify the login goes on and the member is told by email
+	RiskActionNotify = "notify"
+	// RiskActionStepUp the login has to pass a second factor
+	RiskActionStepUp = "stepUp"
+	// RiskActionBlock the login is refused
+	RiskActionBlock = "block"
+
+	// RiskStepUpKeyRedis redis key prefix of the email a step up code is sent to, format: `mfa-risk-USR123-ABC-WEB`
+	RiskStepUpKeyRedis = "mfa-risk"
+
+	// RiskMinTravelDistance distance in kilometers below which geoip accuracy is not good enough to tell travel
+	RiskMinTravelDistance = 100.0
+
+	// ErrorLoginRiskBlockedBahasa error message for a login refused by the risk evaluator
+	ErrorLoginRiskBlockedBahasa = "Login ditolak karena terdeteksi aktivitas yang tidak biasa. Silakan periksa email Anda atau hubungi layanan pelanggan Bhinneka"
+	// ErrorRiskPolicy error message for invalid LOGIN_RISK_POLICY
+	ErrorRiskPolicy = "invalid login risk policy: %s"
+
+	// SubjectRiskLogin email subject of a suspicious login
+	SubjectRiskLogin = "Login Baru ke Akun Bhinneka Anda"
+	// ContentRiskLogin email content of a suspicious login: name, time, device, ip, location and action
+	ContentRiskLogin = "<p>Halo %s,</p><p>Kami mendeteksi login ke akun Anda dari perangkat atau lokasi yang tidak biasa.</p>" +
+		"<p>Waktu: %s<br>Perangkat: %s<br>Alamat IP: %s<br>Lokasi: %s</p><p>%s</p>" +
+		"<p>Jika ini bukan Anda, segera ubah kata sandi dan akhiri sesi lain di halaman keamanan akun.</p>"
+)
+
+// RiskNotificationActions sentence of the email for every action
+var RiskNotificationActions = map[string]string{
+	RiskActionNotify: "Jika ini Anda, abaikan email ini.",
+	RiskActionStepUp: "Login ini memerlukan verifikasi tambahan sebelum dapat dilanjutkan.",
+	RiskActionBlock:  "Login ini telah kami tolak untuk melindungi akun Anda.",
+}
+
+// RiskPolicy scores of each signal and the total score from which each action is taken, a zero threshold disables its action
+type RiskPolicy struct {
+	Scores   map[string]int
+	NotifyAt int
+	StepUpAt int
+	BlockAt  int
+	// History number of recent logins the new one is compared to
+	History int
+	// MaxSpeed fastest believable travel between two logins in km/h
+	MaxSpeed float64
+	// Failures wrong passwords before the successful one that count as a signal
+	Failures int
+}
+
+// DefaultRiskPolicy policy used when LOGIN_RISK_POLICY is not set,
+// a new device alone notifies, impossible travel or a new device after many failures asks a second factor
+var DefaultRiskPolicy = RiskPolicy{
+	Scores: map[string]int{
+		RiskSignalNewDevice:        20,
+		RiskSignalNewIPRange:       10,
+		RiskSignalImpossibleTravel: 50,
+		RiskSignalLoginFailures:    30,
+	},
+	NotifyAt: 20,
+	StepUpAt: 50,
+	BlockAt:  100,
+	History:  20,
+	MaxSpeed: 900,
+	Failures: 3,
+}
+
+// RiskLogin a login compared by the risk evaluator
+type RiskLogin struct {
+	DeviceID string
+	IP       string
+	At       time.Time
+}
+
+// RiskAssessment result of the risk evaluator
+type RiskAssessment struct {
+	Score   int      `json:"score"`
+	Signals []string `json:"signals"`
+	Action  string   `json:"action"`
+	// Location of the login when the geoip database knows its ip
+	Location string `json:"location,omitempty"`
+}
+
+// GeoLocator looks up the coordinates of an ip address
+type GeoLocator interface {
+	Lookup(ip string) (geoip.Location, bool)
+}
+
+// riskPolicyConfig json form of RiskPolicy
+type riskPolicyConfig struct {
+	Scores   map[string]int `json:"scores"`
+	NotifyAt *int           `json:"notifyAt"`
+	StepUpAt *int           `json:"stepUpAt"`
+	BlockAt  *int           `json:"blockAt"`
+	History  int            `json:"history"`
+	MaxSpeed float64        `json:"maxSpeed"`
+	Failures int            `json:"failures"`
+}
+
+// ParseRiskPolicy function for reading LOGIN_RISK_POLICY, e.g. `{"scores":{"newDevice":30},"blockAt":0}`,
+// fields left out keep the default
+func ParseRiskPolicy(raw string) (RiskPolicy, error) {
+	policy := DefaultRiskPolicy
+	policy.Scores = map[string]int{}
+	for signal, score := range DefaultRiskPolicy.Scores {
+		policy.Scores[signal] = score
+	}
+	if strings.TrimSpace(raw) == "" {
+		return policy, nil
+	}
+
+	config := riskPolicyConfig{}
+	if err := json.Unmarshal([]byte(raw), &config); err != nil {
+		return RiskPolicy{}, fmt.Errorf(ErrorRiskPolicy, err.Error())
+	}
+
+	for signal, score := range config.Scores {
+		if _, ok := policy.Scores[signal]; !ok {
+			return RiskPolicy{}, fmt.Errorf(ErrorRiskPolicy, "unknown signal "+signal)
+		}
+		if score < 0 {
+			return RiskPolicy{}, fmt.Errorf(ErrorRiskPolicy, "negative score of "+signal)
+		}
+		policy.Scores[signal] = score
+	}
+
+	thresholds := []struct {
+		value  *int
+		target *int
+	}{
+		{config.NotifyAt, &policy.NotifyAt},
+		{config.StepUpAt, &policy.StepUpAt},
+		{config.BlockAt, &policy.BlockAt},
+	}
+	for _, threshold := range thresholds {
+		if threshold.value == nil {
+			continue
+		}
+		if *threshold.value < 0 {
+			return RiskPolicy{}, fmt.Errorf(ErrorRiskPolicy, "negative threshold")
+		}
+		*threshold.target = *threshold.value
+	}
+
+	if config.History > 0 {
+		policy.History = config.History
+	}
+	if config.MaxSpeed > 0 {
+		policy.MaxSpeed = config.MaxSpeed
+	}
+	if config.Failures > 0 {
+		policy.Failures = config.Failures
+	}
+	return policy, nil
+}
+
+// Assess function for scoring a login against the recent logins of the member, failures are the wrong passwords
+// before it and locator may be nil. Without recent logins there is nothing to compare and only failures count
+func (p RiskPolicy) Assess(login RiskLogin, history []RiskLogin, failures int, locator GeoLocator) RiskAssessment {
+	assessment := RiskAssessment{Signals: []string{}}
+	if locator != nil {
+		if location, ok := locator.Lookup(login.IP); ok {
+			assessment.Location = location.Name
+		}
+	}
+
+	signals := map[string]bool{}
+	if len(history) > 0 {
+		signals[RiskSignalNewDevice] = login.DeviceID != ""
+		ipRange := IPRange(login.IP)
+		signals[RiskSignalNewIPRange] = ipRange != ""
+		for _, previous := range history {
+			if previous.DeviceID == login.DeviceID {
+				signals[RiskSignalNewDevice] = false
+			}
+			if IPRange(previous.IP) == ipRange {
+				signals[RiskSignalNewIPRange] = false
+			}
+		}
+		signals[RiskSignalImpossibleTravel] = p.isImpossibleTravel(login, history, locator)
+	}
+	signals[RiskSignalLoginFailures] = p.Failures > 0 && failures >= p.Failures
+
+	// keep the order of the signals stable for the email and the event
+	for _, signal := range []string{RiskSignalNewDevice, RiskSignalNewIPRange, RiskSignalImpossibleTravel, RiskSignalLoginFailures} {
+		if signals[signal] {
+			assessment.Signals = append(assessment.Signals, signal)
+			assessment.Score += p.Scores[signal]
+		}
+	}
+
+	assessment.Action = p.action(assessment.Score)
+	return assessment
+}
+
+// isImpossibleTravel compares the login with the latest previous login the locator knows
+func (p RiskPolicy) isImpossibleTravel(login RiskLogin, history []RiskLogin, locator GeoLocator) bool {
+	if locator == nil || p.MaxSpeed <= 0 {
+		return false
+	}
+	current, ok := locator.Lookup(login.IP)
+	if !ok {
+		return false
+	}
+
+	var (
+		previous      geoip.Location
+		previousLogin *RiskLogin
+	)
+	for i := range history {
+		location, ok := locator.Lookup(history[i].IP)
+		if !ok || (previousLogin != nil && !history[i].At.After(previousLogin.At)) {
+			continue
+		}
+		previous, previousLogin = location, &history[i]
+	}
+	if previousLogin == nil {
+		return false
+	}
+
+	distance := geoip.Distance(previous, current)
+	if distance < RiskMinTravelDistance {
+		return false
+	}
+	hours := login.At.Sub(previousLogin.At).Hours()
+	return hours <= 0 || distance/hours > p.MaxSpeed
+}
+
+func (p RiskPolicy) action(score int) string {
+	switch {
+	case score <= 0:
+		return RiskActionAllow
+	case p.BlockAt > 0 && score >= p.BlockAt:
+		return RiskActionBlock
+	case p.StepUpAt > 0 && score >= p.StepUpAt:
+		return RiskActionStepUp
+	case p.NotifyAt > 0 && score >= p.NotifyAt:
+		return RiskActionNotify
+	}
+	return RiskActionAllow
+}
+
+// IPRange function for getting the /24 network of an ipv4 or the /48 network of an ipv6 address, empty when invalid
+func IPRange(ip string) string {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return ""
+	}
+	if ipv4 := parsed.To4(); ipv4 != nil {
+		return (&net.IPNet{IP: ipv4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: parsed.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
+
+// GetRiskStepUpKey redis key format: `mfa-risk-USR123-ABC-WEB`
+func GetRiskStepUpKey(memberID, scope string) string {
+	return strings.Join([]string{RiskStepUpKeyRedis, memberID, scope}, "-")
+}
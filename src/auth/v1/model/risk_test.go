@@ -0,0 +1,123 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/src/shared/geoip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRiskPolicy(t *testing.T) {
+	policy, err := ParseRiskPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultRiskPolicy, policy)
+
+	policy, err = ParseRiskPolicy(`{"scores":{"newDevice":30},"blockAt":0,"history":5}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, policy.Scores[RiskSignalNewDevice])
+	assert.Equal(t, 0, policy.BlockAt)
+	assert.Equal(t, DefaultRiskPolicy.StepUpAt, policy.StepUpAt)
+	assert.Equal(t, 5, policy.History)
+	assert.Equal(t, 20, DefaultRiskPolicy.Scores[RiskSignalNewDevice])
+
+	invalidPolicies := []string{
+		`{`,
+		`{"scores":{"unknown":10}}`,
+		`{"scores":{"newDevice":-1}}`,
+		`{"stepUpAt":-1}`,
+	}
+	for _, invalid := range invalidPolicies {
+		_, err = ParseRiskPolicy(invalid)
+		assert.Error(t, err)
+	}
+}
+
+func TestRiskPolicyAssess(t *testing.T) {
+	database, _ := geoip.Load(strings.NewReader(
+		"36.72.0.0/16,-6.2088,106.8456,Jakarta, ID\n" +
+			"36.73.0.0/16,-6.2100,106.8500,Jakarta, ID\n" +
+			"145.0.0.0/8,52.3676,4.9041,Amsterdam, NL\n"))
+	now := time.Now()
+	history := []RiskLogin{
+		{DeviceID: "ASX1234", IP: "36.72.10.1", At: now.Add(-2 * time.Hour)},
+		{DeviceID: "ASX1234", IP: "36.72.10.2", At: now.Add(-24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name        string
+		login       RiskLogin
+		history     []RiskLogin
+		failures    int
+		wantSignals []string
+		wantAction  string
+	}{
+		{
+			name:        "Case 1: Known device and ip range",
+			login:       RiskLogin{DeviceID: "ASX1234", IP: "36.72.10.9", At: now},
+			history:     history,
+			wantSignals: []string{},
+			wantAction:  RiskActionAllow,
+		},
+		{
+			name:        "Case 2: New device notifies",
+			login:       RiskLogin{DeviceID: "NEW999", IP: "36.72.10.9", At: now},
+			history:     history,
+			wantSignals: []string{RiskSignalNewDevice},
+			wantAction:  RiskActionNotify,
+		},
+		{
+			name:        "Case 3: New ip range nearby is not travel",
+			login:       RiskLogin{DeviceID: "ASX1234", IP: "36.73.1.1", At: now},
+			history:     history,
+			wantSignals: []string{RiskSignalNewIPRange},
+			wantAction:  RiskActionAllow,
+		},
+		{
+			name:        "Case 4: Impossible travel steps up",
+			login:       RiskLogin{DeviceID: "ASX1234", IP: "145.1.1.1", At: now},
+			history:     history,
+			wantSignals: []string{RiskSignalNewIPRange, RiskSignalImpossibleTravel},
+			wantAction:  RiskActionStepUp,
+		},
+		{
+			name:        "Case 5: Everything at once blocks",
+			login:       RiskLogin{DeviceID: "NEW999", IP: "145.1.1.1", At: now},
+			history:     history,
+			failures:    5,
+			wantSignals: []string{RiskSignalNewDevice, RiskSignalNewIPRange, RiskSignalImpossibleTravel, RiskSignalLoginFailures},
+			wantAction:  RiskActionBlock,
+		},
+		{
+			name:        "Case 6: First login only counts failures",
+			login:       RiskLogin{DeviceID: "NEW999", IP: "145.1.1.1", At: now},
+			failures:    3,
+			wantSignals: []string{RiskSignalLoginFailures},
+			wantAction:  RiskActionNotify,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := DefaultRiskPolicy.Assess(tt.login, tt.history, tt.failures, database)
+			assert.Equal(t, tt.wantSignals, assessment.Signals)
+			assert.Equal(t, tt.wantAction, assessment.Action)
+		})
+	}
+
+	// a long enough time between the logins is believable travel
+	slow := []RiskLogin{{DeviceID: "ASX1234", IP: "36.72.10.1", At: now.Add(-48 * time.Hour)}}
+	assessment := DefaultRiskPolicy.Assess(RiskLogin{DeviceID: "ASX1234", IP: "145.1.1.1", At: now}, slow, 0, database)
+	assert.Equal(t, []string{RiskSignalNewIPRange}, assessment.Signals)
+	assert.Equal(t, "Amsterdam, NL", assessment.Location)
+
+	// without geoip database travel is not checked
+	assessment = DefaultRiskPolicy.Assess(RiskLogin{DeviceID: "ASX1234", IP: "145.1.1.1", At: now}, history, 0, nil)
+	assert.Equal(t, []string{RiskSignalNewIPRange}, assessment.Signals)
+}
+
+func TestIPRange(t *testing.T) {
+	assert.Equal(t, "36.72.10.0/24", IPRange("36.72.10.1"))
+	assert.Equal(t, "2001:db8:1::/48", IPRange("2001:db8:1:2::1"))
+	assert.Equal(t, "", IPRange("localhost"))
+}
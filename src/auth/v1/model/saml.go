@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// SAMLRequestKeyRedis redis key prefix of a pending saml login, format: `saml-request-<relayState>`
+	SAMLRequestKeyRedis = "saml-request"
+	// SAMLTicketKeyRedis redis key prefix of the hashed ticket exchanged for a token, format: `saml-ticket-<hash>`
+	SAMLTicketKeyRedis = "saml-ticket"
+	// SAMLRequestAge how long the identity provider has to answer a login
+	SAMLRequestAge = 10 * time.Minute
+	// SAMLTicketAge how long the client has to exchange the ticket of a login
+	SAMLTicketAge = time.Minute
+
+	// ErrorSAMLDisabled error message for an account whose single sign on is missing or turned off
+	ErrorSAMLDisabled = "single sign on is not enabled for the account"
+	// ErrorSAMLRelayState error message for an unknown, used or expired relay state
+	ErrorSAMLRelayState = "saml login is expired, please login again"
+	// ErrorSAMLTicketBahasa error message for a wrong, used or expired ticket
+	ErrorSAMLTicketBahasa = "Sesi login SSO Anda telah berakhir, silakan login kembali"
+	// ErrorSAMLContactBahasa error message for an email that is not a contact of the account
+	ErrorSAMLContactBahasa = "Email Anda belum terdaftar pada akun perusahaan ini, silakan hubungi admin perusahaan Anda"
+	// SAMLErrorAccessDenied error sent to the redirect uri when the login failed
+	SAMLErrorAccessDenied = "access_denied"
+)
+
+// SAMLAuthorizeRequest data structure for starting a saml login of a corporate account
+type SAMLAuthorizeRequest struct {
+	ClientID     string `json:"-"`
+	ClientSecret string `json:"-"`
+	RedirectURI  string `json:"redirectUri" form:"redirectUri"`
+}
+
+// SAMLAuthorization data structure of a started saml login, the user agent goes to the authorization url
+type SAMLAuthorization struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	RelayState       string `json:"relayState"`
+}
+
+// SAMLLoginRequest data structure of the response posted by the identity provider to the assertion consumer service
+type SAMLLoginRequest struct {
+	SAMLResponse string `json:"SAMLResponse" form:"SAMLResponse"`
+	RelayState   string `json:"RelayState" form:"RelayState"`
+}
+
+// SAMLPendingLogin data structure of a saml login waiting for the identity provider
+type SAMLPendingLogin struct {
+	RequestID   string `json:"requestId"`
+	AccountID   string `json:"accountId"`
+	Audience    string `json:"audience"`
+	RedirectURI string `json:"redirectUri"`
+}
+
+// SAMLTicket data structure of the contact logged in by the identity provider, waiting for the token exchange
+type SAMLTicket struct {
+	ContactID int    `json:"contactId"`
+	AccountID string `json:"accountId"`
+	Audience  string `json:"audience"`
+}
+
+// GetSAMLRequestKey function for getting redis key of a pending saml login
+func GetSAMLRequestKey(relayState string) string {
+	return strings.Join([]string{SAMLRequestKeyRedis, relayState}, "-")
+}
+
+// GetSAMLTicketKey function for getting redis key of a saml ticket from the hash of the ticket
+func GetSAMLTicketKey(ticketHash string) string {
+	return strings.Join([]string{SAMLTicketKeyRedis, ticketHash}, "-")
+}
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+const (
+	// SecurityEventRefreshTokenReuse event type when an already rotated refresh token is presented again
+	SecurityEventRefreshTokenReuse = "RefreshTokenReuse"
+	// SecurityEventAccountLocked event type when too many failed logins lock an account
+	SecurityEventAccountLocked = "AccountLocked"
+	// SecurityEventAccountUnlocked event type when an admin clears a lockout
+	SecurityEventAccountUnlocked = "AccountUnlocked"
+	// SecurityEventSuspiciousLogin event type when the risk evaluator notifies, steps up or blocks a login
+	SecurityEventSuspiciousLogin = "SuspiciousLogin"
+)
+
+// SecurityEvent data structure of security related event published to kafka
+type SecurityEvent struct {
+	EventType string               `json:"eventType"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Payload   SecurityEventPayload `json:"payload"`
+}
+
+// SecurityEventPayload data structure
+type SecurityEventPayload struct {
+	Subject     string `json:"sub"`
+	DeviceID    string `json:"deviceId,omitempty"`
+	DeviceLogin string `json:"deviceLogin,omitempty"`
+	ClientID    string `json:"clientId,omitempty"`
+	FamilyID    string `json:"familyId,omitempty"`
+	Generation  int    `json:"generation,omitempty"`
+	IP          string `json:"ip,omitempty"`
+	UserAgent   string `json:"userAgent,omitempty"`
+	Revoked     bool   `json:"revoked"`
+
+	// lockout events, the subject is the email used to login
+	MemberType  string     `json:"memberType,omitempty"`
+	Locks       int        `json:"locks,omitempty"`
+	LockedUntil *time.Time `json:"lockedUntil,omitempty"`
+	UnlockedBy  string     `json:"unlockedBy,omitempty"`
+
+	// suspicious login events
+	Risk *RiskAssessment `json:"risk,omitempty"`
+}
+
+// NewSecurityEvent function for building security event
+func NewSecurityEvent(eventType string, payload SecurityEventPayload) SecurityEvent {
+	return SecurityEvent{
+		EventType: eventType,
+		CreatedAt: time.Now(),
+		Payload:   payload,
+	}
+}
@@ -100,3 +100,40 @@ func (aqp *AuthQueryPostgres) GetAccountId(ctxReq context.Context, contactId int
 
 	return output
 }
+
+// GetMemberPermissions function for getting permissions of every role assigned to a member
+func (aqp *AuthQueryPostgres) GetMemberPermissions(ctxReq context.Context, memberID string) <-chan ResultQuery {
+	ctx := "AuthQuery-GetMemberPermissions"
+
+	output := make(chan ResultQuery)
+	go func() {
+		defer close(output)
+
+		q := `SELECT DISTINCT rp."permissionId" FROM member_role mr
+			JOIN role_permission rp ON rp."roleId" = mr."roleId"
+			WHERE mr."memberId" = $1 ORDER BY rp."permissionId"`
+
+		rows, err := aqp.db.Query(q, memberID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultQuery{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		permissions := []string{}
+		for rows.Next() {
+			var permission string
+			if err := rows.Scan(&permission); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "scan_permission", err, memberID)
+				output <- ResultQuery{Error: err}
+				return
+			}
+			permissions = append(permissions, permission)
+		}
+
+		output <- ResultQuery{Result: permissions}
+	}()
+
+	return output
+}
@@ -12,6 +12,7 @@ type ResultQuery struct {
 type AuthQuery interface {
 	UpdateLastLogin(uid string) <-chan ResultQuery
 	GetAccountId(ctxReq context.Context, contactId int) <-chan ResultQuery
+	GetMemberPermissions(ctxReq context.Context, memberID string) <-chan ResultQuery
 }
 
 // AuthQueryOA interface abstraction
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+)
+
+// AuthorizationCodeRepositoryRedis data structure
+type AuthorizationCodeRepositoryRedis struct {
+	client redis.Client
+}
+
+// NewAuthorizationCodeRepositoryRedis function for initializing AuthorizationCodeRepositoryRedis
+func NewAuthorizationCodeRepositoryRedis(cl redis.Client) *AuthorizationCodeRepositoryRedis {
+	return &AuthorizationCodeRepositoryRedis{cl}
+}
+
+// Save function for saving authorization code into redis DB, only the hash of the code is used as key
+func (repo *AuthorizationCodeRepositoryRedis) Save(ctxReq context.Context, data *model.AuthorizationCode) <-chan ResultRepository {
+	ctx := "AuthorizationCodeRepositoryRedis-Save"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["clientId"] = data.ClientID
+
+		payload, err := json.Marshal(data)
+		if err != nil {
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if _, err := repo.client.Set(getAuthorizationCodeKey(data.Code), string(payload), model.AuthorizationCodeAge); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "save_code_to_redis", err, data.ClientID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: data}
+	})
+
+	return output
+}
+
+// Consume function for loading authorization code and deleting it, a code can only be used once
+func (repo *AuthorizationCodeRepositoryRedis) Consume(ctxReq context.Context, code string) <-chan ResultRepository {
+	ctx := "AuthorizationCodeRepositoryRedis-Consume"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		key := getAuthorizationCodeKey(code)
+		val, err := repo.client.Get(key)
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "load_code_from_redis", err, nil)
+			}
+			output <- ResultRepository{Error: err}
+			tags[helper.TextResponse] = err
+			return
+		}
+
+		// only the caller that actually removed the key may use the code
+		deleted, err := repo.client.Del(key)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "delete_code_from_redis", err, nil)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		if deleted == 0 {
+			output <- ResultRepository{Error: errors.New(helper.ErrorRedis)}
+			return
+		}
+
+		data := model.AuthorizationCode{}
+		if err := json.Unmarshal([]byte(val), &data); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "unmarshal_code", err, nil)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		data.Code = code
+		tags["clientId"] = data.ClientID
+
+		output <- ResultRepository{Result: data}
+	})
+
+	return output
+}
+
+func getAuthorizationCodeKey(code string) string {
+	return strings.Join([]string{model.AuthorizationCodeKeyRedis, helper.HashSHA256(code)}, "-")
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Bhinneka/user-service/helper"
@@ -67,16 +68,16 @@ func (repo *ClientAppRepoPostgres) Save(m *model.ClientApp) <-chan ResultReposit
 		q := `INSERT INTO client_app
 				(
 					"clientId", "clientSecret", name, status,
-					created, "lastModified", version
+					created, "lastModified", version, "redirectUris", "scopes", "type"
 				)
 			VALUES
 				(
-					$1, $2, $3, $4, $5, $6, $7
+					$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 				)
 			ON CONFLICT(id)
 			DO UPDATE SET
 				"clientId" = $1, "clientSecret" = $2, name = $3, status = $4,
-				 "lastModified" = $6, version = $7`
+				 "lastModified" = $6, version = $7, "redirectUris" = $8, "scopes" = $9, "type" = $10`
 
 		stmt, err := tx.Prepare(q)
 		if err != nil {
@@ -89,7 +90,8 @@ func (repo *ClientAppRepoPostgres) Save(m *model.ClientApp) <-chan ResultReposit
 
 		_, err = stmt.Exec(
 			m.ClientID, m.ClientSecret, m.Name, m.Status.String(),
-			time.Now(), time.Now(), m.Version,
+			time.Now(), time.Now(), m.Version, strings.Join(m.RedirectURIs, " "),
+			strings.Join(m.Scopes, " "), m.Type,
 		)
 		if err != nil {
 			tx.Rollback()
@@ -162,11 +164,14 @@ func (repo *ClientAppRepoPostgres) findBy(ctx, field string, value interface{})
 		clientIDString     sql.NullString
 		clientSecretString sql.NullString
 		nameString         sql.NullString
+		redirectURIsString sql.NullString
+		scopesString       sql.NullString
+		typeString         sql.NullString
 		status             string
 	)
 	query := `
 		SELECT id, "clientId", "clientSecret", name, status, created, 
-		"lastModified", "version" FROM client_app`
+		"lastModified", "version", "redirectUris", "scopes", "type" FROM client_app`
 
 	query += ` WHERE ` + field + ` = $1`
 	stmt, err := repo.db.Prepare(query)
@@ -179,7 +184,7 @@ func (repo *ClientAppRepoPostgres) findBy(ctx, field string, value interface{})
 
 	err = stmt.QueryRow(value).Scan(&app.ID,
 		&clientIDString, &clientSecretString, &nameString,
-		&status, &app.Created, &app.LastModified, &app.Version)
+		&status, &app.Created, &app.LastModified, &app.Version, &redirectURIsString, &scopesString, &typeString)
 
 	if err == sql.ErrNoRows {
 		return ResultRepository{nil, errors.New(txtClientID)}
@@ -200,6 +205,19 @@ func (repo *ClientAppRepoPostgres) findBy(ctx, field string, value interface{})
 		app.Name = nameString.String
 	}
 
+	if redirectURIsString.Valid {
+		app.RedirectURIs = strings.Fields(redirectURIsString.String)
+	}
+
+	if scopesString.Valid {
+		app.Scopes = strings.Fields(scopesString.String)
+	}
+
+	app.Type = model.ClientTypeConfidential
+	if typeString.Valid && typeString.String == model.ClientTypePublic {
+		app.Type = model.ClientTypePublic
+	}
+
 	app.Status = model.StringToStatus(status)
 	return ResultRepository{Result: app}
 }
@@ -0,0 +1,196 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+)
+
+// LockoutRepositoryRedis data structure
+type LockoutRepositoryRedis struct {
+	client redis.Client
+}
+
+// NewLockoutRepositoryRedis function for initializing LockoutRepositoryRedis
+func NewLockoutRepositoryRedis(cl redis.Client) *LockoutRepositoryRedis {
+	return &LockoutRepositoryRedis{cl}
+}
+
+// updateLockoutScript writes the new record only when the stored one is still the record it was computed from,
+// an empty ARGV[1] stands for a missing key. Returns 1 when written
+const updateLockoutScript = `
+local current = redis.call('GET', KEYS[1])
+if (current or '') ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`
+
+// lockoutUpdateRetries how many times a failed login is counted again after losing the race to another one
+const lockoutUpdateRetries = 20
+
+// Update function for changing failed logins and lock state of an email without losing a concurrent change,
+// update gets the stored record, or an empty one, and returns how long the record is kept. update runs again
+// on a fresh record when another request changed it in between
+func (repo *LockoutRepositoryRedis) Update(ctxReq context.Context, email string, update func(*model.Lockout) time.Duration) <-chan ResultRepository {
+	ctx := "LockoutRepositoryRedis-Update"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		key := model.GetLockoutKey(email)
+		tags[helper.TagKey] = key
+
+		for i := 0; i < lockoutUpdateRetries; i++ {
+			current, err := repo.client.Get(key)
+			if err != nil && err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "load_from_redis", err, key)
+				output <- ResultRepository{Error: err}
+				return
+			}
+
+			data := model.Lockout{}
+			if current != "" {
+				if err := json.Unmarshal([]byte(current), &data); err != nil {
+					output <- ResultRepository{Error: err}
+					return
+				}
+			}
+
+			age := update(&data)
+			payload, err := json.Marshal(data)
+			if err != nil {
+				output <- ResultRepository{Error: err}
+				return
+			}
+
+			written, err := repo.client.Eval(updateLockoutScript, []string{key}, current, string(payload), age.Milliseconds())
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "save_to_redis", err, data)
+				output <- ResultRepository{Error: err}
+				return
+			}
+
+			if n, _ := written.(int64); n == 1 {
+				output <- ResultRepository{Result: data}
+				return
+			}
+		}
+
+		err := errors.New("lockout is changed by too many requests at once")
+		helper.SendErrorLog(ctxReq, ctx, "save_to_redis", err, key)
+		output <- ResultRepository{Error: err}
+	})
+
+	return output
+}
+
+// Load function for loading failed logins and lock state of an email
+func (repo *LockoutRepositoryRedis) Load(ctxReq context.Context, email string) <-chan ResultRepository {
+	ctx := "LockoutRepositoryRedis-Load"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		key := model.GetLockoutKey(email)
+		tags[helper.TagKey] = key
+
+		data, err := repo.load(key)
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "load_from_redis", err, key)
+			}
+			output <- ResultRepository{Error: err}
+			tags[helper.TextResponse] = err
+			return
+		}
+
+		output <- ResultRepository{Result: data}
+	})
+
+	return output
+}
+
+// Delete function for deleting failed logins and lock state of an email
+func (repo *LockoutRepositoryRedis) Delete(ctxReq context.Context, email string) <-chan ResultRepository {
+	ctx := "LockoutRepositoryRedis-Delete"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		key := model.GetLockoutKey(email)
+		tags[helper.TagKey] = key
+
+		deleted, err := repo.client.Del(key)
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "delete_from_redis", err, key)
+			}
+			output <- ResultRepository{Error: err}
+			tags[helper.TextResponse] = err
+			return
+		}
+
+		output <- ResultRepository{Result: deleted}
+	})
+
+	return output
+}
+
+// FindAll function for loading every email with failed logins or a lock
+func (repo *LockoutRepositoryRedis) FindAll(ctxReq context.Context) <-chan ResultRepository {
+	ctx := "LockoutRepositoryRedis-FindAll"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		keys := model.LockoutKeyRedis + ":*"
+		tags[helper.TagKey] = keys
+
+		val, err := repo.client.Keys(keys)
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "get_redis_key", err, keys)
+			}
+			output <- ResultRepository{Error: err}
+			tags[helper.TextResponse] = err
+			return
+		}
+
+		// a key may expire between Keys and Get, it is skipped
+		lockouts := []model.Lockout{}
+		for _, key := range val {
+			data, err := repo.load(key)
+			if err != nil {
+				continue
+			}
+			lockouts = append(lockouts, data)
+		}
+
+		output <- ResultRepository{Result: lockouts}
+	})
+
+	return output
+}
+
+func (repo *LockoutRepositoryRedis) load(key string) (model.Lockout, error) {
+	data := model.Lockout{}
+	val, err := repo.client.Get(key)
+	if err != nil {
+		return data, err
+	}
+
+	err = json.Unmarshal([]byte(val), &data)
+	return data, err
+}
@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutRepositoryRedis_Update(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+
+	lockoutRepo := NewLockoutRepositoryRedis(redis.Conn{Client: goredis.NewClient(&goredis.Options{Addr: mr.Addr()})})
+	policy := model.LockoutPolicy{MaxAttempts: 100, LockDuration: time.Minute, MaxLockDuration: time.Hour, ResetAfter: time.Hour}
+
+	const failures = 10
+	var wg sync.WaitGroup
+	for i := 0; i < failures; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := <-lockoutRepo.Update(context.Background(), "john@bhinneka.com", func(lockout *model.Lockout) time.Duration {
+				now := time.Now()
+				lockout.Email = "john@bhinneka.com"
+				lockout.RegisterFailure(policy, time.Minute, now)
+				return lockout.Age(policy, time.Minute, now)
+			})
+			assert.NoError(t, result.Error)
+		}()
+	}
+	wg.Wait()
+
+	result := <-lockoutRepo.Load(context.Background(), "john@bhinneka.com")
+	assert.NoError(t, result.Error)
+	lockout, _ := result.Result.(model.Lockout)
+	assert.Equal(t, failures, lockout.Failures)
+	assert.True(t, mr.TTL(model.GetLockoutKey("john@bhinneka.com")) > 0)
+}
@@ -2,6 +2,8 @@ package repo
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/Bhinneka/golib/tracer"
 	"github.com/Bhinneka/user-service/config/redis"
@@ -9,6 +11,15 @@ import (
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 )
 
+// incrementAttemptScript counts one more attempt, the key expires ARGV[1] milliseconds after the first one
+const incrementAttemptScript = `
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`
+
 // AttemptRepositoryRedis data structure
 type AttemptRepositoryRedis struct {
 	client redis.Client
@@ -98,3 +109,30 @@ func (repo *AttemptRepositoryRedis) Delete(ctxReq context.Context, key string) <
 
 	return outputDelete
 }
+
+// Increment function for counting one more attempt in a single redis call, so concurrent attempts are not lost.
+// The counter expires age after its first attempt
+func (repo *AttemptRepositoryRedis) Increment(ctxReq context.Context, key string, age time.Duration) <-chan ResultRepository {
+	ctx := "AttemptRepositoryRedis-Increment"
+
+	output := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TagKey] = key
+
+		count, err := repo.client.Eval(incrementAttemptScript, []string{key}, age.Milliseconds())
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "increment_in_redis", err, key)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		n, _ := count.(int64)
+		attempt := model.LoginAttempt{Key: key, Attempt: strconv.FormatInt(n, 10), LoginAttemptAge: age}
+		output <- ResultRepository{Result: attempt}
+		tags[helper.TextResponse] = attempt
+	})
+
+	return output
+}
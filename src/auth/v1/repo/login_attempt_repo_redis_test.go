@@ -0,0 +1,39 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptRepositoryRedis_Increment(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+
+	attemptRepo := NewAttemptRepositoryRedis(redis.Conn{Client: goredis.NewClient(&goredis.Options{Addr: mr.Addr()})})
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := <-attemptRepo.Increment(context.Background(), "MFAOTP:ATTEMPT", time.Minute)
+			assert.NoError(t, result.Error)
+		}()
+	}
+	wg.Wait()
+
+	result := <-attemptRepo.Load(context.Background(), "MFAOTP:ATTEMPT")
+	assert.NoError(t, result.Error)
+	assert.Equal(t, model.LoginAttempt{Attempt: "10"}, result.Result)
+	assert.Equal(t, time.Minute, mr.TTL("MFAOTP:ATTEMPT"))
+}
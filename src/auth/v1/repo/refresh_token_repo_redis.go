@@ -2,13 +2,59 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/Bhinneka/golib/tracer"
 	"github.com/Bhinneka/user-service/config/redis"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	uuid "github.com/satori/go.uuid"
 )
 
+// rotateRefreshTokenScript marks the owner in the index as rotated at ARGV[1] unless it is already,
+// keeping the remaining TTL. Returns the owner before rotation, nil when the index does not exist
+const rotateRefreshTokenScript = `
+local value = redis.call('GET', KEYS[1])
+if not value then
+	return false
+end
+local owner = cjson.decode(value)
+if owner['rat'] and owner['rat'] ~= 0 then
+	return value
+end
+owner['rat'] = tonumber(ARGV[1])
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl <= 0 then
+	ttl = 60000
+end
+redis.call('SET', KEYS[1], cjson.encode(owner), 'PX', ttl)
+return value
+`
+
+// revokeRefreshTokenFamilyScript deletes the session refresh token KEYS[1] and its index KEYS[2] only while the session
+// still holds the token ARGV[1] and the index still belongs to the family ARGV[2], so a login or refresh in between is kept.
+// Returns 1 when deleted
+const revokeRefreshTokenFamilyScript = `
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+local value = redis.call('GET', KEYS[2])
+if not value then
+	return 0
+end
+local owner = cjson.decode(value)
+if owner['fid'] ~= ARGV[2] then
+	return 0
+end
+redis.call('DEL', KEYS[1], KEYS[2])
+return 1
+`
+
+// ErrRefreshTokenNotInFamily the refresh token has no family index, e.g. it was issued before families were introduced
+var ErrRefreshTokenNotInFamily = errors.New("refresh token is not in a family")
+
 // RefreshTokenRepositoryRedis data structure
 type RefreshTokenRepositoryRedis struct {
 	client redis.Client
@@ -36,6 +82,24 @@ func (repo *RefreshTokenRepositoryRedis) Save(ctxReq context.Context, refreshTok
 			return
 		}
 
+		// index by token value so the token can be used without the previous access token
+		if refreshToken.Owner != nil {
+			now := time.Now()
+			refreshToken.Owner.Key = refreshToken.ID
+			refreshToken.Owner.IssuedAt = now.Unix()
+			refreshToken.Owner.ExpiresAt = now.Add(refreshToken.RefreshTokenAge).Unix()
+			refreshToken.Owner.RotatedAt = 0
+			if refreshToken.Owner.FamilyID == "" {
+				refreshToken.Owner.FamilyID = uuid.NewV4().String()
+			}
+			owner, _ := json.Marshal(refreshToken.Owner)
+			if _, err := repo.client.Set(getRefreshTokenIndexKey(refreshToken.Token), string(owner), refreshToken.RefreshTokenAge); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "save_index_to_redis", err, refreshToken.ID)
+				outputSaveRefreshToken <- ResultRepository{Error: err}
+				return
+			}
+		}
+
 		outputSaveRefreshToken <- ResultRepository{Result: refreshToken}
 
 	})
@@ -97,3 +161,135 @@ func (repo *RefreshTokenRepositoryRedis) Delete(ctxReq context.Context, key stri
 
 	return outputDeleteRT
 }
+
+// FindByToken function for loading refresh token and its owner by the token value
+func (repo *RefreshTokenRepositoryRedis) FindByToken(ctxReq context.Context, token string) <-chan ResultRepository {
+	ctx := "RefreshTokenRepositoryRedis-FindByToken"
+
+	outputFindRT := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(outputFindRT)
+
+		val, err := repo.client.Get(getRefreshTokenIndexKey(token))
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "load_index_from_redis", err, nil)
+			}
+			outputFindRT <- ResultRepository{Error: err}
+			tags[helper.TextResponse] = err
+			return
+		}
+
+		owner := model.RefreshTokenOwner{}
+		if err := json.Unmarshal([]byte(val), &owner); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "unmarshal_index", err, nil)
+			outputFindRT <- ResultRepository{Error: err}
+			return
+		}
+		tags[helper.TagKey] = owner.Key
+
+		current, err := repo.client.Get(owner.Key)
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "laod_from_redis", err, owner.Key)
+			}
+			outputFindRT <- ResultRepository{Error: err}
+			tags[helper.TextResponse] = err
+			return
+		}
+
+		outputFindRT <- ResultRepository{Result: model.RefreshToken{ID: owner.Key, Token: current, Owner: &owner}}
+	})
+
+	return outputFindRT
+}
+
+// Rotate function for marking refresh token as exchanged, its index is kept until it expires
+// so a later attempt to use it again can be detected. Result is the owner before rotation,
+// concurrent requests with the same token get it already rotated. ErrRefreshTokenNotInFamily is returned
+// when the token has no index or its index has no family
+func (repo *RefreshTokenRepositoryRedis) Rotate(ctxReq context.Context, token string) <-chan ResultRepository {
+	ctx := "RefreshTokenRepositoryRedis-Rotate"
+
+	outputRotateRT := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(outputRotateRT)
+
+		result, err := repo.client.Eval(rotateRefreshTokenScript, []string{getRefreshTokenIndexKey(token)}, time.Now().Unix())
+		if err != nil {
+			if err.Error() == helper.ErrorRedis {
+				outputRotateRT <- ResultRepository{Error: ErrRefreshTokenNotInFamily}
+				return
+			}
+			helper.SendErrorLog(ctxReq, ctx, "rotate_index_in_redis", err, nil)
+			outputRotateRT <- ResultRepository{Error: err}
+			return
+		}
+
+		value, _ := result.(string)
+		previous := &model.RefreshTokenOwner{}
+		if err := json.Unmarshal([]byte(value), previous); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "unmarshal_index", err, nil)
+			outputRotateRT <- ResultRepository{Error: err}
+			return
+		}
+		tags[helper.TagKey] = previous.Key
+		tags["familyId"] = previous.FamilyID
+		if previous.FamilyID == "" {
+			outputRotateRT <- ResultRepository{Error: ErrRefreshTokenNotInFamily}
+			return
+		}
+
+		outputRotateRT <- ResultRepository{Result: previous}
+	})
+
+	return outputRotateRT
+}
+
+// RevokeFamily function for deleting the session refresh token when it still belongs to the given family,
+// result is true when the session was revoked. The check and the delete run in one script so a concurrent
+// login or refresh of the session is not deleted with the family
+func (repo *RefreshTokenRepositoryRedis) RevokeFamily(ctxReq context.Context, owner *model.RefreshTokenOwner) <-chan ResultRepository {
+	ctx := "RefreshTokenRepositoryRedis-RevokeFamily"
+
+	outputRevokeRT := make(chan ResultRepository)
+
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(outputRevokeRT)
+		tags[helper.TagKey] = owner.Key
+		tags["familyId"] = owner.FamilyID
+
+		current, err := repo.client.Get(owner.Key)
+		if err != nil {
+			if err.Error() != helper.ErrorRedis {
+				helper.SendErrorLog(ctxReq, ctx, "laod_from_redis", err, owner.Key)
+				outputRevokeRT <- ResultRepository{Error: err}
+				return
+			}
+			// session already ended
+			outputRevokeRT <- ResultRepository{Result: false}
+			return
+		}
+
+		// the session may have been replaced by a new login which starts another family
+		keys := []string{owner.Key, getRefreshTokenIndexKey(current)}
+		deleted, err := repo.client.Eval(revokeRefreshTokenFamilyScript, keys, current, owner.FamilyID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "delete_from_redis", err, owner.Key)
+			outputRevokeRT <- ResultRepository{Error: err}
+			return
+		}
+
+		n, _ := deleted.(int64)
+		tags[helper.TextResponse] = n == 1
+		outputRevokeRT <- ResultRepository{Result: n == 1}
+	})
+
+	return outputRevokeRT
+}
+
+func getRefreshTokenIndexKey(token string) string {
+	return "RTX-" + helper.HashSHA256(token)
+}
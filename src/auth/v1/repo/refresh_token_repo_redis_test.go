@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/redis"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRefreshTokenTestRepo(t *testing.T) (*RefreshTokenRepositoryRedis, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	return NewRefreshTokenRepositoryRedis(redis.Conn{Client: goredis.NewClient(&goredis.Options{Addr: mr.Addr()})}), mr
+}
+
+func saveTestRefreshToken(t *testing.T, rtRepo *RefreshTokenRepositoryRedis, token, familyID string) *model.RefreshTokenOwner {
+	owner := &model.RefreshTokenOwner{Subject: "USR123", DeviceID: "ASX1234", DeviceLogin: "WEB", FamilyID: familyID}
+	result := <-rtRepo.Save(context.Background(), &model.RefreshToken{ID: "RT-USR123-ASX1234-WEB", Token: token, RefreshTokenAge: time.Hour, Owner: owner})
+	assert.NoError(t, result.Error)
+	return owner
+}
+
+func TestRefreshTokenRepositoryRedis_Rotate(t *testing.T) {
+	rtRepo, mr := newRefreshTokenTestRepo(t)
+	defer mr.Close()
+
+	result := <-rtRepo.Rotate(context.Background(), "UNKNOWN")
+	assert.Equal(t, ErrRefreshTokenNotInFamily, result.Error)
+
+	saveTestRefreshToken(t, rtRepo, "REFRESH123", "FAM1")
+	result = <-rtRepo.Rotate(context.Background(), "REFRESH123")
+	assert.NoError(t, result.Error)
+	assert.False(t, result.Result.(*model.RefreshTokenOwner).IsRotated())
+
+	result = <-rtRepo.Rotate(context.Background(), "REFRESH123")
+	assert.NoError(t, result.Error)
+	assert.True(t, result.Result.(*model.RefreshTokenOwner).IsRotated())
+
+	mr.Close()
+	result = <-rtRepo.Rotate(context.Background(), "REFRESH123")
+	assert.Error(t, result.Error)
+	assert.NotEqual(t, ErrRefreshTokenNotInFamily, result.Error)
+}
+
+func TestRefreshTokenRepositoryRedis_RevokeFamily(t *testing.T) {
+	tests := []struct {
+		name        string
+		newFamily   string
+		wantRevoked bool
+	}{
+		{
+			name:        "Case 1: Session still in the family",
+			newFamily:   "FAM1",
+			wantRevoked: true,
+		},
+		{
+			name:      "Case 2: Session replaced by a new login",
+			newFamily: "FAM2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rtRepo, mr := newRefreshTokenTestRepo(t)
+			defer mr.Close()
+
+			owner := saveTestRefreshToken(t, rtRepo, "REFRESH123", "FAM1")
+			saveTestRefreshToken(t, rtRepo, "REFRESH456", tt.newFamily)
+
+			result := <-rtRepo.RevokeFamily(context.Background(), owner)
+			assert.NoError(t, result.Error)
+			assert.Equal(t, tt.wantRevoked, result.Result)
+			assert.Equal(t, !tt.wantRevoked, mr.Exists("RT-USR123-ASX1234-WEB"))
+			assert.Equal(t, !tt.wantRevoked, mr.Exists(getRefreshTokenIndexKey("REFRESH456")))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 )
@@ -24,6 +25,15 @@ type RefreshTokenRepository interface {
 	Save(ctxReq context.Context, refreshToken *model.RefreshToken) <-chan ResultRepository
 	Load(ctxReq context.Context, key string) <-chan ResultRepository
 	Delete(ctxReq context.Context, key string) <-chan ResultRepository
+	FindByToken(ctxReq context.Context, token string) <-chan ResultRepository
+	Rotate(ctxReq context.Context, token string) <-chan ResultRepository
+	RevokeFamily(ctxReq context.Context, owner *model.RefreshTokenOwner) <-chan ResultRepository
+}
+
+// AuthorizationCodeRepository interface abstraction
+type AuthorizationCodeRepository interface {
+	Save(ctxReq context.Context, data *model.AuthorizationCode) <-chan ResultRepository
+	Consume(ctxReq context.Context, code string) <-chan ResultRepository
 }
 
 // AttemptRepository interface abstraction
@@ -31,6 +41,15 @@ type AttemptRepository interface {
 	Save(ctxReq context.Context, data *model.LoginAttempt) <-chan ResultRepository
 	Load(ctxReq context.Context, key string) <-chan ResultRepository
 	Delete(ctxReq context.Context, key string) <-chan ResultRepository
+	Increment(ctxReq context.Context, key string, age time.Duration) <-chan ResultRepository
+}
+
+// LockoutRepository interface abstraction
+type LockoutRepository interface {
+	Update(ctxReq context.Context, email string, update func(*model.Lockout) time.Duration) <-chan ResultRepository
+	Load(ctxReq context.Context, email string) <-chan ResultRepository
+	Delete(ctxReq context.Context, email string) <-chan ResultRepository
+	FindAll(ctxReq context.Context) <-chan ResultRepository
 }
 
 // LoginSessionRepository interface abstraction
@@ -2,11 +2,13 @@ package token
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"strings"
 	"time"
 
 	"github.com/Bhinneka/golib"
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	"github.com/Bhinneka/user-service/src/auth/v1/repo"
@@ -28,6 +30,23 @@ type Claim struct {
 	MemberType  string
 	SignUpFrom  string
 	CustomToken string
+	Scope       string
+	Permissions []string
+	TokenAge    time.Duration
+}
+
+// IDTokenClaim openid connect id token data structure
+type IDTokenClaim struct {
+	Issuer      string
+	Audience    string
+	Subject     string
+	Nonce       string
+	AuthTime    time.Time
+	AccessToken string
+	Email       string
+	Name        string
+	GivenName   string
+	FamilyName  string
 }
 
 // AccessToken data structure
@@ -45,7 +64,7 @@ type AccessTokenResponse struct {
 
 // jwtGenerator private data structure
 type jwtGenerator struct {
-	signKey                *rsa.PrivateKey
+	keys                   *rsa.KeySet
 	tokenAge               time.Duration
 	refreshTokenAge        time.Duration
 	LoginSessionRepo       repo.LoginSessionRepository
@@ -58,13 +77,14 @@ type jwtGenerator struct {
 type AccessTokenGenerator interface {
 	GenerateAccessToken(cl Claim) <-chan AccessTokenResponse
 	GenerateAnonymous(ctxReq context.Context) <-chan AccessTokenResponse
+	GenerateIDToken(cl IDTokenClaim) <-chan AccessTokenResponse
 }
 
 // NewJwtGenerator function for initializing jwtGenerator object
-func NewJwtGenerator(signKey *rsa.PrivateKey, tokenAge, refreshTokenAge, stAge, srtAge time.Duration,
+func NewJwtGenerator(keys *rsa.KeySet, tokenAge, refreshTokenAge, stAge, srtAge time.Duration,
 	loginSessionRepo repo.LoginSessionRepository, specialEmail string) AccessTokenGenerator {
 	return &jwtGenerator{
-		signKey:                signKey,
+		keys:                   keys,
 		tokenAge:               tokenAge,
 		refreshTokenAge:        refreshTokenAge,
 		LoginSessionRepo:       loginSessionRepo,
@@ -96,6 +116,10 @@ func (j *jwtGenerator) GenerateAccessToken(cl Claim) <-chan AccessTokenResponse
 		} else {
 			age = now.Add(j.tokenAge)
 		}
+		// short lived tokens, e.g. client credentials, ask for their own age
+		if cl.TokenAge > 0 {
+			age = now.Add(cl.TokenAge)
+		}
 
 		claims["jti"] = jti
 		claims["iss"] = cl.Issuer
@@ -112,9 +136,15 @@ func (j *jwtGenerator) GenerateAccessToken(cl Claim) <-chan AccessTokenResponse
 		claims["memberType"] = cl.MemberType
 		claims["signUpFrom"] = cl.SignUpFrom
 		claims["customToken"] = cl.CustomToken
+		if cl.Scope != "" {
+			claims["scope"] = cl.Scope
+		}
+		if len(cl.Permissions) > 0 {
+			claims["perm"] = cl.Permissions
+		}
 		token.Claims = claims
 
-		tokenString, err := token.SignedString(j.signKey)
+		tokenString, err := j.sign(token)
 		if err != nil {
 			result <- AccessTokenResponse{Error: err}
 			return
@@ -125,6 +155,69 @@ func (j *jwtGenerator) GenerateAccessToken(cl Claim) <-chan AccessTokenResponse
 	return result
 }
 
+// GenerateIDToken function for generating openid connect id token
+func (j *jwtGenerator) GenerateIDToken(cl IDTokenClaim) <-chan AccessTokenResponse {
+	result := make(chan AccessTokenResponse)
+	go func() {
+		defer close(result)
+
+		now := time.Now()
+		age := now.Add(j.tokenAge)
+
+		token := jwt.New(jwt.SigningMethodRS256)
+		claims := make(jwt.MapClaims)
+
+		claims["iss"] = cl.Issuer
+		claims["aud"] = cl.Audience
+		claims["sub"] = cl.Subject
+		claims["exp"] = age.Unix()
+		claims["iat"] = now.Unix()
+		if !cl.AuthTime.IsZero() {
+			claims["auth_time"] = cl.AuthTime.Unix()
+		}
+		if cl.Nonce != "" {
+			claims["nonce"] = cl.Nonce
+		}
+		if cl.AccessToken != "" {
+			claims["at_hash"] = accessTokenHash(cl.AccessToken)
+		}
+		if cl.Email != "" {
+			claims["email"] = cl.Email
+		}
+		if cl.Name != "" {
+			claims["name"] = cl.Name
+			claims["given_name"] = cl.GivenName
+			claims["family_name"] = cl.FamilyName
+		}
+		token.Claims = claims
+
+		tokenString, err := j.sign(token)
+		if err != nil {
+			result <- AccessTokenResponse{Error: err}
+			return
+		}
+		result <- AccessTokenResponse{Error: nil, AccessToken: AccessToken{AccessToken: tokenString, ExpiredAt: age}}
+	}()
+
+	return result
+}
+
+// sign function for signing token with the active key, its id is put on `kid` header
+func (j *jwtGenerator) sign(token *jwt.Token) (string, error) {
+	keyID, signKey, err := j.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	token.Header["kid"] = keyID
+	return token.SignedString(signKey)
+}
+
+// accessTokenHash left-most half of the sha256 hash of the access token, see OpenID Connect core 3.1.3.6
+func accessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
+
 // GenerateAnonymous function for default token anonymous data into redis DB
 func (j *jwtGenerator) GenerateAnonymous(ctxReq context.Context) <-chan AccessTokenResponse {
 	result := make(chan AccessTokenResponse)
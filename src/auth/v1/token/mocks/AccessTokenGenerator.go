@@ -42,3 +42,19 @@ func (_m *AccessTokenGenerator) GenerateAnonymous(ctxReq context.Context) <-chan
 
 	return r0
 }
+
+// GenerateIDToken provides a mock function with given fields: cl
+func (_m *AccessTokenGenerator) GenerateIDToken(cl token.IDTokenClaim) <-chan token.AccessTokenResponse {
+	ret := _m.Called(cl)
+
+	var r0 <-chan token.AccessTokenResponse
+	if rf, ok := ret.Get(0).(func(token.IDTokenClaim) <-chan token.AccessTokenResponse); ok {
+		r0 = rf(cl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan token.AccessTokenResponse)
+		}
+	}
+
+	return r0
+}
@@ -1,35 +1,31 @@
 package token
 
 import (
-	"crypto/rsa"
 	"errors"
 	"fmt"
 
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/golang-jwt/jwt"
 )
 
 //BearerClaims data structure
 type BearerClaims struct {
-	DeviceID       string `json:"did"`
-	DeviceLogin    string `json:"dli"`
-	Email          string `json:"email"`
-	UserAuthorized bool   `json:"authorised"`
-	IsAdmin        bool   `json:"adm"`
-	MemberType     string `json:"memberType"`
+	DeviceID       string   `json:"did"`
+	DeviceLogin    string   `json:"dli"`
+	Email          string   `json:"email"`
+	UserAuthorized bool     `json:"authorised"`
+	IsAdmin        bool     `json:"adm"`
+	MemberType     string   `json:"memberType"`
+	Scope          string   `json:"scope,omitempty"`
+	Permissions    []string `json:"perm,omitempty"`
 	jwt.StandardClaims
 }
 
 // VerifyTokenIgnoreExpiration function for verifying token and ignore expiration
-func VerifyTokenIgnoreExpiration(rsaPublicKey *rsa.PublicKey, oldAccessToken string) (*BearerClaims, error) {
+func VerifyTokenIgnoreExpiration(keys *rsa.KeySet, oldAccessToken string) (*BearerClaims, error) {
 	var errorStr error
 
-	token, err := jwt.ParseWithClaims(oldAccessToken, &BearerClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			errorStr = fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			return nil, errorStr
-		}
-		return rsaPublicKey, nil
-	})
+	token, err := jwt.ParseWithClaims(oldAccessToken, &BearerClaims{}, keys.Keyfunc)
 
 	if token == nil {
 		errorStr = errors.New("invalid old token")
@@ -52,3 +48,17 @@ func VerifyTokenIgnoreExpiration(rsaPublicKey *rsa.PublicKey, oldAccessToken str
 	errorStr = errors.New("unknown errors")
 	return nil, errorStr
 }
+
+// VerifyToken function for verifying token signature and expiration
+func VerifyToken(keys *rsa.KeySet, accessToken string) (*BearerClaims, error) {
+	token, err := jwt.ParseWithClaims(accessToken, &BearerClaims{}, keys.Keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*BearerClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
+	"math"
+	"net/http"
+	"sort"
 	"time"
 
 	"github.com/Bhinneka/golib/tracer"
@@ -12,119 +14,231 @@ import (
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 )
 
-// checkAttempt function for saving login attempt data to redis based on email for 24 hours
-func (au *AuthUseCaseImpl) checkAttempt(ctxReq context.Context, email string) <-chan ResultUseCase {
-	ctx := "AuthUseCase-checkAttempt"
+// checkLockout function for refusing a password login while the email is locked or has to wait after failed logins,
+// when redis can not be read the login goes on
+func (au *AuthUseCaseImpl) checkLockout(ctxReq context.Context, email string) (int, error) {
+	ctx := "AuthUseCase-checkLockout"
+
+	lockoutResult := <-au.LockoutRepo.Load(ctxReq, email)
+	if lockoutResult.Error != nil {
+		if lockoutResult.Error.Error() != helper.ErrorRedis {
+			helper.SendErrorLog(ctxReq, ctx, "load_lockout", lockoutResult.Error, email)
+		}
+		return http.StatusOK, nil
+	}
+
+	lockout, ok := lockoutResult.Result.(model.Lockout)
+	if !ok {
+		return http.StatusOK, nil
+	}
+
+	return lockoutError(&lockout, time.Now())
+}
+
+// registerLoginFailure function for counting a wrong password, it always returns the error shown to the user
+func (au *AuthUseCaseImpl) registerLoginFailure(ctxReq context.Context, data *model.RequestToken) (int, error) {
+	ctx := "AuthUseCase-registerLoginFailure"
+	errInvalid := errors.New(model.ErrorInvalidUsernameOrPasswordBahasa)
+
+	var (
+		now    time.Time
+		locked bool
+	)
+	policyName := au.lockoutPolicyName(data.MemberType)
+	policy := au.LockoutPolicies.Policy(policyName)
+	window := au.loginAttemptWindow()
+
+	// counted on the stored record in one step, concurrent failures cannot overwrite each other
+	updateResult := <-au.LockoutRepo.Update(ctxReq, data.Email, func(lockout *model.Lockout) time.Duration {
+		now = time.Now()
+		lockout.Email = data.Email
+		lockout.MemberType = policyName
+		locked = lockout.RegisterFailure(policy, window, now)
+		return lockout.Age(policy, window, now)
+	})
+	if updateResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_lockout", updateResult.Error, data.Email)
+		return http.StatusBadRequest, errInvalid
+	}
+
+	lockout, ok := updateResult.Result.(model.Lockout)
+	if !ok {
+		return http.StatusBadRequest, errInvalid
+	}
+
+	if locked {
+		au.publishLockoutEvent(ctxReq, model.SecurityEventAccountLocked, &lockout, data, "")
+		return lockoutError(&lockout, now)
+	}
+
+	return http.StatusBadRequest, errInvalid
+}
+
+// clearLoginFailures function for forgetting failed logins after a successful login,
+// returns how many there were
+func (au *AuthUseCaseImpl) clearLoginFailures(ctxReq context.Context, email string) int {
+	failures := au.loginFailures(ctxReq, email)
+	<-au.LockoutRepo.Delete(ctxReq, email)
+	return failures
+}
+
+// loginFailures function for counting the failed logins of an email, a lock counts as all the failures it took
+func (au *AuthUseCaseImpl) loginFailures(ctxReq context.Context, email string) int {
+	lockoutResult := <-au.LockoutRepo.Load(ctxReq, email)
+	lockout, ok := lockoutResult.Result.(model.Lockout)
+	if lockoutResult.Error != nil || !ok {
+		return 0
+	}
+	return lockout.Failures + lockout.Locks*au.LockoutPolicies.Policy(lockout.MemberType).MaxAttempts
+}
+
+// lockoutPolicyName function for getting the lockout policy of the member type sent at login
+func (au *AuthUseCaseImpl) lockoutPolicyName(memberType string) string {
+	switch {
+	case memberType == model.UserTypeCorporate:
+		return model.LockoutPolicyCorporate
+	case memberType == model.UserTypeClientMicrosite, au.isMicrositeClient(memberType):
+		return model.LockoutPolicyMicrosite
+	}
+	return model.LockoutPolicyPersonal
+}
+
+// loginAttemptWindow function for getting how long failed logins are counted
+func (au *AuthUseCaseImpl) loginAttemptWindow() time.Duration {
+	window, err := time.ParseDuration(au.LoginAttemptAge)
+	if err != nil || window <= 0 {
+		return model.DefaultLockoutAttemptWindow
+	}
+	return window
+}
+
+func (au *AuthUseCaseImpl) publishLockoutEvent(ctxReq context.Context, eventType string, lockout *model.Lockout, data *model.RequestToken, unlockedBy string) {
+	payload := model.SecurityEventPayload{
+		Subject:    lockout.Email,
+		MemberType: lockout.MemberType,
+		Locks:      lockout.Locks,
+		UnlockedBy: unlockedBy,
+	}
+	if !lockout.LockedUntil.IsZero() {
+		lockedUntil := lockout.LockedUntil
+		payload.LockedUntil = &lockedUntil
+	}
+	if data != nil {
+		payload.DeviceID = data.DeviceID
+		payload.DeviceLogin = data.DeviceLogin
+		payload.IP = data.IP
+		payload.UserAgent = data.UserAgent
+	}
+
+	go au.PublishSecurityEvent(ctxReq, model.NewSecurityEvent(eventType, payload))
+}
+
+// GetLockouts function for listing emails with failed logins or a lock, latest failure first
+func (au *AuthUseCaseImpl) GetLockouts(ctxReq context.Context) <-chan ResultUseCase {
+	ctx := "AuthUseCase-GetLockouts"
 
 	output := make(chan ResultUseCase)
 	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
 		defer close(output)
-		key := fmt.Sprintf(keyAttempt, email)
-		tags[helper.TextParameter] = key
-		attemptAge, err := time.ParseDuration(au.LoginAttemptAge)
-		if err != nil {
-			errC := errors.New("failed to check login attempt")
-			output <- ResultUseCase{Error: errC}
-			return
-		}
 
-		attempt, err := au.checkAttemptCount(ctxReq, email, attemptAge, key)
-		if err != nil {
-			output <- ResultUseCase{Error: err}
+		lockoutResult := <-au.LockoutRepo.FindAll(ctxReq)
+		if lockoutResult.Error != nil {
+			output <- ResultUseCase{Error: lockoutResult.Error, HTTPStatus: http.StatusInternalServerError}
 			return
 		}
 
-		strAttempt := strconv.Itoa(attempt)
-		newData := model.LoginAttempt{
-			Key:             key,
-			Attempt:         strAttempt,
-			LoginAttemptAge: attemptAge,
+		lockouts, _ := lockoutResult.Result.([]model.Lockout)
+		now := time.Now()
+		for i := range lockouts {
+			lockouts[i].Locked = lockouts[i].IsLocked(now)
 		}
+		sort.Slice(lockouts, func(i, j int) bool {
+			return lockouts[i].LastFailure.After(lockouts[j].LastFailure)
+		})
 
-		saveResult := <-au.LoginAttemptRepo.Save(ctxReq, &newData)
-		if saveResult.Error != nil {
-			helper.SendErrorLog(ctxReq, ctx, "save_login_attemp", err, email)
-			output <- ResultUseCase{Error: saveResult.Error}
-			return
-		}
-
-		output <- ResultUseCase{Error: errors.New("failed to login")}
-
+		tags[helper.TextResponse] = len(lockouts)
+		output <- ResultUseCase{Result: lockouts}
 	})
 
 	return output
 }
 
-// checkAttemptCount function for get attempt count
-func (au *AuthUseCaseImpl) checkAttemptCount(ctxReq context.Context, email string, attemptAge time.Duration, key string) (int, error) {
-	ctx := "AuthUseCase-checkAttemptCount"
-	attempt := 1
-	// get attempt first then increment the number
-	getAttempt := <-au.getAttempt(ctxReq, key, attempt, attemptAge)
-	if getAttempt.Error != nil {
-		if getAttempt.Error.Error() == helper.ErrorRedis {
-			helper.SendErrorLog(ctxReq, ctx, "parse_login_attempt", getAttempt.Error, email)
-		}
+// GetLockout function for getting failed logins and lock state of an email
+func (au *AuthUseCaseImpl) GetLockout(ctxReq context.Context, email string) <-chan ResultUseCase {
+	ctx := "AuthUseCase-GetLockout"
 
-		err := errors.New("result is not login attempt")
-		return attempt, err
-	}
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
 
-	data := getAttempt.Result.(model.LoginAttempt)
-	if len(data.Attempt) > 0 && data.Attempt != "0" {
-		intAttempt, _ := strconv.Atoi(data.Attempt)
+		tags[helper.TextEmail] = email
+		lockout, httpStatus, err := au.loadLockout(ctxReq, email)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
 
-		// by pass when attempt reach 10 times
-		if intAttempt == 9 {
-			updateResult := <-au.MemberQueryWrite.UpdateBlockedMember(email)
-			if updateResult.Error != nil {
-				helper.SendErrorLog(ctxReq, ctx, "update_member_login_attempt", updateResult.Error, email)
-				return attempt, updateResult.Error
-			}
+		output <- ResultUseCase{Result: lockout}
+	})
 
-			err := errors.New(model.ErrorAccountBlockedBahasa)
-			return attempt, err
-		}
-		attempt = intAttempt + 1
-	}
-	return attempt, nil
+	return output
 }
 
-// getAttempt function for get attempt exist
-func (au *AuthUseCaseImpl) getAttempt(ctxReq context.Context, key string, attempt int, attemptAge time.Duration) <-chan ResultUseCase {
-	ctx := "AuthUseCase-getAttempt"
+// ClearLockout function for unlocking an email and forgetting its failed logins
+func (au *AuthUseCaseImpl) ClearLockout(ctxReq context.Context, email, adminID string) <-chan ResultUseCase {
+	ctx := "AuthUseCase-ClearLockout"
+
 	output := make(chan ResultUseCase)
 	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
-		attResult := <-au.LoginAttemptRepo.Load(ctxReq, key)
-		if attResult.Error != nil {
-			if attResult.Error.Error() != helper.ErrorRedis {
-				helper.SendErrorLog(ctxReq, ctx, "get_login_attempt", attResult.Error, key)
-				output <- ResultUseCase{Error: attResult.Error}
-				return
-			}
-
-			// when redis nil
-			strAttempt := strconv.Itoa(attempt)
-			newData := model.LoginAttempt{
-				Key:             key,
-				Attempt:         strAttempt,
-				LoginAttemptAge: attemptAge,
-			}
-
-			tags[helper.TextParameter] = newData
-			saveResult := <-au.LoginAttemptRepo.Save(ctxReq, &newData)
-			if saveResult.Error != nil {
-				helper.SendErrorLog(ctxReq, ctx, "save_login_attempt", saveResult.Error, newData)
-				output <- ResultUseCase{Error: saveResult.Error}
-				return
-			}
-
-			output <- ResultUseCase{Error: attResult.Error}
+		defer close(output)
+
+		tags[helper.TextEmail] = email
+		lockout, httpStatus, err := au.loadLockout(ctxReq, email)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
+		deleteResult := <-au.LockoutRepo.Delete(ctxReq, email)
+		if deleteResult.Error != nil {
+			output <- ResultUseCase{Error: deleteResult.Error, HTTPStatus: http.StatusInternalServerError}
 			return
 		}
 
-		data := attResult.Result.(model.LoginAttempt)
-		output <- ResultUseCase{Result: data}
+		au.publishLockoutEvent(ctxReq, model.SecurityEventAccountUnlocked, &lockout, nil, adminID)
+		output <- ResultUseCase{Result: lockout}
 	})
+
 	return output
 }
+
+func (au *AuthUseCaseImpl) loadLockout(ctxReq context.Context, email string) (model.Lockout, int, error) {
+	lockoutResult := <-au.LockoutRepo.Load(ctxReq, email)
+	if lockoutResult.Error != nil {
+		if lockoutResult.Error.Error() == helper.ErrorRedis {
+			return model.Lockout{}, http.StatusNotFound, errors.New(model.ErrorLockoutNotFound)
+		}
+		return model.Lockout{}, http.StatusInternalServerError, lockoutResult.Error
+	}
+
+	lockout, ok := lockoutResult.Result.(model.Lockout)
+	if !ok {
+		return model.Lockout{}, http.StatusNotFound, errors.New(model.ErrorLockoutNotFound)
+	}
+	lockout.Locked = lockout.IsLocked(time.Now())
+
+	return lockout, http.StatusOK, nil
+}
+
+// lockoutError error shown while the login has to wait, a lock keeps the status of the old blocked account error
+func lockoutError(lockout *model.Lockout, now time.Time) (int, error) {
+	wait := lockout.Wait(now)
+	if wait <= 0 {
+		return http.StatusOK, nil
+	}
+
+	if lockout.IsLocked(now) {
+		return http.StatusBadRequest, fmt.Errorf(model.ErrorAccountLockedBahasa, int(math.Ceil(wait.Minutes())))
+	}
+	return http.StatusTooManyRequests, fmt.Errorf(model.ErrorLoginDelayBahasa, int(math.Ceil(wait.Seconds())))
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const lockoutEmail = "john@bhinneka.com"
+
+func generateLockoutResult(data repo.ResultRepository) <-chan repo.ResultRepository {
+	output := make(chan repo.ResultRepository, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestAuthUseCaseImpl_registerLoginFailure(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		memberType   string
+		stored       model.Lockout
+		updateErr    error
+		wantStatus   int
+		wantErr      string
+		wantFailures int
+		wantLocked   bool
+	}{
+		{
+			name:         "Case 1: first failure",
+			wantStatus:   http.StatusBadRequest,
+			wantErr:      model.ErrorInvalidUsernameOrPasswordBahasa,
+			wantFailures: 1,
+		},
+		{
+			name:       "Case 2: personal reaches max attempts",
+			stored:     model.Lockout{Email: lockoutEmail, Failures: 9, LastFailure: now},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    fmt.Sprintf(model.ErrorAccountLockedBahasa, 5),
+			wantLocked: true,
+		},
+		{
+			name:       "Case 3: corporate is locked sooner",
+			memberType: model.UserTypeCorporate,
+			stored:     model.Lockout{Email: lockoutEmail, Failures: 4, LastFailure: now},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    fmt.Sprintf(model.ErrorAccountLockedBahasa, 15),
+			wantLocked: true,
+		},
+		{
+			name:         "Case 4: personal keeps counting",
+			stored:       model.Lockout{Email: lockoutEmail, Failures: 4, LastFailure: now},
+			wantStatus:   http.StatusBadRequest,
+			wantErr:      model.ErrorInvalidUsernameOrPasswordBahasa,
+			wantFailures: 5,
+		},
+		{
+			name:       "Case 5: redis error does not count",
+			updateErr:  errors.New("connection refused"),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    model.ErrorInvalidUsernameOrPasswordBahasa,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *model.Lockout
+			lockoutRepo := new(mocksRepo.LockoutRepository)
+			lockoutRepo.On("Update", mock.Anything, lockoutEmail, mock.Anything).Return(
+				func(ctxReq context.Context, email string, update func(*model.Lockout) time.Duration) <-chan repo.ResultRepository {
+					if tt.updateErr != nil {
+						return generateLockoutResult(repo.ResultRepository{Error: tt.updateErr})
+					}
+					lockout := tt.stored
+					update(&lockout)
+					saved = &lockout
+					return generateLockoutResult(repo.ResultRepository{Result: lockout})
+				})
+			publisher := new(mocksService.QPublisher)
+			publisher.On("PublishKafka", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			au := &AuthUseCaseImpl{LockoutRepo: lockoutRepo, QPublisher: publisher, LoginAttemptAge: "5m"}
+			httpStatus, err := au.registerLoginFailure(context.Background(), &model.RequestToken{Email: lockoutEmail, MemberType: tt.memberType})
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			assert.EqualError(t, err, tt.wantErr)
+
+			if tt.updateErr != nil {
+				assert.Nil(t, saved)
+				return
+			}
+			assert.Equal(t, tt.wantFailures, saved.Failures)
+			assert.Equal(t, tt.wantLocked, saved.IsLocked(time.Now()))
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_checkLockout(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		loadResult repo.ResultRepository
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: no failed login",
+			loadResult: repo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 2: locked",
+			loadResult: repo.ResultRepository{Result: model.Lockout{LockedUntil: now.Add(time.Minute)}},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "Case 3: waiting after failed logins",
+			loadResult: repo.ResultRepository{Result: model.Lockout{RetryAfter: now.Add(time.Second)}},
+			wantStatus: http.StatusTooManyRequests,
+			wantErr:    true,
+		},
+		{
+			name:       "Case 4: lock expired",
+			loadResult: repo.ResultRepository{Result: model.Lockout{LockedUntil: now.Add(-time.Minute)}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 5: redis down lets the login go on",
+			loadResult: repo.ResultRepository{Error: errors.New("connection refused")},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockoutRepo := new(mocksRepo.LockoutRepository)
+			lockoutRepo.On("Load", mock.Anything, lockoutEmail).Return(generateLockoutResult(tt.loadResult))
+
+			au := &AuthUseCaseImpl{LockoutRepo: lockoutRepo}
+			httpStatus, err := au.checkLockout(context.Background(), lockoutEmail)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ClearLockout(t *testing.T) {
+	tests := []struct {
+		name       string
+		loadResult repo.ResultRepository
+		wantStatus int
+		wantDelete bool
+	}{
+		{
+			name:       "Case 1: Success",
+			loadResult: repo.ResultRepository{Result: model.Lockout{Email: lockoutEmail, Locks: 1, LockedUntil: time.Now().Add(time.Minute)}},
+			wantDelete: true,
+		},
+		{
+			name:       "Case 2: Error not found",
+			loadResult: repo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockoutRepo := new(mocksRepo.LockoutRepository)
+			lockoutRepo.On("Load", mock.Anything, lockoutEmail).Return(generateLockoutResult(tt.loadResult))
+			lockoutRepo.On("Delete", mock.Anything, lockoutEmail).Return(generateLockoutResult(repo.ResultRepository{Result: int64(1)}))
+			publisher := new(mocksService.QPublisher)
+			publisher.On("PublishKafka", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			au := &AuthUseCaseImpl{LockoutRepo: lockoutRepo, QPublisher: publisher}
+			result := <-au.ClearLockout(context.Background(), lockoutEmail, "USR123")
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantDelete {
+				lockoutRepo.AssertCalled(t, "Delete", mock.Anything, lockoutEmail)
+				lockout, _ := result.Result.(model.Lockout)
+				assert.True(t, lockout.Locked)
+			} else {
+				lockoutRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -4,31 +4,37 @@ import (
 	"net/url"
 
 	localConfig "github.com/Bhinneka/user-service/config"
+	"github.com/Bhinneka/user-service/config/rsa"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	"github.com/Bhinneka/user-service/src/auth/v1/query"
 	"github.com/Bhinneka/user-service/src/auth/v1/repo"
 	authServices "github.com/Bhinneka/user-service/src/auth/v1/service"
 	"github.com/Bhinneka/user-service/src/auth/v1/token"
 	corporateQuery "github.com/Bhinneka/user-service/src/corporate/v2/query"
+	corporateRepo "github.com/Bhinneka/user-service/src/corporate/v2/repo"
 	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
 	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
 	memberRepo "github.com/Bhinneka/user-service/src/member/v1/repo"
 	merchantRepoRead "github.com/Bhinneka/user-service/src/merchant/v2/repo"
 	"github.com/Bhinneka/user-service/src/service"
+	sessionInfoQuery "github.com/Bhinneka/user-service/src/session/v1/query"
 	sessionInfoRepo "github.com/Bhinneka/user-service/src/session/v1/repo"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 )
 
 const (
 	scopeErrorParseSocmed             = "error_parse_socmed_data"
 	scopeParseLdap                    = "parse_ldap"
 	scopeValidateRequestTokenFacebook = "validate_request_token_facebook"
-	scopeGetPublicKey                 = "get_public_key"
 	scopeValidateToken                = "validate_token"
 	msgErrorEmailRegisterAccount      = "cannot register your account, your email is hidden"
 	msgResultNotMember                = "result is not member"
 	msgResultNotAccount               = "result is not account"
 	msgFailedLoginSocmedEmail         = "failed to login, email doesn't match with your session email"
 	msgFailedToSendEmail              = "failed to send email"
-	keyAttempt                        = "ATTEMPT:%s"
 	msgTokenExpired                   = "this token has been expired"
 	textGetTokenPass                  = "auth_get_token_pass_params"
 	textGetToken                      = "auth_get_token_params"
@@ -49,9 +55,11 @@ type AuthUseCaseImpl struct {
 	CorporateContactQueryRead    corporateQuery.ContactQuery
 	CorporateAccContactQueryRead corporateQuery.AccountContactQuery
 	RefreshTokenRepo             repo.RefreshTokenRepository
+	AuthorizationCodeRepo        repo.AuthorizationCodeRepository
 	LoginAttemptRepo             repo.AttemptRepository
 	LoginSessionRepo             repo.LoginSessionRepository
 	AccessTokenGenerator         token.AccessTokenGenerator
+	KeySet                       *rsa.KeySet
 	Hash                         memberModel.PasswordHasher
 	RefreshTokenAge              string
 	SpecialRefreshTokenAge       string
@@ -75,6 +83,41 @@ type AuthUseCaseImpl struct {
 	ActivityService        service.ActivityServices
 	B2cCFUrl               string
 	NotificationService    service.NotificationServices
+
+	// OpenID Connect provider
+	OIDCIssuer string
+
+	// WebAuthn passkeys
+	MemberPasskeyRepo memberRepo.MemberPasskeyRepository
+	WebAuthn          *webauthn.RelyingParty
+
+	// MFA recovery codes
+	MemberMFARecoveryRepo memberRepo.MemberMFARecoveryRepository
+
+	// MFA one time codes sent by email or sms
+	MemberMFAMethodRepo memberRepo.MemberMFAMethodRepository
+	MFAOTP              *mfaotp.Issuer
+
+	// failed login lockout
+	LockoutRepo     repo.LockoutRepository
+	LockoutPolicies model.LockoutPolicies
+
+	// suspicious login detection
+	SessionInfoQuery sessionInfoQuery.SessionInfoQuery
+	RiskPolicy       model.RiskPolicy
+	GeoLocator       model.GeoLocator
+
+	// captcha of password logins, nil when no provider is set
+	Captcha *captcha.Guard
+
+	// social login with identity providers added as oidc connectors
+	Connectors         *connector.Registry
+	MemberIdentityRepo memberRepo.MemberIdentityRepository
+
+	// SAML single sign on of corporate accounts
+	AccountSAMLQuery corporateQuery.AccountSAMLQuery
+	AccountSAMLRepo  corporateRepo.AccountSAMLRepository
+	SAMLBaseURL      string
 }
 
 // NewAuthUseCase function for initialise auth use case implmentation model
@@ -92,6 +135,7 @@ func NewAuthUseCase(repository localConfig.ServiceRepository,
 		MemberRepoRead:               repository.MemberRepository,
 		MemberRepoWrite:              repository.MemberRepository,
 		RefreshTokenRepo:             repository.RefreshTokenRepository,
+		AuthorizationCodeRepo:        repository.AuthorizationCodeRepository,
 		LoginAttemptRepo:             repository.AttemptRepositoryRedis,
 		LoginSessionRepo:             repository.LoginSessionRepositoryRedis,
 		SessionInfoRepo:              repository.SessionInfoRepo,
@@ -108,6 +152,7 @@ func NewAuthUseCase(repository localConfig.ServiceRepository,
 		AuthServices:                 params.AuthServices,
 		GoogleVerifyCaptchaURL:       params.GoogleVerifyCaptchaURL,
 		AccessTokenGenerator:         params.AccessTokenGenerator,
+		KeySet:                       params.KeySet,
 		RefreshTokenAge:              params.RefreshTokenAge,
 		B2cCFUrl:                     params.B2cCFUrl,
 		LoginAttemptAge:              params.LoginAttemptAge,
@@ -116,5 +161,22 @@ func NewAuthUseCase(repository localConfig.ServiceRepository,
 		NotificationService:          services.NotificationService,
 		SpecialRefreshTokenAge:       params.SpecialRefreshTokenAge,
 		EmailSpecialTokenAge:         params.EmailSpecialTokenAge,
+		OIDCIssuer:                   params.OIDCIssuer,
+		MemberPasskeyRepo:            repository.MemberPasskeyRepository,
+		MemberMFARecoveryRepo:        repository.MemberMFARecoveryRepository,
+		WebAuthn:                     params.WebAuthn,
+		MemberMFAMethodRepo:          repository.MemberMFAMethodRepository,
+		MFAOTP:                       params.MFAOTP,
+		LockoutRepo:                  repository.LockoutRepositoryRedis,
+		LockoutPolicies:              params.LockoutPolicies,
+		SessionInfoQuery:             queryParam.SessionInfoQuery,
+		RiskPolicy:                   params.RiskPolicy,
+		GeoLocator:                   params.GeoLocator,
+		Captcha:                      params.Captcha,
+		Connectors:                   params.Connectors,
+		MemberIdentityRepo:           repository.MemberIdentityRepository,
+		AccountSAMLQuery:             queryParam.CorporateAccountSAMLQuery,
+		AccountSAMLRepo:              repository.CorporateAccountSAMLRepository,
+		SAMLBaseURL:                  params.SAMLBaseURL,
 	}
 }
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/Bhinneka/golib/tracer"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
 	"github.com/labstack/echo"
 )
 
@@ -59,3 +62,17 @@ func (au *AuthUseCaseImpl) VerifyCaptcha(ctxReq context.Context, data model.Goog
 
 	return output
 }
+
+// checkLoginCaptcha function for asking a captcha on password logins after failed logins of the email,
+// a captcha sent before that is verified as well
+func (au *AuthUseCaseImpl) checkLoginCaptcha(ctxReq context.Context, data *model.RequestToken) (int, error) {
+	if au.Captcha == nil || data.GrantType != model.AuthTypePassword {
+		return http.StatusOK, nil
+	}
+
+	captchaData := serviceModel.Captcha{Response: data.CaptchaResponse, RemoteIP: data.IP, Action: captcha.ActionLogin}
+	if err := au.Captcha.Check(ctxReq, captchaData, au.loginFailures(ctxReq, data.Email)); err != nil {
+		return captcha.HTTPStatus(err), err
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/service"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUseCaseImpl_checkLoginCaptcha(t *testing.T) {
+	email := "john@bhinneka.com"
+	failed := repo.ResultRepository{Result: model.Lockout{Email: email, Failures: 3}}
+
+	tests := []struct {
+		name       string
+		data       model.RequestToken
+		loadResult repo.ResultRepository
+		wantStatus int
+		wantErr    error
+	}{
+		{
+			name:       "Case 1: No failed login",
+			data:       model.RequestToken{GrantType: model.AuthTypePassword},
+			loadResult: repo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 2: Captcha required after failed logins",
+			data:       model.RequestToken{GrantType: model.AuthTypePassword},
+			loadResult: failed,
+			wantStatus: http.StatusPreconditionRequired,
+			wantErr:    captcha.ErrRequired,
+		},
+		{
+			name:       "Case 3: Solved captcha",
+			data:       model.RequestToken{GrantType: model.AuthTypePassword, CaptchaResponse: "solved"},
+			loadResult: failed,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 4: Refused captcha",
+			data:       model.RequestToken{GrantType: model.AuthTypePassword, CaptchaResponse: "fail"},
+			loadResult: failed,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    captcha.ErrInvalid,
+		},
+		{
+			name:       "Case 5: Other grant",
+			data:       model.RequestToken{GrantType: model.AuthTypeRefreshToken},
+			loadResult: failed,
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.Email = email
+			lockoutRepo := new(mocksRepo.LockoutRepository)
+			lockoutRepo.On("Load", mock.Anything, email).Return(generateLockoutResult(tt.loadResult))
+
+			au := &AuthUseCaseImpl{
+				LockoutRepo: lockoutRepo,
+				Captcha:     captcha.NewGuard(service.NewCaptchaFakeService(), nil, captcha.DefaultPolicy),
+			}
+			httpStatus, err := au.checkLoginCaptcha(context.Background(), &tt.data)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
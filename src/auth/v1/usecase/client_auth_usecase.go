@@ -121,8 +121,6 @@ func (au *AuthUseCaseImpl) RequestTokenPasswordTypeMicrositeBela(ctxReq context.
 		return member, http.StatusUnauthorized, err
 	}
 
-	key := fmt.Sprintf(keyAttempt, member.Email)
-
-	<-au.LoginAttemptRepo.Delete(ctxReq, key)
+	au.clearLoginFailures(ctxReq, member.Email)
 	return member, 200, nil
 }
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+)
+
+// issueClientCredentialsToken function for issuing short lived service to service access token of a client app,
+// every token gets its own login session so it can be introspected and revoked alone
+func (au *AuthUseCaseImpl) issueClientCredentialsToken(ctxReq context.Context, clientApp model.ClientApp, data model.OIDCTokenRequest) (model.OIDCTokenResponse, *model.OAuthError) {
+	ctx := "AuthUseCase-ExchangeToken-issueClientCredentialsToken"
+
+	// public clients cannot prove who they are
+	if clientApp.IsPublic() {
+		return model.OIDCTokenResponse{}, model.NewOAuthError(model.OAuthErrorInvalidClient, "client authentication failed", http.StatusUnauthorized)
+	}
+
+	if len(clientApp.Scopes) == 0 {
+		return model.OIDCTokenResponse{}, model.NewOAuthError(model.OAuthErrorUnauthorizedClient, "client is not allowed to use client_credentials", http.StatusBadRequest)
+	}
+
+	scope, ok := clientApp.GrantScope(data.Scope)
+	if !ok {
+		return model.OIDCTokenResponse{}, model.NewOAuthError(model.OAuthErrorInvalidScope, "requested scope is not allowed for this client", http.StatusBadRequest)
+	}
+
+	claims := token.Claim{
+		Issuer:      model.Bhinneka,
+		Audience:    clientApp.ClientID,
+		Subject:     clientApp.ClientID,
+		DeviceID:    model.ClientCredentialsDevicePrefix + helper.RandomStringBase64(12),
+		DeviceLogin: model.ClientCredentialsDeviceLogin,
+		Authorised:  false,
+		Scope:       scope,
+		TokenAge:    model.ClientCredentialsTokenAge,
+	}
+
+	tokenResult := <-au.AccessTokenGenerator.GenerateAccessToken(claims)
+	if tokenResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "generate_access_token", tokenResult.Error, clientApp.ClientID)
+		return model.OIDCTokenResponse{}, model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+	}
+
+	accessToken := tokenResult.AccessToken
+	paramRedis := &model.LoginSessionRedis{
+		Key:         getLoginSessionKey(claims.Subject, claims.DeviceID, claims.DeviceLogin),
+		Token:       accessToken.AccessToken,
+		ExpiredTime: time.Until(accessToken.ExpiredAt),
+	}
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		return model.OIDCTokenResponse{}, model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusServiceUnavailable)
+	}
+
+	return model.OIDCTokenResponse{
+		AccessToken: accessToken.AccessToken,
+		TokenType:   model.TokenTypeBearer,
+		ExpiresIn:   int64(time.Until(accessToken.ExpiredAt).Seconds()),
+		Scope:       scope,
+	}, nil
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	uuid "github.com/satori/go.uuid"
+)
+
+// connectorGrantTypes social login grants that move to the oidc connector of the same name once it is configured
+var connectorGrantTypes = []string{model.AuthTypeFacebook, model.AuthTypeGoogle, model.AuthTypeApple, model.AuthTypeAzure}
+
+// socialMediaProviders identity provider of the social login grants that do not use a connector
+var socialMediaProviders = map[string]string{
+	model.AuthTypeFacebook:      memberModel.IdentityProviderFacebook,
+	model.AuthTypeGoogle:        memberModel.IdentityProviderGoogle,
+	model.AuthTypeGoogleBackend: memberModel.IdentityProviderGoogle,
+	model.AuthTypeGoogleOAauth:  memberModel.IdentityProviderGoogle,
+	model.AuthTypeApple:         memberModel.IdentityProviderApple,
+	model.AuthTypeAzure:         memberModel.IdentityProviderAzure,
+	model.AuthTypeLDAP:          memberModel.IdentityProviderLDAP,
+}
+
+// GetConnectors function for listing identity providers added as oidc connectors
+func (au *AuthUseCaseImpl) GetConnectors() []connector.Info {
+	return au.Connectors.List()
+}
+
+// AuthorizeConnector function for starting a login with an oidc connector, the member is redirected to the
+// authorization url and comes back to the redirect uri with the code and state of the oidc grant
+func (au *AuthUseCaseImpl) AuthorizeConnector(ctxReq context.Context, provider, redirectURI string) <-chan ResultUseCase {
+	ctx := "AuthUseCase-AuthorizeConnector"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		if redirectURI == "" {
+			output <- ResultUseCase{Error: fmt.Errorf(helper.ErrorParameterRequired, "redirectUri"), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		authorization, err := au.Connectors.Authorize(ctxReq, provider, redirectURI)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: connector.HTTPStatus(err)}
+			return
+		}
+
+		tags["provider"] = provider
+		output <- ResultUseCase{Result: authorization}
+	})
+
+	return output
+}
+
+// isConnectorGrant function for checking whether the social login grant is handled by an oidc connector
+func (au *AuthUseCaseImpl) isConnectorGrant(grantType string) bool {
+	if !helper.StringInSlice(grantType, connectorGrantTypes) {
+		return false
+	}
+	_, ok := au.Connectors.Get(grantType)
+	return ok
+}
+
+// parseConnectorType function for login with an oidc connector, the member is found by the linked identity
+// and otherwise by the verified email of the provider, the identity is linked to the member after the login
+func (au *AuthUseCaseImpl) parseConnectorType(ctxReq context.Context, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
+	ctx := "AuthUseCase-GenerateToken-parseConnectorType"
+
+	identity, err := au.Connectors.Exchange(ctxReq, data.Provider, data.Code, data.State, data.RedirectURI)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "exchange_code", err, data.Provider)
+		return connector.HTTPStatus(err), "", err
+	}
+
+	linked, err := au.findLinkedIdentity(ctxReq, identity.Provider, identity.Subject)
+	if err != nil {
+		return http.StatusInternalServerError, "", err
+	}
+
+	if linked != nil {
+		memberResult := <-au.MemberQueryRead.FindByID(ctxReq, linked.MemberID)
+		member, ok := memberResult.Result.(memberModel.Member)
+		if memberResult.Error != nil || !ok {
+			helper.SendErrorLog(ctxReq, ctx, "find_member", memberResult.Error, linked.MemberID)
+			return http.StatusInternalServerError, "", errors.New(msgResultNotMember)
+		}
+		// the member keeps its email when the email at the provider changed
+		identity.Email = member.Email
+	} else if identity.Email == "" || !identity.EmailVerified {
+		return http.StatusUnauthorized, "", errors.New(model.ErrorConnectorEmail)
+	}
+
+	dataMember := au.CheckMemberSocmedType(ctxReq, data, identity, identity.Email)
+	if dataMember.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "check_member_socmed_type", dataMember.Error, identity)
+		return dataMember.HTTPStatus, "", dataMember.Error
+	}
+
+	// if request coming from version 3 and member not yet register then just return with available data
+	if data.Version == helper.Version3 && dataMember.HTTPStatus == http.StatusForbidden {
+		data.UserID = identity.Subject
+		data.FirstName, data.LastName = connectorNames(identity)
+		data.Email = identity.Email
+		return http.StatusForbidden, "", nil
+	}
+
+	member := dataMember.Data
+	au.linkIdentity(ctxReq, member, identity.Provider, identity.Subject)
+
+	claims.Subject = member.ID
+	claims.Authorised = true
+	claims.IsAdmin = member.IsAdmin
+	claims.IsStaff = member.IsStaff
+	claims.Email = member.Email
+	claims.SignUpFrom = member.SignUpFrom
+
+	data.UserID = member.ID
+	data.Email = member.Email
+	data.FirstName = member.FirstName
+	data.LastName = member.LastName
+	data.Mobile = member.Mobile
+	data.NewMember = dataMember.NewMember
+	data.MFAEnabled = member.MFAEnabled
+
+	if len(member.Password) > 0 {
+		data.HasPassword = true
+	}
+	redisUserID = member.ID
+	return http.StatusOK, redisUserID, nil
+}
+
+// parseConnectorData function for filling member data from the identity of an oidc connector,
+// providers that still have a social media column get the subject there for the member events
+func (au *AuthUseCaseImpl) parseConnectorData(identity connector.Identity, member *memberModel.Member, new bool) error {
+	if new || member.FirstName == "" || member.LastName == "" {
+		member.FirstName, member.LastName = connectorNames(identity)
+	}
+
+	now := time.Now()
+	switch identity.Provider {
+	case memberModel.IdentityProviderFacebook:
+		member.SocialMedia.FacebookID = identity.Subject
+		if new || member.SocialMedia.FacebookConnect.IsZero() {
+			member.SocialMedia.FacebookConnect = now
+		}
+	case memberModel.IdentityProviderGoogle:
+		member.SocialMedia.GoogleID = identity.Subject
+		if new || member.SocialMedia.GoogleConnect.IsZero() {
+			member.SocialMedia.GoogleConnect = now
+		}
+	case memberModel.IdentityProviderApple:
+		member.SocialMedia.AppleID = identity.Subject
+		if new || member.SocialMedia.AppleConnect.IsZero() {
+			member.SocialMedia.AppleConnect = now
+		}
+	case memberModel.IdentityProviderAzure:
+		member.SocialMedia.AzureID = identity.Subject
+	}
+
+	member.Status = memberModel.StringToStatus(memberModel.ActiveString)
+	member.IsStaff = member.IsStaff || identity.Staff
+	member.Email = identity.Email
+	return nil
+}
+
+// findLinkedIdentity function for getting the identity of a provider account, nil when it is not linked yet
+func (au *AuthUseCaseImpl) findLinkedIdentity(ctxReq context.Context, provider, subject string) (*memberModel.Identity, error) {
+	if au.MemberIdentityRepo == nil {
+		return nil, nil
+	}
+
+	identityResult := <-au.MemberIdentityRepo.FindByProviderSubject(ctxReq, provider, subject)
+	if identityResult.Error != nil {
+		if identityResult.Error == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, identityResult.Error
+	}
+
+	identity, ok := identityResult.Result.(memberModel.Identity)
+	if !ok {
+		return nil, errors.New("result is not identity")
+	}
+	return &identity, nil
+}
+
+// linkIdentity function for linking the provider account to the member on its first login, later logins
+// refresh the last use. An account linked to another member is left as it is
+func (au *AuthUseCaseImpl) linkIdentity(ctxReq context.Context, member *memberModel.Member, provider, subject string) {
+	ctx := "AuthUseCase-linkIdentity"
+	if au.MemberIdentityRepo == nil || member == nil || subject == "" {
+		return
+	}
+
+	linked, err := au.findLinkedIdentity(ctxReq, provider, subject)
+	if err != nil {
+		return
+	}
+
+	if linked != nil {
+		if linked.MemberID != member.ID {
+			helper.SendErrorLog(ctxReq, ctx, "identity_linked", errors.New(memberModel.ErrorIdentityLinked), linked.ID)
+			return
+		}
+		<-au.MemberIdentityRepo.UpdateLastUsed(ctxReq, linked.ID)
+		return
+	}
+
+	identity := &memberModel.Identity{
+		ID:       uuid.NewV4().String(),
+		MemberID: member.ID,
+		Provider: provider,
+		Subject:  subject,
+		Email:    member.Email,
+		Created:  time.Now(),
+	}
+	<-au.MemberIdentityRepo.Save(ctxReq, identity)
+}
+
+// linkSocialMediaIdentity function for keeping the identity of a social login grant without connector
+// in member identities, the subject is the one saved in the social media column
+func (au *AuthUseCaseImpl) linkSocialMediaIdentity(ctxReq context.Context, data *model.RequestToken, member *memberModel.Member) {
+	provider, ok := socialMediaProviders[data.GrantType]
+	if !ok || data.Provider != "" {
+		return
+	}
+	au.linkIdentity(ctxReq, member, provider, member.SocialMedia.Subject(provider))
+}
+
+// connectorNames function for getting first and last name of the identity
+func connectorNames(identity connector.Identity) (string, string) {
+	if identity.GivenName != "" {
+		return identity.GivenName, identity.FamilyName
+	}
+
+	names := strings.Split(identity.Name, " ")
+	return names[0], helper.SetLastName(names)
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+)
+
+// identityLinkTokenLength random bytes of the link confirmation token
+const identityLinkTokenLength = 32
+
+// checkIdentityLink function for letting a provider account log in to the member of the same email only when
+// it is linked to the member. An account that is not linked yet gets a confirmation email to the member instead
+// of being merged, ldap accounts are left out as the directory is the source of staff accounts
+func (au *AuthUseCaseImpl) checkIdentityLink(ctxReq context.Context, data *model.RequestToken, socialMedia interface{}, member memberModel.Member) (int, error) {
+	if au.MemberIdentityRepo == nil {
+		return http.StatusOK, nil
+	}
+
+	provider, subject := au.socialIdentity(data, socialMedia, member)
+	if provider == "" || provider == memberModel.IdentityProviderLDAP || subject == "" {
+		return http.StatusOK, nil
+	}
+
+	linked, err := au.findLinkedIdentity(ctxReq, provider, subject)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	if linked != nil {
+		if linked.MemberID != member.ID {
+			return http.StatusConflict, errors.New(memberModel.ErrorIdentityLinked)
+		}
+		return http.StatusOK, nil
+	}
+
+	if err := au.sendIdentityLink(ctxReq, member, provider, subject); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusConflict, fmt.Errorf(model.ErrorIdentityLinkConfirmation, au.providerName(provider))
+}
+
+// socialIdentity function for getting provider and subject of the social login, grants without connector
+// read the subject from the social media column the login would fill
+func (au *AuthUseCaseImpl) socialIdentity(data *model.RequestToken, socialMedia interface{}, member memberModel.Member) (string, string) {
+	if identity, ok := socialMedia.(connector.Identity); ok {
+		return identity.Provider, identity.Subject
+	}
+
+	provider, ok := socialMediaProviders[data.GrantType]
+	if !ok {
+		return "", ""
+	}
+
+	// member is a copy, the login itself still saves the social media data
+	if err := au.processSocialMediaData(data.GrantType, socialMedia, &member, false); err != nil {
+		return provider, ""
+	}
+	return provider, member.SocialMedia.Subject(provider)
+}
+
+// sendIdentityLink function for keeping the provider account until the member confirms the link
+// and sending the confirmation link to the member email
+func (au *AuthUseCaseImpl) sendIdentityLink(ctxReq context.Context, member memberModel.Member, provider, subject string) error {
+	ctx := "AuthUseCase-sendIdentityLink"
+
+	token := helper.RandomStringBase64(identityLinkTokenLength)
+	if token == "" {
+		return errors.New("failed to generate link confirmation")
+	}
+
+	payload, _ := json.Marshal(memberModel.IdentityLink{
+		MemberID: member.ID,
+		Provider: provider,
+		Subject:  subject,
+		Email:    member.Email,
+	})
+	paramRedis := &model.LoginSessionRedis{
+		Key:         memberModel.GetIdentityLinkKey(token),
+		Token:       string(payload),
+		ExpiredTime: memberModel.IdentityLinkAge,
+	}
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_identity_link", saveResult.Error, member.ID)
+		return saveResult.Error
+	}
+
+	if au.NotificationService == nil {
+		return nil
+	}
+
+	name := au.providerName(provider)
+	linkURL := strings.TrimRight(au.B2cCFUrl, "/") + memberModel.IdentityLinkPath + "?token=" + url.QueryEscape(token)
+
+	email := serviceModel.Email{}
+	email.From = serviceModel.NoReply
+	email.FromName = serviceModel.NoReplyName
+	email.To = []string{member.Email}
+	email.ToName = []string{member.FirstName}
+	email.Subject = model.SubjectIdentityLink
+	email.Content = fmt.Sprintf(model.ContentIdentityLink, member.FirstName, name, name, linkURL, linkURL,
+		int(memberModel.IdentityLinkAge/time.Hour))
+	go au.sendIdentityLinkEmail(ctxReq, email)
+	return nil
+}
+
+func (au *AuthUseCaseImpl) sendIdentityLinkEmail(ctxReq context.Context, email serviceModel.Email) {
+	if _, err := au.NotificationService.SendEmail(ctxReq, email); err != nil {
+		helper.SendErrorLog(ctxReq, "AuthUseCase-sendIdentityLinkEmail", "send_email", err, email.To)
+	}
+}
+
+// providerName function for getting the name of the provider shown to the member
+func (au *AuthUseCaseImpl) providerName(provider string) string {
+	if c, ok := au.Connectors.Get(provider); ok {
+		return c.DisplayName
+	}
+	if name, ok := memberModel.IdentityProviderNames[provider]; ok {
+		return name
+	}
+	return provider
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberRepo "github.com/Bhinneka/user-service/src/member/v1/repo"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUseCaseImpl_checkIdentityLink(t *testing.T) {
+	member := memberModel.Member{ID: "USR123", Email: "john@bhinneka.com", FirstName: "John"}
+	google := connector.Identity{Provider: memberModel.IdentityProviderGoogle, Subject: "110248495921238986420"}
+
+	tests := []struct {
+		name         string
+		identity     connector.Identity
+		linkedResult memberRepo.ResultRepository
+		wantStatus   int
+		wantEmail    bool
+	}{
+		{
+			name:         "Case 1: Account linked to the member",
+			identity:     google,
+			linkedResult: memberRepo.ResultRepository{Result: memberModel.Identity{MemberID: member.ID}},
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "Case 2: Account not linked sends confirmation",
+			identity:     google,
+			linkedResult: memberRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantStatus:   http.StatusConflict,
+			wantEmail:    true,
+		},
+		{
+			name:         "Case 3: Account linked to another member",
+			identity:     google,
+			linkedResult: memberRepo.ResultRepository{Result: memberModel.Identity{MemberID: "USR456"}},
+			wantStatus:   http.StatusConflict,
+		},
+		{
+			name:       "Case 4: Ldap account is not checked",
+			identity:   connector.Identity{Provider: memberModel.IdentityProviderLDAP, Subject: "john"},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identityRepo := new(mocksRepoMember.MemberIdentityRepository)
+			identityRepo.On("FindByProviderSubject", mock.Anything, tt.identity.Provider, tt.identity.Subject).
+				Return(generateMemberRepoResult(tt.linkedResult))
+			linkRepo := new(mocksRepo.LoginSessionRepository)
+			linkRepo.On("Save", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+
+			sent := make(chan serviceModel.Email, 1)
+			emailService := new(mocksService.NotificationServices)
+			emailService.On("SendEmail", mock.Anything, mock.Anything).Return("", nil).Run(func(args mock.Arguments) {
+				sent <- args.Get(1).(serviceModel.Email)
+			})
+
+			au := &AuthUseCaseImpl{
+				MemberIdentityRepo:  identityRepo,
+				LoginSessionRepo:    linkRepo,
+				NotificationService: emailService,
+				B2cCFUrl:            "https://www.bhinneka.com/",
+			}
+
+			data := &model.RequestToken{GrantType: model.AuthTypeOIDC, Provider: tt.identity.Provider}
+			status, err := au.checkIdentityLink(context.Background(), data, tt.identity, member)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantStatus != http.StatusOK, err != nil)
+			if !tt.wantEmail {
+				linkRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.Contains(t, err.Error(), "Google")
+			saved := linkRepo.Calls[0].Arguments.Get(1).(*model.LoginSessionRedis)
+			assert.True(t, strings.HasPrefix(saved.Key, memberModel.IdentityLinkKeyRedis+":"))
+			assert.Equal(t, memberModel.IdentityLinkAge, saved.ExpiredTime)
+
+			link := memberModel.IdentityLink{}
+			assert.NoError(t, json.Unmarshal([]byte(saved.Token), &link))
+			assert.Equal(t, memberModel.IdentityLink{
+				MemberID: member.ID,
+				Provider: google.Provider,
+				Subject:  google.Subject,
+				Email:    member.Email,
+			}, link)
+
+			email := <-sent
+			token := strings.TrimPrefix(saved.Key, memberModel.IdentityLinkKeyRedis+":")
+			assert.Equal(t, []string{member.Email}, email.To)
+			assert.Contains(t, email.Content, "https://www.bhinneka.com"+memberModel.IdentityLinkPath+"?token="+token)
+		})
+	}
+}
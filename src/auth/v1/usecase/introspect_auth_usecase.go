@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+)
+
+// IntrospectToken function for rfc 7662 token introspection of access and refresh tokens
+func (au *AuthUseCaseImpl) IntrospectToken(ctxReq context.Context, data model.TokenHintRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-IntrospectToken"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["clientId"] = data.ClientID
+		tags["tokenTypeHint"] = data.TokenTypeHint
+
+		if _, oauthErr := au.authenticateTokenClient(ctxReq, data); oauthErr != nil {
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		response := model.IntrospectionResponse{Active: false}
+		for _, tokenType := range tokenTypeOrder(data.TokenTypeHint) {
+			var active bool
+			if tokenType == model.TokenTypeHintRefreshToken {
+				response, _, active = au.introspectRefreshToken(ctxReq, data.Token)
+			} else {
+				response, _, active = au.introspectAccessToken(ctxReq, data.Token)
+			}
+			if active {
+				break
+			}
+		}
+
+		tags[helper.TextResponse] = response.Active
+		output <- ResultUseCase{Result: response, HTTPStatus: http.StatusOK}
+	})
+
+	return output
+}
+
+// RevokeToken function for rfc 7009 token revocation, unknown or already invalid tokens and tokens
+// of another client are not an error and are left as they are
+func (au *AuthUseCaseImpl) RevokeToken(ctxReq context.Context, data model.TokenHintRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-RevokeToken"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["clientId"] = data.ClientID
+		tags["tokenTypeHint"] = data.TokenTypeHint
+
+		clientApp, oauthErr := au.authenticateTokenClient(ctxReq, data)
+		if oauthErr != nil {
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		for _, tokenType := range tokenTypeOrder(data.TokenTypeHint) {
+			var (
+				introspection model.IntrospectionResponse
+				session       *model.RefreshTokenOwner
+				active        bool
+			)
+			if tokenType == model.TokenTypeHintRefreshToken {
+				introspection, session, active = au.introspectRefreshToken(ctxReq, data.Token)
+			} else {
+				introspection, session, active = au.introspectAccessToken(ctxReq, data.Token)
+			}
+			if !active {
+				continue
+			}
+
+			// rfc 7009 section 2.1, a client can only revoke its own tokens. The request still succeeds
+			// so the response does not tell other clients the token is valid
+			if subtle.ConstantTimeCompare([]byte(introspection.ClientID), []byte(clientApp.ClientID)) != 1 {
+				tags["tokenClientId"] = introspection.ClientID
+				break
+			}
+
+			// both token types end the login session, a refresh token also takes its own key with it
+			sessionResult := <-au.LoginSessionRepo.Delete(ctxReq, getLoginSessionKey(session.Subject, session.DeviceID, session.DeviceLogin))
+			if sessionResult.Error != nil && sessionResult.Error.Error() != helper.ErrorRedis {
+				oauthErr := model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusServiceUnavailable)
+				output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+				return
+			}
+			if tokenType == model.TokenTypeHintRefreshToken {
+				rtResult := <-au.RefreshTokenRepo.Delete(ctxReq, session.Key)
+				if rtResult.Error != nil && rtResult.Error.Error() != helper.ErrorRedis {
+					oauthErr := model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusServiceUnavailable)
+					output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+					return
+				}
+			}
+
+			tags[helper.TextResponse] = introspection.Subject
+			break
+		}
+
+		output <- ResultUseCase{HTTPStatus: http.StatusOK}
+	})
+
+	return output
+}
+
+// authenticateTokenClient function for authenticating confidential client calling /introspect and /revoke
+func (au *AuthUseCaseImpl) authenticateTokenClient(ctxReq context.Context, data model.TokenHintRequest) (model.ClientApp, *model.OAuthError) {
+	if data.ClientSecret == "" {
+		return model.ClientApp{}, model.NewOAuthError(model.OAuthErrorInvalidClient, "client authentication failed", http.StatusUnauthorized)
+	}
+
+	clientApp, oauthErr := au.findOAuthClient(ctxReq, data.ClientID, data.ClientSecret, true)
+	if oauthErr != nil {
+		return clientApp, oauthErr
+	}
+
+	if clientApp.IsPublic() {
+		return model.ClientApp{}, model.NewOAuthError(model.OAuthErrorInvalidClient, "client authentication failed", http.StatusUnauthorized)
+	}
+
+	if data.Token == "" {
+		return clientApp, model.NewOAuthError(model.OAuthErrorInvalidRequest, "token is required", http.StatusBadRequest)
+	}
+
+	return clientApp, nil
+}
+
+// introspectAccessToken function for checking access token signature, expiration, jti and its live login session
+func (au *AuthUseCaseImpl) introspectAccessToken(ctxReq context.Context, accessToken string) (model.IntrospectionResponse, *model.RefreshTokenOwner, bool) {
+	inactive := model.IntrospectionResponse{Active: false}
+
+	claims, err := au.verifyAccessToken(ctxReq, accessToken)
+	// every token from GenerateAccessToken carries a jti, the session check above pins it to the live session
+	if err != nil || claims.Id == "" {
+		return inactive, nil, false
+	}
+
+	session := &model.RefreshTokenOwner{
+		Subject:     claims.Subject,
+		DeviceID:    claims.DeviceID,
+		DeviceLogin: claims.DeviceLogin,
+	}
+
+	return model.IntrospectionResponse{
+		Active:    true,
+		Scope:     claims.Scope,
+		ClientID:  claims.Audience,
+		Username:  claims.Email,
+		TokenType: model.TokenTypeBearer,
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+		Subject:   claims.Subject,
+		Audience:  claims.Audience,
+		Issuer:    claims.Issuer,
+		JTI:       claims.Id,
+	}, session, true
+}
+
+// introspectRefreshToken function for checking refresh token is still the current token of its session
+func (au *AuthUseCaseImpl) introspectRefreshToken(ctxReq context.Context, refreshToken string) (model.IntrospectionResponse, *model.RefreshTokenOwner, bool) {
+	inactive := model.IntrospectionResponse{Active: false}
+
+	rtResult := <-au.RefreshTokenRepo.FindByToken(ctxReq, refreshToken)
+	if rtResult.Error != nil {
+		return inactive, nil, false
+	}
+
+	rt, ok := rtResult.Result.(model.RefreshToken)
+	if !ok || rt.Owner == nil || subtle.ConstantTimeCompare([]byte(rt.Token), []byte(refreshToken)) != 1 {
+		return inactive, nil, false
+	}
+	if rt.Owner.ExpiresAt != 0 && time.Now().Unix() > rt.Owner.ExpiresAt {
+		return inactive, nil, false
+	}
+
+	return model.IntrospectionResponse{
+		Active:    true,
+		Scope:     rt.Owner.Scope,
+		ClientID:  rt.Owner.Audience,
+		ExpiresAt: rt.Owner.ExpiresAt,
+		IssuedAt:  rt.Owner.IssuedAt,
+		Subject:   rt.Owner.Subject,
+		Audience:  rt.Owner.Audience,
+	}, rt.Owner, true
+}
+
+// tokenTypeOrder function for ordering lookups by token_type_hint, an unknown hint falls back to both types
+func tokenTypeOrder(hint string) []string {
+	if hint == model.TokenTypeHintRefreshToken {
+		return []string{model.TokenTypeHintRefreshToken, model.TokenTypeHintAccessToken}
+	}
+	return []string{model.TokenTypeHintAccessToken, model.TokenTypeHintRefreshToken}
+}
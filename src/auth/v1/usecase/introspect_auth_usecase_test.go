@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUseCaseImpl_RevokeToken(t *testing.T) {
+	clientApp := model.ClientApp{ClientID: "partner", ClientSecret: "s3cret", Status: model.Active}
+	newRefreshToken := func(audience string) repo.ResultRepository {
+		owner := &model.RefreshTokenOwner{
+			Key: "RT-USR123-ASX1234-WEB", Subject: "USR123", DeviceID: "ASX1234", DeviceLogin: "WEB",
+			Audience: audience, ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		}
+		return repo.ResultRepository{Result: model.RefreshToken{ID: owner.Key, Token: "REFRESH123", Owner: owner}}
+	}
+
+	tests := []struct {
+		name        string
+		data        model.TokenHintRequest
+		findResult  repo.ResultRepository
+		wantStatus  int
+		wantErr     bool
+		wantRevoked bool
+	}{
+		{
+			name:        "Case 1: Token of the client is revoked",
+			data:        model.TokenHintRequest{ClientSecret: "s3cret"},
+			findResult:  newRefreshToken("partner"),
+			wantStatus:  http.StatusOK,
+			wantRevoked: true,
+		},
+		{
+			name:       "Case 2: Token of another client is kept",
+			data:       model.TokenHintRequest{ClientSecret: "s3cret"},
+			findResult: newRefreshToken("other-partner"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 3: Token of a first party login is kept",
+			data:       model.TokenHintRequest{ClientSecret: "s3cret"},
+			findResult: newRefreshToken(""),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 4: Unknown token",
+			data:       model.TokenHintRequest{ClientSecret: "s3cret"},
+			findResult: repo.ResultRepository{Error: errors.New("redis: nil")},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 5: Client authentication failed",
+			data:       model.TokenHintRequest{ClientSecret: "wrong"},
+			findResult: newRefreshToken("partner"),
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.ClientID, tt.data.Token, tt.data.TokenTypeHint = "partner", "REFRESH123", model.TokenTypeHintRefreshToken
+
+			clientAppRepo := new(mocksRepo.ClientAppRepository)
+			clientAppRepo.On("FindByClientID", "partner").Return(generateLockoutResult(repo.ResultRepository{Result: clientApp}))
+			refreshTokenRepo := new(mocksRepo.RefreshTokenRepository)
+			refreshTokenRepo.On("FindByToken", mock.Anything, "REFRESH123").Return(generateLockoutResult(tt.findResult))
+			refreshTokenRepo.On("Delete", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+			loginSessionRepo := new(mocksRepo.LoginSessionRepository)
+			loginSessionRepo.On("Delete", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+
+			au := &AuthUseCaseImpl{ClientAppRepoRead: clientAppRepo, RefreshTokenRepo: refreshTokenRepo, LoginSessionRepo: loginSessionRepo}
+			result := <-au.RevokeToken(context.Background(), tt.data)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			assert.Equal(t, tt.wantErr, result.Error != nil)
+
+			if tt.wantRevoked {
+				loginSessionRepo.AssertCalled(t, "Delete", mock.Anything, "STG-USR123-ASX1234-WEB")
+				refreshTokenRepo.AssertCalled(t, "Delete", mock.Anything, "RT-USR123-ASX1234-WEB")
+			} else {
+				loginSessionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				refreshTokenRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
 	"github.com/Bhinneka/user-service/src/client/v1/model"
 	corporateModel "github.com/Bhinneka/user-service/src/corporate/v2/model"
 	serviceModel "github.com/Bhinneka/user-service/src/service/model"
@@ -54,3 +55,18 @@ func (au *AuthUseCaseImpl) PublishToKafkaContact(ctxReq context.Context, payload
 	}
 	return nil
 }
+
+// PublishSecurityEvent function for publish security event of a member session to kafka
+func (au *AuthUseCaseImpl) PublishSecurityEvent(ctxReq context.Context, event authModel.SecurityEvent) error {
+	payloadJSON, err := json.Marshal(event)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, "PublishSecurityEvent", "marshal_payload", err, event)
+		return err
+	}
+
+	if err := au.QPublisher.PublishKafka(ctxReq, os.Getenv("KAFKA_USER_SERVICE_SECURITY_TOPIC"), event.Payload.Subject, payloadJSON); err != nil {
+		helper.SendErrorLog(ctxReq, "PublishKafka", "publish_security_event", err, event)
+		return err
+	}
+	return nil
+}
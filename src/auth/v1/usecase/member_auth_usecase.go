@@ -194,14 +194,21 @@ func (au *AuthUseCaseImpl) AdjustMemberData(ctxReq context.Context, responseVeri
 		}
 	}
 
-	responseVerify.MerchantIDs = au.merchantEmployee(ctxReq, responseVerify.Email)
+	responseVerify.MerchantIDs, responseVerify.MerchantRoles = au.merchantEmployee(ctxReq, responseVerify.Email)
+	if responseVerify.IsMerchant {
+		ownerRole := model.MerchantRole{
+			MerchantID:  responseVerify.MerchantID,
+			Role:        merchantModel.MerchantRoleOwner,
+			Permissions: merchantModel.MerchantRolePermissions(merchantModel.MerchantRoleOwner),
+		}
+		responseVerify.MerchantRoles = append([]model.MerchantRole{ownerRole}, responseVerify.MerchantRoles...)
+	}
 
 	return responseVerify
 }
 
-//AdjustDataPersonal
-func (au *AuthUseCaseImpl) merchantEmployee(ctxReq context.Context, email string) []string {
-	var merchantIds []string
+// merchantEmployee returns the merchants the member is employed by and the roles of the active employments
+func (au *AuthUseCaseImpl) merchantEmployee(ctxReq context.Context, email string) (merchantIds []string, roles []model.MerchantRole) {
 
 	emailResult := <-au.MemberQueryRead.FindByEmail(ctxReq, email)
 	if emailResult.Result != nil {
@@ -216,9 +223,17 @@ func (au *AuthUseCaseImpl) merchantEmployee(ctxReq context.Context, email string
 			merchantEmployee := merchantEmployeeResult.Result.([]merchantModel.B2CMerchantEmployeeData)
 			for _, val := range merchantEmployee {
 				merchantIds = append(merchantIds, val.MerchantID)
+				if val.Status.String != helper.TextActive {
+					continue
+				}
+				roles = append(roles, model.MerchantRole{
+					MerchantID:  val.MerchantID,
+					Role:        val.Role.String,
+					Permissions: merchantModel.MerchantRolePermissions(val.Role.String),
+				})
 			}
 		}
 	}
 
-	return merchantIds
+	return merchantIds, roles
 }
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksMemberQuery "github.com/Bhinneka/user-service/mocks/src/member/v1/query"
+	mocksMerchantRepo "github.com/Bhinneka/user-service/mocks/src/merchant/v2/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
+	merchantModel "github.com/Bhinneka/user-service/src/merchant/v2/model"
+	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+func generateMerchantRepoResult(data merchantRepo.ResultRepository) <-chan merchantRepo.ResultRepository {
+	output := make(chan merchantRepo.ResultRepository, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestAuthUseCaseImpl_AdjustMemberDataMerchantRoles(t *testing.T) {
+	member := memberModel.Member{ID: "USR123", Email: lockoutEmail, FirstName: "John"}
+	employees := []merchantModel.B2CMerchantEmployeeData{
+		{MerchantID: "MCH002", Status: zero.StringFrom(helper.TextActive), Role: zero.StringFrom(merchantModel.MerchantRoleFinance)},
+		{MerchantID: "MCH003", Status: zero.StringFrom(helper.TextInvited), Role: zero.StringFrom(merchantModel.MerchantRoleAdmin)},
+	}
+
+	tests := []struct {
+		name           string
+		merchantResult merchantRepo.ResultRepository
+		wantRoles      []model.MerchantRole
+	}{
+		{
+			name:           "Case 1: owner and employee",
+			merchantResult: merchantRepo.ResultRepository{Result: merchantModel.B2CMerchantDataV2{ID: "MCH001"}},
+			wantRoles: []model.MerchantRole{
+				{MerchantID: "MCH001", Role: merchantModel.MerchantRoleOwner, Permissions: merchantModel.MerchantRolePermissions(merchantModel.MerchantRoleOwner)},
+				{MerchantID: "MCH002", Role: merchantModel.MerchantRoleFinance, Permissions: merchantModel.MerchantRolePermissions(merchantModel.MerchantRoleFinance)},
+			},
+		},
+		{
+			name:           "Case 2: employee only",
+			merchantResult: merchantRepo.ResultRepository{},
+			wantRoles: []model.MerchantRole{
+				{MerchantID: "MCH002", Role: merchantModel.MerchantRoleFinance, Permissions: merchantModel.MerchantRolePermissions(merchantModel.MerchantRoleFinance)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryRead := new(mocksMemberQuery.MemberQuery)
+			queryRead.On("FindByID", mock.Anything, member.ID).Return(generateMemberQueryResult(memberQuery.ResultQuery{Result: member}))
+			queryRead.On("FindByEmail", mock.Anything, lockoutEmail).Return(generateMemberQueryResult(memberQuery.ResultQuery{Result: member}))
+			merchantRead := new(mocksMerchantRepo.MerchantRepository)
+			merchantRead.On("FindMerchantByUser", mock.Anything, member.ID).Return(tt.merchantResult)
+			employeeRead := new(mocksMerchantRepo.MerchantEmployeeRepository)
+			employeeRead.On("GetAllMerchantEmployees", mock.Anything, mock.Anything).Return(generateMerchantRepoResult(merchantRepo.ResultRepository{Result: employees}))
+
+			au := &AuthUseCaseImpl{
+				MemberQueryRead:          queryRead,
+				MerchantRepoRead:         merchantRead,
+				MerchantEmployeeRepoRead: employeeRead,
+			}
+
+			result := au.AdjustMemberData(context.Background(), &model.VerifyResponse{
+				MemberType: model.UserTypePersonal,
+				UserID:     member.ID,
+				Email:      lockoutEmail,
+			})
+			assert.Equal(t, []string{"MCH002", "MCH003"}, result.MerchantIDs)
+			assert.Equal(t, tt.wantRoles, result.MerchantRoles)
+		})
+	}
+}
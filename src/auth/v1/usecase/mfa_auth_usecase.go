@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"crypto/subtle"
+	"database/sql"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/Bhinneka/golib/tracer"
 	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
 	dgoogauth "github.com/dgryski/dgoogauth"
 )
@@ -58,6 +61,60 @@ func (au *AuthUseCaseImpl) verifyMFACode(ctxReq context.Context, mfaKey string,
 	return output
 }
 
+// useMFARecoveryCode function for verifying one time recovery code in place of an otp, the code is burned on success
+func (au *AuthUseCaseImpl) useMFARecoveryCode(ctxReq context.Context, memberID, code string) (int, error) {
+	ctx := "AuthUseCase-useMFARecoveryCode"
+
+	useResult := <-au.MemberMFARecoveryRepo.UseRecoveryCode(ctxReq, memberID, memberModel.HashMFARecoveryCode(code))
+	if useResult.Error != nil {
+		if useResult.Error == sql.ErrNoRows {
+			return http.StatusBadRequest, errors.New(memberModel.ErrorMFAOTP)
+		}
+
+		helper.SendErrorLog(ctxReq, ctx, "use_recovery_code", useResult.Error, memberID)
+		return http.StatusInternalServerError, useResult.Error
+	}
+
+	return http.StatusOK, nil
+}
+
+// issueMFATrustedDevice function for letting the device skip the second factor, only the hash of the token is kept
+func (au *AuthUseCaseImpl) issueMFATrustedDevice(ctxReq context.Context, memberID string, data *model.RequestToken) {
+	ctx := "AuthUseCase-issueMFATrustedDevice"
+	if !data.TrustDevice || data.DeviceID == "" {
+		return
+	}
+
+	trustedToken := helper.RandomStringBase64(32)
+	paramRedis := &model.LoginSessionRedis{
+		Key:         memberModel.GetMFATrustedDeviceKey(memberID, data.DeviceID),
+		Token:       helper.HashSHA256(trustedToken),
+		ExpiredTime: memberModel.MFATrustedDeviceAge,
+	}
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		// the login goes on, the device is asked again next time
+		helper.SendErrorLog(ctxReq, ctx, "save_trusted_device", saveResult.Error, paramRedis.Key)
+		return
+	}
+
+	data.IssuedTrustedDeviceToken = trustedToken
+}
+
+// isMFATrustedDevice function for checking the trusted device token given by the client
+func (au *AuthUseCaseImpl) isMFATrustedDevice(ctxReq context.Context, data *model.RequestToken) bool {
+	if data.TrustedDeviceToken == "" || data.DeviceID == "" || data.UserID == "" {
+		return false
+	}
+
+	trustedResult := <-au.LoginSessionRepo.Load(ctxReq, memberModel.GetMFATrustedDeviceKey(data.UserID, data.DeviceID))
+	trusted, _ := trustedResult.Result.(model.LoginSessionRedis)
+	if trustedResult.Error != nil || trusted.Token == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(trusted.Token), []byte(helper.HashSHA256(data.TrustedDeviceToken))) == 1
+}
+
 // parseTokenMFA function for parsing token into email and real token
 func (au *AuthUseCaseImpl) parseTokenMFA(token string) (string, string, error) {
 	if len(token) == 0 {
@@ -80,3 +137,42 @@ func (au *AuthUseCaseImpl) parseTokenMFA(token string) (string, string, error) {
 
 	return string(memberID), splitTokens[0], nil
 }
+
+// loadMFASession function for checking the mfa token given at login against the one kept for the device,
+// returns the member waiting for the second factor
+func (au *AuthUseCaseImpl) loadMFASession(ctxReq context.Context, data *model.RequestToken) (string, int, error) {
+	ctx := "AuthUseCase-loadMFASession"
+	memberID, newToken, err := au.parseTokenMFA(data.MFAToken)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "parse_mfa_token", err, data)
+		return "", http.StatusBadRequest, err
+	}
+	var redisOtpKey string
+	if data.GrantType == model.AuthTypeVerifyMFANarwhal {
+		redisOtpKey = model.NarwhalMFATokenKeyRedis
+	} else {
+		redisOtpKey = model.MFATokenKeyRedis
+	}
+
+	redisLoginKey := strings.Join([]string{redisOtpKey, memberID, data.DeviceID, data.DeviceLogin}, "-") //mfa-otp-USR123-ABC-WEB
+	getTokenRedis := <-au.LoginSessionRepo.Load(ctxReq, redisLoginKey)
+	if getTokenRedis.Error != nil {
+		if getTokenRedis.Error.Error() != helper.ErrorRedis {
+			helper.SendErrorLog(ctxReq, ctx, "mfa_load_redis", getTokenRedis.Error, data)
+		}
+
+		return "", http.StatusBadRequest, errors.New(memberModel.ErrorTokenMFA)
+	}
+
+	tokenRedis := ""
+	if existingToken, ok := getTokenRedis.Result.(model.LoginSessionRedis); ok {
+		tokenRedis = existingToken.Token
+	}
+
+	if tokenRedis != newToken {
+		err := fmt.Errorf(helper.ErrorParameterInvalid, "existing token")
+		return "", http.StatusUnauthorized, err
+	}
+
+	return memberID, http.StatusOK, nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+)
+
+// SendMFAOTP function for sending, or sending again, the one time code of an email or sms second factor
+func (au *AuthUseCaseImpl) SendMFAOTP(ctxReq context.Context, data model.RequestToken) <-chan ResultUseCase {
+	ctx := "AuthUseCase-SendMFAOTP"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = data.MFAMethod
+		if !memberModel.IsMFAOTPMethod(data.MFAMethod) {
+			output <- ResultUseCase{Error: errors.New(memberModel.ErrorMFAMethod), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		// the code can only be asked while the login is waiting for the second factor
+		data.GrantType = model.AuthTypeVerifyMFA
+		memberID, httpStatus, err := au.loadMFASession(ctxReq, &data)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
+		method, ok := findMFAOTPMethod(au.enrolledMFAOTPMethods(ctxReq, memberID), data.MFAMethod)
+		if !ok {
+			method, ok = au.riskStepUpMethod(ctxReq, memberID, &data)
+		}
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(memberModel.ErrorMFAMethodNotEnrolled), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if err := au.MFAOTP.Send(ctxReq, method.Method, memberID, getMFAOTPScope(&data), method.Target); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.MFAOTPSent{Method: method.Method, Destination: method.TargetMask}}
+	})
+
+	return output
+}
+
+// mfaOTPMethods function for getting email and sms second factors of a member at login,
+// like passkeys they only apply to personal accounts
+func (au *AuthUseCaseImpl) mfaOTPMethods(ctxReq context.Context, data *model.RequestToken) []memberModel.MFAMethod {
+	if !isPasskeyPrimaryGrant(data) {
+		return nil
+	}
+	return au.enrolledMFAOTPMethods(ctxReq, data.UserID)
+}
+
+func (au *AuthUseCaseImpl) enrolledMFAOTPMethods(ctxReq context.Context, memberID string) []memberModel.MFAMethod {
+	ctx := "AuthUseCase-enrolledMFAOTPMethods"
+	if au.MemberMFAMethodRepo == nil || au.MFAOTP == nil {
+		return nil
+	}
+
+	methodResult := <-au.MemberMFAMethodRepo.FindByMemberID(ctxReq, memberID)
+	if methodResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "find_mfa_methods", methodResult.Error, memberID)
+		return nil
+	}
+
+	methods, _ := methodResult.Result.([]memberModel.MFAMethod)
+	return methods
+}
+
+// sendDefaultMFAOTP function for sending the code right away when the default second factor is email or sms,
+// a failure is only logged, the member can ask for the code again
+func (au *AuthUseCaseImpl) sendDefaultMFAOTP(ctxReq context.Context, data *model.RequestToken, defaultMethod string, methods []memberModel.MFAMethod) {
+	ctx := "AuthUseCase-sendDefaultMFAOTP"
+	method, ok := findMFAOTPMethod(methods, defaultMethod)
+	if !ok {
+		return
+	}
+
+	if err := au.MFAOTP.Send(ctxReq, method.Method, data.UserID, getMFAOTPScope(data), method.Target); err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "send_mfa_otp", err, data.UserID)
+	}
+}
+
+func findMFAOTPMethod(methods []memberModel.MFAMethod, method string) (memberModel.MFAMethod, bool) {
+	for _, enrolled := range methods {
+		if enrolled.Method == method {
+			return enrolled, true
+		}
+	}
+	return memberModel.MFAMethod{}, false
+}
+
+// getMFAOTPScope the code belongs to the device waiting for the second factor, format: `ABC-WEB`
+func getMFAOTPScope(data *model.RequestToken) string {
+	return strings.Join([]string{data.DeviceID, data.DeviceLogin}, "-")
+}
@@ -5,9 +5,13 @@ package mocks
 import (
 	context "context"
 
+	connector "github.com/Bhinneka/user-service/src/shared/connector"
+
 	model "github.com/Bhinneka/user-service/src/auth/v1/model"
 	mock "github.com/stretchr/testify/mock"
 
+	rsa "github.com/Bhinneka/user-service/config/rsa"
+
 	usecase "github.com/Bhinneka/user-service/src/auth/v1/usecase"
 )
 
@@ -16,6 +20,54 @@ type AuthUseCase struct {
 	mock.Mock
 }
 
+// Authorize provides a mock function with given fields: ctxReq, data
+func (_m *AuthUseCase) Authorize(ctxReq context.Context, data model.AuthorizeRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuthorizeRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// AuthorizeConnector provides a mock function with given fields: ctxReq, provider, redirectURI
+func (_m *AuthUseCase) AuthorizeConnector(ctxReq context.Context, provider string, redirectURI string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, provider, redirectURI)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, provider, redirectURI)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// AuthorizeSAML provides a mock function with given fields: ctxReq, accountID, data
+func (_m *AuthUseCase) AuthorizeSAML(ctxReq context.Context, accountID string, data model.SAMLAuthorizeRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accountID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.SAMLAuthorizeRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accountID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // CheckEmail provides a mock function with given fields: ctxReq, email
 func (_m *AuthUseCase) CheckEmail(ctxReq context.Context, email string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, email)
@@ -48,6 +100,22 @@ func (_m *AuthUseCase) CheckEmailV3(ctxReq context.Context, data model.CheckEmai
 	return r0
 }
 
+// ClearLockout provides a mock function with given fields: ctxReq, email, adminID
+func (_m *AuthUseCase) ClearLockout(ctxReq context.Context, email string, adminID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, email, adminID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, email, adminID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // CreateClientApp provides a mock function with given fields: name
 func (_m *AuthUseCase) CreateClientApp(name string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(name)
@@ -64,6 +132,38 @@ func (_m *AuthUseCase) CreateClientApp(name string) <-chan usecase.ResultUseCase
 	return r0
 }
 
+// ExchangeToken provides a mock function with given fields: ctxReq, data
+func (_m *AuthUseCase) ExchangeToken(ctxReq context.Context, data model.OIDCTokenRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.OIDCTokenRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GeneratePasskeyLoginOptions provides a mock function with given fields: ctxReq
+func (_m *AuthUseCase) GeneratePasskeyLoginOptions(ctxReq context.Context) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GenerateToken provides a mock function with given fields: ctxReq, mode, data
 func (_m *AuthUseCase) GenerateToken(ctxReq context.Context, mode string, data model.RequestToken) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, mode, data)
@@ -128,6 +228,36 @@ func (_m *AuthUseCase) GetClientApp(clientID string, clientSecret string) <-chan
 	return r0
 }
 
+// GetConnectors provides a mock function with given fields:
+func (_m *AuthUseCase) GetConnectors() []connector.Info {
+	ret := _m.Called()
+
+	var r0 []connector.Info
+	if rf, ok := ret.Get(0).(func() []connector.Info); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]connector.Info)
+		}
+	}
+
+	return r0
+}
+
+// GetJSONWebKeySet provides a mock function with given fields:
+func (_m *AuthUseCase) GetJSONWebKeySet() rsa.JSONWebKeySet {
+	ret := _m.Called()
+
+	var r0 rsa.JSONWebKeySet
+	if rf, ok := ret.Get(0).(func() rsa.JSONWebKeySet); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(rsa.JSONWebKeySet)
+	}
+
+	return r0
+}
+
 // GetJTIToken provides a mock function with given fields: ctxReq, token, request
 func (_m *AuthUseCase) GetJTIToken(ctxReq context.Context, token string, request string) (string, interface{}, error) {
 	ret := _m.Called(ctxReq, token, request)
@@ -158,6 +288,116 @@ func (_m *AuthUseCase) GetJTIToken(ctxReq context.Context, token string, request
 	return r0, r1, r2
 }
 
+// GetLockout provides a mock function with given fields: ctxReq, email
+func (_m *AuthUseCase) GetLockout(ctxReq context.Context, email string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, email)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetLockouts provides a mock function with given fields: ctxReq
+func (_m *AuthUseCase) GetLockouts(ctxReq context.Context) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetOpenIDConfiguration provides a mock function with given fields:
+func (_m *AuthUseCase) GetOpenIDConfiguration() model.OpenIDConfiguration {
+	ret := _m.Called()
+
+	var r0 model.OpenIDConfiguration
+	if rf, ok := ret.Get(0).(func() model.OpenIDConfiguration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(model.OpenIDConfiguration)
+	}
+
+	return r0
+}
+
+// GetSAMLMetadata provides a mock function with given fields: ctxReq, accountID
+func (_m *AuthUseCase) GetSAMLMetadata(ctxReq context.Context, accountID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accountID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetUserInfo provides a mock function with given fields: ctxReq, accessToken
+func (_m *AuthUseCase) GetUserInfo(ctxReq context.Context, accessToken string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accessToken)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accessToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// IntrospectToken provides a mock function with given fields: ctxReq, data
+func (_m *AuthUseCase) IntrospectToken(ctxReq context.Context, data model.TokenHintRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.TokenHintRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// LoginSAML provides a mock function with given fields: ctxReq, accountID, data
+func (_m *AuthUseCase) LoginSAML(ctxReq context.Context, accountID string, data model.SAMLLoginRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accountID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.SAMLLoginRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accountID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // Logout provides a mock function with given fields: ctxReq, token
 func (_m *AuthUseCase) Logout(ctxReq context.Context, token string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, token)
@@ -174,6 +414,38 @@ func (_m *AuthUseCase) Logout(ctxReq context.Context, token string) <-chan useca
 	return r0
 }
 
+// RequestPasswordless provides a mock function with given fields: ctxReq, data
+func (_m *AuthUseCase) RequestPasswordless(ctxReq context.Context, data model.PasswordlessRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.PasswordlessRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// RevokeToken provides a mock function with given fields: ctxReq, data
+func (_m *AuthUseCase) RevokeToken(ctxReq context.Context, data model.TokenHintRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.TokenHintRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SendEmailWelcomeMember provides a mock function with given fields: ctxReq, data
 func (_m *AuthUseCase) SendEmailWelcomeMember(ctxReq context.Context, data model.AccessTokenResponse) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data)
@@ -190,6 +462,22 @@ func (_m *AuthUseCase) SendEmailWelcomeMember(ctxReq context.Context, data model
 	return r0
 }
 
+// SendMFAOTP provides a mock function with given fields: ctxReq, data
+func (_m *AuthUseCase) SendMFAOTP(ctxReq context.Context, data model.RequestToken) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.RequestToken) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // ValidateBasicAuth provides a mock function with given fields: ctxReq, clientID, clientSecret
 func (_m *AuthUseCase) ValidateBasicAuth(ctxReq context.Context, clientID string, clientSecret string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, clientID, clientSecret)
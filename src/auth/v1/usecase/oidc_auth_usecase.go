@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/config/rsa"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+)
+
+const (
+	msgAuthorizationCodeInvalid = "authorization code is invalid or expired"
+	msgLoginRequired            = "member is not logged in"
+	msgResultNotToken           = "result is not token"
+)
+
+// GetOpenIDConfiguration function for getting openid connect discovery document
+func (au *AuthUseCaseImpl) GetOpenIDConfiguration() model.OpenIDConfiguration {
+	return model.NewOpenIDConfiguration(au.OIDCIssuer)
+}
+
+// GetJSONWebKeySet function for getting public keys that verify tokens issued by this service
+func (au *AuthUseCaseImpl) GetJSONWebKeySet() rsa.JSONWebKeySet {
+	return au.KeySet.JWKS()
+}
+
+// Authorize function for issuing authorization code to the logged in member
+func (au *AuthUseCaseImpl) Authorize(ctxReq context.Context, data model.AuthorizeRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-Authorize"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["clientId"] = data.ClientID
+
+		clientApp, oauthErr := au.findOAuthClient(ctxReq, data.ClientID, "", false)
+		if oauthErr != nil {
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		// never redirect to an unregistered uri, the error is shown to the user agent instead
+		if !clientApp.AllowRedirectURI(data.RedirectURI) {
+			oauthErr := model.NewOAuthError(model.OAuthErrorInvalidRequest, "redirect_uri is not registered for this client", http.StatusBadRequest)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		// from here on errors are sent back to the client through its redirect uri
+		resp := model.AuthorizeResponse{RedirectURI: data.RedirectURI, State: data.State}
+		if oauthErr := validateAuthorizeRequest(&data); oauthErr != nil {
+			output <- ResultUseCase{Result: resp, Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		claims, err := au.verifyAccessToken(ctxReq, data.AccessToken)
+		if err != nil || !claims.UserAuthorized || strings.HasPrefix(claims.DeviceID, model.OIDCDevicePrefix) ||
+			(claims.MemberType != "" && claims.MemberType != model.UserTypePersonal) {
+			oauthErr := model.NewOAuthError(model.OAuthErrorLoginRequired, msgLoginRequired, http.StatusUnauthorized)
+			output <- ResultUseCase{Result: resp, Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		authCode := &model.AuthorizationCode{
+			Code:                helper.RandomStringBase64(48),
+			ClientID:            clientApp.ClientID,
+			RedirectURI:         data.RedirectURI,
+			Scope:               data.Scope,
+			Nonce:               data.Nonce,
+			CodeChallenge:       data.CodeChallenge,
+			CodeChallengeMethod: data.CodeChallengeMethod,
+			Subject:             claims.Subject,
+			AuthTime:            time.Unix(claims.IssuedAt, 0),
+		}
+
+		saveResult := <-au.AuthorizationCodeRepo.Save(ctxReq, authCode)
+		if saveResult.Error != nil {
+			oauthErr := model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+			output <- ResultUseCase{Result: resp, Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		tags[helper.TextResponse] = claims.Subject
+		resp.Code = authCode.Code
+		output <- ResultUseCase{Result: resp}
+	})
+
+	return output
+}
+
+// ExchangeToken function for oauth2 token endpoint, issues access token, refresh token and id token
+func (au *AuthUseCaseImpl) ExchangeToken(ctxReq context.Context, data model.OIDCTokenRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-ExchangeToken"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["clientId"] = data.ClientID
+		tags["grantType"] = data.GrantType
+
+		clientApp, oauthErr := au.findOAuthClient(ctxReq, data.ClientID, data.ClientSecret, true)
+		if oauthErr != nil {
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		requestToken := model.RequestToken{
+			Audience:    clientApp.ClientID,
+			ClientID:    clientApp.ClientID,
+			IP:          data.IP,
+			UserAgent:   data.UserAgent,
+			DeviceID:    model.OIDCDevicePrefix + clientApp.ClientID + "-" + helper.RandomStringBase64(12),
+			DeviceLogin: model.OIDCDeviceLogin,
+			MemberType:  model.UserTypePersonal,
+		}
+
+		switch data.GrantType {
+		case model.AuthTypeAuthorizationCode:
+			if data.Code == "" || data.CodeVerifier == "" {
+				oauthErr := model.NewOAuthError(model.OAuthErrorInvalidRequest, "code and code_verifier are required", http.StatusBadRequest)
+				output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+				return
+			}
+			requestToken.GrantType = model.AuthTypeAuthorizationCode
+			requestToken.Code = data.Code
+			requestToken.RedirectURI = data.RedirectURI
+			requestToken.CodeVerifier = data.CodeVerifier
+
+		case model.OAuthGrantRefreshToken:
+			if data.RefreshToken == "" {
+				oauthErr := model.NewOAuthError(model.OAuthErrorInvalidRequest, "refresh_token is required", http.StatusBadRequest)
+				output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+				return
+			}
+			requestToken.GrantType = model.AuthTypeRefreshToken
+			requestToken.RefreshToken = data.RefreshToken
+
+		case model.AuthTypeClientCredentials:
+			resp, oauthErr := au.issueClientCredentialsToken(ctxReq, clientApp, data)
+			if oauthErr != nil {
+				output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+				return
+			}
+			output <- ResultUseCase{Result: resp}
+			return
+
+		default:
+			oauthErr := model.NewOAuthError(model.OAuthErrorUnsupportedGrantType, "", http.StatusBadRequest)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		tokenResult := <-au.GenerateToken(ctxReq, "", requestToken)
+		if tokenResult.Error != nil {
+			oauthErr := toOAuthError(tokenResult.Error, tokenResult.HTTPStatus)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		issued, ok := tokenResult.Result.(model.RequestToken)
+		if !ok {
+			oauthErr := model.NewOAuthError(model.OAuthErrorServerError, msgResultNotToken, http.StatusInternalServerError)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		resp := model.OIDCTokenResponse{
+			AccessToken:  issued.Token,
+			TokenType:    model.TokenTypeBearer,
+			ExpiresIn:    int64(time.Until(issued.ExpiredAt).Seconds()),
+			RefreshToken: issued.RefreshToken,
+			Scope:        issued.Scope,
+		}
+
+		if model.HasScope(issued.Scope, model.ScopeOpenID) {
+			idTokenClaim := token.IDTokenClaim{
+				Issuer:      au.OIDCIssuer,
+				Audience:    clientApp.ClientID,
+				Subject:     issued.UserID,
+				Nonce:       issued.Nonce,
+				AuthTime:    issued.AuthTime,
+				AccessToken: issued.Token,
+			}
+			if model.HasScope(issued.Scope, model.ScopeEmail) {
+				idTokenClaim.Email = issued.Email
+			}
+			if model.HasScope(issued.Scope, model.ScopeProfile) {
+				idTokenClaim.Name = strings.TrimSpace(issued.FirstName + " " + issued.LastName)
+				idTokenClaim.GivenName = issued.FirstName
+				idTokenClaim.FamilyName = issued.LastName
+			}
+
+			idToken := <-au.AccessTokenGenerator.GenerateIDToken(idTokenClaim)
+			if idToken.Error != nil {
+				helper.SendErrorLog(ctxReq, ctx, "generate_id_token", idToken.Error, issued.UserID)
+				oauthErr := model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+				output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+				return
+			}
+			resp.IDToken = idToken.AccessToken.AccessToken
+		}
+
+		output <- ResultUseCase{Result: resp}
+	})
+
+	return output
+}
+
+// GetUserInfo function for openid connect userinfo endpoint
+func (au *AuthUseCaseImpl) GetUserInfo(ctxReq context.Context, accessToken string) <-chan ResultUseCase {
+	ctx := "AuthUseCase-GetUserInfo"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		claims, err := au.verifyAccessToken(ctxReq, accessToken)
+		if err != nil || !claims.UserAuthorized {
+			oauthErr := model.NewOAuthError(model.OAuthErrorInvalidToken, "access token is invalid or expired", http.StatusUnauthorized)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+		tags[helper.TagKey] = claims.Subject
+
+		memberResult := <-au.MemberRepoRead.Load(ctxReq, claims.Subject)
+		if memberResult.Error != nil {
+			oauthErr := model.NewOAuthError(model.OAuthErrorInvalidToken, "subject is not a member", http.StatusUnauthorized)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		member, ok := memberResult.Result.(memberModel.Member)
+		if !ok {
+			oauthErr := model.NewOAuthError(model.OAuthErrorServerError, msgResultNotMember, http.StatusInternalServerError)
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		// first party tokens carry no scope and may read every claim
+		allowed := func(scope string) bool {
+			return claims.Scope == "" || model.HasScope(claims.Scope, scope)
+		}
+
+		userInfo := model.UserInfo{Subject: member.ID}
+		if allowed(model.ScopeProfile) {
+			userInfo.Name = strings.TrimSpace(member.FirstName + " " + member.LastName)
+			userInfo.GivenName = member.FirstName
+			userInfo.FamilyName = member.LastName
+		}
+		if allowed(model.ScopeEmail) {
+			userInfo.Email = member.Email
+			userInfo.EmailVerified = member.Status == memberModel.Active
+		}
+		if allowed(model.ScopePhone) {
+			userInfo.PhoneNumber = member.Mobile
+		}
+
+		output <- ResultUseCase{Result: userInfo}
+	})
+
+	return output
+}
+
+// findOAuthClient function for loading an active client app, confidential clients must authenticate with their secret
+// when required, public clients cannot keep one and are bound by the PKCE verifier instead
+func (au *AuthUseCaseImpl) findOAuthClient(ctxReq context.Context, clientID, clientSecret string, authenticate bool) (model.ClientApp, *model.OAuthError) {
+	invalidClient := model.NewOAuthError(model.OAuthErrorInvalidClient, "client authentication failed", http.StatusUnauthorized)
+	if clientID == "" {
+		return model.ClientApp{}, invalidClient
+	}
+
+	clientAppResult := <-au.ClientAppRepoRead.FindByClientID(clientID)
+	if clientAppResult.Error != nil {
+		return model.ClientApp{}, invalidClient
+	}
+
+	clientApp, ok := clientAppResult.Result.(model.ClientApp)
+	if !ok || !clientApp.IsActive() {
+		return model.ClientApp{}, invalidClient
+	}
+
+	if !authenticate || clientApp.IsPublic() {
+		return clientApp, nil
+	}
+
+	if clientSecret == "" || !clientApp.Authenticate(clientSecret) {
+		return model.ClientApp{}, invalidClient
+	}
+
+	return clientApp, nil
+}
+
+// verifyAccessToken function for verifying signature and expiration of an access token and its live session
+func (au *AuthUseCaseImpl) verifyAccessToken(ctxReq context.Context, accessToken string) (*token.BearerClaims, error) {
+	if accessToken == "" {
+		return nil, errors.New(helper.ErrorUnauthorized)
+	}
+
+	claims, err := token.VerifyToken(au.KeySet, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	redisKey := getLoginSessionKey(claims.Subject, claims.DeviceID, claims.DeviceLogin)
+	session := <-au.LoginSessionRepo.Load(ctxReq, redisKey)
+	if session.Error != nil {
+		return nil, errors.New(msgTokenExpired)
+	}
+
+	loginSession, ok := session.Result.(model.LoginSessionRedis)
+	if !ok || loginSession.Token != accessToken {
+		return nil, errors.New(errTokenMismatch)
+	}
+
+	return claims, nil
+}
+
+// toOAuthError function for mapping token generation error into rfc 6749 error
+func toOAuthError(err error, httpStatus int) *model.OAuthError {
+	var oauthErr *model.OAuthError
+	if errors.As(err, &oauthErr) {
+		return oauthErr
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		return model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+	}
+	return model.NewOAuthError(model.OAuthErrorInvalidGrant, err.Error(), http.StatusBadRequest)
+}
+
+func (au *AuthUseCaseImpl) parseAuthorizationCodeType(ctxReq context.Context, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
+	ctx := "AuthUseCase-GenerateToken-parseAuthorizationCodeType"
+
+	codeResult := <-au.AuthorizationCodeRepo.Consume(ctxReq, data.Code)
+	if codeResult.Error != nil {
+		return http.StatusBadRequest, "", model.NewOAuthError(model.OAuthErrorInvalidGrant, msgAuthorizationCodeInvalid, http.StatusBadRequest)
+	}
+
+	authCode, ok := codeResult.Result.(model.AuthorizationCode)
+	if !ok {
+		return http.StatusBadRequest, "", model.NewOAuthError(model.OAuthErrorInvalidGrant, msgAuthorizationCodeInvalid, http.StatusBadRequest)
+	}
+
+	if authCode.ClientID != data.Audience || authCode.RedirectURI != data.RedirectURI {
+		return http.StatusBadRequest, "", model.NewOAuthError(model.OAuthErrorInvalidGrant, "authorization code was issued to another client or redirect_uri", http.StatusBadRequest)
+	}
+
+	if !model.VerifyCodeChallenge(data.CodeVerifier, authCode.CodeChallenge, authCode.CodeChallengeMethod) {
+		return http.StatusBadRequest, "", model.NewOAuthError(model.OAuthErrorInvalidGrant, "code_verifier does not match code_challenge", http.StatusBadRequest)
+	}
+
+	memberResult := <-au.MemberRepoRead.Load(ctxReq, authCode.Subject)
+	if memberResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "load_member", memberResult.Error, authCode.Subject)
+		return http.StatusBadRequest, "", model.NewOAuthError(model.OAuthErrorInvalidGrant, msgAuthorizationCodeInvalid, http.StatusBadRequest)
+	}
+
+	member, ok := memberResult.Result.(memberModel.Member)
+	if !ok {
+		return http.StatusInternalServerError, "", errors.New(msgResultNotMember)
+	}
+
+	if member.Status != memberModel.Active {
+		return http.StatusBadRequest, "", model.NewOAuthError(model.OAuthErrorInvalidGrant, "account is not active", http.StatusBadRequest)
+	}
+
+	// tokens issued to third party clients never carry admin or staff privileges
+	claims.Subject = member.ID
+	claims.Authorised = true
+	claims.IsAdmin = false
+	claims.IsStaff = false
+	claims.Email = member.Email
+	claims.SignUpFrom = member.SignUpFrom
+	claims.MemberType = model.UserTypePersonal
+	claims.Scope = authCode.Scope
+
+	data.UserID = member.ID
+	data.Email = member.Email
+	data.FirstName = member.FirstName
+	data.LastName = member.LastName
+	data.Mobile = member.Mobile
+	data.NewMember = false
+	data.HasPassword = len(member.Password) > 0
+	data.MemberType = model.UserTypePersonal
+	data.Scope = authCode.Scope
+	data.Nonce = authCode.Nonce
+	data.AuthTime = authCode.AuthTime
+
+	return http.StatusOK, member.ID, nil
+}
+
+// findRefreshTokenOwner function for resolving the session of a refresh token presented without the previous access token
+func (au *AuthUseCaseImpl) findRefreshTokenOwner(ctxReq context.Context, data *model.RequestToken) (*token.BearerClaims, error) {
+	rtResult := <-au.RefreshTokenRepo.FindByToken(ctxReq, data.RefreshToken)
+	if rtResult.Error != nil {
+		return nil, errors.New(model.ErrorRefreshToken)
+	}
+
+	rt, ok := rtResult.Result.(model.RefreshToken)
+	if !ok || rt.Owner == nil {
+		return nil, errors.New(model.ErrorRefreshToken)
+	}
+
+	// refresh token can only be used by the client it was issued to
+	if rt.Owner.Audience != data.Audience {
+		return nil, errors.New(model.ErrorRefreshToken)
+	}
+
+	oldClaims := &token.BearerClaims{
+		DeviceID:       rt.Owner.DeviceID,
+		DeviceLogin:    rt.Owner.DeviceLogin,
+		UserAuthorized: true,
+		IsAdmin:        rt.Owner.IsAdmin,
+		MemberType:     rt.Owner.MemberType,
+		Scope:          rt.Owner.Scope,
+	}
+	oldClaims.Subject = rt.Owner.Subject
+	oldClaims.Audience = rt.Owner.Audience
+
+	return oldClaims, nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUseCaseImpl_findOAuthClient(t *testing.T) {
+	confidential := model.ClientApp{ClientID: "partner", ClientSecret: "s3cret", Status: model.Active, Type: model.ClientTypeConfidential}
+	public := model.ClientApp{ClientID: "partner", ClientSecret: "s3cret", Status: model.Active, Type: model.ClientTypePublic}
+
+	tests := []struct {
+		name         string
+		clientApp    model.ClientApp
+		clientSecret string
+		authenticate bool
+		wantErr      bool
+	}{
+		{
+			name:         "Case 1: Confidential client with its secret",
+			clientApp:    confidential,
+			clientSecret: "s3cret",
+			authenticate: true,
+		},
+		{
+			name:         "Case 2: Confidential client without a secret",
+			clientApp:    confidential,
+			authenticate: true,
+			wantErr:      true,
+		},
+		{
+			name:         "Case 3: Confidential client with a wrong secret",
+			clientApp:    confidential,
+			clientSecret: "wrong",
+			authenticate: true,
+			wantErr:      true,
+		},
+		{
+			name:         "Case 4: Public client without a secret",
+			clientApp:    public,
+			authenticate: true,
+		},
+		{
+			name:      "Case 5: Confidential client at the authorize endpoint",
+			clientApp: confidential,
+		},
+		{
+			name:         "Case 6: Inactive client",
+			clientApp:    model.ClientApp{ClientID: "partner", ClientSecret: "s3cret", Status: model.InActive},
+			clientSecret: "s3cret",
+			authenticate: true,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientAppRepo := new(mocksRepo.ClientAppRepository)
+			clientAppRepo.On("FindByClientID", "partner").Return(generateLockoutResult(repo.ResultRepository{Result: tt.clientApp}))
+
+			au := &AuthUseCaseImpl{ClientAppRepoRead: clientAppRepo}
+			clientApp, oauthErr := au.findOAuthClient(context.Background(), "partner", tt.clientSecret, tt.authenticate)
+			assert.Equal(t, tt.wantErr, oauthErr != nil)
+			if tt.wantErr {
+				assert.Equal(t, model.OAuthErrorInvalidClient, oauthErr.Code)
+				assert.Equal(t, http.StatusUnauthorized, oauthErr.HTTPStatus)
+				return
+			}
+			assert.Equal(t, "partner", clientApp.ClientID)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_findRefreshTokenOwner(t *testing.T) {
+	newRefreshToken := func(deviceID, audience string) repo.ResultRepository {
+		owner := &model.RefreshTokenOwner{Key: "RT-USR123-" + deviceID + "-WEB", Subject: "USR123", DeviceID: deviceID, DeviceLogin: "WEB", Audience: audience}
+		return repo.ResultRepository{Result: model.RefreshToken{ID: owner.Key, Token: "REFRESH123", Owner: owner}}
+	}
+
+	tests := []struct {
+		name       string
+		audience   string
+		findResult repo.ResultRepository
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: Token of the client",
+			audience:   "partner",
+			findResult: newRefreshToken("OIDC-partner-LOGIN1", "partner"),
+		},
+		{
+			name:       "Case 2: Token of another client",
+			audience:   "partner",
+			findResult: newRefreshToken("OIDC-other-LOGIN1", "other"),
+			wantErr:    true,
+		},
+		{
+			name:       "Case 3: Token of a first party login",
+			audience:   "partner",
+			findResult: newRefreshToken("ASX1234", "sturgeon"),
+			wantErr:    true,
+		},
+		{
+			name:       "Case 4: Unknown token",
+			audience:   "partner",
+			findResult: repo.ResultRepository{Error: errors.New("redis: nil")},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepo := new(mocksRepo.RefreshTokenRepository)
+			refreshTokenRepo.On("FindByToken", mock.Anything, "REFRESH123").Return(generateLockoutResult(tt.findResult))
+
+			au := &AuthUseCaseImpl{RefreshTokenRepo: refreshTokenRepo}
+			claims, err := au.findRefreshTokenOwner(context.Background(), &model.RequestToken{RefreshToken: "REFRESH123", Audience: tt.audience})
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Equal(t, model.ErrorRefreshToken, err.Error())
+				return
+			}
+			assert.Equal(t, "USR123", claims.Subject)
+			assert.Equal(t, "OIDC-partner-LOGIN1", claims.DeviceID)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_ExchangeToken(t *testing.T) {
+	confidential := model.ClientApp{ClientID: "partner", ClientSecret: "s3cret", Status: model.Active, Type: model.ClientTypeConfidential, Scopes: []string{"member:read"}}
+	public := model.ClientApp{ClientID: "partner", ClientSecret: "s3cret", Status: model.Active, Type: model.ClientTypePublic, Scopes: []string{"member:read"}}
+
+	tests := []struct {
+		name       string
+		clientApp  model.ClientApp
+		data       model.OIDCTokenRequest
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "Case 1: Confidential client without a secret",
+			clientApp:  confidential,
+			data:       model.OIDCTokenRequest{GrantType: model.AuthTypeAuthorizationCode, Code: "CODE", CodeVerifier: "VERIFIER"},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   model.OAuthErrorInvalidClient,
+		},
+		{
+			name:       "Case 2: Public client without a code verifier",
+			clientApp:  public,
+			data:       model.OIDCTokenRequest{GrantType: model.AuthTypeAuthorizationCode, Code: "CODE"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   model.OAuthErrorInvalidRequest,
+		},
+		{
+			name:       "Case 3: Public client asking for client credentials",
+			clientApp:  public,
+			data:       model.OIDCTokenRequest{GrantType: model.AuthTypeClientCredentials, ClientSecret: "s3cret"},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   model.OAuthErrorInvalidClient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.ClientID = "partner"
+			clientAppRepo := new(mocksRepo.ClientAppRepository)
+			clientAppRepo.On("FindByClientID", "partner").Return(generateLockoutResult(repo.ResultRepository{Result: tt.clientApp}))
+
+			au := &AuthUseCaseImpl{ClientAppRepoRead: clientAppRepo}
+			result := <-au.ExchangeToken(context.Background(), tt.data)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+
+			oauthErr, ok := result.Error.(*model.OAuthError)
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, tt.wantCode, oauthErr.Code)
+			}
+		})
+	}
+}
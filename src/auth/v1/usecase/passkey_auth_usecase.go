@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
+)
+
+// GeneratePasskeyLoginOptions function for starting the passwordless webauthn assertion ceremony,
+// the member is not known yet so the authenticator offers its discoverable credentials
+func (au *AuthUseCaseImpl) GeneratePasskeyLoginOptions(ctxReq context.Context) <-chan ResultUseCase {
+	ctx := "AuthUseCase-GeneratePasskeyLoginOptions"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		challenge, err := webauthn.NewChallenge()
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "generate_challenge", err, nil)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		paramRedis := &model.LoginSessionRedis{
+			Key:         getPasskeyLoginKey(challenge),
+			Token:       challenge,
+			ExpiredTime: au.WebAuthn.Timeout,
+		}
+		if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+			helper.SendErrorLog(ctxReq, ctx, "save_passkey_challenge", saveResult.Error, paramRedis)
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: au.WebAuthn.RequestOptions(challenge, nil, webauthn.UserVerificationRequired)}
+	})
+
+	return output
+}
+
+// parsePasskeyType function for passwordless login, the passkey is both factors so user verification is required
+func (au *AuthUseCaseImpl) parsePasskeyType(ctxReq context.Context, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
+	ctx := "AuthUseCase-GenerateToken-parsePasskeyType"
+	if data.Passkey == nil {
+		return http.StatusBadRequest, "", errors.New(model.ErrorPasskeyLogin)
+	}
+
+	challenge := au.consumePasskeyChallenge(ctxReq, getPasskeyLoginKey(data.Passkey.Challenge()))
+	if challenge == "" {
+		return http.StatusBadRequest, "", errors.New(model.ErrorPasskeyLogin)
+	}
+
+	// the user handle is the member id given at registration
+	userHandle, err := webauthn.DecodeBase64(data.Passkey.Response.UserHandle)
+	if err != nil || len(userHandle) == 0 {
+		return http.StatusBadRequest, "", errors.New(model.ErrorPasskeyLogin)
+	}
+
+	memberID := string(userHandle)
+	if httpStatus, err := au.verifyPasskey(ctxReq, challenge, *data.Passkey, memberID, true); err != nil {
+		return httpStatus, "", err
+	}
+
+	memberResult := <-au.MemberQueryRead.FindByID(ctxReq, memberID)
+	if memberResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "find_member", memberResult.Error, memberID)
+		return http.StatusUnauthorized, "", errors.New(model.ErrorPasskeyLogin)
+	}
+
+	memberData, ok := memberResult.Result.(memberModel.Member)
+	if !ok {
+		return http.StatusInternalServerError, "", errors.New(msgResultNotMember)
+	}
+
+	switch memberData.StatusString {
+	case memberModel.InactiveString:
+		return http.StatusBadRequest, "", errors.New(model.ErrorAccountInActiveBahasa)
+	case memberModel.NewString:
+		return http.StatusBadRequest, "", errors.New(model.ErrorNewAccountBahasa)
+	case memberModel.BlockedString:
+		return http.StatusBadRequest, "", errors.New(model.ErrorAccountBlockedBahasa)
+	}
+
+	claims.Subject = memberData.ID
+	claims.Authorised = true
+	claims.IsAdmin = memberData.IsAdmin
+	claims.IsStaff = memberData.IsStaff
+	claims.Email = memberData.Email
+	claims.SignUpFrom = memberData.SignUpFrom
+
+	data.UserID = memberData.ID
+	data.Email = memberData.Email
+	data.FirstName = memberData.FirstName
+	data.LastName = memberData.LastName
+	data.Mobile = memberData.Mobile
+	data.NewMember = false
+	if memberData.Password != "" {
+		data.HasPassword = true
+	}
+	redisUserID = memberData.ID
+	return http.StatusOK, redisUserID, nil
+}
+
+// passkeyMFAOptions function for offering the passkeys of the member as second factor,
+// returns nil when the member has no passkey
+func (au *AuthUseCaseImpl) passkeyMFAOptions(ctxReq context.Context, data *model.RequestToken, claims token.Claim) *webauthn.RequestOptions {
+	ctx := "AuthUseCase-passkeyMFAOptions"
+	if au.MemberPasskeyRepo == nil || !isPasskeyPrimaryGrant(data) {
+		return nil
+	}
+
+	passkeyResult := <-au.MemberPasskeyRepo.FindByMemberID(ctxReq, data.UserID)
+	passkeys, _ := passkeyResult.Result.([]memberModel.Passkey)
+	if passkeyResult.Error != nil || len(passkeys) == 0 {
+		return nil
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "generate_challenge", err, data.UserID)
+		return nil
+	}
+
+	paramRedis := &model.LoginSessionRedis{
+		Key:         getPasskeyMFAKey(data.UserID, data.DeviceID, claims.DeviceLogin),
+		Token:       challenge,
+		ExpiredTime: au.WebAuthn.Timeout,
+	}
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_passkey_challenge", saveResult.Error, paramRedis)
+		return nil
+	}
+
+	options := au.WebAuthn.RequestOptions(challenge, memberModel.PasskeyDescriptors(passkeys), webauthn.UserVerificationPreferred)
+	return &options
+}
+
+// verifyPasskeyMFA function for verifying passkey as second factor of the member
+func (au *AuthUseCaseImpl) verifyPasskeyMFA(ctxReq context.Context, memberID string, data *model.RequestToken, claims *token.Claim) (int, error) {
+	challenge := au.consumePasskeyChallenge(ctxReq, getPasskeyMFAKey(memberID, data.DeviceID, claims.DeviceLogin))
+	if challenge == "" {
+		return http.StatusBadRequest, errors.New(model.ErrorPasskeyLogin)
+	}
+
+	return au.verifyPasskey(ctxReq, challenge, *data.Passkey, memberID, false)
+}
+
+// verifyPasskey function for verifying the assertion against a passkey owned by the member
+func (au *AuthUseCaseImpl) verifyPasskey(ctxReq context.Context, challenge string, response webauthn.AssertionResponse, memberID string, requireUserVerification bool) (int, error) {
+	ctx := "AuthUseCase-verifyPasskey"
+
+	passkeyResult := <-au.MemberPasskeyRepo.FindByCredentialID(ctxReq, response.CredentialID())
+	if passkeyResult.Error != nil {
+		if passkeyResult.Error == sql.ErrNoRows {
+			return http.StatusUnauthorized, errors.New(model.ErrorPasskeyLogin)
+		}
+		return http.StatusInternalServerError, passkeyResult.Error
+	}
+
+	passkey, ok := passkeyResult.Result.(memberModel.Passkey)
+	if !ok || passkey.MemberID != memberID {
+		return http.StatusUnauthorized, errors.New(model.ErrorPasskeyLogin)
+	}
+
+	assertion, err := au.WebAuthn.VerifyAssertion(challenge, response, passkey.PublicKey, passkey.SignCount, requireUserVerification)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "verify_assertion", err, passkey.ID)
+		return http.StatusUnauthorized, errors.New(model.ErrorPasskeyLogin)
+	}
+
+	if len(assertion.UserHandle) > 0 && string(assertion.UserHandle) != memberID {
+		return http.StatusUnauthorized, errors.New(model.ErrorPasskeyLogin)
+	}
+
+	if updateResult := <-au.MemberPasskeyRepo.UpdateSignCount(ctxReq, passkey.ID, assertion.SignCount); updateResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "update_sign_count", updateResult.Error, passkey.ID)
+	}
+
+	return http.StatusOK, nil
+}
+
+// consumePasskeyChallenge function for loading a challenge and removing it, challenges are single use
+func (au *AuthUseCaseImpl) consumePasskeyChallenge(ctxReq context.Context, key string) string {
+	challengeResult := <-au.LoginSessionRepo.Load(ctxReq, key)
+	challenge, _ := challengeResult.Result.(model.LoginSessionRedis)
+	if challengeResult.Error != nil || challenge.Token == "" {
+		return ""
+	}
+
+	<-au.LoginSessionRepo.Delete(ctxReq, key)
+	return challenge.Token
+}
+
+// isPasskeyPrimaryGrant passkey is offered as second factor only after a personal member first factor
+func isPasskeyPrimaryGrant(data *model.RequestToken) bool {
+	if data.UserID == "" || data.MemberType == model.UserTypeCorporate || data.MemberType == model.UserTypeMicrositeBela {
+		return false
+	}
+
+	switch data.GrantType {
+	case model.AuthTypePassword, model.AuthTypeFacebook, model.AuthTypeGoogle, model.AuthTypeGoogleBackend,
+		model.AuthTypeGoogleOAauth, model.AuthTypeApple, model.AuthTypePasswordless:
+		return true
+	}
+	return false
+}
+
+// getPasskeyLoginKey redis key format: `passkey-login-<challenge>`
+func getPasskeyLoginKey(challenge string) string {
+	return strings.Join([]string{model.PasskeyLoginKeyRedis, challenge}, "-")
+}
+
+// getPasskeyMFAKey redis key format: `passkey-mfa-USR123-ABC-WEB`
+func getPasskeyMFAKey(memberID, deviceID, deviceLogin string) string {
+	return strings.Join([]string{model.PasskeyMFAKeyRedis, memberID, deviceID, deviceLogin}, "-")
+}
@@ -2,10 +2,8 @@ package usecase
 
 import (
 	"context"
-	"crypto/sha1"
 	"crypto/sha256"
 	"database/sql"
-	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -53,34 +51,48 @@ func (au *AuthUseCaseImpl) RequestTokenPasswordType(ctxReq context.Context, data
 		return nil, http.StatusBadRequest, err
 	}
 
+	if httpStatus, err := au.checkLockout(ctxReq, data.Email); err != nil {
+		return nil, httpStatus, err
+	}
+
 	// validate password
-	passwordSaltDB := strings.TrimSpace(member.Salt)
-	passwordHasher := memberModel.NewPBKDF2Hasher(memberModel.SaltSize, memberModel.SaltSize, memberModel.IterationsCount, sha1.New)
-	passwordHasher.ParseSalt(passwordSaltDB)
-	base64Data := base64.StdEncoding.EncodeToString(passwordHasher.Hash([]byte(data.Password)))
-
-	if member.Password != base64Data {
-		// check attempt and save it
-		attemptResult := <-au.checkAttempt(ctxReq, data.Email)
-		if attemptResult.Error != nil && attemptResult.Error.Error() == helper.ErrorLoginAttempt {
-			err := errors.New(model.ErrorAccountBlockedBahasa)
-			return nil, http.StatusBadRequest, err
-		}
-		tags["encoded_password"] = member.Password
+	if !au.Hash.VerifyPassword(data.Password, member.Salt, member.Password) {
 		tags["email"] = data.Email
-		tags["base64"] = base64Data
+		tags["password_algorithm"] = memberModel.GetPasswordAlgorithm(member.Salt)
 
-		err := errors.New(model.ErrorInvalidUsernameOrPasswordBahasa)
-		return nil, http.StatusBadRequest, err
+		httpStatus, err := au.registerLoginFailure(ctxReq, data)
+		return nil, httpStatus, err
 	}
 
-	key := fmt.Sprintf(keyAttempt, member.Email)
-
-	<-au.LoginAttemptRepo.Delete(ctxReq, key)
-	passwordHasher = nil
+	data.LoginFailures = au.clearLoginFailures(ctxReq, member.Email)
+	au.rehashPassword(ctxReq, &member, data.Password)
 	return &member, 200, nil
 }
 
+// rehashPassword function for moving a password to the current hashing algorithm after a successful login,
+// the login goes on when it fails
+func (au *AuthUseCaseImpl) rehashPassword(ctxReq context.Context, member *memberModel.Member, password string) {
+	ctx := "AuthUseCase-rehashPassword"
+
+	if !au.Hash.NeedsRehash(member.Salt) {
+		return
+	}
+
+	salt, hashed, err := au.Hash.HashPassword(password)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "hash_password", err, member.ID)
+		return
+	}
+
+	updateResult := <-au.MemberRepoWrite.UpdatePasswordHash(ctxReq, member.ID, member.Salt, salt, hashed)
+	if updateResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "update_password_hash", updateResult.Error, member.ID)
+		return
+	}
+
+	member.Salt, member.Password = salt, hashed
+}
+
 // validateMemberLoginPassword function for validate request token with grantType is password
 func (au *AuthUseCaseImpl) validateMemberLoginPassword(member memberModel.Member) error {
 	// check member status
@@ -160,30 +172,20 @@ func (au *AuthUseCaseImpl) RequestTokenPasswordTypeCorporate(ctxReq context.Cont
 		return member, httpStatus, err
 	}
 
-	key := fmt.Sprintf(keyAttempt, member.Email)
-
-	<-au.LoginAttemptRepo.Delete(ctxReq, key)
+	au.clearLoginFailures(ctxReq, member.Email)
 	return member, 200, nil
 }
 
 func (au *AuthUseCaseImpl) ValidatePasswordCorporate(ctxReq context.Context, member sharedModel.B2BContactData, data *model.RequestToken) (sharedModel.B2BContactData, int, error) {
+	if httpStatus, err := au.checkLockout(ctxReq, data.Email); err != nil {
+		return member, httpStatus, err
+	}
+
 	if member.Salt != "" {
 		// validate password
-		passwordSaltDB := strings.TrimSpace(member.Salt)
-		passwordHasher := memberModel.NewPBKDF2Hasher(memberModel.SaltSize, memberModel.SaltSize, memberModel.IterationsCount, sha1.New)
-		passwordHasher.ParseSalt(passwordSaltDB)
-		base64Data := base64.StdEncoding.EncodeToString(passwordHasher.Hash([]byte(data.Password)))
-
-		if member.Password != base64Data {
-			// check attempt and save it
-			attemptResult := <-au.checkAttempt(ctxReq, data.Email)
-			if attemptResult.Error != nil && attemptResult.Error.Error() == helper.ErrorLoginAttempt {
-				err := errors.New(model.ErrorAccountBlockedBahasa)
-				return member, http.StatusBadRequest, err
-			}
-
-			err := errors.New(model.ErrorInvalidUsernameOrPasswordBahasa)
-			return member, http.StatusBadRequest, err
+		if !au.Hash.VerifyPassword(data.Password, member.Salt, member.Password) {
+			httpStatus, err := au.registerLoginFailure(ctxReq, data)
+			return member, httpStatus, err
 		}
 	} else {
 		passwordSlice := strings.Split(member.Password, ":")
@@ -207,8 +209,8 @@ func (au *AuthUseCaseImpl) ValidatePasswordCorporate(ctxReq context.Context, mem
 		codestr := hex.EncodeToString(code) // converts hex to string
 
 		if passwordSlice[0] != codestr {
-			err := errors.New(model.ErrorInvalidUsernameOrPasswordBahasa)
-			return member, http.StatusBadRequest, err
+			httpStatus, err := au.registerLoginFailure(ctxReq, data)
+			return member, httpStatus, err
 		}
 	}
 
@@ -256,9 +258,7 @@ func (au *AuthUseCaseImpl) RequestTokenPasswordTypeMicrosite(ctxReq context.Cont
 		return member, httpStatus, err
 	}
 
-	key := fmt.Sprintf(keyAttempt, member.Email)
-
-	<-au.LoginAttemptRepo.Delete(ctxReq, key)
+	au.clearLoginFailures(ctxReq, member.Email)
 	return member, 200, nil
 }
 
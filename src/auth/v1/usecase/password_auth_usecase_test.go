@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mocksMemberRepo "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberRepo "github.com/Bhinneka/user-service/src/member/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	legacyPassword = "wannabenaked"
+	legacySalt     = "15000.Efns3HvrXL7aP75bjMpzlwWp7s/yZwlEdRNuyuTWZk9JGXXCyGtBa/BQTz3s4vY8ewgDcgL/xz2efTYzPdrKhg=="
+	legacyCipher   = "5fJx5lYkAtHGVFktXjvyAL8t9ZOyjjMGhXXy7QohBUFwYHG9DZKWdhPd1LE/tA9nZcbGHUryKNP+VvH99TLG3w=="
+)
+
+func generateMemberRepoResult(data memberRepo.ResultRepository) <-chan memberRepo.ResultRepository {
+	output := make(chan memberRepo.ResultRepository, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestAuthUseCaseImpl_rehashPassword(t *testing.T) {
+	hashers, _ := memberModel.NewDefaultPasswordHashers("")
+	tests := []struct {
+		name         string
+		salt         string
+		password     string
+		updateResult memberRepo.ResultRepository
+		wantUpdate   bool
+		wantRehashed bool
+	}{
+		{
+			name:         "Case 1: legacy password moves to argon2id",
+			salt:         legacySalt,
+			password:     legacyCipher,
+			updateResult: memberRepo.ResultRepository{Result: int64(1)},
+			wantUpdate:   true,
+			wantRehashed: true,
+		},
+		{
+			name:         "Case 2: update failure keeps the login",
+			salt:         legacySalt,
+			password:     legacyCipher,
+			updateResult: memberRepo.ResultRepository{Error: errors.New("connection refused")},
+			wantUpdate:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocksMemberRepo.MemberRepository)
+			repo.On("UpdatePasswordHash", mock.Anything, "USR123", tt.salt, mock.Anything, mock.Anything).Return(generateMemberRepoResult(tt.updateResult))
+
+			au := &AuthUseCaseImpl{Hash: hashers, MemberRepoWrite: repo}
+			member := memberModel.Member{ID: "USR123", Salt: tt.salt, Password: tt.password}
+			au.rehashPassword(context.Background(), &member, legacyPassword)
+
+			if tt.wantUpdate {
+				repo.AssertCalled(t, "UpdatePasswordHash", mock.Anything, "USR123", tt.salt, mock.Anything, mock.Anything)
+			}
+			assert.Equal(t, tt.wantRehashed, member.Salt != tt.salt)
+			assert.True(t, hashers.VerifyPassword(legacyPassword, member.Salt, member.Password))
+		})
+	}
+
+	t.Run("Case 3: current algorithm is not rehashed", func(t *testing.T) {
+		salt, password, _ := hashers.HashPassword(legacyPassword)
+		repo := new(mocksMemberRepo.MemberRepository)
+
+		au := &AuthUseCaseImpl{Hash: hashers, MemberRepoWrite: repo}
+		au.rehashPassword(context.Background(), &memberModel.Member{ID: "USR123", Salt: salt, Password: password}, legacyPassword)
+		repo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
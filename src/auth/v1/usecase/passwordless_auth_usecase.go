@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+)
+
+// passwordlessLinkTokenLength random bytes of the login link token
+const passwordlessLinkTokenLength = 32
+
+// RequestPasswordless function for sending a login link or code to the email of a personal member,
+// the answer is the same for unknown, inactive or locked emails so it tells nothing about the accounts
+func (au *AuthUseCaseImpl) RequestPasswordless(ctxReq context.Context, data model.PasswordlessRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-RequestPasswordless"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		data.Email = strings.ToLower(strings.TrimSpace(data.Email))
+		tags["email"] = data.Email
+		tags[helper.TextArgs] = data.Mode
+		if err := helper.ValidateEmail(data.Email); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if data.Mode != model.PasswordlessModeLink && data.Mode != model.PasswordlessModeCode {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasswordlessMode), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		sent := model.PasswordlessSent{
+			Mode:        data.Mode,
+			Destination: memberModel.MaskMFATarget(memberModel.MFAMethodEmail, data.Email),
+			ExpiresIn:   int(model.PasswordlessAge.Seconds()),
+		}
+
+		member, ok := au.passwordlessMember(ctxReq, data.Email)
+		if !ok {
+			output <- ResultUseCase{Result: sent}
+			return
+		}
+
+		if err := au.sendPasswordless(ctxReq, member, data.Mode); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: sent}
+	})
+
+	return output
+}
+
+// passwordlessMember function for finding the active member a login link or code can be sent to
+func (au *AuthUseCaseImpl) passwordlessMember(ctxReq context.Context, email string) (memberModel.Member, bool) {
+	ctx := "AuthUseCase-passwordlessMember"
+
+	memberResult := <-au.MemberQueryRead.FindByEmail(ctxReq, email)
+	if memberResult.Error != nil {
+		if memberResult.Error != sql.ErrNoRows {
+			helper.SendErrorLog(ctxReq, ctx, "find_member", memberResult.Error, email)
+		}
+		return memberModel.Member{}, false
+	}
+
+	member, ok := memberResult.Result.(memberModel.Member)
+	if !ok || member.StatusString != memberModel.ActiveString {
+		return memberModel.Member{}, false
+	}
+
+	// a locked email gets nothing until the lock is over
+	if _, err := au.checkLockout(ctxReq, email); err != nil {
+		return memberModel.Member{}, false
+	}
+	return member, true
+}
+
+// sendPasswordless function for keeping the hash of a new login code or link and sending it to the member,
+// a new code replaces the previous one of the email
+func (au *AuthUseCaseImpl) sendPasswordless(ctxReq context.Context, member memberModel.Member, mode string) error {
+	ctx := "AuthUseCase-sendPasswordless"
+	minutes := int(model.PasswordlessAge / time.Minute)
+
+	email := serviceModel.Email{}
+	email.From = serviceModel.NoReply
+	email.FromName = serviceModel.NoReplyName
+	email.To = []string{member.Email}
+	email.ToName = []string{member.FirstName}
+	email.Subject = model.SubjectPasswordless
+
+	paramRedis := &model.LoginSessionRedis{ExpiredTime: model.PasswordlessAge}
+	if mode == model.PasswordlessModeCode {
+		code, err := memberModel.GenerateMFAOTP(model.PasswordlessCodeLength)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "generate_code", err, member.ID)
+			return err
+		}
+
+		paramRedis.Key = model.GetPasswordlessCodeKey(member.Email)
+		paramRedis.Token = helper.HashSHA256(code)
+		email.Content = fmt.Sprintf(model.ContentPasswordlessCode, member.FirstName, code, minutes)
+	} else {
+		linkToken := helper.RandomStringBase64(passwordlessLinkTokenLength)
+		if linkToken == "" {
+			return errors.New("failed to generate login link")
+		}
+
+		linkURL := strings.TrimRight(au.B2cCFUrl, "/") + model.PasswordlessPath + "?token=" + url.QueryEscape(linkToken)
+		paramRedis.Key = model.GetPasswordlessLinkKey(helper.HashSHA256(linkToken))
+		paramRedis.Token = strings.ToLower(member.Email)
+		email.Content = fmt.Sprintf(model.ContentPasswordlessLink, member.FirstName, linkURL, linkURL, minutes)
+	}
+
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_passwordless", saveResult.Error, member.ID)
+		return saveResult.Error
+	}
+
+	go au.sendPasswordlessEmail(ctxReq, email)
+	return nil
+}
+
+func (au *AuthUseCaseImpl) sendPasswordlessEmail(ctxReq context.Context, email serviceModel.Email) {
+	if _, err := au.NotificationService.SendEmail(ctxReq, email); err != nil {
+		helper.SendErrorLog(ctxReq, "AuthUseCase-sendPasswordlessEmail", "send_email", err, email.To)
+	}
+}
+
+// parsePasswordlessType function for login with the link token in `code` or the email code in `otp`,
+// both are single use and a wrong code counts as a failed login of the email
+func (au *AuthUseCaseImpl) parsePasswordlessType(ctxReq context.Context, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
+	ctx := "AuthUseCase-GenerateToken-parsePasswordlessType"
+	if data.MemberType != model.UserTypePersonal {
+		return http.StatusBadRequest, "", errors.New(model.ErrorPasswordlessMemberType)
+	}
+
+	if data.Code != "" {
+		httpStatus, err = au.redeemPasswordlessLink(ctxReq, data)
+	} else {
+		httpStatus, err = au.redeemPasswordlessCode(ctxReq, data)
+	}
+	if err != nil {
+		return httpStatus, "", err
+	}
+	data.LoginFailures = au.clearLoginFailures(ctxReq, data.Email)
+
+	memberResult := <-au.MemberQueryRead.FindByEmail(ctxReq, data.Email)
+	if memberResult.Error != nil {
+		if memberResult.Error == sql.ErrNoRows {
+			return http.StatusUnauthorized, "", errors.New(model.ErrorPasswordlessBahasa)
+		}
+		helper.SendErrorLog(ctxReq, ctx, "find_member", memberResult.Error, data.Email)
+		return http.StatusInternalServerError, "", memberResult.Error
+	}
+
+	memberData, ok := memberResult.Result.(memberModel.Member)
+	if !ok {
+		return http.StatusInternalServerError, "", errors.New(msgResultNotMember)
+	}
+
+	switch memberData.StatusString {
+	case memberModel.InactiveString:
+		return http.StatusBadRequest, "", errors.New(model.ErrorAccountInActiveBahasa)
+	case memberModel.NewString:
+		return http.StatusBadRequest, "", errors.New(model.ErrorNewAccountBahasa)
+	case memberModel.BlockedString:
+		return http.StatusBadRequest, "", errors.New(model.ErrorAccountBlockedBahasa)
+	}
+
+	claims.Subject = memberData.ID
+	claims.Authorised = true
+	claims.IsAdmin = memberData.IsAdmin
+	claims.IsStaff = memberData.IsStaff
+	claims.Email = memberData.Email
+	claims.SignUpFrom = memberData.SignUpFrom
+
+	data.UserID = memberData.ID
+	data.Email = memberData.Email
+	data.FirstName = memberData.FirstName
+	data.LastName = memberData.LastName
+	data.Mobile = memberData.Mobile
+	data.NewMember = false
+	data.MFAEnabled = memberData.MFAEnabled
+	if memberData.Password != "" {
+		data.HasPassword = true
+	}
+	redisUserID = memberData.ID
+	return http.StatusOK, redisUserID, nil
+}
+
+// redeemPasswordlessLink function for using a login link, the link is removed once the email is not locked
+func (au *AuthUseCaseImpl) redeemPasswordlessLink(ctxReq context.Context, data *model.RequestToken) (int, error) {
+	key := model.GetPasswordlessLinkKey(helper.HashSHA256(data.Code))
+	linkResult := <-au.LoginSessionRepo.Load(ctxReq, key)
+	link, _ := linkResult.Result.(model.LoginSessionRedis)
+	if linkResult.Error != nil || link.Token == "" {
+		return http.StatusUnauthorized, errors.New(model.ErrorPasswordlessBahasa)
+	}
+
+	data.Email = link.Token
+	if httpStatus, err := au.checkLockout(ctxReq, data.Email); err != nil {
+		return httpStatus, err
+	}
+
+	<-au.LoginSessionRepo.Delete(ctxReq, key)
+	return http.StatusOK, nil
+}
+
+// redeemPasswordlessCode function for checking the login code of the email, the code is removed when it matches
+func (au *AuthUseCaseImpl) redeemPasswordlessCode(ctxReq context.Context, data *model.RequestToken) (int, error) {
+	data.Email = strings.ToLower(strings.TrimSpace(data.Email))
+	if httpStatus, err := au.checkLockout(ctxReq, data.Email); err != nil {
+		return httpStatus, err
+	}
+
+	key := model.GetPasswordlessCodeKey(data.Email)
+	codeResult := <-au.LoginSessionRepo.Load(ctxReq, key)
+	code, _ := codeResult.Result.(model.LoginSessionRedis)
+	if codeResult.Error != nil || code.Token == "" ||
+		subtle.ConstantTimeCompare([]byte(code.Token), []byte(helper.HashSHA256(data.OTP))) != 1 {
+		// the error of a wrong code tells about the code unless the email gets locked
+		httpStatus, err := au.registerLoginFailure(ctxReq, data)
+		if err.Error() == model.ErrorInvalidUsernameOrPasswordBahasa {
+			return http.StatusUnauthorized, errors.New(model.ErrorPasswordlessBahasa)
+		}
+		return httpStatus, err
+	}
+
+	<-au.LoginSessionRepo.Delete(ctxReq, key)
+	return http.StatusOK, nil
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksMemberQuery "github.com/Bhinneka/user-service/mocks/src/member/v1/query"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func generateMemberQueryResult(data memberQuery.ResultQuery) <-chan memberQuery.ResultQuery {
+	output := make(chan memberQuery.ResultQuery, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestAuthUseCaseImpl_RequestPasswordless(t *testing.T) {
+	member := memberModel.Member{ID: "USR123", Email: lockoutEmail, FirstName: "John", StatusString: memberModel.ActiveString}
+	tests := []struct {
+		name         string
+		mode         string
+		memberResult memberQuery.ResultQuery
+		wantStatus   int
+		wantSent     bool
+	}{
+		{
+			name:         "Case 1: code is sent",
+			mode:         model.PasswordlessModeCode,
+			memberResult: memberQuery.ResultQuery{Result: member},
+			wantSent:     true,
+		},
+		{
+			name:         "Case 2: link is sent",
+			mode:         model.PasswordlessModeLink,
+			memberResult: memberQuery.ResultQuery{Result: member},
+			wantSent:     true,
+		},
+		{
+			name:         "Case 3: unknown email gets the same answer",
+			mode:         model.PasswordlessModeCode,
+			memberResult: memberQuery.ResultQuery{Error: sql.ErrNoRows},
+		},
+		{
+			name:         "Case 4: blocked member gets the same answer",
+			mode:         model.PasswordlessModeLink,
+			memberResult: memberQuery.ResultQuery{Result: memberModel.Member{ID: "USR123", StatusString: memberModel.BlockedString}},
+		},
+		{
+			name:       "Case 5: unknown mode",
+			mode:       "sms",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryRead := new(mocksMemberQuery.MemberQuery)
+			queryRead.On("FindByEmail", mock.Anything, lockoutEmail).Return(generateMemberQueryResult(tt.memberResult))
+			lockoutRepo := new(mocksRepo.LockoutRepository)
+			lockoutRepo.On("Load", mock.Anything, lockoutEmail).Return(generateLockoutResult(repo.ResultRepository{Error: errors.New(helper.ErrorRedis)}))
+			sessionRepo := new(mocksRepo.LoginSessionRepository)
+			sessionRepo.On("Save", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+
+			sent := make(chan serviceModel.Email, 1)
+			emailService := new(mocksService.NotificationServices)
+			emailService.On("SendEmail", mock.Anything, mock.Anything).Return("", nil).Run(func(args mock.Arguments) {
+				sent <- args.Get(1).(serviceModel.Email)
+			})
+
+			au := &AuthUseCaseImpl{
+				MemberQueryRead:     queryRead,
+				LockoutRepo:         lockoutRepo,
+				LoginSessionRepo:    sessionRepo,
+				NotificationService: emailService,
+				B2cCFUrl:            "https://www.bhinneka.com",
+			}
+
+			result := <-au.RequestPasswordless(context.Background(), model.PasswordlessRequest{Email: " John@Bhinneka.com", Mode: tt.mode})
+			if tt.wantStatus != 0 {
+				assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+				assert.Error(t, result.Error)
+				return
+			}
+			assert.NoError(t, result.Error)
+			assert.Equal(t, tt.mode, result.Result.(model.PasswordlessSent).Mode)
+			if !tt.wantSent {
+				sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+				return
+			}
+
+			saved := sessionRepo.Calls[0].Arguments.Get(1).(*model.LoginSessionRedis)
+			assert.Equal(t, model.PasswordlessAge, saved.ExpiredTime)
+			email := <-sent
+			assert.Equal(t, []string{lockoutEmail}, email.To)
+			if tt.mode == model.PasswordlessModeCode {
+				code := regexp.MustCompile(`<b>(\d{6})</b>`).FindStringSubmatch(email.Content)
+				assert.Len(t, code, 2)
+				assert.Equal(t, model.GetPasswordlessCodeKey(lockoutEmail), saved.Key)
+				assert.Equal(t, helper.HashSHA256(code[1]), saved.Token)
+				return
+			}
+
+			linkToken := regexp.MustCompile(`\?token=([^"]+)"`).FindStringSubmatch(email.Content)
+			assert.Len(t, linkToken, 2)
+			assert.True(t, strings.Contains(email.Content, "https://www.bhinneka.com"+model.PasswordlessPath))
+			assert.Equal(t, model.GetPasswordlessLinkKey(helper.HashSHA256(linkToken[1])), saved.Key)
+			assert.Equal(t, lockoutEmail, saved.Token)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_parsePasswordlessType(t *testing.T) {
+	member := memberModel.Member{ID: "USR123", Email: lockoutEmail, FirstName: "John", StatusString: memberModel.ActiveString, MFAEnabled: true}
+	codeKey := model.GetPasswordlessCodeKey(lockoutEmail)
+	linkKey := model.GetPasswordlessLinkKey(helper.HashSHA256("link-token"))
+	tests := []struct {
+		name       string
+		data       model.RequestToken
+		key        string
+		loadResult repo.ResultRepository
+		wantStatus int
+		wantErr    string
+		wantFailed bool
+	}{
+		{
+			name:       "Case 1: email code",
+			data:       model.RequestToken{Email: lockoutEmail, OTP: "123456"},
+			key:        codeKey,
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Token: helper.HashSHA256("123456")}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 2: wrong email code counts as failed login",
+			data:       model.RequestToken{Email: lockoutEmail, OTP: "654321"},
+			key:        codeKey,
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Token: helper.HashSHA256("123456")}},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    model.ErrorPasswordlessBahasa,
+			wantFailed: true,
+		},
+		{
+			name:       "Case 3: login link",
+			data:       model.RequestToken{Code: "link-token"},
+			key:        linkKey,
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Token: lockoutEmail}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Case 4: used or expired login link",
+			data:       model.RequestToken{Code: "link-token"},
+			key:        linkKey,
+			loadResult: repo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    model.ErrorPasswordlessBahasa,
+		},
+		{
+			name:       "Case 5: corporate member type",
+			data:       model.RequestToken{Code: "link-token", MemberType: model.UserTypeCorporate},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    model.ErrorPasswordlessMemberType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryRead := new(mocksMemberQuery.MemberQuery)
+			queryRead.On("FindByEmail", mock.Anything, lockoutEmail).Return(generateMemberQueryResult(memberQuery.ResultQuery{Result: member}))
+			lockoutRepo := new(mocksRepo.LockoutRepository)
+			lockoutRepo.On("Load", mock.Anything, lockoutEmail).Return(generateLockoutResult(repo.ResultRepository{Error: errors.New(helper.ErrorRedis)}))
+			lockoutRepo.On("Update", mock.Anything, lockoutEmail, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{Result: model.Lockout{Email: lockoutEmail, Failures: 1}}))
+			lockoutRepo.On("Delete", mock.Anything, lockoutEmail).Return(generateLockoutResult(repo.ResultRepository{Result: int64(1)}))
+			sessionRepo := new(mocksRepo.LoginSessionRepository)
+			sessionRepo.On("Load", mock.Anything, tt.key).Return(generateLockoutResult(tt.loadResult))
+			sessionRepo.On("Delete", mock.Anything, tt.key).Return(generateLockoutResult(repo.ResultRepository{Result: int64(1)}))
+
+			au := &AuthUseCaseImpl{MemberQueryRead: queryRead, LockoutRepo: lockoutRepo, LoginSessionRepo: sessionRepo, LoginAttemptAge: "5m"}
+
+			data := tt.data
+			data.GrantType = model.AuthTypePasswordless
+			if data.MemberType == "" {
+				data.MemberType = model.UserTypePersonal
+			}
+			claims := token.Claim{}
+			httpStatus, redisUserID, err := au.parsePasswordlessType(context.Background(), &data, &claims)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				sessionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				if tt.wantFailed {
+					lockoutRepo.AssertCalled(t, "Update", mock.Anything, lockoutEmail, mock.Anything)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, member.ID, redisUserID)
+			assert.Equal(t, member.ID, claims.Subject)
+			assert.True(t, claims.Authorised)
+			assert.True(t, data.MFAEnabled)
+			assert.True(t, isPasskeyPrimaryGrant(&data))
+			sessionRepo.AssertCalled(t, "Delete", mock.Anything, tt.key)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+)
+
+// assignPermissions function for embedding the permissions of member roles into the access token claims,
+// tokens of oauth2 clients never carry them
+func (au *AuthUseCaseImpl) assignPermissions(ctxReq context.Context, claims *token.Claim) {
+	claims.Permissions = nil
+	if !claims.Authorised || claims.Subject == "" || model.IsClientBoundDevice(claims.DeviceID) {
+		return
+	}
+
+	permissionResult := <-au.AuthQueryDB.GetMemberPermissions(ctxReq, claims.Subject)
+	if permissionResult.Error != nil {
+		// login still works, the member just cannot reach permission protected routes
+		helper.SendErrorLog(ctxReq, "AuthUseCase-assignPermissions", "get_member_permissions", permissionResult.Error, claims.Subject)
+		return
+	}
+
+	permissions, _ := permissionResult.Result.([]string)
+	claims.Permissions = permissions
+}
@@ -82,3 +82,7 @@ func (au *AuthUseCaseImpl) GetJTIToken(ctxReq context.Context, token, request st
 func getRefreshTokenKey(subject, deviceID, deviceLogin string) string {
 	return strings.Join([]string{"RT", subject, deviceID, deviceLogin}, "-")
 }
+
+func getLoginSessionKey(subject, deviceID, deviceLogin string) string {
+	return strings.Join([]string{"STG", subject, deviceID, deviceLogin}, "-")
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+)
+
+// detectRefreshTokenReuse function for revoking the whole family when an already rotated refresh token is presented again
+func (au *AuthUseCaseImpl) detectRefreshTokenReuse(ctxReq context.Context, data *model.RequestToken) error {
+	rtResult := <-au.RefreshTokenRepo.FindByToken(ctxReq, data.RefreshToken)
+	if rtResult.Error != nil {
+		// unknown token, the usual refresh token check rejects it
+		return nil
+	}
+
+	rt, ok := rtResult.Result.(model.RefreshToken)
+	if !ok || !rt.Owner.IsRotated() {
+		return nil
+	}
+
+	au.revokeRefreshTokenFamily(ctxReq, data, rt.Owner)
+	return errors.New(model.ErrorRefreshToken)
+}
+
+// rotateRefreshToken function for marking the presented refresh token as used, the new refresh token joins its family
+func (au *AuthUseCaseImpl) rotateRefreshToken(ctxReq context.Context, data *model.RequestToken) (int, error) {
+	ctx := "AuthUseCase-rotateRefreshToken"
+	rotateResult := <-au.RefreshTokenRepo.Rotate(ctxReq, data.RefreshToken)
+	if rotateResult.Error == repo.ErrRefreshTokenNotInFamily {
+		// refresh token issued before families were introduced, the new one starts a family
+		return http.StatusOK, nil
+	}
+	if rotateResult.Error != nil {
+		// the token can not be marked as used, refreshing now could hide a reuse
+		helper.SendErrorLog(ctxReq, ctx, "rotate_refresh_token", rotateResult.Error, nil)
+		return http.StatusInternalServerError, errors.New(model.ErrorGetToken)
+	}
+
+	previous, ok := rotateResult.Result.(*model.RefreshTokenOwner)
+	if !ok {
+		return http.StatusInternalServerError, errors.New(model.ErrorGetToken)
+	}
+
+	// another request has rotated the same token in the meantime
+	if previous.IsRotated() {
+		au.revokeRefreshTokenFamily(ctxReq, data, previous)
+		return http.StatusUnauthorized, errors.New(model.ErrorRefreshToken)
+	}
+
+	data.RefreshTokenFamily = previous.FamilyID
+	data.RefreshTokenGeneration = previous.Generation + 1
+	return http.StatusOK, nil
+}
+
+// revokeRefreshTokenFamily function for ending the session of a refresh token family and publishing security event
+func (au *AuthUseCaseImpl) revokeRefreshTokenFamily(ctxReq context.Context, data *model.RequestToken, owner *model.RefreshTokenOwner) {
+	ctx := "AuthUseCase-revokeRefreshTokenFamily"
+	tr := tracer.StartTrace(ctxReq, ctx)
+	tags := map[string]interface{}{"sub": owner.Subject, "familyId": owner.FamilyID, "generation": owner.Generation}
+	defer tr.Finish(tags)
+
+	revokeResult := <-au.RefreshTokenRepo.RevokeFamily(ctxReq, owner)
+	if revokeResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "revoke_refresh_token_family", revokeResult.Error, owner)
+	}
+
+	// a family lives in a single login session, the access token of that session goes with it
+	revoked, _ := revokeResult.Result.(bool)
+	if revoked {
+		sessionKey := getLoginSessionKey(owner.Subject, owner.DeviceID, owner.DeviceLogin)
+		sessionResult := <-au.LoginSessionRepo.Delete(ctxReq, sessionKey)
+		if sessionResult.Error != nil && sessionResult.Error.Error() != helper.ErrorRedis {
+			helper.SendErrorLog(ctxReq, ctx, "delete_login_session", sessionResult.Error, sessionKey)
+		}
+	}
+	tags["revoked"] = revoked
+
+	event := model.NewSecurityEvent(model.SecurityEventRefreshTokenReuse, model.SecurityEventPayload{
+		Subject:     owner.Subject,
+		DeviceID:    owner.DeviceID,
+		DeviceLogin: owner.DeviceLogin,
+		ClientID:    owner.Audience,
+		FamilyID:    owner.FamilyID,
+		Generation:  owner.Generation,
+		IP:          data.IP,
+		UserAgent:   data.UserAgent,
+		Revoked:     revoked,
+	})
+	go au.PublishSecurityEvent(ctxReq, event)
+}
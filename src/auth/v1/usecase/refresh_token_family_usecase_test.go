@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUseCaseImpl_rotateRefreshToken(t *testing.T) {
+	owner := model.RefreshTokenOwner{Key: "RT-USR123-ASX1234-WEB", Subject: "USR123", DeviceID: "ASX1234", DeviceLogin: "WEB", FamilyID: "FAM1", Generation: 2}
+	rotated := owner
+	rotated.RotatedAt = time.Now().Unix()
+
+	tests := []struct {
+		name             string
+		rotateResult     repo.ResultRepository
+		revokeResult     repo.ResultRepository
+		wantStatus       int
+		wantErr          bool
+		wantRevoke       bool
+		wantFamily       string
+		wantGeneration   int
+		wantSessionEnded bool
+	}{
+		{
+			name:           "Case 1: Rotate joins the family",
+			rotateResult:   repo.ResultRepository{Result: &owner},
+			wantStatus:     http.StatusOK,
+			wantFamily:     "FAM1",
+			wantGeneration: 3,
+		},
+		{
+			name:         "Case 2: Token issued before families starts a new one",
+			rotateResult: repo.ResultRepository{Error: repo.ErrRefreshTokenNotInFamily},
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:             "Case 3: Token rotated by another request revokes the family",
+			rotateResult:     repo.ResultRepository{Result: &rotated},
+			revokeResult:     repo.ResultRepository{Result: true},
+			wantStatus:       http.StatusUnauthorized,
+			wantErr:          true,
+			wantRevoke:       true,
+			wantSessionEnded: true,
+		},
+		{
+			name:         "Case 4: Error redis does not skip the family",
+			rotateResult: repo.ResultRepository{Error: errors.New("connection refused")},
+			wantStatus:   http.StatusInternalServerError,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepo := new(mocksRepo.RefreshTokenRepository)
+			refreshTokenRepo.On("Rotate", mock.Anything, "REFRESH123").Return(generateLockoutResult(tt.rotateResult))
+			refreshTokenRepo.On("RevokeFamily", mock.Anything, mock.Anything).Return(generateLockoutResult(tt.revokeResult))
+			loginSessionRepo := new(mocksRepo.LoginSessionRepository)
+			loginSessionRepo.On("Delete", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+			publisher := new(mocksService.QPublisher)
+			publisher.On("PublishKafka", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			au := &AuthUseCaseImpl{RefreshTokenRepo: refreshTokenRepo, LoginSessionRepo: loginSessionRepo, QPublisher: publisher}
+			data := &model.RequestToken{RefreshToken: "REFRESH123"}
+			httpStatus, err := au.rotateRefreshToken(context.Background(), data)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantFamily, data.RefreshTokenFamily)
+			assert.Equal(t, tt.wantGeneration, data.RefreshTokenGeneration)
+
+			if tt.wantRevoke {
+				refreshTokenRepo.AssertCalled(t, "RevokeFamily", mock.Anything, &rotated)
+			} else {
+				refreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+			}
+			if tt.wantSessionEnded {
+				loginSessionRepo.AssertCalled(t, "Delete", mock.Anything, "STG-USR123-ASX1234-WEB")
+			} else {
+				loginSessionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_detectRefreshTokenReuse(t *testing.T) {
+	owner := model.RefreshTokenOwner{Key: "RT-USR123-ASX1234-WEB", Subject: "USR123", DeviceID: "ASX1234", DeviceLogin: "WEB", FamilyID: "FAM1"}
+	rotated := owner
+	rotated.RotatedAt = time.Now().Unix()
+
+	tests := []struct {
+		name       string
+		findResult repo.ResultRepository
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: Unknown token",
+			findResult: repo.ResultRepository{Error: errors.New("redis: nil")},
+		},
+		{
+			name:       "Case 2: Token not rotated yet",
+			findResult: repo.ResultRepository{Result: model.RefreshToken{ID: owner.Key, Token: "REFRESH123", Owner: &owner}},
+		},
+		{
+			name:       "Case 3: Rotated token presented again",
+			findResult: repo.ResultRepository{Result: model.RefreshToken{ID: owner.Key, Token: "REFRESH456", Owner: &rotated}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepo := new(mocksRepo.RefreshTokenRepository)
+			refreshTokenRepo.On("FindByToken", mock.Anything, "REFRESH123").Return(generateLockoutResult(tt.findResult))
+			refreshTokenRepo.On("RevokeFamily", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{Result: true}))
+			loginSessionRepo := new(mocksRepo.LoginSessionRepository)
+			loginSessionRepo.On("Delete", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+			publisher := new(mocksService.QPublisher)
+			publisher.On("PublishKafka", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			au := &AuthUseCaseImpl{RefreshTokenRepo: refreshTokenRepo, LoginSessionRepo: loginSessionRepo, QPublisher: publisher}
+			err := au.detectRefreshTokenReuse(context.Background(), &model.RequestToken{RefreshToken: "REFRESH123"})
+			assert.Equal(t, tt.wantErr, err != nil)
+			if tt.wantErr {
+				assert.Equal(t, model.ErrorRefreshToken, err.Error())
+				refreshTokenRepo.AssertCalled(t, "RevokeFamily", mock.Anything, &rotated)
+			} else {
+				refreshTokenRepo.AssertNotCalled(t, "RevokeFamily", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_revokeRefreshTokenFamily(t *testing.T) {
+	owner := &model.RefreshTokenOwner{Key: "RT-USR123-ASX1234-WEB", Subject: "USR123", DeviceID: "ASX1234", DeviceLogin: "WEB", FamilyID: "FAM1", Generation: 4, RotatedAt: time.Now().Unix()}
+
+	tests := []struct {
+		name         string
+		revokeResult repo.ResultRepository
+		wantRevoked  bool
+	}{
+		{
+			name:         "Case 1: Family revoked ends the login session",
+			revokeResult: repo.ResultRepository{Result: true},
+			wantRevoked:  true,
+		},
+		{
+			name:         "Case 2: Session already replaced by another login",
+			revokeResult: repo.ResultRepository{Result: false},
+		},
+		{
+			name:         "Case 3: Revoke fails",
+			revokeResult: repo.ResultRepository{Error: errors.New("connection refused")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refreshTokenRepo := new(mocksRepo.RefreshTokenRepository)
+			refreshTokenRepo.On("RevokeFamily", mock.Anything, owner).Return(generateLockoutResult(tt.revokeResult))
+			loginSessionRepo := new(mocksRepo.LoginSessionRepository)
+			loginSessionRepo.On("Delete", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+
+			published := make(chan []byte, 1)
+			publisher := new(mocksService.QPublisher)
+			publisher.On("PublishKafka", mock.Anything, mock.Anything, "USR123", mock.Anything).Run(func(args mock.Arguments) {
+				published <- args.Get(3).([]byte)
+			}).Return(nil)
+
+			au := &AuthUseCaseImpl{RefreshTokenRepo: refreshTokenRepo, LoginSessionRepo: loginSessionRepo, QPublisher: publisher}
+			au.revokeRefreshTokenFamily(context.Background(), &model.RequestToken{IP: "36.72.10.1"}, owner)
+
+			if tt.wantRevoked {
+				loginSessionRepo.AssertCalled(t, "Delete", mock.Anything, "STG-USR123-ASX1234-WEB")
+			} else {
+				loginSessionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+
+			select {
+			case payload := <-published:
+				event := model.SecurityEvent{}
+				assert.NoError(t, json.Unmarshal(payload, &event))
+				assert.Equal(t, model.SecurityEventRefreshTokenReuse, event.EventType)
+				assert.Equal(t, "FAM1", event.Payload.FamilyID)
+				assert.Equal(t, 4, event.Payload.Generation)
+				assert.Equal(t, tt.wantRevoked, event.Payload.Revoked)
+			case <-time.After(time.Second):
+				t.Error("security event is not published")
+			}
+		})
+	}
+}
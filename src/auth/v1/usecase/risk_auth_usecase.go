@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	sessionInfoModel "github.com/Bhinneka/user-service/src/session/v1/model"
+)
+
+const formatRiskLoginTime = "02 January 2006 15:04 MST"
+
+// evaluateLoginRisk function for comparing a login with the recent logins of the member, the member is told by email
+// when the login looks unusual. A blocked login returns the error, a step up marks data so showMFAResponse asks
+// a second factor. When the history can not be read the login goes on
+func (au *AuthUseCaseImpl) evaluateLoginRisk(ctxReq context.Context, data *model.RequestToken) (int, error) {
+	ctx := "AuthUseCase-evaluateLoginRisk"
+	if au.SessionInfoQuery == nil || !isPasskeyPrimaryGrant(data) {
+		return http.StatusOK, nil
+	}
+
+	policy := au.riskPolicy()
+	params := &memberModel.ParametersLoginActivity{MemberID: data.UserID, Limit: policy.History}
+	historyResult := <-au.SessionInfoQuery.GetHistorySessionInfo(ctxReq, params)
+	if historyResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "get_history_session", historyResult.Error, data.UserID)
+		return http.StatusOK, nil
+	}
+
+	sessions, _ := historyResult.Result.(sessionInfoModel.SessionInfoList)
+	history := make([]model.RiskLogin, 0, len(sessions.Data))
+	for _, session := range sessions.Data {
+		history = append(history, newRiskLogin(session))
+	}
+
+	login := model.RiskLogin{DeviceID: data.DeviceID, IP: data.IP, At: time.Now()}
+	assessment := policy.Assess(login, history, data.LoginFailures, au.GeoLocator)
+	if assessment.Action == model.RiskActionAllow {
+		return http.StatusOK, nil
+	}
+
+	au.notifyRiskLogin(ctxReq, data, assessment)
+	switch assessment.Action {
+	case model.RiskActionBlock:
+		return http.StatusForbidden, errors.New(model.ErrorLoginRiskBlockedBahasa)
+	case model.RiskActionStepUp:
+		data.RiskStepUp = true
+	}
+	return http.StatusOK, nil
+}
+
+// riskPolicy function for getting the policy of the risk evaluator, the default one when it is not set
+func (au *AuthUseCaseImpl) riskPolicy() model.RiskPolicy {
+	if au.RiskPolicy.Scores == nil {
+		return model.DefaultRiskPolicy
+	}
+	return au.RiskPolicy
+}
+
+// notifyRiskLogin function for publishing the suspicious login and emailing the member, both in the background
+func (au *AuthUseCaseImpl) notifyRiskLogin(ctxReq context.Context, data *model.RequestToken, assessment model.RiskAssessment) {
+	payload := model.SecurityEventPayload{
+		Subject:     data.UserID,
+		DeviceID:    data.DeviceID,
+		DeviceLogin: data.DeviceLogin,
+		IP:          data.IP,
+		UserAgent:   data.UserAgent,
+		Risk:        &assessment,
+	}
+	go au.PublishSecurityEvent(ctxReq, model.NewSecurityEvent(model.SecurityEventSuspiciousLogin, payload))
+
+	if au.NotificationService == nil || data.Email == "" {
+		return
+	}
+
+	device, _, _ := helper.ParseUserAgent(data.UserAgent)
+	location := assessment.Location
+	if location == "" {
+		location = "-"
+	}
+
+	email := serviceModel.Email{}
+	email.From = serviceModel.NoReply
+	email.FromName = serviceModel.NoReplyName
+	email.To = []string{data.Email}
+	email.ToName = []string{data.FirstName}
+	email.Subject = model.SubjectRiskLogin
+	email.Content = fmt.Sprintf(model.ContentRiskLogin, data.FirstName, time.Now().Format(formatRiskLoginTime),
+		device, data.IP, location, model.RiskNotificationActions[assessment.Action])
+	go au.sendRiskNotification(ctxReq, email)
+}
+
+func (au *AuthUseCaseImpl) sendRiskNotification(ctxReq context.Context, email serviceModel.Email) {
+	if _, err := au.NotificationService.SendEmail(ctxReq, email); err != nil {
+		helper.SendErrorLog(ctxReq, "AuthUseCase-sendRiskNotification", "send_email", err, email.To)
+	}
+}
+
+// riskStepUpMethods function for asking a code by email to the account email when a step up finds no second factor,
+// the email is kept for the device so the code can be sent again
+func (au *AuthUseCaseImpl) riskStepUpMethods(ctxReq context.Context, data *model.RequestToken) []memberModel.MFAMethod {
+	ctx := "AuthUseCase-riskStepUpMethods"
+	if au.MFAOTP == nil || data.Email == "" {
+		return nil
+	}
+
+	paramRedis := &model.LoginSessionRedis{
+		Key:         model.GetRiskStepUpKey(data.UserID, getMFAOTPScope(data)),
+		Token:       data.Email,
+		ExpiredTime: 5 * time.Minute,
+	}
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_risk_step_up", saveResult.Error, paramRedis.Key)
+		return nil
+	}
+
+	return []memberModel.MFAMethod{newRiskStepUpMethod(data.Email)}
+}
+
+// riskStepUpMethod function for getting the account email a step up code of the device is sent to
+func (au *AuthUseCaseImpl) riskStepUpMethod(ctxReq context.Context, memberID string, data *model.RequestToken) (memberModel.MFAMethod, bool) {
+	if data.MFAMethod != memberModel.MFAMethodEmail {
+		return memberModel.MFAMethod{}, false
+	}
+
+	stepUpResult := <-au.LoginSessionRepo.Load(ctxReq, model.GetRiskStepUpKey(memberID, getMFAOTPScope(data)))
+	stepUp, _ := stepUpResult.Result.(model.LoginSessionRedis)
+	if stepUpResult.Error != nil || stepUp.Token == "" {
+		return memberModel.MFAMethod{}, false
+	}
+	return newRiskStepUpMethod(stepUp.Token), true
+}
+
+func newRiskStepUpMethod(email string) memberModel.MFAMethod {
+	return memberModel.MFAMethod{
+		Method:     memberModel.MFAMethodEmail,
+		Target:     email,
+		TargetMask: memberModel.MaskMFATarget(memberModel.MFAMethodEmail, email),
+		IsDefault:  true,
+	}
+}
+
+func newRiskLogin(session sessionInfoModel.SessionInfoResponse) model.RiskLogin {
+	login := model.RiskLogin{}
+	if session.DeviceID != nil {
+		login.DeviceID = *session.DeviceID
+	}
+	if session.IP != nil {
+		login.IP = *session.IP
+	}
+	if session.CreatedAt != nil {
+		login.At = *session.CreatedAt
+	}
+	return login
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	mocksQuerySession "github.com/Bhinneka/user-service/mocks/src/session/v1/query"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	sessionInfoModel "github.com/Bhinneka/user-service/src/session/v1/model"
+	sessionInfoQuery "github.com/Bhinneka/user-service/src/session/v1/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func generateSessionInfoQueryResult(data sessionInfoQuery.ResultQuery) <-chan sessionInfoQuery.ResultQuery {
+	output := make(chan sessionInfoQuery.ResultQuery, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestAuthUseCaseImpl_evaluateLoginRisk(t *testing.T) {
+	deviceID, ip, createdAt := "ASX1234", "36.72.10.1", time.Now().Add(-time.Hour)
+	history := sessionInfoQuery.ResultQuery{Result: sessionInfoModel.SessionInfoList{
+		Data: []sessionInfoModel.SessionInfoResponse{{DeviceID: &deviceID, IP: &ip, CreatedAt: &createdAt}},
+	}}
+	blockPolicy, _ := model.ParseRiskPolicy(`{"blockAt":20}`)
+
+	tests := []struct {
+		name          string
+		data          model.RequestToken
+		policy        model.RiskPolicy
+		historyResult sessionInfoQuery.ResultQuery
+		wantStatus    int
+		wantErr       bool
+		wantStepUp    bool
+		wantEmail     bool
+	}{
+		{
+			name:          "Case 1: Known device",
+			data:          model.RequestToken{DeviceID: "ASX1234", IP: "36.72.10.9"},
+			historyResult: history,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "Case 2: New device is notified",
+			data:          model.RequestToken{DeviceID: "NEW999", IP: "36.72.10.9"},
+			historyResult: history,
+			wantStatus:    http.StatusOK,
+			wantEmail:     true,
+		},
+		{
+			name:          "Case 3: New device after failures steps up",
+			data:          model.RequestToken{DeviceID: "NEW999", IP: "36.72.10.9", LoginFailures: 3},
+			historyResult: history,
+			wantStatus:    http.StatusOK,
+			wantStepUp:    true,
+			wantEmail:     true,
+		},
+		{
+			name:          "Case 4: Blocked",
+			data:          model.RequestToken{DeviceID: "NEW999", IP: "36.72.10.9"},
+			policy:        blockPolicy,
+			historyResult: history,
+			wantStatus:    http.StatusForbidden,
+			wantErr:       true,
+			wantEmail:     true,
+		},
+		{
+			name:          "Case 5: History can not be read",
+			data:          model.RequestToken{DeviceID: "NEW999", IP: "36.72.10.9", LoginFailures: 10},
+			historyResult: sessionInfoQuery.ResultQuery{Error: errors.New("connection refused")},
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:       "Case 6: Corporate login is not evaluated",
+			data:       model.RequestToken{DeviceID: "NEW999", MemberType: model.UserTypeCorporate, LoginFailures: 10},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.data.UserID, tt.data.Email, tt.data.GrantType = "USR123", "john@bhinneka.com", model.AuthTypePassword
+
+			sessionQuery := new(mocksQuerySession.SessionInfoQuery)
+			sessionQuery.On("GetHistorySessionInfo", mock.Anything, mock.Anything).Return(generateSessionInfoQueryResult(tt.historyResult))
+			publisher := new(mocksService.QPublisher)
+			publisher.On("PublishKafka", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			sent := make(chan serviceModel.Email, 1)
+			notification := new(mocksService.NotificationServices)
+			notification.On("SendEmail", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				sent <- args.Get(1).(serviceModel.Email)
+			}).Return("", nil)
+
+			au := &AuthUseCaseImpl{
+				SessionInfoQuery:    sessionQuery,
+				QPublisher:          publisher,
+				NotificationService: notification,
+				RiskPolicy:          tt.policy,
+			}
+			httpStatus, err := au.evaluateLoginRisk(context.Background(), &tt.data)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantStepUp, tt.data.RiskStepUp)
+
+			if !tt.wantEmail {
+				notification.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+				return
+			}
+			select {
+			case email := <-sent:
+				assert.Equal(t, []string{"john@bhinneka.com"}, email.To)
+				assert.Equal(t, model.SubjectRiskLogin, email.Subject)
+			case <-time.After(time.Second):
+				t.Error("notification email is not sent")
+			}
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_riskStepUpMethod(t *testing.T) {
+	data := &model.RequestToken{DeviceID: "ASX1234", DeviceLogin: "WEB", MFAMethod: memberModel.MFAMethodEmail}
+	stepUpKey := model.GetRiskStepUpKey("USR123", "ASX1234-WEB")
+
+	tests := []struct {
+		name       string
+		loadResult repo.ResultRepository
+		wantOK     bool
+	}{
+		{
+			name:       "Case 1: Step up is waiting",
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Key: stepUpKey, Token: "john@bhinneka.com"}},
+			wantOK:     true,
+		},
+		{
+			name:       "Case 2: No step up",
+			loadResult: repo.ResultRepository{Error: errors.New("redis: nil")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loginSessionRepo := new(mocksRepo.LoginSessionRepository)
+			loginSessionRepo.On("Load", mock.Anything, stepUpKey).Return(generateLockoutResult(tt.loadResult))
+
+			au := &AuthUseCaseImpl{LoginSessionRepo: loginSessionRepo}
+			method, ok := au.riskStepUpMethod(context.Background(), "USR123", data)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, "john@bhinneka.com", method.Target)
+				assert.Equal(t, "j***@bhinneka.com", method.TargetMask)
+			}
+		})
+	}
+}
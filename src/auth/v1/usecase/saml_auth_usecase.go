@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	corporateModel "github.com/Bhinneka/user-service/src/corporate/v2/model"
+	sharedModel "github.com/Bhinneka/user-service/src/shared/model"
+	"github.com/Bhinneka/user-service/src/shared/saml"
+)
+
+// samlRandomLength random bytes of the relay state and the ticket of a saml login
+const samlRandomLength = 32
+
+// AuthorizeSAML function for starting the single sign on of a corporate account, the user agent is sent to the
+// identity provider with the returned url and comes back to the assertion consumer service with the relay state
+func (au *AuthUseCaseImpl) AuthorizeSAML(ctxReq context.Context, accountID string, data model.SAMLAuthorizeRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-AuthorizeSAML"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = accountID
+		clientApp, oauthErr := au.findOAuthClient(ctxReq, data.ClientID, data.ClientSecret, true)
+		if oauthErr != nil {
+			output <- ResultUseCase{Error: oauthErr, HTTPStatus: oauthErr.HTTPStatus}
+			return
+		}
+
+		if !clientApp.AllowRedirectURI(data.RedirectURI) {
+			output <- ResultUseCase{Error: errors.New(model.ErrorInvalidRedirectURL), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		_, idp, httpStatus, err := au.findAccountIdentityProvider(ctxReq, accountID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
+		requestID, err := saml.NewRequestID()
+		relayState := helper.RandomStringBase64(samlRandomLength)
+		if err != nil || relayState == "" {
+			output <- ResultUseCase{Error: errors.New("failed to start saml login"), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		sp := saml.NewServiceProvider(au.SAMLBaseURL, accountID)
+		authorizationURL, err := sp.AuthnRequestURL(idp, requestID, relayState, time.Now())
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: saml.HTTPStatus(err)}
+			return
+		}
+
+		pending, _ := json.Marshal(model.SAMLPendingLogin{
+			RequestID:   requestID,
+			AccountID:   accountID,
+			Audience:    clientApp.ClientID,
+			RedirectURI: data.RedirectURI,
+		})
+		paramRedis := &model.LoginSessionRedis{
+			Key:         model.GetSAMLRequestKey(relayState),
+			Token:       string(pending),
+			ExpiredTime: model.SAMLRequestAge,
+		}
+		if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+			helper.SendErrorLog(ctxReq, ctx, "save_saml_request", saveResult.Error, accountID)
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.SAMLAuthorization{AuthorizationURL: authorizationURL, RelayState: relayState}}
+	})
+
+	return output
+}
+
+// LoginSAML function for consuming the response of the identity provider, the result is the redirect uri of the
+// client with a short lived ticket exchanged for a token, or with the access_denied error when the login failed
+func (au *AuthUseCaseImpl) LoginSAML(ctxReq context.Context, accountID string, data model.SAMLLoginRequest) <-chan ResultUseCase {
+	ctx := "AuthUseCase-LoginSAML"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = accountID
+		pending, err := au.consumeSAMLRequest(ctxReq, accountID, data.RelayState)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		redirectURL, err := url.Parse(pending.RedirectURI)
+		if err != nil {
+			output <- ResultUseCase{Error: errors.New(model.ErrorInvalidRedirectURL), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		query := redirectURL.Query()
+		ticket, err := au.loginSAML(ctxReq, pending, data.SAMLResponse)
+		if err != nil {
+			tags[helper.TextResponse] = err.Error()
+			query.Set("error", model.SAMLErrorAccessDenied)
+			query.Set("error_description", err.Error())
+		} else {
+			query.Set("code", ticket)
+		}
+		redirectURL.RawQuery = query.Encode()
+
+		output <- ResultUseCase{Result: redirectURL.String()}
+	})
+
+	return output
+}
+
+// GetSAMLMetadata function for getting the service provider metadata of an account, uploaded to the identity provider
+func (au *AuthUseCaseImpl) GetSAMLMetadata(ctxReq context.Context, accountID string) <-chan ResultUseCase {
+	ctx := "AuthUseCase-GetSAMLMetadata"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = accountID
+		samlResult := <-au.AccountSAMLQuery.FindByAccountID(ctxReq, accountID)
+		if samlResult.Error != nil {
+			if samlResult.Error == sql.ErrNoRows {
+				output <- ResultUseCase{Error: errors.New(model.ErrorSAMLDisabled), HTTPStatus: http.StatusNotFound}
+				return
+			}
+			output <- ResultUseCase{Error: samlResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		metadata, err := saml.NewServiceProvider(au.SAMLBaseURL, accountID).Metadata()
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: metadata}
+	})
+
+	return output
+}
+
+// findAccountIdentityProvider function for getting the enabled single sign on of an account with its identity provider
+func (au *AuthUseCaseImpl) findAccountIdentityProvider(ctxReq context.Context, accountID string) (corporateModel.AccountSAML, saml.IdentityProvider, int, error) {
+	samlResult := <-au.AccountSAMLQuery.FindByAccountID(ctxReq, accountID)
+	if samlResult.Error != nil {
+		if samlResult.Error == sql.ErrNoRows {
+			return corporateModel.AccountSAML{}, saml.IdentityProvider{}, http.StatusNotFound, errors.New(model.ErrorSAMLDisabled)
+		}
+		return corporateModel.AccountSAML{}, saml.IdentityProvider{}, http.StatusInternalServerError, samlResult.Error
+	}
+
+	accountSAML, ok := samlResult.Result.(corporateModel.AccountSAML)
+	if !ok || !accountSAML.Enabled {
+		return corporateModel.AccountSAML{}, saml.IdentityProvider{}, http.StatusNotFound, errors.New(model.ErrorSAMLDisabled)
+	}
+
+	idp, err := saml.ParseMetadata([]byte(accountSAML.Metadata))
+	if err != nil {
+		return corporateModel.AccountSAML{}, saml.IdentityProvider{}, http.StatusInternalServerError, err
+	}
+
+	return accountSAML, idp, http.StatusOK, nil
+}
+
+// consumeSAMLRequest function for taking the pending login of a relay state, a relay state is used only once
+func (au *AuthUseCaseImpl) consumeSAMLRequest(ctxReq context.Context, accountID, relayState string) (model.SAMLPendingLogin, error) {
+	if relayState == "" {
+		return model.SAMLPendingLogin{}, errors.New(model.ErrorSAMLRelayState)
+	}
+
+	key := model.GetSAMLRequestKey(relayState)
+	requestResult := <-au.LoginSessionRepo.Load(ctxReq, key)
+	request, _ := requestResult.Result.(model.LoginSessionRedis)
+	if requestResult.Error != nil || request.Token == "" {
+		return model.SAMLPendingLogin{}, errors.New(model.ErrorSAMLRelayState)
+	}
+	<-au.LoginSessionRepo.Delete(ctxReq, key)
+
+	var pending model.SAMLPendingLogin
+	if err := json.Unmarshal([]byte(request.Token), &pending); err != nil || pending.AccountID != accountID {
+		return model.SAMLPendingLogin{}, errors.New(model.ErrorSAMLRelayState)
+	}
+
+	return pending, nil
+}
+
+// loginSAML function for validating the response of the identity provider and saving the ticket of the contact
+func (au *AuthUseCaseImpl) loginSAML(ctxReq context.Context, pending model.SAMLPendingLogin, samlResponse string) (string, error) {
+	ctx := "AuthUseCase-loginSAML"
+
+	accountSAML, idp, _, err := au.findAccountIdentityProvider(ctxReq, pending.AccountID)
+	if err != nil {
+		return "", err
+	}
+
+	sp := saml.NewServiceProvider(au.SAMLBaseURL, pending.AccountID)
+	assertion, err := sp.ParseResponse(idp, samlResponse, pending.RequestID, time.Now())
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "parse_saml_response", err, pending.AccountID)
+		return "", err
+	}
+
+	contact := accountSAML.AttributeMapping.Contact(pending.AccountID, assertion)
+	if err := helper.ValidateEmail(contact.Email); err != nil {
+		return "", errors.New(model.ErrorSAMLContactBahasa)
+	}
+
+	contactID, err := au.samlContact(ctxReq, accountSAML, contact)
+	if err != nil {
+		return "", err
+	}
+
+	ticket := helper.RandomStringBase64(samlRandomLength)
+	if ticket == "" {
+		return "", errors.New("failed to generate saml ticket")
+	}
+
+	samlTicket, _ := json.Marshal(model.SAMLTicket{ContactID: contactID, AccountID: pending.AccountID, Audience: pending.Audience})
+	paramRedis := &model.LoginSessionRedis{
+		Key:         model.GetSAMLTicketKey(helper.HashSHA256(ticket)),
+		Token:       string(samlTicket),
+		ExpiredTime: model.SAMLTicketAge,
+	}
+	if saveResult := <-au.LoginSessionRepo.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_saml_ticket", saveResult.Error, contactID)
+		return "", saveResult.Error
+	}
+
+	return ticket, nil
+}
+
+// samlContact function for finding the contact of the account logged in by the identity provider,
+// a missing contact is provisioned when the account turns on just in time provisioning
+func (au *AuthUseCaseImpl) samlContact(ctxReq context.Context, accountSAML corporateModel.AccountSAML, contact sharedModel.B2BContactData) (int, error) {
+	ctx := "AuthUseCase-samlContact"
+
+	contactResult := <-au.CorporateContactQueryRead.FindContactCorporateByEmail(ctxReq, contact.Email)
+	if contactResult.Error == nil {
+		existing, ok := contactResult.Result.(sharedModel.B2BContactData)
+		if !ok {
+			return 0, errors.New(msgResultNotMember)
+		}
+
+		if err := au.validateMemberLoginPasswordCorporate(&existing); err != nil {
+			return 0, err
+		}
+
+		// an email of another account is not taken over by this identity provider
+		if existing.AccountID != accountSAML.AccountID {
+			return 0, errors.New(model.ErrorSAMLContactBahasa)
+		}
+		return existing.ID, nil
+	}
+
+	if contactResult.Error != sql.ErrNoRows {
+		return 0, contactResult.Error
+	}
+
+	if !accountSAML.JITProvisioning {
+		return 0, errors.New(model.ErrorSAMLContactBahasa)
+	}
+
+	if err := au.AccountSAMLRepo.ProvisionContact(ctxReq, &contact); err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "provision_contact", err, contact.Email)
+		return 0, err
+	}
+
+	return contact.ID, nil
+}
+
+// parseSAMLType function for exchanging the ticket of a saml login for the token of the contact
+func (au *AuthUseCaseImpl) parseSAMLType(ctxReq context.Context, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
+	if err := au.validateInput(data); err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	ticket, err := au.consumeSAMLTicket(ctxReq, data.Code)
+	if err != nil || ticket.Audience != data.Audience {
+		return http.StatusUnauthorized, "", errors.New(model.ErrorSAMLTicketBahasa)
+	}
+
+	contactResult := <-au.CorporateContactQueryRead.FindByID(ctxReq, strconv.Itoa(ticket.ContactID))
+	if contactResult.Error != nil {
+		return http.StatusUnauthorized, "", errors.New(model.ErrorSAMLTicketBahasa)
+	}
+
+	contact, ok := contactResult.Result.(sharedModel.B2BContactData)
+	if !ok {
+		return http.StatusUnauthorized, "", errors.New(msgResultNotMember)
+	}
+
+	// the contact may have been deactivated between the login and the exchange
+	if err := au.validateMemberLoginPasswordCorporate(&contact); err != nil {
+		return http.StatusBadRequest, "", err
+	}
+
+	if contact.AccountID != ticket.AccountID {
+		return http.StatusUnauthorized, "", errors.New(model.ErrorSAMLContactBahasa)
+	}
+
+	claims.Subject = strconv.Itoa(contact.ID)
+	claims.Authorised = true
+	claims.IsAdmin = false
+	claims.IsStaff = false
+	claims.Email = contact.Email
+
+	data.UserID = strconv.Itoa(contact.ID)
+	data.Email = contact.Email
+	data.FirstName = contact.FirstName
+	data.LastName = contact.LastName
+	data.Mobile = contact.PhoneNumber
+	data.NewMember = false
+	data.HasPassword = contact.Password != ""
+	data.AccountID = contact.AccountID
+	redisUserID = strconv.Itoa(contact.ID)
+
+	return http.StatusOK, redisUserID, nil
+}
+
+// consumeSAMLTicket function for taking the contact of a ticket, a ticket is used only once
+func (au *AuthUseCaseImpl) consumeSAMLTicket(ctxReq context.Context, ticket string) (model.SAMLTicket, error) {
+	if ticket == "" {
+		return model.SAMLTicket{}, errors.New(model.ErrorSAMLTicketBahasa)
+	}
+
+	key := model.GetSAMLTicketKey(helper.HashSHA256(ticket))
+	ticketResult := <-au.LoginSessionRepo.Load(ctxReq, key)
+	session, _ := ticketResult.Result.(model.LoginSessionRedis)
+	if ticketResult.Error != nil || session.Token == "" {
+		return model.SAMLTicket{}, errors.New(model.ErrorSAMLTicketBahasa)
+	}
+	<-au.LoginSessionRepo.Delete(ctxReq, key)
+
+	var samlTicket model.SAMLTicket
+	if err := json.Unmarshal([]byte(session.Token), &samlTicket); err != nil {
+		return model.SAMLTicket{}, errors.New(model.ErrorSAMLTicketBahasa)
+	}
+
+	return samlTicket, nil
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksCorporateQuery "github.com/Bhinneka/user-service/mocks/src/corporate/v2/query"
+	mocksCorporateRepo "github.com/Bhinneka/user-service/mocks/src/corporate/v2/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	corporateModel "github.com/Bhinneka/user-service/src/corporate/v2/model"
+	corporateQuery "github.com/Bhinneka/user-service/src/corporate/v2/query"
+	sharedModel "github.com/Bhinneka/user-service/src/shared/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const samlAccountID = "ACC123"
+
+func generateCorporateQueryResult(data corporateQuery.ResultQuery) <-chan corporateQuery.ResultQuery {
+	output := make(chan corporateQuery.ResultQuery, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func samlAccountContact(accountID, status string, isDisabled bool) corporateQuery.ResultQuery {
+	return corporateQuery.ResultQuery{Result: sharedModel.B2BAccountContact{Status: &status, IsDisabled: isDisabled, AccountID: &accountID}}
+}
+
+func TestAuthUseCaseImpl_LoginSAML(t *testing.T) {
+	pending, _ := json.Marshal(model.SAMLPendingLogin{
+		RequestID:   "_request",
+		AccountID:   samlAccountID,
+		Audience:    "client",
+		RedirectURI: "https://corporate.bhinneka.com/sso?next=%2Forders",
+	})
+	tests := []struct {
+		name       string
+		accountID  string
+		loadResult repo.ResultRepository
+		wantStatus int
+		wantErr    string
+	}{
+		{
+			name:       "Case 1: unknown relay state",
+			accountID:  samlAccountID,
+			loadResult: repo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    model.ErrorSAMLRelayState,
+		},
+		{
+			name:       "Case 2: relay state of another account",
+			accountID:  "ACC456",
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Token: string(pending)}},
+			wantStatus: http.StatusBadRequest,
+			wantErr:    model.ErrorSAMLRelayState,
+		},
+		{
+			name:       "Case 3: single sign on turned off after the login started",
+			accountID:  samlAccountID,
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Token: string(pending)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := model.GetSAMLRequestKey("relay-state")
+			sessionRepo := new(mocksRepo.LoginSessionRepository)
+			sessionRepo.On("Load", mock.Anything, key).Return(generateLockoutResult(tt.loadResult))
+			sessionRepo.On("Delete", mock.Anything, key).Return(generateLockoutResult(repo.ResultRepository{Result: int64(1)}))
+			samlQuery := new(mocksCorporateQuery.AccountSAMLQuery)
+			samlQuery.On("FindByAccountID", mock.Anything, samlAccountID).
+				Return(generateCorporateQueryResult(corporateQuery.ResultQuery{Result: corporateModel.AccountSAML{AccountID: samlAccountID}}))
+
+			au := &AuthUseCaseImpl{LoginSessionRepo: sessionRepo, AccountSAMLQuery: samlQuery}
+
+			result := <-au.LoginSAML(context.Background(), tt.accountID, model.SAMLLoginRequest{SAMLResponse: "response", RelayState: "relay-state"})
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+				assert.EqualError(t, result.Error, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, result.Error)
+			location, err := url.Parse(result.Result.(string))
+			assert.NoError(t, err)
+			assert.Equal(t, "corporate.bhinneka.com", location.Host)
+			assert.Equal(t, "/orders", location.Query().Get("next"))
+			assert.Equal(t, model.SAMLErrorAccessDenied, location.Query().Get("error"))
+			assert.Empty(t, location.Query().Get("code"))
+			sessionRepo.AssertCalled(t, "Delete", mock.Anything, key)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_samlContact(t *testing.T) {
+	contact := sharedModel.B2BContactData{ID: 10, Email: lockoutEmail, AccountID: samlAccountID}
+	tests := []struct {
+		name            string
+		jitProvisioning bool
+		contactResult   corporateQuery.ResultQuery
+		accountContact  corporateQuery.ResultQuery
+		wantID          int
+		wantErr         string
+	}{
+		{
+			name:           "Case 1: contact of the account",
+			contactResult:  corporateQuery.ResultQuery{Result: contact},
+			accountContact: samlAccountContact(samlAccountID, corporateModel.StatusActivated, false),
+			wantID:         10,
+		},
+		{
+			name:           "Case 2: contact of another account",
+			contactResult:  corporateQuery.ResultQuery{Result: contact},
+			accountContact: samlAccountContact("ACC456", corporateModel.StatusActivated, false),
+			wantErr:        model.ErrorSAMLContactBahasa,
+		},
+		{
+			name:           "Case 3: deactivated contact",
+			contactResult:  corporateQuery.ResultQuery{Result: contact},
+			accountContact: samlAccountContact(samlAccountID, sharedModel.StatusDeactiveAccount, false),
+			wantErr:        model.ErrorAccountDeactiveBahasa,
+		},
+		{
+			name:          "Case 4: unknown email without provisioning",
+			contactResult: corporateQuery.ResultQuery{Error: sql.ErrNoRows},
+			wantErr:       model.ErrorSAMLContactBahasa,
+		},
+		{
+			name:            "Case 5: unknown email is provisioned",
+			jitProvisioning: true,
+			contactResult:   corporateQuery.ResultQuery{Error: sql.ErrNoRows},
+			wantID:          900000001,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contactQuery := new(mocksCorporateQuery.ContactQuery)
+			contactQuery.On("FindContactCorporateByEmail", mock.Anything, lockoutEmail).Return(generateCorporateQueryResult(tt.contactResult))
+			accContactQuery := new(mocksCorporateQuery.AccountContactQuery)
+			accContactQuery.On("FindByAccountContactID", 10).Return(generateCorporateQueryResult(tt.accountContact))
+			samlRepo := new(mocksCorporateRepo.AccountSAMLRepository)
+			samlRepo.On("ProvisionContact", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				args.Get(1).(*sharedModel.B2BContactData).ID = 900000001
+			})
+
+			au := &AuthUseCaseImpl{
+				CorporateContactQueryRead:    contactQuery,
+				CorporateAccContactQueryRead: accContactQuery,
+				AccountSAMLRepo:              samlRepo,
+			}
+
+			accountSAML := corporateModel.AccountSAML{AccountID: samlAccountID, JITProvisioning: tt.jitProvisioning}
+			contactID, err := au.samlContact(context.Background(), accountSAML, sharedModel.B2BContactData{Email: lockoutEmail, AccountID: samlAccountID})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				samlRepo.AssertNotCalled(t, "ProvisionContact", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, contactID)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_parseSAMLType(t *testing.T) {
+	ticket, _ := json.Marshal(model.SAMLTicket{ContactID: 10, AccountID: samlAccountID, Audience: "client"})
+	contact := sharedModel.B2BContactData{ID: 10, Email: lockoutEmail, FirstName: "John", PhoneNumber: "08123456789"}
+	tests := []struct {
+		name           string
+		audience       string
+		loadResult     repo.ResultRepository
+		accountContact corporateQuery.ResultQuery
+		wantStatus     int
+		wantErr        string
+	}{
+		{
+			name:           "Case 1: ticket is exchanged",
+			audience:       "client",
+			loadResult:     repo.ResultRepository{Result: model.LoginSessionRedis{Token: string(ticket)}},
+			accountContact: samlAccountContact(samlAccountID, corporateModel.StatusActivated, false),
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:       "Case 2: used or expired ticket",
+			audience:   "client",
+			loadResult: repo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    model.ErrorSAMLTicketBahasa,
+		},
+		{
+			name:       "Case 3: ticket of another client",
+			audience:   "other-client",
+			loadResult: repo.ResultRepository{Result: model.LoginSessionRedis{Token: string(ticket)}},
+			wantStatus: http.StatusUnauthorized,
+			wantErr:    model.ErrorSAMLTicketBahasa,
+		},
+		{
+			name:           "Case 4: contact moved to another account",
+			audience:       "client",
+			loadResult:     repo.ResultRepository{Result: model.LoginSessionRedis{Token: string(ticket)}},
+			accountContact: samlAccountContact("ACC456", corporateModel.StatusActivated, false),
+			wantStatus:     http.StatusUnauthorized,
+			wantErr:        model.ErrorSAMLContactBahasa,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := model.GetSAMLTicketKey(helper.HashSHA256("ticket"))
+			sessionRepo := new(mocksRepo.LoginSessionRepository)
+			sessionRepo.On("Load", mock.Anything, key).Return(generateLockoutResult(tt.loadResult))
+			sessionRepo.On("Delete", mock.Anything, key).Return(generateLockoutResult(repo.ResultRepository{Result: int64(1)}))
+			contactQuery := new(mocksCorporateQuery.ContactQuery)
+			contactQuery.On("FindByID", mock.Anything, "10").Return(generateCorporateQueryResult(corporateQuery.ResultQuery{Result: contact}))
+			accContactQuery := new(mocksCorporateQuery.AccountContactQuery)
+			accContactQuery.On("FindByAccountContactID", 10).Return(generateCorporateQueryResult(tt.accountContact))
+
+			au := &AuthUseCaseImpl{
+				LoginSessionRepo:             sessionRepo,
+				CorporateContactQueryRead:    contactQuery,
+				CorporateAccContactQueryRead: accContactQuery,
+			}
+
+			data := model.RequestToken{
+				GrantType:   model.AuthTypeSAML,
+				MemberType:  model.UserTypeCorporate,
+				Code:        "ticket",
+				Audience:    tt.audience,
+				DeviceID:    "device",
+				DeviceLogin: "WEB",
+			}
+			claims := token.Claim{}
+			httpStatus, redisUserID, err := au.parseSAMLType(context.Background(), &data, &claims)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "10", redisUserID)
+			assert.Equal(t, "10", claims.Subject)
+			assert.True(t, claims.Authorised)
+			assert.Equal(t, samlAccountID, data.AccountID)
+			assert.Equal(t, "08123456789", data.Mobile)
+			assert.False(t, data.HasPassword)
+			sessionRepo.AssertCalled(t, "Delete", mock.Anything, key)
+		})
+	}
+}
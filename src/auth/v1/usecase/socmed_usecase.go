@@ -14,6 +14,7 @@ import (
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
 	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/connector"
 )
 
 // createMemberFromSocMed function for create member
@@ -129,6 +130,7 @@ func (au *AuthUseCaseImpl) CheckMemberSocmedType(ctxReq context.Context, data *m
 		data.NewMember = true
 
 		au.publishMemberData(ctxReq, member, eventType)
+		au.linkSocialMediaIdentity(ctxReq, data, &member)
 
 		return &model.ValidateSocmedRequest{Data: &member, NewMember: true, HTTPStatus: 200, Error: nil}
 	}
@@ -138,6 +140,11 @@ func (au *AuthUseCaseImpl) CheckMemberSocmedType(ctxReq context.Context, data *m
 		return &model.ValidateSocmedRequest{HTTPStatus: http.StatusInternalServerError, Error: errors.New(msgResultNotMember)}
 	}
 
+	// the provider account joins the member of its email after the member confirms the link
+	if status, err := au.checkIdentityLink(ctxReq, data, socialMedia, member); err != nil {
+		return &model.ValidateSocmedRequest{HTTPStatus: status, Error: err}
+	}
+
 	// check member status
 	checkMember := <-au.checkMemberStatus(ctxReq, member, socialMedia, data.GrantType)
 	if checkMember.Error != nil {
@@ -158,6 +165,7 @@ func (au *AuthUseCaseImpl) CheckMemberSocmedType(ctxReq context.Context, data *m
 	if err := au.publishUpdateMemberData(ctxReq, data.GrantType, member, memberData); err != nil {
 		return &model.ValidateSocmedRequest{HTTPStatus: http.StatusInternalServerError, Error: err}
 	}
+	au.linkSocialMediaIdentity(ctxReq, data, &memberData)
 
 	return &model.ValidateSocmedRequest{Data: &memberData, NewMember: newMember, HTTPStatus: 200, Error: nil}
 }
@@ -202,6 +210,12 @@ func (au *AuthUseCaseImpl) generateSocmedData(ctxReq context.Context, member mem
 func (au *AuthUseCaseImpl) processSocialMediaData(grantType string, socialMedia interface{}, existingMember *memberModel.Member, new bool) error {
 	ctx := "processSocialMedia-AuthUseCaseImpl"
 	ctxReq := context.Background()
+
+	// identities of oidc connectors are read with the claim mapping of the connector
+	if identity, ok := socialMedia.(connector.Identity); ok {
+		return au.parseConnectorData(identity, existingMember, new)
+	}
+
 	switch grantType {
 	case model.AuthTypeAzure:
 		if err := au.parseAzureData(socialMedia, existingMember, new); err != nil {
@@ -13,13 +13,14 @@ import (
 	"github.com/Bhinneka/golib"
 	"github.com/Bhinneka/golib/jsonschema"
 	"github.com/Bhinneka/golib/tracer"
-	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	"github.com/Bhinneka/user-service/src/auth/v1/token"
 	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
 	sessionInfoModel "github.com/Bhinneka/user-service/src/session/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
 	sharedModel "github.com/Bhinneka/user-service/src/shared/model"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 )
 
 // GenerateToken function for generating token based on grant type
@@ -60,6 +61,13 @@ func (au *AuthUseCaseImpl) GenerateToken(ctxReq context.Context, mode string, da
 
 		claimsGT.MemberType = data.MemberType
 
+		// a password login has to solve a captcha after failed logins or when the provider scores it as a bot
+		if httpStatus, err := au.checkLoginCaptcha(ctxReq, &data); err != nil {
+			tags[helper.TextResponse] = err.Error()
+			outputGT <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
 		// process the authentication based on grant type
 		httpStatus, redisUserID, err = au.parseGlobalData(ctxReq, mode, &data, &claimsGT)
 		if err != nil {
@@ -72,6 +80,13 @@ func (au *AuthUseCaseImpl) GenerateToken(ctxReq context.Context, mode string, da
 			return
 		}
 
+		// unusual device, network or location is told to the member and may ask a second factor or be refused
+		if httpStatus, err := au.evaluateLoginRisk(ctxReq, &data); err != nil {
+			tags[helper.TextResponse] = err.Error()
+			outputGT <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
 		// only for active mfa (multi factor authentication) & grant (password, fb, google) always get challange otp
 		// not applicable for isAdmin = true
 		if mfaRes, err := au.showMFAResponse(ctxReq, &data, claimsGT); mfaRes != nil {
@@ -79,6 +94,14 @@ func (au *AuthUseCaseImpl) GenerateToken(ctxReq context.Context, mode string, da
 			return
 		}
 
+		// a step up without any second factor to ask is refused
+		if data.RiskStepUp {
+			outputGT <- ResultUseCase{Error: errors.New(model.ErrorLoginRiskBlockedBahasa), HTTPStatus: http.StatusForbidden}
+			return
+		}
+
+		au.assignPermissions(ctxReq, &claimsGT)
+
 		// generate token based on claims
 		tokenResult := <-au.AccessTokenGenerator.GenerateAccessToken(claimsGT)
 		data.Token = tokenResult.AccessToken.AccessToken
@@ -155,7 +178,11 @@ func (au *AuthUseCaseImpl) GenerateTokenB2b(ctxReq context.Context, mode string,
 		claims.MemberType = data.MemberType
 
 		// process the authentication based on grant type
-		httpStatus, redisUserID, err = au.parsePasswordTypeMicrositeBela(ctxReq, &data, &claims)
+		if data.GrantType == model.AuthTypeSAML {
+			httpStatus, redisUserID, err = au.parseSAMLType(ctxReq, &data, &claims)
+		} else {
+			httpStatus, redisUserID, err = au.parsePasswordTypeMicrositeBela(ctxReq, &data, &claims)
+		}
 		if err != nil {
 			tags[helper.TextResponse] = err.Error()
 			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
@@ -173,6 +200,8 @@ func (au *AuthUseCaseImpl) GenerateTokenB2b(ctxReq context.Context, mode string,
 			return
 		}
 
+		au.assignPermissions(ctxReq, &claims)
+
 		// generate token based on claims
 		tokenResult := <-au.AccessTokenGenerator.GenerateAccessToken(claims)
 		data.Token = tokenResult.AccessToken.AccessToken
@@ -222,11 +251,41 @@ func (au *AuthUseCaseImpl) saveTokenToRedis(ctxReq context.Context, paramRedis *
 }
 
 func (au *AuthUseCaseImpl) showMFAResponse(ctxReq context.Context, data *model.RequestToken, claims token.Claim) (interface{}, error) {
-	var redisMFAKey string
-
-	if data.MFAEnabled {
+	var (
+		redisMFAKey string
+		methods     []string
+	)
+
+	// a device trusted after the second factor skips account mfa, narwhal mfa and a risk step up are always asked
+	trustedDevice := !data.RiskStepUp && au.isMFATrustedDevice(ctxReq, data)
+
+	// a registered passkey, email or phone number counts as second factor as well
+	var (
+		passkeyOptions *webauthn.RequestOptions
+		otpMethods     []memberModel.MFAMethod
+	)
+	if !trustedDevice {
+		passkeyOptions = au.passkeyMFAOptions(ctxReq, data, claims)
+		otpMethods = au.mfaOTPMethods(ctxReq, data)
+	}
+	accountMFA := data.MFAEnabled && !trustedDevice
+	if data.RiskStepUp && !accountMFA && passkeyOptions == nil && len(otpMethods) == 0 {
+		otpMethods = au.riskStepUpMethods(ctxReq, data)
+	}
+	destinations := map[string]string{}
+	if accountMFA || passkeyOptions != nil || len(otpMethods) > 0 {
 		// redis key format: `mfa-otp-USR123-ABC-WEB`
 		redisMFAKey = model.MFATokenKeyRedis
+		if accountMFA {
+			methods = append(methods, model.MFAMethodTOTP)
+		}
+		if passkeyOptions != nil {
+			methods = append(methods, model.MFAMethodPasskey)
+		}
+		for _, method := range otpMethods {
+			methods = append(methods, method.Method)
+			destinations[method.Method] = method.TargetMask
+		}
 	} else if data.GrantType == model.AuthTypeLDAP && data.NarwhalMFAEnabled {
 		// specific for ldap
 		redisMFAKey = model.NarwhalMFATokenKeyRedis
@@ -254,9 +313,21 @@ func (au *AuthUseCaseImpl) showMFAResponse(ctxReq context.Context, data *model.R
 	base64EncodeEmail := base64.URLEncoding.EncodeToString([]byte(data.UserID))
 	mfaTokenCombine := mfaToken + "-" + base64EncodeEmail
 
+	// the code of the default email or sms method is sent without waiting for the member to ask
+	defaultMethod := memberModel.DefaultMFAMethod(accountMFA, otpMethods)
+	if memberModel.IsMFAOTPMethod(defaultMethod) {
+		au.sendDefaultMFAOTP(ctxReq, data, defaultMethod, otpMethods)
+	}
+
 	mfaResponse := model.MFAResponse{}
 	mfaResponse.MFARequired = true
 	mfaResponse.MFAToken = mfaTokenCombine
+	mfaResponse.Methods = methods
+	mfaResponse.PasskeyOptions = passkeyOptions
+	mfaResponse.DefaultMethod = defaultMethod
+	if len(destinations) > 0 {
+		mfaResponse.Destinations = destinations
+	}
 
 	return mfaResponse, errors.New(memberModel.ErrorMFARequired)
 
@@ -313,6 +384,17 @@ func (au *AuthUseCaseImpl) saveSessionInfo(data model.RequestToken, JTI string)
 	}
 	return nil
 }
+
+// touchSessionInfo function for moving the last used time of the login refreshing its token,
+// the refresh goes on when it can not be saved
+func (au *AuthUseCaseImpl) touchSessionInfo(ctxReq context.Context, claims *token.BearerClaims) {
+	ctx := "AuthUseCase-touchSessionInfo"
+	params := sessionInfoModel.SessionInfoRequest{UserID: claims.Subject, DeviceID: claims.DeviceID, DeviceLogin: claims.DeviceLogin}
+	if updateResult := <-au.SessionInfoRepo.UpdateLastUsed(&params); updateResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "update_last_used", updateResult.Error, params)
+	}
+}
+
 func (au *AuthUseCaseImpl) parsePasswordType(ctxReq context.Context, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
 	if data.MemberType == model.UserTypeCorporate {
 		memberData, httpStatus, err := au.RequestTokenPasswordTypeCorporate(ctxReq, data)
@@ -749,15 +831,22 @@ func (au *AuthUseCaseImpl) parseGoogleOAuthType(ctxReq context.Context, mode str
 
 func (au *AuthUseCaseImpl) parseRefreshTokenType(ctxReq context.Context, mode string, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
 	ctx := "AuthUseCase-GenerateToken-parseRefreshTokenType"
-	pubKey, err := rsa.InitPublicKey()
-	if err != nil {
-		helper.SendErrorLog(ctxReq, ctx, scopeGetPublicKey, err, data)
-		return http.StatusInternalServerError, "", err
+	if err := au.detectRefreshTokenReuse(ctxReq, data); err != nil {
+		return http.StatusUnauthorized, "", err
 	}
 
-	oldClaims, err := token.VerifyTokenIgnoreExpiration(pubKey, data.Token)
-	if err != nil {
-		return http.StatusUnauthorized, "", err
+	var oldClaims *token.BearerClaims
+	if data.Token == "" {
+		// refresh token presented without the previous access token, e.g. oauth2 refresh_token grant
+		oldClaims, err = au.findRefreshTokenOwner(ctxReq, data)
+		if err != nil {
+			return http.StatusUnauthorized, "", err
+		}
+	} else {
+		oldClaims, err = token.VerifyTokenIgnoreExpiration(au.KeySet, data.Token)
+		if err != nil {
+			return http.StatusUnauthorized, "", err
+		}
 	}
 
 	RTKey := getRefreshTokenKey(oldClaims.Subject, oldClaims.DeviceID, oldClaims.DeviceLogin)
@@ -781,14 +870,24 @@ func (au *AuthUseCaseImpl) parseRefreshTokenType(ctxReq context.Context, mode st
 		return http.StatusUnauthorized, "", err
 	}
 
+	if httpStatus, err := au.rotateRefreshToken(ctxReq, data); err != nil {
+		return httpStatus, "", err
+	}
+	au.touchSessionInfo(ctxReq, oldClaims)
+
 	claims.DeviceID = oldClaims.DeviceID
 	claims.DeviceLogin = oldClaims.DeviceLogin
 	claims.Subject = oldClaims.Subject
 	claims.Authorised = true
 	claims.IsAdmin = oldClaims.IsAdmin
 	claims.Email = oldClaims.Email
+	if claims.Email == "" {
+		claims.Email = data.Email
+	}
 	claims.MemberType = oldClaims.MemberType
+	claims.Scope = oldClaims.Scope
 	data.MemberType = oldClaims.MemberType
+	data.Scope = oldClaims.Scope
 
 	redisUserID = oldClaims.Subject
 	return http.StatusOK, redisUserID, nil
@@ -943,37 +1042,9 @@ func (au *AuthUseCaseImpl) parseLDAPType(ctxReq context.Context, mode string, da
 }
 
 func (au *AuthUseCaseImpl) parseVerifyMFAType(ctxReq context.Context, mode string, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
-	ctx := "AuthUseCase-GenerateToken-parseVerifyMFAType"
-	memberID, newToken, err := au.parseTokenMFA(data.MFAToken)
+	memberID, httpStatus, err := au.loadMFASession(ctxReq, data)
 	if err != nil {
-		helper.SendErrorLog(ctxReq, ctx, "parse_mfa_token", err, data)
-		return http.StatusBadRequest, "", err
-	}
-	var redisOtpKey string
-	if data.GrantType == model.AuthTypeVerifyMFANarwhal {
-		redisOtpKey = model.NarwhalMFATokenKeyRedis
-	} else {
-		redisOtpKey = model.MFATokenKeyRedis
-	}
-
-	redisLoginKey := strings.Join([]string{redisOtpKey, memberID, data.DeviceID, claims.DeviceLogin}, "-") //mfa-otp-USR123-ABC-WEB
-	getTokenRedis := <-au.LoginSessionRepo.Load(ctxReq, redisLoginKey)
-	if getTokenRedis.Error != nil {
-		if getTokenRedis.Error.Error() != helper.ErrorRedis {
-			helper.SendErrorLog(ctxReq, ctx, "mfa_load_redis", getTokenRedis.Error, data)
-		}
-
-		return http.StatusBadRequest, "", errors.New(memberModel.ErrorTokenMFA)
-	}
-
-	tokenRedis := ""
-	if existingToken, ok := getTokenRedis.Result.(model.LoginSessionRedis); ok {
-		tokenRedis = existingToken.Token
-	}
-
-	if tokenRedis != newToken {
-		err := fmt.Errorf(helper.ErrorParameterInvalid, "existing token")
-		return http.StatusUnauthorized, "", err
+		return httpStatus, "", err
 	}
 
 	memberResult := <-au.MemberQueryRead.FindByID(ctxReq, memberID)
@@ -985,17 +1056,47 @@ func (au *AuthUseCaseImpl) parseVerifyMFAType(ctxReq context.Context, mode strin
 	if !ok {
 		return http.StatusInternalServerError, "", errors.New(msgResultNotMember)
 	}
-	var mfaKeyDB string
-	if data.GrantType == model.AuthTypeVerifyMFANarwhal {
-		mfaKeyDB = memberData.MFAAdminKey
+	if data.GrantType == model.AuthTypeVerifyMFA && data.Passkey != nil {
+		if httpStatus, err := au.verifyPasskeyMFA(ctxReq, memberID, data, claims); err != nil {
+			return httpStatus, "", err
+		}
+	} else if data.GrantType == model.AuthTypeVerifyMFA && memberModel.IsMFAOTPMethod(data.MFAMethod) {
+		// the code was only sent to an enrolled target of this member
+		if err := au.MFAOTP.Verify(ctxReq, data.MFAMethod, memberID, getMFAOTPScope(data), data.OTP); err != nil {
+			return mfaotp.HTTPStatus(err), "", err
+		}
 	} else {
-		mfaKeyDB = memberData.MFAKey
+		var (
+			mfaKeyDB string
+			enrolled bool
+		)
+		if data.GrantType == model.AuthTypeVerifyMFANarwhal {
+			mfaKeyDB, enrolled = memberData.MFAAdminKey, memberData.AdminMFAEnabled
+		} else {
+			mfaKeyDB, enrolled = memberData.MFAKey, memberData.MFAEnabled
+		}
+
+		// mfa is also asked from members with a passkey, an email or phone method or a risk step up only,
+		// without an authenticator app anyone can compute the code of the empty key
+		if !enrolled || mfaKeyDB == "" {
+			return http.StatusBadRequest, "", errors.New(memberModel.ErrorMFAMethod)
+		}
+
+		if data.GrantType == model.AuthTypeVerifyMFA && memberModel.IsMFARecoveryCode(data.OTP) {
+			if httpStatus, err := au.useMFARecoveryCode(ctxReq, memberID, data.OTP); err != nil {
+				return httpStatus, "", err
+			}
+		} else {
+			mfaKey, _ := base64.URLEncoding.DecodeString(mfaKeyDB)
+			validateOTP := <-au.verifyMFACode(ctxReq, string(mfaKey), data.OTP)
+			if validateOTP.Error != nil {
+				return validateOTP.HTTPStatus, "", validateOTP.Error
+			}
+		}
 	}
 
-	mfaKey, _ := base64.URLEncoding.DecodeString(mfaKeyDB)
-	validateOTP := <-au.verifyMFACode(ctxReq, string(mfaKey), data.OTP)
-	if validateOTP.Error != nil {
-		return validateOTP.HTTPStatus, "", validateOTP.Error
+	if data.GrantType == model.AuthTypeVerifyMFA {
+		au.issueMFATrustedDevice(ctxReq, memberID, data)
 	}
 
 	claims.Subject = memberData.ID
@@ -1034,6 +1135,17 @@ func (au *AuthUseCaseImpl) generateRefreshToken(ctxReq context.Context, redisLog
 
 		newRTokenKey := getRefreshTokenKey(claims.Subject, claims.DeviceID, claims.DeviceLogin)
 		rToken := model.NewRefreshToken(newRTokenKey, refreshToken, rtAge)
+		rToken.Owner = &model.RefreshTokenOwner{
+			Subject:     claims.Subject,
+			DeviceID:    claims.DeviceID,
+			DeviceLogin: claims.DeviceLogin,
+			MemberType:  claims.MemberType,
+			IsAdmin:     claims.IsAdmin,
+			Audience:    claims.Audience,
+			Scope:       claims.Scope,
+			FamilyID:    data.RefreshTokenFamily,
+			Generation:  data.RefreshTokenGeneration,
+		}
 
 		refreshTokenResult := <-au.RefreshTokenRepo.Save(ctxReq, rToken)
 		if refreshTokenResult.Error != nil {
@@ -1050,6 +1162,12 @@ func (au *AuthUseCaseImpl) generateRefreshToken(ctxReq context.Context, redisLog
 }
 
 func (au *AuthUseCaseImpl) parseGlobalData(ctxReq context.Context, mode string, data *model.RequestToken, claims *token.Claim) (httpStatus int, redisUserID string, err error) {
+	// social login of a provider configured as oidc connector
+	if au.isConnectorGrant(data.GrantType) {
+		data.Provider = data.GrantType
+		return au.parseConnectorType(ctxReq, data, claims)
+	}
+
 	switch data.GrantType {
 	case model.AuthTypeAnonymous:
 		claims.Authorised = false
@@ -1082,6 +1200,18 @@ func (au *AuthUseCaseImpl) parseGlobalData(ctxReq context.Context, mode string,
 	case model.AuthTypeVerifyMFA, model.AuthTypeVerifyMFANarwhal:
 		return au.parseVerifyMFAType(ctxReq, mode, data, claims)
 
+	case model.AuthTypeAuthorizationCode:
+		return au.parseAuthorizationCodeType(ctxReq, data, claims)
+
+	case model.AuthTypePasskey:
+		return au.parsePasskeyType(ctxReq, data, claims)
+
+	case model.AuthTypePasswordless:
+		return au.parsePasswordlessType(ctxReq, data, claims)
+
+	case model.AuthTypeOIDC:
+		return au.parseConnectorType(ctxReq, data, claims)
+
 	default:
 		return httpStatus, "", errors.New("invalid grant type")
 	}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mocksRepo "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksMemberQuery "github.com/Bhinneka/user-service/mocks/src/member/v1/query"
+	mocksSessionRepo "github.com/Bhinneka/user-service/mocks/src/session/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/auth/v1/token"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
+	sessionModel "github.com/Bhinneka/user-service/src/session/v1/model"
+	sessionRepo "github.com/Bhinneka/user-service/src/session/v1/repo"
+	"github.com/dgryski/dgoogauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUseCaseImpl_parseVerifyMFAType(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+	totp := func(secret string) string {
+		return fmt.Sprintf("%06d", dgoogauth.ComputeCode(secret, time.Now().Unix()/30))
+	}
+	sessionKey := "mfa-otp-USR123-ASX1234-WEB"
+
+	tests := []struct {
+		name        string
+		member      memberModel.Member
+		otp         string
+		wantStatus  int
+		wantErr     bool
+		wantTrusted bool
+	}{
+		{
+			name:        "Case 1: Authenticator app code",
+			member:      memberModel.Member{ID: "USR123", MFAEnabled: true, MFAKey: base64.URLEncoding.EncodeToString([]byte(secret))},
+			otp:         totp(secret),
+			wantStatus:  http.StatusOK,
+			wantTrusted: true,
+		},
+		{
+			name:       "Case 2: Passkey only member with the code of an empty key",
+			member:     memberModel.Member{ID: "USR123"},
+			otp:        totp(""),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "Case 3: Authenticator app turned off with its key left",
+			member:     memberModel.Member{ID: "USR123", MFAKey: base64.URLEncoding.EncodeToString([]byte(secret))},
+			otp:        totp(secret),
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queryRead := new(mocksMemberQuery.MemberQuery)
+			queryRead.On("FindByID", mock.Anything, "USR123").Return(generateMemberQueryResult(memberQuery.ResultQuery{Result: tt.member}))
+			sessionRepo := new(mocksRepo.LoginSessionRepository)
+			sessionRepo.On("Load", mock.Anything, sessionKey).Return(generateLockoutResult(repo.ResultRepository{Result: model.LoginSessionRedis{Key: sessionKey, Token: "MFATOKEN"}}))
+			sessionRepo.On("Save", mock.Anything, mock.Anything).Return(generateLockoutResult(repo.ResultRepository{}))
+
+			au := &AuthUseCaseImpl{MemberQueryRead: queryRead, LoginSessionRepo: sessionRepo}
+			data := &model.RequestToken{
+				GrantType:   model.AuthTypeVerifyMFA,
+				MFAToken:    "MFATOKEN-" + base64.URLEncoding.EncodeToString([]byte("USR123")),
+				OTP:         tt.otp,
+				DeviceID:    "ASX1234",
+				DeviceLogin: "WEB",
+				TrustDevice: true,
+			}
+			claims := token.Claim{}
+			httpStatus, _, err := au.parseVerifyMFAType(context.Background(), "", data, &claims)
+			assert.Equal(t, tt.wantStatus, httpStatus)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if tt.wantTrusted {
+				sessionRepo.AssertCalled(t, "Save", mock.Anything, mock.Anything)
+				assert.Equal(t, "USR123", claims.Subject)
+				return
+			}
+			sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			assert.False(t, claims.Authorised)
+		})
+	}
+}
+
+func TestAuthUseCaseImpl_touchSessionInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		updateResult sessionRepo.ResultRepository
+	}{
+		{
+			name: "Case 1: Last used time of the login is moved",
+		},
+		{
+			name:         "Case 2: Error database does not stop the refresh",
+			updateResult: sessionRepo.ResultRepository{Error: errors.New("connection refused")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionInfoRepo := new(mocksSessionRepo.SessionInfoRepository)
+			sessionInfoRepo.On("UpdateLastUsed", mock.Anything).Return(func(*sessionModel.SessionInfoRequest) <-chan sessionRepo.ResultRepository {
+				output := make(chan sessionRepo.ResultRepository, 1)
+				output <- tt.updateResult
+				close(output)
+				return output
+			})
+
+			au := &AuthUseCaseImpl{SessionInfoRepo: sessionInfoRepo}
+			claims := &token.BearerClaims{DeviceID: "ASX1234", DeviceLogin: "WEB"}
+			claims.Subject = "USR123"
+			au.touchSessionInfo(context.Background(), claims)
+
+			sessionInfoRepo.AssertCalled(t, "UpdateLastUsed", mock.MatchedBy(func(params *sessionModel.SessionInfoRequest) bool {
+				return params.UserID == "USR123" && params.DeviceID == "ASX1234" && params.DeviceLogin == "WEB"
+			}))
+		})
+	}
+}
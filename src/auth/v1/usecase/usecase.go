@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/connector"
 )
 
 // ResultUseCase data structure
@@ -29,4 +31,22 @@ type AuthUseCase interface {
 	SendEmailWelcomeMember(ctxReq context.Context, data model.AccessTokenResponse) <-chan ResultUseCase
 	ValidateBasicAuth(ctxReq context.Context, clientID, clientSecret string) <-chan ResultUseCase
 	GetJTIToken(ctxReq context.Context, token, request string) (string, interface{}, error)
+	GetOpenIDConfiguration() model.OpenIDConfiguration
+	GetJSONWebKeySet() rsa.JSONWebKeySet
+	Authorize(ctxReq context.Context, data model.AuthorizeRequest) <-chan ResultUseCase
+	ExchangeToken(ctxReq context.Context, data model.OIDCTokenRequest) <-chan ResultUseCase
+	GetUserInfo(ctxReq context.Context, accessToken string) <-chan ResultUseCase
+	IntrospectToken(ctxReq context.Context, data model.TokenHintRequest) <-chan ResultUseCase
+	RevokeToken(ctxReq context.Context, data model.TokenHintRequest) <-chan ResultUseCase
+	GeneratePasskeyLoginOptions(ctxReq context.Context) <-chan ResultUseCase
+	SendMFAOTP(ctxReq context.Context, data model.RequestToken) <-chan ResultUseCase
+	RequestPasswordless(ctxReq context.Context, data model.PasswordlessRequest) <-chan ResultUseCase
+	GetLockouts(ctxReq context.Context) <-chan ResultUseCase
+	GetLockout(ctxReq context.Context, email string) <-chan ResultUseCase
+	ClearLockout(ctxReq context.Context, email, adminID string) <-chan ResultUseCase
+	GetConnectors() []connector.Info
+	AuthorizeConnector(ctxReq context.Context, provider, redirectURI string) <-chan ResultUseCase
+	AuthorizeSAML(ctxReq context.Context, accountID string, data model.SAMLAuthorizeRequest) <-chan ResultUseCase
+	LoginSAML(ctxReq context.Context, accountID string, data model.SAMLLoginRequest) <-chan ResultUseCase
+	GetSAMLMetadata(ctxReq context.Context, accountID string) <-chan ResultUseCase
 }
@@ -44,6 +44,38 @@ func (au *AuthUseCaseImpl) validateInput(data *model.RequestToken) error {
 		return err
 	}
 
+	// validate `passkey` assertion when `grantType` is passkey
+	if data.GrantType == model.AuthTypePasskey && data.Passkey == nil {
+		err := fmt.Errorf(helper.ErrorParameterRequired, "passkey")
+		return err
+	}
+
+	// validate link token in `code`, or `email` and `otp`, when `grantType` is passwordless
+	if data.GrantType == model.AuthTypePasswordless && data.Code == "" {
+		for _, param := range []struct{ name, value string }{
+			{"email", data.Email},
+			{"otp", data.OTP},
+		} {
+			if param.value == "" {
+				return fmt.Errorf(helper.ErrorParameterRequired, param.name)
+			}
+		}
+	}
+
+	// validate connector, `code`, `state` and redirect uri when `grantType` is oidc
+	if data.GrantType == model.AuthTypeOIDC {
+		for _, param := range []struct{ name, value string }{
+			{"provider", data.Provider},
+			{"code", data.Code},
+			{"state", data.State},
+			{"redirectUri", data.RedirectURI},
+		} {
+			if param.value == "" {
+				return fmt.Errorf(helper.ErrorParameterRequired, param.name)
+			}
+		}
+	}
+
 	// validate redirect uri for azure
 	if data.GrantType == model.AuthTypeAzure && len(data.RedirectURI) == 0 {
 		err := fmt.Errorf("%s is required", "redirect uri")
@@ -93,3 +125,29 @@ func (au *AuthUseCaseImpl) ValidateBasicAuth(ctxReq context.Context, clientID, c
 
 	return output
 }
+
+// validateAuthorizeRequest validates /authorize parameters after the client and redirect uri are trusted
+func validateAuthorizeRequest(data *model.AuthorizeRequest) *model.OAuthError {
+	if data.ResponseType != model.ResponseTypeCode {
+		return model.NewOAuthError(model.OAuthErrorUnsupportedResponseType, "only response_type code is supported", http.StatusBadRequest)
+	}
+
+	// PKCE is mandatory for every client
+	if data.CodeChallenge == "" {
+		return model.NewOAuthError(model.OAuthErrorInvalidRequest, "code_challenge is required", http.StatusBadRequest)
+	}
+	if data.CodeChallengeMethod != model.CodeChallengeMethodS256 {
+		return model.NewOAuthError(model.OAuthErrorInvalidRequest, "code_challenge_method must be S256", http.StatusBadRequest)
+	}
+
+	if data.Scope == "" {
+		data.Scope = model.ScopeOpenID
+	}
+	scope := model.FilterScope(data.Scope, model.SupportedScopes)
+	if scope == "" {
+		return model.NewOAuthError(model.OAuthErrorInvalidScope, "", http.StatusBadRequest)
+	}
+	data.Scope = scope
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	merchantRepoRead "github.com/Bhinneka/user-service/src/merchant/v2/repo"
 	"github.com/Bhinneka/user-service/src/service"
 	sessionInfoRepo "github.com/Bhinneka/user-service/src/session/v1/repo"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 )
 
 func TestAuthUseCaseImpl_validateInput(t *testing.T) {
@@ -142,6 +143,29 @@ func TestAuthUseCaseImpl_validateInput(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Case 8: Error empty passkey",
+			args: args{
+				data: &model.RequestToken{
+					GrantType:   model.AuthTypePasskey,
+					DeviceID:    "BelaID",
+					DeviceLogin: "WEB",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Case 9: Success passkey",
+			args: args{
+				data: &model.RequestToken{
+					GrantType:   model.AuthTypePasskey,
+					DeviceID:    "BelaID",
+					DeviceLogin: "WEB",
+					Passkey:     &webauthn.AssertionResponse{ID: "AQID"},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,3 +207,61 @@ func TestAuthUseCaseImpl_validateInput(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateAuthorizeRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      model.AuthorizeRequest
+		wantCode  string
+		wantScope string
+	}{
+		{
+			name:     "Case 1: Error unsupported response type",
+			data:     model.AuthorizeRequest{ResponseType: "token"},
+			wantCode: model.OAuthErrorUnsupportedResponseType,
+		},
+		{
+			name:     "Case 2: Error missing code challenge",
+			data:     model.AuthorizeRequest{ResponseType: model.ResponseTypeCode},
+			wantCode: model.OAuthErrorInvalidRequest,
+		},
+		{
+			name:     "Case 3: Error plain code challenge method",
+			data:     model.AuthorizeRequest{ResponseType: model.ResponseTypeCode, CodeChallenge: "abc", CodeChallengeMethod: "plain"},
+			wantCode: model.OAuthErrorInvalidRequest,
+		},
+		{
+			name:     "Case 4: Error unknown scope",
+			data:     model.AuthorizeRequest{ResponseType: model.ResponseTypeCode, CodeChallenge: "abc", CodeChallengeMethod: model.CodeChallengeMethodS256, Scope: "admin"},
+			wantCode: model.OAuthErrorInvalidScope,
+		},
+		{
+			name:      "Case 5: Success default scope",
+			data:      model.AuthorizeRequest{ResponseType: model.ResponseTypeCode, CodeChallenge: "abc", CodeChallengeMethod: model.CodeChallengeMethodS256},
+			wantScope: model.ScopeOpenID,
+		},
+		{
+			name:      "Case 6: Success unknown scope dropped",
+			data:      model.AuthorizeRequest{ResponseType: model.ResponseTypeCode, CodeChallenge: "abc", CodeChallengeMethod: model.CodeChallengeMethodS256, Scope: "openid admin email"},
+			wantScope: "openid email",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAuthorizeRequest(&tt.data)
+			if tt.wantCode != "" {
+				if err == nil || err.Code != tt.wantCode {
+					t.Errorf("validateAuthorizeRequest() error = %v, wantCode %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("validateAuthorizeRequest() unexpected error = %v", err)
+				return
+			}
+			if tt.data.Scope != tt.wantScope {
+				t.Errorf("validateAuthorizeRequest() scope = %v, want %v", tt.data.Scope, tt.wantScope)
+			}
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/Bhinneka/golib/tracer"
-	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	"github.com/Bhinneka/user-service/src/auth/v1/token"
@@ -145,13 +144,8 @@ func (au *AuthUseCaseImpl) GenerateResponseVerify(ctxReq context.Context, claims
 	t := time.Unix(int64(responseVerify.Exp), 0)
 	responseVerify.ExpiredTime = t
 
-	pubKey, err := rsa.InitPublicKey()
-	if err != nil {
-		tracer.Log(ctxReq, ctx, err)
-	}
-
 	// validate old token
-	oldClaims, _ := token.VerifyTokenIgnoreExpiration(pubKey, tokenStr)
+	oldClaims, _ := token.VerifyTokenIgnoreExpiration(au.KeySet, tokenStr)
 	RTKey := getRefreshTokenKey(oldClaims.Subject, oldClaims.DeviceID, oldClaims.DeviceLogin)
 	refreshResult := <-au.RefreshTokenRepo.Load(ctxReq, RTKey)
 	rt, _ := refreshResult.Result.(model.RefreshToken)
@@ -224,13 +218,8 @@ func (au *AuthUseCaseImpl) GenerateResponseVerifyByID(ctxReq context.Context, cl
 	t := time.Unix(int64(responseVerify.Exp), 0)
 	responseVerify.ExpiredTime = t
 
-	pubKey, err := rsa.InitPublicKey()
-	if err != nil {
-		tracer.Log(ctxReq, ctx, err)
-	}
-
 	// validate old token
-	oldClaims, err := token.VerifyTokenIgnoreExpiration(pubKey, tokenStr)
+	oldClaims, err := token.VerifyTokenIgnoreExpiration(au.KeySet, tokenStr)
 	if err == nil {
 		RTKey := getRefreshTokenKey(oldClaims.Subject, oldClaims.DeviceID, oldClaims.DeviceLogin)
 		refreshResult := <-au.RefreshTokenRepo.Load(ctxReq, RTKey)
@@ -50,6 +50,15 @@ func (h *HTTPAuthHandler) Mount(group *echo.Group) {
 	group.POST("/logout", h.Logout)
 	group.POST("/check-email", h.CheckEmail)
 	group.POST("/verify-captcha", h.VerifyCaptcha)
+	group.POST("/passkey/options", h.GeneratePasskeyLoginOptions)
+	group.POST("/mfa/otp", h.SendMFAOTP)
+	group.POST("/passwordless", h.RequestPasswordless)
+	group.GET("/connectors", h.GetConnectors)
+	group.POST("/connectors/:provider/authorize", h.AuthorizeConnector)
+	group.POST("/saml/token", h.ExchangeSAMLTicket)
+	group.POST("/saml/:accountID/authorize", h.AuthorizeSAML)
+	group.POST("/saml/:accountID/acs", h.LoginSAML)
+	group.GET("/saml/:accountID/metadata", h.GetSAMLMetadata)
 	group.POST("/client-app", h.CreateClientApp)
 	group.GET("/oauth2callback", h.AuthCallback)
 }
@@ -59,6 +68,13 @@ func (h *HTTPAuthHandler) MountAdmin(group *echo.Group) {
 	group.GET("/auth", h.GetAccessTokenFromUserID)
 }
 
+// MountLockout routes for viewing and clearing failed login lockouts
+func (h *HTTPAuthHandler) MountLockout(group *echo.Group) {
+	group.GET("", h.GetLockouts)
+	group.GET("/:email", h.GetLockout)
+	group.DELETE("/:email", h.ClearLockout)
+}
+
 // AuthCallback from google
 func (h *HTTPAuthHandler) AuthCallback(c echo.Context) error {
 	data := model.RequestToken{
@@ -185,7 +201,7 @@ func (h *HTTPAuthHandler) GetAccessToken(c echo.Context) error {
 	// requestFrom flag for sending email welcome if request from sturgeon CF
 	requestFrom := payload.RequestFrom
 
-	tokenResult := <-h.AuthUseCase.GenerateToken(context.WithValue(c.Request().Context(), middleware.ContextKeyClientIP, c.RealIP()), mode, data)
+	tokenResult := <-h.AuthUseCase.GenerateToken(context.WithValue(c.Request().Context(), middleware.ContextKeyClientIP, middleware.ClientIP(c)), mode, data)
 	if tokenResult.Error != nil {
 		if tokenResult.HTTPStatus == http.StatusForbidden {
 			token, ok := tokenResult.Result.(model.MFAResponse)
@@ -232,6 +248,7 @@ func (h *HTTPAuthHandler) GetAccessToken(c echo.Context) error {
 		JobTitle:     reqToken.JobTitle,
 		Mobile:       reqToken.Mobile,
 	}
+	res.TrustedDeviceToken = reqToken.IssuedTrustedDeviceToken
 
 	h.sendWelcomeEmail(c.Request().Context(), requestFrom, ctx, res)
 
@@ -264,7 +281,7 @@ func (h *HTTPAuthHandler) ValidateData(c echo.Context, data model.RequestToken,
 	data.Audience = clientID
 	data.DeviceID = strings.Trim(data.DeviceID, " ")
 	data.Email = strings.ToLower(email)
-	data.IP = c.RealIP()
+	data.IP = middleware.ClientIP(c)
 	data.UserAgent = c.Request().UserAgent()
 
 	// for refreshing token needs old token and old refresh token
@@ -419,7 +436,7 @@ func (h *HTTPAuthHandler) VerifyCaptcha(c echo.Context) error {
 		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
 	}
 
-	payload.RemoteIP = c.RealIP()
+	payload.RemoteIP = middleware.ClientIP(c)
 
 	res := <-h.AuthUseCase.VerifyCaptcha(c.Request().Context(), payload)
 	if res.Error != nil {
@@ -428,3 +445,243 @@ func (h *HTTPAuthHandler) VerifyCaptcha(c echo.Context) error {
 
 	return shared.NewHTTPResponse(http.StatusOK, "success verify captcha", res.Result).JSON(c)
 }
+
+// GeneratePasskeyLoginOptions function for getting webauthn request options of passwordless login
+func (h *HTTPAuthHandler) GeneratePasskeyLoginOptions(c echo.Context) error {
+	// parse client id and secret
+	_, _, ok := c.Request().BasicAuth()
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, errBasicAuth).JSON(c)
+	}
+
+	res := <-h.AuthUseCase.GeneratePasskeyLoginOptions(c.Request().Context())
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Passkey Login Options", res.Result).JSON(c)
+}
+
+// SendMFAOTP function for sending the one time code of email or sms second factor while the login waits for it
+func (h *HTTPAuthHandler) SendMFAOTP(c echo.Context) error {
+	// parse client id and secret
+	_, _, ok := c.Request().BasicAuth()
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, errBasicAuth).JSON(c)
+	}
+
+	payload := model.RequestToken{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	res := <-h.AuthUseCase.SendMFAOTP(c.Request().Context(), payload)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, memberModel.SuccessMFAOTPSend, res.Result).JSON(c)
+}
+
+// RequestPasswordless function for sending a login link or code to the email of a member
+func (h *HTTPAuthHandler) RequestPasswordless(c echo.Context) error {
+	// parse client id and secret
+	_, _, ok := c.Request().BasicAuth()
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, errBasicAuth).JSON(c)
+	}
+
+	payload := model.PasswordlessRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	res := <-h.AuthUseCase.RequestPasswordless(c.Request().Context(), payload)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, model.SuccessPasswordlessSend, res.Result).JSON(c)
+}
+
+// GetConnectors function for listing identity providers added as oidc connectors
+func (h *HTTPAuthHandler) GetConnectors(c echo.Context) error {
+	return shared.NewHTTPResponse(http.StatusOK, "Connectors Response", h.AuthUseCase.GetConnectors()).JSON(c)
+}
+
+// AuthorizeConnector function for getting the authorization url and state of a login with an oidc connector
+func (h *HTTPAuthHandler) AuthorizeConnector(c echo.Context) error {
+	// parse client id and secret
+	_, _, ok := c.Request().BasicAuth()
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, errBasicAuth).JSON(c)
+	}
+
+	payload := model.ConnectorAuthorizeRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	res := <-h.AuthUseCase.AuthorizeConnector(c.Request().Context(), c.Param("provider"), payload.RedirectURI)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Connector Authorization", res.Result).JSON(c)
+}
+
+// GetLockouts function for listing emails with failed logins or a lock
+func (h *HTTPAuthHandler) GetLockouts(c echo.Context) error {
+	res := <-h.AuthUseCase.GetLockouts(c.Request().Context())
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Lockouts Response", res.Result).JSON(c)
+}
+
+// GetLockout function for getting failed logins and lock state of an email
+func (h *HTTPAuthHandler) GetLockout(c echo.Context) error {
+	res := <-h.AuthUseCase.GetLockout(c.Request().Context(), c.Param("email"))
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Lockout Response", res.Result).JSON(c)
+}
+
+// ClearLockout function for unlocking an email before its lock expires
+func (h *HTTPAuthHandler) ClearLockout(c echo.Context) error {
+	adminID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	res := <-h.AuthUseCase.ClearLockout(c.Request().Context(), c.Param("email"), adminID)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success clear lockout", res.Result).JSON(c)
+}
+
+// AuthorizeSAML function for getting the identity provider url and relay state of a corporate account single sign on
+func (h *HTTPAuthHandler) AuthorizeSAML(c echo.Context) error {
+	// parse client id and secret
+	clientID, clientSecret, ok := c.Request().BasicAuth()
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, errBasicAuth).JSON(c)
+	}
+
+	payload := model.SAMLAuthorizeRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+	payload.ClientID = clientID
+	payload.ClientSecret = clientSecret
+
+	res := <-h.AuthUseCase.AuthorizeSAML(c.Request().Context(), c.Param("accountID"), payload)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "SAML Authorization", res.Result).JSON(c)
+}
+
+// LoginSAML function for the assertion consumer service, the identity provider posts the response here
+// and the user agent is redirected back to the client with a ticket or an error
+func (h *HTTPAuthHandler) LoginSAML(c echo.Context) error {
+	payload := model.SAMLLoginRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	res := <-h.AuthUseCase.LoginSAML(c.Request().Context(), c.Param("accountID"), payload)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	location, ok := res.Result.(string)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusInternalServerError, model.ErrorSAMLRelayState).JSON(c)
+	}
+
+	return c.Redirect(http.StatusSeeOther, location)
+}
+
+// GetSAMLMetadata function for getting the service provider metadata of a corporate account
+func (h *HTTPAuthHandler) GetSAMLMetadata(c echo.Context) error {
+	res := <-h.AuthUseCase.GetSAMLMetadata(c.Request().Context(), c.Param("accountID"))
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	metadata, ok := res.Result.([]byte)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusInternalServerError, model.ErrorSAMLDisabled).JSON(c)
+	}
+
+	return c.Blob(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+// ExchangeSAMLTicket function for getting the access token of a corporate contact with the ticket of a saml login
+func (h *HTTPAuthHandler) ExchangeSAMLTicket(c echo.Context) error {
+	ctx := "AuthPresenter-ExchangeSAMLTicket"
+
+	// parse client id and secret
+	clientID, clientSecret, ok := c.Request().BasicAuth()
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, errBasicAuth).JSON(c)
+	}
+
+	payload := model.RequestToken{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+	payload.GrantType = model.AuthTypeSAML
+	payload.MemberType = model.UserTypeCorporate
+
+	data, errMsg, statusCode := h.ValidateData(c, payload, ctx, clientID, clientSecret)
+	if errMsg != "" {
+		return shared.NewHTTPResponse(statusCode, errMsg).JSON(c)
+	}
+
+	tokenResult := <-h.AuthUseCase.GenerateTokenB2b(c.Request().Context(), payload.Mode, data)
+	if tokenResult.Error != nil {
+		if tokenResult.HTTPStatus == http.StatusForbidden {
+			token, ok := tokenResult.Result.(model.MFAResponse)
+			if !ok {
+				err := errors.New(msgErrorResultToken)
+				return shared.NewHTTPResponse(http.StatusInternalServerError, err.Error()).JSON(c)
+			}
+			return shared.NewHTTPResponse(http.StatusForbidden, tokenResult.Error.Error(), token).JSON(c)
+		}
+
+		return shared.NewHTTPResponse(tokenResult.HTTPStatus, tokenResult.Error.Error()).JSON(c)
+	}
+
+	token, ok := tokenResult.Result.(model.RequestToken)
+	if !ok {
+		err := errors.New(msgErrorResultToken)
+		return shared.NewHTTPResponse(http.StatusInternalServerError, err.Error()).JSON(c)
+	}
+
+	res := model.AccessTokenResponse{
+		ID:           golib.RandomString(8),
+		UserID:       token.UserID,
+		Email:        strings.ToLower(token.Email),
+		FirstName:    token.FirstName,
+		LastName:     token.LastName,
+		FullName:     token.FullName,
+		NewMember:    token.NewMember,
+		HasPassword:  token.HasPassword,
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+		ExpiredTime:  token.ExpiredAt.Format(time.RFC3339),
+		MemberType:   token.MemberType,
+		JobTitle:     token.JobTitle,
+		Mobile:       token.Mobile,
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Auth Response", res).JSON(c)
+}
@@ -0,0 +1,236 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Bhinneka/user-service/middleware"
+	"github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/auth/v1/usecase"
+	"github.com/labstack/echo"
+)
+
+// HTTPOIDCHandler model, OAuth 2.0 / OpenID Connect provider endpoints
+type HTTPOIDCHandler struct {
+	AuthUseCase usecase.AuthUseCase
+	LoginURL    string
+}
+
+// NewHTTPOIDCHandler function for initialise *HTTPOIDCHandler
+func NewHTTPOIDCHandler(authUseCase usecase.AuthUseCase, loginURL string) *HTTPOIDCHandler {
+	return &HTTPOIDCHandler{AuthUseCase: authUseCase, LoginURL: loginURL}
+}
+
+// Mount function for mounting routes
+func (h *HTTPOIDCHandler) Mount(group *echo.Group) {
+	group.GET("/.well-known/openid-configuration", h.OpenIDConfiguration)
+	group.GET(model.JWKSPath, h.JSONWebKeySet)
+	group.GET("/authorize", h.Authorize)
+	group.POST("/authorize", h.Authorize)
+	group.POST("/token", h.Token)
+	group.GET("/userinfo", h.UserInfo)
+	group.POST("/userinfo", h.UserInfo)
+	group.POST("/introspect", h.Introspect)
+	group.POST("/revoke", h.Revoke)
+}
+
+// OpenIDConfiguration function for serving discovery document
+func (h *HTTPOIDCHandler) OpenIDConfiguration(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.AuthUseCase.GetOpenIDConfiguration())
+}
+
+// JSONWebKeySet function for serving public keys of every active and retired signing key
+func (h *HTTPOIDCHandler) JSONWebKeySet(c echo.Context) error {
+	// short cache so a newly added key is picked up by consumers quickly
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.AuthUseCase.GetJSONWebKeySet())
+}
+
+// Authorize function for authorization code flow, the member session is taken from
+// bearer header or `access_token` form value posted by the login page
+func (h *HTTPOIDCHandler) Authorize(c echo.Context) error {
+	data := model.AuthorizeRequest{}
+	if err := c.Bind(&data); err != nil {
+		return oauthErrorJSON(c, model.NewOAuthError(model.OAuthErrorInvalidRequest, err.Error(), http.StatusBadRequest))
+	}
+	data.AccessToken = bearerToken(c)
+	if data.AccessToken == "" && c.Request().Method == http.MethodPost {
+		data.AccessToken = c.FormValue("access_token")
+	}
+
+	result := <-h.AuthUseCase.Authorize(c.Request().Context(), data)
+	resp, canRedirect := result.Result.(model.AuthorizeResponse)
+	if result.Error != nil {
+		oauthErr, ok := result.Error.(*model.OAuthError)
+		if !ok {
+			oauthErr = model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+		}
+		if !canRedirect {
+			return oauthErrorJSON(c, oauthErr)
+		}
+
+		// let the member log in first then come back to this very request
+		if oauthErr.Code == model.OAuthErrorLoginRequired && h.LoginURL != "" {
+			query := c.Request().URL.Query()
+			if c.Request().Method == http.MethodPost {
+				query = url.Values{}
+				for key, values := range c.Request().Form {
+					if key != "access_token" {
+						query[key] = values
+					}
+				}
+			}
+			returnTo := c.Scheme() + "://" + c.Request().Host + c.Request().URL.Path + "?" + query.Encode()
+			return c.Redirect(http.StatusFound, appendQuery(h.LoginURL, url.Values{"returnTo": {returnTo}}))
+		}
+
+		params := url.Values{"error": {oauthErr.Code}}
+		if oauthErr.Description != "" {
+			params.Set("error_description", oauthErr.Description)
+		}
+		if resp.State != "" {
+			params.Set("state", resp.State)
+		}
+		return c.Redirect(http.StatusFound, appendQuery(resp.RedirectURI, params))
+	}
+
+	params := url.Values{"code": {resp.Code}}
+	if resp.State != "" {
+		params.Set("state", resp.State)
+	}
+	return c.Redirect(http.StatusFound, appendQuery(resp.RedirectURI, params))
+}
+
+// Token function for oauth2 token endpoint
+func (h *HTTPOIDCHandler) Token(c echo.Context) error {
+	data := model.OIDCTokenRequest{}
+	if err := c.Bind(&data); err != nil {
+		return oauthErrorJSON(c, model.NewOAuthError(model.OAuthErrorInvalidRequest, err.Error(), http.StatusBadRequest))
+	}
+
+	// client_secret_basic takes precedence over client_secret_post
+	if clientID, clientSecret, ok := c.Request().BasicAuth(); ok {
+		data.ClientID = clientID
+		data.ClientSecret = clientSecret
+	}
+	data.IP = middleware.ClientIP(c)
+	data.UserAgent = c.Request().UserAgent()
+
+	ctxReq := context.WithValue(c.Request().Context(), middleware.ContextKeyClientIP, middleware.ClientIP(c))
+	result := <-h.AuthUseCase.ExchangeToken(ctxReq, data)
+	if result.Error != nil {
+		return oauthErrorJSON(c, clientOAuthError(c, result.Error))
+	}
+
+	setNoStore(c)
+	return c.JSON(http.StatusOK, result.Result)
+}
+
+// UserInfo function for openid connect userinfo endpoint
+func (h *HTTPOIDCHandler) UserInfo(c echo.Context) error {
+	accessToken := bearerToken(c)
+	if accessToken == "" {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+		return oauthErrorJSON(c, model.NewOAuthError(model.OAuthErrorInvalidRequest, "bearer token is required", http.StatusUnauthorized))
+	}
+
+	result := <-h.AuthUseCase.GetUserInfo(c.Request().Context(), accessToken)
+	if result.Error != nil {
+		oauthErr, ok := result.Error.(*model.OAuthError)
+		if !ok {
+			oauthErr = model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+		}
+		if oauthErr.HTTPStatus == http.StatusUnauthorized {
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="`+oauthErr.Code+`"`)
+		}
+		return oauthErrorJSON(c, oauthErr)
+	}
+
+	setNoStore(c)
+	return c.JSON(http.StatusOK, result.Result)
+}
+
+// Introspect function for rfc 7662 token introspection endpoint
+func (h *HTTPOIDCHandler) Introspect(c echo.Context) error {
+	data, oauthErr := bindTokenHintRequest(c)
+	if oauthErr != nil {
+		return oauthErrorJSON(c, oauthErr)
+	}
+
+	result := <-h.AuthUseCase.IntrospectToken(c.Request().Context(), data)
+	if result.Error != nil {
+		return oauthErrorJSON(c, clientOAuthError(c, result.Error))
+	}
+
+	setNoStore(c)
+	return c.JSON(http.StatusOK, result.Result)
+}
+
+// Revoke function for rfc 7009 token revocation endpoint
+func (h *HTTPOIDCHandler) Revoke(c echo.Context) error {
+	data, oauthErr := bindTokenHintRequest(c)
+	if oauthErr != nil {
+		return oauthErrorJSON(c, oauthErr)
+	}
+
+	result := <-h.AuthUseCase.RevokeToken(c.Request().Context(), data)
+	if result.Error != nil {
+		return oauthErrorJSON(c, clientOAuthError(c, result.Error))
+	}
+
+	setNoStore(c)
+	return c.NoContent(http.StatusOK)
+}
+
+func bindTokenHintRequest(c echo.Context) (model.TokenHintRequest, *model.OAuthError) {
+	data := model.TokenHintRequest{}
+	if err := c.Bind(&data); err != nil {
+		return data, model.NewOAuthError(model.OAuthErrorInvalidRequest, err.Error(), http.StatusBadRequest)
+	}
+	if clientID, clientSecret, ok := c.Request().BasicAuth(); ok {
+		data.ClientID = clientID
+		data.ClientSecret = clientSecret
+	}
+	return data, nil
+}
+
+// clientOAuthError function for mapping usecase error of a client authenticated endpoint
+func clientOAuthError(c echo.Context, err error) *model.OAuthError {
+	oauthErr, ok := err.(*model.OAuthError)
+	if !ok {
+		oauthErr = model.NewOAuthError(model.OAuthErrorServerError, "", http.StatusInternalServerError)
+	}
+	if oauthErr.Code == model.OAuthErrorInvalidClient {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="token"`)
+	}
+	return oauthErr
+}
+
+// oauthErrorJSON writes rfc 6749 section 5.2 error body
+func oauthErrorJSON(c echo.Context, oauthErr *model.OAuthError) error {
+	setNoStore(c)
+	return c.JSON(oauthErr.HTTPStatus, oauthErr)
+}
+
+func setNoStore(c echo.Context) {
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Pragma", "no-cache")
+}
+
+func bearerToken(c echo.Context) string {
+	auth := c.Request().Header.Get(echo.HeaderAuthorization)
+	if strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return ""
+}
+
+func appendQuery(rawURL string, params url.Values) string {
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + params.Encode()
+}
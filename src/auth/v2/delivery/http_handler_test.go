@@ -25,6 +25,7 @@ import (
 	"github.com/Bhinneka/user-service/src/auth/v1/model"
 	"github.com/Bhinneka/user-service/src/auth/v1/usecase"
 	"github.com/Bhinneka/user-service/src/auth/v1/usecase/mocks"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 )
 
 type VerifyMock struct{}
@@ -91,6 +92,7 @@ func TestAuthMount(t *testing.T) {
 	handler.Mount(e.Group("/"))
 	handler.Mount(e.Group("/logout"))
 	handler.MountAdmin(e.Group("/admin"))
+	handler.MountLockout(e.Group("/lockouts"))
 }
 
 func TestAuthClientMount(t *testing.T) {
@@ -302,6 +304,52 @@ func TestGetAccessToken(t *testing.T) {
 	}
 }
 
+func TestGetAccessTokenClientIP(t *testing.T) {
+	proxies, _ := middleware.ParseTrustedProxies("10.0.0.0/8")
+	tests := []struct {
+		name   string
+		remote string
+		wantIP string
+	}{
+		{
+			name:   "Testcase #1: Forwarded ip from a trusted proxy",
+			remote: "10.0.0.5:4000",
+			wantIP: "198.51.100.1",
+		},
+		{
+			name:   "Testcase #2: Forwarded ip sent by the client",
+			remote: "203.0.113.7:4000",
+			wantIP: "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUseCase := new(mocks.AuthUseCase)
+			mockAuthUseCase.On("GenerateToken", mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(usecase.ResultUseCase{Result: model.RequestToken{}}))
+			mockAuthUseCase.On(getClientApp, mock.Anything, mock.Anything).Return(generateUsecaseResult(usecase.ResultUseCase{Result: true}))
+
+			data := url.Values{}
+			data.Set("email", "abc@email.co")
+			data.Set("grantType", model.AuthTypePassword)
+			req := httptest.NewRequest(echo.POST, root, bytes.NewBufferString(data.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			req.Header.Set(authorization, tokenAdmin)
+			req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.1")
+			req.RemoteAddr = tt.remote
+
+			e := echo.New()
+			c := e.NewContext(req, httptest.NewRecorder())
+			handler := NewHTTPHandler(mockAuthUseCase, googleAuthRedirectURL)
+			assert.NoError(t, middleware.TrustedClientIP(proxies)(handler.GetAccessToken)(c))
+
+			mockAuthUseCase.AssertCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.MatchedBy(func(data model.RequestToken) bool {
+				return data.IP == tt.wantIP
+			}))
+		})
+	}
+}
+
 func TestGetAccessTokenFromUserID(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -643,6 +691,224 @@ func TestCheckEmail(t *testing.T) {
 	}
 }
 
+func TestGeneratePasskeyLoginOptions(t *testing.T) {
+	tests := []struct {
+		name              string
+		token             string
+		expectUseCaseData usecase.ResultUseCase
+		expectStatusCode  int
+	}{
+		{
+			name:              testCasePositive1,
+			token:             tokenAdmin,
+			expectUseCaseData: usecase.ResultUseCase{Result: webauthn.RequestOptions{}},
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name:             testCaseNegative2,
+			token:            noAuth,
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:              testCaseNegative3,
+			token:             tokenAdmin,
+			expectUseCaseData: usecase.ResultUseCase{HTTPStatus: http.StatusInternalServerError, Error: fmt.Errorf(failedResponse)},
+			expectStatusCode:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUseCase := new(mocks.AuthUseCase)
+			mockAuthUseCase.On("GeneratePasskeyLoginOptions", mock.Anything).Return(generateUsecaseResult(tt.expectUseCaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.POST, root, nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(authorization, tt.token)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := NewHTTPHandler(mockAuthUseCase, googleAuthRedirectURL)
+
+			assert.NoError(t, handler.GeneratePasskeyLoginOptions(c))
+			assert.Equal(t, tt.expectStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestSendMFAOTP(t *testing.T) {
+	tests := []struct {
+		name              string
+		token             string
+		expectUseCaseData usecase.ResultUseCase
+		expectStatusCode  int
+	}{
+		{
+			name:              testCasePositive1,
+			token:             tokenAdmin,
+			expectUseCaseData: usecase.ResultUseCase{Result: model.MFAOTPSent{Method: model.MFAMethodSMS, Destination: "*******6789"}},
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name:             testCaseNegative2,
+			token:            noAuth,
+			expectStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:              testCaseNegative3,
+			token:             tokenAdmin,
+			expectUseCaseData: usecase.ResultUseCase{HTTPStatus: http.StatusTooManyRequests, Error: fmt.Errorf(failedResponse)},
+			expectStatusCode:  http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUseCase := new(mocks.AuthUseCase)
+			mockAuthUseCase.On("SendMFAOTP", mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.expectUseCaseData))
+
+			e := echo.New()
+			payload := `{"mfaToken":"abc-VVNSMTIz","mfaMethod":"sms","deviceId":"ABC","deviceLogin":"WEB"}`
+			req := httptest.NewRequest(echo.POST, root, strings.NewReader(payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(authorization, tt.token)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := NewHTTPHandler(mockAuthUseCase, googleAuthRedirectURL)
+
+			assert.NoError(t, handler.SendMFAOTP(c))
+			assert.Equal(t, tt.expectStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestGetLockouts(t *testing.T) {
+	tests := []struct {
+		name              string
+		expectUseCaseData usecase.ResultUseCase
+		expectStatusCode  int
+	}{
+		{
+			name:              testCasePositive1,
+			expectUseCaseData: usecase.ResultUseCase{Result: []model.Lockout{{Email: "john@bhinneka.com", Locked: true}}},
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name:              testCaseNegative2,
+			expectUseCaseData: usecase.ResultUseCase{HTTPStatus: http.StatusInternalServerError, Error: fmt.Errorf(failedResponse)},
+			expectStatusCode:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUseCase := new(mocks.AuthUseCase)
+			mockAuthUseCase.On("GetLockouts", mock.Anything).Return(generateUsecaseResult(tt.expectUseCaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/api/v2/lockouts", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := NewHTTPHandler(mockAuthUseCase, googleAuthRedirectURL)
+
+			assert.NoError(t, handler.GetLockouts(c))
+			assert.Equal(t, tt.expectStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestGetLockout(t *testing.T) {
+	tests := []struct {
+		name              string
+		expectUseCaseData usecase.ResultUseCase
+		expectStatusCode  int
+	}{
+		{
+			name:              testCasePositive1,
+			expectUseCaseData: usecase.ResultUseCase{Result: model.Lockout{Email: "john@bhinneka.com", Failures: 3}},
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name:              testCaseNegative2,
+			expectUseCaseData: usecase.ResultUseCase{HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorLockoutNotFound)},
+			expectStatusCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUseCase := new(mocks.AuthUseCase)
+			mockAuthUseCase.On("GetLockout", mock.Anything, "john@bhinneka.com").Return(generateUsecaseResult(tt.expectUseCaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/api/v2/lockouts/john@bhinneka.com", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("email")
+			c.SetParamValues("john@bhinneka.com")
+
+			handler := NewHTTPHandler(mockAuthUseCase, googleAuthRedirectURL)
+
+			assert.NoError(t, handler.GetLockout(c))
+			assert.Equal(t, tt.expectStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestClearLockout(t *testing.T) {
+	tests := []struct {
+		name              string
+		token             string
+		expectUseCaseData usecase.ResultUseCase
+		expectStatusCode  int
+	}{
+		{
+			name:              testCasePositive1,
+			token:             tokenAdminJwt,
+			expectUseCaseData: usecase.ResultUseCase{Result: model.Lockout{Email: "john@bhinneka.com", Locked: true}},
+			expectStatusCode:  http.StatusOK,
+		},
+		{
+			name:             testCaseNegative2,
+			token:            noAuth,
+			expectStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:              testCaseNegative3,
+			token:             tokenAdminJwt,
+			expectUseCaseData: usecase.ResultUseCase{HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorLockoutNotFound)},
+			expectStatusCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthUseCase := new(mocks.AuthUseCase)
+			mockAuthUseCase.On("ClearLockout", mock.Anything, "john@bhinneka.com", mock.Anything).Return(generateUsecaseResult(tt.expectUseCaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.DELETE, "/api/v2/lockouts/john@bhinneka.com", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("email")
+			c.SetParamValues("john@bhinneka.com")
+
+			token, _ := generateToken(tt.token)
+			c.Set("token", token)
+
+			handler := NewHTTPHandler(mockAuthUseCase, googleAuthRedirectURL)
+
+			assert.NoError(t, handler.ClearLockout(c))
+			assert.Equal(t, tt.expectStatusCode, rec.Code)
+		})
+	}
+}
+
 func TestVerifyCaptcha(t *testing.T) {
 	tests := []struct {
 		name              string
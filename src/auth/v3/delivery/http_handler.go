@@ -110,6 +110,7 @@ func (h *AuthHandlerV3) GetAccessToken(c echo.Context) error {
 		Mobile:       tokenV3.Mobile,
 		AccountID:    tokenV3.AccountID,
 	}
+	response.TrustedDeviceToken = tokenV3.IssuedTrustedDeviceToken
 
 	if response.NewMember {
 		_ = <-h.AuthUseCase.SendEmailWelcomeMember(c.Request().Context(), response)
@@ -204,7 +205,7 @@ func (h *AuthHandlerV3) ValidateData(c echo.Context, payload *model.RequestToken
 	payload.Audience = clientID
 	payload.DeviceID = strings.Trim(payload.DeviceID, " ")
 	payload.Email = strings.ToLower(email)
-	payload.IP = c.RealIP()
+	payload.IP = middleware.ClientIP(c)
 	payload.UserAgent = c.Request().UserAgent()
 
 	// for refreshing token needs old token and old refresh token
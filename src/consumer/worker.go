@@ -28,10 +28,14 @@ func Dispatch(ctxReq context.Context, payload []byte, merchantUsecase merchantUC
 	tags["eventType"] = data.EventType
 	newCtx := context.WithValue(tr.NewChildContext(), helper.TextAuthorization, data.Auth)
 	switch data.EventType {
-	case "SendEmailMerchantAdd", "SendEmailMerchantRejectRegistration", "SendEmailMerchantRejectUpgrade", "SendEmailMerchantUpgrade", "SendEmailAdmin":
+	case "SendEmailMerchantAdd", "SendEmailMerchantRejectRegistration", "SendEmailMerchantRejectUpgrade", "SendEmailMerchantUpgrade", "SendEmailAdmin",
+		"SendEmailMerchantDocumentExpiry", "SendEmailMerchantRestricted", "SendEmailMerchantBankChange",
+		"SendEmailMerchantOwnershipTransfer":
 		err = sendEmailMerchant(newCtx, data.Payload, merchantUsecase, data.EventType)
 	case "SendEmailMerchantEmployeeLogin", "SendEmailMerchantEmployeeRegister":
 		err = sendEmailMerchantEmployee(newCtx, data.Payload, merchantUsecase, data.EventType)
+	case "VerifyMerchantBank":
+		err = verifyMerchantBank(newCtx, data.Payload, merchantUsecase)
 	case "InsertLogMerchantCreate":
 		err = insertLogMerchant(newCtx, data.Payload, merchantUsecase, helper.TextInsertUpper)
 	case "InsertLogMerchantDelete":
@@ -92,11 +96,39 @@ func sendEmailMerchant(ctxReq context.Context, payload interface{}, merchantUsec
 	case "SendEmailAdmin":
 		result := <-merchantUsecase.SendEmailAdmin(ctxReq, merchantPl.Data, merchantPl.MemberName, merchantPl.ReasonReject, merchantPl.AdminCMS)
 		return result.Error
+	case "SendEmailMerchantDocumentExpiry":
+		result := <-merchantUsecase.SendEmailMerchantDocumentExpiry(ctxReq, merchantPl.Data, merchantPl.Documents)
+		return result.Error
+	case "SendEmailMerchantRestricted":
+		result := <-merchantUsecase.SendEmailMerchantRestricted(ctxReq, merchantPl.Data, merchantPl.Documents)
+		return result.Error
+	case "SendEmailMerchantBankChange":
+		result := <-merchantUsecase.SendEmailMerchantBankChange(ctxReq, merchantPl.Data, merchantPl.BankChange)
+		return result.Error
+	case "SendEmailMerchantOwnershipTransfer":
+		result := <-merchantUsecase.SendEmailMerchantOwnershipTransfer(ctxReq, merchantPl.Data, merchantPl.OwnershipTransfer)
+		return result.Error
 	}
 
 	return nil
 }
 
+func verifyMerchantBank(ctxReq context.Context, payload interface{}, merchantUsecase merchantUC.MerchantUseCase) error {
+	request := merchantModel.MerchantBankVerificationRequest{}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, &request); err != nil {
+		return err
+	}
+
+	userAttribute := &merchantModel.MerchantUserAttribute{UserID: request.ActorID}
+	result := <-merchantUsecase.VerifyMerchantBank(ctxReq, request.MerchantID, userAttribute)
+	return result.Error
+}
+
 func sendEmailMerchantEmployee(ctxReq context.Context, payload interface{}, merchantUsecase merchantUC.MerchantUseCase, eventType string) error {
 
 	merchantPl := memberModel.MemberPayloadEmail{}
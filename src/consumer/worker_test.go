@@ -8,6 +8,7 @@ import (
 	memberUC "github.com/Bhinneka/user-service/src/member/v1/usecase"
 	merchantModel "github.com/Bhinneka/user-service/src/merchant/v2/model"
 	merchantUC "github.com/Bhinneka/user-service/src/merchant/v2/usecase"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -136,6 +137,54 @@ func Test_sendEmailMerchant(t *testing.T) {
 				eventType: "SendEmailMerchantUpgrade",
 			},
 		},
+		{
+			name: "Case 7: Success SendEmailMerchantDocumentExpiry",
+			args: args{
+				ctxReq:  context.Background(),
+				payload: merchantModel.MerchantPayloadEmail{Documents: []merchantModel.B2CMerchantDocumentData{{DocumentType: merchantModel.DocumentTypeNPWP}}},
+				merchantUsecase: func() merchantUC.MerchantUseCase {
+					mocksMerchantUsecase := new(mocksMerchantUsecase.MerchantUseCase)
+					mocksMerchantUsecase.On("SendEmailMerchantDocumentExpiry", mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(merchantUC.ResultUseCase{
+						Error: nil,
+					}))
+
+					return mocksMerchantUsecase
+				}(),
+				eventType: "SendEmailMerchantDocumentExpiry",
+			},
+		},
+		{
+			name: "Case 8: Success SendEmailMerchantRestricted",
+			args: args{
+				ctxReq:  context.Background(),
+				payload: merchantModel.MerchantPayloadEmail{Documents: []merchantModel.B2CMerchantDocumentData{{DocumentType: merchantModel.DocumentTypeKTP}}},
+				merchantUsecase: func() merchantUC.MerchantUseCase {
+					mocksMerchantUsecase := new(mocksMerchantUsecase.MerchantUseCase)
+					mocksMerchantUsecase.On("SendEmailMerchantRestricted", mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(merchantUC.ResultUseCase{
+						Error: nil,
+					}))
+
+					return mocksMerchantUsecase
+				}(),
+				eventType: "SendEmailMerchantRestricted",
+			},
+		},
+		{
+			name: "Case 9: Success SendEmailMerchantBankChange",
+			args: args{
+				ctxReq:  context.Background(),
+				payload: merchantModel.MerchantPayloadEmail{BankChange: &merchantModel.MerchantBankChange{ID: 1, NotifyEmail: "merchant@bhinneka.com"}},
+				merchantUsecase: func() merchantUC.MerchantUseCase {
+					mocksMerchantUsecase := new(mocksMerchantUsecase.MerchantUseCase)
+					mocksMerchantUsecase.On("SendEmailMerchantBankChange", mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(merchantUC.ResultUseCase{
+						Error: nil,
+					}))
+
+					return mocksMerchantUsecase
+				}(),
+				eventType: "SendEmailMerchantBankChange",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -143,3 +192,13 @@ func Test_sendEmailMerchant(t *testing.T) {
 		})
 	}
 }
+
+func Test_verifyMerchantBank(t *testing.T) {
+	mocksMerchant := new(mocksMerchantUsecase.MerchantUseCase)
+	mocksMerchant.On("VerifyMerchantBank", mock.Anything, "MCH201210161001", &merchantModel.MerchantUserAttribute{UserID: "USR201210161001"}).Return(generateUsecaseResult(merchantUC.ResultUseCase{}))
+
+	payload := merchantModel.MerchantBankVerificationRequest{MerchantID: "MCH201210161001", ActorID: "USR201210161001"}
+	err := verifyMerchantBank(context.Background(), payload, mocksMerchant)
+	assert.NoError(t, err)
+	mocksMerchant.AssertExpectations(t)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/middleware"
 	"github.com/Bhinneka/user-service/src/corporate/v2/model"
 	"github.com/Bhinneka/user-service/src/corporate/v2/usecase"
 	"github.com/Bhinneka/user-service/src/shared"
@@ -37,6 +38,13 @@ func (s *HTTPCorporateHandler) MountCorporate(group *echo.Group) {
 	group.POST("/contact/import", s.ImportContact)
 }
 
+// MountAccountSAML function for mounting single sign on endpoints of corporate accounts
+func (s *HTTPCorporateHandler) MountAccountSAML(group *echo.Group) {
+	group.GET("/:accountID/saml", s.GetAccountSAML)
+	group.PUT("/:accountID/saml", s.SaveAccountSAML)
+	group.DELETE("/:accountID/saml", s.DeleteAccountSAML)
+}
+
 // GetContactList function for getting list of contact
 func (s *HTTPCorporateHandler) GetContactList(c echo.Context) error {
 	params := model.ParametersContact{
@@ -103,3 +111,43 @@ func (s *HTTPCorporateHandler) ImportContact(c echo.Context) error {
 	}
 	return shared.NewHTTPResponse(http.StatusOK, "Success Import Contact").JSON(c)
 }
+
+// GetAccountSAML function for getting the single sign on of an account
+func (s *HTTPCorporateHandler) GetAccountSAML(c echo.Context) error {
+	res := <-s.CorporateUseCase.GetAccountSAML(c.Request().Context(), c.Param("accountID"))
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Account SAML Response", res.Result).JSON(c)
+}
+
+// SaveAccountSAML function for uploading the identity provider metadata and attribute mapping of an account
+func (s *HTTPCorporateHandler) SaveAccountSAML(c echo.Context) error {
+	adminID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.AccountSAMLPayload{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	res := <-s.CorporateUseCase.SaveAccountSAML(c.Request().Context(), c.Param("accountID"), payload, adminID)
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success save account SAML", res.Result).JSON(c)
+}
+
+// DeleteAccountSAML function for removing the single sign on of an account
+func (s *HTTPCorporateHandler) DeleteAccountSAML(c echo.Context) error {
+	res := <-s.CorporateUseCase.DeleteAccountSAML(c.Request().Context(), c.Param("accountID"))
+	if res.Error != nil {
+		return shared.NewHTTPResponse(res.HTTPStatus, res.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success delete account SAML").JSON(c)
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	sharedModel "github.com/Bhinneka/user-service/src/shared/model"
+	"github.com/Bhinneka/user-service/src/shared/saml"
+)
+
+const (
+	// SAMLAttributeEmail default attribute of the contact email, the name id is used when it is missing
+	SAMLAttributeEmail = "email"
+	// SAMLAttributeFirstName default attribute of the contact first name
+	SAMLAttributeFirstName = "firstName"
+	// SAMLAttributeLastName default attribute of the contact last name
+	SAMLAttributeLastName = "lastName"
+	// SAMLAttributePhoneNumber default attribute of the contact phone number
+	SAMLAttributePhoneNumber = "phoneNumber"
+	// SAMLAttributeJobTitle default attribute of the contact job title
+	SAMLAttributeJobTitle = "jobTitle"
+
+	// ErrorSAMLNotConfigured error message for an account without single sign on
+	ErrorSAMLNotConfigured = "single sign on is not configured for the account"
+	// ErrorSAMLMetadataRequired error message for a configuration without identity provider metadata
+	ErrorSAMLMetadataRequired = "metadata is required"
+)
+
+// SAMLAttributeMapping names of the assertion attributes holding the contact data
+type SAMLAttributeMapping struct {
+	Email       string `json:"email"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	PhoneNumber string `json:"phoneNumber"`
+	JobTitle    string `json:"jobTitle"`
+}
+
+// AccountSAML data structure of the SAML 2.0 single sign on of a corporate account
+type AccountSAML struct {
+	AccountID        string               `json:"accountId"`
+	EntityID         string               `json:"entityId"`
+	SSOURL           string               `json:"ssoUrl"`
+	Metadata         string               `json:"-"`
+	AttributeMapping SAMLAttributeMapping `json:"attributeMapping"`
+	JITProvisioning  bool                 `json:"jitProvisioning"`
+	Enabled          bool                 `json:"enabled"`
+	SPEntityID       string               `json:"spEntityId"`
+	SPACSURL         string               `json:"spAcsUrl"`
+	Created          time.Time            `json:"created"`
+	Modified         time.Time            `json:"modified"`
+	CreatedBy        string               `json:"createdBy"`
+	ModifiedBy       string               `json:"modifiedBy"`
+}
+
+// AccountSAMLPayload data structure of a request for saving the single sign on of an account,
+// metadata is the xml metadata of the identity provider
+type AccountSAMLPayload struct {
+	Metadata         string               `json:"metadata" form:"metadata"`
+	AttributeMapping SAMLAttributeMapping `json:"attributeMapping" form:"attributeMapping"`
+	JITProvisioning  bool                 `json:"jitProvisioning" form:"jitProvisioning"`
+	Enabled          bool                 `json:"enabled" form:"enabled"`
+}
+
+// WithDefaults function for filling the attributes left empty with the default attribute names
+func (m SAMLAttributeMapping) WithDefaults() SAMLAttributeMapping {
+	defaults := []struct {
+		value    *string
+		fallback string
+	}{
+		{&m.Email, SAMLAttributeEmail},
+		{&m.FirstName, SAMLAttributeFirstName},
+		{&m.LastName, SAMLAttributeLastName},
+		{&m.PhoneNumber, SAMLAttributePhoneNumber},
+		{&m.JobTitle, SAMLAttributeJobTitle},
+	}
+	for _, attribute := range defaults {
+		*attribute.value = strings.TrimSpace(*attribute.value)
+		if *attribute.value == "" {
+			*attribute.value = attribute.fallback
+		}
+	}
+	return m
+}
+
+// Contact function for mapping the assertion of the identity provider to contact data of the account,
+// the email falls back to the name id when the identity provider does not send the email attribute
+func (m SAMLAttributeMapping) Contact(accountID string, assertion *saml.Assertion) sharedModel.B2BContactData {
+	m = m.WithDefaults()
+
+	email := assertion.Attribute(m.Email)
+	if email == "" {
+		email = assertion.NameID
+	}
+
+	return sharedModel.B2BContactData{
+		Email:       strings.ToLower(strings.TrimSpace(email)),
+		FirstName:   assertion.Attribute(m.FirstName),
+		LastName:    assertion.Attribute(m.LastName),
+		PhoneNumber: assertion.Attribute(m.PhoneNumber),
+		JobTitle:    assertion.Attribute(m.JobTitle),
+		AccountID:   accountID,
+		Status:      StatusActivated,
+		LoginType:   LoginTypeCorporate,
+	}
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/Bhinneka/user-service/src/shared/saml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSAMLAttributeMapping_Contact(t *testing.T) {
+	tests := []struct {
+		name      string
+		mapping   SAMLAttributeMapping
+		assertion saml.Assertion
+		expected  string
+		firstName string
+	}{
+		{
+			name:    "Case 1: default attributes",
+			mapping: SAMLAttributeMapping{},
+			assertion: saml.Assertion{
+				NameID:     "12345",
+				Attributes: map[string][]string{"email": {" John@Bhinneka.com "}, "firstName": {"John"}},
+			},
+			expected:  "john@bhinneka.com",
+			firstName: "John",
+		},
+		{
+			name:    "Case 2: mapped attributes",
+			mapping: SAMLAttributeMapping{Email: "mail", FirstName: "givenName"},
+			assertion: saml.Assertion{
+				NameID:     "12345",
+				Attributes: map[string][]string{"mail": {"john@bhinneka.com"}, "givenName": {"John"}, "firstName": {"Jane"}},
+			},
+			expected:  "john@bhinneka.com",
+			firstName: "John",
+		},
+		{
+			name:      "Case 3: name id without email attribute",
+			mapping:   SAMLAttributeMapping{},
+			assertion: saml.Assertion{NameID: "John@Bhinneka.com"},
+			expected:  "john@bhinneka.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact := tt.mapping.Contact("ACC123", &tt.assertion)
+			assert.Equal(t, tt.expected, contact.Email)
+			assert.Equal(t, tt.firstName, contact.FirstName)
+			assert.Equal(t, "ACC123", contact.AccountID)
+			assert.Equal(t, StatusActivated, contact.Status)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/corporate/v2/model"
+)
+
+// AccountSAMLQueryPostgres data structure
+type AccountSAMLQueryPostgres struct {
+	db *sql.DB
+}
+
+// NewAccountSAMLQueryPostgres function for initializing account saml query
+func NewAccountSAMLQueryPostgres(db *sql.DB) *AccountSAMLQueryPostgres {
+	return &AccountSAMLQueryPostgres{db: db}
+}
+
+// FindByAccountID function for getting the single sign on of an account
+func (mq *AccountSAMLQueryPostgres) FindByAccountID(ctxReq context.Context, accountID string) <-chan ResultQuery {
+	ctx := "AccountSAMLQuery-FindByAccountID"
+
+	output := make(chan ResultQuery)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		q := `SELECT account_id, entity_id, sso_url, metadata, attribute_mapping,
+				jit_provisioning, enabled, created, modified, created_by, modified_by
+			FROM b2b_account_saml WHERE account_id = $1`
+
+		tags[helper.TextQuery] = q
+		tags[helper.TextArgs] = accountID
+		stmt, err := mq.db.Prepare(q)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, q)
+			output <- ResultQuery{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		var (
+			accountSAML           model.AccountSAML
+			attributeMapping      []byte
+			createdBy, modifiedBy sql.NullString
+		)
+		if err = stmt.QueryRow(accountID).Scan(
+			&accountSAML.AccountID, &accountSAML.EntityID, &accountSAML.SSOURL, &accountSAML.Metadata, &attributeMapping,
+			&accountSAML.JITProvisioning, &accountSAML.Enabled, &accountSAML.Created, &accountSAML.Modified,
+			&createdBy, &modifiedBy,
+		); err != nil {
+			if err != sql.ErrNoRows {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, accountID)
+			}
+			output <- ResultQuery{Error: err}
+			return
+		}
+
+		if err = json.Unmarshal(attributeMapping, &accountSAML.AttributeMapping); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "unmarshal_attribute_mapping", err, accountID)
+			output <- ResultQuery{Error: err}
+			return
+		}
+		accountSAML.CreatedBy = helper.ValidateSQLNullString(createdBy)
+		accountSAML.ModifiedBy = helper.ValidateSQLNullString(modifiedBy)
+
+		output <- ResultQuery{Result: accountSAML}
+	})
+
+	return output
+}
@@ -31,3 +31,8 @@ type AccountContactQuery interface {
 	FindAccountMicrositeByContactID(id int) <-chan ResultQuery
 	FindByAccountMicrositeContactID(id int) <-chan ResultQuery
 }
+
+// AccountSAMLQuery interface abstraction
+type AccountSAMLQuery interface {
+	FindByAccountID(ctxReq context.Context, accountID string) <-chan ResultQuery
+}
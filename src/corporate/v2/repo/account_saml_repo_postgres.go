@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/corporate/v2/model"
+	sharedModel "github.com/Bhinneka/user-service/src/shared/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// AccountSAMLRepoPostgres data structure
+type AccountSAMLRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewAccountSAMLRepoPostgres function for initializing account saml repo
+func NewAccountSAMLRepoPostgres(repo *repository.Repository) *AccountSAMLRepoPostgres {
+	return &AccountSAMLRepoPostgres{repo}
+}
+
+// Save function for saving the single sign on of an account
+func (mr *AccountSAMLRepoPostgres) Save(ctxReq context.Context, accountSAML model.AccountSAML) (err error) {
+	ctx := "AccountSAMLRepo-Save"
+
+	querySave := `INSERT INTO b2b_account_saml
+				(
+					account_id, entity_id, sso_url, metadata, attribute_mapping,
+					jit_provisioning, enabled, created, modified, created_by, modified_by
+				)
+			VALUES
+				(
+					$1, $2, $3, $4, $5::jsonb,
+					$6, $7, $8, $9, $10, $11
+				)
+			ON CONFLICT(account_id)
+			DO UPDATE SET
+			entity_id=$2, sso_url=$3, metadata=$4, attribute_mapping=$5::jsonb,
+			jit_provisioning=$6, enabled=$7, modified=$9, modified_by=$11`
+
+	tr := tracer.StartTrace(ctxReq, ctx)
+	tags := map[string]interface{}{
+		helper.TextQuery: querySave,
+		helper.TextArgs:  accountSAML.AccountID,
+	}
+	defer tr.Finish(tags)
+
+	attributeMapping, _ := json.Marshal(accountSAML.AttributeMapping)
+	if err = repository.Exec(
+		mr.Repository, querySave,
+		accountSAML.AccountID, accountSAML.EntityID, accountSAML.SSOURL, accountSAML.Metadata, attributeMapping,
+		accountSAML.JITProvisioning, accountSAML.Enabled, accountSAML.Created, accountSAML.Modified,
+		accountSAML.CreatedBy, accountSAML.ModifiedBy,
+	); err != nil {
+		tags[helper.TagError] = err
+		helper.SendErrorLog(tr.Context(), ctx, helper.TextExecQuery, err, accountSAML.AccountID)
+		return err
+	}
+
+	return nil
+}
+
+// Delete function for removing the single sign on of an account
+func (mr *AccountSAMLRepoPostgres) Delete(ctxReq context.Context, accountID string) (err error) {
+	ctx := "AccountSAMLRepo-Delete"
+
+	queryDelete := `DELETE FROM b2b_account_saml WHERE account_id = $1`
+
+	tr := tracer.StartTrace(ctxReq, ctx)
+	tags := map[string]interface{}{
+		helper.TextQuery: queryDelete,
+		helper.TextArgs:  accountID,
+	}
+	defer tr.Finish(tags)
+
+	if err = repository.Exec(mr.Repository, queryDelete, accountID); err != nil {
+		tags[helper.TagError] = err
+		helper.SendErrorLog(tr.Context(), ctx, helper.TextExecQuery, err, accountID)
+		return err
+	}
+
+	return nil
+}
+
+// ProvisionContact function for creating the contact of a first single sign on together with its account contact,
+// ids come from a sequence reserved above the ids of shark so the contacts synced from shark never collide
+func (mr *AccountSAMLRepoPostgres) ProvisionContact(ctxReq context.Context, contact *sharedModel.B2BContactData) (err error) {
+	ctx := "AccountSAMLRepo-ProvisionContact"
+
+	queryProvision := `WITH contact AS (
+				INSERT INTO b2b_contact
+					(
+						id, first_name, last_name, job_title, email, phone_number, account_id,
+						status, is_new, is_primary, is_disabled, transaction_type, created_at, modified_at
+					)
+				VALUES
+					(
+						nextval('b2b_saml_provision_id_seq'), $1, $2, $3, $4, $5, $6,
+						$7, false, false, false, $8::jsonb, now(), now()
+					)
+				RETURNING id
+			)
+			INSERT INTO b2b_account_contact
+				(
+					id, status, is_delete, is_disabled, account_id, contact_id,
+					created_at, modified_at, is_admin
+				)
+			SELECT nextval('b2b_saml_provision_id_seq'), $7, false, false, $6, contact.id, now(), now(), false
+			FROM contact
+			RETURNING contact_id`
+
+	tr := tracer.StartTrace(ctxReq, ctx)
+	tags := map[string]interface{}{
+		helper.TextQuery: queryProvision,
+		helper.TextArgs:  contact.Email,
+	}
+	defer tr.Finish(tags)
+
+	transactionType, _ := json.Marshal([]sharedModel.TransactionType{{Microsite: contact.LoginType, Type: contact.LoginType}})
+
+	stmt, err := mr.WriteDB.Prepare(queryProvision)
+	if err != nil {
+		tags[helper.TagError] = err
+		helper.SendErrorLog(tr.Context(), ctx, helper.TextPrepareDatabase, err, contact.Email)
+		return err
+	}
+	defer stmt.Close()
+
+	if err = stmt.QueryRow(
+		contact.FirstName, contact.LastName, contact.JobTitle, contact.Email, contact.PhoneNumber, contact.AccountID,
+		contact.Status, transactionType,
+	).Scan(&contact.ID); err != nil {
+		tags[helper.TagError] = err
+		helper.SendErrorLog(tr.Context(), ctx, helper.TextExecQuery, err, contact.Email)
+		return err
+	}
+
+	return nil
+}
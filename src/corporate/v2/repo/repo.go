@@ -94,3 +94,10 @@ type ContactDocumentRepository interface {
 	Save(context.Context, sharedModel.B2BContactDocument) error
 	Delete(context.Context, sharedModel.B2BContactDocument) error
 }
+
+// AccountSAMLRepository interface abstraction
+type AccountSAMLRepository interface {
+	Save(context.Context, model.AccountSAML) error
+	Delete(ctxReq context.Context, accountID string) error
+	ProvisionContact(context.Context, *sharedModel.B2BContactData) error
+}
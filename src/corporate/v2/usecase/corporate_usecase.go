@@ -30,20 +30,29 @@ const (
 
 //CorporateUseCaseImpl data structure
 type CorporateUseCaseImpl struct {
-	ContactRepo  repo.ContactRepository
-	ContactQuery query.ContactQuery
-	QPublisher   service.QPublisher
+	ContactRepo      repo.ContactRepository
+	ContactQuery     query.ContactQuery
+	AccountSAMLRepo  repo.AccountSAMLRepository
+	AccountSAMLQuery query.AccountSAMLQuery
+	SAMLBaseURL      string
+	QPublisher       service.QPublisher
 }
 
 // NewCorporateUseCase function for initialise contact use case implementation
 func NewCorporateUseCase(
 	contactRepo repo.ContactRepository,
 	contactQuery query.ContactQuery,
+	accountSAMLRepo repo.AccountSAMLRepository,
+	accountSAMLQuery query.AccountSAMLQuery,
+	samlBaseURL string,
 	services localConfig.ServiceShared) CorporateUseCase {
 	return &CorporateUseCaseImpl{
-		ContactRepo:  contactRepo,
-		ContactQuery: contactQuery,
-		QPublisher:   services.QPublisher,
+		ContactRepo:      contactRepo,
+		ContactQuery:     contactQuery,
+		AccountSAMLRepo:  accountSAMLRepo,
+		AccountSAMLQuery: accountSAMLQuery,
+		SAMLBaseURL:      samlBaseURL,
+		QPublisher:       services.QPublisher,
 	}
 }
 
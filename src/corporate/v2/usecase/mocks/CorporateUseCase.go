@@ -12,6 +12,38 @@ type CorporateUseCase struct {
 	mock.Mock
 }
 
+// DeleteAccountSAML provides a mock function with given fields: ctxReq, accountID
+func (_m *CorporateUseCase) DeleteAccountSAML(ctxReq context.Context, accountID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accountID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetAccountSAML provides a mock function with given fields: ctxReq, accountID
+func (_m *CorporateUseCase) GetAccountSAML(ctxReq context.Context, accountID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accountID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GetAllListContact provides a mock function with given fields: ctxReq, params
 func (_m *CorporateUseCase) GetAllListContact(ctxReq context.Context, params *model.ParametersContact) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, params)
@@ -66,3 +98,19 @@ func (_m *CorporateUseCase) ImportContact(ctxReq context.Context, content []byte
 
 	return r0, r1
 }
+
+// SaveAccountSAML provides a mock function with given fields: ctxReq, accountID, payload, adminID
+func (_m *CorporateUseCase) SaveAccountSAML(ctxReq context.Context, accountID string, payload model.AccountSAMLPayload, adminID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, accountID, payload, adminID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.AccountSAMLPayload, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, accountID, payload, adminID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
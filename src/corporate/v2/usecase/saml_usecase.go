@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/corporate/v2/model"
+	"github.com/Bhinneka/user-service/src/shared/saml"
+)
+
+// GetAccountSAML function for getting the single sign on of an account with the service provider
+// entity id and assertion consumer service url given to the identity provider
+func (s *CorporateUseCaseImpl) GetAccountSAML(ctxReq context.Context, accountID string) <-chan ResultUseCase {
+	ctx := "CorporateUseCase-GetAccountSAML"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = accountID
+		accountSAML, httpStatus, err := s.findAccountSAML(ctxReq, accountID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
+		output <- ResultUseCase{Result: s.withServiceProvider(accountSAML)}
+	})
+
+	return output
+}
+
+// SaveAccountSAML function for uploading the metadata of the identity provider of an account, the metadata is
+// checked before it is saved and replaces the previous one
+func (s *CorporateUseCaseImpl) SaveAccountSAML(ctxReq context.Context, accountID string, payload model.AccountSAMLPayload, adminID string) <-chan ResultUseCase {
+	ctx := "CorporateUseCase-SaveAccountSAML"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = accountID
+		payload.Metadata = strings.TrimSpace(payload.Metadata)
+		if payload.Metadata == "" {
+			output <- ResultUseCase{Error: errors.New(model.ErrorSAMLMetadataRequired), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		idp, err := saml.ParseMetadata([]byte(payload.Metadata))
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: saml.HTTPStatus(err)}
+			return
+		}
+
+		now := time.Now()
+		accountSAML := model.AccountSAML{Created: now, CreatedBy: adminID}
+		if existing, _, err := s.findAccountSAML(ctxReq, accountID); err == nil {
+			accountSAML = existing
+		}
+		accountSAML.AccountID = accountID
+		accountSAML.EntityID = idp.EntityID
+		accountSAML.SSOURL = idp.SSOURL
+		accountSAML.Metadata = payload.Metadata
+		accountSAML.AttributeMapping = payload.AttributeMapping.WithDefaults()
+		accountSAML.JITProvisioning = payload.JITProvisioning
+		accountSAML.Enabled = payload.Enabled
+		accountSAML.Modified = now
+		accountSAML.ModifiedBy = adminID
+
+		if err := s.AccountSAMLRepo.Save(ctxReq, accountSAML); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: s.withServiceProvider(accountSAML)}
+	})
+
+	return output
+}
+
+// DeleteAccountSAML function for removing the single sign on of an account, contacts log in with password again
+func (s *CorporateUseCaseImpl) DeleteAccountSAML(ctxReq context.Context, accountID string) <-chan ResultUseCase {
+	ctx := "CorporateUseCase-DeleteAccountSAML"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = accountID
+		if _, httpStatus, err := s.findAccountSAML(ctxReq, accountID); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
+		if err := s.AccountSAMLRepo.Delete(ctxReq, accountID); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: accountID}
+	})
+
+	return output
+}
+
+func (s *CorporateUseCaseImpl) findAccountSAML(ctxReq context.Context, accountID string) (model.AccountSAML, int, error) {
+	samlResult := <-s.AccountSAMLQuery.FindByAccountID(ctxReq, accountID)
+	if samlResult.Error != nil {
+		if samlResult.Error == sql.ErrNoRows {
+			return model.AccountSAML{}, http.StatusNotFound, errors.New(model.ErrorSAMLNotConfigured)
+		}
+		return model.AccountSAML{}, http.StatusInternalServerError, samlResult.Error
+	}
+
+	accountSAML, ok := samlResult.Result.(model.AccountSAML)
+	if !ok {
+		return model.AccountSAML{}, http.StatusInternalServerError, errors.New(model.ErrorSAMLNotConfigured)
+	}
+	return accountSAML, http.StatusOK, nil
+}
+
+func (s *CorporateUseCaseImpl) withServiceProvider(accountSAML model.AccountSAML) model.AccountSAML {
+	sp := saml.NewServiceProvider(s.SAMLBaseURL, accountSAML.AccountID)
+	accountSAML.SPEntityID = sp.EntityID
+	accountSAML.SPACSURL = sp.ACSURL
+	return accountSAML
+}
@@ -19,4 +19,7 @@ type CorporateUseCase interface {
 	GetAllListContact(ctxReq context.Context, params *model.ParametersContact) <-chan ResultUseCase
 	GetDetailContact(ctxReq context.Context, id string) <-chan ResultUseCase
 	ImportContact(ctxReq context.Context, content []byte) ([]*model.ContactPayload, error)
+	GetAccountSAML(ctxReq context.Context, accountID string) <-chan ResultUseCase
+	SaveAccountSAML(ctxReq context.Context, accountID string, payload model.AccountSAMLPayload, adminID string) <-chan ResultUseCase
+	DeleteAccountSAML(ctxReq context.Context, accountID string) <-chan ResultUseCase
 }
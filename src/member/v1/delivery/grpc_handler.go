@@ -1,13 +1,13 @@
 package delivery
 
 import (
-	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -31,17 +31,17 @@ type GRPCHandler struct {
 	MemberUseCase usecase.MemberUseCase
 	QPublisher    service.QPublisher
 	Topic         string
-	PublicKey     *rsa.PublicKey
+	KeySet        *rsa.KeySet
 }
 
 // NewGRPCHandler function for initializing grpc handler object
 func NewGRPCHandler(memberUseCase usecase.MemberUseCase,
-	qPublisher service.QPublisher, topic string, publicKey *rsa.PublicKey) *GRPCHandler {
+	qPublisher service.QPublisher, topic string, keySet *rsa.KeySet) *GRPCHandler {
 	return &GRPCHandler{
 		MemberUseCase: memberUseCase,
 		QPublisher:    qPublisher,
 		Topic:         topic,
-		PublicKey:     publicKey,
+		KeySet:        keySet,
 	}
 }
 
@@ -191,7 +191,7 @@ func (h *GRPCHandler) GetMe(c context.Context, arg *pb.MemberQuery) (*pb.Member,
 		return nil, status.Error(codes.InvalidArgument, messageJwtCannotBeEmpty)
 	}
 
-	claims, err := shared.JWTExtract(h.PublicKey, accessToken)
+	claims, err := shared.JWTExtract(h.KeySet, accessToken)
 
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, err.Error())
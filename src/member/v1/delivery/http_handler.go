@@ -55,7 +55,6 @@ func (h *HTTPMemberHandler) MountMe(group *echo.Group) {
 
 // MountAdmin function for mounting admin endpoints
 func (h *HTTPMemberHandler) MountAdmin(group *echo.Group) {
-	group.POST("/member/import", h.ImportMember)
 	group.PUT("/member/regenerate-token/:memberID", h.RegenerateToken)
 	group.POST("/member", h.AddNewMember)
 	group.GET("/member", h.GetMembers)
@@ -65,6 +64,11 @@ func (h *HTTPMemberHandler) MountAdmin(group *echo.Group) {
 	// group.GET("/member/migrate_legacy/:memberID", h.MigrateLegacyData)
 }
 
+// MountImport function for mounting member import endpoint
+func (h *HTTPMemberHandler) MountImport(group *echo.Group) {
+	group.POST("/member/import", h.ImportMember)
+}
+
 // MountMember function for mounting member endpoints using for internal cms login
 func (h *HTTPMemberHandler) MountMember(group *echo.Group) {
 	group.PUT("/:memberID", h.UpdateMember)
@@ -270,6 +274,8 @@ func (h *HTTPMemberHandler) RegisterMember(c echo.Context) error {
 	member.GenderString = c.FormValue(model.FieldGender)
 	member.BirthDateString = c.FormValue(model.FieldDOB)
 	member.Mobile = c.FormValue(model.FieldMobile)
+	member.CaptchaResponse = c.FormValue(model.FieldCaptchaResponse)
+	member.IP = middleware.ClientIP(c)
 	member.Type = "register"
 
 	// check member existence first when error not happens
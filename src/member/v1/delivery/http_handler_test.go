@@ -29,6 +29,7 @@ func TestHTTPHandler(*testing.T) {
 	handler.Mount(e.Group("/"))
 	handler.MountMe(e.Group("/me"))
 	handler.MountAdmin(e.Group("/"))
+	handler.MountImport(e.Group("/"))
 	handler.MountMember(e.Group("/member"))
 }
 
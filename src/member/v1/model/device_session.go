@@ -0,0 +1,154 @@
+package model
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DeviceSessionLabelMaxLength maximum length of session label
+	DeviceSessionLabelMaxLength = 50
+
+	// deviceSessionIDSeparator separates device id and device login in the session id,
+	// device login never has it while device id may have any character
+	deviceSessionIDSeparator = ":"
+
+	// ErrorDeviceSessionNotFound error message when the session is not active or does not belong to the member
+	ErrorDeviceSessionNotFound = "session not found"
+	// ErrorDeviceSessionCurrent error message for revoking the session of the request
+	ErrorDeviceSessionCurrent = "current session cannot be revoked, please logout instead"
+	// ErrorDeviceSessionLabel error message for invalid session label
+	ErrorDeviceSessionLabel = "session label must be at most 50 characters"
+	// ErrorGetDeviceSessions error message for listing sessions
+	ErrorGetDeviceSessions = "Failed Get Sessions"
+	// ErrorIPLocationLine error message for invalid line of the ip location file
+	ErrorIPLocationLine = "invalid ip location at line %d"
+)
+
+// DeviceSession data structure of an active login session of a member on one device
+type DeviceSession struct {
+	ID          string     `json:"id"`
+	DeviceID    string     `json:"deviceId"`
+	DeviceLogin string     `json:"deviceLogin"`
+	Label       string     `json:"label"`
+	Device      string     `json:"device"`
+	IsMobile    bool       `json:"isMobile"`
+	IsApp       bool       `json:"isApp"`
+	IP          string     `json:"ip"`
+	Location    string     `json:"location"`
+	GrantType   string     `json:"grantType"`
+	LoggedInAt  *time.Time `json:"loggedInAt"`
+	LastSeen    *time.Time `json:"lastSeen"`
+	Current     bool       `json:"current"`
+}
+
+// DeviceSessionLabel data structure of the name a member gives to a session
+type DeviceSessionLabel struct {
+	MemberID    string `json:"-"`
+	DeviceID    string `json:"-"`
+	DeviceLogin string `json:"-"`
+	Label       string `json:"label"`
+}
+
+// SessionLocator interface abstraction of ip address lookup
+type SessionLocator interface {
+	Locate(ip string) string
+}
+
+// NewDeviceSessionID function for creating the id of the session of a device
+func NewDeviceSessionID(deviceID, deviceLogin string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(deviceID + deviceSessionIDSeparator + deviceLogin))
+}
+
+// ParseDeviceSessionID function for getting device id and device login from the session id
+func ParseDeviceSessionID(sessionID string) (string, string, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(sessionID)
+	if err != nil {
+		return "", "", false
+	}
+
+	index := strings.LastIndex(string(decoded), deviceSessionIDSeparator)
+	if index <= 0 || index == len(decoded)-1 {
+		return "", "", false
+	}
+	return string(decoded[:index]), string(decoded[index+1:]), true
+}
+
+// NormalizeDeviceSessionLabel function for trimming session label, returns false when the label is too long
+func NormalizeDeviceSessionLabel(label string) (string, bool) {
+	label = strings.TrimSpace(label)
+	return label, len([]rune(label)) <= DeviceSessionLabelMaxLength
+}
+
+// IPLocations approximate location of ip networks loaded from a file
+type IPLocations struct {
+	networks []ipLocation
+}
+
+type ipLocation struct {
+	network  *net.IPNet
+	location string
+}
+
+// LoadIPLocations function for reading a file of `<cidr>,<location>` lines, e.g. `36.64.0.0/11,Jakarta, ID`
+func LoadIPLocations(reader io.Reader) (*IPLocations, error) {
+	locations := &IPLocations{}
+
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		split := strings.SplitN(text, ",", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf(ErrorIPLocationLine, line)
+		}
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(split[0]))
+		if err != nil {
+			return nil, fmt.Errorf(ErrorIPLocationLine, line)
+		}
+		locations.networks = append(locations.networks, ipLocation{network: network, location: strings.TrimSpace(split[1])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// the most specific network comes first
+	sort.SliceStable(locations.networks, func(i, j int) bool {
+		sizeI, _ := locations.networks[i].network.Mask.Size()
+		sizeJ, _ := locations.networks[j].network.Mask.Size()
+		return sizeI > sizeJ
+	})
+	return locations, nil
+}
+
+// Locate function for getting the location of an ip address, empty when it is unknown
+func (l *IPLocations) Locate(ip string) string {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return ""
+	}
+
+	for _, network := range l.networks {
+		if network.network.Contains(parsed) {
+			return network.location
+		}
+	}
+	return ""
+}
+
+// Len function for getting the number of networks
+func (l *IPLocations) Len() int {
+	return len(l.networks)
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceSessionID(t *testing.T) {
+	tests := []struct {
+		name            string
+		deviceID        string
+		deviceLogin     string
+		wantDeviceID    string
+		wantDeviceLogin string
+	}{
+		{
+			name:            "Case 1: Simple device id",
+			deviceID:        "ASX1234",
+			deviceLogin:     "WEB",
+			wantDeviceID:    "ASX1234",
+			wantDeviceLogin: "WEB",
+		},
+		{
+			name:            "Case 2: Device id with separator",
+			deviceID:        "f3b1:9a2c-11eb",
+			deviceLogin:     "APP",
+			wantDeviceID:    "f3b1:9a2c-11eb",
+			wantDeviceLogin: "APP",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deviceID, deviceLogin, ok := ParseDeviceSessionID(NewDeviceSessionID(tt.deviceID, tt.deviceLogin))
+			assert.True(t, ok)
+			assert.Equal(t, tt.wantDeviceID, deviceID)
+			assert.Equal(t, tt.wantDeviceLogin, deviceLogin)
+		})
+	}
+
+	_, _, ok := ParseDeviceSessionID("not base64!")
+	assert.False(t, ok)
+	_, _, ok = ParseDeviceSessionID(NewDeviceSessionID("ASX1234", ""))
+	assert.False(t, ok)
+}
+
+func TestNormalizeDeviceSessionLabel(t *testing.T) {
+	label, ok := NormalizeDeviceSessionLabel("  Office laptop ")
+	assert.True(t, ok)
+	assert.Equal(t, "Office laptop", label)
+
+	_, ok = NormalizeDeviceSessionLabel(strings.Repeat("a", DeviceSessionLabelMaxLength+1))
+	assert.False(t, ok)
+}
+
+func TestLoadIPLocations(t *testing.T) {
+	file := "# network,location\n36.64.0.0/11,Indonesia\n36.72.0.0/16,Jakarta, ID\n2001:db8::/32,Documentation\n"
+	locations, err := LoadIPLocations(strings.NewReader(file))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, locations.Len())
+
+	tests := []struct {
+		name string
+		ip   string
+		want string
+	}{
+		{name: "Case 1: Most specific network", ip: "36.72.10.1", want: "Jakarta, ID"},
+		{name: "Case 2: Wider network", ip: "36.80.1.1", want: "Indonesia"},
+		{name: "Case 3: IPv6", ip: "2001:db8::1", want: "Documentation"},
+		{name: "Case 4: Unknown network", ip: "10.0.0.1"},
+		{name: "Case 5: Invalid ip", ip: "localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, locations.Locate(tt.ip))
+		})
+	}
+
+	_, err = LoadIPLocations(strings.NewReader("36.64.0.0/33,Indonesia\n"))
+	assert.Error(t, err)
+}
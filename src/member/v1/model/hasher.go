@@ -2,9 +2,12 @@ package model
 
 import (
 	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
 	"hash"
+	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
 )
@@ -14,13 +17,157 @@ const (
 	IterationsCount = 15000
 	// SaltSize to set salt size
 	SaltSize = 64
+
+	// PasswordAlgorithmPBKDF2SHA1 legacy algorithm, its salt has no prefix, format: `15000.<base64>`
+	PasswordAlgorithmPBKDF2SHA1 = "pbkdf2-sha1"
+	// PasswordAlgorithmArgon2id default algorithm of new passwords
+	PasswordAlgorithmArgon2id = "argon2id"
+	// PasswordAlgorithmBcrypt bcrypt algorithm
+	PasswordAlgorithmBcrypt = "bcrypt"
+	// PasswordAlgorithmNone members without password, e.g. registered from social media
+	PasswordAlgorithmNone = "none"
+
+	// PasswordSaltSeparator separates the algorithm prefix and its parameters in the salt
+	PasswordSaltSeparator = "$"
+
+	// ErrorPasswordAlgorithm error message for unknown password hashing algorithm
+	ErrorPasswordAlgorithm = "unknown password hashing algorithm %s"
 )
 
-// PasswordHasher interface abstraction
+// PasswordHasher interface abstraction of the password hashing registry,
+// new passwords use the current algorithm and stored ones are checked with the algorithm of their salt
 type PasswordHasher interface {
-	Hash(data []byte) []byte
-	ParseSalt(salt string) error
-	GenerateSalt() string
+	HashPassword(password string) (salt string, hashed string, err error)
+	VerifyPassword(password, salt, hashed string) bool
+	NeedsRehash(salt string) bool
+	Current() string
+}
+
+// PasswordAlgorithm interface abstraction of one hashing algorithm, salts it makes start with its name
+type PasswordAlgorithm interface {
+	Name() string
+	HashPassword(password string) (salt string, hashed string, err error)
+	VerifyPassword(password, salt, hashed string) bool
+	// NeedsRehash true when the salt was made with weaker parameters than the current ones
+	NeedsRehash(salt string) bool
+}
+
+// PasswordHashers registry of password hashing algorithms keyed by the prefix of the salt
+type PasswordHashers struct {
+	current    string
+	algorithms map[string]PasswordAlgorithm
+}
+
+// NewPasswordHashers function for creating the registry, current is the algorithm of new passwords
+func NewPasswordHashers(current string, algorithms ...PasswordAlgorithm) (*PasswordHashers, error) {
+	hashers := &PasswordHashers{current: current, algorithms: map[string]PasswordAlgorithm{}}
+	for _, algorithm := range algorithms {
+		hashers.algorithms[algorithm.Name()] = algorithm
+	}
+
+	if _, ok := hashers.algorithms[current]; !ok {
+		return nil, fmt.Errorf(ErrorPasswordAlgorithm, current)
+	}
+	return hashers, nil
+}
+
+// NewDefaultPasswordHashers function for creating the registry with every supported algorithm,
+// an empty current uses argon2id
+func NewDefaultPasswordHashers(current string) (*PasswordHashers, error) {
+	if current == "" {
+		current = PasswordAlgorithmArgon2id
+	}
+
+	return NewPasswordHashers(current,
+		NewPBKDF2Algorithm(IterationsCount, sha1.New),
+		NewArgon2idAlgorithm(),
+		NewBcryptAlgorithm(BcryptCost),
+	)
+}
+
+// GetPasswordAlgorithm function for getting the algorithm name of a stored salt
+func GetPasswordAlgorithm(salt string) string {
+	salt = strings.TrimSpace(salt)
+	if salt == "" {
+		return PasswordAlgorithmNone
+	}
+
+	if index := strings.Index(salt, PasswordSaltSeparator); index > 0 {
+		return salt[:index]
+	}
+	return PasswordAlgorithmPBKDF2SHA1
+}
+
+// Current function for getting the algorithm of new passwords
+func (h *PasswordHashers) Current() string {
+	return h.current
+}
+
+// HashPassword function for hashing a new password with the current algorithm
+func (h *PasswordHashers) HashPassword(password string) (string, string, error) {
+	return h.algorithms[h.current].HashPassword(password)
+}
+
+// VerifyPassword function for checking a password against the stored salt and hash
+func (h *PasswordHashers) VerifyPassword(password, salt, hashed string) bool {
+	algorithm, ok := h.algorithms[GetPasswordAlgorithm(salt)]
+	if !ok || hashed == "" {
+		return false
+	}
+	return algorithm.VerifyPassword(password, strings.TrimSpace(salt), hashed)
+}
+
+// NeedsRehash function for checking whether a stored password should be hashed again with the current algorithm
+func (h *PasswordHashers) NeedsRehash(salt string) bool {
+	name := GetPasswordAlgorithm(salt)
+	if name != h.current {
+		return name != PasswordAlgorithmNone
+	}
+	return h.algorithms[name].NeedsRehash(strings.TrimSpace(salt))
+}
+
+// PBKDF2Algorithm legacy PBKDF2 passwords
+type PBKDF2Algorithm struct {
+	iteration int
+	hash      func() hash.Hash
+}
+
+// NewPBKDF2Algorithm function for creating legacy PBKDF2 algorithm
+func NewPBKDF2Algorithm(iteration int, hash func() hash.Hash) *PBKDF2Algorithm {
+	return &PBKDF2Algorithm{iteration: iteration, hash: hash}
+}
+
+// Name of the algorithm
+func (a *PBKDF2Algorithm) Name() string {
+	return PasswordAlgorithmPBKDF2SHA1
+}
+
+// HashPassword function for hashing password with a new salt
+func (a *PBKDF2Algorithm) HashPassword(password string) (string, string, error) {
+	hasher := NewPBKDF2Hasher(SaltSize, SaltSize, a.iteration, a.hash)
+	salt := hasher.GenerateSalt()
+	if err := hasher.ParseSalt(salt); err != nil {
+		return "", "", err
+	}
+	return salt, base64.StdEncoding.EncodeToString(hasher.Hash([]byte(password))), nil
+}
+
+// VerifyPassword function for checking password
+func (a *PBKDF2Algorithm) VerifyPassword(password, salt, hashed string) bool {
+	// a new hasher for every call, the hasher keeps the parsed salt
+	hasher := NewPBKDF2Hasher(SaltSize, SaltSize, a.iteration, a.hash)
+	hasher.ParseSalt(salt)
+	encoded := base64.StdEncoding.EncodeToString(hasher.Hash([]byte(password)))
+	return subtle.ConstantTimeCompare([]byte(encoded), []byte(hashed)) == 1
+}
+
+// NeedsRehash function for checking the iteration of the salt
+func (a *PBKDF2Algorithm) NeedsRehash(salt string) bool {
+	var iteration int
+	if _, err := fmt.Sscanf(salt, "%d.", &iteration); err != nil {
+		return true
+	}
+	return iteration < a.iteration
 }
 
 // PBKDF2Hasher data structure
@@ -83,3 +230,15 @@ func (h *PBKDF2Hasher) ParseSalt(salt string) error {
 
 	return nil
 }
+
+// PasswordAlgorithmCount data structure of the number of members per password hashing algorithm
+type PasswordAlgorithmCount struct {
+	Algorithm string `json:"algorithm"`
+	Total     int    `json:"total"`
+}
+
+// PasswordHashReport data structure
+type PasswordHashReport struct {
+	Current    string                   `json:"current"`
+	Algorithms []PasswordAlgorithmCount `json:"algorithms"`
+}
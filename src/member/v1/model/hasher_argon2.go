@@ -0,0 +1,106 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// Argon2idMemory memory used by argon2id in KiB
+	Argon2idMemory = 64 * 1024
+	// Argon2idTime number of passes of argon2id
+	Argon2idTime = 3
+	// Argon2idThreads parallelism of argon2id
+	Argon2idThreads = 4
+	// Argon2idSaltSize salt size of argon2id
+	Argon2idSaltSize = 16
+	// Argon2idKeyLen hash size of argon2id
+	Argon2idKeyLen = 32
+
+	// argon2idSaltFormat format: `argon2id$v=19$m=65536,t=3,p=4$<base64 salt>`
+	argon2idSaltFormat = "argon2id$v=%d$m=%d,t=%d,p=%d$%s"
+)
+
+// Argon2idAlgorithm argon2id passwords, the parameters are kept in the salt
+type Argon2idAlgorithm struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+// NewArgon2idAlgorithm function for creating argon2id algorithm with the default parameters
+func NewArgon2idAlgorithm() *Argon2idAlgorithm {
+	return &Argon2idAlgorithm{memory: Argon2idMemory, time: Argon2idTime, threads: Argon2idThreads}
+}
+
+// Name of the algorithm
+func (a *Argon2idAlgorithm) Name() string {
+	return PasswordAlgorithmArgon2id
+}
+
+// HashPassword function for hashing password with a new salt
+func (a *Argon2idAlgorithm) HashPassword(password string) (string, string, error) {
+	salt := make([]byte, Argon2idSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", "", err
+	}
+
+	hashed := argon2.IDKey([]byte(password), salt, a.time, a.memory, a.threads, Argon2idKeyLen)
+	saltString := fmt.Sprintf(argon2idSaltFormat, argon2.Version, a.memory, a.time, a.threads,
+		base64.RawStdEncoding.EncodeToString(salt))
+	return saltString, base64.StdEncoding.EncodeToString(hashed), nil
+}
+
+// VerifyPassword function for checking password
+func (a *Argon2idAlgorithm) VerifyPassword(password, salt, hashed string) bool {
+	params, err := parseArgon2idSalt(salt)
+	if err != nil {
+		return false
+	}
+
+	expected, err := base64.StdEncoding.DecodeString(hashed)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	actual := argon2.IDKey([]byte(password), params.salt, params.time, params.memory, params.threads, uint32(len(expected)))
+	return subtle.ConstantTimeCompare(actual, expected) == 1
+}
+
+// NeedsRehash function for checking the parameters of the salt
+func (a *Argon2idAlgorithm) NeedsRehash(salt string) bool {
+	params, err := parseArgon2idSalt(salt)
+	if err != nil {
+		return true
+	}
+	return params.version != argon2.Version || params.memory < a.memory || params.time < a.time
+}
+
+type argon2idParams struct {
+	version int
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+}
+
+func parseArgon2idSalt(salt string) (argon2idParams, error) {
+	var params argon2idParams
+	var saltOnly string
+
+	_, err := fmt.Sscanf(salt, "argon2id$v=%d$m=%d,t=%d,p=%d$%s",
+		&params.version, &params.memory, &params.time, &params.threads, &saltOnly)
+	if err != nil {
+		return params, err
+	}
+
+	params.salt, err = base64.RawStdEncoding.DecodeString(saltOnly)
+	if err != nil {
+		return params, err
+	}
+	return params, nil
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// BcryptCost default cost of bcrypt
+	BcryptCost = 12
+
+	// bcryptSaltFormat bcrypt keeps its own salt in the hash, the salt column only holds the cost, format: `bcrypt$12`
+	bcryptSaltFormat = "bcrypt$%d"
+)
+
+// BcryptAlgorithm bcrypt passwords
+type BcryptAlgorithm struct {
+	cost int
+}
+
+// NewBcryptAlgorithm function for creating bcrypt algorithm
+func NewBcryptAlgorithm(cost int) *BcryptAlgorithm {
+	return &BcryptAlgorithm{cost: cost}
+}
+
+// Name of the algorithm
+func (a *BcryptAlgorithm) Name() string {
+	return PasswordAlgorithmBcrypt
+}
+
+// HashPassword function for hashing password
+func (a *BcryptAlgorithm) HashPassword(password string) (string, string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), a.cost)
+	if err != nil {
+		return "", "", err
+	}
+	return fmt.Sprintf(bcryptSaltFormat, a.cost), string(hashed), nil
+}
+
+// VerifyPassword function for checking password
+func (a *BcryptAlgorithm) VerifyPassword(password, salt, hashed string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) == nil
+}
+
+// NeedsRehash function for checking the cost of the salt
+func (a *BcryptAlgorithm) NeedsRehash(salt string) bool {
+	var cost int
+	if _, err := fmt.Sscanf(salt, bcryptSaltFormat, &cost); err != nil {
+		return true
+	}
+	return cost < a.cost
+}
@@ -37,3 +37,63 @@ func TestGenerateSalt(t *testing.T) {
 		assert.Equal(t, generated, generated)
 	})
 }
+
+func TestPasswordHashers(t *testing.T) {
+	hashers, err := NewPasswordHashers(PasswordAlgorithmArgon2id,
+		NewPBKDF2Algorithm(IterationsCount, sha1.New),
+		NewArgon2idAlgorithm(),
+		NewBcryptAlgorithm(4),
+	)
+	assert.NoError(t, err)
+
+	t.Run("new passwords use the current algorithm", func(t *testing.T) {
+		salt, hashed, err := hashers.HashPassword(TestPassPlain)
+		assert.NoError(t, err)
+		assert.Equal(t, PasswordAlgorithmArgon2id, GetPasswordAlgorithm(salt))
+		assert.True(t, hashers.VerifyPassword(TestPassPlain, salt, hashed))
+		assert.False(t, hashers.VerifyPassword("wrong", salt, hashed))
+		assert.False(t, hashers.NeedsRehash(salt))
+	})
+
+	t.Run("legacy password is verified and needs rehash", func(t *testing.T) {
+		assert.True(t, hashers.VerifyPassword(TestPassPlain, TestPassSalt, TestPassCipher))
+		assert.False(t, hashers.VerifyPassword("wrong", TestPassSalt, TestPassCipher))
+		assert.True(t, hashers.NeedsRehash(TestPassSalt))
+	})
+
+	t.Run("bcrypt", func(t *testing.T) {
+		bcryptHashers, err := NewPasswordHashers(PasswordAlgorithmBcrypt, NewBcryptAlgorithm(4), NewArgon2idAlgorithm())
+		assert.NoError(t, err)
+		salt, hashed, err := bcryptHashers.HashPassword(TestPassPlain)
+		assert.NoError(t, err)
+		assert.Equal(t, "bcrypt$4", salt)
+		assert.True(t, hashers.VerifyPassword(TestPassPlain, salt, hashed))
+		assert.True(t, hashers.NeedsRehash(salt))
+		assert.False(t, bcryptHashers.NeedsRehash(salt))
+	})
+
+	t.Run("weaker argon2id parameters need rehash", func(t *testing.T) {
+		assert.True(t, hashers.NeedsRehash("argon2id$v=19$m=4096,t=3,p=4$c2FsdA"))
+	})
+
+	t.Run("no password", func(t *testing.T) {
+		assert.False(t, hashers.VerifyPassword(TestPassPlain, "", ""))
+		assert.False(t, hashers.NeedsRehash(""))
+		assert.False(t, hashers.VerifyPassword(TestPassPlain, "md5$x", "x"))
+	})
+
+	t.Run("unknown current algorithm", func(t *testing.T) {
+		_, err := NewDefaultPasswordHashers("md5")
+		assert.Error(t, err)
+		defaults, err := NewDefaultPasswordHashers("")
+		assert.NoError(t, err)
+		assert.Equal(t, PasswordAlgorithmArgon2id, defaults.Current())
+	})
+}
+
+func TestGetPasswordAlgorithm(t *testing.T) {
+	assert.Equal(t, PasswordAlgorithmNone, GetPasswordAlgorithm(" "))
+	assert.Equal(t, PasswordAlgorithmPBKDF2SHA1, GetPasswordAlgorithm(TestPassSalt))
+	assert.Equal(t, PasswordAlgorithmArgon2id, GetPasswordAlgorithm("argon2id$v=19$m=65536,t=3,p=4$c2FsdA"))
+	assert.Equal(t, PasswordAlgorithmBcrypt, GetPasswordAlgorithm("bcrypt$12"))
+}
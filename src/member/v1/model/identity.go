@@ -0,0 +1,107 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// IdentityProviderFacebook provider of identities linked by facebook login
+	IdentityProviderFacebook = "facebook"
+	// IdentityProviderGoogle provider of identities linked by google login
+	IdentityProviderGoogle = "google"
+	// IdentityProviderApple provider of identities linked by sign in with apple
+	IdentityProviderApple = "apple"
+	// IdentityProviderAzure provider of identities linked by azure active directory login
+	IdentityProviderAzure = "azure"
+	// IdentityProviderLDAP provider of identities linked by ldap login
+	IdentityProviderLDAP = "ldap"
+
+	// ErrorIdentityNotFound error message when the identity is not linked to the member
+	ErrorIdentityNotFound = "identity not found"
+	// ErrorIdentityLinked error message when the identity is linked to another member
+	ErrorIdentityLinked = "this account is already linked to another member"
+	// ErrorIdentityProvider error message when the provider can not be linked by the member
+	ErrorIdentityProvider = "this provider cannot be linked"
+	// ErrorIdentityLastLogin error message when unlinking would leave the member without login method
+	ErrorIdentityLastLogin = "cannot unlink the last login method of your account"
+	// ErrorIdentityReauth error message when the member did not confirm the password before linking
+	ErrorIdentityReauth = "please enter your password to link another account"
+	// ErrorIdentityLinkToken error message when the link confirmation is wrong, used or expired
+	ErrorIdentityLinkToken = "link confirmation is invalid or has expired"
+
+	// IdentityLinkKeyRedis redis key prefix of a link confirmation sent by email, format: `IDENTITY-LINK:abc`
+	IdentityLinkKeyRedis = "IDENTITY-LINK"
+	// IdentityLinkAge lifetime of a link confirmation
+	IdentityLinkAge = 24 * time.Hour
+	// IdentityLinkPath page of the web that confirms a link with the token of the email
+	IdentityLinkPath = "/account/link"
+)
+
+// Identity data structure of an identity provider account linked to a member,
+// the provider and subject pair identifies the account at most once
+type Identity struct {
+	ID       string     `json:"id"`
+	MemberID string     `json:"-"`
+	Provider string     `json:"provider"`
+	Subject  string     `json:"-"`
+	Email    string     `json:"email"`
+	Created  time.Time  `json:"created"`
+	LastUsed *time.Time `json:"lastUsed,omitempty"`
+}
+
+// IdentityProviderNames name of the providers shown to the member
+var IdentityProviderNames = map[string]string{
+	IdentityProviderFacebook: "Facebook",
+	IdentityProviderGoogle:   "Google",
+	IdentityProviderApple:    "Apple",
+	IdentityProviderAzure:    "Microsoft",
+}
+
+// IdentityLink data structure of a provider account waiting for the member to confirm the link by email
+type IdentityLink struct {
+	MemberID string `json:"memberId"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Email    string `json:"email"`
+}
+
+// IdentityLinkRequest data structure of a member linking the account of an oidc connector, the code and state
+// come from the authorization of the connector. Members with a password confirm it, other members confirm
+// a code sent to their email, the first request without otp sends it
+type IdentityLinkRequest struct {
+	Provider    string `json:"provider" form:"provider"`
+	Code        string `json:"code" form:"code"`
+	State       string `json:"state" form:"state"`
+	RedirectURI string `json:"redirectUri" form:"redirectUri"`
+	Password    string `json:"password,omitempty" form:"password"`
+	OTP         string `json:"otp,omitempty" form:"otp"`
+}
+
+// IdentityLinkConfirmation data structure of the token sent by email to confirm a link
+type IdentityLinkConfirmation struct {
+	Token string `json:"token" form:"token"`
+}
+
+// GetIdentityLinkKey redis key format: `IDENTITY-LINK:abc`
+func GetIdentityLinkKey(token string) string {
+	return strings.Join([]string{IdentityLinkKeyRedis, token}, ":")
+}
+
+// Subject function for getting the id of a provider kept in the social media columns
+// of the member, empty when the provider has no column
+func (s SocialMedia) Subject(provider string) string {
+	switch provider {
+	case IdentityProviderFacebook:
+		return s.FacebookID
+	case IdentityProviderGoogle:
+		return s.GoogleID
+	case IdentityProviderApple:
+		return s.AppleID
+	case IdentityProviderAzure:
+		return s.AzureID
+	case IdentityProviderLDAP:
+		return s.LDAPID
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// MFAMethodTOTP second factor using authenticator app, kept in member table
+	MFAMethodTOTP = "totp"
+
+	// MFAMethodEmail second factor using one time code sent by email
+	MFAMethodEmail = "email"
+
+	// MFAMethodSMS second factor using one time code sent by sms
+	MFAMethodSMS = "sms"
+
+	// MFAOTPCodeKeyRedis redis key prefix of sent code, format: `mfa-code-email-USR123-<scope>`
+	MFAOTPCodeKeyRedis = "mfa-code"
+
+	// MFAOTPAttemptKeyRedis redis key prefix of wrong code counter, format: `mfa-attempt-email-USR123-<scope>`
+	MFAOTPAttemptKeyRedis = "mfa-attempt"
+
+	// MFAOTPResendKeyRedis redis key prefix of sent code counter, format: `mfa-resend-email-USR123`
+	MFAOTPResendKeyRedis = "mfa-resend"
+
+	// MFAOTPScopeEnrol scope of the code sent when the member enrols a method
+	MFAOTPScopeEnrol = "enrol"
+	// MFAOTPScopeIdentity scope of the code sent when a member without password links another account
+	MFAOTPScopeIdentity = "identity"
+
+	// SubjectMFAOTP email subject of the one time code
+	SubjectMFAOTP = "Kode Verifikasi Bhinneka"
+
+	// ContentMFAOTPEmail email content of the one time code
+	ContentMFAOTPEmail = "<p>Kode verifikasi Anda adalah <b>%s</b>.</p><p>Kode berlaku selama %d menit, jangan berikan kode ini kepada siapa pun.</p>"
+
+	// ContentMFAOTPSMS sms content of the one time code
+	ContentMFAOTPSMS = "Kode verifikasi Bhinneka: %s. Berlaku %d menit, jangan berikan kode ini kepada siapa pun."
+
+	// ErrorMFAMethod error message for unknown method
+	ErrorMFAMethod = "MFA method is invalid"
+
+	// ErrorMFAMethodNotEnrolled error message for method the member has not enrolled
+	ErrorMFAMethodNotEnrolled = "MFA method is not enrolled"
+
+	// ErrorMFAOTPResendLimit error message when too many codes have been sent
+	ErrorMFAOTPResendLimit = "Too many verification codes sent, please try again later"
+
+	// ErrorMFAOTPAttempt error message when the code has been guessed too many times
+	ErrorMFAOTPAttempt = "Too many wrong verification codes, please request a new code"
+
+	// ErrorMFAOTPSend error message when the code can not be delivered
+	ErrorMFAOTPSend = "Failed to send verification code"
+
+	// SuccessMFAOTPSend success message of sent code
+	SuccessMFAOTPSend = "Verification code has been sent"
+)
+
+// MFAOTPPolicy limits of a one time code method, sms costs money so it is stricter
+type MFAOTPPolicy struct {
+	CodeLength   int
+	CodeAge      time.Duration
+	ResendLimit  int
+	ResendWindow time.Duration
+	MaxAttempts  int
+}
+
+// MFAOTPPolicies policy of each one time code method
+var MFAOTPPolicies = map[string]MFAOTPPolicy{
+	MFAMethodEmail: {
+		CodeLength:   6,
+		CodeAge:      10 * time.Minute,
+		ResendLimit:  5,
+		ResendWindow: time.Hour,
+		MaxAttempts:  5,
+	},
+	MFAMethodSMS: {
+		CodeLength:   6,
+		CodeAge:      5 * time.Minute,
+		ResendLimit:  3,
+		ResendWindow: time.Hour,
+		MaxAttempts:  3,
+	},
+}
+
+// MFAMethod data structure of one time code method enrolled by member
+type MFAMethod struct {
+	MemberID   string    `json:"-"`
+	Method     string    `json:"method"`
+	Target     string    `json:"-"`
+	TargetMask string    `json:"target"`
+	IsDefault  bool      `json:"isDefault"`
+	Created    time.Time `json:"created"`
+}
+
+// MFAMethodEnrolment data structure for enrolling email or sms method
+type MFAMethodEnrolment struct {
+	Method string `json:"method" form:"method"`
+	Target string `json:"target" form:"target"`
+	OTP    string `json:"otp,omitempty" form:"otp"`
+}
+
+// MFADefaultMethod data structure for choosing the method asked first at login
+type MFADefaultMethod struct {
+	Method string `json:"method" form:"method"`
+}
+
+// IsMFAOTPMethod function for checking method sending one time code
+func IsMFAOTPMethod(method string) bool {
+	_, ok := MFAOTPPolicies[method]
+	return ok
+}
+
+// DefaultMFAMethod function for getting the method asked first at login,
+// the enrolled method marked as default wins, otherwise totp when it is enabled
+func DefaultMFAMethod(totpEnabled bool, methods []MFAMethod) string {
+	for _, method := range methods {
+		if method.IsDefault {
+			return method.Method
+		}
+	}
+	if totpEnabled {
+		return MFAMethodTOTP
+	}
+	return ""
+}
+
+// GenerateMFAOTP function for generating numeric one time code
+func GenerateMFAOTP(length int) (string, error) {
+	var code strings.Builder
+	for i := 0; i < length; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code.WriteString(digit.String())
+	}
+	return code.String(), nil
+}
+
+// MaskMFATarget function for hiding most of email or phone number, `j***@bhinneka.com` or `*******7890`
+func MaskMFATarget(method, target string) string {
+	if method == MFAMethodEmail {
+		at := strings.LastIndex(target, "@")
+		if at < 1 {
+			return strings.Repeat("*", len(target))
+		}
+		return target[:1] + strings.Repeat("*", at-1) + target[at:]
+	}
+
+	visible := 4
+	if len(target) <= visible {
+		return strings.Repeat("*", len(target))
+	}
+	return strings.Repeat("*", len(target)-visible) + target[len(target)-visible:]
+}
+
+// GetMFAOTPCodeKey redis key format: `mfa-code-email-USR123-<scope>`
+func GetMFAOTPCodeKey(method, memberID, scope string) string {
+	return strings.Join([]string{MFAOTPCodeKeyRedis, method, memberID, scope}, "-")
+}
+
+// GetMFAOTPAttemptKey redis key format: `mfa-attempt-email-USR123-<scope>`
+func GetMFAOTPAttemptKey(method, memberID, scope string) string {
+	return strings.Join([]string{MFAOTPAttemptKeyRedis, method, memberID, scope}, "-")
+}
+
+// GetMFAOTPResendKey redis key format: `mfa-resend-email-USR123`, shared by every scope
+// so enrolment can not be used to send unlimited codes
+func GetMFAOTPResendKey(method, memberID string) string {
+	return strings.Join([]string{MFAOTPResendKeyRedis, method, memberID}, "-")
+}
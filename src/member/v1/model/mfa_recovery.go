@@ -0,0 +1,75 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	// MFARecoveryCodeCount number of recovery codes given to a member
+	MFARecoveryCodeCount = 10
+
+	// MFARecoveryCodeLength length of recovery code without separator
+	MFARecoveryCodeLength = 10
+
+	// MFATrustedDeviceKeyRedis redis key prefix of trusted device, format: `mfa-trusted-USR123-ABC`
+	MFATrustedDeviceKeyRedis = "mfa-trusted"
+
+	// MFATrustedDeviceAge how long a device skips the second factor after being trusted
+	MFATrustedDeviceAge = 30 * 24 * time.Hour
+
+	// ErrorMFARecoveryCode error message for generating recovery codes
+	ErrorMFARecoveryCode = "Failed to generate recovery codes"
+
+	// ErrorMFANotEnabled error message when the member has not activated MFA
+	ErrorMFANotEnabled = "Multi Factor Authentication is not enabled"
+)
+
+// recovery codes are lower case base32 without padding, `abcde-fghij`
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// MFARecoveryCodes data structure of newly generated recovery codes, only shown once
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// GenerateMFARecoveryCodes function for generating one time recovery codes
+func GenerateMFARecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, MFARecoveryCodeCount)
+	for len(codes) < MFARecoveryCodeCount {
+		// 5 random bytes give 8 characters, 7 bytes are enough for 10
+		random := make([]byte, 7)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+
+		code := recoveryCodeEncoding.EncodeToString(random)[:MFARecoveryCodeLength]
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+// IsMFARecoveryCode function for telling a recovery code apart from an otp
+func IsMFARecoveryCode(code string) bool {
+	return len(normalizeMFARecoveryCode(code)) == MFARecoveryCodeLength
+}
+
+// HashMFARecoveryCode function for hashing recovery code before it is stored,
+// the code is random so a plain digest is enough
+func HashMFARecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeMFARecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeMFARecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
+
+// GetMFATrustedDeviceKey redis key format: `mfa-trusted-USR123-ABC`
+func GetMFATrustedDeviceKey(memberID, deviceID string) string {
+	return strings.Join([]string{MFATrustedDeviceKeyRedis, memberID, deviceID}, "-")
+}
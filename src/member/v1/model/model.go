@@ -152,6 +152,7 @@ const (
 	FieldRePassword      = "rePassword"
 	FieldPassword        = "password"
 	FieldStatus          = "status"
+	FieldCaptchaResponse = "captchaResponse"
 )
 
 var (
@@ -290,6 +291,8 @@ type Member struct {
 	AdminMFAEnabled            bool        `jsonapi:"attr,mfaAdminEnabled" json:"mfaAdminEnabled"  fieldname:"mfaAdminEnabled"`
 	MFAAdminKey                string      `jsonapi:"attr,mfaAdminKey" json:"mfaAdminKey"  fieldname:"mfaAdminKey"`
 	IsSync                     bool        `jsonapi:"attr,isSync" json:"isSync"  fieldname:"isSync"`
+	CaptchaResponse            string      `json:"captchaResponse,omitempty" form:"captchaResponse"`
+	IP                         string      `json:"-"`
 }
 
 // SocialMedia data structure
@@ -510,9 +513,11 @@ type ResendActivationAttempt struct {
 
 // MFASettings data structure
 type MFASettings struct {
-	MfaEnabled           bool      `json:"mfaEnabled"`
-	LastMfaEnabled       time.Time `json:"-"`
-	LastMfaEnabledString string    `json:"lastMfaEnabled"  fieldname:"lastMfaEnabled"`
+	MfaEnabled           bool        `json:"mfaEnabled"`
+	LastMfaEnabled       time.Time   `json:"-"`
+	LastMfaEnabledString string      `json:"lastMfaEnabled"  fieldname:"lastMfaEnabled"`
+	Methods              []MFAMethod `json:"methods"`
+	DefaultMethod        string      `json:"defaultMethod"`
 }
 
 // MFAAdminSettings specific for narwhal
@@ -535,6 +540,8 @@ type MFAActivateSettings struct {
 	Otp           string `json:"otp"`
 	Password      string `json:"password,omitempty"`
 	RequestFrom   string
+	// RecoveryCodes only filled once, right after activation
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
 }
 
 // SessionInfoDetail data structure
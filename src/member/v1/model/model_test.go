@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,3 +128,44 @@ func TestStatus(t *testing.T) {
 	getDolphinString4 := genderString4.GetDolpinGender()
 	assert.EqualValues(t, "S", getDolphinString4)
 }
+
+func TestMFARecoveryCode(t *testing.T) {
+	codes, err := GenerateMFARecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, MFARecoveryCodeCount)
+
+	for _, code := range codes {
+		assert.Len(t, code, MFARecoveryCodeLength+1)
+		assert.True(t, IsMFARecoveryCode(code))
+		assert.Equal(t, HashMFARecoveryCode(code), HashMFARecoveryCode(" "+strings.ToUpper(code)))
+		assert.Equal(t, HashMFARecoveryCode(code), HashMFARecoveryCode(strings.Replace(code, "-", "", 1)))
+	}
+
+	assert.NotEqual(t, codes[0], codes[1])
+	assert.False(t, IsMFARecoveryCode("123456"))
+	assert.Equal(t, "mfa-trusted-USR123-ABC", GetMFATrustedDeviceKey("USR123", "ABC"))
+}
+
+func TestMFAMethod(t *testing.T) {
+	code, err := GenerateMFAOTP(6)
+	assert.NoError(t, err)
+	assert.Len(t, code, 6)
+	assert.Equal(t, "", strings.Trim(code, "0123456789"))
+
+	assert.True(t, IsMFAOTPMethod(MFAMethodEmail))
+	assert.True(t, IsMFAOTPMethod(MFAMethodSMS))
+	assert.False(t, IsMFAOTPMethod(MFAMethodTOTP))
+
+	assert.Equal(t, "j***@bhinneka.com", MaskMFATarget(MFAMethodEmail, "john@bhinneka.com"))
+	assert.Equal(t, "*******6789", MaskMFATarget(MFAMethodSMS, "08123456789"))
+	assert.Equal(t, "***", MaskMFATarget(MFAMethodSMS, "081"))
+	assert.Equal(t, "mfa-code-sms-USR123-enrol", GetMFAOTPCodeKey(MFAMethodSMS, "USR123", MFAOTPScopeEnrol))
+	assert.Equal(t, "mfa-resend-email-USR123", GetMFAOTPResendKey(MFAMethodEmail, "USR123"))
+}
+
+func TestDefaultMFAMethod(t *testing.T) {
+	methods := []MFAMethod{{Method: MFAMethodEmail}, {Method: MFAMethodSMS, IsDefault: true}}
+	assert.Equal(t, MFAMethodSMS, DefaultMFAMethod(true, methods))
+	assert.Equal(t, MFAMethodTOTP, DefaultMFAMethod(true, methods[:1]))
+	assert.Equal(t, "", DefaultMFAMethod(false, methods[:1]))
+}
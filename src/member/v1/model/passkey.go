@@ -0,0 +1,94 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
+)
+
+const (
+	// PasskeyRegistrationKeyRedis redis key prefix of registration challenge, format: `passkey-reg-USR123`
+	PasskeyRegistrationKeyRedis = "passkey-reg"
+
+	// PasskeyDefaultName name given to a passkey registered without one
+	PasskeyDefaultName = "Passkey"
+
+	// PasskeyNameMaxLength maximum length of passkey name
+	PasskeyNameMaxLength = 100
+
+	// PasskeyMaxPerMember maximum number of passkeys a member can register
+	PasskeyMaxPerMember = 10
+
+	// ErrorPasskeyNotFound error message when the passkey does not belong to the member
+	ErrorPasskeyNotFound = "passkey not found"
+
+	// ErrorPasskeyChallenge error message when the registration challenge is missing or expired
+	ErrorPasskeyChallenge = "passkey challenge has expired, please try again"
+
+	// ErrorPasskeyRegistration error message when the authenticator response cannot be verified
+	ErrorPasskeyRegistration = "Failed to register passkey"
+
+	// ErrorPasskeyRegistered error message when the authenticator is already registered
+	ErrorPasskeyRegistered = "passkey is already registered"
+
+	// ErrorPasskeyLimit error message when the member has too many passkeys
+	ErrorPasskeyLimit = "maximum number of passkeys reached"
+
+	// ErrorPasskeyName error message for invalid passkey name
+	ErrorPasskeyName = "passkey name must be at most 100 characters"
+)
+
+// Passkey data structure of a webauthn credential registered by a member
+type Passkey struct {
+	ID           string     `json:"id"`
+	MemberID     string     `json:"-"`
+	CredentialID string     `json:"credentialId"`
+	PublicKey    []byte     `json:"-"`
+	Algorithm    int        `json:"-"`
+	SignCount    uint32     `json:"-"`
+	AAGUID       string     `json:"aaguid,omitempty"`
+	Transports   []string   `json:"transports,omitempty"`
+	Name         string     `json:"name"`
+	Created      time.Time  `json:"created"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	LastUsed     *time.Time `json:"lastUsed,omitempty"`
+}
+
+// Descriptor function for describing the passkey in webauthn options
+func (p Passkey) Descriptor() webauthn.CredentialDescriptor {
+	return webauthn.CredentialDescriptor{
+		Type:       webauthn.CredentialTypePublicKey,
+		ID:         p.CredentialID,
+		Transports: p.Transports,
+	}
+}
+
+// PasskeyDescriptors function for describing passkeys in webauthn options
+func PasskeyDescriptors(passkeys []Passkey) []webauthn.CredentialDescriptor {
+	descriptors := make([]webauthn.CredentialDescriptor, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		descriptors = append(descriptors, passkey.Descriptor())
+	}
+	return descriptors
+}
+
+// PasskeyRegistration data structure of the registration ceremony result
+type PasskeyRegistration struct {
+	Name       string                       `json:"name"`
+	Credential webauthn.AttestationResponse `json:"credential"`
+}
+
+// PasskeyRename data structure
+type PasskeyRename struct {
+	Name string `json:"name"`
+}
+
+// NormalizePasskeyName function for trimming passkey name, returns false when the name is too long
+func NormalizePasskeyName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return PasskeyDefaultName, true
+	}
+	return name, len([]rune(name)) <= PasskeyNameMaxLength
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// BreachedHashPrefixLength length of the sha1 prefix used as bucket, same as the k-anonymity range API
+	BreachedHashPrefixLength = 5
+
+	// ErrorBreachedPasswordLine error message for invalid line of the breached password file
+	ErrorBreachedPasswordLine = "invalid breached password hash at line %d"
+)
+
+// BreachedPasswordCorpus breached passwords loaded from a sha1 hash file,
+// hashes are kept in buckets of their prefix and only the suffix is compared
+type BreachedPasswordCorpus struct {
+	buckets map[string][]string
+	total   int
+}
+
+// LoadBreachedPasswordCorpus function for reading a file of uppercase or lowercase sha1 hashes,
+// one per line with an optional `:count` like the downloadable pwned passwords file
+func LoadBreachedPasswordCorpus(reader io.Reader) (*BreachedPasswordCorpus, error) {
+	corpus := &BreachedPasswordCorpus{buckets: map[string][]string{}}
+
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		hash := strings.TrimSpace(scanner.Text())
+		if index := strings.Index(hash, ":"); index >= 0 {
+			hash = hash[:index]
+		}
+		if hash == "" || strings.HasPrefix(hash, "#") {
+			continue
+		}
+
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf(ErrorBreachedPasswordLine, line)
+		}
+
+		hash = strings.ToUpper(hash)
+		prefix := hash[:BreachedHashPrefixLength]
+		corpus.buckets[prefix] = append(corpus.buckets[prefix], hash[BreachedHashPrefixLength:])
+		corpus.total++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for prefix := range corpus.buckets {
+		sort.Strings(corpus.buckets[prefix])
+	}
+	return corpus, nil
+}
+
+// IsBreached function for checking whether the password is in the corpus
+func (c *BreachedPasswordCorpus) IsBreached(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes := c.buckets[hash[:BreachedHashPrefixLength]]
+	suffix := hash[BreachedHashPrefixLength:]
+	index := sort.SearchStrings(suffixes, suffix)
+	return index < len(suffixes) && suffixes[index] == suffix
+}
+
+// Len function for getting the number of hashes in the corpus
+func (c *BreachedPasswordCorpus) Len() int {
+	return c.total
+}
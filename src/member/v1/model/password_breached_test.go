@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBreachedPasswordCorpus(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		password string
+		wantLen  int
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "Case 1: Password in corpus",
+			// sha1 of `password` and `123456`
+			file:     "# pwned passwords\n5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\n7c4a8d09ca3762af61e59520943dc26494f8941b\n",
+			password: "password",
+			wantLen:  2,
+			want:     true,
+		},
+		{
+			name:     "Case 2: Password not in corpus",
+			file:     "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8\n",
+			password: "Bhinneka123!",
+			wantLen:  1,
+		},
+		{
+			name:    "Case 3: Invalid hash",
+			file:    "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8\nnot-a-hash\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			corpus, err := LoadBreachedPasswordCorpus(strings.NewReader(tt.file))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLen, corpus.Len())
+			assert.Equal(t, tt.want, corpus.IsBreached(tt.password))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// PasswordRuleMinLength password is shorter than the policy
+	PasswordRuleMinLength = "minLength"
+	// PasswordRuleMaxLength password is longer than the policy
+	PasswordRuleMaxLength = "maxLength"
+	// PasswordRuleUppercase password has no capital letter
+	PasswordRuleUppercase = "uppercase"
+	// PasswordRuleLowercase password has no lowercase letter
+	PasswordRuleLowercase = "lowercase"
+	// PasswordRuleNumber password has no number
+	PasswordRuleNumber = "number"
+	// PasswordRuleSymbol password has no special character
+	PasswordRuleSymbol = "symbol"
+	// PasswordRuleHistory password is one of the last passwords of the member
+	PasswordRuleHistory = "history"
+	// PasswordRuleBreached password is found in the breached password corpus
+	PasswordRuleBreached = "breached"
+
+	// ErrorPasswordPolicy error message for invalid password policy configuration
+	ErrorPasswordPolicy = "invalid password policy: %s"
+	// ErrorPasswordHistory error message for reusing one of the last passwords
+	ErrorPasswordHistory = "password cannot be the same as the last %d passwords"
+	// ErrorPasswordBreached error message for password found in a data breach
+	ErrorPasswordBreached = "password has been found in a data breach, please use another password"
+)
+
+// DefaultPasswordPolicy policy used when PASSWORD_POLICY is not set, same rules as helper.ValidatePassword
+// with a history of the last 3 passwords
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:        8,
+	MaxLength:        72,
+	RequireUppercase: true,
+	RequireLowercase: true,
+	RequireNumber:    true,
+	RequireSymbol:    true,
+	History:          3,
+	CheckBreached:    true,
+}
+
+// PasswordPolicy data structure of the rules of a new password,
+// History counts the current password, 0 turns the history check off
+type PasswordPolicy struct {
+	MinLength        int  `json:"minLength"`
+	MaxLength        int  `json:"maxLength"`
+	RequireUppercase bool `json:"requireUppercase"`
+	RequireLowercase bool `json:"requireLowercase"`
+	RequireNumber    bool `json:"requireNumber"`
+	RequireSymbol    bool `json:"requireSymbol"`
+	History          int  `json:"history"`
+	CheckBreached    bool `json:"checkBreached"`
+}
+
+// PasswordPolicyError error of a password breaking one or more rules, one entry per rule
+type PasswordPolicyError struct {
+	Violations []MemberError
+}
+
+// PasswordHistory data structure of a previous password of a member
+type PasswordHistory struct {
+	MemberID string `json:"memberId"`
+	Salt     string `json:"-"`
+	Password string `json:"-"`
+}
+
+// BreachedPasswordChecker interface abstraction of a breached password corpus
+type BreachedPasswordChecker interface {
+	IsBreached(password string) bool
+}
+
+// ParsePasswordPolicy function for reading the policy from json, missing fields keep the default value
+func ParsePasswordPolicy(raw string) (PasswordPolicy, error) {
+	policy := DefaultPasswordPolicy
+	if strings.TrimSpace(raw) == "" {
+		return policy, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &policy); err != nil {
+		return policy, fmt.Errorf(ErrorPasswordPolicy, err.Error())
+	}
+
+	switch {
+	case policy.MinLength <= 0:
+		return policy, fmt.Errorf(ErrorPasswordPolicy, "minLength must be greater than 0")
+	case policy.MaxLength > 0 && policy.MaxLength < policy.MinLength:
+		return policy, fmt.Errorf(ErrorPasswordPolicy, "maxLength must not be less than minLength")
+	case policy.History < 0:
+		return policy, fmt.Errorf(ErrorPasswordPolicy, "history must not be negative")
+	}
+	return policy, nil
+}
+
+// Validate function for checking the length and character rules of a password
+func (p PasswordPolicy) Validate(password string) []MemberError {
+	var violations []MemberError
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		violations = append(violations, MemberError{ID: PasswordRuleMinLength, Message: fmt.Sprintf("password cannot less than %d characters", p.MinLength)})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, MemberError{ID: PasswordRuleMaxLength, Message: fmt.Sprintf("password cannot more than %d characters", p.MaxLength)})
+	}
+
+	var uppercase, lowercase, number, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			uppercase = true
+		case unicode.IsLower(r):
+			lowercase = true
+		case unicode.IsDigit(r):
+			number = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	if p.RequireUppercase && !uppercase {
+		violations = append(violations, MemberError{ID: PasswordRuleUppercase, Message: "password contains at least 1 capital letter"})
+	}
+	if p.RequireLowercase && !lowercase {
+		violations = append(violations, MemberError{ID: PasswordRuleLowercase, Message: "password contains at least 1 lowercase letter"})
+	}
+	if p.RequireNumber && !number {
+		violations = append(violations, MemberError{ID: PasswordRuleNumber, Message: "password contains at least 1 numeric"})
+	}
+	if p.RequireSymbol && !symbol {
+		violations = append(violations, MemberError{ID: PasswordRuleSymbol, Message: "password contains at least 1 special character"})
+	}
+	return violations
+}
+
+// NewPasswordPolicyError function for creating the error of violated rules, nil when there is none
+func NewPasswordPolicyError(violations []MemberError) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PasswordPolicyError{Violations: violations}
+}
+
+// Error messages of every violated rule
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return strings.Join(messages, ", ")
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantIDs  []string
+	}{
+		{
+			name:     "Case 1: Valid password",
+			policy:   DefaultPasswordPolicy,
+			password: "Bhinneka123!",
+		},
+		{
+			name:     "Case 2: Too short without symbol",
+			policy:   DefaultPasswordPolicy,
+			password: "Bhin1",
+			wantIDs:  []string{PasswordRuleMinLength, PasswordRuleSymbol},
+		},
+		{
+			name:     "Case 3: Lowercase only",
+			policy:   DefaultPasswordPolicy,
+			password: "bhinnekaaa",
+			wantIDs:  []string{PasswordRuleUppercase, PasswordRuleNumber, PasswordRuleSymbol},
+		},
+		{
+			name:     "Case 4: Too long",
+			policy:   DefaultPasswordPolicy,
+			password: "Bhinneka123!" + strings.Repeat("a", 72),
+			wantIDs:  []string{PasswordRuleMaxLength},
+		},
+		{
+			name:     "Case 5: Relaxed policy",
+			policy:   PasswordPolicy{MinLength: 6},
+			password: "bhinneka",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for _, violation := range tt.policy.Validate(tt.password) {
+				ids = append(ids, violation.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestParsePasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    PasswordPolicy
+		wantErr bool
+	}{
+		{
+			name: "Case 1: Empty uses the default",
+			raw:  "",
+			want: DefaultPasswordPolicy,
+		},
+		{
+			name: "Case 2: Override some fields",
+			raw:  `{"minLength":12,"requireSymbol":false,"history":5}`,
+			want: PasswordPolicy{MinLength: 12, MaxLength: 72, RequireUppercase: true, RequireLowercase: true,
+				RequireNumber: true, History: 5, CheckBreached: true},
+		},
+		{
+			name:    "Case 3: Invalid json",
+			raw:     `{"minLength":`,
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: Max length less than min length",
+			raw:     `{"minLength":12,"maxLength":10}`,
+			wantErr: true,
+		},
+		{
+			name:    "Case 5: Negative history",
+			raw:     `{"history":-1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePasswordPolicy(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewPasswordPolicyError(t *testing.T) {
+	assert.NoError(t, NewPasswordPolicyError(nil))
+
+	err := NewPasswordPolicyError([]MemberError{
+		{ID: PasswordRuleUppercase, Message: "first"},
+		{ID: PasswordRuleNumber, Message: "second"},
+	})
+	assert.EqualError(t, err, "first, second")
+}
@@ -824,3 +824,47 @@ func (mq *MemberQueryPostgres) UpdateLastTokenAttempt(ctxReq context.Context, em
 
 	return output
 }
+
+// CountPasswordAlgorithms function for counting members per password hashing algorithm,
+// the algorithm is read from the prefix of the salt like model.GetPasswordAlgorithm
+func (mq *MemberQueryPostgres) CountPasswordAlgorithms(ctxReq context.Context) <-chan ResultQuery {
+	ctx := "MemberQuery-CountPasswordAlgorithms"
+
+	output := make(chan ResultQuery)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		sq := `SELECT algorithm, count(id) FROM (
+				SELECT id, CASE
+					WHEN TRIM(COALESCE(salt, '')) = '' THEN $1
+					WHEN POSITION('$' IN TRIM(salt)) > 1 THEN SPLIT_PART(TRIM(salt), '$', 1)
+					ELSE $2
+				END AS algorithm FROM member
+			) AS member_algorithm GROUP BY algorithm ORDER BY algorithm`
+
+		tags[helper.TextQuery] = sq
+		rows, err := mq.db.QueryContext(ctxReq, sq, model.PasswordAlgorithmNone, model.PasswordAlgorithmPBKDF2SHA1)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, nil)
+			output <- ResultQuery{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		counts := []model.PasswordAlgorithmCount{}
+		for rows.Next() {
+			var count model.PasswordAlgorithmCount
+			if err := rows.Scan(&count.Algorithm, &count.Total); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "scan_password_algorithm", err, nil)
+				output <- ResultQuery{Error: err}
+				return
+			}
+			counts = append(counts, count)
+		}
+
+		tags[helper.TextResponse] = counts
+		output <- ResultQuery{Result: counts}
+	})
+
+	return output
+}
@@ -28,6 +28,22 @@ func (_m *MemberQuery) BulkFindByEmail(ctxReq context.Context, emails []string)
 	return r0
 }
 
+// CountPasswordAlgorithms provides a mock function with given fields: ctxReq
+func (_m *MemberQuery) CountPasswordAlgorithms(ctxReq context.Context) <-chan query.ResultQuery {
+	ret := _m.Called(ctxReq)
+
+	var r0 <-chan query.ResultQuery
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan query.ResultQuery); ok {
+		r0 = rf(ctxReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan query.ResultQuery)
+		}
+	}
+
+	return r0
+}
+
 // FindByEmail provides a mock function with given fields: ctxReq, email
 func (_m *MemberQuery) FindByEmail(ctxReq context.Context, email string) <-chan query.ResultQuery {
 	ret := _m.Called(ctxReq, email)
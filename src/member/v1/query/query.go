@@ -25,6 +25,7 @@ type MemberQuery interface {
 	GetTotalMembers(params *model.Parameters) <-chan ResultQuery
 	UpdateLastTokenAttempt(ctxReq context.Context, email string) <-chan ResultQuery
 	BulkFindByEmail(ctxReq context.Context, emails []string) <-chan ResultQuery
+	CountPasswordAlgorithms(ctxReq context.Context) <-chan ResultQuery
 }
 
 // MemberMFAQuery interface abstraction
@@ -0,0 +1,194 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+	"github.com/lib/pq"
+)
+
+const identityColumns = `id, "memberId", provider, subject, email, created, "lastUsed"`
+
+// MemberIdentityRepoPostgres data structure
+type MemberIdentityRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMemberIdentityRepoPostgres function for initializing member identity repo
+func NewMemberIdentityRepoPostgres(repo *repository.Repository) *MemberIdentityRepoPostgres {
+	return &MemberIdentityRepoPostgres{repo}
+}
+
+// Save function for linking identity to a member, fails when the provider subject is linked already
+func (mr *MemberIdentityRepoPostgres) Save(ctxReq context.Context, identity *model.Identity) <-chan ResultRepository {
+	ctx := "MemberIdentityRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `INSERT INTO member_identities (id, "memberId", provider, subject, email, created)
+				VALUES ($1, $2, $3, $4, $5, $6)`
+		tags[helper.TextQuery] = query
+		stmt, err := mr.WriteDB.Prepare(query)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, identity.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		_, err = stmt.Exec(identity.ID, identity.MemberID, identity.Provider, identity.Subject, identity.Email, identity.Created)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, identity.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: identity}
+	})
+
+	return output
+}
+
+// FindByMemberID function for getting identities linked to a member, oldest first
+func (mr *MemberIdentityRepoPostgres) FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository {
+	ctx := "MemberIdentityRepo-FindByMemberID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + identityColumns + ` FROM member_identities WHERE "memberId" = $1 ORDER BY created`
+		tags[helper.TextQuery] = query
+		rows, err := mr.ReadDB.Query(query, memberID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		identities := []model.Identity{}
+		for rows.Next() {
+			identity, err := scanIdentity(rows)
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+				output <- ResultRepository{Error: err}
+				return
+			}
+			identities = append(identities, identity)
+		}
+
+		output <- ResultRepository{Result: identities}
+	})
+
+	return output
+}
+
+// FindByProviderSubject function for getting the identity of a provider account, sql.ErrNoRows when it is not linked
+func (mr *MemberIdentityRepoPostgres) FindByProviderSubject(ctxReq context.Context, provider, subject string) <-chan ResultRepository {
+	ctx := "MemberIdentityRepo-FindByProviderSubject"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + identityColumns + ` FROM member_identities WHERE provider = $1 AND subject = $2`
+		tags[helper.TextQuery] = query
+		identity, err := scanIdentity(mr.ReadDB.QueryRow(query, provider, subject))
+		if err != nil {
+			if err != sql.ErrNoRows {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, provider)
+			}
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: identity}
+	})
+
+	return output
+}
+
+// UpdateLastUsed function for saving the time of the last login with the identity
+func (mr *MemberIdentityRepoPostgres) UpdateLastUsed(ctxReq context.Context, identityID string) <-chan ResultRepository {
+	ctx := "MemberIdentityRepo-UpdateLastUsed"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE member_identities SET "lastUsed" = $1 WHERE id = $2`
+		tags[helper.TextQuery] = query
+		output <- mr.exec(ctxReq, ctx, query, time.Now(), identityID)
+	})
+
+	return output
+}
+
+// Delete function for unlinking identity of a member, sql.ErrNoRows when the member has no such identity
+func (mr *MemberIdentityRepoPostgres) Delete(ctxReq context.Context, memberID, identityID string) <-chan ResultRepository {
+	ctx := "MemberIdentityRepo-Delete"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `DELETE FROM member_identities WHERE id = $1 AND "memberId" = $2`
+		tags[helper.TextQuery] = query
+		output <- mr.exec(ctxReq, ctx, query, identityID, memberID)
+	})
+
+	return output
+}
+
+// exec function for running update statement, sql.ErrNoRows is returned when nothing matched
+func (mr *MemberIdentityRepoPostgres) exec(ctxReq context.Context, ctx, query string, args ...interface{}) ResultRepository {
+	stmt, err := mr.WriteDB.Prepare(query)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, args)
+		return ResultRepository{Error: err}
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(args...)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, args)
+		return ResultRepository{Error: err}
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ResultRepository{Error: sql.ErrNoRows}
+	}
+	return ResultRepository{}
+}
+
+type identityScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanIdentity(row identityScanner) (model.Identity, error) {
+	var (
+		identity model.Identity
+		email    sql.NullString
+		lastUsed pq.NullTime
+	)
+
+	err := row.Scan(&identity.ID, &identity.MemberID, &identity.Provider, &identity.Subject, &email,
+		&identity.Created, &lastUsed)
+	if err != nil {
+		return identity, err
+	}
+
+	identity.Email = email.String
+	if lastUsed.Valid {
+		identity.LastUsed = &lastUsed.Time
+	}
+	return identity, nil
+}
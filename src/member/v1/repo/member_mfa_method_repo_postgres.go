@@ -0,0 +1,164 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// MemberMFAMethodRepoPostgres data structure
+type MemberMFAMethodRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMemberMFAMethodRepoPostgres function for initializing member mfa method repo
+func NewMemberMFAMethodRepoPostgres(repo *repository.Repository) *MemberMFAMethodRepoPostgres {
+	return &MemberMFAMethodRepoPostgres{repo}
+}
+
+// Save function for enrolling method, enrolling again replaces the target
+func (mr *MemberMFAMethodRepoPostgres) Save(ctxReq context.Context, method *model.MFAMethod) <-chan ResultRepository {
+	ctx := "MemberMFAMethodRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `INSERT INTO member_mfa_method ("memberId", method, target, created, "lastModified")
+				VALUES ($1, $2, $3, $4, $4)
+				ON CONFLICT ("memberId", method) DO UPDATE SET target = EXCLUDED.target, "lastModified" = EXCLUDED."lastModified"`
+		tags[helper.TextQuery] = query
+		stmt, err := mr.WriteDB.Prepare(query)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, method.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if _, err = stmt.Exec(method.MemberID, method.Method, method.Target, method.Created); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, method.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: method}
+	})
+
+	return output
+}
+
+// FindByMemberID function for getting enrolled methods of a member, oldest first
+func (mr *MemberMFAMethodRepoPostgres) FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository {
+	ctx := "MemberMFAMethodRepo-FindByMemberID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT "memberId", method, target, "isDefault", created FROM member_mfa_method
+				WHERE "memberId" = $1 ORDER BY created`
+		tags[helper.TextQuery] = query
+		rows, err := mr.ReadDB.Query(query, memberID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		methods := []model.MFAMethod{}
+		for rows.Next() {
+			var method model.MFAMethod
+			if err := rows.Scan(&method.MemberID, &method.Method, &method.Target, &method.IsDefault, &method.Created); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+				output <- ResultRepository{Error: err}
+				return
+			}
+			method.TargetMask = model.MaskMFATarget(method.Method, method.Target)
+			methods = append(methods, method)
+		}
+
+		output <- ResultRepository{Result: methods}
+	})
+
+	return output
+}
+
+// SetDefault function for marking the method asked first at login, a method
+// without row such as totp clears the flag of every row
+func (mr *MemberMFAMethodRepoPostgres) SetDefault(ctxReq context.Context, memberID, method string) <-chan ResultRepository {
+	ctx := "MemberMFAMethodRepo-SetDefault"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE member_mfa_method SET "isDefault" = (method = $1), "lastModified" = $2 WHERE "memberId" = $3`
+		tags[helper.TextQuery] = query
+		if _, err := mr.WriteDB.Exec(query, method, time.Now(), memberID); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
+
+// Delete function for removing a method of a member, sql.ErrNoRows is returned when it is not enrolled
+func (mr *MemberMFAMethodRepoPostgres) Delete(ctxReq context.Context, memberID, method string) <-chan ResultRepository {
+	ctx := "MemberMFAMethodRepo-Delete"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `DELETE FROM member_mfa_method WHERE "memberId" = $1 AND method = $2`
+		tags[helper.TextQuery] = query
+		result, err := mr.WriteDB.Exec(query, memberID, method)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			output <- ResultRepository{Error: sql.ErrNoRows}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
+
+// DeleteByMemberID function for removing every method of a member
+func (mr *MemberMFAMethodRepoPostgres) DeleteByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository {
+	ctx := "MemberMFAMethodRepo-DeleteByMemberID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `DELETE FROM member_mfa_method WHERE "memberId" = $1`
+		tags[helper.TextQuery] = query
+		if _, err := mr.WriteDB.Exec(query, memberID); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
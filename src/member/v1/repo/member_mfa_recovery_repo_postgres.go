@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// MemberMFARecoveryRepoPostgres data structure
+type MemberMFARecoveryRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMemberMFARecoveryRepoPostgres function for initializing member mfa recovery code repo
+func NewMemberMFARecoveryRepoPostgres(repo *repository.Repository) *MemberMFARecoveryRepoPostgres {
+	return &MemberMFARecoveryRepoPostgres{repo}
+}
+
+// ReplaceRecoveryCodes function for replacing every recovery code of a member, old codes stop working
+func (mr *MemberMFARecoveryRepoPostgres) ReplaceRecoveryCodes(ctxReq context.Context, memberID string, codeHashes []string) <-chan ResultRepository {
+	ctx := "MemberMFARecoveryRepo-ReplaceRecoveryCodes"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		tx, err := mr.WriteDB.Begin()
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if _, err := tx.Exec(`DELETE FROM member_mfa_recovery_code WHERE "memberId" = $1`, memberID); err != nil {
+			tx.Rollback()
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		stmt, err := tx.Prepare(`INSERT INTO member_mfa_recovery_code ("memberId", "codeHash", created) VALUES ($1, $2, $3)`)
+		if err != nil {
+			tx.Rollback()
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		now := time.Now()
+		for _, codeHash := range codeHashes {
+			if _, err := stmt.Exec(memberID, codeHash, now); err != nil {
+				tx.Rollback()
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+				output <- ResultRepository{Error: err}
+				return
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: len(codeHashes)}
+	})
+
+	return output
+}
+
+// UseRecoveryCode function for marking recovery code as used, sql.ErrNoRows is returned
+// when the code does not exist or has been used
+func (mr *MemberMFARecoveryRepoPostgres) UseRecoveryCode(ctxReq context.Context, memberID, codeHash string) <-chan ResultRepository {
+	ctx := "MemberMFARecoveryRepo-UseRecoveryCode"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE member_mfa_recovery_code SET "usedAt" = $1
+				WHERE "memberId" = $2 AND "codeHash" = $3 AND "usedAt" IS NULL`
+		tags[helper.TextQuery] = query
+		result, err := mr.WriteDB.Exec(query, time.Now(), memberID, codeHash)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			output <- ResultRepository{Error: sql.ErrNoRows}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
+
+// DeleteRecoveryCodes function for removing every recovery code of a member
+func (mr *MemberMFARecoveryRepoPostgres) DeleteRecoveryCodes(ctxReq context.Context, memberID string) <-chan ResultRepository {
+	ctx := "MemberMFARecoveryRepo-DeleteRecoveryCodes"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `DELETE FROM member_mfa_recovery_code WHERE "memberId" = $1`
+		tags[helper.TextQuery] = query
+		if _, err := mr.WriteDB.Exec(query, memberID); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
@@ -0,0 +1,222 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+	"github.com/lib/pq"
+)
+
+const passkeyColumns = `id, "memberId", "credentialId", "publicKey", algorithm, "signCount", aaguid, transports,
+		name, created, "lastModified", "lastUsed"`
+
+// MemberPasskeyRepoPostgres data structure
+type MemberPasskeyRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMemberPasskeyRepoPostgres function for initializing member passkey repo
+func NewMemberPasskeyRepoPostgres(repo *repository.Repository) *MemberPasskeyRepoPostgres {
+	return &MemberPasskeyRepoPostgres{repo}
+}
+
+// Save function for saving newly registered passkey
+func (mr *MemberPasskeyRepoPostgres) Save(ctxReq context.Context, passkey *model.Passkey) <-chan ResultRepository {
+	ctx := "MemberPasskeyRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `INSERT INTO member_passkey
+				(id, "memberId", "credentialId", "publicKey", algorithm, "signCount", aaguid, transports, name, created)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		tags[helper.TextQuery] = query
+		stmt, err := mr.WriteDB.Prepare(query)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, passkey.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		_, err = stmt.Exec(passkey.ID, passkey.MemberID, passkey.CredentialID, passkey.PublicKey, passkey.Algorithm,
+			int64(passkey.SignCount), passkey.AAGUID, strings.Join(passkey.Transports, " "), passkey.Name, passkey.Created)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, passkey.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: passkey}
+	})
+
+	return output
+}
+
+// FindByMemberID function for getting passkeys of a member, oldest first
+func (mr *MemberPasskeyRepoPostgres) FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository {
+	ctx := "MemberPasskeyRepo-FindByMemberID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + passkeyColumns + ` FROM member_passkey WHERE "memberId" = $1 ORDER BY created`
+		tags[helper.TextQuery] = query
+		rows, err := mr.ReadDB.Query(query, memberID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		passkeys := []model.Passkey{}
+		for rows.Next() {
+			passkey, err := scanPasskey(rows)
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+				output <- ResultRepository{Error: err}
+				return
+			}
+			passkeys = append(passkeys, passkey)
+		}
+
+		output <- ResultRepository{Result: passkeys}
+	})
+
+	return output
+}
+
+// FindByCredentialID function for getting passkey by the base64url webauthn credential id
+func (mr *MemberPasskeyRepoPostgres) FindByCredentialID(ctxReq context.Context, credentialID string) <-chan ResultRepository {
+	ctx := "MemberPasskeyRepo-FindByCredentialID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + passkeyColumns + ` FROM member_passkey WHERE "credentialId" = $1`
+		tags[helper.TextQuery] = query
+		passkey, err := scanPasskey(mr.ReadDB.QueryRow(query, credentialID))
+		if err != nil {
+			if err != sql.ErrNoRows {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, credentialID)
+			}
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: passkey}
+	})
+
+	return output
+}
+
+// UpdateName function for renaming passkey of a member
+func (mr *MemberPasskeyRepoPostgres) UpdateName(ctxReq context.Context, memberID, passkeyID, name string) <-chan ResultRepository {
+	ctx := "MemberPasskeyRepo-UpdateName"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE member_passkey SET name = $1, "lastModified" = $2 WHERE id = $3 AND "memberId" = $4`
+		tags[helper.TextQuery] = query
+		output <- mr.exec(ctxReq, ctx, query, name, time.Now(), passkeyID, memberID)
+	})
+
+	return output
+}
+
+// UpdateSignCount function for saving the signature counter after a successful assertion
+func (mr *MemberPasskeyRepoPostgres) UpdateSignCount(ctxReq context.Context, passkeyID string, signCount uint32) <-chan ResultRepository {
+	ctx := "MemberPasskeyRepo-UpdateSignCount"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE member_passkey SET "signCount" = $1, "lastUsed" = $2 WHERE id = $3`
+		tags[helper.TextQuery] = query
+		output <- mr.exec(ctxReq, ctx, query, int64(signCount), time.Now(), passkeyID)
+	})
+
+	return output
+}
+
+// Delete function for removing passkey of a member
+func (mr *MemberPasskeyRepoPostgres) Delete(ctxReq context.Context, memberID, passkeyID string) <-chan ResultRepository {
+	ctx := "MemberPasskeyRepo-Delete"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `DELETE FROM member_passkey WHERE id = $1 AND "memberId" = $2`
+		tags[helper.TextQuery] = query
+		output <- mr.exec(ctxReq, ctx, query, passkeyID, memberID)
+	})
+
+	return output
+}
+
+// exec function for running update statement, sql.ErrNoRows is returned when nothing matched
+func (mr *MemberPasskeyRepoPostgres) exec(ctxReq context.Context, ctx, query string, args ...interface{}) ResultRepository {
+	stmt, err := mr.WriteDB.Prepare(query)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, args)
+		return ResultRepository{Error: err}
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(args...)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, args)
+		return ResultRepository{Error: err}
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ResultRepository{Error: sql.ErrNoRows}
+	}
+	return ResultRepository{}
+}
+
+type passkeyScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPasskey(row passkeyScanner) (model.Passkey, error) {
+	var (
+		passkey      model.Passkey
+		signCount    int64
+		aaguid       sql.NullString
+		transports   sql.NullString
+		lastModified pq.NullTime
+		lastUsed     pq.NullTime
+	)
+
+	err := row.Scan(&passkey.ID, &passkey.MemberID, &passkey.CredentialID, &passkey.PublicKey, &passkey.Algorithm,
+		&signCount, &aaguid, &transports, &passkey.Name, &passkey.Created, &lastModified, &lastUsed)
+	if err != nil {
+		return passkey, err
+	}
+
+	passkey.SignCount = uint32(signCount)
+	passkey.AAGUID = aaguid.String
+	passkey.Transports = strings.Fields(transports.String)
+	if lastModified.Valid {
+		passkey.LastModified = &lastModified.Time
+	}
+	if lastUsed.Valid {
+		passkey.LastUsed = &lastUsed.Time
+	}
+	return passkey, nil
+}
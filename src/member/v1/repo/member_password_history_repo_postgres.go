@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// MemberPasswordHistoryRepoPostgres data structure
+type MemberPasswordHistoryRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMemberPasswordHistoryRepoPostgres function for initializing member password history repo
+func NewMemberPasswordHistoryRepoPostgres(repo *repository.Repository) *MemberPasswordHistoryRepoPostgres {
+	return &MemberPasswordHistoryRepoPostgres{repo}
+}
+
+// Save function for keeping a replaced password, only the latest keep passwords of the member are kept
+func (mr *MemberPasswordHistoryRepoPostgres) Save(ctxReq context.Context, history model.PasswordHistory, keep int) <-chan ResultRepository {
+	ctx := "MemberPasswordHistoryRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = history.MemberID
+		tx, err := mr.WriteDB.Begin()
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, history.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		query := `INSERT INTO member_password_history ("memberId", salt, password, created) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(query, history.MemberID, history.Salt, history.Password, time.Now()); err != nil {
+			tx.Rollback()
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, history.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		query = `DELETE FROM member_password_history WHERE "memberId" = $1 AND id NOT IN (
+				SELECT id FROM member_password_history WHERE "memberId" = $1 ORDER BY created DESC, id DESC LIMIT $2)`
+		if _, err := tx.Exec(query, history.MemberID, keep); err != nil {
+			tx.Rollback()
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, history.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		if err := tx.Commit(); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, history.MemberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
+
+// FindByMemberID function for getting the latest replaced passwords of a member, newest first
+func (mr *MemberPasswordHistoryRepoPostgres) FindByMemberID(ctxReq context.Context, memberID string, limit int) <-chan ResultRepository {
+	ctx := "MemberPasswordHistoryRepo-FindByMemberID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT "memberId", salt, password FROM member_password_history
+				WHERE "memberId" = $1 ORDER BY created DESC, id DESC LIMIT $2`
+		tags[helper.TextQuery] = query
+		rows, err := mr.ReadDB.Query(query, memberID, limit)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		histories := []model.PasswordHistory{}
+		for rows.Next() {
+			var history model.PasswordHistory
+			if err := rows.Scan(&history.MemberID, &history.Salt, &history.Password); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+				output <- ResultRepository{Error: err}
+				return
+			}
+			histories = append(histories, history)
+		}
+
+		output <- ResultRepository{Result: histories}
+	})
+
+	return output
+}
@@ -793,3 +793,31 @@ func (mr *MemberRepoPostgres) UpdateFlagIsSyncMember(ctxReq context.Context, mem
 
 	return output
 }
+
+// UpdatePasswordHash function for replacing the hash of the same password,
+// the salt guards against a password changed since it was loaded
+func (mr *MemberRepoPostgres) UpdatePasswordHash(ctxReq context.Context, memberID, oldSalt, salt, password string) <-chan ResultRepository {
+	ctx := "MemberRepo-UpdatePasswordHash"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE "member" SET "password" = $3, "salt" = $4 WHERE "id" = $1 AND "salt" = $2`
+		tags[helper.TextQuery] = query
+		tags[helper.TextMemberIDCamel] = memberID
+
+		result, err := mr.WriteDB.ExecContext(ctxReq, query, memberID, oldSalt, password, salt)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		affected, _ := result.RowsAffected()
+		tags[helper.TextResponse] = affected
+		output <- ResultRepository{Result: affected}
+	})
+
+	return output
+}
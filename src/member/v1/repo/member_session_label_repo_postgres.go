@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// MemberSessionLabelRepoPostgres data structure
+type MemberSessionLabelRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMemberSessionLabelRepoPostgres function for initializing member session label repo
+func NewMemberSessionLabelRepoPostgres(repo *repository.Repository) *MemberSessionLabelRepoPostgres {
+	return &MemberSessionLabelRepoPostgres{repo}
+}
+
+// Save function for creating or replacing the label of a device session
+func (mr *MemberSessionLabelRepoPostgres) Save(ctxReq context.Context, label model.DeviceSessionLabel) <-chan ResultRepository {
+	ctx := "MemberSessionLabelRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `INSERT INTO member_session_label ("memberId", "deviceId", "deviceLogin", label, modified)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT ("memberId", "deviceId", "deviceLogin") DO UPDATE SET label = EXCLUDED.label, modified = EXCLUDED.modified`
+		tags[helper.TextQuery] = query
+		tags[helper.TextArgs] = label
+
+		stmt, err := mr.WriteDB.Prepare(query)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, label)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if _, err := stmt.Exec(label.MemberID, label.DeviceID, label.DeviceLogin, label.Label, time.Now()); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, label)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: label}
+	})
+
+	return output
+}
+
+// FindByMemberID function for getting the session labels of a member
+func (mr *MemberSessionLabelRepoPostgres) FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository {
+	ctx := "MemberSessionLabelRepo-FindByMemberID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT "memberId", "deviceId", "deviceLogin", label FROM member_session_label WHERE "memberId" = $1`
+		tags[helper.TextQuery] = query
+		rows, err := mr.ReadDB.Query(query, memberID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		labels := []model.DeviceSessionLabel{}
+		for rows.Next() {
+			var label model.DeviceSessionLabel
+			if err := rows.Scan(&label.MemberID, &label.DeviceID, &label.DeviceLogin, &label.Label); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+				output <- ResultRepository{Error: err}
+				return
+			}
+			labels = append(labels, label)
+		}
+
+		output <- ResultRepository{Result: labels}
+	})
+
+	return output
+}
+
+// Delete function for removing the label of a device session
+func (mr *MemberSessionLabelRepoPostgres) Delete(ctxReq context.Context, memberID, deviceID, deviceLogin string) <-chan ResultRepository {
+	ctx := "MemberSessionLabelRepo-Delete"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `DELETE FROM member_session_label WHERE "memberId" = $1 AND "deviceId" = $2 AND "deviceLogin" = $3`
+		tags[helper.TextQuery] = query
+		if _, err := mr.WriteDB.Exec(query, memberID, deviceID, deviceLogin); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, memberID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{}
+	})
+
+	return output
+}
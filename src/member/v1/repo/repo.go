@@ -23,6 +23,7 @@ type MemberRepository interface {
 	UpdateProfilePicture(ctxReq context.Context, data model.ProfilePicture) <-chan ResultRepository
 	UpdateFlagIsSyncMember(ctxReq context.Context, member model.Member) <-chan ResultRepository
 	UpdatePasswordMemberByEmail(ctxReq context.Context, member model.Member) <-chan ResultRepository
+	UpdatePasswordHash(ctxReq context.Context, memberID, oldSalt, salt, password string) <-chan ResultRepository
 }
 
 // MemberRepositoryRedis interface abstraction
@@ -62,3 +63,51 @@ type MemberMFARepository interface {
 	EnableNarwhalMFA(ctxReq context.Context, uid string, mfaKey string) <-chan ResultRepository
 	DisableNarwhalMFA(ctxReq context.Context, uid string) <-chan ResultRepository
 }
+
+// MemberMFARecoveryRepository interface
+type MemberMFARecoveryRepository interface {
+	ReplaceRecoveryCodes(ctxReq context.Context, memberID string, codeHashes []string) <-chan ResultRepository
+	UseRecoveryCode(ctxReq context.Context, memberID, codeHash string) <-chan ResultRepository
+	DeleteRecoveryCodes(ctxReq context.Context, memberID string) <-chan ResultRepository
+}
+
+// MemberMFAMethodRepository interface
+type MemberMFAMethodRepository interface {
+	Save(ctxReq context.Context, method *model.MFAMethod) <-chan ResultRepository
+	FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository
+	SetDefault(ctxReq context.Context, memberID, method string) <-chan ResultRepository
+	Delete(ctxReq context.Context, memberID, method string) <-chan ResultRepository
+	DeleteByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository
+}
+
+// MemberPasskeyRepository interface
+type MemberPasskeyRepository interface {
+	Save(ctxReq context.Context, passkey *model.Passkey) <-chan ResultRepository
+	FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository
+	FindByCredentialID(ctxReq context.Context, credentialID string) <-chan ResultRepository
+	UpdateName(ctxReq context.Context, memberID, passkeyID, name string) <-chan ResultRepository
+	UpdateSignCount(ctxReq context.Context, passkeyID string, signCount uint32) <-chan ResultRepository
+	Delete(ctxReq context.Context, memberID, passkeyID string) <-chan ResultRepository
+}
+
+// MemberIdentityRepository interface
+type MemberIdentityRepository interface {
+	Save(ctxReq context.Context, identity *model.Identity) <-chan ResultRepository
+	FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository
+	FindByProviderSubject(ctxReq context.Context, provider, subject string) <-chan ResultRepository
+	UpdateLastUsed(ctxReq context.Context, identityID string) <-chan ResultRepository
+	Delete(ctxReq context.Context, memberID, identityID string) <-chan ResultRepository
+}
+
+// MemberPasswordHistoryRepository interface
+type MemberPasswordHistoryRepository interface {
+	Save(ctxReq context.Context, history model.PasswordHistory, keep int) <-chan ResultRepository
+	FindByMemberID(ctxReq context.Context, memberID string, limit int) <-chan ResultRepository
+}
+
+// MemberSessionLabelRepository interface
+type MemberSessionLabelRepository interface {
+	Save(ctxReq context.Context, label model.DeviceSessionLabel) <-chan ResultRepository
+	FindByMemberID(ctxReq context.Context, memberID string) <-chan ResultRepository
+	Delete(ctxReq context.Context, memberID, deviceID, deviceLogin string) <-chan ResultRepository
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+)
+
+// checkRegistrationCaptcha function for asking a captcha on self registration after failed registrations from the ip,
+// or on every one when the policy is scored. A captcha sent before that is verified as well. Members added by an admin
+// or without ip are not checked
+func (mu *MemberUseCaseImpl) checkRegistrationCaptcha(ctxReq context.Context, data *model.Member) (int, error) {
+	response := data.CaptchaResponse
+	data.CaptchaResponse = ""
+	if mu.Captcha == nil || data.Type != textRegister || data.IP == "" {
+		return http.StatusOK, nil
+	}
+
+	captchaData := serviceModel.Captcha{Response: response, RemoteIP: data.IP, Action: captcha.ActionRegister}
+	failures := mu.Captcha.Failures(ctxReq, captcha.GetFailureKey(captcha.ActionRegister, data.IP))
+	if mu.Captcha.Policy.Scored && failures < mu.Captcha.Policy.AfterFailures {
+		failures = mu.Captcha.Policy.AfterFailures
+	}
+	if err := mu.Captcha.Check(ctxReq, captchaData, failures); err != nil {
+		return captcha.HTTPStatus(err), err
+	}
+	return http.StatusOK, nil
+}
+
+// registerCaptchaFailure function for counting a refused self registration of the ip
+func (mu *MemberUseCaseImpl) registerCaptchaFailure(ctxReq context.Context, data *model.Member) {
+	if mu.Captcha == nil || data.Type != textRegister || data.IP == "" {
+		return
+	}
+	mu.Captcha.RegisterFailure(ctxReq, captcha.GetFailureKey(captcha.ActionRegister, data.IP))
+}
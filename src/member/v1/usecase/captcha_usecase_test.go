@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/service"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+	"github.com/Bhinneka/user-service/src/shared/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberUseCaseImpl_checkRegistrationCaptcha(t *testing.T) {
+	values := map[string]string{}
+	fakeRedis := &mocks.FakeRedis{
+		GetFunc: func(key string) (string, error) {
+			value, ok := values[key]
+			if !ok {
+				return "", errors.New("redis: nil")
+			}
+			return value, nil
+		},
+		SetFunc: func(key, value string, exp time.Duration) (string, error) {
+			values[key] = value
+			return "OK", nil
+		},
+	}
+	mu := &MemberUseCaseImpl{
+		Captcha: captcha.NewGuard(service.NewCaptchaFakeService(), authRepo.NewAttemptRepositoryRedis(fakeRedis), captcha.DefaultPolicy),
+	}
+	ctx := context.Background()
+
+	// the first registrations of an ip do not need a captcha
+	data := &model.Member{Type: textRegister, IP: "10.0.0.1"}
+	httpStatus, err := mu.checkRegistrationCaptcha(ctx, data)
+	assert.Equal(t, http.StatusOK, httpStatus)
+	assert.NoError(t, err)
+
+	for i := 0; i < captcha.DefaultPolicy.AfterFailures; i++ {
+		mu.registerCaptchaFailure(ctx, data)
+	}
+	httpStatus, err = mu.checkRegistrationCaptcha(ctx, data)
+	assert.Equal(t, http.StatusPreconditionRequired, httpStatus)
+	assert.Equal(t, captcha.ErrRequired, err)
+
+	// a solved captcha is not kept in the member
+	data.CaptchaResponse = "solved"
+	httpStatus, err = mu.checkRegistrationCaptcha(ctx, data)
+	assert.Equal(t, http.StatusOK, httpStatus)
+	assert.NoError(t, err)
+	assert.Equal(t, "", data.CaptchaResponse)
+
+	// members added by an admin are not checked
+	httpStatus, err = mu.checkRegistrationCaptcha(ctx, &model.Member{Type: "add", IP: "10.0.0.1"})
+	assert.Equal(t, http.StatusOK, httpStatus)
+	assert.NoError(t, err)
+
+	// a scored policy asks every registration for a captcha
+	scored := captcha.DefaultPolicy
+	scored.Scored = true
+	mu.Captcha = captcha.NewGuard(service.NewCaptchaFakeService(), authRepo.NewAttemptRepositoryRedis(fakeRedis), scored)
+	httpStatus, err = mu.checkRegistrationCaptcha(ctx, &model.Member{Type: textRegister, IP: "10.0.0.2"})
+	assert.Equal(t, http.StatusPreconditionRequired, httpStatus)
+	assert.Equal(t, captcha.ErrRequired, err)
+}
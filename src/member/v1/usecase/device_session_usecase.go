@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	sessionModel "github.com/Bhinneka/user-service/src/session/v1/model"
+)
+
+// GetDeviceSessions function for getting the active login sessions of a member,
+// token is the access token of the request and marks the current session, empty for admin
+func (mu *MemberUseCaseImpl) GetDeviceSessions(ctxReq context.Context, memberID, token string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-GetDeviceSessions"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextMemberIDCamel] = memberID
+		if result := mu.loadSessionMember(ctxReq, memberID); result.Error != nil {
+			output <- result
+			return
+		}
+
+		// redis key format: STG-USR123-ASX1234-WEB
+		prefix := strings.Join([]string{"STG", memberID, ""}, "-")
+		activeResult := <-mu.LoginSessionRedis.GetLoginActive(ctxReq, prefix)
+		if activeResult.Error != nil {
+			helper.SendErrorLog(ctxReq, ctx, "get_login_active", activeResult.Error, memberID)
+			output <- ResultUseCase{Error: errors.New(model.ErrorGetDeviceSessions), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		activeSessions, _ := activeResult.Result.([]authModel.LoginSessionRedis)
+
+		currentKey := mu.currentSessionKey(ctxReq, token)
+		labels := mu.deviceSessionLabels(ctxReq, memberID)
+
+		sessions := []model.DeviceSession{}
+		for _, active := range activeSessions {
+			deviceID, deviceLogin, ok := splitLoginSessionKey(active.Key, prefix)
+			if !ok {
+				continue
+			}
+
+			session := mu.getDeviceSession(ctxReq, memberID, deviceID, deviceLogin)
+			session.Label = labels[model.NewDeviceSessionID(deviceID, deviceLogin)]
+			session.Current = active.Key == currentKey
+			sessions = append(sessions, session)
+		}
+
+		// current session first, then the last seen one
+		sort.SliceStable(sessions, func(i, j int) bool {
+			if sessions[i].Current != sessions[j].Current {
+				return sessions[i].Current
+			}
+			if sessions[i].LastSeen == nil || sessions[j].LastSeen == nil {
+				return sessions[j].LastSeen == nil && sessions[i].LastSeen != nil
+			}
+			return sessions[i].LastSeen.After(*sessions[j].LastSeen)
+		})
+
+		output <- ResultUseCase{Result: sessions}
+	})
+
+	return output
+}
+
+// LabelDeviceSession function for naming an active session of a member, an empty label removes it
+func (mu *MemberUseCaseImpl) LabelDeviceSession(ctxReq context.Context, memberID, sessionID string, data model.DeviceSessionLabel) <-chan ResultUseCase {
+	ctx := "MemberUseCase-LabelDeviceSession"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = sessionID
+		label, ok := model.NormalizeDeviceSessionLabel(data.Label)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(model.ErrorDeviceSessionLabel), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		deviceID, deviceLogin, err := mu.findDeviceSession(ctxReq, memberID, sessionID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusNotFound}
+			return
+		}
+
+		sessionLabel := model.DeviceSessionLabel{MemberID: memberID, DeviceID: deviceID, DeviceLogin: deviceLogin, Label: label}
+		if label == "" {
+			deleteResult := <-mu.MemberSessionLabelRepo.Delete(ctxReq, memberID, deviceID, deviceLogin)
+			if deleteResult.Error != nil {
+				output <- ResultUseCase{Error: deleteResult.Error, HTTPStatus: http.StatusInternalServerError}
+				return
+			}
+			output <- ResultUseCase{Result: sessionLabel}
+			return
+		}
+
+		saveResult := <-mu.MemberSessionLabelRepo.Save(ctxReq, sessionLabel)
+		if saveResult.Error != nil {
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: sessionLabel}
+	})
+
+	return output
+}
+
+// RevokeDeviceSession function for ending one session of a member, the session of token cannot be revoked
+func (mu *MemberUseCaseImpl) RevokeDeviceSession(ctxReq context.Context, memberID, sessionID, token string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-RevokeDeviceSession"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = sessionID
+		deviceID, deviceLogin, err := mu.findDeviceSession(ctxReq, memberID, sessionID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusNotFound}
+			return
+		}
+
+		redisKey := strings.Join([]string{"STG", memberID, deviceID, deviceLogin}, "-")
+		if redisKey == mu.currentSessionKey(ctxReq, token) {
+			output <- ResultUseCase{Error: errors.New(model.ErrorDeviceSessionCurrent), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		<-mu.LoginSessionRedis.Delete(ctxReq, redisKey)
+		<-mu.LoginSessionRedis.Delete(ctxReq, strings.Join([]string{"RT", memberID, deviceID, deviceLogin}, "-"))
+
+		output <- ResultUseCase{Result: sessionID}
+	})
+
+	return output
+}
+
+// RevokeOtherDeviceSessions function for ending every session of a member except the session of token,
+// an empty token ends all of them
+func (mu *MemberUseCaseImpl) RevokeOtherDeviceSessions(ctxReq context.Context, memberID, token string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-RevokeOtherDeviceSessions"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextMemberIDCamel] = memberID
+		if result := mu.loadSessionMember(ctxReq, memberID); result.Error != nil {
+			output <- result
+			return
+		}
+
+		if err := mu.revokeAllAccessProccess(ctxReq, memberID, token, token != ""); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "revoke_other_sessions", err, memberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: nil}
+	})
+
+	return output
+}
+
+// loadSessionMember function for checking the member of the sessions exists
+func (mu *MemberUseCaseImpl) loadSessionMember(ctxReq context.Context, memberID string) ResultUseCase {
+	memberResult := <-mu.MemberRepoRead.Load(ctxReq, memberID)
+	if memberResult.Error != nil {
+		if memberResult.Error == sql.ErrNoRows {
+			return ResultUseCase{Error: fmt.Errorf(helper.ErrorDataNotFound, labelMember), HTTPStatus: http.StatusNotFound}
+		}
+		return ResultUseCase{Error: memberResult.Error, HTTPStatus: http.StatusBadRequest}
+	}
+	return ResultUseCase{Result: memberResult.Result}
+}
+
+// currentSessionKey function for getting the redis key of the session of an access token
+func (mu *MemberUseCaseImpl) currentSessionKey(ctxReq context.Context, token string) string {
+	if token == "" {
+		return ""
+	}
+
+	redisKey, _, _, err := mu.ParseToken(ctxReq, token)
+	if err != nil {
+		return ""
+	}
+	return redisKey
+}
+
+// findDeviceSession function for checking the session is active and belongs to the member
+func (mu *MemberUseCaseImpl) findDeviceSession(ctxReq context.Context, memberID, sessionID string) (string, string, error) {
+	deviceID, deviceLogin, ok := model.ParseDeviceSessionID(sessionID)
+	if !ok {
+		return "", "", errors.New(model.ErrorDeviceSessionNotFound)
+	}
+
+	loadResult := <-mu.LoginSessionRedis.Load(ctxReq, strings.Join([]string{"STG", memberID, deviceID, deviceLogin}, "-"))
+	if loadResult.Error != nil {
+		return "", "", errors.New(model.ErrorDeviceSessionNotFound)
+	}
+	return deviceID, deviceLogin, nil
+}
+
+// getDeviceSession function for getting the detail of a session from the latest session info of the device
+func (mu *MemberUseCaseImpl) getDeviceSession(ctxReq context.Context, memberID, deviceID, deviceLogin string) model.DeviceSession {
+	session := model.DeviceSession{
+		ID:          model.NewDeviceSessionID(deviceID, deviceLogin),
+		DeviceID:    deviceID,
+		DeviceLogin: deviceLogin,
+	}
+
+	param := sessionModel.ParametersGetSession{DeviceID: deviceID, ClientType: deviceLogin, UserID: memberID}
+	sessionResult := <-mu.SessionQueryRead.GetDetailSessionInfo(ctxReq, param)
+	if sessionResult.Error != nil {
+		return session
+	}
+
+	info, ok := sessionResult.Result.(sessionModel.SessionInfoResponse)
+	if !ok {
+		return session
+	}
+
+	if info.UserAgent != nil {
+		session.Device, session.IsMobile, session.IsApp = helper.ParseUserAgent(*info.UserAgent)
+	}
+	if info.IP != nil {
+		session.IP = *info.IP
+		if mu.SessionLocator != nil {
+			session.Location = mu.SessionLocator.Locate(session.IP)
+		}
+	}
+	if info.GrantType != nil {
+		session.GrantType = *info.GrantType
+	}
+	// session info is saved on every login and its last used time moves on every token refresh
+	session.LoggedInAt = info.CreatedAt
+	session.LastSeen = info.LastUsedAt
+	if session.LastSeen == nil {
+		session.LastSeen = info.CreatedAt
+	}
+	return session
+}
+
+// deviceSessionLabels function for getting the session labels of a member keyed by session id
+func (mu *MemberUseCaseImpl) deviceSessionLabels(ctxReq context.Context, memberID string) map[string]string {
+	ctx := "MemberUseCase-deviceSessionLabels"
+
+	labels := map[string]string{}
+	if mu.MemberSessionLabelRepo == nil {
+		return labels
+	}
+
+	labelResult := <-mu.MemberSessionLabelRepo.FindByMemberID(ctxReq, memberID)
+	if labelResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "find_session_label", labelResult.Error, memberID)
+		return labels
+	}
+
+	sessionLabels, _ := labelResult.Result.([]model.DeviceSessionLabel)
+	for _, label := range sessionLabels {
+		labels[model.NewDeviceSessionID(label.DeviceID, label.DeviceLogin)] = label.Label
+	}
+	return labels
+}
+
+// splitLoginSessionKey function for getting device id and device login from the redis key of a login session,
+// device login is the last part as device id may have `-`
+func splitLoginSessionKey(key, prefix string) (string, string, bool) {
+	if !strings.HasPrefix(key, prefix) {
+		return "", "", false
+	}
+
+	rest := strings.TrimPrefix(key, prefix)
+	index := strings.LastIndex(rest, "-")
+	if index <= 0 || index == len(rest)-1 {
+		return "", "", false
+	}
+	return rest[:index], rest[index+1:], true
+}
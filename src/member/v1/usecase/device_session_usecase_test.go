@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	mocksRepoAuth "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	mocksQuerySession "github.com/Bhinneka/user-service/mocks/src/session/v1/query"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/repo"
+	sessionModel "github.com/Bhinneka/user-service/src/session/v1/model"
+	sessionQuery "github.com/Bhinneka/user-service/src/session/v1/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func generateResultSessionQuery(data sessionQuery.ResultQuery) <-chan sessionQuery.ResultQuery {
+	output := make(chan sessionQuery.ResultQuery, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestMemberUseCaseImpl_GetDeviceSessions(t *testing.T) {
+	oldest := time.Now().Add(-2 * time.Hour)
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+	ip, userAgent, grantType := "36.72.10.1", "BhinnekaApp/1.0", "password"
+	locations, _ := model.LoadIPLocations(strings.NewReader("36.72.0.0/16,Jakarta, ID\n"))
+
+	tests := []struct {
+		name         string
+		memberResult repo.ResultRepository
+		activeResult authRepo.ResultRepository
+		wantStatus   int
+		wantIDs      []string
+	}{
+		{
+			name:         "Case 1: Success, last seen first",
+			memberResult: repo.ResultRepository{Result: model.Member{ID: "USR123"}},
+			activeResult: authRepo.ResultRepository{Result: []authModel.LoginSessionRedis{
+				{Key: "STG-USR123-c0b4d1b4-4474-WEB"},
+				{Key: "STG-USR123-ASX1234-APP"},
+				{Key: "STG-USR123-invalid"},
+			}},
+			wantIDs: []string{
+				model.NewDeviceSessionID("ASX1234", "APP"),
+				model.NewDeviceSessionID("c0b4d1b4-4474", "WEB"),
+			},
+		},
+		{
+			name:         "Case 2: Member not found",
+			memberResult: repo.ResultRepository{Error: sql.ErrNoRows},
+			wantStatus:   http.StatusNotFound,
+		},
+		{
+			name:         "Case 3: Error redis",
+			memberResult: repo.ResultRepository{Result: model.Member{ID: "USR123"}},
+			activeResult: authRepo.ResultRepository{Error: errors.New("connection refused")},
+			wantStatus:   http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberRepo := new(mocksRepoMember.MemberRepository)
+			memberRepo.On("Load", mock.Anything, "USR123").Return(generateResultRepository(tt.memberResult))
+
+			loginSessionRepo := new(mocksRepoAuth.LoginSessionRepository)
+			loginSessionRepo.On("GetLoginActive", mock.Anything, "STG-USR123-").Return(generateAuthResultRepository(tt.activeResult))
+
+			sessionQueryRead := new(mocksQuerySession.SessionInfoQuery)
+			sessionQueryRead.On("GetDetailSessionInfo", mock.Anything, mock.MatchedBy(func(param sessionModel.ParametersGetSession) bool {
+				return param.DeviceID == "ASX1234"
+			})).Return(generateResultSessionQuery(sessionQuery.ResultQuery{Result: sessionModel.SessionInfoResponse{
+				IP: &ip, UserAgent: &userAgent, GrantType: &grantType, CreatedAt: &oldest, LastUsedAt: &newer,
+			}}))
+			sessionQueryRead.On("GetDetailSessionInfo", mock.Anything, mock.Anything).Return(generateResultSessionQuery(sessionQuery.ResultQuery{Result: sessionModel.SessionInfoResponse{
+				CreatedAt: &older,
+			}}))
+
+			labelRepo := new(mocksRepoMember.MemberSessionLabelRepository)
+			labelRepo.On("FindByMemberID", mock.Anything, "USR123").Return(generateResultRepository(repo.ResultRepository{
+				Result: []model.DeviceSessionLabel{{MemberID: "USR123", DeviceID: "ASX1234", DeviceLogin: "APP", Label: "Phone"}},
+			}))
+
+			mu := &MemberUseCaseImpl{
+				MemberRepoRead:         memberRepo,
+				LoginSessionRedis:      loginSessionRepo,
+				SessionQueryRead:       sessionQueryRead,
+				MemberSessionLabelRepo: labelRepo,
+				SessionLocator:         locations,
+			}
+			result := <-mu.GetDeviceSessions(context.Background(), "USR123", "")
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantStatus != 0 {
+				return
+			}
+
+			sessions := result.Result.([]model.DeviceSession)
+			var ids []string
+			for _, session := range sessions {
+				ids = append(ids, session.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, "Phone", sessions[0].Label)
+			assert.Equal(t, "Jakarta, ID", sessions[0].Location)
+			assert.True(t, sessions[0].IsApp)
+			assert.False(t, sessions[0].Current)
+			assert.Equal(t, &oldest, sessions[0].LoggedInAt)
+			assert.Equal(t, &newer, sessions[0].LastSeen)
+			assert.Equal(t, &older, sessions[1].LastSeen)
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_RevokeDeviceSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		sessionID  string
+		loadResult authRepo.ResultRepository
+		wantStatus int
+	}{
+		{
+			name:       "Case 1: Success",
+			sessionID:  model.NewDeviceSessionID("ASX1234", "APP"),
+			loadResult: authRepo.ResultRepository{Result: authModel.LoginSessionRedis{}},
+		},
+		{
+			name:       "Case 2: Session not active",
+			sessionID:  model.NewDeviceSessionID("ASX1234", "APP"),
+			loadResult: authRepo.ResultRepository{Error: errors.New("redis: nil")},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Case 3: Invalid session id",
+			sessionID:  "invalid!",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loginSessionRepo := new(mocksRepoAuth.LoginSessionRepository)
+			loginSessionRepo.On("Load", mock.Anything, "STG-USR123-ASX1234-APP").Return(generateAuthResultRepository(tt.loadResult))
+			loginSessionRepo.On("Delete", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+
+			mu := &MemberUseCaseImpl{LoginSessionRedis: loginSessionRepo}
+			result := <-mu.RevokeDeviceSession(context.Background(), "USR123", tt.sessionID, "")
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+
+			if tt.wantStatus == 0 {
+				loginSessionRepo.AssertCalled(t, "Delete", mock.Anything, "STG-USR123-ASX1234-APP")
+				loginSessionRepo.AssertCalled(t, "Delete", mock.Anything, "RT-USR123-ASX1234-APP")
+				return
+			}
+			loginSessionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSplitLoginSessionKey(t *testing.T) {
+	deviceID, deviceLogin, ok := splitLoginSessionKey("STG-USR123-c0b4d1b4-4474-WEB", "STG-USR123-")
+	assert.True(t, ok)
+	assert.Equal(t, "c0b4d1b4-4474", deviceID)
+	assert.Equal(t, "WEB", deviceLogin)
+
+	_, _, ok = splitLoginSessionKey("STG-USR1234-ASX1234-WEB", "STG-USR123-")
+	assert.False(t, ok)
+	_, _, ok = splitLoginSessionKey("STG-USR123-ASX1234", "STG-USR123-")
+	assert.False(t, ok)
+}
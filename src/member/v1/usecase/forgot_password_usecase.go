@@ -158,16 +158,21 @@ func (mu *MemberUseCaseImpl) ChangeForgotPassword(ctxReq context.Context, token,
 			return
 		}
 
+		// validate password policy
+		if err := mu.validatePasswordPolicy(ctxReq, &member, newPassword); err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest, ErrorData: passwordPolicyViolations(err)}
+			return
+		}
+		memberOld := member
+
 		// generate salt and password
 		// encode the new password then replace the old password and salt
-		member.Salt = mu.Hash.GenerateSalt()
-		err = mu.Hash.ParseSalt(member.Salt)
+		member.Salt, member.Password, err = mu.Hash.HashPassword(newPassword)
 		if err != nil {
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
 			return
 		}
-
-		member.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(newPassword)))
 		member.LastPasswordModified = time.Now()
 
 		memberSaveResult := <-mu.MemberRepoWrite.Save(ctxReq, member)
@@ -175,6 +180,7 @@ func (mu *MemberUseCaseImpl) ChangeForgotPassword(ctxReq context.Context, token,
 			output <- ResultUseCase{Error: memberSaveResult.Error, HTTPStatus: http.StatusBadRequest}
 			return
 		}
+		mu.savePasswordHistory(ctxReq, memberOld)
 
 		// delete redis key after saving new password
 		<-mu.MemberRepoRedis.Delete(member.ID)
@@ -262,11 +268,6 @@ func (mu *MemberUseCaseImpl) validateChangeForgotPassword(token, newPassword, re
 		return err
 	}
 
-	// validate password format
-	if err := helper.ValidatePassword(newPassword); err != nil {
-		return err
-	}
-
 	if len(rePassword) == 0 {
 		err := fmt.Errorf(helper.ErrorParameterRequired, "confirmation password")
 		return err
@@ -292,20 +293,17 @@ func (mu *MemberUseCaseImpl) ActivateNewPassword(ctxReq context.Context, token,
 		member, err := mu.validateActiveNewPassword(ctxReq, token, password, rePassword)
 		if err != nil {
 			tags[helper.TextResponse] = err
-			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest, ErrorData: passwordPolicyViolations(err)}
 			return
 		}
 
 		// generate salt and password
 		// encode the new password then replace the old password and salt
-		member.Salt = mu.Hash.GenerateSalt()
-		err = mu.Hash.ParseSalt(member.Salt)
+		member.Salt, member.Password, err = mu.Hash.HashPassword(password)
 		if err != nil {
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
 			return
 		}
-
-		member.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(password)))
 		member.LastPasswordModified = time.Now()
 
 		// append the new data
@@ -372,8 +370,8 @@ func (mu *MemberUseCaseImpl) validateActiveNewPassword(ctxReq context.Context, t
 		return member, err
 	}
 
-	// validate password format
-	if err := helper.ValidatePassword(password); err != nil {
+	// validate password policy, the member has no password to compare yet
+	if err := mu.validatePasswordPolicy(ctxReq, nil, password); err != nil {
 		return member, err
 	}
 
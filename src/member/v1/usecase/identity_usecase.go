@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+	uuid "github.com/satori/go.uuid"
+)
+
+// GetIdentities function for listing provider accounts linked to a member
+func (mu *MemberUseCaseImpl) GetIdentities(ctxReq context.Context, memberID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-GetIdentities"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		identities, err := mu.findIdentities(ctxReq, memberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: identities}
+	})
+
+	return output
+}
+
+// LinkIdentity function for linking the account of an oidc connector to a member, the member confirms
+// the password first. Members without password confirm a code sent to their email, the request without otp sends it
+func (mu *MemberUseCaseImpl) LinkIdentity(ctxReq context.Context, memberID string, data model.IdentityLinkRequest) <-chan ResultUseCase {
+	ctx := "MemberUseCase-LinkIdentity"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		if _, ok := mu.Connectors.Get(data.Provider); !ok {
+			output <- ResultUseCase{Error: errors.New(model.ErrorIdentityProvider), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		memberResult := <-mu.MemberRepoRead.Load(ctxReq, memberID)
+		if memberResult.Error != nil {
+			if memberResult.Error == sql.ErrNoRows {
+				memberResult.Error = fmt.Errorf(helper.ErrorDataNotFound, labelMember)
+			}
+			output <- ResultUseCase{Error: memberResult.Error, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		member, ok := memberResult.Result.(model.Member)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(msgErrorResultMember), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		if member.Password == "" && data.OTP == "" && mu.MFAOTP != nil {
+			if err := mu.MFAOTP.Send(ctxReq, model.MFAMethodEmail, memberID, model.MFAOTPScopeIdentity, member.Email); err != nil {
+				output <- ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}
+				return
+			}
+
+			output <- ResultUseCase{Result: model.SuccessMFAOTPSend}
+			return
+		}
+
+		if result, ok := mu.reauthenticate(ctxReq, member, data); !ok {
+			output <- result
+			return
+		}
+
+		for _, param := range []struct{ field, value string }{
+			{"code", data.Code}, {"state", data.State}, {"redirectUri", data.RedirectURI},
+		} {
+			if param.value == "" {
+				output <- ResultUseCase{Error: fmt.Errorf(helper.ErrorParameterRequired, param.field), HTTPStatus: http.StatusBadRequest}
+				return
+			}
+		}
+
+		account, err := mu.Connectors.Exchange(ctxReq, data.Provider, data.Code, data.State, data.RedirectURI)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "exchange_code", err, data.Provider)
+			output <- ResultUseCase{Error: err, HTTPStatus: connector.HTTPStatus(err)}
+			return
+		}
+
+		identity := model.Identity{
+			ID:       uuid.NewV4().String(),
+			MemberID: memberID,
+			Provider: account.Provider,
+			Subject:  account.Subject,
+			Email:    account.Email,
+			Created:  time.Now(),
+		}
+		output <- mu.saveIdentity(ctxReq, identity)
+	})
+
+	return output
+}
+
+// UnlinkIdentity function for unlinking a provider account from a member, the member keeps at least one login method
+func (mu *MemberUseCaseImpl) UnlinkIdentity(ctxReq context.Context, memberID, identityID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-UnlinkIdentity"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = identityID
+		memberResult := <-mu.MemberRepoRead.Load(ctxReq, memberID)
+		if memberResult.Error != nil {
+			if memberResult.Error == sql.ErrNoRows {
+				memberResult.Error = fmt.Errorf(helper.ErrorDataNotFound, labelMember)
+			}
+			output <- ResultUseCase{Error: memberResult.Error, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		member, ok := memberResult.Result.(model.Member)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(msgErrorResultMember), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		identities, err := mu.findIdentities(ctxReq, memberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		found := false
+		for _, identity := range identities {
+			found = found || identity.ID == identityID
+		}
+		if !found {
+			output <- ResultUseCase{Error: errors.New(model.ErrorIdentityNotFound), HTTPStatus: http.StatusNotFound}
+			return
+		}
+
+		// password, passkeys and the other identities are the login methods left
+		loginMethods := len(identities) - 1
+		if member.Password != "" {
+			loginMethods++
+		}
+		if loginMethods == 0 {
+			passkeys, err := mu.findPasskeys(ctxReq, memberID)
+			if err != nil {
+				output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+				return
+			}
+			loginMethods += len(passkeys)
+		}
+		if loginMethods == 0 {
+			output <- ResultUseCase{Error: errors.New(model.ErrorIdentityLastLogin), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		deleteResult := <-mu.MemberIdentityRepo.Delete(ctxReq, memberID, identityID)
+		if deleteResult.Error != nil {
+			if deleteResult.Error == sql.ErrNoRows {
+				output <- ResultUseCase{Error: errors.New(model.ErrorIdentityNotFound), HTTPStatus: http.StatusNotFound}
+				return
+			}
+			output <- ResultUseCase{Error: deleteResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: identityID}
+	})
+
+	return output
+}
+
+// ConfirmIdentityLink function for linking the provider account of a social login to the member of its email,
+// the token is sent by email when the account logs in before it is linked and is single use
+func (mu *MemberUseCaseImpl) ConfirmIdentityLink(ctxReq context.Context, data model.IdentityLinkConfirmation) <-chan ResultUseCase {
+	ctx := "MemberUseCase-ConfirmIdentityLink"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		if data.Token == "" {
+			output <- ResultUseCase{Error: fmt.Errorf(helper.ErrorParameterRequired, "token"), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		key := model.GetIdentityLinkKey(data.Token)
+		linkResult := <-mu.LoginSessionRedis.Load(ctxReq, key)
+		pending, _ := linkResult.Result.(authModel.LoginSessionRedis)
+		if linkResult.Error != nil || pending.Token == "" {
+			output <- ResultUseCase{Error: errors.New(model.ErrorIdentityLinkToken), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		<-mu.LoginSessionRedis.Delete(ctxReq, key)
+
+		link := model.IdentityLink{}
+		if err := json.Unmarshal([]byte(pending.Token), &link); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "parse_identity_link", err, key)
+			output <- ResultUseCase{Error: errors.New(model.ErrorIdentityLinkToken), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		tags[helper.TextArgs] = link.MemberID
+		identity := model.Identity{
+			ID:       uuid.NewV4().String(),
+			MemberID: link.MemberID,
+			Provider: link.Provider,
+			Subject:  link.Subject,
+			Email:    link.Email,
+			Created:  time.Now(),
+		}
+		output <- mu.saveIdentity(ctxReq, identity)
+	})
+
+	return output
+}
+
+// reauthenticate function for checking the password of the member, or the email code of a member without password
+func (mu *MemberUseCaseImpl) reauthenticate(ctxReq context.Context, member model.Member, data model.IdentityLinkRequest) (ResultUseCase, bool) {
+	if member.Password != "" {
+		if data.Password == "" || !mu.Hash.VerifyPassword(data.Password, member.Salt, member.Password) {
+			return ResultUseCase{Error: errors.New(model.ErrorIdentityReauth), HTTPStatus: http.StatusUnauthorized}, false
+		}
+		return ResultUseCase{}, true
+	}
+
+	if mu.MFAOTP == nil {
+		return ResultUseCase{Error: errors.New(model.ErrorIdentityReauth), HTTPStatus: http.StatusUnauthorized}, false
+	}
+	if err := mu.MFAOTP.Verify(ctxReq, model.MFAMethodEmail, member.ID, model.MFAOTPScopeIdentity, data.OTP); err != nil {
+		return ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}, false
+	}
+	return ResultUseCase{}, true
+}
+
+// saveIdentity function for linking identity unless the provider account is linked already,
+// linking it again to the same member answers the linked identity
+func (mu *MemberUseCaseImpl) saveIdentity(ctxReq context.Context, identity model.Identity) ResultUseCase {
+	linkedResult := <-mu.MemberIdentityRepo.FindByProviderSubject(ctxReq, identity.Provider, identity.Subject)
+	if linkedResult.Error == nil {
+		linked, _ := linkedResult.Result.(model.Identity)
+		if linked.MemberID != identity.MemberID {
+			return ResultUseCase{Error: errors.New(model.ErrorIdentityLinked), HTTPStatus: http.StatusConflict}
+		}
+		return ResultUseCase{Result: linked}
+	}
+	if linkedResult.Error != sql.ErrNoRows {
+		return ResultUseCase{Error: linkedResult.Error, HTTPStatus: http.StatusInternalServerError}
+	}
+
+	if saveResult := <-mu.MemberIdentityRepo.Save(ctxReq, &identity); saveResult.Error != nil {
+		return ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+	}
+	return ResultUseCase{Result: identity}
+}
+
+func (mu *MemberUseCaseImpl) findIdentities(ctxReq context.Context, memberID string) ([]model.Identity, error) {
+	identityResult := <-mu.MemberIdentityRepo.FindByMemberID(ctxReq, memberID)
+	if identityResult.Error != nil {
+		return nil, identityResult.Error
+	}
+
+	identities, ok := identityResult.Result.([]model.Identity)
+	if !ok {
+		return nil, errors.New("result is not identities")
+	}
+	return identities, nil
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksRepoAuth "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/repo"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	identityID       = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	identityPassword = "Bhinneka123!"
+)
+
+func TestMemberUseCaseImpl_LinkIdentity(t *testing.T) {
+	hashers, _ := model.NewDefaultPasswordHashers(model.PasswordAlgorithmBcrypt)
+	salt, password, _ := hashers.HashPassword(identityPassword)
+	connectors := connector.NewRegistry([]connector.Config{{
+		Name:     model.IdentityProviderGoogle,
+		Issuer:   "https://accounts.google.com",
+		ClientID: "client",
+	}}, nil)
+
+	tests := []struct {
+		name          string
+		member        model.Member
+		data          model.IdentityLinkRequest
+		wantStatus    int
+		wantSentEmail bool
+	}{
+		{
+			name:       "Case 1: Error provider without connector",
+			member:     model.Member{ID: passkeyMemberID, Salt: salt, Password: password},
+			data:       model.IdentityLinkRequest{Provider: model.IdentityProviderFacebook, Password: identityPassword},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 2: Error wrong password",
+			member:     model.Member{ID: passkeyMemberID, Salt: salt, Password: password},
+			data:       model.IdentityLinkRequest{Provider: model.IdentityProviderGoogle, Password: "wrong"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Case 3: Error code of the connector required",
+			member:     model.Member{ID: passkeyMemberID, Salt: salt, Password: password},
+			data:       model.IdentityLinkRequest{Provider: model.IdentityProviderGoogle, Password: identityPassword},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Case 4: Success send code to member without password",
+			member:        model.Member{ID: passkeyMemberID, Email: "john@bhinneka.com"},
+			data:          model.IdentityLinkRequest{Provider: model.IdentityProviderGoogle},
+			wantSentEmail: true,
+		},
+		{
+			name:       "Case 5: Error wrong code of member without password",
+			member:     model.Member{ID: passkeyMemberID, Email: "john@bhinneka.com"},
+			data:       model.IdentityLinkRequest{Provider: model.IdentityProviderGoogle, OTP: "654321"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberRepo := new(mocksRepoMember.MemberRepository)
+			memberRepo.On("Load", mock.Anything, passkeyMemberID).Return(generateResultRepository(repo.ResultRepository{Result: tt.member}))
+
+			codeRepo := new(mocksRepoAuth.LoginSessionRepository)
+			codeRepo.On("Save", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			codeRepo.On("Load", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{
+				Result: authModel.LoginSessionRedis{Token: helper.HashSHA256(mfaMethodOTP)},
+			}))
+			codeRepo.On("Delete", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			counterRepo := new(mocksRepoAuth.AttemptRepository)
+			counterRepo.On("Increment", mock.Anything, mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{
+				Result: authModel.LoginAttempt{Attempt: "1"},
+			}))
+			counterRepo.On("Delete", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			emailService := new(mocksService.NotificationServices)
+			emailService.On("SendEmail", mock.Anything, mock.Anything).Return("", nil)
+
+			mu := &MemberUseCaseImpl{
+				MemberRepoRead: memberRepo,
+				Hash:           hashers,
+				MFAOTP:         mfaotp.NewIssuer(codeRepo, counterRepo, emailService, nil),
+				Connectors:     connectors,
+			}
+
+			result := <-mu.LinkIdentity(context.Background(), passkeyMemberID, tt.data)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantSentEmail {
+				emailService.AssertCalled(t, "SendEmail", mock.Anything, mock.Anything)
+				assert.Equal(t, model.SuccessMFAOTPSend, result.Result)
+			} else {
+				emailService.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_UnlinkIdentity(t *testing.T) {
+	google := model.Identity{ID: identityID, MemberID: passkeyMemberID, Provider: model.IdentityProviderGoogle}
+	apple := model.Identity{ID: "apple-identity", MemberID: passkeyMemberID, Provider: model.IdentityProviderApple}
+
+	tests := []struct {
+		name         string
+		member       model.Member
+		identities   []model.Identity
+		passkeys     []model.Passkey
+		deleteResult repo.ResultRepository
+		wantStatus   int
+		wantDeleted  bool
+	}{
+		{
+			name:        "Case 1: Success member keeps password",
+			member:      model.Member{ID: passkeyMemberID, Password: "hash"},
+			identities:  []model.Identity{google},
+			wantDeleted: true,
+		},
+		{
+			name:        "Case 2: Success member keeps another identity",
+			member:      model.Member{ID: passkeyMemberID},
+			identities:  []model.Identity{google, apple},
+			wantDeleted: true,
+		},
+		{
+			name:        "Case 3: Success member keeps passkey",
+			member:      model.Member{ID: passkeyMemberID},
+			identities:  []model.Identity{google},
+			passkeys:    []model.Passkey{{ID: passkeyID}},
+			wantDeleted: true,
+		},
+		{
+			name:       "Case 4: Error last login method",
+			member:     model.Member{ID: passkeyMemberID},
+			identities: []model.Identity{google},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 5: Error identity of another member",
+			member:     model.Member{ID: passkeyMemberID, Password: "hash"},
+			identities: []model.Identity{apple},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:         "Case 6: Error delete identity",
+			member:       model.Member{ID: passkeyMemberID, Password: "hash"},
+			identities:   []model.Identity{google},
+			deleteResult: repo.ResultRepository{Error: errors.New("pq: connection refused")},
+			wantStatus:   http.StatusInternalServerError,
+			wantDeleted:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberRepo := new(mocksRepoMember.MemberRepository)
+			memberRepo.On("Load", mock.Anything, passkeyMemberID).Return(generateResultRepository(repo.ResultRepository{Result: tt.member}))
+			identityRepo := new(mocksRepoMember.MemberIdentityRepository)
+			identityRepo.On("FindByMemberID", mock.Anything, passkeyMemberID).Return(generateResultRepository(repo.ResultRepository{Result: tt.identities}))
+			identityRepo.On("Delete", mock.Anything, passkeyMemberID, identityID).Return(generateResultRepository(tt.deleteResult))
+			passkeyRepo := new(mocksRepoMember.MemberPasskeyRepository)
+			passkeyRepo.On("FindByMemberID", mock.Anything, passkeyMemberID).Return(generateResultRepository(repo.ResultRepository{Result: tt.passkeys}))
+
+			mu := &MemberUseCaseImpl{
+				MemberRepoRead:     memberRepo,
+				MemberIdentityRepo: identityRepo,
+				MemberPasskeyRepo:  passkeyRepo,
+			}
+
+			result := <-mu.UnlinkIdentity(context.Background(), passkeyMemberID, identityID)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantDeleted {
+				identityRepo.AssertCalled(t, "Delete", mock.Anything, passkeyMemberID, identityID)
+			} else {
+				identityRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_ConfirmIdentityLink(t *testing.T) {
+	link, _ := json.Marshal(model.IdentityLink{
+		MemberID: passkeyMemberID,
+		Provider: model.IdentityProviderGoogle,
+		Subject:  "110248495921238986420",
+		Email:    "john@bhinneka.com",
+	})
+
+	tests := []struct {
+		name         string
+		token        string
+		savedLink    authRepo.ResultRepository
+		linkedResult repo.ResultRepository
+		wantStatus   int
+		wantSaved    bool
+	}{
+		{
+			name:         "Case 1: Success",
+			token:        "token",
+			savedLink:    authRepo.ResultRepository{Result: authModel.LoginSessionRedis{Token: string(link)}},
+			linkedResult: repo.ResultRepository{Error: sql.ErrNoRows},
+			wantSaved:    true,
+		},
+		{
+			name:         "Case 2: Success linked already",
+			token:        "token",
+			savedLink:    authRepo.ResultRepository{Result: authModel.LoginSessionRedis{Token: string(link)}},
+			linkedResult: repo.ResultRepository{Result: model.Identity{ID: identityID, MemberID: passkeyMemberID}},
+		},
+		{
+			name:       "Case 3: Error token required",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 4: Error token used or expired",
+			token:      "token",
+			savedLink:  authRepo.ResultRepository{Error: errors.New(helper.ErrorRedis)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "Case 5: Error account linked to another member",
+			token:        "token",
+			savedLink:    authRepo.ResultRepository{Result: authModel.LoginSessionRedis{Token: string(link)}},
+			linkedResult: repo.ResultRepository{Result: model.Identity{ID: identityID, MemberID: "USR456"}},
+			wantStatus:   http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linkRepo := new(mocksRepoAuth.LoginSessionRepository)
+			linkRepo.On("Load", mock.Anything, model.GetIdentityLinkKey(tt.token)).Return(generateAuthResultRepository(tt.savedLink))
+			linkRepo.On("Delete", mock.Anything, model.GetIdentityLinkKey(tt.token)).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			identityRepo := new(mocksRepoMember.MemberIdentityRepository)
+			identityRepo.On("FindByProviderSubject", mock.Anything, model.IdentityProviderGoogle, "110248495921238986420").
+				Return(generateResultRepository(tt.linkedResult))
+			identityRepo.On("Save", mock.Anything, mock.Anything).Return(generateResultRepository(repo.ResultRepository{}))
+
+			mu := &MemberUseCaseImpl{LoginSessionRedis: linkRepo, MemberIdentityRepo: identityRepo}
+
+			result := <-mu.ConfirmIdentityLink(context.Background(), model.IdentityLinkConfirmation{Token: tt.token})
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantStatus == 0 {
+				linkRepo.AssertCalled(t, "Delete", mock.Anything, model.GetIdentityLinkKey(tt.token))
+				identity, ok := result.Result.(model.Identity)
+				assert.True(t, ok)
+				assert.Equal(t, passkeyMemberID, identity.MemberID)
+			}
+			if tt.wantSaved {
+				identityRepo.AssertCalled(t, "Save", mock.Anything, mock.Anything)
+			} else {
+				identityRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
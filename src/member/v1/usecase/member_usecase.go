@@ -2,9 +2,7 @@ package usecase
 
 import (
 	"context"
-	"crypto/sha1"
 	"database/sql"
-	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -33,7 +31,11 @@ import (
 	serviceModel "github.com/Bhinneka/user-service/src/service/model"
 	sessionModel "github.com/Bhinneka/user-service/src/session/v1/model"
 	sessionQuery "github.com/Bhinneka/user-service/src/session/v1/query"
+	"github.com/Bhinneka/user-service/src/shared/captcha"
+	"github.com/Bhinneka/user-service/src/shared/connector"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
 	sharedModel "github.com/Bhinneka/user-service/src/shared/model"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
 	shippingRepo "github.com/Bhinneka/user-service/src/shipping_address/v2/repo"
 	"github.com/golang-jwt/jwt"
 )
@@ -43,10 +45,17 @@ type MemberUseCaseImpl struct {
 	MemberRepoRead                    repo.MemberRepository
 	MemberRepoWrite                   repo.MemberRepository
 	MemberMFARepoWrite                repo.MemberMFARepository
+	MemberPasskeyRepo                 repo.MemberPasskeyRepository
+	MemberMFARecoveryRepo             repo.MemberMFARecoveryRepository
+	MemberMFAMethodRepo               repo.MemberMFAMethodRepository
+	MemberPasswordHistoryRepo         repo.MemberPasswordHistoryRepository
+	MemberSessionLabelRepo            repo.MemberSessionLabelRepository
+	MemberIdentityRepo                repo.MemberIdentityRepository
 	MemberRepoRedis                   repo.MemberRepositoryRedis
 	TokenActivationRepo               repo.TokenActivationRepository
 	ShippingAddressRepo               shippingRepo.ShippingAddressRepository
 	LoginAttemptRepo                  authRepo.AttemptRepository
+	LockoutRepo                       authRepo.LockoutRepository
 	LoginSessionRedis                 authRepo.LoginSessionRepository
 	MemberQueryRead                   query.MemberQuery
 	MemberQueryWrite                  query.MemberQuery
@@ -57,6 +66,9 @@ type MemberUseCaseImpl struct {
 	ActivityService                   service.ActivityServices
 	QPublisher                        service.QPublisher
 	Hash                              model.PasswordHasher
+	PasswordPolicy                    *model.PasswordPolicy
+	BreachedPasswords                 model.BreachedPasswordChecker
+	SessionLocator                    model.SessionLocator
 	TokenActivationExpiration         time.Duration
 	ResendActivationAttemptAge        string
 	ResendActivationAttemptAgeRequest string
@@ -72,6 +84,10 @@ type MemberUseCaseImpl struct {
 	CorporateAccContactQueryRead      corporateQuery.AccountContactQuery
 	MerchantRepoRead                  merchantRepoRead.MerchantRepository
 	MerchantEmployeeRead              merchantRepoRead.MerchantEmployeeRepository
+	WebAuthn                          *webauthn.RelyingParty
+	MFAOTP                            *mfaotp.Issuer
+	Captcha                           *captcha.Guard
+	Connectors                        *connector.Registry
 }
 
 // NewMemberUseCase function for initialise member use case implementation
@@ -87,9 +103,16 @@ func NewMemberUseCase(
 		MemberRepoRead:                    repository.MemberRepository,
 		MemberRepoWrite:                   repository.MemberRepository,
 		MemberMFARepoWrite:                repository.MemberMFARepository,
+		MemberPasskeyRepo:                 repository.MemberPasskeyRepository,
+		MemberMFARecoveryRepo:             repository.MemberMFARecoveryRepository,
+		MemberMFAMethodRepo:               repository.MemberMFAMethodRepository,
+		MemberPasswordHistoryRepo:         repository.MemberPasswordHistoryRepository,
+		MemberSessionLabelRepo:            repository.MemberSessionLabelRepository,
+		MemberIdentityRepo:                repository.MemberIdentityRepository,
 		MemberRepoRedis:                   repository.MemberRedisRepository,
 		TokenActivationRepo:               repository.TokenActivationRepoRedis,
 		LoginAttemptRepo:                  repository.AttemptRepositoryRedis,
+		LockoutRepo:                       repository.LockoutRepositoryRedis,
 		LoginSessionRedis:                 repository.LoginSessionRepositoryRedis,
 		ShippingAddressRepo:               repository.ShippingAddressRepository,
 		MemberQueryRead:                   query.MemberQueryRead,
@@ -102,6 +125,9 @@ func NewMemberUseCase(
 		UploadService:                     services.UploadService,
 		ActivityService:                   services.ActivityService,
 		Hash:                              params.Hash,
+		PasswordPolicy:                    params.PasswordPolicy,
+		BreachedPasswords:                 params.BreachedPasswords,
+		SessionLocator:                    params.SessionLocator,
 		TokenActivationExpiration:         params.TokenActivationExpiration,
 		Topic:                             params.Topic,
 		IsProductionStage:                 params.IsProductionStage,
@@ -115,6 +141,10 @@ func NewMemberUseCase(
 		AuthUseCase:                       authUsecase,
 		MerchantRepoRead:                  repository.MerchantRepository,
 		MerchantEmployeeRead:              repository.MerchantEmployeeRepository,
+		WebAuthn:                          params.WebAuthn,
+		MFAOTP:                            params.MFAOTP,
+		Captcha:                           params.Captcha,
+		Connectors:                        params.Connectors,
 	}
 }
 
@@ -402,13 +432,11 @@ func (mu *MemberUseCaseImpl) adjustUpdateMember(ctxReq context.Context, member,
 	// when new password exists
 	if len(data.NewPassword) > 0 {
 		// encode the new password then replace the old password and salt
-		member.Salt = mu.Hash.GenerateSalt()
-		err := mu.Hash.ParseSalt(member.Salt)
+		salt, password, err := mu.Hash.HashPassword(data.NewPassword)
 		if err != nil {
 			return member, http.StatusInternalServerError, err
 		}
-
-		member.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(data.NewPassword)))
+		member.Salt, member.Password = salt, password
 		member.LastPasswordModified = time.Now()
 	}
 
@@ -428,10 +456,8 @@ func (mu *MemberUseCaseImpl) adjustUpdateMember(ctxReq context.Context, member,
 func (mu *MemberUseCaseImpl) deleteSessionByStatus(ctxReq context.Context, data model.Member, member model.Member) error {
 	// compare old data with the new one and process the following steps
 	if member.StatusString == model.BlockedString && (data.StatusString == model.ActiveString || data.StatusString == model.InactiveString || data.StatusString == model.NewString) {
-		// delete the redis data of login attempt
-		key := fmt.Sprintf("ATTEMPT:%s", member.Email)
-
-		delResult := <-mu.LoginAttemptRepo.Delete(ctxReq, key)
+		// unlocking the member also forgets its failed logins
+		delResult := <-mu.LockoutRepo.Delete(ctxReq, member.Email)
 		if delResult.Error != nil {
 			return delResult.Error
 		}
@@ -506,16 +532,27 @@ func (mu *MemberUseCaseImpl) RegisterMember(ctxReq context.Context, data *model.
 		data.Email = strings.ToLower(data.Email)
 		tags[helper.TextEmail] = data.Email
 
+		// a registration has to solve a captcha after failed registrations from its ip or when the provider scores it as a bot
+		if httpStatus, err := mu.checkRegistrationCaptcha(ctxReq, data); err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
+			return
+		}
+
 		// validate data first
 		if err := mu.validateMemberData(ctxReq, data); err != nil {
 			tags[helper.TextResponse] = err
-			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			mu.registerCaptchaFailure(ctxReq, data)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest, ErrorData: passwordPolicyViolations(err)}
 			return
 		}
 
 		data, httpStatus, err := mu.adjustRegistrationData(ctxReq, data)
 		if err != nil {
 			tracer.SetError(ctxReq, err)
+			if httpStatus == http.StatusBadRequest {
+				mu.registerCaptchaFailure(ctxReq, data)
+			}
 			output <- ResultUseCase{Error: err, HTTPStatus: httpStatus}
 			return
 		}
@@ -615,14 +652,11 @@ func (mu *MemberUseCaseImpl) adjustRegistrationData(ctxReq context.Context, data
 	// optional when password exists only
 	if len(data.NewPassword) > 0 {
 		// encode the new password then replace the old password and salt
-		passwordHasher := model.NewPBKDF2Hasher(model.SaltSize, model.SaltSize, model.IterationsCount, sha1.New)
-		data.Salt = passwordHasher.GenerateSalt()
-		err := passwordHasher.ParseSalt(data.Salt)
+		salt, password, err := mu.Hash.HashPassword(data.NewPassword)
 		if err != nil {
 			return data, http.StatusInternalServerError, err
 		}
-
-		data.Password = base64.StdEncoding.EncodeToString(passwordHasher.Hash([]byte(data.NewPassword)))
+		data.Salt, data.Password = salt, password
 		// set only if validation is success
 		data.RePassword = ""
 		data.HasPassword = true
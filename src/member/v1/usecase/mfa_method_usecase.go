@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	goString "github.com/Bhinneka/golib/string"
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+)
+
+// EnrolMFAMethod function for enrolling email or sms second factor, the first call sends a code
+// to the target and the second call with the code saves the method
+func (mu *MemberUseCaseImpl) EnrolMFAMethod(ctxReq context.Context, memberID string, data model.MFAMethodEnrolment) <-chan ResultUseCase {
+	ctx := "MemberUseCase-EnrolMFAMethod"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		if !strings.Contains(memberID, usrFormat) {
+			err := fmt.Errorf(helper.ErrorParameterInvalid, msgErrorMemberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		data.Target = strings.TrimSpace(data.Target)
+		if err := validateMFAMethodTarget(data.Method, data.Target); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		// the code is bound to the target, a code sent to one number can not enrol another
+		scope := model.MFAOTPScopeEnrol + "-" + helper.HashSHA256(data.Target)[:16]
+		if data.OTP == "" {
+			if err := mu.MFAOTP.Send(ctxReq, data.Method, memberID, scope, data.Target); err != nil {
+				output <- ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}
+				return
+			}
+
+			output <- ResultUseCase{Result: model.SuccessMFAOTPSend}
+			return
+		}
+
+		if err := mu.MFAOTP.Verify(ctxReq, data.Method, memberID, scope, data.OTP); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}
+			return
+		}
+
+		methodResult := <-mu.MemberMFAMethodRepo.FindByMemberID(ctxReq, memberID)
+		if methodResult.Error != nil {
+			output <- ResultUseCase{Error: methodResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		methods, _ := methodResult.Result.([]model.MFAMethod)
+
+		method := &model.MFAMethod{
+			MemberID:   memberID,
+			Method:     data.Method,
+			Target:     data.Target,
+			TargetMask: model.MaskMFATarget(data.Method, data.Target),
+			Created:    time.Now(),
+		}
+		if saveResult := <-mu.MemberMFAMethodRepo.Save(ctxReq, method); saveResult.Error != nil {
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		// the first method becomes the default, unless the member already has one
+		if model.DefaultMFAMethod(false, methods) == "" {
+			if defaultResult := <-mu.MemberMFAMethodRepo.SetDefault(ctxReq, memberID, data.Method); defaultResult.Error != nil {
+				output <- ResultUseCase{Error: defaultResult.Error, HTTPStatus: http.StatusInternalServerError}
+				return
+			}
+			method.IsDefault = true
+		}
+
+		output <- ResultUseCase{Result: *method}
+	})
+
+	return output
+}
+
+// SetDefaultMFAMethod function for choosing the second factor asked first at login
+func (mu *MemberUseCaseImpl) SetDefaultMFAMethod(ctxReq context.Context, memberID string, data model.MFADefaultMethod) <-chan ResultUseCase {
+	ctx := "MemberUseCase-SetDefaultMFAMethod"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		if !strings.Contains(memberID, usrFormat) {
+			err := fmt.Errorf(helper.ErrorParameterInvalid, msgErrorMemberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if data.Method == model.MFAMethodTOTP {
+			memberResult := <-mu.MemberRepoRead.Load(ctxReq, memberID)
+			if memberResult.Error != nil {
+				if memberResult.Error == sql.ErrNoRows {
+					memberResult.Error = fmt.Errorf(helper.ErrorDataNotFound, labelMember)
+				}
+
+				output <- ResultUseCase{Error: memberResult.Error, HTTPStatus: http.StatusBadRequest}
+				return
+			}
+
+			if member, _ := memberResult.Result.(model.Member); !member.MFAEnabled {
+				output <- ResultUseCase{Error: errors.New(model.ErrorMFANotEnabled), HTTPStatus: http.StatusBadRequest}
+				return
+			}
+		} else {
+			if !model.IsMFAOTPMethod(data.Method) {
+				output <- ResultUseCase{Error: errors.New(model.ErrorMFAMethod), HTTPStatus: http.StatusBadRequest}
+				return
+			}
+
+			methodResult := <-mu.MemberMFAMethodRepo.FindByMemberID(ctxReq, memberID)
+			if methodResult.Error != nil {
+				output <- ResultUseCase{Error: methodResult.Error, HTTPStatus: http.StatusInternalServerError}
+				return
+			}
+
+			methods, _ := methodResult.Result.([]model.MFAMethod)
+			if !isMFAMethodEnrolled(methods, data.Method) {
+				output <- ResultUseCase{Error: errors.New(model.ErrorMFAMethodNotEnrolled), HTTPStatus: http.StatusBadRequest}
+				return
+			}
+		}
+
+		// totp is not a row, choosing it clears the flag of every enrolled method
+		if defaultResult := <-mu.MemberMFAMethodRepo.SetDefault(ctxReq, memberID, data.Method); defaultResult.Error != nil {
+			output <- ResultUseCase{Error: defaultResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: data}
+	})
+
+	return output
+}
+
+// DeleteMFAMethod function for removing email or sms second factor of a member
+func (mu *MemberUseCaseImpl) DeleteMFAMethod(ctxReq context.Context, memberID, method string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-DeleteMFAMethod"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		if !strings.Contains(memberID, usrFormat) {
+			err := fmt.Errorf(helper.ErrorParameterInvalid, msgErrorMemberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if !model.IsMFAOTPMethod(method) {
+			output <- ResultUseCase{Error: errors.New(model.ErrorMFAMethod), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		deleteResult := <-mu.MemberMFAMethodRepo.Delete(ctxReq, memberID, method)
+		if deleteResult.Error != nil {
+			if deleteResult.Error == sql.ErrNoRows {
+				output <- ResultUseCase{Error: errors.New(model.ErrorMFAMethodNotEnrolled), HTTPStatus: http.StatusNotFound}
+				return
+			}
+
+			output <- ResultUseCase{Error: deleteResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: method}
+	})
+
+	return output
+}
+
+func validateMFAMethodTarget(method, target string) error {
+	switch method {
+	case model.MFAMethodEmail:
+		if err := goString.ValidateEmail(target); err != nil {
+			return fmt.Errorf(helper.ErrorParameterInvalid, "target")
+		}
+	case model.MFAMethodSMS:
+		if target == "" || helper.ValidateMobileNumberMaxInput(target) != nil {
+			return fmt.Errorf(helper.ErrorParameterInvalid, "target")
+		}
+	default:
+		return errors.New(model.ErrorMFAMethod)
+	}
+	return nil
+}
+
+func isMFAMethodEnrolled(methods []model.MFAMethod, method string) bool {
+	for _, enrolled := range methods {
+		if enrolled.Method == method {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Bhinneka/user-service/helper"
+	mocksRepoAuth "github.com/Bhinneka/user-service/mocks/src/auth/v1/repo"
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	mocksService "github.com/Bhinneka/user-service/mocks/src/service"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/repo"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const mfaMethodOTP = "123456"
+
+func generateAuthResultRepository(data authRepo.ResultRepository) <-chan authRepo.ResultRepository {
+	output := make(chan authRepo.ResultRepository, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestMemberUseCaseImpl_EnrolMFAMethod(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         model.MFAMethodEnrolment
+		savedCode    string
+		methods      []model.MFAMethod
+		saveResult   repo.ResultRepository
+		wantStatus   int
+		wantDefault  bool
+		wantSentSMS  bool
+		wantSavedRow bool
+	}{
+		{
+			name:        "Case 1: Success send code",
+			data:        model.MFAMethodEnrolment{Method: model.MFAMethodSMS, Target: "08123456789"},
+			wantSentSMS: true,
+		},
+		{
+			name:         "Case 2: Success enrol first method becomes default",
+			data:         model.MFAMethodEnrolment{Method: model.MFAMethodSMS, Target: "08123456789", OTP: mfaMethodOTP},
+			savedCode:    helper.HashSHA256(mfaMethodOTP),
+			wantDefault:  true,
+			wantSavedRow: true,
+		},
+		{
+			name:         "Case 3: Success enrol keeps existing default",
+			data:         model.MFAMethodEnrolment{Method: model.MFAMethodEmail, Target: "john@bhinneka.com", OTP: mfaMethodOTP},
+			savedCode:    helper.HashSHA256(mfaMethodOTP),
+			methods:      []model.MFAMethod{{Method: model.MFAMethodSMS, IsDefault: true}},
+			wantSavedRow: true,
+		},
+		{
+			name:       "Case 4: Error invalid method",
+			data:       model.MFAMethodEnrolment{Method: model.MFAMethodTOTP, Target: "08123456789"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 5: Error invalid phone number",
+			data:       model.MFAMethodEnrolment{Method: model.MFAMethodSMS, Target: "0812-abc"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 6: Error wrong code",
+			data:       model.MFAMethodEnrolment{Method: model.MFAMethodSMS, Target: "08123456789", OTP: "654321"},
+			savedCode:  helper.HashSHA256(mfaMethodOTP),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 7: Error save method",
+			data:       model.MFAMethodEnrolment{Method: model.MFAMethodSMS, Target: "08123456789", OTP: mfaMethodOTP},
+			savedCode:  helper.HashSHA256(mfaMethodOTP),
+			saveResult: repo.ResultRepository{Error: errors.New("pq: connection refused")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codeRepo := new(mocksRepoAuth.LoginSessionRepository)
+			codeRepo.On("Save", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			codeRepo.On("Load", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{
+				Result: authModel.LoginSessionRedis{Token: tt.savedCode},
+			}))
+			codeRepo.On("Delete", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			counterRepo := new(mocksRepoAuth.AttemptRepository)
+			counterRepo.On("Increment", mock.Anything, mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{
+				Result: authModel.LoginAttempt{Attempt: "1"},
+			}))
+			counterRepo.On("Delete", mock.Anything, mock.Anything).Return(generateAuthResultRepository(authRepo.ResultRepository{}))
+			smsService := new(mocksService.SMSServices)
+			smsService.On("SendSMS", mock.Anything, mock.Anything).Return(nil)
+
+			methodRepo := new(mocksRepoMember.MemberMFAMethodRepository)
+			methodRepo.On("FindByMemberID", mock.Anything, passkeyMemberID).Return(generateResultRepository(repo.ResultRepository{Result: tt.methods}))
+			methodRepo.On("Save", mock.Anything, mock.Anything).Return(generateResultRepository(tt.saveResult))
+			methodRepo.On("SetDefault", mock.Anything, passkeyMemberID, tt.data.Method).Return(generateResultRepository(repo.ResultRepository{}))
+
+			mu := &MemberUseCaseImpl{
+				MemberMFAMethodRepo: methodRepo,
+				MFAOTP:              mfaotp.NewIssuer(codeRepo, counterRepo, nil, smsService),
+			}
+
+			result := <-mu.EnrolMFAMethod(context.Background(), passkeyMemberID, tt.data)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantSentSMS {
+				smsService.AssertCalled(t, "SendSMS", mock.Anything, mock.Anything)
+				assert.Equal(t, model.SuccessMFAOTPSend, result.Result)
+			}
+			if tt.wantSavedRow {
+				method, ok := result.Result.(model.MFAMethod)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantDefault, method.IsDefault)
+				assert.Equal(t, model.MaskMFATarget(tt.data.Method, tt.data.Target), method.TargetMask)
+			}
+			if !tt.wantDefault {
+				methodRepo.AssertNotCalled(t, "SetDefault", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_SetDefaultMFAMethod(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		memberResult repo.ResultRepository
+		methods      []model.MFAMethod
+		wantStatus   int
+	}{
+		{
+			name:    "Case 1: Success enrolled method",
+			method:  model.MFAMethodEmail,
+			methods: []model.MFAMethod{{Method: model.MFAMethodEmail}},
+		},
+		{
+			name:         "Case 2: Success totp",
+			method:       model.MFAMethodTOTP,
+			memberResult: repo.ResultRepository{Result: model.Member{ID: passkeyMemberID, MFAEnabled: true}},
+		},
+		{
+			name:         "Case 3: Error totp not enabled",
+			method:       model.MFAMethodTOTP,
+			memberResult: repo.ResultRepository{Result: model.Member{ID: passkeyMemberID}},
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:       "Case 4: Error method not enrolled",
+			method:     model.MFAMethodSMS,
+			methods:    []model.MFAMethod{{Method: model.MFAMethodEmail}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 5: Error unknown method",
+			method:     "pigeon",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberRepo := new(mocksRepoMember.MemberRepository)
+			memberRepo.On("Load", mock.Anything, passkeyMemberID).Return(generateResultRepository(tt.memberResult))
+			methodRepo := new(mocksRepoMember.MemberMFAMethodRepository)
+			methodRepo.On("FindByMemberID", mock.Anything, passkeyMemberID).Return(generateResultRepository(repo.ResultRepository{Result: tt.methods}))
+			methodRepo.On("SetDefault", mock.Anything, passkeyMemberID, tt.method).Return(generateResultRepository(repo.ResultRepository{}))
+			mu := &MemberUseCaseImpl{MemberRepoRead: memberRepo, MemberMFAMethodRepo: methodRepo}
+
+			result := <-mu.SetDefaultMFAMethod(context.Background(), passkeyMemberID, model.MFADefaultMethod{Method: tt.method})
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantStatus == 0 {
+				methodRepo.AssertCalled(t, "SetDefault", mock.Anything, passkeyMemberID, tt.method)
+			}
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_DeleteMFAMethod(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		deleteResult repo.ResultRepository
+		wantStatus   int
+	}{
+		{
+			name:   "Case 1: Success",
+			method: model.MFAMethodSMS,
+		},
+		{
+			name:         "Case 2: Error not enrolled",
+			method:       model.MFAMethodSMS,
+			deleteResult: repo.ResultRepository{Error: sql.ErrNoRows},
+			wantStatus:   http.StatusNotFound,
+		},
+		{
+			name:       "Case 3: Error totp is disabled from mfa settings",
+			method:     model.MFAMethodTOTP,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			methodRepo := new(mocksRepoMember.MemberMFAMethodRepository)
+			methodRepo.On("Delete", mock.Anything, passkeyMemberID, tt.method).Return(generateResultRepository(tt.deleteResult))
+			mu := &MemberUseCaseImpl{MemberMFAMethodRepo: methodRepo}
+
+			result := <-mu.DeleteMFAMethod(context.Background(), passkeyMemberID, tt.method)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+		})
+	}
+}
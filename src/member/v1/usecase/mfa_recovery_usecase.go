@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+)
+
+// RegenerateMFARecoveryCodes function for replacing recovery codes of a member, the old codes stop working
+func (mu *MemberUseCaseImpl) RegenerateMFARecoveryCodes(ctxReq context.Context, memberID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-RegenerateMFARecoveryCodes"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		if !strings.Contains(memberID, usrFormat) {
+			err := fmt.Errorf(helper.ErrorParameterInvalid, msgErrorMemberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		memberResult := <-mu.MemberRepoRead.Load(ctxReq, memberID)
+		if memberResult.Error != nil {
+			if memberResult.Error == sql.ErrNoRows {
+				memberResult.Error = fmt.Errorf(helper.ErrorDataNotFound, labelMember)
+			}
+
+			output <- ResultUseCase{Error: memberResult.Error, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		member, ok := memberResult.Result.(model.Member)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(msgErrorResultMember), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		if !member.MFAEnabled {
+			output <- ResultUseCase{Error: errors.New(model.ErrorMFANotEnabled), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		recoveryCodes, err := mu.issueMFARecoveryCodes(ctxReq, helper.TextAccount, member.ID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: model.MFARecoveryCodes{RecoveryCodes: recoveryCodes}}
+	})
+
+	return output
+}
+
+// RevokeMFATrustedDevices function for asking the second factor again on every device of a member
+func (mu *MemberUseCaseImpl) RevokeMFATrustedDevices(ctxReq context.Context, memberID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-RevokeMFATrustedDevices"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		if !strings.Contains(memberID, usrFormat) {
+			err := fmt.Errorf(helper.ErrorParameterInvalid, msgErrorMemberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if err := mu.revokeMFATrustedDevices(ctxReq, memberID); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: memberID}
+	})
+
+	return output
+}
+
+// issueMFARecoveryCodes function for generating recovery codes when account mfa is activated,
+// narwhal mfa is managed by admins and has no recovery codes
+func (mu *MemberUseCaseImpl) issueMFARecoveryCodes(ctxReq context.Context, requestFrom, memberID string) ([]string, error) {
+	ctx := "MemberUseCase-issueMFARecoveryCodes"
+	if requestFrom != helper.TextAccount {
+		return nil, nil
+	}
+
+	recoveryCodes, err := model.GenerateMFARecoveryCodes()
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "generate_recovery_codes", err, memberID)
+		return nil, errors.New(model.ErrorMFARecoveryCode)
+	}
+
+	codeHashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		codeHashes = append(codeHashes, model.HashMFARecoveryCode(code))
+	}
+
+	if saveResult := <-mu.MemberMFARecoveryRepo.ReplaceRecoveryCodes(ctxReq, memberID, codeHashes); saveResult.Error != nil {
+		return nil, errors.New(model.ErrorMFARecoveryCode)
+	}
+
+	// a new secret or new codes mean the member starts over, devices trusted before have to verify again
+	if err := mu.revokeMFATrustedDevices(ctxReq, memberID); err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "revoke_trusted_devices", err, memberID)
+	}
+
+	return recoveryCodes, nil
+}
+
+func (mu *MemberUseCaseImpl) revokeMFATrustedDevices(ctxReq context.Context, memberID string) error {
+	// trailing separator, so USR1 does not match USR12
+	prefix := model.GetMFATrustedDeviceKey(memberID, "")
+	if revokeResult := <-mu.MemberRepoRedis.RevokeAllAccess(ctxReq, prefix, ""); revokeResult.Error != nil {
+		return revokeResult.Error
+	}
+	return nil
+}
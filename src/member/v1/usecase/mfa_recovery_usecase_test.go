@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMemberUseCaseImpl_RegenerateMFARecoveryCodes(t *testing.T) {
+	tests := []struct {
+		name          string
+		memberID      string
+		memberResult  repo.ResultRepository
+		replaceResult repo.ResultRepository
+		wantStatus    int
+	}{
+		{
+			name:         "Case 1: Success",
+			memberID:     passkeyMemberID,
+			memberResult: repo.ResultRepository{Result: model.Member{ID: passkeyMemberID, MFAEnabled: true}},
+		},
+		{
+			name:       "Case 2: Error invalid member id",
+			memberID:   "123",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "Case 3: Error member not found",
+			memberID:     passkeyMemberID,
+			memberResult: repo.ResultRepository{Error: sql.ErrNoRows},
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:         "Case 4: Error mfa not enabled",
+			memberID:     passkeyMemberID,
+			memberResult: repo.ResultRepository{Result: model.Member{ID: passkeyMemberID}},
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			name:          "Case 5: Error save recovery codes",
+			memberID:      passkeyMemberID,
+			memberResult:  repo.ResultRepository{Result: model.Member{ID: passkeyMemberID, MFAEnabled: true}},
+			replaceResult: repo.ResultRepository{Error: errors.New("pq: connection refused")},
+			wantStatus:    http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberRepo := new(mocksRepoMember.MemberRepository)
+			memberRepo.On("Load", mock.Anything, tt.memberID).Return(generateResultRepository(tt.memberResult))
+			recoveryRepo := new(mocksRepoMember.MemberMFARecoveryRepository)
+			recoveryRepo.On("ReplaceRecoveryCodes", mock.Anything, tt.memberID, mock.Anything).Return(generateResultRepository(tt.replaceResult))
+			redisRepo := new(mocksRepoMember.MemberRepositoryRedis)
+			redisRepo.On("RevokeAllAccess", mock.Anything, model.GetMFATrustedDeviceKey(tt.memberID, ""), "").Return(generateResultRepository(repo.ResultRepository{}))
+			mu := &MemberUseCaseImpl{MemberRepoRead: memberRepo, MemberMFARecoveryRepo: recoveryRepo, MemberRepoRedis: redisRepo}
+
+			result := <-mu.RegenerateMFARecoveryCodes(context.Background(), tt.memberID)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantStatus == 0 {
+				codes, ok := result.Result.(model.MFARecoveryCodes)
+				assert.True(t, ok)
+				assert.Len(t, codes.RecoveryCodes, model.MFARecoveryCodeCount)
+				redisRepo.AssertCalled(t, "RevokeAllAccess", mock.Anything, model.GetMFATrustedDeviceKey(tt.memberID, ""), "")
+			}
+		})
+	}
+}
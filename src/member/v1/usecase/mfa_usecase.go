@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"context"
 	"crypto/rand"
-	"crypto/sha1"
 	"database/sql"
 	"encoding/base32"
 	"encoding/base64"
@@ -47,6 +46,15 @@ func (mu *MemberUseCaseImpl) GetMFASettings(ctxReq context.Context, uid string)
 		}
 
 		result := memberResult.Result.(model.MFASettings)
+
+		methodResult := <-mu.MemberMFAMethodRepo.FindByMemberID(ctxReq, uid)
+		if methodResult.Error != nil {
+			output <- ResultUseCase{Error: methodResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		result.Methods, _ = methodResult.Result.([]model.MFAMethod)
+		result.DefaultMethod = model.DefaultMFAMethod(result.MfaEnabled, result.Methods)
+
 		output <- ResultUseCase{Result: result}
 
 	})
@@ -235,6 +243,13 @@ func (mu *MemberUseCaseImpl) ActivateMFASettings(ctxReq context.Context, activat
 			return
 		}
 
+		recoveryCodes, err := mu.issueMFARecoveryCodes(ctxReq, activateData.RequestFrom, activateData.MemberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		activateData.RecoveryCodes = recoveryCodes
+
 		output <- ResultUseCase{Result: activateData}
 	})
 
@@ -264,17 +279,13 @@ func (mu *MemberUseCaseImpl) ActivateMFASettingV3(ctxReq context.Context, activa
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
 			return
 		}
-		passwordSalt := strings.TrimSpace(members.Salt)
-		passwordHasher := model.NewPBKDF2Hasher(model.SaltSize, model.SaltSize, model.IterationsCount, sha1.New)
-		passwordHasher.ParseSalt(passwordSalt)
-		base64Data := base64.StdEncoding.EncodeToString(passwordHasher.Hash([]byte(activateDatas.Password)))
-		if base64Data != members.Password {
+		if !mu.Hash.VerifyPassword(activateDatas.Password, members.Salt, members.Password) {
 			err := fmt.Errorf(model.ErrorMFAPassword)
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
 			return
 		}
 
-		errorValidateMFA := mu.validateMFAV3(activateDatas, base64Data, members)
+		errorValidateMFA := mu.validateMFAV3(activateDatas)
 		if errorValidateMFA != nil {
 			tracer.SetError(ctxReq, errorValidateMFA)
 			output <- ResultUseCase{Error: errorValidateMFA, HTTPStatus: http.StatusBadRequest}
@@ -290,6 +301,13 @@ func (mu *MemberUseCaseImpl) ActivateMFASettingV3(ctxReq context.Context, activa
 			return
 		}
 
+		recoveryCodes, err := mu.issueMFARecoveryCodes(ctxReq, activateDatas.RequestFrom, activateDatas.MemberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		activateDatas.RecoveryCodes = recoveryCodes
+
 		output <- ResultUseCase{Result: activateDatas}
 	})
 
@@ -342,10 +360,10 @@ func (mu *MemberUseCaseImpl) validateMFA(activateData model.MFAActivateSettings)
 	return nil
 }
 
-func (mu *MemberUseCaseImpl) validateMFAV3(activateData model.MFAActivateSettings, base64Data string, member model.Member) error {
+func (mu *MemberUseCaseImpl) validateMFAV3(activateData model.MFAActivateSettings) error {
 	byPassStatic := false
 	// only for exclude prod & key static
-	if os.Getenv("ENV") != "PROD" && activateData.SharedKeyText == model.StaticSharedMfaKeyForDev && activateData.Otp == model.StaticOTPMfaForDev && base64Data == member.Password {
+	if os.Getenv("ENV") != "PROD" && activateData.SharedKeyText == model.StaticSharedMfaKeyForDev && activateData.Otp == model.StaticOTPMfaForDev {
 		byPassStatic = true
 	}
 
@@ -426,6 +444,17 @@ func (mu *MemberUseCaseImpl) disabledMFA(ctxReq context.Context, requestFrom str
 		if updateResult.Error != nil {
 			return errors.New(model.ErrorDisabledMFA)
 		}
+
+		// recovery codes and trusted devices belong to the disabled second factor
+		if deleteResult := <-mu.MemberMFARecoveryRepo.DeleteRecoveryCodes(ctxReq, memberID); deleteResult.Error != nil {
+			return errors.New(model.ErrorDisabledMFA)
+		}
+		if err := mu.revokeMFATrustedDevices(ctxReq, memberID); err != nil {
+			return errors.New(model.ErrorDisabledMFA)
+		}
+		if deleteResult := <-mu.MemberMFAMethodRepo.DeleteByMemberID(ctxReq, memberID); deleteResult.Error != nil {
+			return errors.New(model.ErrorDisabledMFA)
+		}
 	} else if requestFrom == helper.TextNarwhal {
 		adminResult := <-mu.MemberMFARepoWrite.DisableNarwhalMFA(ctxReq, memberID)
 		if adminResult.Error != nil {
@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -40,12 +39,11 @@ func (mu *MemberUseCaseImpl) processRows(row *xlsx.Row) (*model.Member, error) {
 	birthDateStr := row.Cells[7].String()
 
 	if len(passwordPlain) > 0 {
-		member.Salt = mu.Hash.GenerateSalt()
-		err := mu.Hash.ParseSalt(member.Salt)
+		salt, password, err := mu.Hash.HashPassword(passwordPlain)
 		if err != nil {
 			return nil, err
 		}
-		member.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(passwordPlain)))
+		member.Salt, member.Password = salt, password
 		if len(member.Salt) > 255 {
 			return nil, fmt.Errorf("password for %s is invalid", email)
 		}
@@ -260,14 +258,12 @@ func (mu *MemberUseCaseImpl) importMember(ctxReq context.Context, ctx string, da
 	// optional when password exists only
 	if len(data.NewPassword) > 0 {
 		// encode the new password then replace the old password and salt
-		data.Salt = mu.Hash.GenerateSalt()
-		err := mu.Hash.ParseSalt(data.Salt)
+		salt, password, err := mu.Hash.HashPassword(data.NewPassword)
 		if err != nil {
 			tracer.SetError(ctxReq, err)
 			return nil, err
 		}
-
-		data.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(data.NewPassword)))
+		data.Salt, data.Password = salt, password
 		hasPassword = true
 	}
 
@@ -222,6 +222,54 @@ func (_m *MemberUseCase) Clients(ctxReq context.Context, token string) <-chan us
 	return r0
 }
 
+// ConfirmIdentityLink provides a mock function with given fields: ctxReq, data
+func (_m *MemberUseCase) ConfirmIdentityLink(ctxReq context.Context, data model.IdentityLinkConfirmation) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.IdentityLinkConfirmation) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// DeleteMFAMethod provides a mock function with given fields: ctxReq, memberID, method
+func (_m *MemberUseCase) DeleteMFAMethod(ctxReq context.Context, memberID string, method string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, method)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, method)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// DeletePasskey provides a mock function with given fields: ctxReq, memberID, passkeyID
+func (_m *MemberUseCase) DeletePasskey(ctxReq context.Context, memberID string, passkeyID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, passkeyID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, passkeyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // DisabledMFASetting provides a mock function with given fields: ctxReq, userID, requestFrom
 func (_m *MemberUseCase) DisabledMFASetting(ctxReq context.Context, userID string, requestFrom string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, userID, requestFrom)
@@ -238,6 +286,22 @@ func (_m *MemberUseCase) DisabledMFASetting(ctxReq context.Context, userID strin
 	return r0
 }
 
+// EnrolMFAMethod provides a mock function with given fields: ctxReq, memberID, data
+func (_m *MemberUseCase) EnrolMFAMethod(ctxReq context.Context, memberID string, data model.MFAMethodEnrolment) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.MFAMethodEnrolment) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // ForgotPassword provides a mock function with given fields: ctxReq, email
 func (_m *MemberUseCase) ForgotPassword(ctxReq context.Context, email string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, email)
@@ -270,6 +334,22 @@ func (_m *MemberUseCase) GenerateMFASettings(ctxReq context.Context, userID stri
 	return r0
 }
 
+// GeneratePasskeyRegistration provides a mock function with given fields: ctxReq, memberID
+func (_m *MemberUseCase) GeneratePasskeyRegistration(ctxReq context.Context, memberID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GetDetailMemberByEmail provides a mock function with given fields: email
 func (_m *MemberUseCase) GetDetailMemberByEmail(email string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(email)
@@ -302,6 +382,38 @@ func (_m *MemberUseCase) GetDetailMemberByID(ctxReq context.Context, uid string)
 	return r0
 }
 
+// GetDeviceSessions provides a mock function with given fields: ctxReq, memberID, token
+func (_m *MemberUseCase) GetDeviceSessions(ctxReq context.Context, memberID string, token string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, token)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetIdentities provides a mock function with given fields: ctxReq, memberID
+func (_m *MemberUseCase) GetIdentities(ctxReq context.Context, memberID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GetListMembers provides a mock function with given fields: ctxReq, params
 func (_m *MemberUseCase) GetListMembers(ctxReq context.Context, params *model.Parameters) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, params)
@@ -366,6 +478,38 @@ func (_m *MemberUseCase) GetNarwhalMFASettings(ctxReq context.Context, uid strin
 	return r0
 }
 
+// GetPasskeys provides a mock function with given fields: ctxReq, memberID
+func (_m *MemberUseCase) GetPasskeys(ctxReq context.Context, memberID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetPasswordHashReport provides a mock function with given fields: ctxReq
+func (_m *MemberUseCase) GetPasswordHashReport(ctxReq context.Context) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GetProfileComplete provides a mock function with given fields: ctxReq, uid
 func (_m *MemberUseCase) GetProfileComplete(ctxReq context.Context, uid string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, uid)
@@ -440,6 +584,38 @@ func (_m *MemberUseCase) InsertLogMember(ctxReq context.Context, oldData *model.
 	return r0
 }
 
+// LabelDeviceSession provides a mock function with given fields: ctxReq, memberID, sessionID, data
+func (_m *MemberUseCase) LabelDeviceSession(ctxReq context.Context, memberID string, sessionID string, data model.DeviceSessionLabel) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, sessionID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.DeviceSessionLabel) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, sessionID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// LinkIdentity provides a mock function with given fields: ctxReq, memberID, data
+func (_m *MemberUseCase) LinkIdentity(ctxReq context.Context, memberID string, data model.IdentityLinkRequest) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.IdentityLinkRequest) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // MigrateMember provides a mock function with given fields: ctxReq, members
 func (_m *MemberUseCase) MigrateMember(ctxReq context.Context, members *model.Members) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, members)
@@ -493,6 +669,22 @@ func (_m *MemberUseCase) PublishToKafkaUser(ctxReq context.Context, data *model.
 	return r0
 }
 
+// RegenerateMFARecoveryCodes provides a mock function with given fields: ctxReq, memberID
+func (_m *MemberUseCase) RegenerateMFARecoveryCodes(ctxReq context.Context, memberID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // RegenerateToken provides a mock function with given fields: ctxReq, data
 func (_m *MemberUseCase) RegenerateToken(ctxReq context.Context, data model.Member) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data)
@@ -525,6 +717,38 @@ func (_m *MemberUseCase) RegisterMember(ctxReq context.Context, data *model.Memb
 	return r0
 }
 
+// RegisterPasskey provides a mock function with given fields: ctxReq, memberID, data
+func (_m *MemberUseCase) RegisterPasskey(ctxReq context.Context, memberID string, data model.PasskeyRegistration) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.PasskeyRegistration) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// RenamePasskey provides a mock function with given fields: ctxReq, memberID, passkeyID, data
+func (_m *MemberUseCase) RenamePasskey(ctxReq context.Context, memberID string, passkeyID string, data model.PasskeyRename) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, passkeyID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.PasskeyRename) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, passkeyID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // ResendActivation provides a mock function with given fields: ctxReq, email
 func (_m *MemberUseCase) ResendActivation(ctxReq context.Context, email string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, email)
@@ -573,6 +797,54 @@ func (_m *MemberUseCase) RevokeAllAccess(ctxReq context.Context, uid string, tok
 	return r0
 }
 
+// RevokeDeviceSession provides a mock function with given fields: ctxReq, memberID, sessionID, token
+func (_m *MemberUseCase) RevokeDeviceSession(ctxReq context.Context, memberID string, sessionID string, token string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, sessionID, token)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, sessionID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// RevokeMFATrustedDevices provides a mock function with given fields: ctxReq, memberID
+func (_m *MemberUseCase) RevokeMFATrustedDevices(ctxReq context.Context, memberID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// RevokeOtherDeviceSessions provides a mock function with given fields: ctxReq, memberID, token
+func (_m *MemberUseCase) RevokeOtherDeviceSessions(ctxReq context.Context, memberID string, token string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, token)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SendEmailAddMember provides a mock function with given fields: ctxReq, data
 func (_m *MemberUseCase) SendEmailAddMember(ctxReq context.Context, data model.SuccessResponse) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data)
@@ -653,6 +925,22 @@ func (_m *MemberUseCase) SendEmailWelcomeMember(ctxReq context.Context, data mod
 	return r0
 }
 
+// SetDefaultMFAMethod provides a mock function with given fields: ctxReq, memberID, data
+func (_m *MemberUseCase) SetDefaultMFAMethod(ctxReq context.Context, memberID string, data model.MFADefaultMethod) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, data)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.MFADefaultMethod) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SyncPassword provides a mock function with given fields: ctxReq, token, oldPassword, newPassword
 func (_m *MemberUseCase) SyncPassword(ctxReq context.Context, token string, oldPassword string, newPassword string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, token, oldPassword, newPassword)
@@ -669,6 +957,22 @@ func (_m *MemberUseCase) SyncPassword(ctxReq context.Context, token string, oldP
 	return r0
 }
 
+// UnlinkIdentity provides a mock function with given fields: ctxReq, memberID, identityID
+func (_m *MemberUseCase) UnlinkIdentity(ctxReq context.Context, memberID string, identityID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, identityID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, identityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // UpdateDetailMemberByID provides a mock function with given fields: ctxReq, data
 func (_m *MemberUseCase) UpdateDetailMemberByID(ctxReq context.Context, data model.Member) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data)
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
+	uuid "github.com/satori/go.uuid"
+)
+
+// GeneratePasskeyRegistration function for starting the webauthn registration ceremony of a member
+func (mu *MemberUseCaseImpl) GeneratePasskeyRegistration(ctxReq context.Context, memberID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-GeneratePasskeyRegistration"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		memberResult := <-mu.MemberRepoRead.Load(ctxReq, memberID)
+		if memberResult.Error != nil {
+			if memberResult.Error == sql.ErrNoRows {
+				memberResult.Error = fmt.Errorf(helper.ErrorDataNotFound, labelMember)
+			}
+			output <- ResultUseCase{Error: memberResult.Error, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		member, ok := memberResult.Result.(model.Member)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(msgErrorResultMember), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		passkeys, err := mu.findPasskeys(ctxReq, memberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		if len(passkeys) >= model.PasskeyMaxPerMember {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyLimit), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		challenge, err := webauthn.NewChallenge()
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, "generate_challenge", err, memberID)
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		// one registration at a time, a new request replaces the previous challenge
+		paramRedis := &authModel.LoginSessionRedis{
+			Key:         getPasskeyRegistrationKey(memberID),
+			Token:       challenge,
+			ExpiredTime: mu.WebAuthn.Timeout,
+		}
+		if saveResult := <-mu.LoginSessionRedis.Save(ctxReq, paramRedis); saveResult.Error != nil {
+			helper.SendErrorLog(ctxReq, ctx, "save_passkey_challenge", saveResult.Error, memberID)
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		user := webauthn.UserEntity{
+			ID:          webauthn.EncodeBase64([]byte(member.ID)),
+			Name:        member.Email,
+			DisplayName: strings.TrimSpace(member.FirstName + " " + member.LastName),
+		}
+
+		output <- ResultUseCase{Result: mu.WebAuthn.CreationOptions(challenge, user, model.PasskeyDescriptors(passkeys))}
+	})
+
+	return output
+}
+
+// RegisterPasskey function for finishing the webauthn registration ceremony of a member
+func (mu *MemberUseCaseImpl) RegisterPasskey(ctxReq context.Context, memberID string, data model.PasskeyRegistration) <-chan ResultUseCase {
+	ctx := "MemberUseCase-RegisterPasskey"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		name, ok := model.NormalizePasskeyName(data.Name)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyName), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		// challenge is single use
+		challengeKey := getPasskeyRegistrationKey(memberID)
+		challengeResult := <-mu.LoginSessionRedis.Load(ctxReq, challengeKey)
+		challenge, _ := challengeResult.Result.(authModel.LoginSessionRedis)
+		if challengeResult.Error != nil || challenge.Token == "" {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyChallenge), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		<-mu.LoginSessionRedis.Delete(ctxReq, challengeKey)
+
+		credential, err := mu.WebAuthn.VerifyRegistration(challenge.Token, data.Credential)
+		if err != nil {
+			tags[helper.TextResponse] = err.Error()
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyRegistration), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		credentialID := webauthn.EncodeBase64(credential.ID)
+		if existing := <-mu.MemberPasskeyRepo.FindByCredentialID(ctxReq, credentialID); existing.Error == nil {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyRegistered), HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		passkeys, err := mu.findPasskeys(ctxReq, memberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		if len(passkeys) >= model.PasskeyMaxPerMember {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyLimit), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		passkey := &model.Passkey{
+			ID:           uuid.NewV4().String(),
+			MemberID:     memberID,
+			CredentialID: credentialID,
+			PublicKey:    credential.PublicKey,
+			Algorithm:    credential.Algorithm,
+			SignCount:    credential.SignCount,
+			Transports:   credential.Transports,
+			Name:         name,
+			Created:      time.Now(),
+		}
+		if aaguid, err := uuid.FromBytes(credential.AAGUID); err == nil && aaguid != uuid.Nil {
+			passkey.AAGUID = aaguid.String()
+		}
+
+		if saveResult := <-mu.MemberPasskeyRepo.Save(ctxReq, passkey); saveResult.Error != nil {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyRegistration), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: *passkey}
+	})
+
+	return output
+}
+
+// GetPasskeys function for listing passkeys of a member
+func (mu *MemberUseCaseImpl) GetPasskeys(ctxReq context.Context, memberID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-GetPasskeys"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = memberID
+		passkeys, err := mu.findPasskeys(ctxReq, memberID)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: passkeys}
+	})
+
+	return output
+}
+
+// RenamePasskey function for renaming passkey of a member
+func (mu *MemberUseCaseImpl) RenamePasskey(ctxReq context.Context, memberID, passkeyID string, data model.PasskeyRename) <-chan ResultUseCase {
+	ctx := "MemberUseCase-RenamePasskey"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = passkeyID
+		name, ok := model.NormalizePasskeyName(data.Name)
+		if !ok {
+			output <- ResultUseCase{Error: errors.New(model.ErrorPasskeyName), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		updateResult := <-mu.MemberPasskeyRepo.UpdateName(ctxReq, memberID, passkeyID, name)
+		if updateResult.Error != nil {
+			output <- passkeyRepoError(updateResult.Error)
+			return
+		}
+
+		output <- ResultUseCase{Result: model.PasskeyRename{Name: name}}
+	})
+
+	return output
+}
+
+// DeletePasskey function for removing passkey of a member
+func (mu *MemberUseCaseImpl) DeletePasskey(ctxReq context.Context, memberID, passkeyID string) <-chan ResultUseCase {
+	ctx := "MemberUseCase-DeletePasskey"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		tags[helper.TextArgs] = passkeyID
+		deleteResult := <-mu.MemberPasskeyRepo.Delete(ctxReq, memberID, passkeyID)
+		if deleteResult.Error != nil {
+			output <- passkeyRepoError(deleteResult.Error)
+			return
+		}
+
+		output <- ResultUseCase{Result: passkeyID}
+	})
+
+	return output
+}
+
+func (mu *MemberUseCaseImpl) findPasskeys(ctxReq context.Context, memberID string) ([]model.Passkey, error) {
+	passkeyResult := <-mu.MemberPasskeyRepo.FindByMemberID(ctxReq, memberID)
+	if passkeyResult.Error != nil {
+		return nil, passkeyResult.Error
+	}
+
+	passkeys, ok := passkeyResult.Result.([]model.Passkey)
+	if !ok {
+		return nil, errors.New("result is not passkeys")
+	}
+	return passkeys, nil
+}
+
+func passkeyRepoError(err error) ResultUseCase {
+	if err == sql.ErrNoRows {
+		return ResultUseCase{Error: errors.New(model.ErrorPasskeyNotFound), HTTPStatus: http.StatusNotFound}
+	}
+	return ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+}
+
+// getPasskeyRegistrationKey redis key format: `passkey-reg-USR123`
+func getPasskeyRegistrationKey(memberID string) string {
+	return strings.Join([]string{model.PasskeyRegistrationKeyRedis, memberID}, "-")
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	passkeyMemberID = "USR123"
+	passkeyID       = "d3b07384-d9a0-4c9b-8f4a-0d4f1f2e6c11"
+)
+
+func TestMemberUseCaseImpl_GetPasskeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoResult repo.ResultRepository
+		wantStatus int
+		wantCount  int
+	}{
+		{
+			name:       "Case 1: Success",
+			repoResult: repo.ResultRepository{Result: []model.Passkey{{ID: passkeyID}}},
+			wantCount:  1,
+		},
+		{
+			name:       "Case 2: Error database",
+			repoResult: repo.ResultRepository{Error: errors.New("pq: connection refused")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passkeyRepo := new(mocksRepoMember.MemberPasskeyRepository)
+			passkeyRepo.On("FindByMemberID", mock.Anything, passkeyMemberID).Return(generateResultRepository(tt.repoResult))
+			mu := &MemberUseCaseImpl{MemberPasskeyRepo: passkeyRepo}
+
+			result := <-mu.GetPasskeys(context.Background(), passkeyMemberID)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantStatus == 0 {
+				assert.Len(t, result.Result, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_RenamePasskey(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       model.PasskeyRename
+		repoResult repo.ResultRepository
+		wantName   string
+		wantStatus int
+	}{
+		{
+			name:     "Case 1: Success",
+			data:     model.PasskeyRename{Name: "  Work laptop "},
+			wantName: "Work laptop",
+		},
+		{
+			name:     "Case 2: Success default name",
+			data:     model.PasskeyRename{Name: " "},
+			wantName: model.PasskeyDefaultName,
+		},
+		{
+			name:       "Case 3: Error name too long",
+			data:       model.PasskeyRename{Name: strings.Repeat("a", model.PasskeyNameMaxLength+1)},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Case 4: Error passkey of another member",
+			data:       model.PasskeyRename{Name: "Phone"},
+			repoResult: repo.ResultRepository{Error: sql.ErrNoRows},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passkeyRepo := new(mocksRepoMember.MemberPasskeyRepository)
+			passkeyRepo.On("UpdateName", mock.Anything, passkeyMemberID, passkeyID, mock.Anything).Return(generateResultRepository(tt.repoResult))
+			mu := &MemberUseCaseImpl{MemberPasskeyRepo: passkeyRepo}
+
+			result := <-mu.RenamePasskey(context.Background(), passkeyMemberID, passkeyID, tt.data)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			if tt.wantStatus == 0 {
+				assert.Equal(t, model.PasskeyRename{Name: tt.wantName}, result.Result)
+				passkeyRepo.AssertCalled(t, "UpdateName", mock.Anything, passkeyMemberID, passkeyID, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_DeletePasskey(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoResult repo.ResultRepository
+		wantStatus int
+	}{
+		{
+			name: "Case 1: Success",
+		},
+		{
+			name:       "Case 2: Error passkey not found",
+			repoResult: repo.ResultRepository{Error: sql.ErrNoRows},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Case 3: Error database",
+			repoResult: repo.ResultRepository{Error: errors.New("pq: connection refused")},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passkeyRepo := new(mocksRepoMember.MemberPasskeyRepository)
+			passkeyRepo.On("Delete", mock.Anything, passkeyMemberID, passkeyID).Return(generateResultRepository(tt.repoResult))
+			mu := &MemberUseCaseImpl{MemberPasskeyRepo: passkeyRepo}
+
+			result := <-mu.DeletePasskey(context.Background(), passkeyMemberID, passkeyID)
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+)
+
+// GetPasswordHashReport function for counting members per password hashing algorithm,
+// legacy members move to the current algorithm when they log in
+func (mu *MemberUseCaseImpl) GetPasswordHashReport(ctxReq context.Context) <-chan ResultUseCase {
+	ctx := "MemberUseCase-GetPasswordHashReport"
+
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		countResult := <-mu.MemberQueryRead.CountPasswordAlgorithms(ctxReq)
+		if countResult.Error != nil {
+			output <- ResultUseCase{Error: countResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		counts, _ := countResult.Result.([]model.PasswordAlgorithmCount)
+		report := model.PasswordHashReport{Current: mu.Hash.Current(), Algorithms: counts}
+
+		tags[helper.TextResponse] = report
+		output <- ResultUseCase{Result: report}
+	})
+
+	return output
+}
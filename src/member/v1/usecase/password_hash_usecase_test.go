@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/query"
+	mockQuery "github.com/Bhinneka/user-service/src/member/v1/query/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func generateResultQuery(data query.ResultQuery) <-chan query.ResultQuery {
+	output := make(chan query.ResultQuery, 1)
+	output <- data
+	close(output)
+	return output
+}
+
+func TestMemberUseCaseImpl_GetPasswordHashReport(t *testing.T) {
+	hashers, _ := model.NewDefaultPasswordHashers("")
+	counts := []model.PasswordAlgorithmCount{
+		{Algorithm: model.PasswordAlgorithmArgon2id, Total: 2},
+		{Algorithm: model.PasswordAlgorithmPBKDF2SHA1, Total: 5},
+	}
+	tests := []struct {
+		name        string
+		countResult query.ResultQuery
+		wantStatus  int
+		wantResult  interface{}
+	}{
+		{
+			name:        "Case 1: Success",
+			countResult: query.ResultQuery{Result: counts},
+			wantResult:  model.PasswordHashReport{Current: model.PasswordAlgorithmArgon2id, Algorithms: counts},
+		},
+		{
+			name:        "Case 2: Error query",
+			countResult: query.ResultQuery{Error: errors.New("connection refused")},
+			wantStatus:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberQuery := new(mockQuery.MemberQuery)
+			memberQuery.On("CountPasswordAlgorithms", mock.Anything).Return(generateResultQuery(tt.countResult))
+
+			mu := &MemberUseCaseImpl{MemberQueryRead: memberQuery, Hash: hashers}
+			result := <-mu.GetPasswordHashReport(context.Background())
+			assert.Equal(t, tt.wantStatus, result.HTTPStatus)
+			assert.Equal(t, tt.wantResult, result.Result)
+		})
+	}
+}
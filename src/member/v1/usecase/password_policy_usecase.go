@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+)
+
+// passwordPolicy function for getting the password policy, the default one when it is not set
+func (mu *MemberUseCaseImpl) passwordPolicy() model.PasswordPolicy {
+	if mu.PasswordPolicy == nil {
+		return model.DefaultPasswordPolicy
+	}
+	return *mu.PasswordPolicy
+}
+
+// validatePasswordPolicy function for checking a new password against the password policy,
+// member is nil for a new member so its password history is not checked
+func (mu *MemberUseCaseImpl) validatePasswordPolicy(ctxReq context.Context, member *model.Member, password string) error {
+	policy := mu.passwordPolicy()
+
+	violations := policy.Validate(password)
+	if policy.CheckBreached && mu.BreachedPasswords != nil && mu.BreachedPasswords.IsBreached(password) {
+		violations = append(violations, model.MemberError{ID: model.PasswordRuleBreached, Message: model.ErrorPasswordBreached})
+	}
+
+	// comparing hashes is slow, only done for a password that passes the other rules
+	if len(violations) == 0 && member != nil && mu.isPasswordReused(ctxReq, member, password, policy.History) {
+		violations = append(violations, model.MemberError{ID: model.PasswordRuleHistory, Message: fmt.Sprintf(model.ErrorPasswordHistory, policy.History)})
+	}
+
+	return model.NewPasswordPolicyError(violations)
+}
+
+// isPasswordReused function for checking the password against the current password and
+// the previous ones of the member, history is the number of passwords checked
+func (mu *MemberUseCaseImpl) isPasswordReused(ctxReq context.Context, member *model.Member, password string, history int) bool {
+	ctx := "MemberUseCase-isPasswordReused"
+
+	if history <= 0 {
+		return false
+	}
+
+	if member.Password != "" && mu.Hash.VerifyPassword(password, member.Salt, member.Password) {
+		return true
+	}
+
+	if history == 1 || mu.MemberPasswordHistoryRepo == nil {
+		return false
+	}
+
+	historyResult := <-mu.MemberPasswordHistoryRepo.FindByMemberID(ctxReq, member.ID, history-1)
+	if historyResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "find_password_history", historyResult.Error, member.ID)
+		return false
+	}
+
+	histories, _ := historyResult.Result.([]model.PasswordHistory)
+	for _, previous := range histories {
+		if mu.Hash.VerifyPassword(password, previous.Salt, previous.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+// savePasswordHistory function for keeping the replaced password of a member, the password change goes on when it fails
+func (mu *MemberUseCaseImpl) savePasswordHistory(ctxReq context.Context, memberOld model.Member) {
+	ctx := "MemberUseCase-savePasswordHistory"
+
+	// the current password is in the member row, the history keeps the rest
+	keep := mu.passwordPolicy().History - 1
+	if memberOld.Password == "" || keep <= 0 || mu.MemberPasswordHistoryRepo == nil {
+		return
+	}
+
+	history := model.PasswordHistory{MemberID: memberOld.ID, Salt: memberOld.Salt, Password: memberOld.Password}
+	if saveResult := <-mu.MemberPasswordHistoryRepo.Save(ctxReq, history, keep); saveResult.Error != nil {
+		helper.SendErrorLog(ctxReq, ctx, "save_password_history", saveResult.Error, memberOld.ID)
+	}
+}
+
+// passwordPolicyViolations function for getting the violated rules of a password policy error
+func passwordPolicyViolations(err error) []model.MemberError {
+	var policyErr *model.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr.Violations
+	}
+	return nil
+}
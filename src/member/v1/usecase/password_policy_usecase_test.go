@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Bhinneka/golib/jsonschema"
+	mocksRepoMember "github.com/Bhinneka/user-service/mocks/src/member/v1/repo"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMemberUseCaseImpl_validatePasswordPolicy(t *testing.T) {
+	hashers, _ := model.NewPasswordHashers(model.PasswordAlgorithmBcrypt, model.NewBcryptAlgorithm(4))
+	currentSalt, currentPassword, _ := hashers.HashPassword("Bhinneka123!")
+	previousSalt, previousPassword, _ := hashers.HashPassword("Bhinneka456!")
+	// sha1 of `P@ssw0rd`
+	breached, _ := model.LoadBreachedPasswordCorpus(strings.NewReader("21BD12DC183F740EE76F27B78EB39C8AD972A757\n"))
+	member := &model.Member{ID: "USR123", Salt: currentSalt, Password: currentPassword}
+
+	tests := []struct {
+		name     string
+		member   *model.Member
+		password string
+		wantIDs  []string
+	}{
+		{
+			name:     "Case 1: Valid password",
+			member:   member,
+			password: "Bhinneka789!",
+		},
+		{
+			name:     "Case 2: Breaking character rules",
+			member:   member,
+			password: "bhinneka",
+			wantIDs:  []string{model.PasswordRuleUppercase, model.PasswordRuleNumber, model.PasswordRuleSymbol},
+		},
+		{
+			name:     "Case 3: Breached password",
+			member:   nil,
+			password: "P@ssw0rd",
+			wantIDs:  []string{model.PasswordRuleBreached},
+		},
+		{
+			name:     "Case 4: Same as current password",
+			member:   member,
+			password: "Bhinneka123!",
+			wantIDs:  []string{model.PasswordRuleHistory},
+		},
+		{
+			name:     "Case 5: Same as previous password",
+			member:   member,
+			password: "Bhinneka456!",
+			wantIDs:  []string{model.PasswordRuleHistory},
+		},
+		{
+			name:     "Case 6: New member has no history",
+			member:   nil,
+			password: "Bhinneka123!",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := new(mocksRepoMember.MemberPasswordHistoryRepository)
+			historyRepo.On("FindByMemberID", mock.Anything, "USR123", 2).Return(generateResultRepository(repo.ResultRepository{
+				Result: []model.PasswordHistory{{MemberID: "USR123", Salt: previousSalt, Password: previousPassword}},
+			}))
+
+			mu := &MemberUseCaseImpl{Hash: hashers, MemberPasswordHistoryRepo: historyRepo, BreachedPasswords: breached}
+			err := mu.validatePasswordPolicy(context.Background(), tt.member, tt.password)
+
+			var ids []string
+			for _, violation := range passwordPolicyViolations(err) {
+				ids = append(ids, violation.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, len(tt.wantIDs) > 0, err != nil)
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_validateNewPassword(t *testing.T) {
+	jsonschema.Load("../../../../schema/")
+	// sha1 of `P@ssw0rd`
+	breached, _ := model.LoadBreachedPasswordCorpus(strings.NewReader("21BD12DC183F740EE76F27B78EB39C8AD972A757\n"))
+
+	tests := []struct {
+		name       string
+		password   string
+		rePassword string
+		wantIDs    []string
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: Valid password",
+			password:   "Bhinneka789!",
+			rePassword: "Bhinneka789!",
+		},
+		{
+			name:       "Case 2: Password confirmation does not match",
+			password:   "Bhinneka789!",
+			rePassword: "Bhinneka780!",
+			wantErr:    true,
+		},
+		{
+			name:       "Case 3: Breaking character rules",
+			password:   "bhinneka",
+			rePassword: "bhinneka",
+			wantIDs:    []string{model.PasswordRuleUppercase, model.PasswordRuleNumber, model.PasswordRuleSymbol},
+			wantErr:    true,
+		},
+		{
+			name:       "Case 4: Breached password",
+			password:   "P@ssw0rd",
+			rePassword: "P@ssw0rd",
+			wantIDs:    []string{model.PasswordRuleBreached},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu := &MemberUseCaseImpl{BreachedPasswords: breached}
+			data := model.Member{ID: "USR123", NewPassword: tt.password, RePassword: tt.rePassword}
+			err := mu.validateNewPassword(context.Background(), data)
+
+			var ids []string
+			for _, violation := range passwordPolicyViolations(err) {
+				ids = append(ids, violation.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestMemberUseCaseImpl_savePasswordHistory(t *testing.T) {
+	tests := []struct {
+		name      string
+		memberOld model.Member
+		policy    *model.PasswordPolicy
+		wantSave  bool
+	}{
+		{
+			name:      "Case 1: Keep the replaced password",
+			memberOld: model.Member{ID: "USR123", Salt: "bcrypt$4", Password: "hashed"},
+			wantSave:  true,
+		},
+		{
+			name:      "Case 2: Member without password",
+			memberOld: model.Member{ID: "USR123"},
+		},
+		{
+			name:      "Case 3: History turned off",
+			memberOld: model.Member{ID: "USR123", Salt: "bcrypt$4", Password: "hashed"},
+			policy:    &model.PasswordPolicy{MinLength: 8, History: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := new(mocksRepoMember.MemberPasswordHistoryRepository)
+			historyRepo.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(generateResultRepository(repo.ResultRepository{}))
+
+			mu := &MemberUseCaseImpl{MemberPasswordHistoryRepo: historyRepo, PasswordPolicy: tt.policy}
+			mu.savePasswordHistory(context.Background(), tt.memberOld)
+
+			if tt.wantSave {
+				history := model.PasswordHistory{MemberID: "USR123", Salt: "bcrypt$4", Password: "hashed"}
+				historyRepo.AssertCalled(t, "Save", mock.Anything, history, model.DefaultPasswordPolicy.History-1)
+				return
+			}
+			historyRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
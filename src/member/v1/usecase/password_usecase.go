@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -38,7 +37,7 @@ func (mu *MemberUseCaseImpl) UpdatePassword(ctxReq context.Context, token, uid,
 		memberOld, member, err := mu.validateUpdatePassword(ctxReq, params, uid)
 		if err != nil {
 			tags[helper.TextResponse] = err
-			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest, ErrorData: passwordPolicyViolations(err)}
 			return
 		}
 
@@ -47,6 +46,7 @@ func (mu *MemberUseCaseImpl) UpdatePassword(ctxReq context.Context, token, uid,
 			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusBadRequest}
 			return
 		}
+		mu.savePasswordHistory(ctxReq, memberOld)
 
 		// logout all session login
 		err = mu.revokeAllAccessProccess(ctxReq, member.ID, token, false)
@@ -113,34 +113,27 @@ func (mu *MemberUseCaseImpl) validateUpdatePassword(ctxReq context.Context, para
 
 	memberOldVUP = memberVUP
 
-	// validate password format
-	if err := helper.ValidatePassword(params.NewPassword); err != nil {
-		return memberOldVUP, memberVUP, err
-	}
-
 	// matching old password with new password
 	if params.OldPassword == params.NewPassword {
 		err := errors.New("new password and previous password cannot be same")
 		return memberOldVUP, memberVUP, err
 	}
 
-	passwordSaltDB := strings.TrimSpace(memberVUP.Salt)
-	mu.Hash.ParseSalt(passwordSaltDB)
-	base64Data := base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(params.OldPassword)))
-
-	if memberVUP.Password != base64Data {
+	if !mu.Hash.VerifyPassword(params.OldPassword, memberVUP.Salt, memberVUP.Password) {
 		err := errors.New(model.ErrorOldPasswordInvalid)
 		return memberOldVUP, memberVUP, err
 	}
 
+	// validate password policy
+	if err := mu.validatePasswordPolicy(ctxReq, &memberVUP, params.NewPassword); err != nil {
+		return memberOldVUP, memberVUP, err
+	}
+
 	// encode the new password then replace the old password and salt
-	memberVUP.Salt = mu.Hash.GenerateSalt()
-	err = mu.Hash.ParseSalt(memberVUP.Salt)
+	memberVUP.Salt, memberVUP.Password, err = mu.Hash.HashPassword(params.NewPassword)
 	if err != nil {
 		return memberOldVUP, memberVUP, err
 	}
-
-	memberVUP.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(params.NewPassword)))
 	memberVUP.LastPasswordModified = time.Now()
 
 	return memberOldVUP, memberVUP, nil
@@ -173,36 +166,29 @@ func (mu *MemberUseCaseImpl) validateUpdateSyncPassword(ctxReq context.Context,
 
 	memberOld = member
 
-	// validate password format
-	if err := helper.ValidatePassword(params.NewPassword); err != nil {
-		return memberOld, member, err
-	}
-
 	// matching old password with new password
 	if params.OldPassword == params.NewPassword {
 		err := errors.New("new password and previous password cannot be same")
 		return memberOld, member, err
 	}
 
-	passwordSaltDB := strings.TrimSpace(member.Salt)
-	mu.Hash.ParseSalt(passwordSaltDB)
-	base64Data := base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(params.OldPassword)))
-
 	if memberOld.Password != "" {
-		if member.Password != base64Data {
+		if !mu.Hash.VerifyPassword(params.OldPassword, member.Salt, member.Password) {
 			err := errors.New(model.ErrorOldPasswordInvalid)
 			return memberOld, member, err
 		}
 	}
 
+	// validate password policy
+	if err := mu.validatePasswordPolicy(ctxReq, &member, params.NewPassword); err != nil {
+		return memberOld, member, err
+	}
+
 	// encode the new password then replace the old password and salt
-	member.Salt = mu.Hash.GenerateSalt()
-	err = mu.Hash.ParseSalt(member.Salt)
+	member.Salt, member.Password, err = mu.Hash.HashPassword(params.NewPassword)
 	if err != nil {
 		return memberOld, member, err
 	}
-
-	member.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(params.NewPassword)))
 	member.LastPasswordModified = time.Now()
 
 	return memberOld, member, nil
@@ -216,22 +202,20 @@ func (mu *MemberUseCaseImpl) AddNewPassword(ctxReq context.Context, data model.M
 	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
 		defer close(output)
 
-		errorNewPassword := mu.validateNewPassword(data)
+		errorNewPassword := mu.validateNewPassword(ctxReq, data)
 		if errorNewPassword != nil {
 			tags[helper.TextResponse] = errorNewPassword
-			output <- ResultUseCase{Error: errorNewPassword, HTTPStatus: http.StatusBadRequest}
+			output <- ResultUseCase{Error: errorNewPassword, HTTPStatus: http.StatusBadRequest, ErrorData: passwordPolicyViolations(errorNewPassword)}
 			return
 		}
 
 		// encode the new password then replace the old password and salt
-		data.Salt = mu.Hash.GenerateSalt()
-		err := mu.Hash.ParseSalt(data.Salt)
+		salt, password, err := mu.Hash.HashPassword(data.NewPassword)
 		if err != nil {
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
 			return
 		}
-
-		data.Password = base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(data.NewPassword)))
+		data.Salt, data.Password = salt, password
 		data.LastPasswordModified = time.Now()
 
 		memberResult := <-mu.MemberRepoWrite.Save(ctxReq, data)
@@ -252,7 +236,7 @@ func (mu *MemberUseCaseImpl) AddNewPassword(ctxReq context.Context, data model.M
 }
 
 // validateNewPassword function for validate new password
-func (mu *MemberUseCaseImpl) validateNewPassword(data model.Member) error {
+func (mu *MemberUseCaseImpl) validateNewPassword(ctxReq context.Context, data model.Member) error {
 	// validate request
 	params := model.PayloadUpdate{
 		NewPassword: data.NewPassword,
@@ -269,12 +253,8 @@ func (mu *MemberUseCaseImpl) validateNewPassword(data model.Member) error {
 		return err
 	}
 
-	// validate password format
-	if err := helper.ValidatePassword(data.NewPassword); err != nil {
-		return err
-	}
-
-	return nil
+	// validate password policy
+	return mu.validatePasswordPolicy(ctxReq, &data, data.NewPassword)
 }
 
 func (mu *MemberUseCaseImpl) ClaimsToken(token string) (jwt.MapClaims, error) {
@@ -315,7 +295,7 @@ func (mu *MemberUseCaseImpl) SyncPassword(ctxReq context.Context, token, oldPass
 		memberOldSP, memberSP, errSP := mu.validateUpdateSyncPassword(ctxReq, paramsSP, claims["email"].(string), claims["memberType"].(string))
 		if errSP != nil {
 			tags[helper.TextResponse] = errSP
-			output <- ResultUseCase{Error: errSP, HTTPStatus: http.StatusBadRequest}
+			output <- ResultUseCase{Error: errSP, HTTPStatus: http.StatusBadRequest, ErrorData: passwordPolicyViolations(errSP)}
 			return
 		}
 
@@ -338,11 +318,7 @@ func (mu *MemberUseCaseImpl) SyncPassword(ctxReq context.Context, token, oldPass
 
 		// check password bbcom if bcom password not set
 		if memberOldSP.Password == "" {
-			passwordSaltDB := strings.TrimSpace(contactSP.Salt)
-			mu.Hash.ParseSalt(passwordSaltDB)
-			base64Data := base64.StdEncoding.EncodeToString(mu.Hash.Hash([]byte(paramsSP.OldPassword)))
-
-			if contactSP.Password != base64Data {
+			if !mu.Hash.VerifyPassword(paramsSP.OldPassword, contactSP.Salt, contactSP.Password) {
 				errSP := errors.New(model.ErrorOldPasswordInvalid)
 				tags[helper.TextResponse] = errSP
 				output <- ResultUseCase{Error: errSP, HTTPStatus: http.StatusBadRequest}
@@ -356,6 +332,7 @@ func (mu *MemberUseCaseImpl) SyncPassword(ctxReq context.Context, token, oldPass
 			output <- ResultUseCase{Error: saveResultSP.Error, HTTPStatus: http.StatusBadRequest}
 			return
 		}
+		mu.savePasswordHistory(ctxReq, memberOldSP)
 
 		// logout all session login
 		errSP = mu.revokeAllAccessProccess(ctxReq, memberSP.ID, token, false)
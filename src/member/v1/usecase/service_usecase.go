@@ -208,8 +208,8 @@ func (mu *MemberUseCaseImpl) validateMemberDataRegister(ctxReq context.Context,
 		return err
 	}
 
-	// validate password format
-	if err := helper.ValidatePassword(data.NewPassword); err != nil {
+	// validate password policy
+	if err := mu.validatePasswordPolicy(ctxReq, nil, data.NewPassword); err != nil {
 		return err
 	}
 
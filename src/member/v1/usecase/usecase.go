@@ -34,6 +34,7 @@ type MemberUseCase interface {
 
 	ActivateNewPassword(ctxReq context.Context, token, password, rePassword string) <-chan ResultUseCase
 	GetListMembers(ctxReq context.Context, params *model.Parameters) <-chan ResultUseCase
+	GetPasswordHashReport(ctxReq context.Context) <-chan ResultUseCase
 	RegenerateToken(ctxReq context.Context, data model.Member) <-chan ResultUseCase
 	MigrateMember(ctxReq context.Context, members *model.Members) <-chan ResultUseCase
 	ResendActivation(ctxReq context.Context, email string) <-chan ResultUseCase
@@ -48,10 +49,34 @@ type MemberUseCase interface {
 	UpdateProfileName(ctxReq context.Context, data model.ProfileName) <-chan ResultUseCase
 	RevokeAccess(ctxReq context.Context, uid string, jti string) <-chan ResultUseCase
 
+	// Device session related
+	GetDeviceSessions(ctxReq context.Context, memberID, token string) <-chan ResultUseCase
+	LabelDeviceSession(ctxReq context.Context, memberID, sessionID string, data model.DeviceSessionLabel) <-chan ResultUseCase
+	RevokeDeviceSession(ctxReq context.Context, memberID, sessionID, token string) <-chan ResultUseCase
+	RevokeOtherDeviceSessions(ctxReq context.Context, memberID, token string) <-chan ResultUseCase
+
 	// MFA Related
 	GetMFASettings(ctxReq context.Context, uid string) <-chan ResultUseCase
 	ActivateMFASettings(ctxReq context.Context, activateData model.MFAActivateSettings) <-chan ResultUseCase
 	ActivateMFASettingV3(ctxReq context.Context, activateData model.MFAActivateSettings) <-chan ResultUseCase
+	RegenerateMFARecoveryCodes(ctxReq context.Context, memberID string) <-chan ResultUseCase
+	RevokeMFATrustedDevices(ctxReq context.Context, memberID string) <-chan ResultUseCase
+	EnrolMFAMethod(ctxReq context.Context, memberID string, data model.MFAMethodEnrolment) <-chan ResultUseCase
+	SetDefaultMFAMethod(ctxReq context.Context, memberID string, data model.MFADefaultMethod) <-chan ResultUseCase
+	DeleteMFAMethod(ctxReq context.Context, memberID, method string) <-chan ResultUseCase
+
+	// Passkey related
+	GeneratePasskeyRegistration(ctxReq context.Context, memberID string) <-chan ResultUseCase
+	RegisterPasskey(ctxReq context.Context, memberID string, data model.PasskeyRegistration) <-chan ResultUseCase
+	GetPasskeys(ctxReq context.Context, memberID string) <-chan ResultUseCase
+	RenamePasskey(ctxReq context.Context, memberID, passkeyID string, data model.PasskeyRename) <-chan ResultUseCase
+	DeletePasskey(ctxReq context.Context, memberID, passkeyID string) <-chan ResultUseCase
+
+	// Identity related
+	GetIdentities(ctxReq context.Context, memberID string) <-chan ResultUseCase
+	LinkIdentity(ctxReq context.Context, memberID string, data model.IdentityLinkRequest) <-chan ResultUseCase
+	UnlinkIdentity(ctxReq context.Context, memberID, identityID string) <-chan ResultUseCase
+	ConfirmIdentityLink(ctxReq context.Context, data model.IdentityLinkConfirmation) <-chan ResultUseCase
 
 	// Import Related
 	ParseMemberData(ctxReq context.Context, input []byte) ([]*model.Member, error)
@@ -52,6 +52,7 @@ func (h *HTTPMemberHandler) Mount(group *echo.Group) {
 	group.POST("/resend-activation", h.ResendActivation)
 	group.POST("/validate-token", h.ValidateToken)
 	group.GET("/employee/activation", h.ActivationMerchantEmployee)
+	group.POST("/identities/confirm", h.ConfirmIdentityLink)
 }
 
 // MountMe function for mounting me routes
@@ -66,10 +67,27 @@ func (h *HTTPMemberHandler) MountMe(group *echo.Group) {
 	group.GET("/mfa/generate", h.GenerateMFASettings)
 	group.POST("/mfa/activation", h.ActivateMFASettings)
 	group.DELETE("/mfa", h.DisabledMFASetting)
+	group.POST("/mfa/recovery-codes", h.RegenerateMFARecoveryCodes)
+	group.DELETE("/mfa/trusted-devices", h.RevokeMFATrustedDevices)
+	group.POST("/mfa/methods", h.EnrolMFAMethod)
+	group.PUT("/mfa/default", h.SetDefaultMFAMethod)
+	group.DELETE("/mfa/methods/:method", h.DeleteMFAMethod)
+	group.GET("/passkeys", h.GetPasskeys)
+	group.POST("/passkeys", h.RegisterPasskey)
+	group.POST("/passkeys/options", h.GeneratePasskeyRegistration)
+	group.PUT("/passkeys/:passkeyID", h.RenamePasskey)
+	group.DELETE("/passkeys/:passkeyID", h.DeletePasskey)
+	group.GET("/identities", h.GetIdentities)
+	group.POST("/identities", h.LinkIdentity)
+	group.DELETE("/identities/:identityID", h.UnlinkIdentity)
 	group.DELETE("/revoke-all", h.RevokeAllAccess)
 	group.GET("/login-activity", h.GetLoginActivity)
 	group.GET("/profile-complete", h.GetProfileComplete)
 	group.GET("/revoke", h.RevokeAccess)
+	group.GET("/sessions", h.GetSessions)
+	group.PUT("/sessions/:sessionID", h.LabelSession)
+	group.DELETE("/sessions/:sessionID", h.RevokeSession)
+	group.DELETE("/sessions", h.RevokeOtherSessions)
 
 	// specific for narwhal
 	group.GET("/mfa-narwhal", h.GetNarwhalMFASettings)
@@ -86,16 +104,24 @@ func (h *HTTPMemberHandler) MountMe(group *echo.Group) {
 
 // MountAdmin function for mounting anonymous membership endpoints
 func (h *HTTPMemberHandler) MountAdmin(group *echo.Group) {
-	group.POST("/import", h.ImportMember)
 	group.GET("/member", h.GetMembers)
+	group.GET("/member/password-hash-report", h.GetPasswordHashReport)
 	group.PUT("/member/regenerate-token/:memberID", h.RegenerateToken)
 	group.GET("/member/:memberID", h.GetDetailMember)
+	group.GET("/member/:memberID/sessions", h.GetMemberSessions)
+	group.DELETE("/member/:memberID/sessions/:sessionID", h.RevokeMemberSession)
+	group.DELETE("/member/:memberID/sessions", h.RevokeMemberSessions)
 	group.POST("/member/migrate", h.MigrateData)
 	group.POST("/bulk-member-send", h.BulkMemberSend)
 	group.POST("/member-send", h.MemberSend)
 	group.POST("/member", h.AddNewMember) // add new member
 }
 
+// MountImport function for mounting member import endpoint
+func (h *HTTPMemberHandler) MountImport(group *echo.Group) {
+	group.POST("/import", h.ImportMember)
+}
+
 // MountMember function for mounting member endpoints
 // special endpoints for dolphin
 func (h *HTTPMemberHandler) MountMember(group *echo.Group) {
@@ -245,7 +271,7 @@ func (h *HTTPMemberHandler) UpdatePassword(c echo.Context) error {
 	passResultV2 := <-h.MemberUseCase.UpdatePassword(newCtx, token, memberID, oldPasswordV2, newPasswordV2)
 
 	if passResultV2.Error != nil {
-		return shared.NewHTTPResponse(passResultV2.HTTPStatus, passResultV2.Error.Error()).JSON(c)
+		return errorResponse(c, passResultV2)
 	}
 
 	res, ok := passResultV2.Result.(model.SuccessResponse)
@@ -320,6 +346,8 @@ func (h *HTTPMemberHandler) RegisterMember(c echo.Context) error {
 	memberV2.GenderString = c.FormValue(model.FieldGender)
 	memberV2.BirthDateString = c.FormValue(model.FieldDOB)
 	memberV2.Mobile = c.FormValue(model.FieldMobile)
+	memberV2.CaptchaResponse = c.FormValue(model.FieldCaptchaResponse)
+	memberV2.IP = middleware.ClientIP(c)
 	memberV2.Type = "register"
 
 	registerType := c.FormValue("registerType")
@@ -335,7 +363,7 @@ func (h *HTTPMemberHandler) RegisterMember(c echo.Context) error {
 
 	saveResult := <-h.MemberUseCase.RegisterMember(c.Request().Context(), memberV2)
 	if saveResult.Error != nil {
-		return shared.NewHTTPResponse(saveResult.HTTPStatus, saveResult.Error.Error()).JSON(c)
+		return errorResponse(c, saveResult)
 	}
 
 	result, ok := saveResult.Result.(model.SuccessResponse)
@@ -410,7 +438,7 @@ func (h *HTTPMemberHandler) ChangeForgotPassword(c echo.Context) error {
 
 	passResult := <-h.MemberUseCase.ChangeForgotPassword(c.Request().Context(), token, newPassword, rePassword, requestFrom)
 	if passResult.Error != nil {
-		return shared.NewHTTPResponse(passResult.HTTPStatus, passResult.Error.Error()).JSON(c)
+		return errorResponse(c, passResult)
 	}
 
 	res, ok := passResult.Result.(model.SuccessResponse)
@@ -430,7 +458,7 @@ func (h *HTTPMemberHandler) ActivateNewPassword(c echo.Context) error {
 
 	activateResult := <-h.MemberUseCase.ActivateNewPassword(c.Request().Context(), token, newPassword, rePassword)
 	if activateResult.Error != nil {
-		return shared.NewHTTPResponse(activateResult.HTTPStatus, activateResult.Error.Error()).JSON(c)
+		return errorResponse(c, activateResult)
 	}
 
 	res, ok := activateResult.Result.(model.SuccessResponse)
@@ -581,6 +609,16 @@ func (h *HTTPMemberHandler) GetMembers(c echo.Context) error {
 	return shared.NewHTTPResponse(http.StatusOK, "Get Members Response", member.Members, meta).JSON(c)
 }
 
+// GetPasswordHashReport function for getting the number of members per password hashing algorithm
+func (h *HTTPMemberHandler) GetPasswordHashReport(c echo.Context) error {
+	reportResult := <-h.MemberUseCase.GetPasswordHashReport(c.Request().Context())
+	if reportResult.Error != nil {
+		return shared.NewHTTPResponse(reportResult.HTTPStatus, reportResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Password Hash Report Response", reportResult.Result).JSON(c)
+}
+
 // MemberSend function for send to kafka for updated nav
 func (h *HTTPMemberHandler) MemberSend(c echo.Context) error {
 	memberID := c.FormValue(memberID)
@@ -712,7 +750,7 @@ func (h *HTTPMemberHandler) SyncPassword(c echo.Context) error {
 	newCtx = context.WithValue(newCtx, middleware.ContextKeyClientIP, c.RealIP())
 	syncPassword := <-h.MemberUseCase.SyncPassword(newCtx, token, payload.OldPassword, payload.NewPassword)
 	if syncPassword.Error != nil {
-		return shared.NewHTTPResponse(syncPassword.HTTPStatus, syncPassword.Error.Error()).JSON(c)
+		return errorResponse(c, syncPassword)
 	}
 
 	return shared.NewHTTPResponse(http.StatusOK, "Success sync password").JSON(c)
@@ -781,3 +819,12 @@ func (h *HTTPMemberHandler) ActivationMerchantEmployee(c echo.Context) error {
 
 	return shared.NewHTTPResponse(http.StatusOK, "Success activation merchant employee").JSON(c)
 }
+
+// errorResponse function for returning the error of a use case result,
+// the violated rules of the password policy are returned as data
+func errorResponse(c echo.Context, result usecase.ResultUseCase) error {
+	if len(result.ErrorData) > 0 {
+		return shared.NewHTTPResponse(result.HTTPStatus, result.Error.Error(), result.ErrorData).JSON(c)
+	}
+	return shared.NewHTTPResponse(result.HTTPStatus, result.Error.Error()).JSON(c)
+}
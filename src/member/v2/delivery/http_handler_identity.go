@@ -0,0 +1,80 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/middleware"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared"
+	"github.com/labstack/echo"
+)
+
+// GetIdentities function for listing provider accounts linked to the member
+func (h *HTTPMemberHandler) GetIdentities(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	identityResult := <-h.MemberUseCase.GetIdentities(c.Request().Context(), memberID)
+	if identityResult.Error != nil {
+		return shared.NewHTTPResponse(identityResult.HTTPStatus, identityResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Get Identities", identityResult.Result).JSON(c)
+}
+
+// LinkIdentity function for linking the account of an oidc connector to the member
+func (h *HTTPMemberHandler) LinkIdentity(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	data := model.IdentityLinkRequest{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	identityResult := <-h.MemberUseCase.LinkIdentity(c.Request().Context(), memberID, data)
+	if identityResult.Error != nil {
+		return shared.NewHTTPResponse(identityResult.HTTPStatus, identityResult.Error.Error()).JSON(c)
+	}
+
+	if identityResult.Result == model.SuccessMFAOTPSend {
+		return shared.NewHTTPResponse(http.StatusOK, model.SuccessMFAOTPSend).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusCreated, "Success link identity", identityResult.Result).JSON(c)
+}
+
+// UnlinkIdentity function for unlinking a provider account from the member
+func (h *HTTPMemberHandler) UnlinkIdentity(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	identityResult := <-h.MemberUseCase.UnlinkIdentity(c.Request().Context(), memberID, c.Param("identityID"))
+	if identityResult.Error != nil {
+		return shared.NewHTTPResponse(identityResult.HTTPStatus, identityResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success unlink identity").JSON(c)
+}
+
+// ConfirmIdentityLink function for linking a provider account with the token of the confirmation email
+func (h *HTTPMemberHandler) ConfirmIdentityLink(c echo.Context) error {
+	data := model.IdentityLinkConfirmation{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	identityResult := <-h.MemberUseCase.ConfirmIdentityLink(c.Request().Context(), data)
+	if identityResult.Error != nil {
+		return shared.NewHTTPResponse(identityResult.HTTPStatus, identityResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success link identity", identityResult.Result).JSON(c)
+}
@@ -58,7 +58,10 @@ func (h *HTTPMemberHandler) ActivateMFASettings(c echo.Context) error {
 		return shared.NewHTTPResponse(activateResult.HTTPStatus, activateResult.Error.Error()).JSON(c)
 	}
 
-	return shared.NewHTTPResponse(http.StatusOK, model.SuccessMFAActivation).JSON(c)
+	// recovery codes are shown once, only their hashes are kept
+	activated, _ := activateResult.Result.(model.MFAActivateSettings)
+	recoveryCodes := model.MFARecoveryCodes{RecoveryCodes: activated.RecoveryCodes}
+	return shared.NewHTTPResponse(http.StatusOK, model.SuccessMFAActivation, recoveryCodes).JSON(c)
 }
 
 // DisabledMFASetting function for getting status mfa settings
@@ -75,3 +78,92 @@ func (h *HTTPMemberHandler) DisabledMFASetting(c echo.Context) error {
 
 	return shared.NewHTTPResponse(http.StatusOK, "Success disable MFA").JSON(c)
 }
+
+// RegenerateMFARecoveryCodes function for replacing recovery codes, the old codes stop working
+func (h *HTTPMemberHandler) RegenerateMFARecoveryCodes(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	recoveryResult := <-h.MemberUseCase.RegenerateMFARecoveryCodes(c.Request().Context(), memberID)
+	if recoveryResult.Error != nil {
+		return shared.NewHTTPResponse(recoveryResult.HTTPStatus, recoveryResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success regenerate recovery codes", recoveryResult.Result).JSON(c)
+}
+
+// RevokeMFATrustedDevices function for asking the second factor again on every trusted device
+func (h *HTTPMemberHandler) RevokeMFATrustedDevices(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	revokeResult := <-h.MemberUseCase.RevokeMFATrustedDevices(c.Request().Context(), memberID)
+	if revokeResult.Error != nil {
+		return shared.NewHTTPResponse(revokeResult.HTTPStatus, revokeResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success revoke trusted devices").JSON(c)
+}
+
+// EnrolMFAMethod function for enrolling email or sms second factor, without otp a code is sent to the target
+func (h *HTTPMemberHandler) EnrolMFAMethod(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	data := model.MFAMethodEnrolment{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	methodResult := <-h.MemberUseCase.EnrolMFAMethod(c.Request().Context(), memberID, data)
+	if methodResult.Error != nil {
+		return shared.NewHTTPResponse(methodResult.HTTPStatus, methodResult.Error.Error()).JSON(c)
+	}
+
+	if data.OTP == "" {
+		return shared.NewHTTPResponse(http.StatusOK, model.SuccessMFAOTPSend).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success enrol MFA method", methodResult.Result).JSON(c)
+}
+
+// SetDefaultMFAMethod function for choosing the second factor asked first at login
+func (h *HTTPMemberHandler) SetDefaultMFAMethod(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	data := model.MFADefaultMethod{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	methodResult := <-h.MemberUseCase.SetDefaultMFAMethod(c.Request().Context(), memberID, data)
+	if methodResult.Error != nil {
+		return shared.NewHTTPResponse(methodResult.HTTPStatus, methodResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success set default MFA method", methodResult.Result).JSON(c)
+}
+
+// DeleteMFAMethod function for removing email or sms second factor
+func (h *HTTPMemberHandler) DeleteMFAMethod(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	methodResult := <-h.MemberUseCase.DeleteMFAMethod(c.Request().Context(), memberID, c.Param("method"))
+	if methodResult.Error != nil {
+		return shared.NewHTTPResponse(methodResult.HTTPStatus, methodResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success delete MFA method").JSON(c)
+}
@@ -0,0 +1,139 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/usecase"
+)
+
+func TestRegenerateMFARecoveryCodes(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MFARecoveryCodes{RecoveryCodes: []string{"abcde-fghij"}}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusBadRequest, Error: errors.New(model.ErrorMFANotEnabled),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "RegenerateMFARecoveryCodes", 2, tests, (*HTTPMemberHandler).RegenerateMFARecoveryCodes)
+}
+
+func TestRevokeMFATrustedDevices(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: "USR123"},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusInternalServerError, Error: errors.New(msgErrorPq),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "RevokeMFATrustedDevices", 2, tests, (*HTTPMemberHandler).RevokeMFATrustedDevices)
+}
+
+func TestEnrolMFAMethod(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			payload:         `{"method":"sms","target":"08123456789","otp":"123456"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MFAMethod{Method: model.MFAMethodSMS, TargetMask: "*******6789"}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:    testCaseNegative2,
+			token:   tokenAdmin,
+			payload: `{"method":"sms","target":"08123456789"}`,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusTooManyRequests, Error: errors.New(model.ErrorMFAOTPResendLimit),
+			},
+			wantStatusCode: http.StatusTooManyRequests,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "EnrolMFAMethod", 3, tests, (*HTTPMemberHandler).EnrolMFAMethod)
+}
+
+func TestSetDefaultMFAMethod(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			payload:         `{"method":"email"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MFADefaultMethod{Method: model.MFAMethodEmail}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:    testCaseNegative2,
+			token:   tokenAdmin,
+			payload: `{"method":"sms"}`,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusBadRequest, Error: errors.New(model.ErrorMFAMethodNotEnrolled),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "SetDefaultMFAMethod", 3, tests, (*HTTPMemberHandler).SetDefaultMFAMethod)
+}
+
+func TestDeleteMFAMethod(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MFAMethodSMS},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorMFAMethodNotEnrolled),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "DeleteMFAMethod", 3, tests, (*HTTPMemberHandler).DeleteMFAMethod)
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/middleware"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared"
+	"github.com/labstack/echo"
+)
+
+// GetPasskeys function for listing passkeys of the member
+func (h *HTTPMemberHandler) GetPasskeys(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	passkeyResult := <-h.MemberUseCase.GetPasskeys(c.Request().Context(), memberID)
+	if passkeyResult.Error != nil {
+		return shared.NewHTTPResponse(passkeyResult.HTTPStatus, passkeyResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Get Passkeys", passkeyResult.Result).JSON(c)
+}
+
+// GeneratePasskeyRegistration function for getting webauthn creation options
+func (h *HTTPMemberHandler) GeneratePasskeyRegistration(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	optionsResult := <-h.MemberUseCase.GeneratePasskeyRegistration(c.Request().Context(), memberID)
+	if optionsResult.Error != nil {
+		return shared.NewHTTPResponse(optionsResult.HTTPStatus, optionsResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Passkey Registration Options", optionsResult.Result).JSON(c)
+}
+
+// RegisterPasskey function for saving the credential created by the authenticator
+func (h *HTTPMemberHandler) RegisterPasskey(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	data := model.PasskeyRegistration{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	passkeyResult := <-h.MemberUseCase.RegisterPasskey(c.Request().Context(), memberID, data)
+	if passkeyResult.Error != nil {
+		return shared.NewHTTPResponse(passkeyResult.HTTPStatus, passkeyResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusCreated, "Success register passkey", passkeyResult.Result).JSON(c)
+}
+
+// RenamePasskey function for renaming passkey of the member
+func (h *HTTPMemberHandler) RenamePasskey(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	data := model.PasskeyRename{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	passkeyResult := <-h.MemberUseCase.RenamePasskey(c.Request().Context(), memberID, c.Param("passkeyID"), data)
+	if passkeyResult.Error != nil {
+		return shared.NewHTTPResponse(passkeyResult.HTTPStatus, passkeyResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success rename passkey", passkeyResult.Result).JSON(c)
+}
+
+// DeletePasskey function for removing passkey of the member
+func (h *HTTPMemberHandler) DeletePasskey(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	passkeyResult := <-h.MemberUseCase.DeletePasskey(c.Request().Context(), memberID, c.Param("passkeyID"))
+	if passkeyResult.Error != nil {
+		return shared.NewHTTPResponse(passkeyResult.HTTPStatus, passkeyResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success delete passkey").JSON(c)
+}
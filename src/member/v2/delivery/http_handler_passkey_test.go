@@ -0,0 +1,195 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mocksMember "github.com/Bhinneka/user-service/mocks/src/member/v1/usecase"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/usecase"
+	"github.com/Bhinneka/user-service/src/shared/webauthn"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type passkeyHandlerTest struct {
+	name            string
+	token           string
+	payload         string
+	wantUsecaseData usecase.ResultUseCase
+	wantStatusCode  int
+}
+
+func runPasskeyHandlerTests(t *testing.T, method string, args int, tests []passkeyHandlerTest, handle func(*HTTPMemberHandler, echo.Context) error) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMemberUsecase := new(mocksMember.MemberUseCase)
+			arguments := make([]interface{}, args)
+			for i := range arguments {
+				arguments[i] = mock.Anything
+			}
+			mockMemberUsecase.On(method, arguments...).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.POST, root+"/passkeys", strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("passkeyID")
+			c.SetParamValues("d3b07384-d9a0-4c9b-8f4a-0d4f1f2e6c11")
+
+			token, _ := generateToken(tt.token)
+			c.Set("token", token)
+			handler := NewHTTPHandler(mockMemberUsecase)
+
+			assert.NoError(t, handle(handler, c))
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestGetPasskeys(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: []model.Passkey{}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusInternalServerError, Error: errors.New(msgErrorPq),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "GetPasskeys", 2, tests, (*HTTPMemberHandler).GetPasskeys)
+}
+
+func TestGeneratePasskeyRegistration(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: webauthn.CreationOptions{}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusBadRequest, Error: errors.New(model.ErrorPasskeyLimit),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "GeneratePasskeyRegistration", 2, tests, (*HTTPMemberHandler).GeneratePasskeyRegistration)
+}
+
+func TestRegisterPasskey(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			payload:         `{"name":"Laptop","credential":{"id":"AQID","type":"public-key"}}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.Passkey{}},
+			wantStatusCode:  http.StatusCreated,
+		},
+		{
+			name:    testCaseNegative2,
+			token:   tokenAdmin,
+			payload: `{"name":"Laptop"}`,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusConflict, Error: errors.New(model.ErrorPasskeyRegistered),
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenAdmin,
+			payload:        `{"name":`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative4,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "RegisterPasskey", 3, tests, (*HTTPMemberHandler).RegisterPasskey)
+}
+
+func TestRenamePasskey(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			payload:         `{"name":"Phone"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.PasskeyRename{Name: "Phone"}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:    testCaseNegative2,
+			token:   tokenAdmin,
+			payload: `{"name":"Phone"}`,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorPasskeyNotFound),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenAdmin,
+			payload:        `[`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative4,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "RenamePasskey", 4, tests, (*HTTPMemberHandler).RenamePasskey)
+}
+
+func TestDeletePasskey(t *testing.T) {
+	tests := []passkeyHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: "d3b07384-d9a0-4c9b-8f4a-0d4f1f2e6c11"},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorPasskeyNotFound),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runPasskeyHandlerTests(t, "DeletePasskey", 3, tests, (*HTTPMemberHandler).DeletePasskey)
+}
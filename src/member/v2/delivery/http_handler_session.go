@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"net/http"
+
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/middleware"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/shared"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo"
+)
+
+const paramSessionID = "sessionID"
+
+// GetSessions function for getting active sessions of logged in member
+func (h *HTTPMemberHandler) GetSessions(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	sessionResult := <-h.MemberUseCase.GetDeviceSessions(c.Request().Context(), memberID, rawToken(c))
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Get sessions", sessionResult.Result).JSON(c)
+}
+
+// LabelSession function for naming an active session of logged in member
+func (h *HTTPMemberHandler) LabelSession(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	data := model.DeviceSessionLabel{}
+	if err := c.Bind(&data); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, helper.ErrorPayload).JSON(c)
+	}
+
+	sessionResult := <-h.MemberUseCase.LabelDeviceSession(c.Request().Context(), memberID, c.Param(paramSessionID), data)
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success label session", sessionResult.Result).JSON(c)
+}
+
+// RevokeSession function for ending another session of logged in member
+func (h *HTTPMemberHandler) RevokeSession(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	sessionResult := <-h.MemberUseCase.RevokeDeviceSession(c.Request().Context(), memberID, c.Param(paramSessionID), rawToken(c))
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success revoke session").JSON(c)
+}
+
+// RevokeOtherSessions function for ending every session of logged in member except the current one
+func (h *HTTPMemberHandler) RevokeOtherSessions(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	token := rawToken(c)
+	if token == "" {
+		return shared.NewHTTPResponse(http.StatusUnauthorized, helper.ErrorUnauthorized).JSON(c)
+	}
+
+	sessionResult := <-h.MemberUseCase.RevokeOtherDeviceSessions(c.Request().Context(), memberID, token)
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success revoke other sessions").JSON(c)
+}
+
+// GetMemberSessions function for getting active sessions of a member by admin
+func (h *HTTPMemberHandler) GetMemberSessions(c echo.Context) error {
+	sessionResult := <-h.MemberUseCase.GetDeviceSessions(c.Request().Context(), c.Param(memberID), "")
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Get sessions", sessionResult.Result).JSON(c)
+}
+
+// RevokeMemberSession function for ending one session of a member by admin
+func (h *HTTPMemberHandler) RevokeMemberSession(c echo.Context) error {
+	sessionResult := <-h.MemberUseCase.RevokeDeviceSession(c.Request().Context(), c.Param(memberID), c.Param(paramSessionID), "")
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success revoke session").JSON(c)
+}
+
+// RevokeMemberSessions function for ending every session of a member by admin
+func (h *HTTPMemberHandler) RevokeMemberSessions(c echo.Context) error {
+	sessionResult := <-h.MemberUseCase.RevokeOtherDeviceSessions(c.Request().Context(), c.Param(memberID), "")
+	if sessionResult.Error != nil {
+		return shared.NewHTTPResponse(sessionResult.HTTPStatus, sessionResult.Error.Error()).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success revoke all sessions").JSON(c)
+}
+
+// rawToken function for getting the access token of the request
+func rawToken(c echo.Context) string {
+	token, ok := c.Get("token").(*jwt.Token)
+	if !ok || token == nil {
+		return ""
+	}
+	return token.Raw
+}
@@ -0,0 +1,183 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mocksMember "github.com/Bhinneka/user-service/mocks/src/member/v1/usecase"
+	"github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/member/v1/usecase"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type sessionHandlerTest struct {
+	name            string
+	token           string
+	payload         string
+	wantUsecaseData usecase.ResultUseCase
+	wantStatusCode  int
+}
+
+func runSessionHandlerTests(t *testing.T, method string, args int, tests []sessionHandlerTest, handle func(*HTTPMemberHandler, echo.Context) error) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMemberUsecase := new(mocksMember.MemberUseCase)
+			arguments := make([]interface{}, args)
+			for i := range arguments {
+				arguments[i] = mock.Anything
+			}
+			mockMemberUsecase.On(method, arguments...).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.PUT, root+"/sessions", strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(memberID, paramSessionID)
+			c.SetParamValues("USR123", model.NewDeviceSessionID("c0b4d1b4c4474", "WEB"))
+
+			if tt.token != "" {
+				token, _ := generateToken(tt.token)
+				c.Set("token", token)
+			}
+			handler := NewHTTPHandler(mockMemberUsecase)
+
+			assert.NoError(t, handle(handler, c))
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestGetSessions(t *testing.T) {
+	tests := []sessionHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{Result: []model.DeviceSession{{Current: true}}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusInternalServerError, Error: errors.New(model.ErrorGetDeviceSessions),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runSessionHandlerTests(t, "GetDeviceSessions", 3, tests, (*HTTPMemberHandler).GetSessions)
+}
+
+func TestLabelSession(t *testing.T) {
+	tests := []sessionHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			payload:         `{"label":"Office laptop"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.DeviceSessionLabel{Label: "Office laptop"}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:    testCaseNegative2,
+			token:   tokenAdmin,
+			payload: `{"label":"Office laptop"}`,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorDeviceSessionNotFound),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenAdmin,
+			payload:        `[`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative4,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runSessionHandlerTests(t, "LabelDeviceSession", 4, tests, (*HTTPMemberHandler).LabelSession)
+}
+
+func TestRevokeSession(t *testing.T) {
+	tests := []sessionHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusBadRequest, Error: errors.New(model.ErrorDeviceSessionCurrent),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runSessionHandlerTests(t, "RevokeDeviceSession", 4, tests, (*HTTPMemberHandler).RevokeSession)
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	tests := []sessionHandlerTest{
+		{
+			name:            testCasePositive1,
+			token:           tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:  testCaseNegative2,
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusInternalServerError, Error: errors.New(model.ErrorRevokeAllAccess),
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           testCaseNegative3,
+			token:          tokenfailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	runSessionHandlerTests(t, "RevokeOtherDeviceSessions", 3, tests, (*HTTPMemberHandler).RevokeOtherSessions)
+}
+
+func TestMemberSessionsAdmin(t *testing.T) {
+	tests := []sessionHandlerTest{
+		{
+			name:            testCasePositive1,
+			wantUsecaseData: usecase.ResultUseCase{Result: []model.DeviceSession{}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name: testCaseNegative2,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusNotFound, Error: errors.New(model.ErrorDeviceSessionNotFound),
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	runSessionHandlerTests(t, "GetDeviceSessions", 3, tests, (*HTTPMemberHandler).GetMemberSessions)
+	runSessionHandlerTests(t, "RevokeDeviceSession", 4, tests, (*HTTPMemberHandler).RevokeMemberSession)
+	runSessionHandlerTests(t, "RevokeOtherDeviceSessions", 3, tests, (*HTTPMemberHandler).RevokeMemberSessions)
+}
@@ -94,6 +94,7 @@ func TestHTTPMemberHandlerMount(*testing.T) {
 	handler := NewHTTPHandler(new(mocksMember.MemberUseCase))
 	handler.MountMe(e.Group("/me"))
 	handler.MountAdmin(e.Group("/anon"))
+	handler.MountImport(e.Group("/anon"))
 	handler.Mount(e.Group(""))
 	handler.MountMember(e.Group("/member"))
 }
@@ -731,6 +732,19 @@ func TestHTTPMemberHandlerChangeForgotPassword(t *testing.T) {
 			wantStatusCode: http.StatusOK,
 			param1:         optionalParamSturgeon,
 		},
+		{
+			name:  "Testcase #6: Negative, password policy",
+			token: tokenAdmin,
+			wantUsecaseData: usecase.ResultUseCase{
+				HTTPStatus: http.StatusBadRequest, Error: model.NewPasswordPolicyError([]model.MemberError{
+					{ID: model.PasswordRuleHistory, Message: "password cannot be the same as the last 3 passwords"},
+				}),
+				ErrorData: []model.MemberError{
+					{ID: model.PasswordRuleHistory, Message: "password cannot be the same as the last 3 passwords"},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2369,3 +2383,40 @@ func TestHTTPMemberHandler_ChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPMemberHandler_GetPasswordHashReport(t *testing.T) {
+	tests := []struct {
+		name            string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+	}{
+		{
+			name: testCasePositive1,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.PasswordHashReport{
+				Current:    model.PasswordAlgorithmArgon2id,
+				Algorithms: []model.PasswordAlgorithmCount{{Algorithm: model.PasswordAlgorithmPBKDF2SHA1, Total: 3}},
+			}},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:            testCaseNegative2,
+			wantUsecaseData: usecase.ResultUseCase{HTTPStatus: http.StatusInternalServerError, Error: errors.New(msgErrorPq)},
+			wantStatusCode:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMemberUsecase := new(mocksMember.MemberUseCase)
+			mockMemberUsecase.On("GetPasswordHashReport", mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/api/v2/member/password-hash-report", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := NewHTTPHandler(mockMemberUsecase)
+			assert.NoError(t, handler.GetPasswordHashReport(c))
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
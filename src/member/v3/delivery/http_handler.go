@@ -71,6 +71,7 @@ func (h *MemberHandlerV3) RegisterMember(c echo.Context) error {
 	}
 	memberV3.Type = "register"
 	memberV3.NewPassword = memberV3.Password
+	memberV3.IP = middleware.ClientIP(c)
 
 	checkResult := <-h.MemberUseCase.CheckEmailAndMobileExistence(c.Request().Context(), &memberV3)
 	if checkResult.Error != nil {
@@ -170,5 +171,8 @@ func (h *MemberHandlerV3) ActivateMFASettingsV3(c echo.Context) error {
 		return shared.NewHTTPResponse(activateResult.HTTPStatus, activateResult.Error.Error()).JSON(c)
 	}
 
-	return shared.NewHTTPResponse(http.StatusOK, model.SuccessMFAActivation).JSON(c)
+	// recovery codes are shown once, only their hashes are kept
+	activated, _ := activateResult.Result.(model.MFAActivateSettings)
+	recoveryCodes := model.MFARecoveryCodes{RecoveryCodes: activated.RecoveryCodes}
+	return shared.NewHTTPResponse(http.StatusOK, model.SuccessMFAActivation, recoveryCodes).JSON(c)
 }
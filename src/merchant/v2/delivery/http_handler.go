@@ -28,6 +28,7 @@ const (
 	scopeParseWarehouse = "parse_warehouse"
 	messageSuccessGet   = "Success Get Data"
 	pathWarehouseID     = "/warehouse/:addressId"
+	pathBankChangeID    = "/bank/changes/:changeId"
 	privacy             = "private"
 )
 
@@ -48,26 +49,74 @@ func NewHTTPHandler(MerchantUseCase usecase.MerchantUseCase,
 
 // MountMe function for mounting routes
 func (m *HTTPMerchantHandler) MountMe(group *echo.Group) {
+	canRead := m.requireMerchantPermission(model.MerchantPermissionRead)
+	canUpdate := m.requireMerchantPermission(model.MerchantPermissionProfile)
+	canManageWarehouse := m.requireMerchantPermission(model.MerchantPermissionWarehouse)
+	canReadFinance := m.requireMerchantPermission(model.MerchantPermissionFinance)
+	canManageEmployee := m.requireMerchantPermission(model.MerchantPermissionEmployee)
+	isOwner := m.requireMerchantPermission(model.MerchantPermissionOwner)
+
 	group.POST("", m.AddMerchant)
-	group.POST("/upgrade", m.UpgradeMerchant)
-	group.GET("", m.GetMerchantByUserID)
-	group.POST("/warehouse", m.AddWarehouse)
-	group.PUT(pathWarehouseID, m.UpdateWarehouse)
-	group.PUT("/warehouse/:addressId/set-primary", m.UpdateWarehousePrimary)
-	group.GET("/warehouse", m.GetWarehouse)
-	group.GET(pathWarehouseID, m.GetWarehouseDetail)
-	group.DELETE(pathWarehouseID, m.DeleteWarehouse)
-	group.PUT("", m.UpdateMerchant)
-	group.PUT("/change-name", m.ChangeMerchantName)
-	group.PATCH("", m.UpdateMerchantPartial)
-
-	group.POST("/employees", m.AddEmployee)
-	group.GET("/employees", m.ListEmployee)
-	group.GET("/employees/:memberId", m.GetEmployee)
-	group.PUT("/employees/:memberId", m.UpdateEmployee)
-	group.POST("/employees/resend-email", m.ResendEmailEmployee)
-
-	group.POST("/clear-upgrade", m.clearRejectMerchantUpgrade)
+	group.POST("/upgrade", m.UpgradeMerchant, isOwner)
+	group.GET("", m.GetMerchantByUserID, canRead)
+	group.POST("/warehouse", m.AddWarehouse, canManageWarehouse)
+	group.PUT(pathWarehouseID, m.UpdateWarehouse, canManageWarehouse)
+	group.PUT("/warehouse/:addressId/set-primary", m.UpdateWarehousePrimary, canManageWarehouse)
+	group.GET("/warehouse", m.GetWarehouse, canRead)
+	group.GET(pathWarehouseID, m.GetWarehouseDetail, canRead)
+	group.DELETE(pathWarehouseID, m.DeleteWarehouse, canManageWarehouse)
+	group.PUT("", m.UpdateMerchant, canUpdate)
+	group.PUT("/change-name", m.ChangeMerchantName, canUpdate)
+	group.PATCH("", m.UpdateMerchantPartial, canUpdate)
+
+	group.GET("/bank/changes", m.ListBankChange, canReadFinance)
+	group.POST(pathBankChangeID+"/otp", m.SendBankChangeOTP, isOwner)
+	group.POST(pathBankChangeID+"/confirm", m.ConfirmBankChange, isOwner)
+	group.DELETE(pathBankChangeID, m.CancelBankChange, isOwner)
+
+	group.POST("/employees", m.AddEmployee, canManageEmployee)
+	group.GET("/employees", m.ListEmployee, canManageEmployee)
+	group.GET("/employees/:memberId", m.GetEmployee, canManageEmployee)
+	group.PUT("/employees/:memberId", m.UpdateEmployee, canManageEmployee)
+	group.PUT("/employees/:memberId/role", m.ChangeEmployeeRole, isOwner)
+	group.POST("/employees/resend-email", m.ResendEmailEmployee, canManageEmployee)
+	group.POST("/transfer-ownership/otp", m.SendOwnershipOTP, isOwner)
+	group.POST("/transfer-ownership", m.TransferOwnership, isOwner)
+
+	group.POST("/clear-upgrade", m.clearRejectMerchantUpgrade, isOwner)
+}
+
+// requireMerchantPermission function to allow only members whose role on the merchant they act on grants the permission,
+// an employee of several merchants picks one with the X-Merchant-Id header. Members without a merchant pass through
+// so the routes answer them as before
+func (m *HTTPMerchantHandler) requireMerchantPermission(permission string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			memberID, err := middleware.ExtractMemberIDFromToken(c)
+			if err != nil {
+				return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+			}
+
+			accessResult := <-m.MerchantUseCase.GetMerchantAccess(c.Request().Context(), memberID, c.Request().Header.Get(model.MerchantIDHeader))
+			if accessResult.Error != nil {
+				return shared.NewHTTPResponse(accessResult.HTTPStatus, accessResult.Error.Error()).JSON(c)
+			}
+
+			access, ok := accessResult.Result.(model.MerchantAccess)
+			if !ok {
+				return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+			}
+			if access.MerchantID == "" {
+				return next(c)
+			}
+			if !access.Grants(permission) {
+				return shared.NewHTTPResponse(http.StatusForbidden, "permission "+permission+" is required").JSON(c)
+			}
+
+			c.SetRequest(c.Request().WithContext(model.NewMerchantAccessContext(c.Request().Context(), access)))
+			return next(c)
+		}
+	}
 }
 
 // MountMerchant function for mounting routes
@@ -592,6 +641,7 @@ func (h *HTTPMerchantHandler) AddEmployee(c echo.Context) error {
 	var payload struct {
 		Email     string `json:"email" form:"email"`
 		FirstName string `json:"firstName" form:"firstName"`
+		Role      string `json:"role" form:"role"`
 		Token     string `json:"token" form:"token"`
 	}
 	if err := c.Bind(&payload); err != nil {
@@ -610,7 +660,7 @@ func (h *HTTPMerchantHandler) AddEmployee(c echo.Context) error {
 
 	newCtx := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
 	newCtx = context.WithValue(newCtx, middleware.ContextKeyClientIP, c.RealIP())
-	result := <-h.MerchantUseCase.AddEmployee(newCtx, token, payload.Email, payload.FirstName)
+	result := <-h.MerchantUseCase.AddEmployee(newCtx, token, payload.Email, payload.FirstName, payload.Role)
 	if result.Error != nil {
 		return shared.NewHTTPResponse(http.StatusBadRequest, result.Error.Error()).JSON(c)
 	}
@@ -727,7 +777,7 @@ func (m *HTTPMerchantHandler) ResendEmailEmployee(c echo.Context) error {
 
 	newCtx := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
 	newCtx = context.WithValue(newCtx, middleware.ContextKeyClientIP, c.RealIP())
-	result := <-m.MerchantUseCase.AddEmployee(newCtx, token, payload.Email, "{resend-email}")
+	result := <-m.MerchantUseCase.AddEmployee(newCtx, token, payload.Email, "{resend-email}", "")
 	if result.Error != nil {
 		return shared.NewHTTPResponse(http.StatusBadRequest, result.Error.Error()).JSON(c)
 	}
@@ -761,3 +811,219 @@ func (m *HTTPMerchantHandler) clearRejectMerchantUpgrade(c echo.Context) error {
 
 	return shared.NewHTTPResponse(http.StatusOK, "Success cleared reject upgrade status", merchant).JSON(c)
 }
+
+// ListBankChange function for the owner to get the bank account changes of the merchant
+func (m *HTTPMerchantHandler) ListBankChange(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	changeResult := <-m.MerchantUseCase.GetOwnMerchantBankChanges(c.Request().Context(), userAttribute)
+	if changeResult.Error != nil {
+		return shared.NewHTTPResponse(changeResult.HTTPStatus, changeResult.Error.Error(), make(helper.EmptySlice, 0)).JSON(c)
+	}
+
+	changes, ok := changeResult.Result.([]model.MerchantBankChange)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult, make(helper.EmptySlice, 0)).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success get merchant bank account changes", changes).JSON(c)
+}
+
+// SendBankChangeOTP function for sending the owner a verification code by email or sms for a bank account change
+func (m *HTTPMerchantHandler) SendBankChangeOTP(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	changeID, err := bankChangeID(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.MerchantBankChangeOTPInput{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	ctxReq := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
+	ctxReq = context.WithValue(ctxReq, middleware.ContextKeyClientIP, c.RealIP())
+
+	sendResult := <-m.MerchantUseCase.SendMerchantBankChangeOTP(ctxReq, changeID, payload, userAttribute)
+	if sendResult.Error != nil {
+		return shared.NewHTTPResponse(sendResult.HTTPStatus, sendResult.Error.Error()).JSON(c)
+	}
+
+	message, ok := sendResult.Result.(string)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, message).JSON(c)
+}
+
+// ConfirmBankChange function for the owner to confirm a bank account change with the second factor
+func (m *HTTPMerchantHandler) ConfirmBankChange(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	changeID, err := bankChangeID(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.MerchantBankChangeConfirmInput{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	ctxReq := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
+	ctxReq = context.WithValue(ctxReq, middleware.ContextKeyClientIP, c.RealIP())
+
+	confirmResult := <-m.MerchantUseCase.ConfirmMerchantBankChange(ctxReq, changeID, payload, userAttribute)
+	if confirmResult.Error != nil {
+		return shared.NewHTTPResponse(confirmResult.HTTPStatus, confirmResult.Error.Error()).JSON(c)
+	}
+
+	change, ok := confirmResult.Result.(model.MerchantBankChange)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success confirm merchant bank account change", change).JSON(c)
+}
+
+// CancelBankChange function for the owner to withdraw a bank account change
+func (m *HTTPMerchantHandler) CancelBankChange(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	changeID, err := bankChangeID(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	cancelResult := <-m.MerchantUseCase.CancelMerchantBankChange(c.Request().Context(), changeID, userAttribute)
+	if cancelResult.Error != nil {
+		return shared.NewHTTPResponse(cancelResult.HTTPStatus, cancelResult.Error.Error()).JSON(c)
+	}
+
+	change, ok := cancelResult.Result.(model.MerchantBankChange)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success cancel merchant bank account change", change).JSON(c)
+}
+
+// ChangeEmployeeRole function for the owner to assign a role to an employee
+func (m *HTTPMerchantHandler) ChangeEmployeeRole(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.MerchantEmployeeRoleInput{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	roleResult := <-m.MerchantUseCase.ChangeMerchantEmployeeRole(c.Request().Context(), c.Param("memberId"), payload, userAttribute)
+	if roleResult.Error != nil {
+		return shared.NewHTTPResponse(roleResult.HTTPStatus, roleResult.Error.Error()).JSON(c)
+	}
+
+	employee, ok := roleResult.Result.(model.B2CMerchantEmployeeData)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success change merchant employee role", employee).JSON(c)
+}
+
+// SendOwnershipOTP function for sending the owner a verification code by email or sms for an ownership transfer
+func (m *HTTPMerchantHandler) SendOwnershipOTP(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.MerchantOwnershipOTPInput{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	ctxReq := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
+	ctxReq = context.WithValue(ctxReq, middleware.ContextKeyClientIP, c.RealIP())
+
+	sendResult := <-m.MerchantUseCase.SendMerchantOwnershipOTP(ctxReq, payload, userAttribute)
+	if sendResult.Error != nil {
+		return shared.NewHTTPResponse(sendResult.HTTPStatus, sendResult.Error.Error()).JSON(c)
+	}
+
+	message, ok := sendResult.Result.(string)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, message).JSON(c)
+}
+
+// TransferOwnership function for the owner to hand the merchant over to an active employee, confirmed with the second factor
+func (m *HTTPMerchantHandler) TransferOwnership(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.MerchantOwnershipInput{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	ctxReq := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
+	ctxReq = context.WithValue(ctxReq, middleware.ContextKeyClientIP, c.RealIP())
+	transferResult := <-m.MerchantUseCase.TransferMerchantOwnership(ctxReq, payload, userAttribute)
+	if transferResult.Error != nil {
+		return shared.NewHTTPResponse(transferResult.HTTPStatus, transferResult.Error.Error()).JSON(c)
+	}
+
+	merchant, ok := transferResult.Result.(model.B2CMerchantDataV2)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success transfer merchant ownership", merchant).JSON(c)
+}
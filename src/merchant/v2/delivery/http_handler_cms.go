@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Bhinneka/golib/jsonschema"
@@ -23,6 +24,17 @@ const (
 	rejectMerchantUpgradePath      = "/:merchantId/reject-upgrade"
 	merchantWarehousePath          = "/:merchantId/warehouses"
 	merchantWarehouseAddressPath   = "/:merchantId/warehouses/:addressId"
+	merchantStatusHistoryPath      = "/:merchantId/history"
+	approveMerchantDocumentPath    = "/:merchantId/documents/:documentType/approve"
+	rejectMerchantDocumentPath     = "/:merchantId/documents/:documentType/reject"
+	documentTypeParam              = "documentType"
+	merchantBankVerifyPath         = "/:merchantId/bank/verify"
+	merchantBankApprovePath        = "/:merchantId/bank/approve"
+	merchantBankVerificationPath   = "/:merchantId/bank/verification"
+	merchantBankChangesPath        = "/:merchantId/bank/changes"
+	approveMerchantBankChangePath  = "/:merchantId/bank/changes/:changeId/approve"
+	rejectMerchantBankChangePath   = "/:merchantId/bank/changes/:changeId/reject"
+	bankChangeIDParam              = "changeId"
 	merchantVanityURL              = "vanityUrl"
 )
 
@@ -34,10 +46,13 @@ func (m *HTTPMerchantHandler) MountCMS(group *echo.Group) {
 	group.GET("/list", m.getList)                           // list and filter [STG-564]
 	group.GET(merchantIDPath, m.getMerchant)                // get single merchant [STG-565]
 	group.POST(merchantIDPath+"/officer", m.addMerchantPIC) //set merchant pic [STG-939]
-
-	// reject registration
-	group.POST(rejectMerchantRegistrationPath, m.rejectMerchantRegistration) // reject merchant registration [STG-778]
-	group.POST(rejectMerchantUpgradePath, m.rejectMerchantUpgrade)           // reject merchant upgrade [STG-779]
+	group.GET(merchantStatusHistoryPath, m.getMerchantStatusHistory)
+	group.POST(approveMerchantDocumentPath, m.approveMerchantDocument)
+	group.POST(merchantBankVerifyPath, m.verifyMerchantBank)
+	group.POST(merchantBankApprovePath, m.approveMerchantBank)
+	group.GET(merchantBankVerificationPath, m.getMerchantBankVerification)
+	group.GET(merchantBankChangesPath, m.getMerchantBankChanges)
+	group.POST(approveMerchantBankChangePath, m.approveMerchantBankChange)
 
 	// merchant warehouse
 	group.GET(merchantWarehousePath, m.getMerchantWarehouse)       // get warehouse list per merchant [STG-823]
@@ -46,6 +61,14 @@ func (m *HTTPMerchantHandler) MountCMS(group *echo.Group) {
 	group.GET("/employees", m.CmsListEmployee)
 }
 
+// MountCMSReject specific for CMS usage, rejection needs its own permission
+func (m *HTTPMerchantHandler) MountCMSReject(group *echo.Group) {
+	group.POST(rejectMerchantRegistrationPath, m.rejectMerchantRegistration) // reject merchant registration [STG-778]
+	group.POST(rejectMerchantUpgradePath, m.rejectMerchantUpgrade)           // reject merchant upgrade [STG-779]
+	group.POST(rejectMerchantDocumentPath, m.rejectMerchantDocument)
+	group.POST(rejectMerchantBankChangePath, m.rejectMerchantBankChange)
+}
+
 func (m *HTTPMerchantHandler) createMerchant(c echo.Context) error {
 	creatorID, err := middleware.ExtractMemberIDFromToken(c)
 	if err != nil {
@@ -216,6 +239,20 @@ func (m *HTTPMerchantHandler) getMerchant(c echo.Context) error {
 	return shared.NewHTTPResponse(http.StatusOK, "Success get merchant", merchant).JSON(c)
 }
 
+func (m *HTTPMerchantHandler) getMerchantStatusHistory(c echo.Context) error {
+	historyResult := <-m.MerchantUseCase.GetMerchantStatusHistory(c.Request().Context(), c.Param(merchantIDParam))
+	if historyResult.Error != nil {
+		return shared.NewHTTPResponse(historyResult.HTTPStatus, historyResult.Error.Error(), make(helper.EmptySlice, 0)).JSON(c)
+	}
+
+	histories, ok := historyResult.Result.([]model.MerchantStatusHistory)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult, make(helper.EmptySlice, 0)).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success get merchant status history", histories).JSON(c)
+}
+
 func (m *HTTPMerchantHandler) rejectMerchantRegistration(c echo.Context) error {
 	memberID, err := middleware.ExtractMemberIDFromToken(c)
 	if err != nil {
@@ -276,6 +313,206 @@ func (m *HTTPMerchantHandler) rejectMerchantUpgrade(c echo.Context) error {
 	return shared.NewHTTPResponse(http.StatusOK, "Success reject merchant upgrade", merchant).JSON(c)
 }
 
+func (m *HTTPMerchantHandler) approveMerchantDocument(c echo.Context) error {
+	return m.reviewMerchantDocument(c, model.DocumentStatusVerified)
+}
+
+func (m *HTTPMerchantHandler) rejectMerchantDocument(c echo.Context) error {
+	return m.reviewMerchantDocument(c, model.DocumentStatusRejected)
+}
+
+func (m *HTTPMerchantHandler) reviewMerchantDocument(c echo.Context, status string) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	payload := model.B2CMerchantDocumentReviewInput{}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	payload.MerchantID = c.Param(merchantIDParam)
+	payload.DocumentType = c.Param(documentTypeParam)
+	payload.Status = status
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	ctxReq := context.WithValue(c.Request().Context(), helper.TextAuthorization, c.Request().Header.Get(helper.TextAuthorization))
+	ctxReq = context.WithValue(ctxReq, middleware.ContextKeyClientIP, c.RealIP())
+
+	reviewResult := <-m.MerchantUseCase.ReviewMerchantDocument(ctxReq, payload, userAttribute)
+	if reviewResult.Error != nil {
+		return shared.NewHTTPResponse(reviewResult.HTTPStatus, reviewResult.Error.Error()).JSON(c)
+	}
+
+	document, ok := reviewResult.Result.(model.B2CMerchantDocumentData)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success review merchant document", document).JSON(c)
+}
+
+func (m *HTTPMerchantHandler) verifyMerchantBank(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	verifyResult := <-m.MerchantUseCase.VerifyMerchantBank(c.Request().Context(), c.Param(merchantIDParam), userAttribute)
+	if verifyResult.Error != nil {
+		return shared.NewHTTPResponse(verifyResult.HTTPStatus, verifyResult.Error.Error()).JSON(c)
+	}
+
+	verification, ok := verifyResult.Result.(model.MerchantBankVerification)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success verify merchant bank account", verification).JSON(c)
+}
+
+func (m *HTTPMerchantHandler) approveMerchantBank(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	approveResult := <-m.MerchantUseCase.ApproveMerchantBank(c.Request().Context(), c.Param(merchantIDParam), payload.Reason, userAttribute)
+	if approveResult.Error != nil {
+		return shared.NewHTTPResponse(approveResult.HTTPStatus, approveResult.Error.Error()).JSON(c)
+	}
+
+	verification, ok := approveResult.Result.(model.MerchantBankVerification)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success approve merchant bank account", verification).JSON(c)
+}
+
+func (m *HTTPMerchantHandler) getMerchantBankVerification(c echo.Context) error {
+	verificationResult := <-m.MerchantUseCase.GetMerchantBankVerification(c.Request().Context(), c.Param(merchantIDParam))
+	if verificationResult.Error != nil {
+		return shared.NewHTTPResponse(verificationResult.HTTPStatus, verificationResult.Error.Error()).JSON(c)
+	}
+
+	verification, ok := verificationResult.Result.(model.MerchantBankVerification)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success get merchant bank verification", verification).JSON(c)
+}
+
+func (m *HTTPMerchantHandler) getMerchantBankChanges(c echo.Context) error {
+	changeResult := <-m.MerchantUseCase.GetMerchantBankChanges(c.Request().Context(), c.Param(merchantIDParam))
+	if changeResult.Error != nil {
+		return shared.NewHTTPResponse(changeResult.HTTPStatus, changeResult.Error.Error(), make(helper.EmptySlice, 0)).JSON(c)
+	}
+
+	changes, ok := changeResult.Result.([]model.MerchantBankChange)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult, make(helper.EmptySlice, 0)).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success get merchant bank account changes", changes).JSON(c)
+}
+
+func (m *HTTPMerchantHandler) approveMerchantBankChange(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	changeID, err := bankChangeID(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	// reason is only required to approve a new bank account not verified by the name inquiry, the body may be empty
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&payload); err != nil {
+			return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+		}
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	approveResult := <-m.MerchantUseCase.ApproveMerchantBankChange(c.Request().Context(), c.Param(merchantIDParam), changeID, payload.Reason, userAttribute)
+	if approveResult.Error != nil {
+		return shared.NewHTTPResponse(approveResult.HTTPStatus, approveResult.Error.Error()).JSON(c)
+	}
+
+	change, ok := approveResult.Result.(model.MerchantBankChange)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success approve merchant bank account change", change).JSON(c)
+}
+
+func (m *HTTPMerchantHandler) rejectMerchantBankChange(c echo.Context) error {
+	memberID, err := middleware.ExtractMemberIDFromToken(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	changeID, err := bankChangeID(c)
+	if err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+	var payload struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.Bind(&payload); err != nil {
+		return shared.NewHTTPResponse(http.StatusBadRequest, err.Error()).JSON(c)
+	}
+
+	userAttribute := &model.MerchantUserAttribute{}
+	userAttribute.UserID = memberID
+	userAttribute.UserIP = c.RealIP()
+
+	rejectResult := <-m.MerchantUseCase.RejectMerchantBankChange(c.Request().Context(), c.Param(merchantIDParam), changeID, payload.Reason, userAttribute)
+	if rejectResult.Error != nil {
+		return shared.NewHTTPResponse(rejectResult.HTTPStatus, rejectResult.Error.Error()).JSON(c)
+	}
+
+	change, ok := rejectResult.Result.(model.MerchantBankChange)
+	if !ok {
+		return shared.NewHTTPResponse(http.StatusBadRequest, msgErrorResult).JSON(c)
+	}
+
+	return shared.NewHTTPResponse(http.StatusOK, "Success reject merchant bank account change", change).JSON(c)
+}
+
+// bankChangeID reads the bank account change id of the path
+func bankChangeID(c echo.Context) (int64, error) {
+	changeID, err := strconv.ParseInt(c.Param(bankChangeIDParam), 10, 64)
+	if err != nil || changeID <= 0 {
+		return 0, fmt.Errorf(helper.ErrorParameterInvalid, bankChangeIDParam)
+	}
+	return changeID, nil
+}
+
 func (m *HTTPMerchantHandler) getMerchantWarehouse(c echo.Context) error {
 	whParams := model.ParameterWarehouse{
 		StrPage:    c.QueryParam("page"),
@@ -285,6 +285,258 @@ func TestGetSingleMerchant(t *testing.T) {
 	}
 }
 
+func TestGetMerchantStatusHistory(t *testing.T) {
+	testData := []struct {
+		name            string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+	}{
+		{
+			name:            testCasePositive1,
+			wantStatusCode:  http.StatusOK,
+			wantUsecaseData: usecase.ResultUseCase{Result: []model.MerchantStatusHistory{{Event: model.MerchantEventActivate}}},
+		},
+		{
+			name:            testCaseNegative2,
+			wantStatusCode:  http.StatusNotFound,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusNotFound},
+		},
+		{
+			name:            testCaseNegative3,
+			wantStatusCode:  http.StatusBadRequest,
+			wantUsecaseData: usecase.ResultUseCase{Result: nil},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMerchantUsecase := new(mocksMerchant.MerchantUseCase)
+			mockWarehouseAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, merchantWithPath+"/history", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(merchantIDParam)
+			c.SetParamValues("MCH201210161001")
+
+			mockMerchantUsecase.On("GetMerchantStatusHistory", mock.Anything, "MCH201210161001").Return(generateUsecaseResult(tt.wantUsecaseData))
+			handler := NewHTTPHandler(mockMerchantUsecase, mockWarehouseAddressUsecase)
+
+			handler.getMerchantStatusHistory(c)
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestMerchantBankVerification(t *testing.T) {
+	testData := []struct {
+		name            string
+		handler         string
+		token           string
+		payload         string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+	}{
+		{
+			name:           testCaseNegative2,
+			handler:        "VerifyMerchantBank",
+			token:          tokenUserFailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            testCasePositive1,
+			handler:         "VerifyMerchantBank",
+			token:           tokenUser,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantBankVerification{Status: model.BankVerificationVerified}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative3,
+			handler:         "VerifyMerchantBank",
+			token:           tokenUser,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusNotFound},
+			wantStatusCode:  http.StatusNotFound,
+		},
+		{
+			name:            testCasePositive2,
+			handler:         "ApproveMerchantBank",
+			token:           tokenUser,
+			payload:         `{"reason":"account of the director"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantBankVerification{Status: model.BankVerificationVerified}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative4,
+			handler:         "ApproveMerchantBank",
+			token:           tokenUser,
+			payload:         `{}`,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusBadRequest},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:            testCasePositive1,
+			handler:         "GetMerchantBankVerification",
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantBankVerification{Status: model.BankVerificationNameMismatch}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative5,
+			handler:         "GetMerchantBankVerification",
+			wantUsecaseData: usecase.ResultUseCase{Result: nil},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.handler+" "+tt.name, func(t *testing.T) {
+			mockMerchantUsecase := new(mocksMerchant.MerchantUseCase)
+			mockWarehouseAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
+
+			mockMerchantUsecase.On("VerifyMerchantBank", mock.Anything, "MCH201210161001", mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockMerchantUsecase.On("ApproveMerchantBank", mock.Anything, "MCH201210161001", mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockMerchantUsecase.On("GetMerchantBankVerification", mock.Anything, "MCH201210161001").Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, merchantWithPath+"/bank", strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(merchantIDParam)
+			c.SetParamValues("MCH201210161001")
+
+			token, _ := generateTokenMerchant(tt.token)
+			c.Set("token", token)
+			handler := NewHTTPHandler(mockMerchantUsecase, mockWarehouseAddressUsecase)
+
+			switch tt.handler {
+			case "VerifyMerchantBank":
+				handler.verifyMerchantBank(c)
+			case "ApproveMerchantBank":
+				handler.approveMerchantBank(c)
+			default:
+				handler.getMerchantBankVerification(c)
+			}
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestMerchantBankChange(t *testing.T) {
+	testData := []struct {
+		name            string
+		handler         string
+		token           string
+		changeID        string
+		payload         string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+	}{
+		{
+			name:            testCasePositive1,
+			handler:         "GetMerchantBankChanges",
+			wantUsecaseData: usecase.ResultUseCase{Result: []model.MerchantBankChange{{ID: 1, Status: model.BankChangePendingApproval}}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative2,
+			handler:         "GetMerchantBankChanges",
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusInternalServerError},
+			wantStatusCode:  http.StatusInternalServerError,
+		},
+		{
+			name:           testCaseNegative2,
+			handler:        "ApproveMerchantBankChange",
+			token:          tokenUserFailed,
+			changeID:       "1",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           testCaseNegative3,
+			handler:        "ApproveMerchantBankChange",
+			token:          tokenUser,
+			changeID:       "abc",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            testCasePositive1,
+			handler:         "ApproveMerchantBankChange",
+			token:           tokenUser,
+			changeID:        "1",
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantBankChange{ID: 1, Status: model.BankChangeApproved}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCasePositive2,
+			handler:         "ApproveMerchantBankChange",
+			token:           tokenUser,
+			changeID:        "1",
+			payload:         `{"reason":"company account under the name of the director"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantBankChange{ID: 1, Status: model.BankChangeApproved}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative4,
+			handler:         "ApproveMerchantBankChange",
+			token:           tokenUser,
+			changeID:        "1",
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusForbidden},
+			wantStatusCode:  http.StatusForbidden,
+		},
+		{
+			name:            testCasePositive2,
+			handler:         "RejectMerchantBankChange",
+			token:           tokenUser,
+			changeID:        "1",
+			payload:         `{"reason":"account holder does not match the company"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantBankChange{ID: 1, Status: model.BankChangeRejected}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative5,
+			handler:         "RejectMerchantBankChange",
+			token:           tokenUser,
+			changeID:        "1",
+			payload:         `{}`,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusBadRequest},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.handler+" "+tt.name, func(t *testing.T) {
+			mockMerchantUsecase := new(mocksMerchant.MerchantUseCase)
+			mockWarehouseAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
+
+			mockMerchantUsecase.On("GetMerchantBankChanges", mock.Anything, "MCH201210161001").Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockMerchantUsecase.On("ApproveMerchantBankChange", mock.Anything, "MCH201210161001", int64(1), mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockMerchantUsecase.On("RejectMerchantBankChange", mock.Anything, "MCH201210161001", int64(1), mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, merchantWithPath+"/bank/changes", strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(merchantIDParam, bankChangeIDParam)
+			c.SetParamValues("MCH201210161001", tt.changeID)
+
+			token, _ := generateTokenMerchant(tt.token)
+			c.Set("token", token)
+			handler := NewHTTPHandler(mockMerchantUsecase, mockWarehouseAddressUsecase)
+
+			switch tt.handler {
+			case "ApproveMerchantBankChange":
+				handler.approveMerchantBankChange(c)
+			case "RejectMerchantBankChange":
+				handler.rejectMerchantBankChange(c)
+			default:
+				handler.getMerchantBankChanges(c)
+			}
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
 func TestRejectMerchantRegistration(t *testing.T) {
 	testData := []struct {
 		name            string
@@ -405,6 +657,82 @@ func TestRejectMerchantUpgrade(t *testing.T) {
 	}
 }
 
+func TestReviewMerchantDocument(t *testing.T) {
+	testData := []struct {
+		name            string
+		token           string
+		reject          bool
+		payload         string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+	}{
+		{
+			name:           testCaseNegative2,
+			token:          tokenUserFailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            testCasePositive1,
+			token:           tokenUser,
+			payload:         `{"expirationDate":"2030-12-31"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantDocumentData{Status: model.DocumentStatusVerified}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCasePositive2,
+			token:           tokenUser,
+			reject:          true,
+			payload:         `{"reason":"blurry image"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantDocumentData{Status: model.DocumentStatusRejected}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative3,
+			token:           tokenUser,
+			reject:          true,
+			payload:         `{}`,
+			wantUsecaseData: usecase.ResultUseCase{Error: fmt.Errorf("reason is required to reject a document"), HTTPStatus: http.StatusBadRequest},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:            testCaseNegative4,
+			token:           tokenUser,
+			payload:         `{}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantDataV2{}},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMerchantUsecase := new(mocksMerchant.MerchantUseCase)
+			mockWarehouseAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
+
+			mockMerchantUsecase.On("ReviewMerchantDocument", mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, merchantWithPath, strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames(merchantIDParam, documentTypeParam)
+			c.SetParamValues("MCH201008161332", model.DocumentTypeNPWP)
+
+			token, _ := generateTokenMerchant(tt.token)
+			c.Set("token", token)
+			handler := NewHTTPHandler(mockMerchantUsecase, mockWarehouseAddressUsecase)
+
+			if tt.reject {
+				handler.rejectMerchantDocument(c)
+			} else {
+				handler.approveMerchantDocument(c)
+			}
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
 func TestCreateMerchant(t *testing.T) {
 	jsonschema.Load(jsonSchemaMerchantDir)
 	testData := []struct {
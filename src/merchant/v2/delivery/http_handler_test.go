@@ -222,6 +222,7 @@ func TestHTTPMerchantHandlerMount(*testing.T) {
 	handler.MountMe(e.Group("/anon"))
 	handler.MountMerchant(e.Group("/basic"))
 	handler.MountCMS(e.Group("/basic"))
+	handler.MountCMSReject(e.Group("/basic"))
 	handler.MountMerchantPublic(e.Group("/api/v2/merchant"))
 }
 
@@ -1375,7 +1376,7 @@ func TestHTTPMerchantHandler_AddEmployee(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAuthUseCase := new(mocksMerchant.MerchantUseCase)
 			mockMerchantAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
-			mockAuthUseCase.On("AddEmployee", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockAuthUseCase.On("AddEmployee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
 
 			e := echo.New()
 			req := httptest.NewRequest(echo.POST, root, strings.NewReader(tt.payload))
@@ -1562,7 +1563,7 @@ func TestHTTPMerchantHandler_ResendEmailEmployee(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAuthUseCase := new(mocksMerchant.MerchantUseCase)
 			mockMerchantAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
-			mockAuthUseCase.On("AddEmployee", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockAuthUseCase.On("AddEmployee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
 
 			e := echo.New()
 			req := httptest.NewRequest(echo.POST, root, strings.NewReader(tt.payload))
@@ -1642,3 +1643,194 @@ func TestHTTPMerchantHandler_GetEmployee(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPMerchantHandler_requireMerchantPermission(t *testing.T) {
+	testData := []struct {
+		name            string
+		token           string
+		merchantID      string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+		wantAccess      bool
+	}{
+		{
+			name:            testCasePositive1,
+			token:           tokenUser,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.NewMerchantAccess("MCH201210161001", "bhinneka-microservices-b13714-5312115", model.MerchantRoleOwner)},
+			wantStatusCode:  http.StatusOK,
+			wantAccess:      true,
+		},
+		{
+			name:            testCasePositive2,
+			token:           tokenUser,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.MerchantAccess{MemberID: "bhinneka-microservices-b13714-5312115"}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:           testCaseNegative2,
+			token:          tokenUserFailed,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            testCaseNegative3,
+			token:           tokenUser,
+			merchantID:      "MCH201210161001",
+			wantUsecaseData: usecase.ResultUseCase{Result: model.NewMerchantAccess("MCH201210161001", "bhinneka-microservices-b13714-5312115", model.MerchantRoleViewer)},
+			wantStatusCode:  http.StatusForbidden,
+		},
+		{
+			name:            testCaseNegative4,
+			token:           tokenUser,
+			merchantID:      "MCH201210161002",
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusForbidden},
+			wantStatusCode:  http.StatusForbidden,
+		},
+		{
+			name:            testCaseNegative5,
+			token:           tokenUser,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantEmployeeData{}},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMerchantUsecase := new(mocksMerchant.MerchantUseCase)
+			mockWarehouseAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
+			mockMerchantUsecase.On("GetMerchantAccess", mock.Anything, "bhinneka-microservices-b13714-5312115", tt.merchantID).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.PUT, root+"/warehouse/ADDR1", nil)
+			req.Header.Set(model.MerchantIDHeader, tt.merchantID)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			token, _ := generateTokenMerchant(tt.token)
+			c.Set("token", token)
+			handler := NewHTTPHandler(mockMerchantUsecase, mockWarehouseAddressUsecase)
+
+			next := func(c echo.Context) error {
+				_, ok := model.MerchantAccessFromContext(c.Request().Context())
+				assert.Equal(t, tt.wantAccess, ok)
+				return c.NoContent(http.StatusOK)
+			}
+			handler.requireMerchantPermission(model.MerchantPermissionWarehouse)(next)(c)
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestHTTPMerchantHandler_EmployeeRole(t *testing.T) {
+	testData := []struct {
+		name            string
+		handler         string
+		token           string
+		payload         string
+		wantUsecaseData usecase.ResultUseCase
+		wantStatusCode  int
+	}{
+		{
+			name:            testCasePositive1,
+			handler:         "ChangeEmployeeRole",
+			token:           tokenUser,
+			payload:         `{"role":"FINANCE"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantEmployeeData{MemberID: "USR123123424"}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:           testCaseNegative2,
+			handler:        "ChangeEmployeeRole",
+			token:          tokenUserFailed,
+			payload:        `{"role":"FINANCE"}`,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:            testCaseNegative3,
+			handler:         "ChangeEmployeeRole",
+			token:           tokenUser,
+			payload:         `{"role":"OWNER"}`,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusBadRequest},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:            testCaseNegative4,
+			handler:         "ChangeEmployeeRole",
+			token:           tokenUser,
+			payload:         `{:}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantEmployeeData{}},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:            testCasePositive2,
+			handler:         "TransferOwnership",
+			token:           tokenUser,
+			payload:         `{"memberId":"USR123123424","otp":"123456"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantDataV2{ID: "MCH201210161001", UserID: "USR123123424"}},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative5,
+			handler:         "TransferOwnership",
+			token:           tokenUser,
+			payload:         `{"memberId":"USR123123424","otp":"123456"}`,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusConflict},
+			wantStatusCode:  http.StatusConflict,
+		},
+		{
+			name:            testCaseNegative6,
+			handler:         "TransferOwnership",
+			token:           tokenUser,
+			payload:         `{"memberId":"USR123123424","otp":"123456"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: model.B2CMerchantEmployeeData{}},
+			wantStatusCode:  http.StatusBadRequest,
+		},
+		{
+			name:            "Testcase #3: Positive",
+			handler:         "SendOwnershipOTP",
+			token:           tokenUser,
+			payload:         `{"method":"email"}`,
+			wantUsecaseData: usecase.ResultUseCase{Result: "verification code sent"},
+			wantStatusCode:  http.StatusOK,
+		},
+		{
+			name:            testCaseNegative7,
+			handler:         "SendOwnershipOTP",
+			token:           tokenUser,
+			payload:         `{"method":"email"}`,
+			wantUsecaseData: usecase.ResultUseCase{Error: errDefault, HTTPStatus: http.StatusTooManyRequests},
+			wantStatusCode:  http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.handler+" "+tt.name, func(t *testing.T) {
+			mockMerchantUsecase := new(mocksMerchant.MerchantUseCase)
+			mockWarehouseAddressUsecase := new(mocksMerchant.MerchantAddressUseCase)
+			mockMerchantUsecase.On("ChangeMerchantEmployeeRole", mock.Anything, "USR123123424", mock.Anything, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockMerchantUsecase.On("TransferMerchantOwnership", mock.Anything, model.MerchantOwnershipInput{MemberID: "USR123123424", OTP: "123456"}, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+			mockMerchantUsecase.On("SendMerchantOwnershipOTP", mock.Anything, model.MerchantOwnershipOTPInput{Method: "email"}, mock.Anything).Return(generateUsecaseResult(tt.wantUsecaseData))
+
+			e := echo.New()
+			req := httptest.NewRequest(echo.PUT, root+"/employees/USR123123424/role", strings.NewReader(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("memberId")
+			c.SetParamValues("USR123123424")
+
+			token, _ := generateTokenMerchant(tt.token)
+			c.Set("token", token)
+			handler := NewHTTPHandler(mockMerchantUsecase, mockWarehouseAddressUsecase)
+
+			switch tt.handler {
+			case "TransferOwnership":
+				handler.TransferOwnership(c)
+			case "SendOwnershipOTP":
+				handler.SendOwnershipOTP(c)
+			default:
+				handler.ChangeEmployeeRole(c)
+			}
+			assert.Equal(t, tt.wantStatusCode, rec.Code)
+		})
+	}
+}
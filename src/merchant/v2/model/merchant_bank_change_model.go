@@ -0,0 +1,118 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	// BankChangeAwaitingMFA the owner still has to confirm the change with the second factor
+	BankChangeAwaitingMFA = "AWAITING_MFA"
+	// BankChangePendingApproval the change waits for an admin
+	BankChangePendingApproval = "PENDING_APPROVAL"
+	// BankChangeApproved the change is applied once the cooling-off period ends
+	BankChangeApproved = "APPROVED"
+	// BankChangeApplied the new bank account is stored on the merchant
+	BankChangeApplied = "APPLIED"
+	// BankChangeRejected an admin rejected the change
+	BankChangeRejected = "REJECTED"
+	// BankChangeCancelled the owner withdrew the change, sent another one or did not confirm it in time
+	BankChangeCancelled = "CANCELLED"
+
+	// MerchantBankChangeSubject email subject of the bank account change notification
+	MerchantBankChangeSubject = "Permintaan Perubahan Rekening Bank Toko"
+)
+
+// MerchantBankChange data structure of a requested change of the merchant bank account
+type MerchantBankChange struct {
+	ID                   int64  `json:"id"`
+	MerchantID           string `json:"merchantId"`
+	RequesterID          string `json:"requesterId"`
+	OldBankID            int64  `json:"oldBankId"`
+	OldBankName          string `json:"oldBankName"`
+	OldBankBranch        string `json:"oldBankBranch"`
+	OldAccountNumber     string `json:"oldAccountNumber"`
+	OldAccountHolderName string `json:"oldAccountHolderName"`
+	NewBankID            int64  `json:"newBankId"`
+	NewBankName          string `json:"newBankName"`
+	NewBankBranch        string `json:"newBankBranch"`
+	NewAccountNumber     string `json:"newAccountNumber"`
+	NewAccountHolderName string `json:"newAccountHolderName"`
+	// NotifyEmail merchant email when the change was requested, warned of the change
+	NotifyEmail string    `json:"notifyEmail"`
+	Status      string    `json:"status"`
+	ReviewerID  string    `json:"reviewerId"`
+	Reason      string    `json:"reason"`
+	MFAVerified null.Time `json:"mfaVerified"`
+	Reviewed    null.Time `json:"reviewed"`
+	// EffectiveAt end of the cooling-off period, the change is never applied before. Set from the request
+	// as the earliest date, it starts again when an admin approves the change
+	EffectiveAt time.Time `json:"effectiveAt"`
+	Applied     null.Time `json:"applied"`
+	// VerificationStatus name inquiry of the new account run when the owner confirms, a BankVerification status.
+	// It is kept on the change only, merchant_bank_verification checks the account the merchant holds
+	VerificationStatus string `json:"verificationStatus"`
+	VerificationReason string `json:"verificationReason"`
+	// OverrideReason why an admin approved a change whose new account is not verified
+	OverrideReason string    `json:"overrideReason"`
+	Created        time.Time `json:"created"`
+	LastModified   time.Time `json:"lastModified"`
+}
+
+// MerchantBankChangeOTPInput data structure for asking the one time code of a bank account change
+type MerchantBankChangeOTPInput struct {
+	Method string `json:"method" form:"method"`
+}
+
+// MerchantBankChangeConfirmInput data structure for confirming a bank account change with the second factor
+type MerchantBankChangeConfirmInput struct {
+	Method string `json:"method" form:"method"`
+	OTP    string `json:"otp" form:"otp"`
+}
+
+// MerchantBankChangeResult data structure of a scheduled bank account change run
+type MerchantBankChangeResult struct {
+	Applied  int `json:"applied"`
+	Rejected int `json:"rejected"`
+	Failed   int `json:"failed"`
+	// Expired changes the owner did not confirm in time, cancelled
+	Expired int64 `json:"expired"`
+}
+
+// IsOpen checks the change can still be applied
+func (r MerchantBankChange) IsOpen() bool {
+	switch r.Status {
+	case BankChangeAwaitingMFA, BankChangePendingApproval, BankChangeApproved:
+		return true
+	}
+	return false
+}
+
+// CanBeApproved checks the new account passed the name inquiry, or an admin stated why it is approved anyway
+func (r MerchantBankChange) CanBeApproved() bool {
+	return r.VerificationStatus == BankVerificationVerified || strings.TrimSpace(r.OverrideReason) != ""
+}
+
+// MaskedNewAccount new bank account with only the last four digits of the number, e.g. BCA ******7890
+func (r MerchantBankChange) MaskedNewAccount() string {
+	number := r.NewAccountNumber
+	if len(number) > 4 {
+		number = strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+	}
+	return strings.TrimSpace(r.NewBankName + " " + number)
+}
+
+// HasBankAccount checks the merchant stored a complete bank account
+func (s B2CMerchantDataV2) HasBankAccount() bool {
+	return s.BankID.ValueOrZero() != 0 && s.AccountNumber.String != "" && s.AccountHolderName.String != ""
+}
+
+// IsBankChanged checks the bank account of the merchant differs from old
+func (s B2CMerchantDataV2) IsBankChanged(old B2CMerchantDataV2) bool {
+	return s.BankID.ValueOrZero() != old.BankID.ValueOrZero() ||
+		s.BankBranch.String != old.BankBranch.String ||
+		s.AccountNumber.String != old.AccountNumber.String ||
+		s.AccountHolderName.String != old.AccountHolderName.String
+}
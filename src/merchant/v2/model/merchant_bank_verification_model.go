@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	// BankVerificationPending the provider still checks the account
+	BankVerificationPending = "PENDING"
+	// BankVerificationVerified the account holder matches the legal name or the PIC of the merchant
+	BankVerificationVerified = "VERIFIED"
+	// BankVerificationNameMismatch the account belongs to someone else
+	BankVerificationNameMismatch = "NAME_MISMATCH"
+	// BankVerificationInvalidAccount the account does not exist or cannot receive transfers
+	BankVerificationInvalidAccount = "INVALID_ACCOUNT"
+	// BankVerificationFailed the provider could not be asked, try again later
+	BankVerificationFailed = "FAILED"
+)
+
+// MerchantBankVerification data structure of a single bank account check of a merchant
+type MerchantBankVerification struct {
+	ID                int64  `json:"id"`
+	MerchantID        string `json:"merchantId"`
+	BankID            int64  `json:"bankId"`
+	BankCode          string `json:"bankCode"`
+	AccountNumber     string `json:"accountNumber"`
+	AccountHolderName string `json:"accountHolderName"`
+	// InquiryName holder name answered by the provider
+	InquiryName string `json:"inquiryName"`
+	// MatchedName legal name or PIC closest to the inquiry name
+	MatchedName string    `json:"matchedName"`
+	Score       float64   `json:"score"`
+	Status      string    `json:"status"`
+	Reason      string    `json:"reason"`
+	ActorID     string    `json:"actorId"`
+	Created     time.Time `json:"created"`
+}
+
+// MerchantBankVerificationRequest data structure of a queued bank account check
+type MerchantBankVerificationRequest struct {
+	MerchantID string `json:"merchantId"`
+	ActorID    string `json:"actorId"`
+}
+
+// MerchantBankVerificationPollResult data structure of a scheduled run asking the provider again
+// for the bank account checks left pending
+type MerchantBankVerificationPollResult struct {
+	Queued int `json:"queued"`
+	Failed int `json:"failed"`
+}
+
+// IsFor checks the verification was made for the current bank account of the merchant
+func (v MerchantBankVerification) IsFor(merchant B2CMerchantDataV2) bool {
+	return v.MerchantID == merchant.ID &&
+		v.BankID == merchant.BankID.ValueOrZero() &&
+		v.AccountNumber == merchant.AccountNumber.String &&
+		v.AccountHolderName == merchant.AccountHolderName.String
+}
+
+// IsVerifiedFor checks the current bank account of the merchant is verified
+func (v *MerchantBankVerification) IsVerifiedFor(merchant B2CMerchantDataV2) bool {
+	return v != nil && v.Status == BankVerificationVerified && v.IsFor(merchant)
+}
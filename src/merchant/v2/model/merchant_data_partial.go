@@ -88,6 +88,8 @@ type B2CMerchantDataPatial struct {
 	LastModified             null.Time                 `json:"lastModified"`
 	DeletedAt                null.Time                 `json:"deletedAt"`
 	Documents                []B2CMerchantDocumentData `json:"documents"`
+	BankVerification         *MerchantBankVerification `json:"-"`
+	PendingBankChange        *MerchantBankChange       `json:"pendingBankChange,omitempty"`
 	ProductType              zero.String               `json:"productType"`
 	LegalEntity              zero.Int                  `json:"legalEntity"`
 	LegalEntityName          zero.String               `json:"legalEntityName,omitempty"`
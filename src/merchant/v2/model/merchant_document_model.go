@@ -1,9 +1,37 @@
 package model
 
 import (
+	"time"
+
 	"gopkg.in/guregu/null.v4"
 )
 
+const (
+	// DocumentTypeKTP identity card of the merchant PIC, the file is kept in picKtpFile of the merchant
+	DocumentTypeKTP = "KTP-file"
+	// DocumentTypeNPWP tax number of the merchant, the file is kept in npwpFile of the merchant
+	DocumentTypeNPWP = "NPWP-file"
+	// DocumentTypeSIUP trading business license
+	DocumentTypeSIUP = "SIUP-file"
+	// DocumentTypeTDP company registration certificate
+	DocumentTypeTDP = "TDP-file"
+
+	// DocumentStatusPending document is waiting for review
+	DocumentStatusPending = "PENDING"
+	// DocumentStatusVerified document is approved from CMS
+	DocumentStatusVerified = "VERIFIED"
+	// DocumentStatusRejected document is rejected from CMS
+	DocumentStatusRejected = "REJECTED"
+	// DocumentStatusExpired verified document passed its expiration date
+	DocumentStatusExpired = "EXPIRED"
+)
+
+// MandatoryDocumentTypes documents a merchant needs to be active
+var MandatoryDocumentTypes = []string{DocumentTypeKTP, DocumentTypeNPWP}
+
+// ExpiringDocumentTypes documents with tracked expiration date
+var ExpiringDocumentTypes = []string{DocumentTypeKTP, DocumentTypeNPWP, DocumentTypeSIUP, DocumentTypeTDP}
+
 // B2CMerchantDocument data structure
 type B2CMerchantDocument struct {
 	ID                     string  `json:"id"`
@@ -52,6 +80,38 @@ type B2CMerchantDocumentData struct {
 	Version                int       `json:"version"`
 	Created                null.Time `json:"created"`
 	LastModified           null.Time `json:"lastModified"`
+	Status                 string    `json:"status"`
+	RejectReason           string    `json:"rejectReason"`
+	ReviewerID             string    `json:"reviewerID"`
+	Reviewed               null.Time `json:"reviewed"`
+	ExpiryNotified         null.Time `json:"-"`
+}
+
+// IsExpired function for checking the document expiration date against now
+func (d B2CMerchantDocumentData) IsExpired(now time.Time) bool {
+	return d.DocumentExpirationDate.Valid && !d.DocumentExpirationDate.Time.After(now)
+}
+
+// IsVerified function for checking the document is approved and not expired
+func (d B2CMerchantDocumentData) IsVerified(now time.Time) bool {
+	return d.Status == DocumentStatusVerified && !d.IsExpired(now)
+}
+
+// B2CMerchantDocumentReviewInput data structure of a document review from CMS
+type B2CMerchantDocumentReviewInput struct {
+	MerchantID     string `json:"-"`
+	DocumentType   string `json:"-"`
+	Status         string `json:"-"`
+	Reason         string `json:"reason"`
+	ExpirationDate string `json:"expirationDate"`
+}
+
+// MerchantDocumentExpiryResult data structure of a scheduled document expiry run
+type MerchantDocumentExpiryResult struct {
+	Reminded   int `json:"reminded"`
+	Expired    int `json:"expired"`
+	Restricted int `json:"restricted"`
+	Failed     int `json:"failed"`
 }
 
 // ListB2CMerchantDocument data structure
@@ -29,6 +29,7 @@ type B2CMerchantEmployee struct {
 	ModifiedAt time.Time `json:"modifiedAt"`
 	ModifiedBy *string   `json:"modifiedBy"`
 	Status     string    `json:"status"`
+	Role       string    `json:"role"`
 }
 
 // B2CMerchantEmployeeData data structure
@@ -49,6 +50,7 @@ type B2CMerchantEmployeeData struct {
 	ModifiedAt      *time.Time  `json:"modifiedAt"`
 	ModifiedBy      *string     `json:"modifiedBy"`
 	Status          zero.String `json:"status"`
+	Role            zero.String `json:"role"`
 	ProfilePicture  zero.String `json:"profilePicture"`
 	MerchantLogo    zero.String `json:"merchantLogo"`
 	MerchantName    zero.String `json:"merchantName"`
@@ -36,6 +36,8 @@ const (
 
 	MerchantRegistrationRejectSubject = "Pendaftaran Toko Ditolak"
 	MerchantUpgradeRejectSubject      = "Pengajuan Upgrade Toko Ditolak"
+	MerchantDocumentExpirySubject     = "Dokumen Toko Akan Kedaluwarsa"
+	MerchantRestrictedSubject         = "Toko Dibatasi Karena Dokumen Kedaluwarsa"
 
 	// FailedGetMerchant const variable
 	FailedGetMerchant = "Failed get merchant"
@@ -55,6 +57,8 @@ const (
 	Deleted
 	// New status merchant
 	New
+	// Restricted status merchant
+	Restricted
 
 	// Inactive const variable
 	InactiveString = "INACTIVE"
@@ -62,6 +66,8 @@ const (
 	DeletedString = "DELETED"
 	// New const variable
 	NewString = "NEW"
+	// Restricted const variable, merchant with lapsed mandatory documents
+	RestrictedString = "RESTRICTED"
 
 	// RegularString const variable
 	RegularString = "REGULAR"
@@ -178,6 +184,8 @@ func (ms MerchantStatus) String() string {
 		return DeletedString
 	case New:
 		return NewString
+	case Restricted:
+		return RestrictedString
 	}
 	return InactiveString
 }
@@ -192,6 +200,8 @@ func StringToStatus(s string) MerchantStatus {
 		return Deleted
 	case NewString:
 		return New
+	case RestrictedString:
+		return Restricted
 	}
 	return InActive
 }
@@ -522,7 +532,7 @@ func ValidateProductType(input string) bool {
 
 // ValidateStatus function for validating merchantStatus
 func ValidateMerchantStatus(s string) bool {
-	return golib.StringInSlice(strings.ToUpper(s), []string{ActiveString, InactiveString, DeletedString, NewString})
+	return golib.StringInSlice(strings.ToUpper(s), []string{ActiveString, InactiveString, DeletedString, NewString, RestrictedString})
 }
 
 type Param struct {
@@ -732,6 +742,8 @@ type B2CMerchantDataV2 struct {
 	LastModified             null.Time                 `json:"lastModified"`
 	DeletedAt                null.Time                 `json:"deletedAt"`
 	Documents                []B2CMerchantDocumentData `json:"documents"`
+	BankVerification         *MerchantBankVerification `json:"bankVerification,omitempty"`
+	PendingBankChange        *MerchantBankChange       `json:"pendingBankChange,omitempty"`
 	ProductType              zero.String               `json:"productType"`
 	LegalEntity              zero.Int                  `json:"legalEntity"`
 	LegalEntityName          zero.String               `json:"legalEntityName,omitempty"`
@@ -758,10 +770,14 @@ type CompanySize struct {
 }
 
 type MerchantPayloadEmail struct {
-	MemberName   string            `json:"memberName"`
-	Data         B2CMerchantDataV2 `json:"merchant"`
-	ReasonReject string            `json:"reasonReject"`
-	AdminCMS     string            `json:"adminCMS"`
+	MemberName   string                    `json:"memberName"`
+	Data         B2CMerchantDataV2         `json:"merchant"`
+	ReasonReject string                    `json:"reasonReject"`
+	AdminCMS     string                    `json:"adminCMS"`
+	Documents    []B2CMerchantDocumentData `json:"documents,omitempty"`
+	BankChange   *MerchantBankChange       `json:"bankChange,omitempty"`
+	// OwnershipTransfer former and new owner of the merchant
+	OwnershipTransfer *MerchantOwnershipTransfer `json:"ownershipTransfer,omitempty"`
 }
 
 type MerchantLog struct {
@@ -850,6 +866,8 @@ type B2CMerchantDataPublic struct {
 	LastModified             null.Time                 `json:"-"`
 	DeletedAt                null.Time                 `json:"-"`
 	Documents                []B2CMerchantDocumentData `json:"-"`
+	BankVerification         *MerchantBankVerification `json:"-"`
+	PendingBankChange        *MerchantBankChange       `json:"-"`
 	ProductType              zero.String               `json:"productType"`
 	LegalEntity              zero.Int                  `json:"-"`
 	LegalEntityName          zero.String               `json:"-"`
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// MerchantRoleOwner the member owning the merchant, holds every permission
+	MerchantRoleOwner = "OWNER"
+	// MerchantRoleAdmin manages the merchant profile, warehouses, catalog and employees
+	MerchantRoleAdmin = "ADMIN"
+	// MerchantRoleCatalog manages the products of the merchant
+	MerchantRoleCatalog = "CATALOG"
+	// MerchantRoleFinance follows the bank account and settlements of the merchant
+	MerchantRoleFinance = "FINANCE"
+	// MerchantRoleWarehouse manages the warehouses of the merchant
+	MerchantRoleWarehouse = "WAREHOUSE"
+	// MerchantRoleViewer only reads the merchant
+	MerchantRoleViewer = "VIEWER"
+
+	// MerchantOwnershipTransferSubject email subject of the ownership transfer notification
+	MerchantOwnershipTransferSubject = "Perpindahan Kepemilikan Toko"
+
+	// MerchantPermissionRead permission for reading the merchant profile and warehouses
+	MerchantPermissionRead = "merchant:read"
+	// MerchantPermissionProfile permission for updating the merchant profile
+	MerchantPermissionProfile = "merchant:profile"
+	// MerchantPermissionWarehouse permission for managing the merchant warehouses
+	MerchantPermissionWarehouse = "merchant:warehouse"
+	// MerchantPermissionFinance permission for reading the merchant bank account changes
+	MerchantPermissionFinance = "merchant:finance"
+	// MerchantPermissionCatalog permission for managing the merchant products, checked by the catalog services
+	MerchantPermissionCatalog = "merchant:catalog"
+	// MerchantPermissionEmployee permission for inviting and managing the merchant employees
+	MerchantPermissionEmployee = "merchant:employee"
+	// MerchantPermissionOwner permission for confirming bank account changes, assigning roles and transferring ownership
+	MerchantPermissionOwner = "merchant:owner"
+
+	// MerchantIDHeader header picking the merchant an employee of several merchants acts on
+	MerchantIDHeader = "X-Merchant-Id"
+)
+
+// EmployeeRoles roles assignable to merchant employees, the owner role is only given by an ownership transfer
+var EmployeeRoles = []string{MerchantRoleAdmin, MerchantRoleCatalog, MerchantRoleFinance, MerchantRoleWarehouse, MerchantRoleViewer}
+
+var merchantRolePermissions = map[string][]string{
+	MerchantRoleOwner: {
+		MerchantPermissionRead, MerchantPermissionProfile, MerchantPermissionWarehouse, MerchantPermissionFinance,
+		MerchantPermissionCatalog, MerchantPermissionEmployee, MerchantPermissionOwner,
+	},
+	MerchantRoleAdmin: {
+		MerchantPermissionRead, MerchantPermissionProfile, MerchantPermissionWarehouse, MerchantPermissionFinance,
+		MerchantPermissionCatalog, MerchantPermissionEmployee,
+	},
+	MerchantRoleCatalog:   {MerchantPermissionRead, MerchantPermissionCatalog},
+	MerchantRoleFinance:   {MerchantPermissionRead, MerchantPermissionFinance},
+	MerchantRoleWarehouse: {MerchantPermissionRead, MerchantPermissionWarehouse},
+	MerchantRoleViewer:    {MerchantPermissionRead},
+}
+
+// MerchantAccess data structure of the role a member holds on the merchant the member acts on
+type MerchantAccess struct {
+	MerchantID  string   `json:"merchantId"`
+	MemberID    string   `json:"-"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// MerchantEmployeeRoleInput data structure for assigning a role to an employee
+type MerchantEmployeeRoleInput struct {
+	Role string `json:"role" form:"role"`
+}
+
+// MerchantOwnershipInput data structure for transferring the merchant to an employee,
+// confirmed with the second factor of the owner like a bank account change
+type MerchantOwnershipInput struct {
+	MemberID string `json:"memberId" form:"memberId"`
+	Method   string `json:"method" form:"method"`
+	OTP      string `json:"otp" form:"otp"`
+}
+
+// MerchantOwnershipOTPInput data structure for asking the one time code of an ownership transfer
+type MerchantOwnershipOTPInput struct {
+	Method string `json:"method" form:"method"`
+}
+
+// MerchantOwnershipTransfer data structure of a done ownership transfer, both owners are told
+type MerchantOwnershipTransfer struct {
+	FormerOwnerName  string    `json:"formerOwnerName"`
+	FormerOwnerEmail string    `json:"formerOwnerEmail"`
+	NewOwnerName     string    `json:"newOwnerName"`
+	NewOwnerEmail    string    `json:"newOwnerEmail"`
+	Transferred      time.Time `json:"transferred"`
+}
+
+type merchantAccessContextKey struct{}
+
+// IsEmployeeRole checks the role can be assigned to an employee
+func IsEmployeeRole(role string) bool {
+	for _, employeeRole := range EmployeeRoles {
+		if employeeRole == role {
+			return true
+		}
+	}
+	return false
+}
+
+// MerchantRolePermissions returns the permissions granted by the role, none for an unknown role
+func MerchantRolePermissions(role string) []string {
+	return merchantRolePermissions[role]
+}
+
+// NewMerchantAccess returns the access of the member on the merchant with the permissions of the role
+func NewMerchantAccess(merchantID, memberID, role string) MerchantAccess {
+	return MerchantAccess{
+		MerchantID:  merchantID,
+		MemberID:    memberID,
+		Role:        role,
+		Permissions: MerchantRolePermissions(role),
+	}
+}
+
+// Grants checks the role of the access grants the permission
+func (a MerchantAccess) Grants(permission string) bool {
+	for _, granted := range a.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManage checks the access may manage an employee holding the role, only the owner manages admins
+func (a MerchantAccess) CanManage(role string) bool {
+	if !a.Grants(MerchantPermissionEmployee) || role == MerchantRoleOwner {
+		return false
+	}
+	return a.Role == MerchantRoleOwner || role != MerchantRoleAdmin
+}
+
+// IsEmployee checks the member acts on the merchant as an employee rather than the owner
+func (a MerchantAccess) IsEmployee() bool {
+	return a.MerchantID != "" && a.Role != MerchantRoleOwner
+}
+
+// NewMerchantAccessContext returns a copy of ctx carrying the merchant access of the request
+func NewMerchantAccessContext(ctx context.Context, access MerchantAccess) context.Context {
+	return context.WithValue(ctx, merchantAccessContextKey{}, access)
+}
+
+// MerchantAccessFromContext returns the merchant access of the request, if any
+func MerchantAccessFromContext(ctx context.Context) (MerchantAccess, bool) {
+	access, ok := ctx.Value(merchantAccessContextKey{}).(MerchantAccess)
+	return access, ok
+}
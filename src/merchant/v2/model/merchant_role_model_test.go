@@ -0,0 +1,42 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerchantAccessCanManage(t *testing.T) {
+	tests := []struct {
+		name   string
+		access MerchantAccess
+		role   string
+		want   bool
+	}{
+		{name: "owner manages admin", access: NewMerchantAccess("MCH001", "USR001", MerchantRoleOwner), role: MerchantRoleAdmin, want: true},
+		{name: "admin manages catalog", access: NewMerchantAccess("MCH001", "USR002", MerchantRoleAdmin), role: MerchantRoleCatalog, want: true},
+		{name: "admin does not manage admin", access: NewMerchantAccess("MCH001", "USR002", MerchantRoleAdmin), role: MerchantRoleAdmin},
+		{name: "nobody manages owner", access: NewMerchantAccess("MCH001", "USR001", MerchantRoleOwner), role: MerchantRoleOwner},
+		{name: "finance does not manage employees", access: NewMerchantAccess("MCH001", "USR003", MerchantRoleFinance), role: MerchantRoleViewer},
+		{name: "unknown role grants nothing", access: NewMerchantAccess("MCH001", "USR004", "SUPERVISOR"), role: MerchantRoleViewer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.access.CanManage(tt.role))
+		})
+	}
+}
+
+func TestMerchantAccessContext(t *testing.T) {
+	_, ok := MerchantAccessFromContext(context.Background())
+	assert.False(t, ok)
+
+	access := NewMerchantAccess("MCH001", "USR002", MerchantRoleWarehouse)
+	got, ok := MerchantAccessFromContext(NewMerchantAccessContext(context.Background(), access))
+	assert.True(t, ok)
+	assert.True(t, got.IsEmployee())
+	assert.True(t, got.Grants(MerchantPermissionWarehouse))
+	assert.False(t, got.Grants(MerchantPermissionProfile))
+	assert.False(t, IsEmployeeRole(MerchantRoleOwner))
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	// MerchantStatusField lifecycle of merchant status (NEW, ACTIVE, INACTIVE, DELETED)
+	MerchantStatusField = "status"
+	// MerchantUpgradeStatusField lifecycle of merchant upgrade status (PENDING_*, REJECT_*, ACTIVE)
+	MerchantUpgradeStatusField = "upgradeStatus"
+	// SystemActor actor of the transitions made by scheduled jobs
+	SystemActor = "SYSTEM"
+
+	// MerchantEventRegister merchant is created
+	MerchantEventRegister = "REGISTER"
+	// MerchantEventActivate merchant is approved to sell
+	MerchantEventActivate = "ACTIVATE"
+	// MerchantEventDeactivate merchant is suspended
+	MerchantEventDeactivate = "DEACTIVATE"
+	// MerchantEventRejectRegistration merchant registration is rejected
+	MerchantEventRejectRegistration = "REJECT_REGISTRATION"
+	// MerchantEventRestrict merchant mandatory documents lapsed
+	MerchantEventRestrict = "RESTRICT"
+	// MerchantEventDelete merchant is deleted
+	MerchantEventDelete = "DELETE"
+	// MerchantEventRequestUpgrade merchant requests to become manage or associate
+	MerchantEventRequestUpgrade = "REQUEST_UPGRADE"
+	// MerchantEventApproveUpgrade merchant upgrade is approved
+	MerchantEventApproveUpgrade = "APPROVE_UPGRADE"
+	// MerchantEventRejectUpgrade merchant upgrade is rejected
+	MerchantEventRejectUpgrade = "REJECT_UPGRADE"
+	// MerchantEventClearRejectUpgrade merchant acknowledges the rejected upgrade
+	MerchantEventClearRejectUpgrade = "CLEAR_REJECT_UPGRADE"
+)
+
+// MerchantStatusHistory data structure of a single merchant transition
+type MerchantStatusHistory struct {
+	ID         int64     `json:"id"`
+	MerchantID string    `json:"merchantId"`
+	Field      string    `json:"field"`
+	Event      string    `json:"event"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	ActorID    string    `json:"actorId"`
+	ActorIP    string    `json:"actorIp"`
+	Reason     string    `json:"reason"`
+	Created    time.Time `json:"created"`
+}
@@ -0,0 +1,297 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+	"github.com/lib/pq"
+)
+
+const merchantBankChangeColumns = `"id", "merchantId", "requesterId",
+	"oldBankId", "oldBankName", "oldBankBranch", "oldAccountNumber", "oldAccountHolderName",
+	"newBankId", "newBankName", "newBankBranch", "newAccountNumber", "newAccountHolderName",
+	"notifyEmail", "status", "reviewerId", "reason", "mfaVerified", "reviewed",
+	"effectiveAt", "applied", "verificationStatus", "verificationReason", "overrideReason", "created", "lastModified"`
+
+// MerchantBankChangeRepoPostgres data structure
+type MerchantBankChangeRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMerchantBankChangeRepoPostgres function for initializing repo
+func NewMerchantBankChangeRepoPostgres(repo *repository.Repository) MerchantBankChangeRepository {
+	return &MerchantBankChangeRepoPostgres{repo}
+}
+
+// Save function for recording a bank account change request, joins the running transaction if any
+func (mr *MerchantBankChangeRepoPostgres) Save(ctxReq context.Context, change *model.MerchantBankChange) <-chan ResultRepository {
+	ctx := "MerchantBankChangeRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+
+		query := `INSERT INTO merchant_bank_change
+					(
+						"merchantId", "requesterId",
+						"oldBankId", "oldBankName", "oldBankBranch", "oldAccountNumber", "oldAccountHolderName",
+						"newBankId", "newBankName", "newBankBranch", "newAccountNumber", "newAccountHolderName",
+						"notifyEmail", "status", "effectiveAt", "created", "lastModified"
+					)
+				VALUES
+					(
+						$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+					)
+				RETURNING id`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = change.MerchantID
+
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(query)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(query)
+		}
+
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, change)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if change.Created.IsZero() {
+			change.Created = time.Now()
+		}
+		change.LastModified = change.Created
+
+		err = stmt.QueryRow(
+			change.MerchantID, change.RequesterID,
+			change.OldBankID, change.OldBankName, change.OldBankBranch, change.OldAccountNumber, change.OldAccountHolderName,
+			change.NewBankID, change.NewBankName, change.NewBankBranch, change.NewAccountNumber, change.NewAccountHolderName,
+			change.NotifyEmail, change.Status, change.EffectiveAt, change.Created, change.LastModified,
+		).Scan(&change.ID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, change)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: *change}
+	})
+	return output
+}
+
+// UpdateStatus function for moving a bank account change request, joins the running transaction if any
+func (mr *MerchantBankChangeRepoPostgres) UpdateStatus(ctxReq context.Context, change *model.MerchantBankChange) <-chan ResultRepository {
+	ctx := "MerchantBankChangeRepo-UpdateStatus"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+		tags["changeId"] = change.ID
+		tags["status"] = change.Status
+
+		query := `UPDATE merchant_bank_change SET "status"=$2, "reviewerId"=$3, "reason"=$4,
+		"mfaVerified"=$5, "reviewed"=$6, "applied"=$7, "effectiveAt"=$8, "verificationStatus"=$9,
+		"verificationReason"=$10, "overrideReason"=$11, "lastModified"=$12 WHERE "id"=$1;`
+
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(query)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(query)
+		}
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, change)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		change.LastModified = time.Now()
+		if _, err = stmt.Exec(
+			change.ID, change.Status, change.ReviewerID, change.Reason,
+			change.MFAVerified, change.Reviewed, change.Applied, change.EffectiveAt, change.VerificationStatus,
+			change.VerificationReason, change.OverrideReason, change.LastModified,
+		); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, change)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		output <- ResultRepository{Result: *change}
+	})
+	return output
+}
+
+// FindByID function for loading a bank account change request of a merchant
+func (mr *MerchantBankChangeRepoPostgres) FindByID(ctxReq context.Context, merchantID string, id int64) <-chan ResultRepository {
+	ctx := "MerchantBankChangeRepo-FindByID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + merchantBankChangeColumns + `
+				FROM merchant_bank_change WHERE "merchantId"=$1 AND "id"=$2`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = merchantID
+		tags["changeId"] = id
+
+		change, err := scanMerchantBankChange(mr.ReadDB.QueryRow(query, merchantID, id))
+		if err != nil {
+			if err != sql.ErrNoRows {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, id)
+			}
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: change}
+	})
+	return output
+}
+
+// GetByMerchantID function for loading the bank account change requests of a merchant, latest first
+func (mr *MerchantBankChangeRepoPostgres) GetByMerchantID(ctxReq context.Context, merchantID string, statuses []string) <-chan ResultRepository {
+	ctx := "MerchantBankChangeRepo-GetByMerchantID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + merchantBankChangeColumns + `
+				FROM merchant_bank_change WHERE "merchantId"=$1 AND (cardinality($2::text[]) = 0 OR "status" = ANY($2))
+				ORDER BY "created" DESC, "id" DESC`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = merchantID
+		tags["statuses"] = statuses
+
+		rows, err := mr.ReadDB.Query(query, merchantID, pq.Array(statuses))
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		changes := []model.MerchantBankChange{}
+		for rows.Next() {
+			change, err := scanMerchantBankChange(rows)
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
+				tags[helper.TextResponse] = err
+				output <- ResultRepository{Error: err}
+				return
+			}
+			changes = append(changes, change)
+		}
+
+		output <- ResultRepository{Result: changes, TotalData: len(changes)}
+	})
+	return output
+}
+
+// GetDueChanges function for loading approved bank account change requests whose cooling-off period ended
+func (mr *MerchantBankChangeRepoPostgres) GetDueChanges(ctxReq context.Context, now time.Time) <-chan ResultRepository {
+	ctx := "MerchantBankChangeRepo-GetDueChanges"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT ` + merchantBankChangeColumns + `
+				FROM merchant_bank_change WHERE "status"=$1 AND "effectiveAt" <= $2
+				ORDER BY "effectiveAt", "id"`
+
+		tags[helper.TextQuery] = query
+		tags["now"] = now
+
+		rows, err := mr.ReadDB.Query(query, model.BankChangeApproved, now)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, now)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		changes := []model.MerchantBankChange{}
+		for rows.Next() {
+			change, err := scanMerchantBankChange(rows)
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, now)
+				tags[helper.TextResponse] = err
+				output <- ResultRepository{Error: err}
+				return
+			}
+			changes = append(changes, change)
+		}
+
+		output <- ResultRepository{Result: changes, TotalData: len(changes)}
+	})
+	return output
+}
+
+// ExpireAwaitingMFA function for cancelling the bank account change requests the owner did not confirm
+// since before, returns the number of cancelled requests
+func (mr *MerchantBankChangeRepoPostgres) ExpireAwaitingMFA(ctxReq context.Context, before time.Time, reason string) <-chan ResultRepository {
+	ctx := "MerchantBankChangeRepo-ExpireAwaitingMFA"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `UPDATE merchant_bank_change SET "status"=$1, "reason"=$2, "lastModified"=now()
+				WHERE "status"=$3 AND "created" < $4`
+
+		tags[helper.TextQuery] = query
+		tags["before"] = before
+
+		result, err := mr.WriteDB.Exec(query, model.BankChangeCancelled, reason, model.BankChangeAwaitingMFA, before)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, before)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+		expired, _ := result.RowsAffected()
+
+		output <- ResultRepository{Result: expired}
+	})
+	return output
+}
+
+type merchantBankChangeScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMerchantBankChange(row merchantBankChangeScanner) (model.MerchantBankChange, error) {
+	var change model.MerchantBankChange
+	err := row.Scan(
+		&change.ID, &change.MerchantID, &change.RequesterID,
+		&change.OldBankID, &change.OldBankName, &change.OldBankBranch, &change.OldAccountNumber, &change.OldAccountHolderName,
+		&change.NewBankID, &change.NewBankName, &change.NewBankBranch, &change.NewAccountNumber, &change.NewAccountHolderName,
+		&change.NotifyEmail, &change.Status, &change.ReviewerID, &change.Reason, &change.MFAVerified, &change.Reviewed,
+		&change.EffectiveAt, &change.Applied, &change.VerificationStatus, &change.VerificationReason, &change.OverrideReason,
+		&change.Created, &change.LastModified,
+	)
+	return change, err
+}
@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// MerchantBankVerificationRepoPostgres data structure
+type MerchantBankVerificationRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMerchantBankVerificationRepoPostgres function for initializing repo
+func NewMerchantBankVerificationRepoPostgres(repo *repository.Repository) MerchantBankVerificationRepository {
+	return &MerchantBankVerificationRepoPostgres{repo}
+}
+
+// Save function for recording a bank account check, joins the running transaction if any
+func (mr *MerchantBankVerificationRepoPostgres) Save(ctxReq context.Context, verification model.MerchantBankVerification) <-chan ResultRepository {
+	ctx := "MerchantBankVerificationRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+
+		query := `INSERT INTO merchant_bank_verification
+					(
+						"merchantId", "bankId", "bankCode", "accountNumber", "accountHolderName",
+						"inquiryName", "matchedName", "score", "status", "reason",
+						"actorId", "created"
+					)
+				VALUES
+					(
+						$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+					)
+				RETURNING id`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = verification.MerchantID
+
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(query)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(query)
+		}
+
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, verification)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if verification.Created.IsZero() {
+			verification.Created = time.Now()
+		}
+
+		err = stmt.QueryRow(
+			verification.MerchantID, verification.BankID, verification.BankCode, verification.AccountNumber, verification.AccountHolderName,
+			verification.InquiryName, verification.MatchedName, verification.Score, verification.Status, verification.Reason,
+			verification.ActorID, verification.Created,
+		).Scan(&verification.ID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, verification)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: verification}
+	})
+	return output
+}
+
+// FindLatestByMerchantID function for loading the last bank account check of a merchant
+func (mr *MerchantBankVerificationRepoPostgres) FindLatestByMerchantID(ctxReq context.Context, merchantID string) <-chan ResultRepository {
+	ctx := "MerchantBankVerificationRepo-FindLatestByMerchantID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT "id", "merchantId", "bankId", "bankCode", "accountNumber", "accountHolderName",
+					"inquiryName", "matchedName", "score", "status", "reason",
+					"actorId", "created"
+				FROM merchant_bank_verification WHERE "merchantId"=$1
+				ORDER BY "created" DESC, "id" DESC LIMIT 1`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		var verification model.MerchantBankVerification
+		err := mr.ReadDB.QueryRow(query, merchantID).Scan(
+			&verification.ID, &verification.MerchantID, &verification.BankID, &verification.BankCode, &verification.AccountNumber, &verification.AccountHolderName,
+			&verification.InquiryName, &verification.MatchedName, &verification.Score, &verification.Status, &verification.Reason,
+			&verification.ActorID, &verification.Created,
+		)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
+			}
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: verification}
+	})
+	return output
+}
+
+// GetPending function for loading the bank account checks still waiting for the provider, only the last check
+// of each merchant counts and only when it was made before the given time
+func (mr *MerchantBankVerificationRepoPostgres) GetPending(ctxReq context.Context, before time.Time) <-chan ResultRepository {
+	ctx := "MerchantBankVerificationRepo-GetPending"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT "id", "merchantId", "bankId", "bankCode", "accountNumber", "accountHolderName",
+					"inquiryName", "matchedName", "score", "status", "reason",
+					"actorId", "created"
+				FROM (
+					SELECT DISTINCT ON ("merchantId") * FROM merchant_bank_verification
+					ORDER BY "merchantId", "created" DESC, "id" DESC
+				) latest
+				WHERE "status"=$1 AND "created" <= $2
+				ORDER BY "created", "id"`
+
+		tags[helper.TextQuery] = query
+		tags["before"] = before
+
+		rows, err := mr.ReadDB.Query(query, model.BankVerificationPending, before)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, before)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		verifications := []model.MerchantBankVerification{}
+		for rows.Next() {
+			var verification model.MerchantBankVerification
+			err := rows.Scan(
+				&verification.ID, &verification.MerchantID, &verification.BankID, &verification.BankCode, &verification.AccountNumber, &verification.AccountHolderName,
+				&verification.InquiryName, &verification.MatchedName, &verification.Score, &verification.Status, &verification.Reason,
+				&verification.ActorID, &verification.Created,
+			)
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, before)
+				tags[helper.TextResponse] = err
+				output <- ResultRepository{Error: err}
+				return
+			}
+			verifications = append(verifications, verification)
+		}
+
+		output <- ResultRepository{Result: verifications, TotalData: len(verifications)}
+	})
+	return output
+}
@@ -122,7 +122,8 @@ func (mr *MerchantDocumentRepoPostgres) FindMerchantDocumentByParam(ctxReq conte
 		}
 
 		q := fmt.Sprintf(`SELECT id, "merchantId", "documentType", "documentValue", "documentExpirationDate",
-			"creatorId", "creatorIp", "editorId", "editorIp", "version", "created", "lastModified" 
+			"creatorId", "creatorIp", "editorId", "editorIp", "version", "created", "lastModified",
+			"status", "rejectReason", "reviewerId", "reviewed", "expiryNotified"
 			FROM b2c_merchantdocument %s`, queryParam)
 
 		tags[helper.TextQuery] = q
@@ -147,8 +148,14 @@ func (mr *MerchantDocumentRepoPostgres) FindMerchantDocumentByParam(ctxReq conte
 			&documentExpirationDate, &merchantDocument.CreatorID, &merchantDocument.CreatorIP,
 			&merchantDocument.EditorID, &merchantDocument.EditorIP, &version,
 			&merchantDocument.Created, &merchantDocument.LastModified,
+			&merchantDocument.Status, &merchantDocument.RejectReason, &merchantDocument.ReviewerID,
+			&merchantDocument.Reviewed, &merchantDocument.ExpiryNotified,
 		)
 
+		if documentExpirationDate.Valid {
+			merchantDocument.DocumentExpirationDate = null.TimeFrom(documentExpirationDate.Time)
+		}
+
 		if version.Valid {
 			merchantDocument.Version = cast.ToInt(version.Int64)
 		}
@@ -238,8 +245,9 @@ func (mr *MerchantDocumentRepoPostgres) UpdateMerchantDocument(ctxReq context.Co
 		)
 		tags["docId"] = id
 
+		// a new file has to be reviewed again
 		query := `UPDATE b2c_merchantdocument SET "merchantId"=$2, "documentType"=$3, "documentValue"=$4,
-		"editorId"=$5, "editorIp"=$6, "lastModified"=$7 WHERE "id"=$1;`
+		"editorId"=$5, "editorIp"=$6, "lastModified"=$7, "status"=CASE WHEN "documentValue"=$4 THEN "status" ELSE 'PENDING' END WHERE "id"=$1;`
 
 		if mr.Tx != nil {
 			stmt, err = mr.Tx.Prepare(query)
@@ -327,7 +335,8 @@ func (mr *MerchantDocumentRepoPostgres) GetListMerchantDocument(ctxReq context.C
 		defer close(output)
 
 		q := fmt.Sprintf(`SELECT "id", "merchantId", "documentType", "documentValue", "documentExpirationDate",
-		"creatorId", "creatorIp", "editorId", "editorIp", "version", "created", "lastModified" 
+		"creatorId", "creatorIp", "editorId", "editorIp", "version", "created", "lastModified",
+		"status", "rejectReason", "reviewerId", "reviewed", "expiryNotified"
 		FROM b2c_merchantdocument WHERE "merchantId" ='%s'`, params.MerchantID)
 
 		tags[helper.TextQuery] = q
@@ -356,9 +365,11 @@ func (mr *MerchantDocumentRepoPostgres) GetListMerchantDocument(ctxReq context.C
 				&documentExpirationDate, &merchantDocument.CreatorID, &merchantDocument.CreatorIP,
 				&merchantDocument.EditorID, &merchantDocument.EditorIP, &version,
 				&merchantDocument.Created, &merchantDocument.LastModified,
+				&merchantDocument.Status, &merchantDocument.RejectReason, &merchantDocument.ReviewerID,
+				&merchantDocument.Reviewed, &merchantDocument.ExpiryNotified,
 			)
 
-			if merchantDocument.DocumentExpirationDate.Valid {
+			if documentExpirationDate.Valid {
 				merchantDocument.DocumentExpirationDate = null.TimeFrom(documentExpirationDate.Time)
 			}
 
@@ -424,3 +435,142 @@ func (mr *MerchantDocumentRepoPostgres) ResetRejectedDocument(ctxReq context.Con
 	})
 	return output
 }
+
+// UpdateDocumentReview function for saving the review state and expiration date of a merchant document
+func (mr *MerchantDocumentRepoPostgres) UpdateDocumentReview(ctxReq context.Context, param *model.B2CMerchantDocumentData) <-chan ResultRepository {
+	ctx := "MerchantDocumentRepo-UpdateDocumentReview"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+		tags["docId"] = param.ID
+		tags["status"] = param.Status
+
+		query := `UPDATE b2c_merchantdocument SET "status"=$2, "rejectReason"=$3, "reviewerId"=$4, "reviewed"=$5,
+		"documentExpirationDate"=$6, "expiryNotified"=$7, "lastModified"=$8 WHERE "id"=$1;`
+
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(query)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(query)
+		}
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, param)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if _, err = stmt.Exec(
+			param.ID, param.Status, param.RejectReason, param.ReviewerID, param.Reviewed,
+			param.DocumentExpirationDate, param.ExpiryNotified, param.LastModified,
+		); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, param)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		output <- ResultRepository{Result: *param}
+	})
+	return output
+}
+
+// ResetDocumentReview function for moving reviewed documents of the given types back to pending
+func (mr *MerchantDocumentRepoPostgres) ResetDocumentReview(ctxReq context.Context, merchantID string, documentTypes []string) <-chan ResultRepository {
+	ctx := "MerchantDocumentRepo-ResetDocumentReview"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+		tags["merchantId"] = merchantID
+		tags["documentTypes"] = documentTypes
+
+		query := `UPDATE b2c_merchantdocument SET "status"='PENDING', "rejectReason"='', "reviewed"=NULL,
+		"expiryNotified"=NULL, "lastModified"=$3 WHERE "merchantId"=$1 AND "documentType"=ANY($2) AND "status"<>'PENDING';`
+
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(query)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(query)
+		}
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, merchantID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if _, err = stmt.Exec(merchantID, pq.Array(documentTypes), time.Now()); err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		output <- ResultRepository{Error: nil}
+	})
+	return output
+}
+
+// GetDocumentsExpiringBefore function for getting verified documents of the given types with expiration date until before
+func (mr *MerchantDocumentRepoPostgres) GetDocumentsExpiringBefore(ctxReq context.Context, documentTypes []string, before time.Time) <-chan ResultRepository {
+	ctx := "MerchantDocumentRepo-GetDocumentsExpiringBefore"
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(_ context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["documentTypes"] = documentTypes
+		tags["before"] = before
+
+		q := `SELECT "id", "merchantId", "documentType", "documentValue", "documentExpirationDate",
+		"creatorId", "creatorIp", "editorId", "editorIp", "version", "created", "lastModified",
+		"status", "rejectReason", "reviewerId", "reviewed", "expiryNotified"
+		FROM b2c_merchantdocument
+		WHERE "documentType"=ANY($1) AND "status"='VERIFIED' AND "documentExpirationDate" <= $2
+		ORDER BY "merchantId", "documentExpirationDate"`
+
+		tags[helper.TextQuery] = q
+		rows, err := mr.ReadDB.Query(q, pq.Array(documentTypes), before)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, documentTypes)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		documents := []model.B2CMerchantDocumentData{}
+		for rows.Next() {
+			var (
+				merchantDocument model.B2CMerchantDocumentData
+				version          sql.NullInt64
+			)
+
+			if err = rows.Scan(
+				&merchantDocument.ID, &merchantDocument.MerchantID, &merchantDocument.DocumentType, &merchantDocument.DocumentValue,
+				&merchantDocument.DocumentExpirationDate, &merchantDocument.CreatorID, &merchantDocument.CreatorIP,
+				&merchantDocument.EditorID, &merchantDocument.EditorIP, &version,
+				&merchantDocument.Created, &merchantDocument.LastModified,
+				&merchantDocument.Status, &merchantDocument.RejectReason, &merchantDocument.ReviewerID,
+				&merchantDocument.Reviewed, &merchantDocument.ExpiryNotified,
+			); err != nil {
+				tags[helper.TextResponse] = err
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, documentTypes)
+				output <- ResultRepository{Error: err}
+				return
+			}
+
+			if version.Valid {
+				merchantDocument.Version = cast.ToInt(version.Int64)
+			}
+			documents = append(documents, merchantDocument)
+		}
+
+		output <- ResultRepository{Result: documents}
+	})
+	return output
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -24,31 +25,41 @@ func NewMerchantEmployeeRepoPostgres(repo *repository.Repository) *MerchantEmplo
 	return &MerchantEmployeeRepoPostgres{repo}
 }
 
-// Save function for saving  data
+// Save function for saving  data, joins the running transaction if any
 func (mr *MerchantEmployeeRepoPostgres) Save(merchantBank model.B2CMerchantEmployee) error {
 	ctx := "MerchantEmployeeRepo-create"
 
 	query := `INSERT INTO b2c_merchant_employees
 				(
-					"id", "merchantId", "memberId", "createdAt", "createdBy"
+					"id", "merchantId", "memberId", "createdAt", "createdBy", "role", "status"
 				)
 			VALUES
 				(
-					$1, $2, $3, $4, $5
+					$1, $2, $3, $4, $5, $6, $7
 
 				)
 			ON CONFLICT("merchantId", "memberId") 
 			DO UPDATE SET 
-			"merchantId"=$2, "memberId"=$3, "modifiedAt"=$4, "modifiedBy"=$5`
+			"merchantId"=$2, "memberId"=$3, "modifiedAt"=$4, "modifiedBy"=$5, "role"=$6, "status"=$7`
 
-	stmt, err := mr.WriteDB.Prepare(query)
+	var (
+		stmt *sql.Stmt
+		err  error
+	)
+	if mr.Tx != nil {
+		stmt, err = mr.Tx.Prepare(query)
+	} else {
+		stmt, err = mr.WriteDB.Prepare(query)
+	}
 
 	if err != nil {
 		return err
 	}
+	defer stmt.Close()
 
 	_, err = stmt.Exec(
 		merchantBank.ID, merchantBank.MerchantID, merchantBank.MemberID, merchantBank.CreatedAt, merchantBank.CreatedBy,
+		merchantBank.Role, merchantBank.Status,
 	)
 
 	if err != nil {
@@ -59,7 +70,7 @@ func (mr *MerchantEmployeeRepoPostgres) Save(merchantBank model.B2CMerchantEmplo
 	return nil
 }
 
-// ChangeStatus function for flagging delete
+// ChangeStatus function for flagging delete, joins the running transaction if any
 func (mr *MerchantEmployeeRepoPostgres) ChangeStatus(ctxReq context.Context, params model.B2CMerchantEmployee) error {
 	ctx := "MerchantEmployeeRepo-ChangeStatus"
 
@@ -76,7 +87,15 @@ func (mr *MerchantEmployeeRepoPostgres) ChangeStatus(ctxReq context.Context, par
 	tags[helper.TextMemberIDCamel] = params.MemberID
 	tags[helper.TextStatus] = params.Status
 
-	stmt, err := mr.WriteDB.Prepare(query)
+	var (
+		stmt *sql.Stmt
+		err  error
+	)
+	if mr.Tx != nil {
+		stmt, err = mr.Tx.Prepare(query)
+	} else {
+		stmt, err = mr.WriteDB.Prepare(query)
+	}
 	if err != nil {
 		helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, map[string]string{
 			helper.TextMerchantIDCamel: params.MerchantID,
@@ -100,6 +119,48 @@ func (mr *MerchantEmployeeRepoPostgres) ChangeStatus(ctxReq context.Context, par
 	return nil
 }
 
+// ChangeRole function for assigning a role to an employee, joins the running transaction if any
+func (mr *MerchantEmployeeRepoPostgres) ChangeRole(ctxReq context.Context, params model.B2CMerchantEmployee) error {
+	ctx := "MerchantEmployeeRepo-ChangeRole"
+
+	tr := tracer.StartTrace(ctxReq, ctx)
+	tags := make(map[string]interface{})
+	defer func() {
+		tr.Finish(tags)
+	}()
+
+	query := `UPDATE "b2c_merchant_employees" SET "role"=$1, "modifiedAt"=$2, "modifiedBy"=$3 WHERE "merchantId"=$4 AND "memberId"=$5;`
+
+	tags[helper.TextQuery] = query
+	tags[helper.TextMerchantIDCamel] = params.MerchantID
+	tags[helper.TextMemberIDCamel] = params.MemberID
+	tags["role"] = params.Role
+
+	var (
+		stmt *sql.Stmt
+		err  error
+	)
+	if mr.Tx != nil {
+		stmt, err = mr.Tx.Prepare(query)
+	} else {
+		stmt, err = mr.WriteDB.Prepare(query)
+	}
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, params)
+		tags[helper.TextResponse] = err
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(params.Role, params.ModifiedAt, params.ModifiedBy, params.MerchantID, params.MemberID); err != nil {
+		helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, params)
+		tags[helper.TextResponse] = err
+		return err
+	}
+
+	return nil
+}
+
 // GetAllMerchantEmployees retrieve merchant data based on given parameters
 func (mr *MerchantEmployeeRepoPostgres) GetAllMerchantEmployees(ctxReq context.Context, params *model.QueryMerchantEmployeeParameters) <-chan ResultRepository {
 	ctx := "MerchantRepoPostgres-GetAllMerchantEmployees"
@@ -111,7 +172,7 @@ func (mr *MerchantEmployeeRepoPostgres) GetAllMerchantEmployees(ctxReq context.C
 		query := `SELECT 
 					me."id", me."merchantId", me."memberId", 
 					m."firstName", m."lastName", m."email", m."gender", m."mobile", m."phone", m."birthDate", 
-					me."createdAt", me."modifiedAt", me."status", m."profilePicture", 
+					me."createdAt", me."modifiedAt", me."status", me."role", m."profilePicture", 
 					bcm."merchantLogo", bcm."merchantName", bcm."merchantType", 
 					bcm."vanityURL", bcm."isActive", bcm."isPKP"
 				FROM b2c_merchant_employees me
@@ -142,7 +203,7 @@ func (mr *MerchantEmployeeRepoPostgres) GetAllMerchantEmployees(ctxReq context.C
 			err := rows.Scan(
 				&row.ID, &row.MerchantID, &row.MemberID,
 				&row.FirstName, &row.LastName, &row.Email, &row.Gender, &row.Mobile, &row.Phone, &row.BirthDate,
-				&row.CreatedAt, &row.ModifiedAt, &row.Status, &row.ProfilePicture,
+				&row.CreatedAt, &row.ModifiedAt, &row.Status, &row.Role, &row.ProfilePicture,
 				&row.MerchantLogo, &row.MerchantName, &row.MerchantType,
 				&row.VanityURL, &row.IsActive, &row.IsPKP)
 
@@ -209,7 +270,7 @@ func (mr *MerchantEmployeeRepoPostgres) GetMerchantEmployees(ctxReq context.Cont
 		query := `SELECT 
 					me."id", me."merchantId", me."memberId", 
 					m."firstName", m."lastName", m."email", m."gender", m."mobile", m."phone", m."birthDate", 
-					me."createdAt", me."modifiedAt", me."status", m."profilePicture",
+					me."createdAt", me."modifiedAt", me."status", me."role", m."profilePicture",
 					bcm."merchantLogo", bcm."merchantName", bcm."merchantType", 
 					bcm."vanityURL", bcm."isActive", bcm."isPKP" 
 				FROM b2c_merchant_employees me
@@ -239,7 +300,7 @@ func (mr *MerchantEmployeeRepoPostgres) GetMerchantEmployees(ctxReq context.Cont
 		err = stmt.QueryRow(queryValues...).Scan(
 			&row.ID, &row.MerchantID, &row.MemberID,
 			&row.FirstName, &row.LastName, &row.Email, &row.Gender, &row.Mobile, &row.Phone, &row.BirthDate,
-			&row.CreatedAt, &row.ModifiedAt, &row.Status, &row.ProfilePicture,
+			&row.CreatedAt, &row.ModifiedAt, &row.Status, &row.Role, &row.ProfilePicture,
 			&row.MerchantLogo, &row.MerchantName, &row.MerchantType,
 			&row.VanityURL, &row.IsActive, &row.IsPKP)
 
@@ -447,7 +447,15 @@ func (mr *MerchantRepoPostgres) SoftDelete(ctxReq context.Context, merchantID st
 		tags[helper.TextQuery] = queryDelete
 		tags[helper.TextMerchantIDCamel] = merchantID
 
-		stmt, err := mr.WriteDB.Prepare(queryDelete)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(queryDelete)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(queryDelete)
+		}
 		if err != nil {
 			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
 			tags[helper.TextResponse] = err
@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+)
+
+// MerchantStatusHistoryRepoPostgres data structure
+type MerchantStatusHistoryRepoPostgres struct {
+	*repository.Repository
+}
+
+// NewMerchantStatusHistoryRepoPostgres function for initializing repo
+func NewMerchantStatusHistoryRepoPostgres(repo *repository.Repository) MerchantStatusHistoryRepository {
+	return &MerchantStatusHistoryRepoPostgres{repo}
+}
+
+// Save function for recording a merchant transition, joins the running transaction if any
+func (mr *MerchantStatusHistoryRepoPostgres) Save(ctxReq context.Context, history model.MerchantStatusHistory) <-chan ResultRepository {
+	ctx := "MerchantStatusHistoryRepo-Save"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		var (
+			stmt *sql.Stmt
+			err  error
+		)
+
+		query := `INSERT INTO merchant_status_history
+					(
+						"merchantId", "field", "event", "fromStatus", "toStatus",
+						"actorId", "actorIp", "reason", "created"
+					)
+				VALUES
+					(
+						$1, $2, $3, $4, $5, $6, $7, $8, $9
+					)
+				RETURNING id`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = history.MerchantID
+
+		if mr.Tx != nil {
+			stmt, err = mr.Tx.Prepare(query)
+		} else {
+			stmt, err = mr.WriteDB.Prepare(query)
+		}
+
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextPrepareDatabase, err, history)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if history.Created.IsZero() {
+			history.Created = time.Now()
+		}
+
+		err = stmt.QueryRow(
+			history.MerchantID, history.Field, history.Event, history.FromStatus, history.ToStatus,
+			history.ActorID, history.ActorIP, history.Reason, history.Created,
+		).Scan(&history.ID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, history)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Result: history}
+	})
+	return output
+}
+
+// GetByMerchantID function for loading the transitions of a merchant, latest first
+func (mr *MerchantStatusHistoryRepoPostgres) GetByMerchantID(ctxReq context.Context, merchantID string) <-chan ResultRepository {
+	ctx := "MerchantStatusHistoryRepo-GetByMerchantID"
+
+	output := make(chan ResultRepository)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		query := `SELECT "id", "merchantId", "field", "event", "fromStatus", "toStatus",
+					"actorId", "actorIp", "reason", "created"
+				FROM merchant_status_history WHERE "merchantId"=$1
+				ORDER BY "created" DESC, "id" DESC`
+
+		tags[helper.TextQuery] = query
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		rows, err := mr.ReadDB.Query(query, merchantID)
+		if err != nil {
+			helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
+			tags[helper.TextResponse] = err
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer rows.Close()
+
+		histories := []model.MerchantStatusHistory{}
+		for rows.Next() {
+			var history model.MerchantStatusHistory
+			err = rows.Scan(
+				&history.ID, &history.MerchantID, &history.Field, &history.Event, &history.FromStatus, &history.ToStatus,
+				&history.ActorID, &history.ActorIP, &history.Reason, &history.Created,
+			)
+			if err != nil {
+				helper.SendErrorLog(ctxReq, ctx, helper.TextExecQuery, err, merchantID)
+				tags[helper.TextResponse] = err
+				output <- ResultRepository{Error: err}
+				return
+			}
+			histories = append(histories, history)
+		}
+
+		output <- ResultRepository{Result: histories, TotalData: len(histories)}
+	})
+	return output
+}
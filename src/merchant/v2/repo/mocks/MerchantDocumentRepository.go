@@ -3,6 +3,7 @@
 package mocks
 
 import context "context"
+import time "time"
 import mock "github.com/stretchr/testify/mock"
 import model "github.com/Bhinneka/user-service/src/merchant/v2/model"
 import repo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
@@ -42,6 +43,22 @@ func (_m *MerchantDocumentRepository) FindMerchantDocumentByParam(ctxReq context
 	return r0
 }
 
+// GetDocumentsExpiringBefore provides a mock function with given fields: ctxReq, documentTypes, before
+func (_m *MerchantDocumentRepository) GetDocumentsExpiringBefore(ctxReq context.Context, documentTypes []string, before time.Time) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, documentTypes, before)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, documentTypes, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // GetListMerchantDocument provides a mock function with given fields: ctxReq, params
 func (_m *MerchantDocumentRepository) GetListMerchantDocument(ctxReq context.Context, params *model.B2CMerchantDocumentQueryInput) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, params)
@@ -74,6 +91,22 @@ func (_m *MerchantDocumentRepository) InsertNewMerchantDocument(ctxReq context.C
 	return r0
 }
 
+// ResetDocumentReview provides a mock function with given fields: ctxReq, merchantID, documentTypes
+func (_m *MerchantDocumentRepository) ResetDocumentReview(ctxReq context.Context, merchantID string, documentTypes []string) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, merchantID, documentTypes)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, merchantID, documentTypes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // ResetRejectedDocument provides a mock function with given fields: ctxReq, param
 func (_m *MerchantDocumentRepository) ResetRejectedDocument(ctxReq context.Context, param model.B2CMerchantDocumentData) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, param)
@@ -118,6 +151,22 @@ func (_m *MerchantDocumentRepository) SaveMerchantDocumentGWS(_a0 model.B2CMerch
 	return r0
 }
 
+// UpdateDocumentReview provides a mock function with given fields: ctxReq, param
+func (_m *MerchantDocumentRepository) UpdateDocumentReview(ctxReq context.Context, param *model.B2CMerchantDocumentData) <-chan repo.ResultRepository {
+	ret := _m.Called(ctxReq, param)
+
+	var r0 <-chan repo.ResultRepository
+	if rf, ok := ret.Get(0).(func(context.Context, *model.B2CMerchantDocumentData) <-chan repo.ResultRepository); ok {
+		r0 = rf(ctxReq, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan repo.ResultRepository)
+		}
+	}
+
+	return r0
+}
+
 // UpdateMerchantDocument provides a mock function with given fields: ctxReq, id, param
 func (_m *MerchantDocumentRepository) UpdateMerchantDocument(ctxReq context.Context, id string, param *model.B2CMerchantDocumentData) <-chan repo.ResultRepository {
 	ret := _m.Called(ctxReq, id, param)
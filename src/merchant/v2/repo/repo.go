@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/Bhinneka/user-service/src/merchant/v2/model"
 )
@@ -48,6 +49,9 @@ type MerchantDocumentRepository interface {
 	InsertNewMerchantDocument(ctxReq context.Context, param *model.B2CMerchantDocumentData) <-chan ResultRepository
 	UpdateMerchantDocument(ctxReq context.Context, id string, param *model.B2CMerchantDocumentData) <-chan ResultRepository
 	ResetRejectedDocument(ctxReq context.Context, param model.B2CMerchantDocumentData) <-chan ResultRepository
+	UpdateDocumentReview(ctxReq context.Context, param *model.B2CMerchantDocumentData) <-chan ResultRepository
+	ResetDocumentReview(ctxReq context.Context, merchantID string, documentTypes []string) <-chan ResultRepository
+	GetDocumentsExpiringBefore(ctxReq context.Context, documentTypes []string, before time.Time) <-chan ResultRepository
 }
 
 // MerchantBankRepository interface abstraction
@@ -80,9 +84,33 @@ type MerchantAddressRepository interface {
 type MerchantEmployeeRepository interface {
 	Save(model.B2CMerchantEmployee) error
 	ChangeStatus(ctxReq context.Context, params model.B2CMerchantEmployee) error
+	ChangeRole(ctxReq context.Context, params model.B2CMerchantEmployee) error
 
 	GetAllMerchantEmployees(ctxReq context.Context, params *model.QueryMerchantEmployeeParameters) <-chan ResultRepository
 	GetTotalMerchantEmployees(ctxReq context.Context, params *model.QueryMerchantEmployeeParameters) <-chan ResultRepository
 
 	GetMerchantEmployees(ctxReq context.Context, params *model.QueryMerchantEmployeeParameters) <-chan ResultRepository
 }
+
+// MerchantStatusHistoryRepository interface abstraction
+type MerchantStatusHistoryRepository interface {
+	Save(ctxReq context.Context, history model.MerchantStatusHistory) <-chan ResultRepository
+	GetByMerchantID(ctxReq context.Context, merchantID string) <-chan ResultRepository
+}
+
+// MerchantBankVerificationRepository interface abstraction
+type MerchantBankVerificationRepository interface {
+	Save(ctxReq context.Context, verification model.MerchantBankVerification) <-chan ResultRepository
+	FindLatestByMerchantID(ctxReq context.Context, merchantID string) <-chan ResultRepository
+	GetPending(ctxReq context.Context, before time.Time) <-chan ResultRepository
+}
+
+// MerchantBankChangeRepository interface abstraction
+type MerchantBankChangeRepository interface {
+	Save(ctxReq context.Context, change *model.MerchantBankChange) <-chan ResultRepository
+	UpdateStatus(ctxReq context.Context, change *model.MerchantBankChange) <-chan ResultRepository
+	FindByID(ctxReq context.Context, merchantID string, id int64) <-chan ResultRepository
+	GetByMerchantID(ctxReq context.Context, merchantID string, statuses []string) <-chan ResultRepository
+	GetDueChanges(ctxReq context.Context, now time.Time) <-chan ResultRepository
+	ExpireAwaitingMFA(ctxReq context.Context, before time.Time, reason string) <-chan ResultRepository
+}
@@ -28,6 +28,12 @@ const (
 	upgradeStatusPlaceholder = "##UPGRADE_STATUS##"
 	reasonRejectText         = "##REASON_REJECT##"
 	adminName                = "##ADMIN_NAME##"
+	documentsPlaceholder     = "##DOCUMENTS##"
+	bankAccountPlaceholder   = "##BANK_ACCOUNT##"
+	effectiveDatePlaceholder = "##EFFECTIVE_DATE##"
+	formerOwnerPlaceholder   = "##FORMER_OWNER##"
+	newOwnerPlaceholder      = "##NEW_OWNER##"
+	transferDatePlaceholder  = "##TRANSFER_DATE##"
 
 	textErrorSturgeonCFURL = "you need to specify %s in the environment variable"
 )
@@ -375,32 +381,6 @@ func (m *MerchantUseCaseImpl) SendEmailActivation(ctxReq context.Context, mercha
 	return output
 }
 
-func (m *MerchantUseCaseImpl) sendEmailActivationOrApproval(ctxReq context.Context, oldData model.B2CMerchantDataV2, payload *model.B2CMerchantCreateInput) error {
-	if !oldData.IsActive && payload.IsActive {
-		plQueue := model.MerchantPayloadEmail{
-			MemberName: oldData.MerchantName,
-			Data:       oldData,
-		}
-		go m.QueuePublisher.QueueJob(ctxReq, plQueue, oldData.ID, "SendEmailActivation")
-	}
-
-	// send email only if current upgradeStatus is not ACTIVE and new payload is ACTIVE
-	if oldData.UpgradeStatus.String != model.ActiveString && payload.UpgradeStatus == model.ActiveString {
-		// prevent repetitive sending email if merchant being updated
-		if oldData.UpgradeStatus.String == model.ActiveString && payload.UpgradeStatus == model.ActiveString {
-			// skip send email, current status is already active
-			return nil
-		}
-
-		plQueue := model.MerchantPayloadEmail{
-			MemberName: oldData.MerchantName,
-			Data:       oldData,
-		}
-		go m.QueuePublisher.QueueJob(ctxReq, plQueue, oldData.ID, "SendEmailApproval")
-	}
-	return nil
-}
-
 // SendEmailMerchantRejectRegistration usecase function for send email merchant reject registration
 func (m *MerchantUseCaseImpl) SendEmailMerchantRejectRegistration(ctxReq context.Context, data model.B2CMerchantDataV2, fullName string) <-chan ResultUseCase {
 	ctx := "MerchantUseCase-SendEmailRejectRegistration"
@@ -660,3 +640,192 @@ func (m *MerchantUseCaseImpl) SendEmailAdmin(ctxReq context.Context, data model.
 
 	return output
 }
+
+// SendEmailMerchantDocumentExpiry usecase function for reminding merchant of documents close to expiration date
+func (m *MerchantUseCaseImpl) SendEmailMerchantDocumentExpiry(ctxReq context.Context, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan ResultUseCase {
+	return m.sendEmailMerchantDocuments(ctxReq, "MerchantUseCase-SendEmailMerchantDocumentExpiry", "EMAIL_MERCHANT_DOCUMENT_EXPIRY", model.MerchantDocumentExpirySubject, merchant, documents)
+}
+
+// SendEmailMerchantRestricted usecase function for telling merchant the store is restricted because of expired documents
+func (m *MerchantUseCaseImpl) SendEmailMerchantRestricted(ctxReq context.Context, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan ResultUseCase {
+	return m.sendEmailMerchantDocuments(ctxReq, "MerchantUseCase-SendEmailMerchantRestricted", "EMAIL_MERCHANT_RESTRICTED", model.MerchantRestrictedSubject, merchant, documents)
+}
+
+// SendEmailMerchantBankChange usecase function for warning the merchant email of a requested bank account change
+func (m *MerchantUseCaseImpl) SendEmailMerchantBankChange(ctxReq context.Context, merchant model.B2CMerchantDataV2, change *model.MerchantBankChange) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-SendEmailMerchantBankChange"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		if change == nil || change.NotifyEmail == "" {
+			output <- ResultUseCase{Error: errBankChangeNotFound, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		tags["merchantId"] = merchant.ID
+		tags["changeId"] = change.ID
+
+		// get template email
+		templateEmailDetail, errTemplate := m.GetTemplateEmail(ctxReq, "EMAIL_MERCHANT_BANK_CHANGE")
+		if errTemplate != nil {
+			output <- ResultUseCase{Error: errTemplate, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		emailContent := templateEmailDetail.Content
+		emailContent = strings.Replace(emailContent, merchantPlaceholder, merchant.MerchantName, -1)
+		emailContent = strings.Replace(emailContent, bankAccountPlaceholder, change.MaskedNewAccount(), -1)
+		emailContent = strings.Replace(emailContent, effectiveDatePlaceholder, change.EffectiveAt.Format(helper.FormatDateDB), -1)
+
+		bCCEmail, err := m.getBCC()
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		pl := serviceModel.Email{}
+		pl.From = serviceModel.EmailCare
+		pl.FromName = serviceModel.NoReplyName
+		pl.To = []string{change.NotifyEmail}
+		pl.ToName = []string{merchant.MerchantName}
+		if bCCEmail != "" {
+			pl.BCC = []string{bCCEmail}
+			pl.BCCName = []string{serviceModel.NoReplyName}
+		}
+		pl.Subject = model.MerchantBankChangeSubject
+		pl.Content = emailContent
+
+		if err = m.sendEmailMerchant(ctxReq, pl); err != nil {
+			output <- ResultUseCase{Error: errors.New(errMsgFailedSendEmail), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		output <- ResultUseCase{Result: merchant}
+	})
+
+	return output
+}
+
+// SendEmailMerchantOwnershipTransfer usecase function for telling the former and the new owner the merchant changed hands
+func (m *MerchantUseCaseImpl) SendEmailMerchantOwnershipTransfer(ctxReq context.Context, merchant model.B2CMerchantDataV2, transfer *model.MerchantOwnershipTransfer) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-SendEmailMerchantOwnershipTransfer"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		if transfer == nil {
+			output <- ResultUseCase{Error: errOwnershipTransferNotFound, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		tags["merchantId"] = merchant.ID
+
+		to, toName := []string{}, []string{}
+		for _, owner := range [][2]string{{transfer.FormerOwnerEmail, transfer.FormerOwnerName}, {transfer.NewOwnerEmail, transfer.NewOwnerName}} {
+			if owner[0] != "" {
+				to = append(to, owner[0])
+				toName = append(toName, owner[1])
+			}
+		}
+		if len(to) == 0 {
+			output <- ResultUseCase{Error: errOwnershipTransferNotFound, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		// get template email
+		templateEmailDetail, errTemplate := m.GetTemplateEmail(ctxReq, "EMAIL_MERCHANT_OWNERSHIP_TRANSFER")
+		if errTemplate != nil {
+			output <- ResultUseCase{Error: errTemplate, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		emailContent := templateEmailDetail.Content
+		emailContent = strings.Replace(emailContent, merchantPlaceholder, merchant.MerchantName, -1)
+		emailContent = strings.Replace(emailContent, formerOwnerPlaceholder, transfer.FormerOwnerName, -1)
+		emailContent = strings.Replace(emailContent, newOwnerPlaceholder, transfer.NewOwnerName, -1)
+		emailContent = strings.Replace(emailContent, transferDatePlaceholder, transfer.Transferred.Format(helper.FormatDateDB), -1)
+
+		bCCEmail, err := m.getBCC()
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		pl := serviceModel.Email{}
+		pl.From = serviceModel.EmailCare
+		pl.FromName = serviceModel.NoReplyName
+		pl.To = to
+		pl.ToName = toName
+		if bCCEmail != "" {
+			pl.BCC = []string{bCCEmail}
+			pl.BCCName = []string{serviceModel.NoReplyName}
+		}
+		pl.Subject = model.MerchantOwnershipTransferSubject
+		pl.Content = emailContent
+
+		if err = m.sendEmailMerchant(ctxReq, pl); err != nil {
+			output <- ResultUseCase{Error: errors.New(errMsgFailedSendEmail), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		output <- ResultUseCase{Result: merchant}
+	})
+
+	return output
+}
+
+func (m *MerchantUseCaseImpl) sendEmailMerchantDocuments(ctxReq context.Context, ctx, templateID, subject string, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan ResultUseCase {
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		// get template email
+		templateEmailDetail, errTemplate := m.GetTemplateEmail(ctxReq, templateID)
+		if errTemplate != nil {
+			output <- ResultUseCase{Error: errTemplate, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		tags["templateId"] = templateID
+		tags["merchantId"] = merchant.ID
+
+		// one line per document, e.g. NPWP - 2026-12-31
+		documentLines := []string{}
+		for _, document := range documents {
+			line := strings.TrimSuffix(document.DocumentType, "-file")
+			if document.DocumentExpirationDate.Valid {
+				line = fmt.Sprintf("%s - %s", line, document.DocumentExpirationDate.Time.Format(helper.FormatDateDB))
+			}
+			documentLines = append(documentLines, line)
+		}
+
+		emailContent := templateEmailDetail.Content
+		emailContent = strings.Replace(emailContent, merchantPlaceholder, merchant.MerchantName, -1)
+		emailContent = strings.Replace(emailContent, documentsPlaceholder, strings.Join(documentLines, "<br>"), -1)
+
+		bCCEmail, err := m.getBCC()
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		pl := serviceModel.Email{}
+		pl.From = serviceModel.EmailCare
+		pl.FromName = serviceModel.NoReplyName
+		pl.To = []string{merchant.MerchantEmail.String}
+		pl.ToName = []string{merchant.MerchantName}
+		if bCCEmail != "" {
+			pl.BCC = []string{bCCEmail}
+			pl.BCCName = []string{serviceModel.NoReplyName}
+		}
+		pl.Subject = subject
+		pl.Content = emailContent
+
+		if err = m.sendEmailMerchant(ctxReq, pl); err != nil {
+			output <- ResultUseCase{Error: errors.New(errMsgFailedSendEmail), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		output <- ResultUseCase{Result: merchant}
+	})
+
+	return output
+}
@@ -224,7 +224,7 @@ func (m *MerchantAddressUseCaseImpl) validateAddress(ctxReq context.Context, dat
 	}
 
 	// get merchant data by member id
-	getMerchantByUser := m.MerchantRepo.FindMerchantByUser(ctxReq, memberID)
+	getMerchantByUser := findActingMerchant(ctxReq, m.MerchantRepo, memberID)
 	if getMerchantByUser.Result == nil {
 		err := fmt.Errorf("Merchant doesn't exist")
 		return oldData, data, err
@@ -686,7 +686,7 @@ func (m *MerchantAddressUseCaseImpl) loadMerchant(ctxReq context.Context, params
 	var merchant model.B2CMerchantDataV2
 	privacy := "private"
 	if params.MemberID != "" {
-		getMerchantByUser := m.MerchantRepo.FindMerchantByUser(ctxReq, params.MemberID)
+		getMerchantByUser := findActingMerchant(ctxReq, m.MerchantRepo, params.MemberID)
 		if getMerchantByUser.Result == nil {
 			return fmt.Errorf(msgErrorFindAddress)
 		}
@@ -819,8 +819,13 @@ func (m *MerchantAddressUseCaseImpl) FindWarehouseAddress(ctxReq context.Context
 			return
 		}
 
-		// get merchant data by member id & merchant id
-		getMerchantByUser := m.MerchantRepo.FindMerchantByID(ctxReq, addressDetail.MerchantID, memberID)
+		// get merchant data by member id & merchant id, an employee loads the merchant the employee acts on
+		var getMerchantByUser repo.ResultRepository
+		if access, ok := employeeAccess(ctxReq, memberID); ok && access.MerchantID == addressDetail.MerchantID {
+			getMerchantByUser = m.MerchantRepo.LoadMerchant(ctxReq, addressDetail.MerchantID, private)
+		} else {
+			getMerchantByUser = m.MerchantRepo.FindMerchantByID(ctxReq, addressDetail.MerchantID, memberID)
+		}
 		if getMerchantByUser.Result == nil {
 			err := fmt.Errorf(msgErrorFindAddress)
 			tags[helper.TextResponse] = err
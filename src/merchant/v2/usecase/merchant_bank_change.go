@@ -0,0 +1,621 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+	dgoogauth "github.com/dgryski/dgoogauth"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+const (
+	// defaultBankChangeCoolingOff time between the request of a bank account change and its earliest application
+	defaultBankChangeCoolingOff = 72 * time.Hour
+	// defaultBankChangeMFATTL time the owner has to confirm a bank account change before it is cancelled
+	defaultBankChangeMFATTL = 24 * time.Hour
+)
+
+// openBankChangeStatuses statuses of the bank account changes that can still be applied
+var openBankChangeStatuses = []string{model.BankChangeAwaitingMFA, model.BankChangePendingApproval, model.BankChangeApproved}
+
+// GetOwnMerchantBankChanges function for the owner to load the bank account changes of the merchant
+func (m *MerchantUseCaseImpl) GetOwnMerchantBankChanges(ctxReq context.Context, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-GetOwnMerchantBankChanges"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, userAttribute.UserID)
+		if merchantResult.Error != nil {
+			tags[helper.TextResponse] = merchantResult.Error.Error()
+			output <- ResultUseCase{Error: merchantResult.Error, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+		output <- <-m.GetMerchantBankChanges(ctxReq, merchant.ID)
+	})
+	return output
+}
+
+// SendMerchantBankChangeOTP function for sending the owner a one time code by email or sms to confirm a bank account change
+func (m *MerchantUseCaseImpl) SendMerchantBankChangeOTP(ctxReq context.Context, changeID int64, input model.MerchantBankChangeOTPInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-SendMerchantBankChangeOTP"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["changeId"] = changeID
+		tags["method"] = input.Method
+
+		change, err := m.findOwnBankChange(ctxReq, changeID, userAttribute)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankChangeHTTPStatus(err)}
+			return
+		}
+		if change.Status != model.BankChangeAwaitingMFA {
+			output <- ResultUseCase{Error: errBankChangeStatus, HTTPStatus: http.StatusConflict}
+			return
+		}
+		if isBankChangeMFAExpired(change, time.Now()) {
+			output <- ResultUseCase{Error: errBankChangeExpired, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		if !memberModel.IsMFAOTPMethod(input.Method) || m.MFAOTP == nil {
+			output <- ResultUseCase{Error: errors.New(memberModel.ErrorMFAMethod), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		enrolled, err := m.findMFAMethod(ctxReq, userAttribute.UserID, input.Method)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if err := m.MFAOTP.Send(ctxReq, input.Method, userAttribute.UserID, bankChangeOTPScope(change.ID), enrolled.Target); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}
+			return
+		}
+
+		output <- ResultUseCase{Result: memberModel.SuccessMFAOTPSend}
+	})
+	return output
+}
+
+// ConfirmMerchantBankChange function for the owner to confirm a bank account change with the second factor,
+// the confirmed change waits for an admin
+func (m *MerchantUseCaseImpl) ConfirmMerchantBankChange(ctxReq context.Context, changeID int64, input model.MerchantBankChangeConfirmInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ConfirmMerchantBankChange"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["changeId"] = changeID
+		tags["method"] = input.Method
+
+		change, err := m.findOwnBankChange(ctxReq, changeID, userAttribute)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankChangeHTTPStatus(err)}
+			return
+		}
+		if change.Status != model.BankChangeAwaitingMFA {
+			output <- ResultUseCase{Error: errBankChangeStatus, HTTPStatus: http.StatusConflict}
+			return
+		}
+		if isBankChangeMFAExpired(change, time.Now()) {
+			output <- ResultUseCase{Error: errBankChangeExpired, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		if status, err := m.verifyOwnerMFA(ctxReq, userAttribute.UserID, bankChangeOTPScope(change.ID), input.Method, input.OTP); err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: status}
+			return
+		}
+
+		// the admin sees the name inquiry of the new account, only a verified one is approved without a reason
+		m.verifyBankChangeAccount(ctxReq, &change)
+		tags["verificationStatus"] = change.VerificationStatus
+
+		change.Status = model.BankChangePendingApproval
+		change.MFAVerified = null.TimeFrom(time.Now())
+		updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, &change)
+		if updateResult.Error != nil {
+			output <- ResultUseCase{Error: updateResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: change}
+	})
+	return output
+}
+
+// CancelMerchantBankChange function for the owner to withdraw a bank account change not applied yet
+func (m *MerchantUseCaseImpl) CancelMerchantBankChange(ctxReq context.Context, changeID int64, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-CancelMerchantBankChange"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["changeId"] = changeID
+
+		change, err := m.findOwnBankChange(ctxReq, changeID, userAttribute)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankChangeHTTPStatus(err)}
+			return
+		}
+		if !change.IsOpen() {
+			output <- ResultUseCase{Error: errBankChangeStatus, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		change.Status = model.BankChangeCancelled
+		updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, &change)
+		if updateResult.Error != nil {
+			output <- ResultUseCase{Error: updateResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: change}
+	})
+	return output
+}
+
+// GetMerchantBankChanges function for loading the bank account changes of a merchant, latest first
+func (m *MerchantUseCaseImpl) GetMerchantBankChanges(ctxReq context.Context, merchantID string) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-GetMerchantBankChanges"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		changeResult := <-m.BankChangeRepo.GetByMerchantID(ctxReq, merchantID, []string{})
+		if changeResult.Error != nil {
+			output <- ResultUseCase{Error: changeResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: changeResult.Result, TotalData: changeResult.TotalData}
+	})
+	return output
+}
+
+// ApproveMerchantBankChange function for an admin to approve a bank account change confirmed by the owner,
+// the change is applied once the cooling-off period counted from the approval ends. A new account not verified
+// by the name inquiry is only approved with an override reason
+func (m *MerchantUseCaseImpl) ApproveMerchantBankChange(ctxReq context.Context, merchantID string, changeID int64, overrideReason string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ApproveMerchantBankChange"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+		tags["changeId"] = changeID
+
+		change, err := m.findBankChange(ctxReq, merchantID, changeID)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankChangeHTTPStatus(err)}
+			return
+		}
+		if change.Status != model.BankChangePendingApproval {
+			output <- ResultUseCase{Error: errBankChangeStatus, HTTPStatus: http.StatusConflict}
+			return
+		}
+		if change.RequesterID == userAttribute.UserID {
+			output <- ResultUseCase{Error: errBankChangeReviewer, HTTPStatus: http.StatusForbidden}
+			return
+		}
+
+		// an inquiry the provider left pending or could not answer is asked again before the admin needs a reason
+		if !isBankChangeVerificationFinal(change.VerificationStatus) {
+			m.verifyBankChangeAccount(ctxReq, &change)
+		}
+		tags["verificationStatus"] = change.VerificationStatus
+		if change.VerificationStatus != model.BankVerificationVerified {
+			change.OverrideReason = strings.TrimSpace(overrideReason)
+		}
+		if !change.CanBeApproved() {
+			output <- ResultUseCase{Error: errBankChangeUnverified, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		// the cooling-off period starts at the approval, a change waiting long for approval is never applied at once
+		now := time.Now()
+		change.Status = model.BankChangeApproved
+		change.ReviewerID = userAttribute.UserID
+		change.Reviewed = null.TimeFrom(now)
+		change.EffectiveAt = now.Add(bankChangeCoolingOff())
+		updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, &change)
+		if updateResult.Error != nil {
+			output <- ResultUseCase{Error: updateResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: change}
+	})
+	return output
+}
+
+// RejectMerchantBankChange function for an admin to reject a bank account change not applied yet
+func (m *MerchantUseCaseImpl) RejectMerchantBankChange(ctxReq context.Context, merchantID string, changeID int64, reason string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-RejectMerchantBankChange"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+		tags["changeId"] = changeID
+
+		reason = strings.TrimSpace(reason)
+		if reason == "" {
+			output <- ResultUseCase{Error: errBankChangeReason, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		change, err := m.findBankChange(ctxReq, merchantID, changeID)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankChangeHTTPStatus(err)}
+			return
+		}
+		if !change.IsOpen() {
+			output <- ResultUseCase{Error: errBankChangeStatus, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		change.Status = model.BankChangeRejected
+		change.ReviewerID = userAttribute.UserID
+		change.Reviewed = null.TimeFrom(time.Now())
+		change.Reason = reason
+		updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, &change)
+		if updateResult.Error != nil {
+			output <- ResultUseCase{Error: updateResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: change}
+	})
+	return output
+}
+
+// ProcessBankChanges function for applying the approved bank account changes whose cooling-off period ended
+func (m *MerchantUseCaseImpl) ProcessBankChanges(ctxReq context.Context, now time.Time) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ProcessBankChanges"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["now"] = now
+
+		summary := model.MerchantBankChangeResult{}
+		expireResult := <-m.BankChangeRepo.ExpireAwaitingMFA(ctxReq, now.Add(-bankChangeMFATTL()), errBankChangeExpired.Error())
+		if expireResult.Error != nil {
+			helper.SendErrorLog(ctxReq, ctx, "expire_bank_changes", expireResult.Error, now)
+		}
+		summary.Expired, _ = expireResult.Result.(int64)
+
+		changeResult := <-m.BankChangeRepo.GetDueChanges(ctxReq, now)
+		if changeResult.Error != nil {
+			output <- ResultUseCase{Error: changeResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		changes, _ := changeResult.Result.([]model.MerchantBankChange)
+
+		for i := range changes {
+			change := &changes[i]
+			if err := m.applyBankChange(ctxReq, change, now); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "apply_bank_change", err, change.ID)
+				summary.Failed++
+				continue
+			}
+			if change.Status == model.BankChangeApplied {
+				summary.Applied++
+			} else {
+				summary.Rejected++
+			}
+		}
+		tags[helper.TextResponse] = summary
+
+		output <- ResultUseCase{Result: summary}
+	})
+	return output
+}
+
+// applyBankChange stores the new bank account on the merchant, a change whose old account is no longer
+// the merchant bank account, e.g. changed by an admin meanwhile, or whose new account is neither verified
+// nor approved with an override reason is rejected instead
+func (m *MerchantUseCaseImpl) applyBankChange(ctxReq context.Context, change *model.MerchantBankChange, now time.Time) error {
+	if !change.CanBeApproved() {
+		change.Status = model.BankChangeRejected
+		change.Reason = errBankChangeUnverified.Error()
+		updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, change)
+		return updateResult.Error
+	}
+
+	merchantResult := m.MerchantRepo.LoadMerchant(ctxReq, change.MerchantID, private)
+	if merchantResult.Error != nil {
+		return merchantResult.Error
+	}
+	merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+	if merchant.BankID.ValueOrZero() != change.OldBankID ||
+		merchant.BankBranch.String != change.OldBankBranch ||
+		merchant.AccountNumber.String != change.OldAccountNumber ||
+		merchant.AccountHolderName.String != change.OldAccountHolderName {
+		change.Status = model.BankChangeRejected
+		change.Reason = errBankChangeStale.Error()
+		updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, change)
+		return updateResult.Error
+	}
+
+	after := merchant
+	after.BankID = zero.IntFrom(change.NewBankID)
+	after.BankName = zero.StringFrom(change.NewBankName)
+	after.BankBranch = zero.StringFrom(change.NewBankBranch)
+	after.AccountNumber = zero.StringFrom(change.NewAccountNumber)
+	after.AccountHolderName = zero.StringFrom(change.NewAccountHolderName)
+	after.EditorID = null.StringFrom(model.SystemActor)
+	after.LastModified = null.TimeFrom(now)
+	after.Version = zero.IntFrom(after.Version.ValueOrZero() + 1)
+
+	m.Repository.StartTransaction()
+	updateResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, after)
+	if updateResult.Error != nil {
+		m.Repository.Rollback()
+		return updateResult.Error
+	}
+
+	change.Status = model.BankChangeApplied
+	change.Applied = null.TimeFrom(now)
+	if changeResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, change); changeResult.Error != nil {
+		m.Repository.Rollback()
+		return changeResult.Error
+	}
+	m.Repository.Commit()
+
+	plLog := model.MerchantLog{
+		Before: merchant,
+		After:  after,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plLog, merchant.ID, "InsertLogMerchantUpdate")
+	go func() {
+		m.PublishToKafkaMerchant(ctxReq, after, helper.EventProduceUpdateMerchant)
+	}()
+	m.requestBankVerification(ctxReq, merchant, after, change.RequesterID)
+	return nil
+}
+
+// holdMerchantBank keeps the bank account of a merchant the owner changes, the new account becomes a change request
+// applied after the owner confirmation, the admin approval and the cooling-off period. The first bank account is not held.
+func holdMerchantBank(oldData model.B2CMerchantDataV2, currentData *model.B2CMerchantDataV2, requesterID string, now time.Time) *model.MerchantBankChange {
+	if !oldData.HasBankAccount() || !currentData.IsBankChanged(oldData) {
+		return nil
+	}
+
+	change := &model.MerchantBankChange{
+		MerchantID:           oldData.ID,
+		RequesterID:          requesterID,
+		OldBankID:            oldData.BankID.ValueOrZero(),
+		OldBankName:          oldData.BankName.String,
+		OldBankBranch:        oldData.BankBranch.String,
+		OldAccountNumber:     oldData.AccountNumber.String,
+		OldAccountHolderName: oldData.AccountHolderName.String,
+		NewBankID:            currentData.BankID.ValueOrZero(),
+		NewBankName:          currentData.BankName.String,
+		NewBankBranch:        currentData.BankBranch.String,
+		NewAccountNumber:     currentData.AccountNumber.String,
+		NewAccountHolderName: currentData.AccountHolderName.String,
+		NotifyEmail:          oldData.MerchantEmail.String,
+		Status:               model.BankChangeAwaitingMFA,
+		EffectiveAt:          now.Add(bankChangeCoolingOff()),
+		Created:              now,
+	}
+
+	currentData.BankID = oldData.BankID
+	currentData.BankCode = oldData.BankCode
+	currentData.BankName = oldData.BankName
+	currentData.BankBranch = oldData.BankBranch
+	currentData.AccountNumber = oldData.AccountNumber
+	currentData.AccountHolderName = oldData.AccountHolderName
+	return change
+}
+
+// saveBankChange records a held bank account change within the running transaction,
+// the open changes of the merchant are cancelled as only the latest one counts
+func (m *MerchantUseCaseImpl) saveBankChange(ctxReq context.Context, change *model.MerchantBankChange) error {
+	openResult := <-m.BankChangeRepo.GetByMerchantID(ctxReq, change.MerchantID, openBankChangeStatuses)
+	if openResult.Error != nil {
+		return openResult.Error
+	}
+	openChanges, _ := openResult.Result.([]model.MerchantBankChange)
+	for i := range openChanges {
+		openChanges[i].Status = model.BankChangeCancelled
+		openChanges[i].Reason = "replaced by a newer bank account change"
+		if updateResult := <-m.BankChangeRepo.UpdateStatus(ctxReq, &openChanges[i]); updateResult.Error != nil {
+			return updateResult.Error
+		}
+	}
+
+	saveResult := <-m.BankChangeRepo.Save(ctxReq, change)
+	return saveResult.Error
+}
+
+// notifyBankChange warns the merchant email known before the change, so the owner learns of a change made by someone else
+func (m *MerchantUseCaseImpl) notifyBankChange(ctxReq context.Context, merchant model.B2CMerchantDataV2, change *model.MerchantBankChange) {
+	plQueue := model.MerchantPayloadEmail{
+		MemberName: merchant.MerchantName,
+		Data:       merchant,
+		BankChange: change,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, merchant.ID, "SendEmailMerchantBankChange")
+}
+
+// verifyOwnerMFA checks the second factor of the owner for a sensitive change of the merchant, totp unless
+// an enrolled email or sms method is chosen. A code sent by email or sms only confirms the change of its scope
+func (m *MerchantUseCaseImpl) verifyOwnerMFA(ctxReq context.Context, memberID, scope, method, otp string) (int, error) {
+	if otp == "" {
+		return http.StatusBadRequest, fmt.Errorf(helper.ErrorParameterRequired, "otp")
+	}
+
+	if method == "" {
+		method = memberModel.MFAMethodTOTP
+	}
+
+	if memberModel.IsMFAOTPMethod(method) {
+		if m.MFAOTP == nil {
+			return http.StatusBadRequest, errors.New(memberModel.ErrorMFAMethod)
+		}
+		if _, err := m.findMFAMethod(ctxReq, memberID, method); err != nil {
+			return http.StatusBadRequest, err
+		}
+		if err := m.MFAOTP.Verify(ctxReq, method, memberID, scope, otp); err != nil {
+			return mfaotp.HTTPStatus(err), err
+		}
+		return http.StatusOK, nil
+	}
+	if method != memberModel.MFAMethodTOTP {
+		return http.StatusBadRequest, errors.New(memberModel.ErrorMFAMethod)
+	}
+
+	memberResult := <-m.MemberQueryRead.FindByID(ctxReq, memberID)
+	if memberResult.Error != nil {
+		return http.StatusInternalServerError, memberResult.Error
+	}
+	member, _ := memberResult.Result.(memberModel.Member)
+	if !member.MFAEnabled || member.MFAKey == "" {
+		return http.StatusForbidden, errOwnerMFARequired
+	}
+
+	// only for exclude prod & key static
+	if os.Getenv("ENV") != "PROD" && member.MFAKey == memberModel.StaticSharedMfaKeyForDev && otp == memberModel.StaticOTPMfaForDev {
+		return http.StatusOK, nil
+	}
+	otpc := &dgoogauth.OTPConfig{
+		Secret:      member.MFAKey,
+		WindowSize:  3,
+		HotpCounter: 0,
+		UTC:         true,
+	}
+	valid, err := otpc.Authenticate(otp)
+	if err != nil || !valid {
+		return http.StatusBadRequest, errors.New(memberModel.ErrorMFAOTP)
+	}
+	return http.StatusOK, nil
+}
+
+// verifyBankChangeAccount runs the name inquiry on the new bank account of a change. The result is kept on the change,
+// merchant_bank_verification only holds checks of the account the merchant holds
+func (m *MerchantUseCaseImpl) verifyBankChangeAccount(ctxReq context.Context, change *model.MerchantBankChange) {
+	merchantResult := m.MerchantRepo.LoadMerchant(ctxReq, change.MerchantID, private)
+	merchant, ok := merchantResult.Result.(model.B2CMerchantDataV2)
+	if merchantResult.Error != nil || !ok {
+		change.VerificationStatus = model.BankVerificationFailed
+		change.VerificationReason = errMerchantNotFound.Error()
+		return
+	}
+
+	bankResult := <-m.MerchantBankRepo.FindActiveMerchantBankByID(ctxReq, int(change.NewBankID))
+	bank, ok := bankResult.Result.(model.B2CMerchantBankData)
+	if bankResult.Error != nil || !ok {
+		change.VerificationStatus = model.BankVerificationInvalidAccount
+		change.VerificationReason = errBankNotExist.Error()
+		return
+	}
+
+	verification := model.MerchantBankVerification{
+		MerchantID:        change.MerchantID,
+		BankID:            change.NewBankID,
+		BankCode:          bank.BankCode,
+		AccountNumber:     change.NewAccountNumber,
+		AccountHolderName: change.NewAccountHolderName,
+	}
+	m.inquireBankAccount(ctxReq, merchant, &verification)
+	change.VerificationStatus = verification.Status
+	change.VerificationReason = verification.Reason
+}
+
+// findMFAMethod returns the email or sms method the member enrolled
+func (m *MerchantUseCaseImpl) findMFAMethod(ctxReq context.Context, memberID, method string) (memberModel.MFAMethod, error) {
+	methodResult := <-m.MemberMFAMethodRepo.FindByMemberID(ctxReq, memberID)
+	methods, _ := methodResult.Result.([]memberModel.MFAMethod)
+	for _, enrolled := range methods {
+		if enrolled.Method == method {
+			return enrolled, nil
+		}
+	}
+	return memberModel.MFAMethod{}, errors.New(memberModel.ErrorMFAMethodNotEnrolled)
+}
+
+// findOwnBankChange loads a bank account change of the merchant owned by the user
+func (m *MerchantUseCaseImpl) findOwnBankChange(ctxReq context.Context, changeID int64, userAttribute *model.MerchantUserAttribute) (model.MerchantBankChange, error) {
+	merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+	if merchantResult.Error != nil {
+		return model.MerchantBankChange{}, errMerchantNotFound
+	}
+	merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+	return m.findBankChange(ctxReq, merchant.ID, changeID)
+}
+
+func (m *MerchantUseCaseImpl) findBankChange(ctxReq context.Context, merchantID string, changeID int64) (model.MerchantBankChange, error) {
+	changeResult := <-m.BankChangeRepo.FindByID(ctxReq, merchantID, changeID)
+	if changeResult.Error != nil {
+		if changeResult.Error == sql.ErrNoRows {
+			return model.MerchantBankChange{}, errBankChangeNotFound
+		}
+		return model.MerchantBankChange{}, changeResult.Error
+	}
+	return changeResult.Result.(model.MerchantBankChange), nil
+}
+
+func bankChangeHTTPStatus(err error) int {
+	switch err {
+	case errMerchantNotFound, errBankChangeNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// bankChangeOTPScope scope of the one time code confirming a bank account change, a code confirms one change only
+func bankChangeOTPScope(changeID int64) string {
+	return fmt.Sprintf("merchant-bank-%d", changeID)
+}
+
+// isBankChangeMFAExpired checks the owner did not confirm the change in time
+func isBankChangeMFAExpired(change model.MerchantBankChange, now time.Time) bool {
+	return !change.Created.IsZero() && change.Created.Before(now.Add(-bankChangeMFATTL()))
+}
+
+// isBankChangeVerificationFinal checks the name inquiry of the new account got an answer asking again would not change
+func isBankChangeVerificationFinal(status string) bool {
+	switch status {
+	case model.BankVerificationVerified, model.BankVerificationNameMismatch, model.BankVerificationInvalidAccount:
+		return true
+	}
+	return false
+}
+
+// bankChangeMFATTL returns MERCHANT_BANK_CHANGE_MFA_TTL, e.g. 24h, or the default when it is not set
+func bankChangeMFATTL() time.Duration {
+	if value, err := time.ParseDuration(os.Getenv("MERCHANT_BANK_CHANGE_MFA_TTL")); err == nil && value > 0 {
+		return value
+	}
+	return defaultBankChangeMFATTL
+}
+
+// bankChangeCoolingOff returns MERCHANT_BANK_CHANGE_COOLING_OFF, e.g. 72h, or the default when it is not set
+func bankChangeCoolingOff() time.Duration {
+	if value, err := time.ParseDuration(os.Getenv("MERCHANT_BANK_CHANGE_COOLING_OFF")); err == nil && value >= 0 {
+		return value
+	}
+	return defaultBankChangeCoolingOff
+}
@@ -0,0 +1,614 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	localConfig "github.com/Bhinneka/user-service/config"
+	mockToken "github.com/Bhinneka/user-service/src/auth/v1/token/mocks"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
+	mockMemberQuery "github.com/Bhinneka/user-service/src/member/v1/query/mocks"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
+	mockMerchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo/mocks"
+	serviceMock "github.com/Bhinneka/user-service/src/service/mocks"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+	sharedMock "github.com/Bhinneka/user-service/src/shared/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	sqlMock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+const defOldAccountNumber = "1234567890"
+
+func bankChangeMerchant() model.B2CMerchantDataV2 {
+	return model.B2CMerchantDataV2{
+		ID:                defaultMerchantID,
+		MerchantEmail:     zero.StringFrom(defEmail),
+		BankID:            zero.IntFrom(1),
+		BankName:          zero.StringFrom("BCA"),
+		AccountNumber:     zero.StringFrom(defOldAccountNumber),
+		AccountHolderName: zero.StringFrom(defName),
+		Pic:               zero.StringFrom(defName),
+	}
+}
+
+func pendingBankChange(status string) model.MerchantBankChange {
+	return model.MerchantBankChange{
+		ID:                   1,
+		MerchantID:           defaultMerchantID,
+		RequesterID:          "USR002",
+		OldBankID:            1,
+		OldBankName:          "BCA",
+		OldAccountNumber:     defOldAccountNumber,
+		OldAccountHolderName: defName,
+		NewBankID:            2,
+		NewBankName:          "MANDIRI",
+		NewAccountNumber:     defPhoneNumber,
+		NewAccountHolderName: defName,
+		NotifyEmail:          defEmail,
+		Status:               status,
+		EffectiveAt:          time.Now().Add(defaultBankChangeCoolingOff),
+		VerificationStatus:   model.BankVerificationVerified,
+		Created:              time.Now(),
+	}
+}
+
+// newBankAccountInquiry name inquiry of the new account of pendingBankChange
+func newBankAccountInquiry() serviceModel.BankAccountInquiry {
+	return serviceModel.BankAccountInquiry{BankCode: "MANDIRI", AccountNumber: defPhoneNumber, AccountHolderName: defName}
+}
+
+func updateBankChange(ctxReq context.Context, change *model.MerchantBankChange) <-chan merchantRepo.ResultRepository {
+	return generateRepoResult(merchantRepo.ResultRepository{Result: *change})
+}
+
+// assertBankChangeHeld checks a self update kept the old bank account and recorded the new one as a change request
+func assertBankChangeHeld(t *testing.T, ucResult ResultUseCase, repoMock *mockMerchantRepo.MerchantBankChangeRepository) {
+	merchant, _ := ucResult.Result.(model.B2CMerchantDataV2)
+	assert.Equal(t, defOldAccountNumber, merchant.AccountNumber.String)
+
+	change := merchant.PendingBankChange
+	if assert.NotNil(t, change) {
+		assert.Equal(t, model.BankChangeAwaitingMFA, change.Status)
+		assert.Equal(t, defOldAccountNumber, change.OldAccountNumber)
+		assert.Equal(t, defPhoneNumber, change.NewAccountNumber)
+		assert.Equal(t, defEmail, change.NotifyEmail)
+	}
+	repoMock.AssertCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestHoldMerchantBank(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		oldData  model.B2CMerchantDataV2
+		newData  func(model.B2CMerchantDataV2) model.B2CMerchantDataV2
+		wantHeld bool
+	}{
+		{
+			name:    "Case 1: first bank account",
+			oldData: model.B2CMerchantDataV2{ID: defaultMerchantID},
+			newData: func(model.B2CMerchantDataV2) model.B2CMerchantDataV2 {
+				return bankChangeMerchant()
+			},
+		},
+		{
+			name:    "Case 2: bank account unchanged",
+			oldData: bankChangeMerchant(),
+			newData: func(data model.B2CMerchantDataV2) model.B2CMerchantDataV2 {
+				data.MerchantName = defName
+				return data
+			},
+		},
+		{
+			name:    "Case 3: account number changed",
+			oldData: bankChangeMerchant(),
+			newData: func(data model.B2CMerchantDataV2) model.B2CMerchantDataV2 {
+				data.AccountNumber = zero.StringFrom(defPhoneNumber)
+				return data
+			},
+			wantHeld: true,
+		},
+		{
+			name:    "Case 4: account holder changed",
+			oldData: bankChangeMerchant(),
+			newData: func(data model.B2CMerchantDataV2) model.B2CMerchantDataV2 {
+				data.AccountHolderName = zero.StringFrom("other name")
+				return data
+			},
+			wantHeld: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentData := tt.newData(tt.oldData)
+			wantData := currentData
+			change := holdMerchantBank(tt.oldData, &currentData, defUserAttr.UserID, now)
+			if !tt.wantHeld {
+				assert.Nil(t, change)
+				assert.Equal(t, wantData, currentData)
+				return
+			}
+
+			if assert.NotNil(t, change) {
+				assert.Equal(t, model.BankChangeAwaitingMFA, change.Status)
+				assert.Equal(t, defUserAttr.UserID, change.RequesterID)
+				assert.Equal(t, wantData.AccountNumber.String, change.NewAccountNumber)
+				assert.Equal(t, wantData.AccountHolderName.String, change.NewAccountHolderName)
+				assert.Equal(t, now.Add(defaultBankChangeCoolingOff), change.EffectiveAt)
+			}
+			assert.False(t, currentData.IsBankChanged(tt.oldData))
+		})
+	}
+}
+
+func TestBankChangeCoolingOff(t *testing.T) {
+	defer os.Unsetenv("MERCHANT_BANK_CHANGE_COOLING_OFF")
+
+	os.Setenv("MERCHANT_BANK_CHANGE_COOLING_OFF", "24h")
+	assert.Equal(t, 24*time.Hour, bankChangeCoolingOff())
+
+	os.Setenv("MERCHANT_BANK_CHANGE_COOLING_OFF", "three days")
+	assert.Equal(t, defaultBankChangeCoolingOff, bankChangeCoolingOff())
+}
+
+func TestConfirmMerchantBankChange(t *testing.T) {
+	unverifiedChange := pendingBankChange(model.BankChangeAwaitingMFA)
+	unverifiedChange.VerificationStatus = ""
+	expiredChange := unverifiedChange
+	expiredChange.Created = time.Now().Add(-defaultBankChangeMFATTL - time.Minute)
+
+	tests := []struct {
+		name             string
+		change           merchantRepo.ResultRepository
+		member           memberModel.Member
+		input            model.MerchantBankChangeConfirmInput
+		inquiryResult    serviceModel.BankAccountInquiryResult
+		wantVerification string
+		wantErr          bool
+		wantHTTPStatus   int
+	}{
+		{
+			name:             "Case 1: confirmed with totp",
+			change:           merchantRepo.ResultRepository{Result: unverifiedChange},
+			member:           memberModel.Member{MFAEnabled: true, MFAKey: memberModel.StaticSharedMfaKeyForDev},
+			input:            model.MerchantBankChangeConfirmInput{OTP: memberModel.StaticOTPMfaForDev},
+			inquiryResult:    serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquirySuccess, AccountHolderName: defName},
+			wantVerification: model.BankVerificationVerified,
+		},
+		{
+			name:             "Case 8: confirmed with a new account of someone else",
+			change:           merchantRepo.ResultRepository{Result: unverifiedChange},
+			member:           memberModel.Member{MFAEnabled: true, MFAKey: memberModel.StaticSharedMfaKeyForDev},
+			input:            model.MerchantBankChangeConfirmInput{OTP: memberModel.StaticOTPMfaForDev},
+			inquiryResult:    serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquirySuccess, AccountHolderName: "Someone Else Entirely"},
+			wantVerification: model.BankVerificationNameMismatch,
+		},
+		{
+			name:           "Case 9: not confirmed in time",
+			change:         merchantRepo.ResultRepository{Result: expiredChange},
+			member:         memberModel.Member{MFAEnabled: true, MFAKey: memberModel.StaticSharedMfaKeyForDev},
+			input:          model.MerchantBankChangeConfirmInput{OTP: memberModel.StaticOTPMfaForDev},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+		{
+			name:           "Case 2: owner without mfa",
+			change:         merchantRepo.ResultRepository{Result: pendingBankChange(model.BankChangeAwaitingMFA)},
+			input:          model.MerchantBankChangeConfirmInput{OTP: memberModel.StaticOTPMfaForDev},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Case 3: wrong otp",
+			change:         merchantRepo.ResultRepository{Result: pendingBankChange(model.BankChangeAwaitingMFA)},
+			member:         memberModel.Member{MFAEnabled: true, MFAKey: memberModel.StaticSharedMfaKeyForDev},
+			input:          model.MerchantBankChangeConfirmInput{OTP: "123456"},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 4: missing otp",
+			change:         merchantRepo.ResultRepository{Result: pendingBankChange(model.BankChangeAwaitingMFA)},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 5: unknown method",
+			change:         merchantRepo.ResultRepository{Result: pendingBankChange(model.BankChangeAwaitingMFA)},
+			input:          model.MerchantBankChangeConfirmInput{Method: "fax", OTP: memberModel.StaticOTPMfaForDev},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 6: already confirmed",
+			change:         merchantRepo.ResultRepository{Result: pendingBankChange(model.BankChangePendingApproval)},
+			input:          model.MerchantBankChangeConfirmInput{OTP: memberModel.StaticOTPMfaForDev},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+		{
+			name:           "Case 7: change not found",
+			change:         merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			input:          model.MerchantBankChangeConfirmInput{OTP: memberModel.StaticOTPMfaForDev},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantBankRepoMock := mockMerchantRepo.MerchantBankRepository{}
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
+			memberQueryMock := mockMemberQuery.MemberQuery{}
+			bankVerifier := serviceMock.BankAccountVerifier{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantRepository:           &merchantRepoMock,
+				MerchantBankRepository:       &merchantBankRepoMock,
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+			}
+			svcShared := localConfig.ServiceShared{
+				BankVerifier: &bankVerifier,
+			}
+			localQuery := localConfig.ServiceQuery{
+				MemberQueryRead: &memberQueryMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, svcShared, &tokenGen, localQuery)
+
+			merchantRepoMock.On("FindMerchantByUser", mock.Anything, defUserAttr.UserID).Return(merchantRepo.ResultRepository{Result: bankChangeMerchant()})
+			merchantRepoMock.On("LoadMerchant", mock.Anything, defaultMerchantID, mock.Anything).Return(merchantRepo.ResultRepository{Result: bankChangeMerchant()})
+			merchantBankRepoMock.On("FindActiveMerchantBankByID", mock.Anything, 2).Return(generateRepoResult(merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 2, BankCode: "MANDIRI"}}))
+			bankVerifier.On("InquireAccount", mock.Anything, newBankAccountInquiry()).Return(tt.inquiryResult, nil)
+			bankChangeRepoMock.On("FindByID", mock.Anything, defaultMerchantID, int64(1)).Return(generateRepoResult(tt.change))
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(updateBankChange)
+			memberQueryMock.On("FindByID", mock.Anything, defUserAttr.UserID).Return(sharedMock.MemberQueryResult(memberQuery.ResultQuery{Result: tt.member}))
+
+			ucResult := <-m.ConfirmMerchantBankChange(context.Background(), 1, tt.input, defUserAttr)
+			if tt.wantErr {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				bankChangeRepoMock.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			change := ucResult.Result.(model.MerchantBankChange)
+			assert.Equal(t, model.BankChangePendingApproval, change.Status)
+			assert.True(t, change.MFAVerified.Valid)
+			assert.Equal(t, tt.wantVerification, change.VerificationStatus)
+		})
+	}
+}
+
+func TestCancelMerchantBankChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         string
+		wantErr        bool
+		wantHTTPStatus int
+	}{
+		{
+			name:   "Case 1: cancel change waiting for approval",
+			status: model.BankChangePendingApproval,
+		},
+		{
+			name:           "Case 2: cancel applied change",
+			status:         model.BankChangeApplied,
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantRepository:           &merchantRepoMock,
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &tokenGen, localConfig.ServiceQuery{})
+
+			merchantRepoMock.On("FindMerchantByUser", mock.Anything, defUserAttr.UserID).Return(merchantRepo.ResultRepository{Result: bankChangeMerchant()})
+			bankChangeRepoMock.On("FindByID", mock.Anything, defaultMerchantID, int64(1)).Return(generateRepoResult(merchantRepo.ResultRepository{Result: pendingBankChange(tt.status)}))
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(updateBankChange)
+
+			ucResult := <-m.CancelMerchantBankChange(context.Background(), 1, defUserAttr)
+			if tt.wantErr {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			assert.Equal(t, model.BankChangeCancelled, ucResult.Result.(model.MerchantBankChange).Status)
+		})
+	}
+}
+
+func TestApproveMerchantBankChange(t *testing.T) {
+	lateChange := pendingBankChange(model.BankChangePendingApproval)
+	lateChange.Created = time.Now().Add(-2 * defaultBankChangeCoolingOff)
+	lateChange.EffectiveAt = time.Now().Add(-defaultBankChangeCoolingOff)
+	mismatchChange := pendingBankChange(model.BankChangePendingApproval)
+	mismatchChange.VerificationStatus = model.BankVerificationNameMismatch
+	pendingInquiryChange := pendingBankChange(model.BankChangePendingApproval)
+	pendingInquiryChange.VerificationStatus = model.BankVerificationPending
+
+	tests := []struct {
+		name           string
+		change         model.MerchantBankChange
+		reviewer       *model.MerchantUserAttribute
+		reason         string
+		inquiryResult  serviceModel.BankAccountInquiryResult
+		wantStatus     string
+		wantOverride   string
+		wantErr        bool
+		wantHTTPStatus int
+	}{
+		{
+			name:       "Case 1: approve within cooling-off period",
+			change:     pendingBankChange(model.BankChangePendingApproval),
+			reviewer:   defUserAttr,
+			wantStatus: model.BankChangeApproved,
+		},
+		{
+			name:       "Case 2: approve after the cooling-off period of the request",
+			change:     lateChange,
+			reviewer:   defUserAttr,
+			wantStatus: model.BankChangeApproved,
+		},
+		{
+			name:           "Case 3: requester approves own change",
+			change:         pendingBankChange(model.BankChangePendingApproval),
+			reviewer:       &model.MerchantUserAttribute{UserID: "USR002"},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Case 4: change not confirmed by the owner",
+			change:         pendingBankChange(model.BankChangeAwaitingMFA),
+			reviewer:       defUserAttr,
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+		{
+			name:           "Case 5: new account of someone else without reason",
+			change:         mismatchChange,
+			reviewer:       defUserAttr,
+			reason:         " ",
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+		{
+			name:         "Case 6: new account of someone else with reason",
+			change:       mismatchChange,
+			reviewer:     defUserAttr,
+			reason:       "company account under the name of the director",
+			wantStatus:   model.BankChangeApproved,
+			wantOverride: "company account under the name of the director",
+		},
+		{
+			name:          "Case 7: pending inquiry answered at the approval",
+			change:        pendingInquiryChange,
+			reviewer:      defUserAttr,
+			inquiryResult: serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquirySuccess, AccountHolderName: defName},
+			wantStatus:    model.BankChangeApproved,
+		},
+		{
+			name:           "Case 8: pending inquiry still pending without reason",
+			change:         pendingInquiryChange,
+			reviewer:       defUserAttr,
+			inquiryResult:  serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquiryPending},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantBankRepoMock := mockMerchantRepo.MerchantBankRepository{}
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
+			mockDB, _, _ := sqlMock.New()
+			defer mockDB.Close()
+
+			svcRepo := localConfig.ServiceRepository{
+				Repository:                   &repository.Repository{WriteDB: mockDB},
+				MerchantRepository:           &merchantRepoMock,
+				MerchantBankRepository:       &merchantBankRepoMock,
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+			}
+			publisher := serviceMock.QPublisher{}
+			merchantService := serviceMock.MerchantServices{}
+			bankVerifier := serviceMock.BankAccountVerifier{}
+			svcShared := localConfig.ServiceShared{
+				QPublisher:      &publisher,
+				MerchantService: &merchantService,
+				BankVerifier:    &bankVerifier,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, svcShared, &tokenGen, localConfig.ServiceQuery{})
+
+			bankChangeRepoMock.On("FindByID", mock.Anything, defaultMerchantID, int64(1)).Return(generateRepoResult(merchantRepo.ResultRepository{Result: tt.change}))
+			merchantBankRepoMock.On("FindActiveMerchantBankByID", mock.Anything, 2).Return(generateRepoResult(merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 2, BankCode: "MANDIRI"}}))
+			bankVerifier.On("InquireAccount", mock.Anything, newBankAccountInquiry()).Return(tt.inquiryResult, nil)
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(updateBankChange)
+			merchantRepoMock.On("LoadMerchant", mock.Anything, defaultMerchantID, mock.Anything).Return(merchantRepo.ResultRepository{Result: bankChangeMerchant()})
+			merchantRepoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(nil)
+			merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			approved := time.Now()
+			ucResult := <-m.ApproveMerchantBankChange(context.Background(), defaultMerchantID, 1, tt.reason, tt.reviewer)
+			if tt.wantErr {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				bankChangeRepoMock.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			change := ucResult.Result.(model.MerchantBankChange)
+			assert.Equal(t, tt.wantStatus, change.Status)
+			assert.Equal(t, tt.reviewer.UserID, change.ReviewerID)
+			assert.Equal(t, tt.wantOverride, change.OverrideReason)
+			assert.False(t, change.EffectiveAt.Before(approved.Add(defaultBankChangeCoolingOff)))
+			merchantRepoMock.AssertNotCalled(t, "AddUpdateMerchant", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRejectMerchantBankChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         string
+		reason         string
+		wantErr        bool
+		wantHTTPStatus int
+	}{
+		{
+			name:   "Case 1: reject change waiting for approval",
+			status: model.BankChangePendingApproval,
+			reason: "account holder does not match the company",
+		},
+		{
+			name:           "Case 2: reject without reason",
+			status:         model.BankChangePendingApproval,
+			reason:         " ",
+			wantErr:        true,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 3: reject cancelled change",
+			status:         model.BankChangeCancelled,
+			reason:         "account holder does not match the company",
+			wantErr:        true,
+			wantHTTPStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &tokenGen, localConfig.ServiceQuery{})
+
+			bankChangeRepoMock.On("FindByID", mock.Anything, defaultMerchantID, int64(1)).Return(generateRepoResult(merchantRepo.ResultRepository{Result: pendingBankChange(tt.status)}))
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(updateBankChange)
+
+			ucResult := <-m.RejectMerchantBankChange(context.Background(), defaultMerchantID, 1, tt.reason, defUserAttr)
+			if tt.wantErr {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			change := ucResult.Result.(model.MerchantBankChange)
+			assert.Equal(t, model.BankChangeRejected, change.Status)
+			assert.Equal(t, tt.reason, change.Reason)
+		})
+	}
+}
+
+func TestProcessBankChanges(t *testing.T) {
+	now := time.Now()
+	dueChange := pendingBankChange(model.BankChangeApproved)
+	dueChange.EffectiveAt = now.Add(-time.Hour)
+	staleChange := dueChange
+	staleChange.ID = 2
+	staleChange.MerchantID = "MCH002"
+
+	staleMerchant := bankChangeMerchant()
+	staleMerchant.ID = staleChange.MerchantID
+	staleMerchant.AccountNumber = zero.StringFrom("5555555555")
+
+	unverifiedChange := dueChange
+	unverifiedChange.ID = 3
+	unverifiedChange.VerificationStatus = model.BankVerificationNameMismatch
+	overriddenChange := unverifiedChange
+	overriddenChange.OverrideReason = "company account under the name of the director"
+
+	tests := []struct {
+		name        string
+		dueResult   merchantRepo.ResultRepository
+		wantErr     bool
+		wantSummary model.MerchantBankChangeResult
+	}{
+		{
+			name:        "Case 1: apply due change and reject stale change",
+			dueResult:   merchantRepo.ResultRepository{Result: []model.MerchantBankChange{dueChange, staleChange}},
+			wantSummary: model.MerchantBankChangeResult{Applied: 1, Rejected: 1, Expired: 2},
+		},
+		{
+			name:      "Case 2: failed load due changes",
+			dueResult: merchantRepo.ResultRepository{Error: errDefault},
+			wantErr:   true,
+		},
+		{
+			name:        "Case 3: reject unverified change and apply overridden change",
+			dueResult:   merchantRepo.ResultRepository{Result: []model.MerchantBankChange{unverifiedChange, overriddenChange}},
+			wantSummary: model.MerchantBankChangeResult{Applied: 1, Rejected: 1, Expired: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
+			mockDB, _, _ := sqlMock.New()
+			defer mockDB.Close()
+
+			svcRepo := localConfig.ServiceRepository{
+				Repository:                   &repository.Repository{WriteDB: mockDB},
+				MerchantRepository:           &merchantRepoMock,
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+			}
+			publisher := serviceMock.QPublisher{}
+			merchantService := serviceMock.MerchantServices{}
+			svcShared := localConfig.ServiceShared{
+				QPublisher:      &publisher,
+				MerchantService: &merchantService,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, svcShared, &tokenGen, localConfig.ServiceQuery{})
+
+			bankChangeRepoMock.On("ExpireAwaitingMFA", mock.Anything, now.Add(-defaultBankChangeMFATTL), errBankChangeExpired.Error()).Return(generateRepoResult(merchantRepo.ResultRepository{Result: int64(2)}))
+			bankChangeRepoMock.On("GetDueChanges", mock.Anything, now).Return(generateRepoResult(tt.dueResult))
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(updateBankChange)
+			merchantRepoMock.On("LoadMerchant", mock.Anything, defaultMerchantID, mock.Anything).Return(merchantRepo.ResultRepository{Result: bankChangeMerchant()})
+			merchantRepoMock.On("LoadMerchant", mock.Anything, staleMerchant.ID, mock.Anything).Return(merchantRepo.ResultRepository{Result: staleMerchant})
+			merchantRepoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(nil)
+			merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			ucResult := <-m.ProcessBankChanges(context.Background(), now)
+			if tt.wantErr {
+				assert.Error(t, ucResult.Error)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			assert.Equal(t, tt.wantSummary, ucResult.Result)
+			merchantRepoMock.AssertNumberOfCalls(t, "AddUpdateMerchant", 1)
+		})
+	}
+}
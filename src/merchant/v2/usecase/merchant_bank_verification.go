@@ -0,0 +1,370 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+)
+
+// bankHolderNameThreshold lowest similarity of the account holder with the legal name or PIC to verify the account
+const bankHolderNameThreshold = 0.8
+
+// holderNameTitles legal forms and honorifics left out of the name comparison
+var holderNameTitles = map[string]bool{
+	"PT": true, "CV": true, "TBK": true, "UD": true, "PD": true, "KOPERASI": true, "YAYASAN": true,
+	"BPK": true, "BAPAK": true, "IBU": true, "SDR": true, "SDRI": true, "TN": true, "NY": true, "NN": true,
+	"MR": true, "MRS": true, "MS": true, "H": true, "HJ": true, "DR": true, "DRS": true, "IR": true,
+}
+
+// VerifyMerchantBank function to check the bank account of a merchant with the bank verifier
+func (m *MerchantUseCaseImpl) VerifyMerchantBank(ctxReq context.Context, merchantID string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-VerifyMerchantBank"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		merchant, verification, err := m.newBankVerification(ctxReq, merchantID, userAttribute)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankVerificationHTTPStatus(err)}
+			return
+		}
+
+		m.inquireBankAccount(ctxReq, merchant, &verification)
+		tags["status"] = verification.Status
+
+		saveResult := <-m.BankVerificationRepo.Save(ctxReq, verification)
+		if saveResult.Error != nil {
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: saveResult.Result}
+	})
+	return output
+}
+
+// ApproveMerchantBank function for an admin to verify the bank account of a merchant by hand,
+// e.g. a company account under the name of its director
+func (m *MerchantUseCaseImpl) ApproveMerchantBank(ctxReq context.Context, merchantID, reason string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ApproveMerchantBank"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		reason = strings.TrimSpace(reason)
+		if reason == "" {
+			output <- ResultUseCase{Error: errBankApprovalReason, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		_, verification, err := m.newBankVerification(ctxReq, merchantID, userAttribute)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: bankVerificationHTTPStatus(err)}
+			return
+		}
+		verification.Status = model.BankVerificationVerified
+		verification.Reason = reason
+
+		saveResult := <-m.BankVerificationRepo.Save(ctxReq, verification)
+		if saveResult.Error != nil {
+			output <- ResultUseCase{Error: saveResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: saveResult.Result}
+	})
+	return output
+}
+
+// GetMerchantBankVerification function for loading the last bank account check of a merchant
+func (m *MerchantUseCaseImpl) GetMerchantBankVerification(ctxReq context.Context, merchantID string) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-GetMerchantBankVerification"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		verificationResult := <-m.BankVerificationRepo.FindLatestByMerchantID(ctxReq, merchantID)
+		if verificationResult.Error != nil {
+			if verificationResult.Error == sql.ErrNoRows {
+				output <- ResultUseCase{Error: errBankVerificationNotFound, HTTPStatus: http.StatusNotFound}
+				return
+			}
+			output <- ResultUseCase{Error: verificationResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: verificationResult.Result}
+	})
+	return output
+}
+
+// PollPendingBankVerifications function for asking the provider again about the bank account checks it left pending,
+// a check is queued the same way as after a bank account change and stays pending until the provider answers
+func (m *MerchantUseCaseImpl) PollPendingBankVerifications(ctxReq context.Context, before time.Time) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-PollPendingBankVerifications"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["before"] = before
+
+		pendingResult := <-m.BankVerificationRepo.GetPending(ctxReq, before)
+		if pendingResult.Error != nil {
+			output <- ResultUseCase{Error: pendingResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		verifications, _ := pendingResult.Result.([]model.MerchantBankVerification)
+
+		summary := model.MerchantBankVerificationPollResult{}
+		for _, verification := range verifications {
+			payload := model.MerchantBankVerificationRequest{
+				MerchantID: verification.MerchantID,
+				ActorID:    verification.ActorID,
+			}
+			if err := m.QueuePublisher.QueueJob(ctxReq, payload, verification.MerchantID, "VerifyMerchantBank"); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "queue_bank_verification", err, verification.MerchantID)
+				summary.Failed++
+				continue
+			}
+			summary.Queued++
+		}
+		tags[helper.TextResponse] = summary
+
+		output <- ResultUseCase{Result: summary}
+	})
+	return output
+}
+
+// newBankVerification loads the merchant and prepares a check of its current bank account
+func (m *MerchantUseCaseImpl) newBankVerification(ctxReq context.Context, merchantID string, userAttribute *model.MerchantUserAttribute) (model.B2CMerchantDataV2, model.MerchantBankVerification, error) {
+	merchantResult := m.MerchantRepo.LoadMerchant(ctxReq, merchantID, private)
+	if merchantResult.Error != nil {
+		if merchantResult.Error == sql.ErrNoRows {
+			return model.B2CMerchantDataV2{}, model.MerchantBankVerification{}, errMerchantNotFound
+		}
+		return model.B2CMerchantDataV2{}, model.MerchantBankVerification{}, merchantResult.Error
+	}
+	merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+	if merchant.BankID.ValueOrZero() == 0 || merchant.AccountNumber.String == "" || merchant.AccountHolderName.String == "" {
+		return merchant, model.MerchantBankVerification{}, errMerchantBankRequired
+	}
+
+	bankResult := <-m.MerchantBankRepo.FindActiveMerchantBankByID(ctxReq, int(merchant.BankID.ValueOrZero()))
+	bank, ok := bankResult.Result.(model.B2CMerchantBankData)
+	if bankResult.Error != nil || !ok {
+		return merchant, model.MerchantBankVerification{}, errBankNotExist
+	}
+
+	verification := model.MerchantBankVerification{
+		MerchantID:        merchant.ID,
+		BankID:            merchant.BankID.ValueOrZero(),
+		BankCode:          bank.BankCode,
+		AccountNumber:     merchant.AccountNumber.String,
+		AccountHolderName: merchant.AccountHolderName.String,
+		ActorID:           userAttribute.UserID,
+		Created:           time.Now(),
+	}
+	return merchant, verification, nil
+}
+
+// inquireBankAccount asks the bank verifier for the holder of the account of the verification
+// and compares it with the legal name and the PIC of the merchant
+func (m *MerchantUseCaseImpl) inquireBankAccount(ctxReq context.Context, merchant model.B2CMerchantDataV2, verification *model.MerchantBankVerification) {
+	ctx := "MerchantUseCase-inquireBankAccount"
+
+	inquiry := serviceModel.BankAccountInquiry{
+		BankCode:          verification.BankCode,
+		AccountNumber:     verification.AccountNumber,
+		AccountHolderName: verification.AccountHolderName,
+	}
+	result, err := m.BankVerifier.InquireAccount(ctxReq, inquiry)
+	switch {
+	case err != nil:
+		helper.SendErrorLog(ctxReq, ctx, "inquire_bank_account", err, inquiry)
+		verification.Status = model.BankVerificationFailed
+		verification.Reason = err.Error()
+	case result.Status == serviceModel.BankInquiryPending:
+		verification.Status = model.BankVerificationPending
+	case result.Status == serviceModel.BankInquiryInvalidAccount:
+		verification.Status = model.BankVerificationInvalidAccount
+		verification.Reason = fmt.Sprintf("bank account is not valid (%s)", result.Code)
+	default:
+		verification.InquiryName = result.AccountHolderName
+		verification.MatchedName, verification.Score = matchHolderName(result.AccountHolderName,
+			merchant.CompanyName.String, merchant.NpwpHolderName.String, merchant.Pic.String)
+		verification.Status = model.BankVerificationVerified
+		if verification.Score < bankHolderNameThreshold {
+			verification.Status = model.BankVerificationNameMismatch
+			verification.Reason = fmt.Sprintf("account holder %s does not match the legal name or PIC", result.AccountHolderName)
+		}
+	}
+}
+
+func bankVerificationHTTPStatus(err error) int {
+	switch err {
+	case errMerchantNotFound:
+		return http.StatusNotFound
+	case errMerchantBankRequired, errBankNotExist:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// getBankVerification returns the last bank account check of a merchant for the bank guard, nil when there is none
+func (m *MerchantUseCaseImpl) getBankVerification(ctxReq context.Context, merchantID string) *model.MerchantBankVerification {
+	verificationResult := <-m.BankVerificationRepo.FindLatestByMerchantID(ctxReq, merchantID)
+	verification, ok := verificationResult.Result.(model.MerchantBankVerification)
+	if verificationResult.Error != nil || !ok {
+		return nil
+	}
+	return &verification
+}
+
+// requestBankVerification queues a check of the bank account when it was added or changed
+func (m *MerchantUseCaseImpl) requestBankVerification(ctxReq context.Context, before, after model.B2CMerchantDataV2, actorID string) {
+	if after.BankID.ValueOrZero() == 0 || after.AccountNumber.String == "" || after.AccountHolderName.String == "" {
+		return
+	}
+	if before.BankID.ValueOrZero() == after.BankID.ValueOrZero() &&
+		before.AccountNumber.String == after.AccountNumber.String &&
+		before.AccountHolderName.String == after.AccountHolderName.String {
+		return
+	}
+
+	payload := model.MerchantBankVerificationRequest{
+		MerchantID: after.ID,
+		ActorID:    actorID,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, payload, after.ID, "VerifyMerchantBank")
+}
+
+// matchHolderName returns the candidate closest to the account holder and their similarity from 0 to 1
+func matchHolderName(holderName string, candidates ...string) (string, float64) {
+	matched, best := "", 0.0
+	for _, candidate := range candidates {
+		if score := holderNameScore(holderName, candidate); score > best {
+			matched, best = candidate, score
+		}
+	}
+	return matched, best
+}
+
+// holderNameScore compares two names regardless of case, punctuation, word order, titles and legal forms,
+// initials and a last word truncated by the bank count as a match
+func holderNameScore(holderName, name string) float64 {
+	holder, other := holderNameTokens(holderName), holderNameTokens(name)
+	if len(holder) == 0 || len(other) == 0 {
+		return 0
+	}
+
+	joined := editSimilarity(strings.Join(holder, ""), strings.Join(other, ""))
+	return math.Max(joined, tokenSimilarity(holder, other))
+}
+
+func holderNameTokens(name string) []string {
+	fields := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := []string{}
+	for _, field := range fields {
+		if !holderNameTitles[field] {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+// tokenSimilarity pairs every word of the holder with the closest unused word of the other name
+func tokenSimilarity(holder, other []string) float64 {
+	used := make([]bool, len(other))
+	total := 0.0
+	for i, token := range holder {
+		best, bestIndex := 0.0, -1
+		for j, candidate := range other {
+			if used[j] {
+				continue
+			}
+			if score := tokenScore(token, candidate, i == len(holder)-1); score > best {
+				best, bestIndex = score, j
+			}
+		}
+		if bestIndex >= 0 && best >= bankHolderNameThreshold {
+			used[bestIndex] = true
+			total += best
+		}
+	}
+
+	words := len(holder)
+	if len(other) > words {
+		words = len(other)
+	}
+	return total / float64(words)
+}
+
+func tokenScore(token, candidate string, last bool) float64 {
+	switch {
+	case token == candidate:
+		return 1
+	case len(token) == 1 && strings.HasPrefix(candidate, token), len(candidate) == 1 && strings.HasPrefix(token, candidate):
+		return 1
+	case last && len(token) >= 3 && strings.HasPrefix(candidate, token):
+		return 1
+	}
+	return editSimilarity(token, candidate)
+}
+
+// editSimilarity levenshtein distance turned into a similarity from 0 to 1
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(previous[len(rb)])/float64(longest)
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
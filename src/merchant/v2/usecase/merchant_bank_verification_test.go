@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+	"time"
+
+	localConfig "github.com/Bhinneka/user-service/config"
+	mockToken "github.com/Bhinneka/user-service/src/auth/v1/token/mocks"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
+	mockMerchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo/mocks"
+	serviceMock "github.com/Bhinneka/user-service/src/service/mocks"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+func bankMerchant() model.B2CMerchantDataV2 {
+	return model.B2CMerchantDataV2{
+		ID:                defaultMerchantID,
+		CompanyName:       zero.StringFrom(defCompanyName),
+		NpwpHolderName:    zero.StringFrom(defName),
+		Pic:               zero.StringFrom(defPIC),
+		BankID:            zero.IntFrom(1),
+		AccountNumber:     zero.StringFrom(defPhoneNumber),
+		AccountHolderName: zero.StringFrom(defName),
+	}
+}
+
+func saveBankVerification(ctxReq context.Context, verification model.MerchantBankVerification) <-chan merchantRepo.ResultRepository {
+	return generateRepoResult(merchantRepo.ResultRepository{Result: verification})
+}
+
+func TestHolderNameScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		holderName string
+		otherName  string
+		wantMatch  bool
+	}{
+		{
+			name:       "Case 1: legal form and punctuation",
+			holderName: "PT BHINNEKA MENTARIDIMENSI",
+			otherName:  "PT. Bhinneka Mentari Dimensi",
+			wantMatch:  true,
+		},
+		{
+			name:       "Case 2: honorific",
+			holderName: "BPK BUDI SANTOSO",
+			otherName:  "Budi Santoso",
+			wantMatch:  true,
+		},
+		{
+			name:       "Case 3: word order",
+			holderName: "SANTOSO BUDI",
+			otherName:  "Budi Santoso",
+			wantMatch:  true,
+		},
+		{
+			name:       "Case 4: initial",
+			holderName: "B SANTOSO",
+			otherName:  "Budi Santoso",
+			wantMatch:  true,
+		},
+		{
+			name:       "Case 5: last word truncated by the bank",
+			holderName: "BUDI SANTOSO WIJAYAKUS",
+			otherName:  "Budi Santoso Wijayakusuma",
+			wantMatch:  true,
+		},
+		{
+			name:       "Case 6: typo",
+			holderName: "BUDI SANTOSA",
+			otherName:  "Budi Santoso",
+			wantMatch:  true,
+		},
+		{
+			name:       "Case 7: someone else",
+			holderName: "SITI AMINAH",
+			otherName:  "Budi Santoso",
+		},
+		{
+			name:       "Case 8: first name only",
+			holderName: "BUDI",
+			otherName:  "Budi Santoso",
+		},
+		{
+			name:      "Case 9: empty holder name",
+			otherName: "Budi Santoso",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := holderNameScore(tt.holderName, tt.otherName)
+			assert.Equal(t, tt.wantMatch, score >= bankHolderNameThreshold, score)
+		})
+	}
+}
+
+func TestMatchHolderName(t *testing.T) {
+	matched, score := matchHolderName("IBU SITI AMINAH", "PT Sumber Rejeki", "", "Siti Aminah")
+	assert.Equal(t, "Siti Aminah", matched)
+	assert.Equal(t, 1.0, score)
+
+	matched, score = matchHolderName("SITI AMINAH")
+	assert.Equal(t, "", matched)
+	assert.Equal(t, 0.0, score)
+}
+
+func TestVerifyMerchantBank(t *testing.T) {
+	withoutBank := bankMerchant()
+	withoutBank.AccountNumber = zero.StringFrom("")
+
+	tests := []struct {
+		name           string
+		merchantResult merchantRepo.ResultRepository
+		bankResult     merchantRepo.ResultRepository
+		inquiryResult  serviceModel.BankAccountInquiryResult
+		inquiryErr     error
+		wantStatus     string
+		wantErr        error
+		wantHTTPStatus int
+	}{
+		{
+			name:           "Case 1: holder matches the NPWP holder",
+			merchantResult: merchantRepo.ResultRepository{Result: bankMerchant()},
+			bankResult:     merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 1, BankCode: "BCA"}},
+			inquiryResult:  serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquirySuccess, AccountHolderName: "SOME NAME"},
+			wantStatus:     model.BankVerificationVerified,
+		},
+		{
+			name:           "Case 2: holder is someone else",
+			merchantResult: merchantRepo.ResultRepository{Result: bankMerchant()},
+			bankResult:     merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 1, BankCode: "BCA"}},
+			inquiryResult:  serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquirySuccess, AccountHolderName: "SITI AMINAH"},
+			wantStatus:     model.BankVerificationNameMismatch,
+		},
+		{
+			name:           "Case 3: invalid account",
+			merchantResult: merchantRepo.ResultRepository{Result: bankMerchant()},
+			bankResult:     merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 1, BankCode: "BCA"}},
+			inquiryResult:  serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquiryInvalidAccount, Code: "BLACKLISTED"},
+			wantStatus:     model.BankVerificationInvalidAccount,
+		},
+		{
+			name:           "Case 4: inquiry still running",
+			merchantResult: merchantRepo.ResultRepository{Result: bankMerchant()},
+			bankResult:     merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 1, BankCode: "BCA"}},
+			inquiryResult:  serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquiryPending},
+			wantStatus:     model.BankVerificationPending,
+		},
+		{
+			name:           "Case 5: provider error",
+			merchantResult: merchantRepo.ResultRepository{Result: bankMerchant()},
+			bankResult:     merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 1, BankCode: "BCA"}},
+			inquiryErr:     errDefault,
+			wantStatus:     model.BankVerificationFailed,
+		},
+		{
+			name:           "Case 6: merchant not found",
+			merchantResult: merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantErr:        errMerchantNotFound,
+			wantHTTPStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Case 7: incomplete bank account",
+			merchantResult: merchantRepo.ResultRepository{Result: withoutBank},
+			wantErr:        errMerchantBankRequired,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 8: inactive bank",
+			merchantResult: merchantRepo.ResultRepository{Result: bankMerchant()},
+			bankResult:     merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantErr:        errBankNotExist,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantBankRepoMock := mockMerchantRepo.MerchantBankRepository{}
+			bankVerificationRepoMock := mockMerchantRepo.MerchantBankVerificationRepository{}
+			bankVerifier := serviceMock.BankAccountVerifier{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantRepository:                 &merchantRepoMock,
+				MerchantBankRepository:             &merchantBankRepoMock,
+				MerchantBankVerificationRepository: &bankVerificationRepoMock,
+			}
+			svcShared := localConfig.ServiceShared{
+				BankVerifier: &bankVerifier,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, svcShared, &tokenGen, localConfig.ServiceQuery{})
+
+			merchantRepoMock.On("LoadMerchant", mock.Anything, defaultMerchantID, mock.Anything).Return(tt.merchantResult)
+			merchantBankRepoMock.On("FindActiveMerchantBankByID", mock.Anything, 1).Return(generateRepoResult(tt.bankResult))
+			bankVerifier.On("InquireAccount", mock.Anything, serviceModel.BankAccountInquiry{
+				BankCode:          "BCA",
+				AccountNumber:     defPhoneNumber,
+				AccountHolderName: defName,
+			}).Return(tt.inquiryResult, tt.inquiryErr)
+			bankVerificationRepoMock.On("Save", mock.Anything, mock.Anything).Return(saveBankVerification)
+
+			ucResult := <-m.VerifyMerchantBank(context.Background(), defaultMerchantID, defUserAttr)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			verification := ucResult.Result.(model.MerchantBankVerification)
+			assert.Equal(t, tt.wantStatus, verification.Status)
+			assert.Equal(t, "BCA", verification.BankCode)
+			assert.Equal(t, defUserID, verification.ActorID)
+			assert.True(t, verification.IsFor(bankMerchant()))
+		})
+	}
+}
+
+func TestApproveMerchantBank(t *testing.T) {
+	tests := []struct {
+		name           string
+		reason         string
+		saveResult     merchantRepo.ResultRepository
+		wantErr        bool
+		wantHTTPStatus int
+	}{
+		{
+			name:   "Case 1: approve company account under the director name",
+			reason: "account holder is the director of the company",
+		},
+		{
+			name:           "Case 2: approve without reason",
+			reason:         " ",
+			wantErr:        true,
+			wantHTTPStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 3: failed save",
+			reason:         "account holder is the director of the company",
+			saveResult:     merchantRepo.ResultRepository{Error: errDefault},
+			wantErr:        true,
+			wantHTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantBankRepoMock := mockMerchantRepo.MerchantBankRepository{}
+			bankVerificationRepoMock := mockMerchantRepo.MerchantBankVerificationRepository{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantRepository:                 &merchantRepoMock,
+				MerchantBankRepository:             &merchantBankRepoMock,
+				MerchantBankVerificationRepository: &bankVerificationRepoMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &tokenGen, localConfig.ServiceQuery{})
+
+			merchantRepoMock.On("LoadMerchant", mock.Anything, defaultMerchantID, mock.Anything).Return(merchantRepo.ResultRepository{Result: bankMerchant()})
+			merchantBankRepoMock.On("FindActiveMerchantBankByID", mock.Anything, 1).Return(generateRepoResult(merchantRepo.ResultRepository{Result: model.B2CMerchantBankData{ID: 1, BankCode: "BCA"}}))
+			if tt.saveResult.Error != nil {
+				bankVerificationRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(tt.saveResult))
+			} else {
+				bankVerificationRepoMock.On("Save", mock.Anything, mock.Anything).Return(saveBankVerification)
+			}
+
+			ucResult := <-m.ApproveMerchantBank(context.Background(), defaultMerchantID, tt.reason, defUserAttr)
+			if tt.wantErr {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			verification := ucResult.Result.(model.MerchantBankVerification)
+			assert.Equal(t, model.BankVerificationVerified, verification.Status)
+			assert.Equal(t, tt.reason, verification.Reason)
+			assert.True(t, verification.IsVerifiedFor(bankMerchant()))
+		})
+	}
+}
+
+func TestGetMerchantBankVerification(t *testing.T) {
+	tests := []struct {
+		name           string
+		repoResult     merchantRepo.ResultRepository
+		wantErr        error
+		wantHTTPStatus int
+	}{
+		{
+			name:       "Case 1: last verification",
+			repoResult: merchantRepo.ResultRepository{Result: model.MerchantBankVerification{MerchantID: defaultMerchantID, Status: model.BankVerificationVerified}},
+		},
+		{
+			name:           "Case 2: never verified",
+			repoResult:     merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantErr:        errBankVerificationNotFound,
+			wantHTTPStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Case 3: database error",
+			repoResult:     merchantRepo.ResultRepository{Error: errDefault},
+			wantErr:        errDefault,
+			wantHTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bankVerificationRepoMock := mockMerchantRepo.MerchantBankVerificationRepository{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantBankVerificationRepository: &bankVerificationRepoMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &tokenGen, localConfig.ServiceQuery{})
+
+			bankVerificationRepoMock.On("FindLatestByMerchantID", mock.Anything, defaultMerchantID).Return(generateRepoResult(tt.repoResult))
+
+			ucResult := <-m.GetMerchantBankVerification(context.Background(), defaultMerchantID)
+			assert.Equal(t, tt.wantErr, ucResult.Error)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+			assert.Equal(t, tt.repoResult.Result, ucResult.Result)
+		})
+	}
+}
+
+func TestPollPendingBankVerifications(t *testing.T) {
+	before := time.Now().Add(-15 * time.Minute)
+	pending := []model.MerchantBankVerification{
+		{MerchantID: defaultMerchantID, ActorID: defUserID, Status: model.BankVerificationPending},
+		{MerchantID: "M2", ActorID: defUserID, Status: model.BankVerificationPending},
+	}
+
+	tests := []struct {
+		name           string
+		repoResult     merchantRepo.ResultRepository
+		queueErr       error
+		wantErr        error
+		wantHTTPStatus int
+		wantSummary    model.MerchantBankVerificationPollResult
+	}{
+		{
+			name:        "Case 1: pending checks are queued again",
+			repoResult:  merchantRepo.ResultRepository{Result: pending},
+			wantSummary: model.MerchantBankVerificationPollResult{Queued: 2},
+		},
+		{
+			name:        "Case 2: queue is down",
+			repoResult:  merchantRepo.ResultRepository{Result: pending},
+			queueErr:    errDefault,
+			wantSummary: model.MerchantBankVerificationPollResult{Failed: 2},
+		},
+		{
+			name:           "Case 3: database error",
+			repoResult:     merchantRepo.ResultRepository{Error: errDefault},
+			wantErr:        errDefault,
+			wantHTTPStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bankVerificationRepoMock := mockMerchantRepo.MerchantBankVerificationRepository{}
+			publisher := serviceMock.QPublisher{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantBankVerificationRepository: &bankVerificationRepoMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{QPublisher: &publisher}, &tokenGen, localConfig.ServiceQuery{})
+
+			bankVerificationRepoMock.On("GetPending", mock.Anything, before).Return(generateRepoResult(tt.repoResult))
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(tt.queueErr)
+
+			ucResult := <-m.PollPendingBankVerifications(context.Background(), before)
+			assert.Equal(t, tt.wantErr, ucResult.Error)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+			assert.Equal(t, tt.wantSummary, ucResult.Result)
+			publisher.AssertCalled(t, "QueueJob", mock.Anything, model.MerchantBankVerificationRequest{MerchantID: "M2", ActorID: defUserID}, "M2", "VerifyMerchantBank")
+		})
+	}
+}
@@ -179,6 +179,16 @@ func (m *MerchantUseCaseImpl) UpdateMerchant(ctxReq context.Context, payload *mo
 		existingMerchant.MerchantName = oldData.MerchantName
 		existingMerchant.MerchantEmail = oldData.MerchantEmail
 
+		// review state of the stored documents and bank account for the guards
+		existingMerchant.Documents = m.getStoredDocuments(ctxReq, existingMerchant.ID)
+		existingMerchant.BankVerification = m.getBankVerification(ctxReq, existingMerchant.ID)
+		changes, err := planMerchantTransitions(oldData, existingMerchant)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
 		m.Repository.StartTransaction()
 		updateResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, existingMerchant)
 		if updateResult.Error != nil {
@@ -194,14 +204,8 @@ func (m *MerchantUseCaseImpl) UpdateMerchant(ctxReq context.Context, payload *mo
 		}
 		existingMerchant.Documents = documentProccess.Result.([]model.B2CMerchantDocumentData)
 
-		// create user sendbird
-		if err := m.CreateMerchantSendbirdV4(ctxReq, oldData, payload); err != nil {
-			m.Repository.Rollback()
-			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
-			return
-		}
-
-		if err := m.sendEmailActivationOrApproval(ctxReq, oldData, payload); err != nil {
+		// status history, sendbird user and activation or approval email
+		if err := m.applyMerchantTransitions(ctxReq, changes, merchantChangeMeta{Actor: userAttribute}); err != nil {
 			m.Repository.Rollback()
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
 			return
@@ -214,6 +218,7 @@ func (m *MerchantUseCaseImpl) UpdateMerchant(ctxReq context.Context, payload *mo
 		}
 
 		go m.QueuePublisher.QueueJob(ctxReq, plLog, existingMerchant.ID, "InsertLogMerchantUpdate")
+		m.requestBankVerification(ctxReq, oldData, existingMerchant, userAttribute.UserID)
 
 		output <- ResultUseCase{Result: existingMerchant}
 	})
@@ -235,14 +240,29 @@ func (m *MerchantUseCaseImpl) DeleteMerchant(ctxReq context.Context, merchantID
 		}
 
 		existingMerchant := merchant.Result.(model.B2CMerchantDataV2)
-		existingMerchant.Status = model.DeletedString
 		oldData := existingMerchant
+		existingMerchant.Status = model.DeletedString
+		changes, err := planMerchantTransitions(oldData, existingMerchant, model.MerchantEventDelete)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		m.Repository.StartTransaction()
 		del := <-m.MerchantRepo.SoftDelete(ctxReq, merchantID)
 		if del.Error != nil {
+			m.Repository.Rollback()
 			output <- ResultUseCase{Error: del.Error}
 			return
 		}
 
+		if err := m.applyMerchantTransitions(ctxReq, changes, merchantChangeMeta{Actor: userAttribute}); err != nil {
+			m.Repository.Rollback()
+			output <- ResultUseCase{Error: err}
+			return
+		}
+		m.Repository.Commit()
+
 		existingMerchant.DeletedAt = null.TimeFrom(time.Now())
 		plLog := model.MerchantLog{
 			Before: oldData,
@@ -453,6 +473,12 @@ func (m *MerchantUseCaseImpl) CreateMerchant(ctxReq context.Context, params *mod
 		merchantInput.Status = model.NewString
 		merchantInput.CountUpdateNameAvailable = 1
 
+		changes, err := planMerchantRegistration(merchantInput)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
 		m.Repository.StartTransaction()
 		saveResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, merchantInput)
 		if saveResult.Error != nil {
@@ -471,6 +497,12 @@ func (m *MerchantUseCaseImpl) CreateMerchant(ctxReq context.Context, params *mod
 
 		merchantInput.Documents = documentProccess.Result.([]model.B2CMerchantDocumentData)
 
+		if err := m.applyMerchantTransitions(ctxReq, changes, merchantChangeMeta{Actor: userAttribute}); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			m.Repository.Rollback()
+			return
+		}
+
 		plLog := model.MerchantLog{
 			Before: model.B2CMerchantDataV2{},
 			After:  merchantInput,
@@ -479,6 +511,7 @@ func (m *MerchantUseCaseImpl) CreateMerchant(ctxReq context.Context, params *mod
 		go m.QueuePublisher.QueueJob(ctxReq, plLog, merchantInput.ID, "InsertLogMerchantCreate")
 
 		m.Repository.Commit()
+		m.requestBankVerification(ctxReq, model.B2CMerchantDataV2{}, merchantInput, userAttribute.UserID)
 
 		tags[helper.TextResponse] = merchantInput
 		output <- ResultUseCase{Result: merchantInput}
@@ -503,36 +536,42 @@ func (m *MerchantUseCaseImpl) RejectMerchantRegistration(ctxReq context.Context,
 		}
 
 		existingMerchant := merchantDB.Result.(model.B2CMerchantDataV2)
-		if existingMerchant.IsActive && existingMerchant.Status == model.ActiveString {
+		if merchantLifecycle(model.MerchantStatusField, existingMerchant) == model.ActiveString {
 			output <- ResultUseCase{Error: errUnableToRejectRegistration, HTTPStatus: http.StatusBadRequest}
 			return
 		}
 
 		oldData := existingMerchant
+		existingMerchant.DeletedAt = null.TimeFrom(time.Now())
+		existingMerchant.Status = model.DeletedString
+		changes, err := planMerchantTransitions(oldData, existingMerchant, model.MerchantEventRejectRegistration)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		m.Repository.StartTransaction()
 		del := <-m.MerchantRepo.SoftDelete(ctxReq, merchantID)
 		if del.Error != nil {
+			m.Repository.Rollback()
 			output <- ResultUseCase{Error: del.Error}
 			return
 		}
 
-		existingMerchant.DeletedAt = null.TimeFrom(time.Now())
-		existingMerchant.Status = model.DeletedString
-		memberName := existingMerchant.MerchantName
-
-		plQueue := model.MerchantPayloadEmail{
-			MemberName: memberName,
-			Data:       existingMerchant,
+		// status history, reject email and kafka delete event
+		if err := m.applyMerchantTransitions(ctxReq, changes, merchantChangeMeta{Actor: userAttribute}); err != nil {
+			m.Repository.Rollback()
+			output <- ResultUseCase{Error: err}
+			return
 		}
+		m.Repository.Commit()
+
 		plLog := model.MerchantLog{
 			Before: existingMerchant,
 			After:  model.B2CMerchantDataV2{},
 		}
 
-		go m.QueuePublisher.QueueJob(ctxReq, plQueue, existingMerchant.ID, "SendEmailMerchantRejectRegistration")
 		go m.QueuePublisher.QueueJob(ctxReq, plLog, existingMerchant.ID, "InsertLogMerchantDelete")
-		go func() {
-			m.PublishToKafkaMerchant(ctxReq, oldData, helper.EventProduceDeleteMerchant)
-		}()
 
 		output <- ResultUseCase{Result: existingMerchant}
 
@@ -578,6 +617,18 @@ func (m *MerchantUseCaseImpl) RejectMerchantUpgrade(ctxReq context.Context, merc
 		merchantOnDB.MerchantTypeString = zero.StringFrom(model.RegularString)
 		merchantOnDB.Reason = zero.StringFrom(reasonReject)
 		merchantOnDB = checkUpgradeStatusBeforeReject(merchantOnDB)
+		changes, err := planMerchantTransitions(oldData, merchantOnDB, model.MerchantEventRejectUpgrade)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		emailResult := <-m.MemberQueryRead.FindByID(ctxReq, userAttribute.UserID)
+		member := memberModel.Member{}
+		if emailResult.Result != nil {
+			member = emailResult.Result.(memberModel.Member)
+
+		}
 
 		m.Repository.StartTransaction()
 
@@ -599,32 +650,26 @@ func (m *MerchantUseCaseImpl) RejectMerchantUpgrade(ctxReq context.Context, merc
 			output <- ResultUseCase{Error: resetAction.Error}
 			return
 		}
-		m.Repository.Commit()
-
-		memberName := merchantOnDB.MerchantName
-		emailResult := <-m.MemberQueryRead.FindByID(ctxReq, userAttribute.UserID)
-		member := memberModel.Member{}
-		if emailResult.Result != nil {
-			member = emailResult.Result.(memberModel.Member)
 
+		// status history, reject emails to merchant and admin, kafka update event
+		meta := merchantChangeMeta{
+			Actor:     userAttribute,
+			Reason:    reasonReject,
+			AdminName: member.FirstName + " " + member.LastName,
 		}
-		plQueue := model.MerchantPayloadEmail{
-			MemberName:   memberName,
-			Data:         oldData, // to get upgradeStatus, pass old data
-			ReasonReject: reasonReject,
-			AdminCMS:     member.FirstName + " " + member.LastName,
+		if err := m.applyMerchantTransitions(ctxReq, changes, meta); err != nil {
+			m.Repository.Rollback()
+			output <- ResultUseCase{Error: err}
+			return
 		}
+		m.Repository.Commit()
+
 		plLog := model.MerchantLog{
 			Before: oldData,
 			After:  merchantOnDB,
 		}
 
-		go m.QueuePublisher.QueueJob(ctxReq, plQueue, merchantOnDB.ID, "SendEmailMerchantRejectUpgrade")
-		go m.QueuePublisher.QueueJob(ctxReq, plQueue, merchantOnDB.ID, "SendEmailAdmin")
 		go m.QueuePublisher.QueueJob(ctxReq, plLog, merchantOnDB.ID, "InsertLogMerchantUpdate")
-		go func() {
-			m.PublishToKafkaMerchant(ctxReq, merchantOnDB, helper.EventProduceUpdateMerchant)
-		}()
 
 		output <- ResultUseCase{Result: merchantOnDB}
 
@@ -737,3 +782,29 @@ func (m *MerchantUseCaseImpl) AddMerchantPIC(ctxReq context.Context, payload *mo
 	})
 	return output
 }
+
+// GetMerchantStatusHistory return the recorded status transitions of a merchant, latest first
+func (m *MerchantUseCaseImpl) GetMerchantStatusHistory(ctxReq context.Context, merchantID string) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-GetMerchantStatusHistory"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		merchant := m.MerchantRepo.LoadMerchant(ctxReq, merchantID, private)
+		if merchant.Result == nil {
+			output <- ResultUseCase{Error: errMerchantNotFound, HTTPStatus: http.StatusNotFound}
+			return
+		}
+
+		historyResult := <-m.StatusHistoryRepo.GetByMerchantID(ctxReq, merchantID)
+		if historyResult.Error != nil {
+			tags[helper.TextResponse] = historyResult.Error
+			output <- ResultUseCase{Error: historyResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		output <- ResultUseCase{Result: historyResult.Result, TotalData: historyResult.TotalData}
+	})
+	return output
+}
@@ -89,9 +89,14 @@ var testDataReject = []struct {
 func TestRejectMerchantRegistration(t *testing.T) {
 	for _, tc := range testDataReject {
 		merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+		historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
+		mockDB, _, _ := sqlMock.New()
+		defer mockDB.Close()
 
 		svcRepo := localConfig.ServiceRepository{
-			MerchantRepository: &merchantRepoMock,
+			Repository:                      &repository.Repository{WriteDB: mockDB},
+			MerchantRepository:              &merchantRepoMock,
+			MerchantStatusHistoryRepository: &historyRepoMock,
 		}
 		publisher := serviceMock.QPublisher{}
 		merchantService := serviceMock.MerchantServices{}
@@ -110,6 +115,7 @@ func TestRejectMerchantRegistration(t *testing.T) {
 		publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantDelete").Return(nil)
 		merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
+		historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
 		ucResult := <-m.RejectMerchantRegistration(ctxReq, tc.input, tc.userAttr)
 		if tc.wantError {
 			assert.Error(t, ucResult.Error)
@@ -132,8 +138,14 @@ func TestDeleteMerchantByID(t *testing.T) {
 	for _, tc := range testDataDelete {
 		merchantRepoMock := mockMerchantRepo.MerchantRepository{}
 		merchantServices := serviceMock.MerchantServices{}
+		historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
+		mockDB, _, _ := sqlMock.New()
+		defer mockDB.Close()
+
 		svcRepo := localConfig.ServiceRepository{
-			MerchantRepository: &merchantRepoMock,
+			Repository:                      &repository.Repository{WriteDB: mockDB},
+			MerchantRepository:              &merchantRepoMock,
+			MerchantStatusHistoryRepository: &historyRepoMock,
 		}
 		publisher := serviceMock.QPublisher{}
 		svcShared := localConfig.ServiceShared{
@@ -148,6 +160,7 @@ func TestDeleteMerchantByID(t *testing.T) {
 		merchantRepoMock.On("LoadMerchant", mock.Anything, mock.Anything, mock.Anything).Return(tc.merchantRepoResult)
 		merchantRepoMock.On("SoftDelete", mock.Anything, mock.Anything).Return(sharedMock.MerchantRepoResult(tc.merchantRepoResult2))
 		publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantDelete").Return(nil)
+		historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
 		ucResult := <-m.DeleteMerchant(ctx, tc.input, defUserAttr)
 		if tc.wantError {
 			assert.Error(t, ucResult.Error)
@@ -232,9 +245,11 @@ func TestCreateMerchantFromCMS(t *testing.T) {
 		mockDB, _, _ := sqlMock.New()
 		defer mockDB.Close()
 
+		historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
 		svcRepo := localConfig.ServiceRepository{
-			MerchantRepository: &merchantRepoMock,
-			Repository:         &repository.Repository{WriteDB: mockDB},
+			MerchantRepository:              &merchantRepoMock,
+			Repository:                      &repository.Repository{WriteDB: mockDB},
+			MerchantStatusHistoryRepository: &historyRepoMock,
 		}
 		publisher := serviceMock.QPublisher{}
 		merchantService := serviceMock.MerchantServices{}
@@ -258,8 +273,10 @@ func TestCreateMerchantFromCMS(t *testing.T) {
 		merchantRepoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(tc.merchantRepoResult))
 
 		publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantCreate").Return(nil)
+		publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(nil)
 		merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
+		historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
 		ucResult := <-m.CreateMerchant(ctxReq, tc.input, tc.userAttr)
 		if tc.wantError {
 			assert.Error(t, ucResult.Error)
@@ -270,12 +287,16 @@ func TestCreateMerchantFromCMS(t *testing.T) {
 }
 
 func TestUpdateMerchantFromCMS(t *testing.T) {
+	// copies with an explicit status, defInput is shared with the tests run before
+	defInput1 := defInput
+	defInput1.Status = model.ActiveString
 	defInput2 := defInput
 	defInput2.UpgradeStatus = "MM"
 	os.Setenv("EMAIL_MERCHANT_ACTIVATION", "22")
 	os.Setenv("EMAIL_MERCHANT_UPGRADE_APPROVAL", "21")
 	os.Setenv("EMAIL_BCC_HUNTER", "email@bhinneka.com")
 	defInput3 := defInput
+	defInput3.Status = model.ActiveString
 	defInput3.UpgradeStatus = "ACTIVE"
 	var rsSendbird serviceModel.SendbirdStringResponse
 	rsSendbird.Code = 400201
@@ -296,12 +317,13 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 		errorEmail               error
 		memberQueryID            memberQuery.ResultQuery
 		responseNotFound         serviceModel.SendbirdErrorResponse
+		bankNotVerified          bool
 	}{
 		{
 			name:               "Test Update Merchant #1", // all passed
-			input:              &defInput,
+			input:              &defInput1,
 			userAttr:           defUserAttr,
-			loadMerchantResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{IsActive: false}},
+			loadMerchantResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{IsActive: false, BankID: zero.IntFrom(1)}},
 			serviceResult:      serviceModel.ServiceResult{Result: defEmailContent},
 		},
 		{
@@ -312,7 +334,7 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 		},
 		{
 			name:               "Test Update Merchant #3", // failed update
-			input:              &defInput,
+			input:              &defInput1,
 			userAttr:           defUserAttr,
 			wantError:          true,
 			loadMerchantResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{}},
@@ -322,7 +344,7 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 			name:                  "Test Update Merchant #4", // all passed, send email upgrade approval
 			input:                 &defInput3,
 			userAttr:              defUserAttr,
-			loadMerchantResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
+			loadMerchantResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, BankID: zero.IntFrom(1), UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
 			memberQueryID:         memberQuery.ResultQuery{Result: memberModel.Member{ID: defUserID}},
 			serviceSendbirdResult: serviceModel.ServiceResult{Result: serviceModel.SendbirdStringResponse{}},
 			serviceResult:         serviceModel.ServiceResult{Result: defEmailContent},
@@ -331,7 +353,7 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 			name:                  "Test Update Merchant #5", // check userid sendbird
 			input:                 &defInput3,
 			userAttr:              defUserAttr,
-			loadMerchantResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
+			loadMerchantResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, BankID: zero.IntFrom(1), UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
 			memberQueryID:         memberQuery.ResultQuery{Result: memberModel.Member{ID: defUserID}},
 			serviceSendbirdResult: serviceModel.ServiceResult{Error: errDefault, Result: serviceModel.SendbirdStringResponse{}},
 			wantError:             false,
@@ -340,7 +362,7 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 			name:                     "Test Update Merchant #6", // all passed, send email upgrade approval
 			input:                    &defInput3,
 			userAttr:                 defUserAttr,
-			loadMerchantResult:       merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
+			loadMerchantResult:       merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, BankID: zero.IntFrom(1), UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
 			memberQueryID:            memberQuery.ResultQuery{Result: memberModel.Member{ID: defUserID}},
 			serviceSendbirdResult:    serviceModel.ServiceResult{Error: errDefault, Result: rsSendbird},
 			createUserSendbirdResult: serviceModel.ServiceResult{Result: serviceModel.SendbirdStringResponse{}},
@@ -351,7 +373,7 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 			name:                     "Test Update Merchant #7", // all passed, send email upgrade approval
 			input:                    &defInput3,
 			userAttr:                 defUserAttr,
-			loadMerchantResult:       merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
+			loadMerchantResult:       merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, BankID: zero.IntFrom(1), UpgradeStatus: zero.StringFrom("PENDING_MANAGE")}},
 			memberQueryID:            memberQuery.ResultQuery{Result: memberModel.Member{ID: defUserID}},
 			serviceSendbirdResult:    serviceModel.ServiceResult{Error: errDefault, Result: rsSendbird},
 			createUserSendbirdResult: serviceModel.ServiceResult{Error: errDefault, Result: serviceModel.SendbirdStringResponse{}},
@@ -359,7 +381,7 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 		},
 		{
 			name:               "Test Update Merchant #8", // no rows on merchant
-			input:              &defInput,
+			input:              &defInput1,
 			userAttr:           defUserAttr,
 			wantError:          true,
 			loadMerchantResult: merchantRepo.ResultRepository{Error: sql.ErrNoRows},
@@ -368,22 +390,36 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 			name:               "Test Update Merchant #9", // all passed skip send email approval
 			input:              &defInput3,
 			userAttr:           defUserAttr,
-			loadMerchantResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, UpgradeStatus: zero.StringFrom("PENDING_ASSOCIATE")}},
+			loadMerchantResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "1", IsActive: true, BankID: zero.IntFrom(1), UpgradeStatus: zero.StringFrom("PENDING_ASSOCIATE")}},
 			serviceResult:      serviceModel.ServiceResult{Result: defEmailContent},
 			errorEmail:         errDefault,
 		},
+		{
+			name:               "Test Update Merchant #10", // activate with unverified bank account
+			input:              &defInput1,
+			userAttr:           defUserAttr,
+			loadMerchantResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{IsActive: false, BankID: zero.IntFrom(1)}},
+			bankNotVerified:    true,
+			wantError:          true,
+		},
 	}
 
 	for _, tc := range testDataUpdateMerchant {
 		t.Run(tc.name, func(t *testing.T) {
 			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantDocRepoMock := mockMerchantRepo.MerchantDocumentRepository{}
+			bankVerificationRepoMock := mockMerchantRepo.MerchantBankVerificationRepository{}
 			memberQueryMock := mockMemberQuery.MemberQuery{}
 			mockDB, _, _ := sqlMock.New()
 			defer mockDB.Close()
 
+			historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
 			svcRepo := localConfig.ServiceRepository{
-				MerchantRepository: &merchantRepoMock,
-				Repository:         &repository.Repository{WriteDB: mockDB},
+				MerchantRepository:                 &merchantRepoMock,
+				MerchantDocumentRepository:         &merchantDocRepoMock,
+				MerchantBankVerificationRepository: &bankVerificationRepoMock,
+				Repository:                         &repository.Repository{WriteDB: mockDB},
+				MerchantStatusHistoryRepository:    &historyRepoMock,
 			}
 			publisher := serviceMock.QPublisher{}
 			merchantService := serviceMock.MerchantServices{}
@@ -406,6 +442,20 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 
 			merchantRepoMock.On("LoadMerchant", mock.Anything, mock.Anything, mock.Anything).Return(tc.loadMerchantResult)
 			merchantRepoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(tc.merchantRepoResult))
+			merchantDocRepoMock.On("GetListMerchantDocument", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{Result: verifiedDocuments}))
+
+			loadedMerchant, _ := tc.loadMerchantResult.Result.(model.B2CMerchantDataV2)
+			bankVerification := model.MerchantBankVerification{
+				MerchantID:        loadedMerchant.ID,
+				BankID:            1,
+				AccountNumber:     defPhoneNumber,
+				AccountHolderName: defName,
+				Status:            model.BankVerificationVerified,
+			}
+			if tc.bankNotVerified {
+				bankVerification.Status = model.BankVerificationNameMismatch
+			}
+			bankVerificationRepoMock.On("FindLatestByMerchantID", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{Result: bankVerification}))
 
 			sendbirdService.On("CheckUserSenbird", mock.Anything, mock.Anything).Return(serviceModel.ServiceResult(tc.serviceSendbirdResult))
 
@@ -414,11 +464,15 @@ func TestUpdateMerchantFromCMS(t *testing.T) {
 			memberQueryMock.On("FindByID", mock.Anything, mock.Anything).Return(sharedMock.MemberQueryResult(tc.memberQueryID))
 			sendbirdService.On("CheckUserSenbird", mock.Anything, mock.Anything).Return(serviceModel.ServiceResult(tc.serviceSendbirdResult))
 			sendbirdService.On("CreateUserSendbird", mock.Anything, mock.Anything).Return(serviceModel.ServiceResult(tc.createUserSendbirdResult))
+			sendbirdService.On("CheckUserSenbirdV4", mock.Anything, mock.Anything).Return(serviceModel.ServiceResult{Result: serviceModel.SendbirdStringResponseV4{}})
 
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailActivation").Return(nil)
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailApproval").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailMerchantUpgrade").Return(nil)
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(nil)
 
+			historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
 			ucResult := <-m.UpdateMerchant(ctxReq, tc.input, tc.userAttr)
 			if tc.wantError {
 				assert.Error(t, ucResult.Error)
@@ -534,10 +588,12 @@ func TestRejectMerchantUpgrade(t *testing.T) {
 		defer mockDB.Close()
 		// sqlMock.ExpectBegin()
 
+		historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
 		svcRepo := localConfig.ServiceRepository{
-			MerchantRepository:         &merchantRepoMock,
-			Repository:                 &repository.Repository{WriteDB: mockDB},
-			MerchantDocumentRepository: &merchantDocRepoMock,
+			MerchantRepository:              &merchantRepoMock,
+			Repository:                      &repository.Repository{WriteDB: mockDB},
+			MerchantDocumentRepository:      &merchantDocRepoMock,
+			MerchantStatusHistoryRepository: &historyRepoMock,
 		}
 		publisher := serviceMock.QPublisher{}
 		merchantService := serviceMock.MerchantServices{}
@@ -562,6 +618,7 @@ func TestRejectMerchantUpgrade(t *testing.T) {
 
 		merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
+		historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
 		ucResult := <-m.RejectMerchantUpgrade(ctxReq, tc.input, tc.userAttr, mock.Anything)
 		if tc.wantError {
 			assert.Error(t, ucResult.Error)
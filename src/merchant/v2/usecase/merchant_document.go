@@ -145,3 +145,16 @@ func (m *MerchantUseCaseImpl) ValidateDocument(ctxReq context.Context, document
 	}
 	return nil
 }
+
+// getStoredDocuments function to get the stored merchant documents with their review state
+func (m *MerchantUseCaseImpl) getStoredDocuments(ctxReq context.Context, merchantID string) []model.B2CMerchantDocumentData {
+	query := model.B2CMerchantDocumentQueryInput{
+		MerchantID: merchantID,
+	}
+	documentResult := <-m.MerchantDocumentRepo.GetListMerchantDocument(ctxReq, &query)
+	documents, ok := documentResult.Result.(model.ListB2CMerchantDocument)
+	if !ok {
+		return []model.B2CMerchantDocumentData{}
+	}
+	return documents.MerchantDocument
+}
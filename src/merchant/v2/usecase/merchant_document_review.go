@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib"
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+// ReviewMerchantDocument function to approve or reject a merchant document from CMS
+func (m *MerchantUseCaseImpl) ReviewMerchantDocument(ctxReq context.Context, input model.B2CMerchantDocumentReviewInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ReviewMerchantDocument"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMerchantIDCamel] = input.MerchantID
+		tags["documentType"] = input.DocumentType
+		tags["status"] = input.Status
+
+		now := time.Now()
+		expirationDate, err := validateDocumentReview(&input, now)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		merchantResult := m.MerchantRepo.LoadMerchant(ctxReq, input.MerchantID, private)
+		if merchantResult.Error != nil {
+			if merchantResult.Error == sql.ErrNoRows {
+				output <- ResultUseCase{Error: errMerchantNotFound, HTTPStatus: http.StatusNotFound}
+				return
+			}
+			output <- ResultUseCase{Error: merchantResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+		document, err := m.findReviewDocument(ctxReq, merchant, input.DocumentType)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusNotFound}
+			return
+		}
+
+		document.Status = input.Status
+		document.RejectReason = input.Reason
+		document.ReviewerID = userAttribute.UserID
+		document.Reviewed = null.TimeFrom(now)
+		document.ExpiryNotified = null.Time{}
+		document.EditorID = userAttribute.UserID
+		document.EditorIP = userAttribute.UserIP
+		document.LastModified = null.TimeFrom(now)
+		if expirationDate.Valid {
+			document.DocumentExpirationDate = expirationDate
+		}
+		if document.Status == model.DocumentStatusVerified && document.IsExpired(now) {
+			output <- ResultUseCase{Error: errDocumentExpired, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		m.Repository.StartTransaction()
+		if document.ID == "" {
+			document.ID = helper.GenerateDocumentID()
+			document.Version = 1
+			document.CreatorID = userAttribute.UserID
+			document.CreatorIP = userAttribute.UserIP
+			document.Created = null.TimeFrom(now)
+			insertResult := <-m.MerchantDocumentRepo.InsertNewMerchantDocument(ctxReq, &document)
+			if insertResult.Error != nil {
+				m.Repository.Rollback()
+				output <- ResultUseCase{Error: errors.New("failed to save merchant document"), HTTPStatus: http.StatusInternalServerError}
+				return
+			}
+		}
+
+		reviewResult := <-m.MerchantDocumentRepo.UpdateDocumentReview(ctxReq, &document)
+		if reviewResult.Error != nil {
+			m.Repository.Rollback()
+			output <- ResultUseCase{Error: errors.New("failed to review merchant document"), HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		m.Repository.Commit()
+
+		output <- ResultUseCase{Result: document}
+	})
+	return output
+}
+
+// findReviewDocument returns the stored document of the given type,
+// KTP and NPWP files are kept in the merchant so their review row is created on the first review
+func (m *MerchantUseCaseImpl) findReviewDocument(ctxReq context.Context, merchant model.B2CMerchantDataV2, documentType string) (model.B2CMerchantDocumentData, error) {
+	query := model.B2CMerchantDocumentQueryInput{
+		MerchantID:   merchant.ID,
+		DocumentType: documentType,
+	}
+	document := model.B2CMerchantDocumentData{}
+	findResult := <-m.MerchantDocumentRepo.FindMerchantDocumentByParam(ctxReq, &query)
+	if findResult.Error == nil {
+		document, _ = findResult.Result.(model.B2CMerchantDocumentData)
+	}
+
+	switch documentType {
+	case model.DocumentTypeKTP:
+		document.DocumentValue = merchant.PicKtpFile.String
+	case model.DocumentTypeNPWP:
+		document.DocumentValue = merchant.NpwpFile.String
+	}
+	if document.DocumentValue == "" {
+		return document, errMerchantDocumentNotFound
+	}
+
+	document.MerchantID = merchant.ID
+	document.DocumentType = documentType
+	return document, nil
+}
+
+// resetIdentityDocumentReview moves the KTP and NPWP review back to pending when the merchant uploads another file
+func (m *MerchantUseCaseImpl) resetIdentityDocumentReview(ctxReq context.Context, oldData, currentData model.B2CMerchantDataV2) error {
+	documentTypes := []string{}
+	if currentData.PicKtpFile.String != oldData.PicKtpFile.String {
+		documentTypes = append(documentTypes, model.DocumentTypeKTP)
+	}
+	if currentData.NpwpFile.String != oldData.NpwpFile.String {
+		documentTypes = append(documentTypes, model.DocumentTypeNPWP)
+	}
+	if len(documentTypes) == 0 {
+		return nil
+	}
+
+	resetResult := <-m.MerchantDocumentRepo.ResetDocumentReview(ctxReq, currentData.ID, documentTypes)
+	return resetResult.Error
+}
+
+// ProcessDocumentExpiry function for the scheduled check of verified documents,
+// merchants are reminded of documents expiring within reminder and restricted when a mandatory document lapsed
+func (m *MerchantUseCaseImpl) ProcessDocumentExpiry(ctxReq context.Context, now time.Time, reminder time.Duration) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ProcessDocumentExpiry"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["now"] = now
+		tags["reminder"] = reminder.String()
+
+		documentResult := <-m.MerchantDocumentRepo.GetDocumentsExpiringBefore(ctxReq, model.ExpiringDocumentTypes, now.Add(reminder))
+		if documentResult.Error != nil {
+			output <- ResultUseCase{Error: documentResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		documents, _ := documentResult.Result.([]model.B2CMerchantDocumentData)
+
+		merchantIDs := []string{}
+		merchantDocuments := map[string][]model.B2CMerchantDocumentData{}
+		for _, document := range documents {
+			if _, ok := merchantDocuments[document.MerchantID]; !ok {
+				merchantIDs = append(merchantIDs, document.MerchantID)
+			}
+			merchantDocuments[document.MerchantID] = append(merchantDocuments[document.MerchantID], document)
+		}
+
+		summary := model.MerchantDocumentExpiryResult{}
+		for _, merchantID := range merchantIDs {
+			if err := m.processMerchantDocumentExpiry(ctxReq, merchantID, merchantDocuments[merchantID], now, &summary); err != nil {
+				helper.SendErrorLog(ctxReq, ctx, "process_merchant_document_expiry", err, merchantID)
+				summary.Failed++
+			}
+		}
+		tags[helper.TextResponse] = summary
+
+		output <- ResultUseCase{Result: summary}
+	})
+	return output
+}
+
+func (m *MerchantUseCaseImpl) processMerchantDocumentExpiry(ctxReq context.Context, merchantID string, documents []model.B2CMerchantDocumentData, now time.Time, summary *model.MerchantDocumentExpiryResult) error {
+	merchantResult := m.MerchantRepo.LoadMerchant(ctxReq, merchantID, private)
+	if merchantResult.Error != nil {
+		return merchantResult.Error
+	}
+	merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+	expired := []model.B2CMerchantDocumentData{}
+	expiring := []model.B2CMerchantDocumentData{}
+	lapsed := false
+	for _, document := range documents {
+		switch {
+		case document.IsExpired(now):
+			document.Status = model.DocumentStatusExpired
+			expired = append(expired, document)
+			lapsed = lapsed || golib.StringInSlice(document.DocumentType, model.MandatoryDocumentTypes)
+		case !document.ExpiryNotified.Valid:
+			document.ExpiryNotified = null.TimeFrom(now)
+			expiring = append(expiring, document)
+		}
+	}
+
+	changes := []merchantChange{}
+	after := merchant
+	if lapsed && merchantLifecycle(model.MerchantStatusField, merchant) == model.ActiveString {
+		after.Status = model.RestrictedString
+		after.IsActive = false
+		after.EditorID = null.StringFrom(model.SystemActor)
+		after.LastModified = null.TimeFrom(now)
+		after.Version = zero.IntFrom(after.Version.ValueOrZero() + 1)
+		after.Documents = expired
+
+		var err error
+		changes, err = planMerchantTransitions(merchant, after, model.MerchantEventRestrict)
+		if err != nil {
+			return err
+		}
+	}
+
+	m.Repository.StartTransaction()
+	for _, document := range append(expired, expiring...) {
+		document.LastModified = null.TimeFrom(now)
+		reviewResult := <-m.MerchantDocumentRepo.UpdateDocumentReview(ctxReq, &document)
+		if reviewResult.Error != nil {
+			m.Repository.Rollback()
+			return reviewResult.Error
+		}
+	}
+
+	if len(changes) > 0 {
+		updateResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, after)
+		if updateResult.Error != nil {
+			m.Repository.Rollback()
+			return updateResult.Error
+		}
+
+		meta := merchantChangeMeta{
+			Actor:     &model.MerchantUserAttribute{UserID: model.SystemActor},
+			Reason:    "expired documents: " + documentNames(expired),
+			Documents: expired,
+		}
+		if err := m.applyMerchantTransitions(ctxReq, changes, meta); err != nil {
+			m.Repository.Rollback()
+			return err
+		}
+	}
+	m.Repository.Commit()
+
+	if len(expiring) > 0 {
+		plQueue := model.MerchantPayloadEmail{
+			MemberName: merchant.MerchantName,
+			Data:       merchant,
+			Documents:  expiring,
+		}
+		go m.QueuePublisher.QueueJob(ctxReq, plQueue, merchant.ID, "SendEmailMerchantDocumentExpiry")
+	}
+	if len(changes) > 0 {
+		plLog := model.MerchantLog{
+			Before: merchant,
+			After:  after,
+		}
+		go m.QueuePublisher.QueueJob(ctxReq, plLog, merchant.ID, "InsertLogMerchantUpdate")
+		summary.Restricted++
+	}
+
+	summary.Reminded += len(expiring)
+	summary.Expired += len(expired)
+	return nil
+}
+
+// documentNames returns the document types without the file suffix, e.g. KTP, NPWP
+func documentNames(documents []model.B2CMerchantDocumentData) string {
+	names := []string{}
+	for _, document := range documents {
+		names = append(names, strings.TrimSuffix(document.DocumentType, "-file"))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	localConfig "github.com/Bhinneka/user-service/config"
+	mockToken "github.com/Bhinneka/user-service/src/auth/v1/token/mocks"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
+	mockMerchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo/mocks"
+	serviceMock "github.com/Bhinneka/user-service/src/service/mocks"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	sqlMock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestValidateDocumentReview(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		input      model.B2CMerchantDocumentReviewInput
+		wantErr    error
+		wantExpiry null.Time
+	}{
+		{
+			name:  "Case 1: approve without expiration date",
+			input: model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusVerified},
+		},
+		{
+			name:       "Case 2: approve with expiration date",
+			input:      model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusVerified, ExpirationDate: "2027-10-18"},
+			wantExpiry: null.TimeFrom(time.Date(2027, 10, 18, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:    "Case 3: approve with invalid expiration date",
+			input:   model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusVerified, ExpirationDate: "18-10-2027"},
+			wantErr: errDocumentExpirationDate,
+		},
+		{
+			name:    "Case 4: approve with past expiration date",
+			input:   model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusVerified, ExpirationDate: "2026-10-01"},
+			wantErr: errDocumentExpired,
+		},
+		{
+			name:  "Case 5: reject with reason",
+			input: model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusRejected, Reason: "blurry image"},
+		},
+		{
+			name:    "Case 6: reject without reason",
+			input:   model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusRejected, Reason: "  "},
+			wantErr: errDocumentReviewReason,
+		},
+		{
+			name:    "Case 7: invalid status",
+			input:   model.B2CMerchantDocumentReviewInput{Status: model.DocumentStatusExpired},
+			wantErr: errDocumentReviewStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiry, err := validateDocumentReview(&tt.input, now)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantExpiry.Valid, expiry.Valid)
+			if tt.wantExpiry.Valid {
+				assert.True(t, tt.wantExpiry.Time.Equal(expiry.Time))
+			}
+		})
+	}
+}
+
+func TestReviewMerchantDocument(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          model.B2CMerchantDocumentReviewInput
+		loadResult     merchantRepo.ResultRepository
+		findResult     merchantRepo.ResultRepository
+		wantError      bool
+		wantHTTPStatus int
+		wantInsert     bool
+	}{
+		{
+			name: "Case 1: approve KTP reviewed for the first time",
+			input: model.B2CMerchantDocumentReviewInput{
+				MerchantID:     defaultMerchantID,
+				DocumentType:   model.DocumentTypeKTP,
+				Status:         model.DocumentStatusVerified,
+				ExpirationDate: time.Now().AddDate(1, 0, 0).Format("2006-01-02"),
+			},
+			loadResult: merchantRepo.ResultRepository{Result: stateMerchant(model.NewString, false, "")},
+			findResult: merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantInsert: true,
+		},
+		{
+			name: "Case 2: reject stored SIUP",
+			input: model.B2CMerchantDocumentReviewInput{
+				MerchantID:   defaultMerchantID,
+				DocumentType: model.DocumentTypeSIUP,
+				Status:       model.DocumentStatusRejected,
+				Reason:       "blurry image",
+			},
+			loadResult: merchantRepo.ResultRepository{Result: stateMerchant(model.ActiveString, true, "")},
+			findResult: merchantRepo.ResultRepository{Result: model.B2CMerchantDocumentData{
+				ID:            "DOC201210161001",
+				MerchantID:    defaultMerchantID,
+				DocumentType:  model.DocumentTypeSIUP,
+				DocumentValue: defImage,
+			}},
+		},
+		{
+			name: "Case 3: reject without reason",
+			input: model.B2CMerchantDocumentReviewInput{
+				MerchantID:   defaultMerchantID,
+				DocumentType: model.DocumentTypeNPWP,
+				Status:       model.DocumentStatusRejected,
+			},
+			wantError:      true,
+			wantHTTPStatus: 400,
+		},
+		{
+			name: "Case 4: merchant not found",
+			input: model.B2CMerchantDocumentReviewInput{
+				MerchantID:   defaultMerchantID,
+				DocumentType: model.DocumentTypeKTP,
+				Status:       model.DocumentStatusVerified,
+			},
+			loadResult:     merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantError:      true,
+			wantHTTPStatus: 404,
+		},
+		{
+			name: "Case 5: document not uploaded",
+			input: model.B2CMerchantDocumentReviewInput{
+				MerchantID:   defaultMerchantID,
+				DocumentType: model.DocumentTypeTDP,
+				Status:       model.DocumentStatusVerified,
+			},
+			loadResult:     merchantRepo.ResultRepository{Result: stateMerchant(model.ActiveString, true, "")},
+			findResult:     merchantRepo.ResultRepository{Error: sql.ErrNoRows},
+			wantError:      true,
+			wantHTTPStatus: 404,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantDocRepoMock := mockMerchantRepo.MerchantDocumentRepository{}
+			mockDB, _, _ := sqlMock.New()
+			defer mockDB.Close()
+
+			svcRepo := localConfig.ServiceRepository{
+				Repository:                 &repository.Repository{WriteDB: mockDB},
+				MerchantRepository:         &merchantRepoMock,
+				MerchantDocumentRepository: &merchantDocRepoMock,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &tokenGen, localConfig.ServiceQuery{})
+
+			merchantRepoMock.On("LoadMerchant", mock.Anything, mock.Anything, mock.Anything).Return(tt.loadResult)
+			merchantDocRepoMock.On("FindMerchantDocumentByParam", mock.Anything, mock.Anything).Return(generateRepoResult(tt.findResult))
+			merchantDocRepoMock.On("InsertNewMerchantDocument", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+			merchantDocRepoMock.On("UpdateDocumentReview", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+
+			ucResult := <-m.ReviewMerchantDocument(context.Background(), tt.input, defUserAttr)
+			if tt.wantError {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantHTTPStatus, ucResult.HTTPStatus)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			document := ucResult.Result.(model.B2CMerchantDocumentData)
+			assert.Equal(t, tt.input.Status, document.Status)
+			assert.Equal(t, defUserID, document.ReviewerID)
+			assert.NotEmpty(t, document.DocumentValue)
+			if tt.wantInsert {
+				merchantDocRepoMock.AssertCalled(t, "InsertNewMerchantDocument", mock.Anything, mock.Anything)
+			} else {
+				merchantDocRepoMock.AssertNotCalled(t, "InsertNewMerchantDocument", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProcessDocumentExpiry(t *testing.T) {
+	now := time.Now()
+	expiring := stateDocuments(model.DocumentStatusVerified, now.AddDate(0, 0, 10))
+	expired := stateDocuments(model.DocumentStatusVerified, now.AddDate(0, 0, -1))
+	notified := stateDocuments(model.DocumentStatusVerified, now.AddDate(0, 0, 10))
+	for i := range notified {
+		notified[i].ExpiryNotified = null.TimeFrom(now.AddDate(0, 0, -1))
+	}
+
+	tests := []struct {
+		name        string
+		documents   []model.B2CMerchantDocumentData
+		merchant    model.B2CMerchantDataV2
+		wantSummary model.MerchantDocumentExpiryResult
+	}{
+		{
+			name:        "Case 1: remind documents expiring soon",
+			documents:   expiring,
+			merchant:    stateMerchant(model.ActiveString, true, ""),
+			wantSummary: model.MerchantDocumentExpiryResult{Reminded: 2},
+		},
+		{
+			name:        "Case 2: documents already reminded",
+			documents:   notified,
+			merchant:    stateMerchant(model.ActiveString, true, ""),
+			wantSummary: model.MerchantDocumentExpiryResult{},
+		},
+		{
+			name:        "Case 3: restrict active merchant with expired documents",
+			documents:   expired,
+			merchant:    stateMerchant(model.ActiveString, true, ""),
+			wantSummary: model.MerchantDocumentExpiryResult{Expired: 2, Restricted: 1},
+		},
+		{
+			name:        "Case 4: expired documents of inactive merchant",
+			documents:   expired,
+			merchant:    stateMerchant(model.InactiveString, false, ""),
+			wantSummary: model.MerchantDocumentExpiryResult{Expired: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			merchantDocRepoMock := mockMerchantRepo.MerchantDocumentRepository{}
+			historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
+			mockDB, _, _ := sqlMock.New()
+			defer mockDB.Close()
+
+			svcRepo := localConfig.ServiceRepository{
+				Repository:                      &repository.Repository{WriteDB: mockDB},
+				MerchantRepository:              &merchantRepoMock,
+				MerchantDocumentRepository:      &merchantDocRepoMock,
+				MerchantStatusHistoryRepository: &historyRepoMock,
+			}
+			publisher := serviceMock.QPublisher{}
+			merchantService := serviceMock.MerchantServices{}
+			svcShared := localConfig.ServiceShared{
+				QPublisher:      &publisher,
+				MerchantService: &merchantService,
+			}
+			tokenGen := mockToken.AccessTokenGenerator{}
+			m := NewMerchantUseCase(svcRepo, svcShared, &tokenGen, localConfig.ServiceQuery{})
+
+			merchantDocRepoMock.On("GetDocumentsExpiringBefore", mock.Anything, model.ExpiringDocumentTypes, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{Result: tt.documents}))
+			merchantDocRepoMock.On("UpdateDocumentReview", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+			merchantRepoMock.On("LoadMerchant", mock.Anything, mock.Anything, mock.Anything).Return(merchantRepo.ResultRepository{Result: tt.merchant})
+			merchantRepoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+			historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{}))
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			ucResult := <-m.ProcessDocumentExpiry(context.Background(), now, 30*24*time.Hour)
+			assert.NoError(t, ucResult.Error)
+			assert.Equal(t, tt.wantSummary, ucResult.Result)
+		})
+	}
+}
@@ -25,7 +25,8 @@ const ErrorMerchantNotFound = "merchant not found"
 const ErrorMerchantNotRegister = "you are not registered as a merchant"
 const ParamsResendEmail = "{resend-email}"
 
-func (m *MerchantUseCaseImpl) AddEmployee(ctxReq context.Context, token, email, firstName string) <-chan ResultUseCase {
+// AddEmployee invites an employee to the merchant with the role, VIEWER when empty
+func (m *MerchantUseCaseImpl) AddEmployee(ctxReq context.Context, token, email, firstName, role string) <-chan ResultUseCase {
 	ctx := "MerchantUseCase-AddEmployee"
 
 	output := make(chan ResultUseCase)
@@ -48,15 +49,30 @@ func (m *MerchantUseCaseImpl) AddEmployee(ctxReq context.Context, token, email,
 			return
 		}
 
+		// validate role
+		role = strings.ToUpper(strings.TrimSpace(role))
+		if role != "" && !model.IsEmployeeRole(role) {
+			output <- ResultUseCase{Error: errEmployeeRole, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		if access, ok := employeeAccess(ctxReq, claims["sub"].(string)); ok && !access.CanManage(role) {
+			output <- ResultUseCase{Error: errEmployeeManage, HTTPStatus: http.StatusForbidden}
+			return
+		}
+
 		// get merchant
-		merchantResult := m.MerchantRepo.FindMerchantByEmail(ctxReq, claims["email"].(string))
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, claims["sub"].(string))
 		if merchantResult.Error != nil {
 			output <- ResultUseCase{Error: errors.New(ErrorMerchantNotRegister)}
 			return
 		}
 		merchant := merchantResult.Result.(model.B2CMerchantDataV2)
 
-		token, err = m.CreateMerchantEmployee(ctxReq, merchant, email, firstName, claims)
+		token, err = m.CreateMerchantEmployee(ctxReq, merchant, email, firstName, role, claims)
+		if err == errEmployeeManage {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusForbidden}
+			return
+		}
 		if err != nil {
 			output <- ResultUseCase{Error: err}
 			return
@@ -68,7 +84,7 @@ func (m *MerchantUseCaseImpl) AddEmployee(ctxReq context.Context, token, email,
 
 }
 
-func (m *MerchantUseCaseImpl) CreateMerchantEmployee(ctxReq context.Context, merchant model.B2CMerchantDataV2, email, firstName string, claims jwt.MapClaims) (string, error) {
+func (m *MerchantUseCaseImpl) CreateMerchantEmployee(ctxReq context.Context, merchant model.B2CMerchantDataV2, email, firstName, role string, claims jwt.MapClaims) (string, error) {
 	// get member
 	var err error
 	paramsMember := memberModel.Member{}
@@ -110,7 +126,7 @@ func (m *MerchantUseCaseImpl) CreateMerchantEmployee(ctxReq context.Context, mer
 	}
 
 	// checking merchant employee
-	err = m.CheckingMerchantEmployee(ctxReq, merchant, &member, claims, firstName)
+	err = m.CheckingMerchantEmployee(ctxReq, merchant, &member, claims, firstName, role)
 	if err != nil {
 		return "", err
 	}
@@ -126,7 +142,7 @@ func (m *MerchantUseCaseImpl) CreateMerchantEmployee(ctxReq context.Context, mer
 	return member.Token, nil
 }
 
-func (m *MerchantUseCaseImpl) CheckingMerchantEmployee(ctxReq context.Context, merchant model.B2CMerchantDataV2, member *memberModel.Member, claims jwt.MapClaims, firstName string) error {
+func (m *MerchantUseCaseImpl) CheckingMerchantEmployee(ctxReq context.Context, merchant model.B2CMerchantDataV2, member *memberModel.Member, claims jwt.MapClaims, firstName, role string) error {
 	// checking merchant employee
 	// get data employee
 	params := &model.QueryMerchantEmployeeParameters{
@@ -149,6 +165,11 @@ func (m *MerchantUseCaseImpl) CheckingMerchantEmployee(ctxReq context.Context, m
 		param.MemberID = member.ID
 		param.CreatedAt = time.Now()
 		param.CreatedBy = claims["sub"].(string)
+		param.Status = helper.TextInvited
+		param.Role = role
+		if param.Role == "" {
+			param.Role = model.MerchantRoleViewer
+		}
 
 		err := m.MerchantEmployeeRepo.Save(param)
 		if err != nil {
@@ -162,10 +183,34 @@ func (m *MerchantUseCaseImpl) CheckingMerchantEmployee(ctxReq context.Context, m
 			}
 		} else {
 			merchantEmployee := mr.Result.(model.B2CMerchantEmployeeData)
+			inviterID := claims["sub"].(string)
+
+			// the role the employee holds has to be manageable too, not only the role given now
+			if access, ok := employeeAccess(ctxReq, inviterID); ok && !access.CanManage(merchantEmployee.Role.String) {
+				return errEmployeeManage
+			}
+
+			m.Repository.StartTransaction()
 			err := m.CheckEmployeeRevoked(ctxReq, merchantEmployee)
 			if err != nil {
+				m.Repository.Rollback()
 				return err
 			}
+
+			// an employee invited again may come back with another role
+			if role != "" && role != merchantEmployee.Role.String {
+				param := model.B2CMerchantEmployee{}
+				param.MerchantID = merchantEmployee.MerchantID
+				param.MemberID = merchantEmployee.MemberID
+				param.ModifiedAt = time.Now()
+				param.ModifiedBy = &inviterID
+				param.Role = role
+				if err := m.MerchantEmployeeRepo.ChangeRole(ctxReq, param); err != nil {
+					m.Repository.Rollback()
+					return err
+				}
+			}
+			m.Repository.Commit()
 		}
 	}
 
@@ -329,7 +374,7 @@ func (m *MerchantUseCaseImpl) GetAllMerchantEmployee(ctxReq context.Context, tok
 		}
 
 		// get merchant
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, claims["sub"].(string))
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, claims["sub"].(string))
 		if merchantResult.Error != nil {
 			err := errors.New(ErrorMerchantNotRegister)
 			tags[helper.TextResponse] = merchantResult.Error
@@ -380,7 +425,7 @@ func (m *MerchantUseCaseImpl) GetMerchantEmployee(ctxReq context.Context, token
 		}
 
 		// get merchant
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, claims["sub"].(string))
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, claims["sub"].(string))
 		if merchantResult.Error != nil {
 			err := errors.New(ErrorMerchantNotRegister)
 			tags[helper.TextResponse] = merchantResult.Error
@@ -426,7 +471,7 @@ func (m *MerchantUseCaseImpl) UpdateMerchantEmployee(ctxReq context.Context, tok
 		}
 
 		// get merchant
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, claims["sub"].(string))
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, claims["sub"].(string))
 		if merchantResult.Error != nil {
 			err := errors.New(ErrorMerchantNotRegister)
 			tags[helper.TextResponse] = merchantResult.Error
@@ -450,6 +495,12 @@ func (m *MerchantUseCaseImpl) UpdateMerchantEmployee(ctxReq context.Context, tok
 		}
 		merchantEmployee := mr.Result.(model.B2CMerchantEmployeeData)
 
+		// an employee manages the employees of a lower role only
+		if access, ok := employeeAccess(ctxReq, claims["sub"].(string)); ok && !access.CanManage(merchantEmployee.Role.String) {
+			output <- ResultUseCase{Error: errEmployeeManage, HTTPStatus: http.StatusForbidden}
+			return
+		}
+
 		// validate status
 		if err := params.ValidateStatus(merchantEmployee.Status.String, params.Status); err != nil {
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
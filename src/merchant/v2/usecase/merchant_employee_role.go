@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"github.com/Bhinneka/user-service/src/merchant/v2/repo"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+// GetMerchantAccess function for resolving the role a member holds on the merchant the member acts on,
+// the owned merchant unless merchantID picks a merchant the member is an active employee of
+func (m *MerchantUseCaseImpl) GetMerchantAccess(ctxReq context.Context, memberID, merchantID string) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-GetMerchantAccess"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMemberIDCamel] = memberID
+		tags[helper.TextMerchantIDCamel] = merchantID
+
+		ownedResult := m.MerchantRepo.FindMerchantByUser(ctxReq, memberID)
+		if ownedResult.Error == nil {
+			owned := ownedResult.Result.(model.B2CMerchantDataV2)
+			if merchantID == "" || merchantID == owned.ID {
+				output <- ResultUseCase{Result: model.NewMerchantAccess(owned.ID, memberID, model.MerchantRoleOwner)}
+				return
+			}
+		}
+
+		params := &model.QueryMerchantEmployeeParameters{
+			MerchantID: merchantID,
+			MemberID:   memberID,
+			Status:     helper.TextActive,
+		}
+		employeeResult := <-m.MerchantEmployeeRepo.GetAllMerchantEmployees(ctxReq, params)
+		if employeeResult.Error != nil {
+			tags[helper.TextResponse] = employeeResult.Error.Error()
+			output <- ResultUseCase{Error: employeeResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		employees := employeeResult.Result.([]model.B2CMerchantEmployeeData)
+
+		switch {
+		case len(employees) == 1:
+			employee := employees[0]
+			output <- ResultUseCase{Result: model.NewMerchantAccess(employee.MerchantID, memberID, employee.Role.String)}
+		case len(employees) > 1:
+			output <- ResultUseCase{Error: errMerchantAccessAmbiguous, HTTPStatus: http.StatusBadRequest}
+		case merchantID != "":
+			output <- ResultUseCase{Error: errMerchantAccessDenied, HTTPStatus: http.StatusForbidden}
+		default:
+			// not a merchant, the routes keep answering as they do for members without a merchant
+			output <- ResultUseCase{Result: model.MerchantAccess{MemberID: memberID}}
+		}
+	})
+	return output
+}
+
+// ChangeMerchantEmployeeRole function for the owner to assign a role to an employee
+func (m *MerchantUseCaseImpl) ChangeMerchantEmployeeRole(ctxReq context.Context, memberID string, input model.MerchantEmployeeRoleInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-ChangeMerchantEmployeeRole"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextMemberIDCamel] = memberID
+		tags[helper.TextArgs] = input
+
+		role := strings.ToUpper(strings.TrimSpace(input.Role))
+		if !model.IsEmployeeRole(role) {
+			output <- ResultUseCase{Error: errEmployeeRole, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+		if merchantResult.Error != nil {
+			tags[helper.TextResponse] = merchantResult.Error.Error()
+			output <- ResultUseCase{Error: errMerchantNotRegister, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+		employeeResult := <-m.MerchantEmployeeRepo.GetMerchantEmployees(ctxReq, &model.QueryMerchantEmployeeParameters{
+			MerchantID: merchant.ID,
+			MemberID:   memberID,
+		})
+		if employeeResult.Error != nil {
+			tags[helper.TextResponse] = employeeResult.Error.Error()
+			output <- ResultUseCase{Error: errEmployeeNotFound, HTTPStatus: http.StatusNotFound}
+			return
+		}
+		employee := employeeResult.Result.(model.B2CMerchantEmployeeData)
+		if employee.Status.String == helper.TextRevoked {
+			output <- ResultUseCase{Error: errEmployeeRevoked, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		modifiedBy := userAttribute.UserID
+		payload := model.B2CMerchantEmployee{
+			MerchantID: merchant.ID,
+			MemberID:   memberID,
+			ModifiedAt: time.Now(),
+			ModifiedBy: &modifiedBy,
+			Role:       role,
+		}
+		if err := m.MerchantEmployeeRepo.ChangeRole(ctxReq, payload); err != nil {
+			tags[helper.TextResponse] = err.Error()
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		employee.Role = zero.StringFrom(role)
+		output <- ResultUseCase{Result: employee}
+	})
+	return output
+}
+
+// SendMerchantOwnershipOTP function for sending the owner a one time code by email or sms to confirm an ownership transfer
+func (m *MerchantUseCaseImpl) SendMerchantOwnershipOTP(ctxReq context.Context, input model.MerchantOwnershipOTPInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-SendMerchantOwnershipOTP"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags["method"] = input.Method
+
+		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+		if merchantResult.Error != nil {
+			tags[helper.TextResponse] = merchantResult.Error.Error()
+			output <- ResultUseCase{Error: errMerchantNotRegister, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+		if !memberModel.IsMFAOTPMethod(input.Method) || m.MFAOTP == nil {
+			output <- ResultUseCase{Error: errors.New(memberModel.ErrorMFAMethod), HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		enrolled, err := m.findMFAMethod(ctxReq, userAttribute.UserID, input.Method)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		if err := m.MFAOTP.Send(ctxReq, input.Method, userAttribute.UserID, ownershipOTPScope(merchant.ID), enrolled.Target); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: mfaotp.HTTPStatus(err)}
+			return
+		}
+
+		output <- ResultUseCase{Result: memberModel.SuccessMFAOTPSend}
+	})
+	return output
+}
+
+// TransferMerchantOwnership function for the owner to hand the merchant over to an active employee, confirmed with
+// the second factor of the owner. The former owner stays on the merchant as an admin and both owners are told.
+// The merchant email moves to the new owner only when it is the login email of the former owner,
+// a shared mailbox of the company stays
+func (m *MerchantUseCaseImpl) TransferMerchantOwnership(ctxReq context.Context, input model.MerchantOwnershipInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
+	ctx := "MerchantUseCase-TransferMerchantOwnership"
+	output := make(chan ResultUseCase)
+	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
+		defer close(output)
+		tags[helper.TextArgs] = input
+
+		newOwnerID := strings.TrimSpace(input.MemberID)
+		if newOwnerID == "" || newOwnerID == userAttribute.UserID {
+			output <- ResultUseCase{Error: errOwnershipTarget, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
+		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+		if merchantResult.Error != nil {
+			tags[helper.TextResponse] = merchantResult.Error.Error()
+			output <- ResultUseCase{Error: errMerchantNotRegister, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+		merchant := merchantResult.Result.(model.B2CMerchantDataV2)
+
+		employeeResult := <-m.MerchantEmployeeRepo.GetMerchantEmployees(ctxReq, &model.QueryMerchantEmployeeParameters{
+			MerchantID: merchant.ID,
+			MemberID:   newOwnerID,
+		})
+		if employeeResult.Error != nil {
+			tags[helper.TextResponse] = employeeResult.Error.Error()
+			output <- ResultUseCase{Error: errEmployeeNotFound, HTTPStatus: http.StatusNotFound}
+			return
+		}
+		employee := employeeResult.Result.(model.B2CMerchantEmployeeData)
+		if employee.Status.String != helper.TextActive {
+			output <- ResultUseCase{Error: errOwnershipNotActive, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		// a member owns one merchant at most
+		if ownedResult := m.MerchantRepo.FindMerchantByUser(ctxReq, newOwnerID); ownedResult.Error == nil {
+			output <- ResultUseCase{Error: errOwnershipTaken, HTTPStatus: http.StatusConflict}
+			return
+		}
+
+		// checked last so a code is not spent on a transfer refused anyway
+		if status, err := m.verifyOwnerMFA(ctxReq, userAttribute.UserID, ownershipOTPScope(merchant.ID), input.Method, input.OTP); err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: status}
+			return
+		}
+
+		formerOwnerResult := <-m.MemberQueryRead.FindByID(ctxReq, userAttribute.UserID)
+		formerOwnerMember, _ := formerOwnerResult.Result.(memberModel.Member)
+		newOwnerResult := <-m.MemberQueryRead.FindByID(ctxReq, newOwnerID)
+		newOwnerMember, ok := newOwnerResult.Result.(memberModel.Member)
+		if newOwnerResult.Error != nil || !ok {
+			output <- ResultUseCase{Error: errEmployeeNotFound, HTTPStatus: http.StatusNotFound}
+			return
+		}
+
+		now := time.Now()
+		after := merchant
+		after.UserID = newOwnerID
+		if formerOwnerMember.Email != "" && strings.EqualFold(merchant.MerchantEmail.String, formerOwnerMember.Email) {
+			after.MerchantEmail = zero.StringFrom(newOwnerMember.Email)
+		}
+		after.EditorID = null.StringFrom(userAttribute.UserID)
+		after.EditorIP = null.StringFrom(userAttribute.UserIP)
+		after.LastModified = null.TimeFrom(now)
+		after.Version = zero.IntFrom(after.Version.ValueOrZero() + 1)
+
+		m.Repository.StartTransaction()
+		if updateResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, after); updateResult.Error != nil {
+			m.Repository.Rollback()
+			tags[helper.TextResponse] = updateResult.Error.Error()
+			output <- ResultUseCase{Error: updateResult.Error, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		modifiedBy := userAttribute.UserID
+		newOwner := model.B2CMerchantEmployee{
+			MerchantID: merchant.ID,
+			MemberID:   newOwnerID,
+			ModifiedAt: now,
+			ModifiedBy: &modifiedBy,
+			Status:     helper.TextRevoked,
+		}
+		if err := m.MerchantEmployeeRepo.ChangeStatus(ctxReq, newOwner); err != nil {
+			m.Repository.Rollback()
+			tags[helper.TextResponse] = err.Error()
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+
+		formerOwner := model.B2CMerchantEmployee{
+			ID:         "EMP" + now.Format(helper.FormatYmdhis),
+			MerchantID: merchant.ID,
+			MemberID:   userAttribute.UserID,
+			CreatedAt:  now,
+			CreatedBy:  userAttribute.UserID,
+			Status:     helper.TextActive,
+			Role:       model.MerchantRoleAdmin,
+		}
+		if err := m.MerchantEmployeeRepo.Save(formerOwner); err != nil {
+			m.Repository.Rollback()
+			tags[helper.TextResponse] = err.Error()
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusInternalServerError}
+			return
+		}
+		m.Repository.Commit()
+
+		plLog := model.MerchantLog{
+			Before: merchant,
+			After:  after,
+		}
+		go m.QueuePublisher.QueueJob(ctxReq, plLog, merchant.ID, "InsertLogMerchantUpdate")
+		go func() {
+			m.PublishToKafkaMerchant(ctxReq, after, helper.EventProduceUpdateMerchant)
+		}()
+
+		transfer := &model.MerchantOwnershipTransfer{
+			FormerOwnerName:  strings.TrimSpace(formerOwnerMember.FirstName + " " + formerOwnerMember.LastName),
+			FormerOwnerEmail: formerOwnerMember.Email,
+			NewOwnerName:     strings.TrimSpace(newOwnerMember.FirstName + " " + newOwnerMember.LastName),
+			NewOwnerEmail:    newOwnerMember.Email,
+			Transferred:      now,
+		}
+		plQueue := model.MerchantPayloadEmail{
+			MemberName:        after.MerchantName,
+			Data:              after,
+			OwnershipTransfer: transfer,
+		}
+		go m.QueuePublisher.QueueJob(ctxReq, plQueue, merchant.ID, "SendEmailMerchantOwnershipTransfer")
+
+		output <- ResultUseCase{Result: after}
+	})
+	return output
+}
+
+// ownershipOTPScope scope of the one time code confirming an ownership transfer of the merchant
+func ownershipOTPScope(merchantID string) string {
+	return fmt.Sprintf("merchant-ownership-%s", merchantID)
+}
+
+// findActingMerchant loads the merchant the member acts on, the merchant of the employee access of the request
+// or else the merchant the member owns
+func findActingMerchant(ctxReq context.Context, merchantRepo repo.MerchantRepository, memberID string) repo.ResultRepository {
+	if access, ok := employeeAccess(ctxReq, memberID); ok {
+		return merchantRepo.LoadMerchant(ctxReq, access.MerchantID, private)
+	}
+	return merchantRepo.FindMerchantByUser(ctxReq, memberID)
+}
+
+// employeeAccess returns the access of the request when the member acts on the merchant as an employee
+func employeeAccess(ctxReq context.Context, memberID string) (model.MerchantAccess, bool) {
+	access, ok := model.MerchantAccessFromContext(ctxReq)
+	if !ok || access.MemberID != memberID || !access.IsEmployee() {
+		return model.MerchantAccess{}, false
+	}
+	return access, true
+}
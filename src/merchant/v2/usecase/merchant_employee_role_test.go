@@ -0,0 +1,481 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	localConfig "github.com/Bhinneka/user-service/config"
+	"github.com/Bhinneka/user-service/helper"
+	mockEmployeeRepo "github.com/Bhinneka/user-service/mocks/src/merchant/v2/repo"
+	mockToken "github.com/Bhinneka/user-service/src/auth/v1/token/mocks"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	memberQuery "github.com/Bhinneka/user-service/src/member/v1/query"
+	mockMemberQuery "github.com/Bhinneka/user-service/src/member/v1/query/mocks"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	merchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo"
+	mockMerchantRepo "github.com/Bhinneka/user-service/src/merchant/v2/repo/mocks"
+	serviceMock "github.com/Bhinneka/user-service/src/service/mocks"
+	"github.com/Bhinneka/user-service/src/shared/repository"
+	sharedMock "github.com/Bhinneka/user-service/src/shared/repository/mocks"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	sqlMock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+const (
+	defOwnerID    = "USR001"
+	defEmployeeID = "USR002"
+)
+
+func roleEmployee(status, role string) model.B2CMerchantEmployeeData {
+	return model.B2CMerchantEmployeeData{
+		MerchantID: defaultMerchantID,
+		MemberID:   defEmployeeID,
+		Status:     zero.StringFrom(status),
+		Role:       zero.StringFrom(role),
+	}
+}
+
+func TestGetMerchantAccess(t *testing.T) {
+	owned := merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID, UserID: defEmployeeID}}
+	notOwner := merchantRepo.ResultRepository{Error: errDefault}
+
+	tests := []struct {
+		name           string
+		merchantID     string
+		ownedResult    merchantRepo.ResultRepository
+		employeeResult merchantRepo.ResultRepository
+		wantAccess     model.MerchantAccess
+		wantStatus     int
+	}{
+		{
+			name:        "Case 1: owner of the merchant",
+			ownedResult: owned,
+			wantAccess:  model.NewMerchantAccess(defaultMerchantID, defEmployeeID, model.MerchantRoleOwner),
+		},
+		{
+			name:           "Case 2: active employee of one merchant",
+			ownedResult:    notOwner,
+			employeeResult: merchantRepo.ResultRepository{Result: []model.B2CMerchantEmployeeData{roleEmployee(helper.TextActive, model.MerchantRoleFinance)}},
+			wantAccess:     model.NewMerchantAccess(defaultMerchantID, defEmployeeID, model.MerchantRoleFinance),
+		},
+		{
+			name:           "Case 3: owner picking a merchant the owner does not work at",
+			merchantID:     "MCH002",
+			ownedResult:    owned,
+			employeeResult: merchantRepo.ResultRepository{Result: []model.B2CMerchantEmployeeData{}},
+			wantStatus:     http.StatusForbidden,
+		},
+		{
+			name:           "Case 4: member without merchant",
+			ownedResult:    notOwner,
+			employeeResult: merchantRepo.ResultRepository{Result: []model.B2CMerchantEmployeeData{}},
+			wantAccess:     model.MerchantAccess{MemberID: defEmployeeID},
+		},
+		{
+			name:        "Case 5: employee of several merchants without header",
+			ownedResult: notOwner,
+			employeeResult: merchantRepo.ResultRepository{Result: []model.B2CMerchantEmployeeData{
+				roleEmployee(helper.TextActive, model.MerchantRoleAdmin), roleEmployee(helper.TextActive, model.MerchantRoleViewer),
+			}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 6: failed load employees",
+			ownedResult:    notOwner,
+			employeeResult: merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			employeeRepoMock := mockEmployeeRepo.MerchantEmployeeRepository{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantRepository:         &merchantRepoMock,
+				MerchantEmployeeRepository: &employeeRepoMock,
+			}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &mockToken.AccessTokenGenerator{}, localConfig.ServiceQuery{})
+
+			merchantRepoMock.On("FindMerchantByUser", mock.Anything, defEmployeeID).Return(tt.ownedResult)
+			employeeRepoMock.On("GetAllMerchantEmployees", mock.Anything, &model.QueryMerchantEmployeeParameters{
+				MerchantID: tt.merchantID,
+				MemberID:   defEmployeeID,
+				Status:     helper.TextActive,
+			}).Return(generateRepoResult(tt.employeeResult))
+
+			ucResult := <-m.GetMerchantAccess(context.Background(), defEmployeeID, tt.merchantID)
+			if tt.wantStatus != 0 {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantStatus, ucResult.HTTPStatus)
+				return
+			}
+			assert.NoError(t, ucResult.Error)
+			assert.Equal(t, tt.wantAccess, ucResult.Result)
+		})
+	}
+}
+
+func TestChangeMerchantEmployeeRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		ownedResult    merchantRepo.ResultRepository
+		employeeResult merchantRepo.ResultRepository
+		changeErr      error
+		wantStatus     int
+	}{
+		{
+			name:           "Case 1: success",
+			role:           " finance ",
+			ownedResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID}},
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleViewer)},
+		},
+		{
+			name:       "Case 2: owner role is not assignable",
+			role:       model.MerchantRoleOwner,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Case 3: member does not own a merchant",
+			role:        model.MerchantRoleAdmin,
+			ownedResult: merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:           "Case 4: employee not found",
+			role:           model.MerchantRoleAdmin,
+			ownedResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID}},
+			employeeResult: merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:     http.StatusNotFound,
+		},
+		{
+			name:           "Case 5: employee revoked",
+			role:           model.MerchantRoleAdmin,
+			ownedResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID}},
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextRevoked, model.MerchantRoleViewer)},
+			wantStatus:     http.StatusBadRequest,
+		},
+		{
+			name:           "Case 6: failed change role",
+			role:           model.MerchantRoleAdmin,
+			ownedResult:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID}},
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleViewer)},
+			changeErr:      errDefault,
+			wantStatus:     http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			employeeRepoMock := mockEmployeeRepo.MerchantEmployeeRepository{}
+			svcRepo := localConfig.ServiceRepository{
+				MerchantRepository:         &merchantRepoMock,
+				MerchantEmployeeRepository: &employeeRepoMock,
+			}
+			m := NewMerchantUseCase(svcRepo, localConfig.ServiceShared{}, &mockToken.AccessTokenGenerator{}, localConfig.ServiceQuery{})
+
+			merchantRepoMock.On("FindMerchantByUser", mock.Anything, defOwnerID).Return(tt.ownedResult)
+			employeeRepoMock.On("GetMerchantEmployees", mock.Anything, mock.Anything).Return(generateRepoResult(tt.employeeResult))
+			employeeRepoMock.On("ChangeRole", mock.Anything, mock.MatchedBy(func(params model.B2CMerchantEmployee) bool {
+				return params.MerchantID == defaultMerchantID && params.MemberID == defEmployeeID && model.IsEmployeeRole(params.Role)
+			})).Return(tt.changeErr)
+
+			input := model.MerchantEmployeeRoleInput{Role: tt.role}
+			ucResult := <-m.ChangeMerchantEmployeeRole(context.Background(), defEmployeeID, input, &model.MerchantUserAttribute{UserID: defOwnerID})
+			if tt.wantStatus != 0 {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantStatus, ucResult.HTTPStatus)
+				return
+			}
+			assert.NoError(t, ucResult.Error)
+			employee, ok := ucResult.Result.(model.B2CMerchantEmployeeData)
+			assert.True(t, ok)
+			assert.Equal(t, model.MerchantRoleFinance, employee.Role.String)
+		})
+	}
+}
+
+func TestCheckingMerchantEmployeeReinvite(t *testing.T) {
+	ownerCtx := context.Background()
+	adminCtx := model.NewMerchantAccessContext(context.Background(), model.NewMerchantAccess(defaultMerchantID, "USR003", model.MerchantRoleAdmin))
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		inviterID  string
+		role       string
+		employee   model.B2CMerchantEmployeeData
+		changeErr  error
+		wantErr    error
+		wantChange bool
+	}{
+		{
+			name:       "Case 1: owner invites a revoked admin back as catalog",
+			ctx:        ownerCtx,
+			inviterID:  defOwnerID,
+			role:       model.MerchantRoleCatalog,
+			employee:   roleEmployee(helper.TextRevoked, model.MerchantRoleAdmin),
+			wantChange: true,
+		},
+		{
+			name:      "Case 2: admin can not invite a revoked admin back",
+			ctx:       adminCtx,
+			inviterID: "USR003",
+			role:      model.MerchantRoleViewer,
+			employee:  roleEmployee(helper.TextRevoked, model.MerchantRoleAdmin),
+			wantErr:   errEmployeeManage,
+		},
+		{
+			name:       "Case 3: admin invites a revoked viewer back as catalog",
+			ctx:        adminCtx,
+			inviterID:  "USR003",
+			role:       model.MerchantRoleCatalog,
+			employee:   roleEmployee(helper.TextRevoked, model.MerchantRoleViewer),
+			wantChange: true,
+		},
+		{
+			name:       "Case 4: failed change role",
+			ctx:        ownerCtx,
+			inviterID:  defOwnerID,
+			role:       model.MerchantRoleCatalog,
+			employee:   roleEmployee(helper.TextRevoked, model.MerchantRoleViewer),
+			changeErr:  errDefault,
+			wantErr:    errDefault,
+			wantChange: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			employeeRepoMock := mockEmployeeRepo.MerchantEmployeeRepository{}
+			mockDB, sqlExpect, _ := sqlMock.New()
+			defer mockDB.Close()
+
+			m := &MerchantUseCaseImpl{
+				Repository:           &repository.Repository{WriteDB: mockDB},
+				MerchantEmployeeRepo: &employeeRepoMock,
+			}
+
+			employeeRepoMock.On("GetMerchantEmployees", mock.Anything, mock.Anything).Return(generateRepoResult(merchantRepo.ResultRepository{Result: tt.employee}))
+			employeeRepoMock.On("ChangeStatus", mock.Anything, mock.Anything).Return(nil)
+			employeeRepoMock.On("ChangeRole", mock.Anything, mock.Anything).Return(tt.changeErr)
+			if tt.wantErr != errEmployeeManage {
+				sqlExpect.ExpectBegin()
+				if tt.wantErr != nil {
+					sqlExpect.ExpectRollback()
+				} else {
+					sqlExpect.ExpectCommit()
+				}
+			}
+
+			merchant := model.B2CMerchantDataV2{ID: defaultMerchantID}
+			member := &memberModel.Member{ID: defEmployeeID}
+			claims := jwt.MapClaims{"sub": tt.inviterID}
+			err := m.CheckingMerchantEmployee(tt.ctx, merchant, member, claims, "John", tt.role)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, sqlExpect.ExpectationsWereMet())
+
+			if !tt.wantChange {
+				employeeRepoMock.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything)
+				employeeRepoMock.AssertNotCalled(t, "ChangeRole", mock.Anything, mock.Anything)
+				return
+			}
+			employeeRepoMock.AssertCalled(t, "ChangeRole", mock.Anything, mock.MatchedBy(func(params model.B2CMerchantEmployee) bool {
+				return params.MemberID == defEmployeeID && params.Role == tt.role && params.ModifiedBy != nil && *params.ModifiedBy == tt.inviterID
+			}))
+		})
+	}
+}
+
+func TestTransferMerchantOwnership(t *testing.T) {
+	const (
+		ownerEmail    = "owner@bhinneka.com"
+		employeeEmail = "employee@bhinneka.com"
+	)
+	owned := merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID, UserID: defOwnerID, MerchantEmail: zero.StringFrom(ownerEmail)}}
+	owner := memberModel.Member{ID: defOwnerID, Email: ownerEmail, MFAEnabled: true, MFAKey: memberModel.StaticSharedMfaKeyForDev}
+	employee := memberModel.Member{ID: defEmployeeID, Email: employeeEmail}
+
+	tests := []struct {
+		name           string
+		memberID       string
+		otp            string
+		owned          merchantRepo.ResultRepository
+		owner          memberModel.Member
+		employeeResult merchantRepo.ResultRepository
+		targetOwned    merchantRepo.ResultRepository
+		updateResult   merchantRepo.ResultRepository
+		wantEmail      string
+		wantStatus     int
+	}{
+		{
+			name:           "Case 1: success, the merchant email of the owner moves",
+			memberID:       defEmployeeID,
+			otp:            memberModel.StaticOTPMfaForDev,
+			owned:          owned,
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Error: errDefault},
+			wantEmail:      employeeEmail,
+		},
+		{
+			name:       "Case 2: transfer to self",
+			memberID:   defOwnerID,
+			otp:        memberModel.StaticOTPMfaForDev,
+			owned:      owned,
+			owner:      owner,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Case 3: employee not active",
+			memberID:       defEmployeeID,
+			otp:            memberModel.StaticOTPMfaForDev,
+			owned:          owned,
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextInvited, model.MerchantRoleAdmin)},
+			wantStatus:     http.StatusConflict,
+		},
+		{
+			name:           "Case 4: employee already owns a merchant",
+			memberID:       defEmployeeID,
+			otp:            memberModel.StaticOTPMfaForDev,
+			owned:          owned,
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "MCH002"}},
+			wantStatus:     http.StatusConflict,
+		},
+		{
+			name:           "Case 5: failed update merchant",
+			memberID:       defEmployeeID,
+			otp:            memberModel.StaticOTPMfaForDev,
+			owned:          owned,
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Error: errDefault},
+			updateResult:   merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:     http.StatusInternalServerError,
+		},
+		{
+			name:           "Case 6: missing otp",
+			memberID:       defEmployeeID,
+			owned:          owned,
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:     http.StatusBadRequest,
+		},
+		{
+			name:           "Case 7: invalid otp",
+			memberID:       defEmployeeID,
+			otp:            "000000",
+			owned:          owned,
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:     http.StatusBadRequest,
+		},
+		{
+			name:           "Case 8: owner without multi factor authentication",
+			memberID:       defEmployeeID,
+			otp:            memberModel.StaticOTPMfaForDev,
+			owned:          owned,
+			owner:          memberModel.Member{ID: defOwnerID, Email: ownerEmail},
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Error: errDefault},
+			wantStatus:     http.StatusForbidden,
+		},
+		{
+			name:     "Case 9: success, a company merchant email stays",
+			memberID: defEmployeeID,
+			otp:      memberModel.StaticOTPMfaForDev,
+			owned: merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{
+				ID: defaultMerchantID, UserID: defOwnerID, MerchantEmail: zero.StringFrom("finance@company.com"),
+			}},
+			owner:          owner,
+			employeeResult: merchantRepo.ResultRepository{Result: roleEmployee(helper.TextActive, model.MerchantRoleAdmin)},
+			targetOwned:    merchantRepo.ResultRepository{Error: errDefault},
+			wantEmail:      "finance@company.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+			employeeRepoMock := mockEmployeeRepo.MerchantEmployeeRepository{}
+			memberQueryMock := mockMemberQuery.MemberQuery{}
+			mockDB, _, _ := sqlMock.New()
+			defer mockDB.Close()
+
+			svcRepo := localConfig.ServiceRepository{
+				Repository:                 &repository.Repository{WriteDB: mockDB},
+				MerchantRepository:         &merchantRepoMock,
+				MerchantEmployeeRepository: &employeeRepoMock,
+			}
+			publisher := serviceMock.QPublisher{}
+			merchantService := serviceMock.MerchantServices{}
+			svcShared := localConfig.ServiceShared{
+				QPublisher:      &publisher,
+				MerchantService: &merchantService,
+			}
+			m := NewMerchantUseCase(svcRepo, svcShared, &mockToken.AccessTokenGenerator{}, localConfig.ServiceQuery{MemberQueryRead: &memberQueryMock})
+
+			merchantRepoMock.On("FindMerchantByUser", mock.Anything, defOwnerID).Return(tt.owned)
+			merchantRepoMock.On("FindMerchantByUser", mock.Anything, defEmployeeID).Return(tt.targetOwned)
+			merchantRepoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(tt.updateResult))
+			employeeRepoMock.On("GetMerchantEmployees", mock.Anything, mock.Anything).Return(generateRepoResult(tt.employeeResult))
+			employeeRepoMock.On("ChangeStatus", mock.Anything, mock.Anything).Return(nil)
+			employeeRepoMock.On("Save", mock.Anything).Return(nil)
+			memberQueryMock.On("FindByID", mock.Anything, defOwnerID).Return(func(context.Context, string) <-chan memberQuery.ResultQuery {
+				return sharedMock.MemberQueryResult(memberQuery.ResultQuery{Result: tt.owner})
+			})
+			memberQueryMock.On("FindByID", mock.Anything, defEmployeeID).Return(func(context.Context, string) <-chan memberQuery.ResultQuery {
+				return sharedMock.MemberQueryResult(memberQuery.ResultQuery{Result: employee})
+			})
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailMerchantOwnershipTransfer").Return(nil)
+			merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			input := model.MerchantOwnershipInput{MemberID: tt.memberID, OTP: tt.otp}
+			ucResult := <-m.TransferMerchantOwnership(context.Background(), input, &model.MerchantUserAttribute{UserID: defOwnerID})
+			if tt.wantStatus != 0 {
+				assert.Error(t, ucResult.Error)
+				assert.Equal(t, tt.wantStatus, ucResult.HTTPStatus)
+				employeeRepoMock.AssertNotCalled(t, "Save", mock.Anything)
+				return
+			}
+
+			assert.NoError(t, ucResult.Error)
+			merchant, ok := ucResult.Result.(model.B2CMerchantDataV2)
+			assert.True(t, ok)
+			assert.Equal(t, defEmployeeID, merchant.UserID)
+			assert.Equal(t, tt.wantEmail, merchant.MerchantEmail.String)
+			employeeRepoMock.AssertCalled(t, "ChangeStatus", mock.Anything, mock.MatchedBy(func(params model.B2CMerchantEmployee) bool {
+				return params.MemberID == defEmployeeID && params.Status == helper.TextRevoked
+			}))
+			employeeRepoMock.AssertCalled(t, "Save", mock.MatchedBy(func(params model.B2CMerchantEmployee) bool {
+				return params.MemberID == defOwnerID && params.Role == model.MerchantRoleAdmin && params.Status == helper.TextActive
+			}))
+		})
+	}
+}
+
+func TestFindActingMerchant(t *testing.T) {
+	merchantRepoMock := mockMerchantRepo.MerchantRepository{}
+	merchantRepoMock.On("LoadMerchant", mock.Anything, defaultMerchantID, private).Return(merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: defaultMerchantID}})
+	merchantRepoMock.On("FindMerchantByUser", mock.Anything, defOwnerID).Return(merchantRepo.ResultRepository{Result: model.B2CMerchantDataV2{ID: "MCH002"}})
+
+	employeeCtx := model.NewMerchantAccessContext(context.Background(), model.NewMerchantAccess(defaultMerchantID, defEmployeeID, model.MerchantRoleWarehouse))
+	result := findActingMerchant(employeeCtx, &merchantRepoMock, defEmployeeID)
+	assert.Equal(t, defaultMerchantID, result.Result.(model.B2CMerchantDataV2).ID)
+
+	// the access of another member is ignored
+	result = findActingMerchant(employeeCtx, &merchantRepoMock, defOwnerID)
+	assert.Equal(t, "MCH002", result.Result.(model.B2CMerchantDataV2).ID)
+}
@@ -22,7 +22,7 @@ func (m *MerchantUseCaseImpl) GetMerchantByUserID(ctxReq context.Context, userID
 	go tracer.WithTraceFunc(ctxReq, ctx, func(ctxReq context.Context, tags map[string]interface{}) {
 		defer close(output)
 		available := model.ResponseAvailable{}
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userID)
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, userID)
 		if merchantResult.Result == nil {
 			err := fmt.Errorf("Merchant not found")
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusNotFound}
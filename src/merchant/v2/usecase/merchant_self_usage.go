@@ -104,6 +104,13 @@ func (m *MerchantUseCaseImpl) AddMerchant(ctxReq context.Context, data *model.B2
 		merchant.Status = data.Status
 		merchant.CountUpdateNameAvailable = 1
 
+		changes, err := planMerchantRegistration(merchant)
+		if err != nil {
+			tags[helper.TextResponse] = err
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
 		m.Repository.StartTransaction()
 		saveResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, merchant)
 		if saveResult.Error != nil {
@@ -140,6 +147,11 @@ func (m *MerchantUseCaseImpl) AddMerchant(ctxReq context.Context, data *model.B2
 		}
 
 		merchant.Documents = documentProccess.Result.([]model.B2CMerchantDocumentData)
+		if err := m.applyMerchantTransitions(ctxReq, changes, merchantChangeMeta{Actor: userAttribute}); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			m.Repository.Rollback()
+			return
+		}
 		memberName := memberData.FirstName + " " + memberData.LastName
 
 		plQueue := model.MerchantPayloadEmail{
@@ -156,6 +168,7 @@ func (m *MerchantUseCaseImpl) AddMerchant(ctxReq context.Context, data *model.B2
 		go m.MerchantService.PublishToKafkaUserMerchant(ctxReq, &merchant, helper.EventProduceCreateMerchant, producerSturgeon)
 
 		m.Repository.Commit()
+		m.requestBankVerification(ctxReq, model.B2CMerchantDataV2{}, merchant, userAttribute.UserID)
 
 		tags[helper.TextResponse] = merchant
 		output <- ResultUseCase{Result: merchant}
@@ -199,10 +212,6 @@ func (m *MerchantUseCaseImpl) UpgradeMerchant(ctxReq context.Context, data *mode
 		}
 
 		merchant := merchantData.Result.(model.B2CMerchantDataV2)
-		if !merchant.IsActive {
-			output <- ResultUseCase{Error: errors.New("your merchant is not active"), HTTPStatus: http.StatusBadRequest}
-			return
-		}
 		if err := CheckUpgradeStatusBeforeSelfUpgrade(merchant); err != nil {
 			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
 			return
@@ -222,6 +231,12 @@ func (m *MerchantUseCaseImpl) UpgradeMerchant(ctxReq context.Context, data *mode
 		merchant.LegalEntity = zero.IntFrom(int64(data.LegalEntity))
 		merchant.NumberOfEmployee = zero.IntFrom(int64(data.NumberOfEmployee))
 
+		changes, err := planMerchantTransitions(oldMerchant, merchant, model.MerchantEventRequestUpgrade)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
+
 		m.Repository.StartTransaction()
 		saveResult := <-m.MerchantRepo.AddUpdateMerchant(ctxReq, merchant)
 		if saveResult.Error != nil {
@@ -240,16 +255,22 @@ func (m *MerchantUseCaseImpl) UpgradeMerchant(ctxReq context.Context, data *mode
 
 		merchant.Documents = documentProccess.Result.([]model.B2CMerchantDocumentData)
 
+		// status history and upgrade email
+		meta := merchantChangeMeta{
+			Actor:      userAttribute,
+			MemberName: memberData.FirstName + " " + memberData.LastName,
+		}
+		if err := m.applyMerchantTransitions(ctxReq, changes, meta); err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			m.Repository.Rollback()
+			return
+		}
+
 		plLog := model.MerchantLog{
 			Before: oldMerchant,
 			After:  merchant,
 		}
-		plQueue := model.MerchantPayloadEmail{
-			MemberName: memberData.FirstName + " " + memberData.LastName,
-			Data:       merchant,
-		}
 
-		go m.QueuePublisher.QueueJob(ctxReq, plQueue, merchant.ID, "SendEmailMerchantUpgrade")
 		go m.QueuePublisher.QueueJob(ctxReq, plLog, merchant.ID, "InsertLogMerchantUpdate")
 		go func() {
 			m.PublishToKafkaMerchant(ctxReq, merchant, helper.EventProduceUpdateMerchant)
@@ -285,7 +306,7 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchant(ctxReq context.Context, input *
 		defer close(output)
 		input := checkIsActiveStatus(input)
 
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, userAttribute.UserID)
 		if merchantResult.Error != nil {
 			tags[helper.TextResponse] = merchantResult.Error.Error()
 			output <- ResultUseCase{Error: merchantResult.Error, HTTPStatus: http.StatusBadRequest}
@@ -307,6 +328,7 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchant(ctxReq context.Context, input *
 		}
 
 		input.MerchantEmail = currentData.MerchantEmail.String
+		input.UserID = currentData.UserID
 		m.GetCorporateData(&currentData, input)
 
 		currentData.SetMerchantData(input)
@@ -324,7 +346,8 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchant(ctxReq context.Context, input *
 		currentData.MerchantTypeString = zero.StringFrom(input.MerchantTypeString)
 		currentData.MerchantType = model.StringToMerchantType(input.MerchantTypeString)
 
-		currentData = checkIsActiveStatusV2(currentData, oldData)
+		currentData = keepMerchantLifecycle(currentData, oldData)
+		bankChange := holdMerchantBank(oldData, &currentData, userAttribute.UserID, time.Now())
 
 		//Store Address
 		currentData.StoreAddress = zero.StringFrom(input.StoreAddress)
@@ -382,6 +405,20 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchant(ctxReq context.Context, input *
 			currentData.Documents = documentProccess.Result.([]model.B2CMerchantDocumentData)
 		}
 
+		if err := m.resetIdentityDocumentReview(ctxReq, oldData, currentData); err != nil {
+			output <- ResultUseCase{Error: errors.New(model.MerchantFailedUpdateError), HTTPStatus: http.StatusBadRequest}
+			m.Repository.Rollback()
+			return
+		}
+
+		if bankChange != nil {
+			if err := m.saveBankChange(ctxReq, bankChange); err != nil {
+				output <- ResultUseCase{Error: errors.New(model.MerchantFailedUpdateError), HTTPStatus: http.StatusBadRequest}
+				m.Repository.Rollback()
+				return
+			}
+		}
+
 		params.UserID = currentData.ID
 		params.NickName = currentData.MerchantName
 		params.ProfileURL = currentData.MerchantLogo.String
@@ -398,6 +435,11 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchant(ctxReq context.Context, input *
 		}()
 
 		m.Repository.Commit()
+		m.requestBankVerification(ctxReq, oldData, currentData, userAttribute.UserID)
+		if bankChange != nil {
+			currentData.PendingBankChange = bankChange
+			m.notifyBankChange(ctxReq, currentData, bankChange)
+		}
 
 		output <- ResultUseCase{Result: currentData}
 	})
@@ -431,28 +473,6 @@ func checkIsActiveStatus(input *model.B2CMerchantCreateInput) *model.B2CMerchant
 	return input
 }
 
-func checkIsActiveStatusV2(currentData model.B2CMerchantDataV2, oldData model.B2CMerchantDataV2) model.B2CMerchantDataV2 {
-	if currentData.Status == model.NewString {
-		switch currentData.IsActive {
-		case true:
-			currentData.Status = model.ActiveString
-		default:
-			currentData.Status = oldData.Status
-			if currentData.Status == model.ActiveString {
-				currentData.Status = model.InactiveString
-			}
-		}
-	} else {
-		switch currentData.Status {
-		case model.ActiveString:
-			currentData.IsActive = true
-		default:
-			currentData.IsActive = false
-		}
-	}
-	return currentData
-}
-
 func (m *MerchantUseCaseImpl) SelfUpdateMerchantPartial(ctxReq context.Context, input *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase {
 	ctx := "MerchantUseCase-SelfUpdateMerchantPartial"
 	output := make(chan ResultUseCase)
@@ -467,7 +487,7 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchantPartial(ctxReq context.Context,
 			return
 		}
 
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, userAttribute.UserID)
 		if merchantResult.Error != nil {
 			tags[helper.TextResponse] = merchantResult.Error.Error()
 			output <- ResultUseCase{Error: merchantResult.Error, HTTPStatus: http.StatusBadRequest}
@@ -487,6 +507,7 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchantPartial(ctxReq context.Context,
 		}
 		input = CheckEmptyMerchantInput(oldData, input)
 		input.MerchantEmail = currentData.MerchantEmail.String
+		input.UserID = currentData.UserID
 		m.GetCorporateData(&currentData, input)
 		currentData.SetMerchantData(input)
 		currentData.EditorID = null.StringFrom(userAttribute.UserID)
@@ -517,8 +538,9 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchantPartial(ctxReq context.Context,
 		currentData.StoreZipCode = zero.StringFrom(input.StoreZipCode)
 		currentData.Documents = oldData.Documents
 
-		currentData = checkIsActiveStatusV2(currentData, oldData)
+		currentData = keepMerchantLifecycle(currentData, oldData)
 		currentData = CheckEmptyCurrentData(currentData, oldData)
+		bankChange := holdMerchantBank(oldData, &currentData, userAttribute.UserID, time.Now())
 
 		// set disallow field
 		currentData.MerchantName = input.MerchantName
@@ -563,6 +585,20 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchantPartial(ctxReq context.Context,
 			currentData.Documents = documentProccess.Result.([]model.B2CMerchantDocumentData)
 		}
 
+		if err := m.resetIdentityDocumentReview(ctxReq, oldData, currentData); err != nil {
+			output <- ResultUseCase{Error: errors.New(model.MerchantFailedUpdateError), HTTPStatus: http.StatusBadRequest}
+			m.Repository.Rollback()
+			return
+		}
+
+		if bankChange != nil {
+			if err := m.saveBankChange(ctxReq, bankChange); err != nil {
+				output <- ResultUseCase{Error: errors.New(model.MerchantFailedUpdateError), HTTPStatus: http.StatusBadRequest}
+				m.Repository.Rollback()
+				return
+			}
+		}
+
 		params.UserID = currentData.ID
 		params.NickName = currentData.MerchantName
 		params.ProfileURL = currentData.MerchantLogo.String
@@ -579,6 +615,11 @@ func (m *MerchantUseCaseImpl) SelfUpdateMerchantPartial(ctxReq context.Context,
 		}()
 
 		m.Repository.Commit()
+		m.requestBankVerification(ctxReq, oldData, currentData, userAttribute.UserID)
+		if bankChange != nil {
+			currentData.PendingBankChange = bankChange
+			m.notifyBankChange(ctxReq, currentData, bankChange)
+		}
 
 		output <- ResultUseCase{Result: currentData}
 	})
@@ -715,7 +756,7 @@ func (m *MerchantUseCaseImpl) ChangeMerchantName(ctxReq context.Context, input *
 			return
 		}
 
-		merchantResult := m.MerchantRepo.FindMerchantByUser(ctxReq, userAttribute.UserID)
+		merchantResult := findActingMerchant(ctxReq, m.MerchantRepo, userAttribute.UserID)
 		if merchantResult.Error != nil {
 			tags[helper.TextResponse] = merchantResult.Error.Error()
 			output <- ResultUseCase{Error: merchantResult.Error, HTTPStatus: http.StatusBadRequest}
@@ -856,6 +897,11 @@ func (m *MerchantUseCaseImpl) ClearRejectUpgrade(ctxReq context.Context, memberI
 		currentData.LastModified = null.TimeFrom(time.Now())
 		currentData.UpgradeStatus = zero.StringFrom("")
 		currentData.Reason = zero.StringFrom("")
+		changes, err := planMerchantTransitions(oldData, currentData, model.MerchantEventClearRejectUpgrade)
+		if err != nil {
+			output <- ResultUseCase{Error: err, HTTPStatus: http.StatusBadRequest}
+			return
+		}
 
 		m.Repository.StartTransaction()
 
@@ -866,6 +912,13 @@ func (m *MerchantUseCaseImpl) ClearRejectUpgrade(ctxReq context.Context, memberI
 			return
 		}
 
+		// status history and kafka update event
+		if err := m.applyMerchantTransitions(ctxReq, changes, merchantChangeMeta{Actor: userAttribute}); err != nil {
+			m.Repository.Rollback()
+			output <- ResultUseCase{Error: err}
+			return
+		}
+
 		m.Repository.Commit()
 
 		plLog := model.MerchantLog{
@@ -875,9 +928,6 @@ func (m *MerchantUseCaseImpl) ClearRejectUpgrade(ctxReq context.Context, memberI
 		fmt.Println(userAttribute.UserID)
 
 		go m.QueuePublisher.QueueJob(ctxReq, plLog, currentData.ID, "InsertLogMerchantUpdate")
-		go func() {
-			m.PublishToKafkaMerchant(ctxReq, currentData, helper.EventProduceUpdateMerchant)
-		}()
 
 		output <- ResultUseCase{Result: currentData}
 
@@ -196,11 +196,13 @@ func TestAddMerchant(t *testing.T) {
 
 		defer mockDB.Close()
 
+		historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
 		svcRepo := localConfig.ServiceRepository{
-			MemberRepository:          &memberRepoMock,
-			MerchantRepository:        &repoMock,
-			MerchantAddressRepository: &merchantAddressRepoMock,
-			Repository:                &repository.Repository{WriteDB: mockDB},
+			MemberRepository:                &memberRepoMock,
+			MerchantRepository:              &repoMock,
+			MerchantAddressRepository:       &merchantAddressRepoMock,
+			Repository:                      &repository.Repository{WriteDB: mockDB},
+			MerchantStatusHistoryRepository: &historyRepoMock,
 		}
 		publisher := serviceMock.QPublisher{}
 		merchantService := serviceMock.MerchantServices{}
@@ -232,6 +234,7 @@ func TestAddMerchant(t *testing.T) {
 		publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
+		historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
 		ucResult := <-m.AddMerchant(ctxReq, tc.input, tc.userAttr)
 		if tc.wantError {
 			assert.Error(t, ucResult.Error)
@@ -377,10 +380,12 @@ func TestUpgradeMerchant(t *testing.T) {
 
 			defer mockDB.Close()
 
+			historyRepoMock := mockMerchantRepo.MerchantStatusHistoryRepository{}
 			svcRepo := localConfig.ServiceRepository{
-				MemberRepository:   &memberRepoMock,
-				MerchantRepository: &repoMock,
-				Repository:         &repository.Repository{WriteDB: mockDB},
+				MemberRepository:                &memberRepoMock,
+				MerchantRepository:              &repoMock,
+				Repository:                      &repository.Repository{WriteDB: mockDB},
+				MerchantStatusHistoryRepository: &historyRepoMock,
 			}
 			publisher := serviceMock.QPublisher{}
 			notifService := serviceMock.NotificationServices{}
@@ -408,6 +413,7 @@ func TestUpgradeMerchant(t *testing.T) {
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailMerchantUpgrade").Return(nil)
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
 
+			historyRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
 			ucResult := <-m.UpgradeMerchant(ctxReq, tc.input, tc.userAttr)
 			if tc.wantError {
 				assert.Error(t, ucResult.Error)
@@ -437,6 +443,7 @@ func TestSelfUpdateMerchant(t *testing.T) {
 		serviceResult         serviceModel.ServiceResult
 		serviceSendbirdResult serviceModel.ServiceResult
 		UpdateUserSendbirdV4  serviceModel.ServiceResult
+		wantBankChange        bool
 	}{
 		{
 			name:     "Test Update Merchant #1",
@@ -476,6 +483,13 @@ func TestSelfUpdateMerchant(t *testing.T) {
 			repoResult: repo.ResultRepository{Error: errDefault},
 			wantError:  true,
 		},
+		{
+			name:           "Test Update Merchant #5", // bank account change is held
+			input:          &defInput,
+			userAttr:       defUserAttr,
+			repoLoadResult: repo.ResultRepository{Result: bankChangeMerchant()},
+			wantBankChange: true,
+		},
 	}
 
 	for _, tc := range testDataUpgradeMerchant {
@@ -483,14 +497,18 @@ func TestSelfUpdateMerchant(t *testing.T) {
 			memberRepoMock := mockMemberRepo.MemberRepository{}
 			repoMock := mockMerchantRepo.MerchantRepository{}
 			merchantAddressRepoMock := mockMerchantRepo.MerchantAddressRepository{}
+			merchantDocumentRepoMock := mockMerchantRepo.MerchantDocumentRepository{}
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
 			mockDB, _, _ := sqlMock.New()
 			defer mockDB.Close()
 
 			svcRepo := localConfig.ServiceRepository{
-				MemberRepository:          &memberRepoMock,
-				MerchantRepository:        &repoMock,
-				MerchantAddressRepository: &merchantAddressRepoMock,
-				Repository:                &repository.Repository{WriteDB: mockDB},
+				MemberRepository:             &memberRepoMock,
+				MerchantRepository:           &repoMock,
+				MerchantAddressRepository:    &merchantAddressRepoMock,
+				MerchantDocumentRepository:   &merchantDocumentRepoMock,
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+				Repository:                   &repository.Repository{WriteDB: mockDB},
 			}
 			publisher := serviceMock.QPublisher{}
 			notifService := serviceMock.NotificationServices{}
@@ -509,9 +527,17 @@ func TestSelfUpdateMerchant(t *testing.T) {
 			repoMock.On("FindMerchantByUser", mock.Anything, mock.Anything).Return(tc.repoLoadResult)
 			repoMock.On("AddUpdateMerchant", mock.Anything, mock.Anything).Return(generateRepoResult(tc.repoResult))
 			merchantAddressRepoMock.On("AddUpdateAddressMaps", mock.Anything, mock.Anything).Return(generateRepoResult(tc.repoResult))
+			merchantDocumentRepoMock.On("ResetDocumentReview", mock.Anything, defaultMerchantID, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
 			sendbirdService.On("UpdateUserSendbirdV4", mock.Anything, mock.Anything).Return(serviceModel.ServiceResult(tc.UpdateUserSendbirdV4))
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailMerchantBankChange").Return(nil)
 			merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			bankChangeRepoMock.On("GetByMerchantID", mock.Anything, defaultMerchantID, openBankChangeStatuses).Return(generateRepoResult(repo.ResultRepository{
+				Result: []model.MerchantBankChange{{ID: 1, MerchantID: defaultMerchantID, Status: model.BankChangeAwaitingMFA}},
+			}))
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
+			bankChangeRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
 
 			ucResult := <-m.SelfUpdateMerchant(ctxReq, tc.input, tc.userAttr)
 			if tc.wantError {
@@ -519,6 +545,9 @@ func TestSelfUpdateMerchant(t *testing.T) {
 			} else {
 				assert.NoError(t, ucResult.Error)
 			}
+			if tc.wantBankChange {
+				assertBankChangeHeld(t, ucResult, &bankChangeRepoMock)
+			}
 		})
 	}
 }
@@ -694,6 +723,7 @@ func TestMerchantUseCaseImpl_SelfUpdateMerchantPartial(t *testing.T) {
 		serviceResult         serviceModel.ServiceResult
 		serviceSendbirdResult serviceModel.ServiceResult
 		UpdateUserSendbirdV4  serviceModel.ServiceResult
+		wantBankChange        bool
 	}{
 		{
 			name:     "Test Self Update Merchant Partial #1",
@@ -729,6 +759,13 @@ func TestMerchantUseCaseImpl_SelfUpdateMerchantPartial(t *testing.T) {
 			repoResult: repo.ResultRepository{Error: errDefault},
 			wantError:  true,
 		},
+		{
+			name:           "Test Self Update Merchant Partial #5", // bank account change is held
+			input:          &defInput,
+			userAttr:       defUserAttr,
+			repoLoadResult: repo.ResultRepository{Result: bankChangeMerchant()},
+			wantBankChange: true,
+		},
 	}
 
 	for _, tc := range testDataSelfUpdateMerchantPartial {
@@ -737,15 +774,17 @@ func TestMerchantUseCaseImpl_SelfUpdateMerchantPartial(t *testing.T) {
 			repoMock := mockMerchantRepo.MerchantRepository{}
 			merchantAddressRepoMock := mockMerchantRepo.MerchantAddressRepository{}
 			merchantDocumentRepoMock := mockMerchantRepo.MerchantDocumentRepository{}
+			bankChangeRepoMock := mockMerchantRepo.MerchantBankChangeRepository{}
 			mockDB, _, _ := sqlMock.New()
 			defer mockDB.Close()
 
 			svcRepo := localConfig.ServiceRepository{
-				MemberRepository:           &memberRepoMock,
-				MerchantRepository:         &repoMock,
-				MerchantAddressRepository:  &merchantAddressRepoMock,
-				MerchantDocumentRepository: &merchantDocumentRepoMock,
-				Repository:                 &repository.Repository{WriteDB: mockDB},
+				MemberRepository:             &memberRepoMock,
+				MerchantRepository:           &repoMock,
+				MerchantAddressRepository:    &merchantAddressRepoMock,
+				MerchantDocumentRepository:   &merchantDocumentRepoMock,
+				MerchantBankChangeRepository: &bankChangeRepoMock,
+				Repository:                   &repository.Repository{WriteDB: mockDB},
 			}
 			publisher := serviceMock.QPublisher{}
 			notifService := serviceMock.NotificationServices{}
@@ -766,9 +805,17 @@ func TestMerchantUseCaseImpl_SelfUpdateMerchantPartial(t *testing.T) {
 			merchantDocumentRepoMock.On("GetListMerchantDocument", mock.Anything, mock.Anything).Return(sharedMock.MerchantRepoResult(tc.repoResult))
 			merchantAddressRepoMock.On("FindAddressMaps", mock.Anything, mock.Anything, "b2c_merchant").Return(sharedMock.MerchantRepoResult(tc.repoResult))
 			merchantAddressRepoMock.On("AddUpdateAddressMaps", mock.Anything, mock.Anything).Return(generateRepoResult(tc.repoResult))
+			merchantDocumentRepoMock.On("ResetDocumentReview", mock.Anything, defaultMerchantID, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
 			sendbirdService.On("UpdateUserSendbirdV4", mock.Anything, mock.Anything).Return(serviceModel.ServiceResult(tc.UpdateUserSendbirdV4))
 			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "InsertLogMerchantUpdate").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "VerifyMerchantBank").Return(nil)
+			publisher.On("QueueJob", mock.Anything, mock.Anything, mock.Anything, "SendEmailMerchantBankChange").Return(nil)
 			merchantService.On("PublishToKafkaUserMerchant", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			bankChangeRepoMock.On("GetByMerchantID", mock.Anything, defaultMerchantID, openBankChangeStatuses).Return(generateRepoResult(repo.ResultRepository{
+				Result: []model.MerchantBankChange{{ID: 1, MerchantID: defaultMerchantID, Status: model.BankChangeAwaitingMFA}},
+			}))
+			bankChangeRepoMock.On("UpdateStatus", mock.Anything, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
+			bankChangeRepoMock.On("Save", mock.Anything, mock.Anything).Return(generateRepoResult(repo.ResultRepository{}))
 
 			ucResult := <-m.SelfUpdateMerchantPartial(ctxReq, tc.input, tc.userAttr)
 			if tc.wantError {
@@ -776,6 +823,9 @@ func TestMerchantUseCaseImpl_SelfUpdateMerchantPartial(t *testing.T) {
 			} else {
 				assert.NoError(t, ucResult.Error)
 			}
+			if tc.wantBankChange {
+				assertBankChangeHeld(t, ucResult, &bankChangeRepoMock)
+			}
 		})
 	}
 }
@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Bhinneka/golib"
+	"github.com/Bhinneka/user-service/helper"
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+)
+
+// merchantGuard checks the merchant data a transition ends with
+type merchantGuard func(merchant model.B2CMerchantDataV2) error
+
+// merchantEffect runs a side effect of a transition inside the running transaction,
+// returning error rolls the transition back
+type merchantEffect func(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error
+
+// merchantTransition declares an allowed move of a merchant lifecycle field
+type merchantTransition struct {
+	Event   string
+	Field   string
+	From    []string
+	To      string
+	Guards  []merchantGuard
+	Effects []merchantEffect
+}
+
+// merchantChangeMeta data structure of who applies the transitions and the email details
+type merchantChangeMeta struct {
+	Actor      *model.MerchantUserAttribute
+	Reason     string
+	MemberName string
+	AdminName  string
+	Documents  []model.B2CMerchantDocumentData
+}
+
+// merchantChange is a declared transition matched against a merchant update
+type merchantChange struct {
+	Transition merchantTransition
+	From       string
+	Before     model.B2CMerchantDataV2
+	After      model.B2CMerchantDataV2
+	Meta       merchantChangeMeta
+}
+
+// merchantTransitions is the only place where merchant onboarding can move,
+// any status or upgrade status change not listed here is rejected
+var merchantTransitions = []merchantTransition{
+	{
+		Event: model.MerchantEventRegister,
+		Field: model.MerchantStatusField,
+		From:  []string{""},
+		To:    model.NewString,
+	},
+	{
+		Event:   model.MerchantEventActivate,
+		Field:   model.MerchantStatusField,
+		From:    []string{model.NewString, model.InactiveString, model.RestrictedString},
+		To:      model.ActiveString,
+		Guards:  []merchantGuard{guardMerchantBank, guardMerchantDocuments},
+		Effects: []merchantEffect{effectSendbirdUser, effectEmailActivation},
+	},
+	{
+		Event: model.MerchantEventDeactivate,
+		Field: model.MerchantStatusField,
+		From:  []string{model.NewString, model.ActiveString, model.RestrictedString},
+		To:    model.InactiveString,
+	},
+	{
+		Event:   model.MerchantEventRestrict,
+		Field:   model.MerchantStatusField,
+		From:    []string{model.ActiveString},
+		To:      model.RestrictedString,
+		Guards:  []merchantGuard{guardMerchantDocumentsLapsed},
+		Effects: []merchantEffect{effectEmailRestricted, effectPublishUpdate},
+	},
+	{
+		Event: model.MerchantEventDelete,
+		Field: model.MerchantStatusField,
+		From:  []string{model.NewString, model.ActiveString, model.InactiveString, model.RestrictedString},
+		To:    model.DeletedString,
+	},
+	{
+		Event:   model.MerchantEventRejectRegistration,
+		Field:   model.MerchantStatusField,
+		From:    []string{model.NewString, model.InactiveString},
+		To:      model.DeletedString,
+		Effects: []merchantEffect{effectEmailRejectRegistration, effectPublishDelete},
+	},
+	{
+		Event:   model.MerchantEventRequestUpgrade,
+		Field:   model.MerchantUpgradeStatusField,
+		From:    []string{"", model.RejectManageString, model.RejectAssociateString},
+		To:      model.PendingManageString,
+		Guards:  []merchantGuard{guardMerchantActive},
+		Effects: []merchantEffect{effectEmailUpgrade},
+	},
+	{
+		Event:   model.MerchantEventRequestUpgrade,
+		Field:   model.MerchantUpgradeStatusField,
+		From:    []string{"", model.RejectManageString, model.RejectAssociateString},
+		To:      model.PendingAssociateString,
+		Guards:  []merchantGuard{guardMerchantActive},
+		Effects: []merchantEffect{effectEmailUpgrade},
+	},
+	{
+		Event:   model.MerchantEventApproveUpgrade,
+		Field:   model.MerchantUpgradeStatusField,
+		From:    []string{model.PendingManageString, model.PendingAssociateString},
+		To:      model.ActiveString,
+		Guards:  []merchantGuard{guardMerchantActive, guardMerchantBank, guardMerchantDocuments},
+		Effects: []merchantEffect{effectEmailApproval},
+	},
+	{
+		Event:   model.MerchantEventRejectUpgrade,
+		Field:   model.MerchantUpgradeStatusField,
+		From:    []string{model.PendingManageString},
+		To:      model.RejectManageString,
+		Effects: []merchantEffect{effectEmailRejectUpgrade, effectPublishUpdate},
+	},
+	{
+		Event:   model.MerchantEventRejectUpgrade,
+		Field:   model.MerchantUpgradeStatusField,
+		From:    []string{model.PendingAssociateString},
+		To:      model.RejectAssociateString,
+		Effects: []merchantEffect{effectEmailRejectUpgrade, effectPublishUpdate},
+	},
+	{
+		Event:   model.MerchantEventClearRejectUpgrade,
+		Field:   model.MerchantUpgradeStatusField,
+		From:    []string{model.RejectManageString, model.RejectAssociateString},
+		To:      "",
+		Effects: []merchantEffect{effectPublishUpdate},
+	},
+}
+
+// merchantLifecycle returns the value of a lifecycle field, old merchants without status follow isActive
+func merchantLifecycle(field string, merchant model.B2CMerchantDataV2) string {
+	if field == model.MerchantUpgradeStatusField {
+		return merchant.UpgradeStatus.String
+	}
+	if merchant.Status == "" {
+		if merchant.IsActive {
+			return model.ActiveString
+		}
+		return model.NewString
+	}
+	return merchant.Status
+}
+
+// keepMerchantLifecycle restores the lifecycle fields of a self update, merchants can only move them through transitions
+func keepMerchantLifecycle(merchant, oldData model.B2CMerchantDataV2) model.B2CMerchantDataV2 {
+	merchant.Status = oldData.Status
+	merchant.IsActive = oldData.IsActive
+	merchant.UpgradeStatus = oldData.UpgradeStatus
+	return merchant
+}
+
+func findMerchantTransition(field, from, to string, events []string) (merchantTransition, bool) {
+	for _, transition := range merchantTransitions {
+		if transition.Field != field || transition.To != to || !golib.StringInSlice(from, transition.From) {
+			continue
+		}
+		if len(events) > 0 && !golib.StringInSlice(transition.Event, events) {
+			continue
+		}
+		return transition, true
+	}
+	return merchantTransition{}, false
+}
+
+func planMerchantField(field, from string, before, after model.B2CMerchantDataV2, events []string) (*merchantChange, error) {
+	to := merchantLifecycle(field, after)
+	if from == to {
+		return nil, nil
+	}
+
+	transition, ok := findMerchantTransition(field, from, to, events)
+	if !ok {
+		return nil, fmt.Errorf("merchant %s cannot move from %s to %s", field, printLifecycle(from), printLifecycle(to))
+	}
+
+	for _, guard := range transition.Guards {
+		if err := guard(after); err != nil {
+			return nil, err
+		}
+	}
+	return &merchantChange{Transition: transition, From: from, Before: before, After: after}, nil
+}
+
+// planMerchantTransitions matches every lifecycle field changed between before and after with a declared transition,
+// limited to the given events when any, and checks the guards against after
+func planMerchantTransitions(before, after model.B2CMerchantDataV2, events ...string) ([]merchantChange, error) {
+	changes := []merchantChange{}
+	for _, field := range []string{model.MerchantStatusField, model.MerchantUpgradeStatusField} {
+		change, err := planMerchantField(field, merchantLifecycle(field, before), before, after, events)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes, nil
+}
+
+// planMerchantRegistration matches the status of a new merchant with the register transition
+func planMerchantRegistration(merchant model.B2CMerchantDataV2) ([]merchantChange, error) {
+	change, err := planMerchantField(model.MerchantStatusField, "", model.B2CMerchantDataV2{}, merchant, []string{model.MerchantEventRegister})
+	if err != nil {
+		return nil, err
+	}
+	return []merchantChange{*change}, nil
+}
+
+func printLifecycle(status string) string {
+	if status == "" {
+		return "EMPTY"
+	}
+	return status
+}
+
+// applyMerchantTransitions records the planned changes in the status history and runs their effects,
+// it has to be called inside the transaction that saves the merchant
+func (m *MerchantUseCaseImpl) applyMerchantTransitions(ctxReq context.Context, changes []merchantChange, meta merchantChangeMeta) error {
+	for _, change := range changes {
+		history := model.MerchantStatusHistory{
+			MerchantID: change.After.ID,
+			Field:      change.Transition.Field,
+			Event:      change.Transition.Event,
+			FromStatus: change.From,
+			ToStatus:   change.Transition.To,
+			Reason:     meta.Reason,
+			Created:    time.Now(),
+		}
+		if meta.Actor != nil {
+			history.ActorID = meta.Actor.UserID
+			history.ActorIP = meta.Actor.UserIP
+		}
+
+		saveResult := <-m.StatusHistoryRepo.Save(ctxReq, history)
+		if saveResult.Error != nil {
+			helper.SendErrorLog(ctxReq, "MerchantUseCase-applyMerchantTransitions", "save_status_history", saveResult.Error, history)
+			return saveResult.Error
+		}
+	}
+
+	for _, change := range changes {
+		change.Meta = meta
+		for _, effect := range change.Transition.Effects {
+			if err := effect(m, ctxReq, change); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func guardMerchantActive(merchant model.B2CMerchantDataV2) error {
+	if merchantLifecycle(model.MerchantStatusField, merchant) != model.ActiveString {
+		return errMerchantNotActive
+	}
+	return nil
+}
+
+func guardMerchantBank(merchant model.B2CMerchantDataV2) error {
+	if merchant.BankID.ValueOrZero() == 0 || merchant.AccountNumber.String == "" || merchant.AccountHolderName.String == "" {
+		return errMerchantBankRequired
+	}
+	if !merchant.BankVerification.IsVerifiedFor(merchant) {
+		return errMerchantBankNotVerified
+	}
+	return nil
+}
+
+func guardMerchantDocuments(merchant model.B2CMerchantDataV2) error {
+	if merchant.PicKtpFile.String == "" || merchant.NpwpFile.String == "" {
+		return errMerchantDocumentRequired
+	}
+	if !mandatoryDocumentsVerified(merchant.Documents, time.Now()) {
+		return errMerchantDocumentNotVerified
+	}
+	return nil
+}
+
+func guardMerchantDocumentsLapsed(merchant model.B2CMerchantDataV2) error {
+	if mandatoryDocumentsVerified(merchant.Documents, time.Now()) {
+		return errMerchantDocumentNotLapsed
+	}
+	return nil
+}
+
+// mandatoryDocumentsVerified checks every mandatory document is approved and not expired
+func mandatoryDocumentsVerified(documents []model.B2CMerchantDocumentData, now time.Time) bool {
+	for _, documentType := range model.MandatoryDocumentTypes {
+		verified := false
+		for _, document := range documents {
+			if document.DocumentType == documentType && document.IsVerified(now) {
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			return false
+		}
+	}
+	return true
+}
+
+func effectSendbirdUser(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	return m.CreateMerchantSendbirdV4(ctxReq, change.Before, &model.B2CMerchantCreateInput{ID: change.After.ID, IsActive: change.After.IsActive})
+}
+
+func effectEmailActivation(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	plQueue := model.MerchantPayloadEmail{
+		MemberName: change.Before.MerchantName,
+		Data:       change.Before,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.Before.ID, "SendEmailActivation")
+	return nil
+}
+
+func effectEmailApproval(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	plQueue := model.MerchantPayloadEmail{
+		MemberName: change.Before.MerchantName,
+		Data:       change.Before,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.Before.ID, "SendEmailApproval")
+	return nil
+}
+
+func effectEmailUpgrade(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	memberName := change.Meta.MemberName
+	if memberName == "" {
+		memberName = change.After.MerchantName
+	}
+	plQueue := model.MerchantPayloadEmail{
+		MemberName: memberName,
+		Data:       change.After,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.After.ID, "SendEmailMerchantUpgrade")
+	return nil
+}
+
+func effectEmailRejectRegistration(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	plQueue := model.MerchantPayloadEmail{
+		MemberName: change.After.MerchantName,
+		Data:       change.After,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.After.ID, "SendEmailMerchantRejectRegistration")
+	return nil
+}
+
+func effectEmailRejectUpgrade(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	plQueue := model.MerchantPayloadEmail{
+		MemberName:   change.After.MerchantName,
+		Data:         change.Before, // to get upgradeStatus, pass old data
+		ReasonReject: change.Meta.Reason,
+		AdminCMS:     change.Meta.AdminName,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.After.ID, "SendEmailMerchantRejectUpgrade")
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.After.ID, "SendEmailAdmin")
+	return nil
+}
+
+func effectEmailRestricted(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	plQueue := model.MerchantPayloadEmail{
+		MemberName: change.After.MerchantName,
+		Data:       change.After,
+		Documents:  change.Meta.Documents,
+	}
+	go m.QueuePublisher.QueueJob(ctxReq, plQueue, change.After.ID, "SendEmailMerchantRestricted")
+	return nil
+}
+
+func effectPublishUpdate(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	go func() {
+		m.PublishToKafkaMerchant(ctxReq, change.After, helper.EventProduceUpdateMerchant)
+	}()
+	return nil
+}
+
+func effectPublishDelete(m *MerchantUseCaseImpl, ctxReq context.Context, change merchantChange) error {
+	go func() {
+		m.PublishToKafkaMerchant(ctxReq, change.Before, helper.EventProduceDeleteMerchant)
+	}()
+	return nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/guregu/null.v4"
+	"gopkg.in/guregu/null.v4/zero"
+)
+
+var verifiedDocuments = model.ListB2CMerchantDocument{
+	MerchantDocument: stateDocuments(model.DocumentStatusVerified, time.Now().AddDate(1, 0, 0)),
+}
+
+func stateDocuments(status string, expiry time.Time) []model.B2CMerchantDocumentData {
+	documents := []model.B2CMerchantDocumentData{}
+	for _, documentType := range model.MandatoryDocumentTypes {
+		documents = append(documents, model.B2CMerchantDocumentData{
+			MerchantID:             "MCH201210161001",
+			DocumentType:           documentType,
+			DocumentValue:          defImage,
+			Status:                 status,
+			DocumentExpirationDate: null.TimeFrom(expiry),
+		})
+	}
+	return documents
+}
+
+func stateMerchant(status string, isActive bool, upgradeStatus string) model.B2CMerchantDataV2 {
+	return model.B2CMerchantDataV2{
+		ID:                "MCH201210161001",
+		Status:            status,
+		IsActive:          isActive,
+		UpgradeStatus:     zero.StringFrom(upgradeStatus),
+		BankID:            zero.IntFrom(1),
+		AccountNumber:     zero.StringFrom("1234567890"),
+		AccountHolderName: zero.StringFrom(defName),
+		PicKtpFile:        zero.StringFrom(defImage),
+		NpwpFile:          zero.StringFrom(defImage),
+		Documents:         verifiedDocuments.MerchantDocument,
+		BankVerification: &model.MerchantBankVerification{
+			MerchantID:        "MCH201210161001",
+			BankID:            1,
+			AccountNumber:     "1234567890",
+			AccountHolderName: defName,
+			Status:            model.BankVerificationVerified,
+		},
+	}
+}
+
+func TestPlanMerchantTransitions(t *testing.T) {
+	withoutBank := stateMerchant(model.ActiveString, true, "")
+	withoutBank.AccountNumber = zero.StringFrom("")
+	withoutDocuments := stateMerchant(model.ActiveString, true, "")
+	withoutDocuments.NpwpFile = zero.StringFrom("")
+	pendingDocuments := stateMerchant(model.ActiveString, true, "")
+	pendingDocuments.Documents = stateDocuments(model.DocumentStatusPending, time.Now().AddDate(1, 0, 0))
+	expiredDocuments := stateMerchant(model.RestrictedString, false, "")
+	expiredDocuments.Documents = stateDocuments(model.DocumentStatusVerified, time.Now().AddDate(0, 0, -1))
+	reactivatedExpired := stateMerchant(model.ActiveString, true, "")
+	reactivatedExpired.Documents = expiredDocuments.Documents
+	mismatchedBank := stateMerchant(model.ActiveString, true, "")
+	mismatchedBank.BankVerification.Status = model.BankVerificationNameMismatch
+	changedBank := stateMerchant(model.ActiveString, true, "")
+	changedBank.AccountNumber = zero.StringFrom("0987654321")
+	unverifiedBank := stateMerchant(model.ActiveString, true, "")
+	unverifiedBank.BankVerification = nil
+
+	tests := []struct {
+		name       string
+		before     model.B2CMerchantDataV2
+		after      model.B2CMerchantDataV2
+		events     []string
+		wantEvents []string
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			name:       "Case 1: activate new merchant",
+			before:     stateMerchant(model.NewString, false, ""),
+			after:      stateMerchant(model.ActiveString, true, ""),
+			wantEvents: []string{model.MerchantEventActivate},
+		},
+		{
+			name:       "Case 2: merchant without status follows isActive",
+			before:     stateMerchant("", true, ""),
+			after:      stateMerchant(model.InactiveString, false, ""),
+			wantEvents: []string{model.MerchantEventDeactivate},
+		},
+		{
+			name:       "Case 3: deleted merchant cannot be activated",
+			before:     stateMerchant(model.DeletedString, false, ""),
+			after:      stateMerchant(model.ActiveString, true, ""),
+			wantErrMsg: "merchant status cannot move from DELETED to ACTIVE",
+		},
+		{
+			name:    "Case 4: activate without bank account",
+			before:  stateMerchant(model.NewString, false, ""),
+			after:   withoutBank,
+			wantErr: errMerchantBankRequired,
+		},
+		{
+			name:    "Case 5: activate without documents",
+			before:  stateMerchant(model.NewString, false, ""),
+			after:   withoutDocuments,
+			wantErr: errMerchantDocumentRequired,
+		},
+		{
+			name:       "Case 6: approve upgrade",
+			before:     stateMerchant(model.ActiveString, true, model.PendingManageString),
+			after:      stateMerchant(model.ActiveString, true, model.ActiveString),
+			wantEvents: []string{model.MerchantEventApproveUpgrade},
+		},
+		{
+			name:    "Case 7: request upgrade of inactive merchant",
+			before:  stateMerchant(model.InactiveString, false, ""),
+			after:   stateMerchant(model.InactiveString, false, model.PendingAssociateString),
+			wantErr: errMerchantNotActive,
+		},
+		{
+			name:       "Case 8: skip pending upgrade",
+			before:     stateMerchant(model.ActiveString, true, ""),
+			after:      stateMerchant(model.ActiveString, true, model.ActiveString),
+			wantErrMsg: "merchant upgradeStatus cannot move from EMPTY to ACTIVE",
+		},
+		{
+			name:       "Case 9: activate and request upgrade together",
+			before:     stateMerchant(model.NewString, false, ""),
+			after:      stateMerchant(model.ActiveString, true, model.PendingManageString),
+			wantEvents: []string{model.MerchantEventActivate, model.MerchantEventRequestUpgrade},
+		},
+		{
+			name:       "Case 10: move outside of the requested event",
+			before:     stateMerchant(model.NewString, false, ""),
+			after:      stateMerchant(model.DeletedString, false, ""),
+			events:     []string{model.MerchantEventRejectRegistration},
+			wantEvents: []string{model.MerchantEventRejectRegistration},
+		},
+		{
+			name:       "Case 11: reject registration of active merchant",
+			before:     stateMerchant(model.ActiveString, true, ""),
+			after:      stateMerchant(model.DeletedString, false, ""),
+			events:     []string{model.MerchantEventRejectRegistration},
+			wantErrMsg: "merchant status cannot move from ACTIVE to DELETED",
+		},
+		{
+			name:   "Case 12: nothing changed",
+			before: stateMerchant(model.ActiveString, true, ""),
+			after:  stateMerchant(model.ActiveString, true, ""),
+		},
+		{
+			name:    "Case 13: activate with documents pending review",
+			before:  stateMerchant(model.NewString, false, ""),
+			after:   pendingDocuments,
+			wantErr: errMerchantDocumentNotVerified,
+		},
+		{
+			name:       "Case 14: restrict merchant with expired documents",
+			before:     stateMerchant(model.ActiveString, true, ""),
+			after:      expiredDocuments,
+			events:     []string{model.MerchantEventRestrict},
+			wantEvents: []string{model.MerchantEventRestrict},
+		},
+		{
+			name:    "Case 15: restrict merchant with verified documents",
+			before:  stateMerchant(model.ActiveString, true, ""),
+			after:   stateMerchant(model.RestrictedString, false, ""),
+			events:  []string{model.MerchantEventRestrict},
+			wantErr: errMerchantDocumentNotLapsed,
+		},
+		{
+			name:    "Case 16: reactivate restricted merchant with expired documents",
+			before:  expiredDocuments,
+			after:   reactivatedExpired,
+			wantErr: errMerchantDocumentNotVerified,
+		},
+		{
+			name:       "Case 17: reactivate restricted merchant",
+			before:     expiredDocuments,
+			after:      stateMerchant(model.ActiveString, true, ""),
+			wantEvents: []string{model.MerchantEventActivate},
+		},
+		{
+			name:    "Case 18: activate with mismatched bank account holder",
+			before:  stateMerchant(model.NewString, false, ""),
+			after:   mismatchedBank,
+			wantErr: errMerchantBankNotVerified,
+		},
+		{
+			name:    "Case 19: activate after changing the verified bank account",
+			before:  stateMerchant(model.NewString, false, ""),
+			after:   changedBank,
+			wantErr: errMerchantBankNotVerified,
+		},
+		{
+			name:    "Case 20: activate without bank verification",
+			before:  stateMerchant(model.NewString, false, ""),
+			after:   unverifiedBank,
+			wantErr: errMerchantBankNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := planMerchantTransitions(tt.before, tt.after, tt.events...)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			events := []string{}
+			for _, change := range changes {
+				events = append(events, change.Transition.Event)
+			}
+			if tt.wantEvents == nil {
+				tt.wantEvents = []string{}
+			}
+			assert.Equal(t, tt.wantEvents, events)
+		})
+	}
+}
+
+func TestPlanMerchantRegistration(t *testing.T) {
+	changes, err := planMerchantRegistration(stateMerchant(model.NewString, false, ""))
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, model.MerchantEventRegister, changes[0].Transition.Event)
+	assert.Equal(t, "", changes[0].From)
+
+	_, err = planMerchantRegistration(stateMerchant(model.ActiveString, true, ""))
+	assert.EqualError(t, err, "merchant status cannot move from EMPTY to ACTIVE")
+}
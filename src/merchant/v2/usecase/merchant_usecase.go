@@ -7,6 +7,7 @@ import (
 	memberRepo "github.com/Bhinneka/user-service/src/member/v1/repo"
 	"github.com/Bhinneka/user-service/src/merchant/v2/repo"
 	"github.com/Bhinneka/user-service/src/service"
+	"github.com/Bhinneka/user-service/src/shared/mfaotp"
 	sharedRepo "github.com/Bhinneka/user-service/src/shared/repository"
 )
 
@@ -26,7 +27,11 @@ type MerchantUseCaseImpl struct {
 	MerchantBankRepo     repo.MerchantBankRepository
 	MerchantEmployeeRepo repo.MerchantEmployeeRepository
 	MerchantDocumentRepo repo.MerchantDocumentRepository
+	StatusHistoryRepo    repo.MerchantStatusHistoryRepository
+	BankVerificationRepo repo.MerchantBankVerificationRepository
+	BankChangeRepo       repo.MerchantBankChangeRepository
 	MemberRepoRead       memberRepo.MemberRepository
+	MemberMFAMethodRepo  memberRepo.MemberMFAMethodRepository
 	UploadService        service.UploadServices
 	MerchantService      service.MerchantServices
 	TokenGenerator       token.AccessTokenGenerator
@@ -34,6 +39,8 @@ type MerchantUseCaseImpl struct {
 	NotificationService  service.NotificationServices
 	QueuePublisher       service.QPublisher
 	SendbirdService      service.SendbirdServices
+	BankVerifier         service.BankAccountVerifier
+	MFAOTP               *mfaotp.Issuer
 }
 
 // NewMerchantUseCase function for initialise merchant use case implementation mo el
@@ -45,7 +52,11 @@ func NewMerchantUseCase(repository localConfig.ServiceRepository, services local
 		MerchantBankRepo:     repository.MerchantBankRepository,
 		MerchantEmployeeRepo: repository.MerchantEmployeeRepository,
 		MerchantDocumentRepo: repository.MerchantDocumentRepository,
+		StatusHistoryRepo:    repository.MerchantStatusHistoryRepository,
+		BankVerificationRepo: repository.MerchantBankVerificationRepository,
+		BankChangeRepo:       repository.MerchantBankChangeRepository,
 		MemberRepoRead:       repository.MemberRepository,
+		MemberMFAMethodRepo:  repository.MemberMFAMethodRepository,
 		UploadService:        services.UploadService,
 		MerchantService:      services.MerchantService,
 		TokenGenerator:       tokenGenerator,
@@ -53,5 +64,7 @@ func NewMerchantUseCase(repository localConfig.ServiceRepository, services local
 		NotificationService:  services.NotificationService,
 		QueuePublisher:       services.QPublisher,
 		SendbirdService:      services.SendbirdService,
+		BankVerifier:         services.BankVerifier,
+		MFAOTP:               services.MFAOTP,
 	}
 }
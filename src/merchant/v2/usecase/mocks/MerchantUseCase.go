@@ -10,6 +10,8 @@ import (
 
 	usecase "github.com/Bhinneka/user-service/src/merchant/v2/usecase"
 
+	time "time"
+
 	v1model "github.com/Bhinneka/user-service/src/member/v1/model"
 )
 
@@ -18,13 +20,13 @@ type MerchantUseCase struct {
 	mock.Mock
 }
 
-// AddEmployee provides a mock function with given fields: ctxReq, token, email, firstName
-func (_m *MerchantUseCase) AddEmployee(ctxReq context.Context, token string, email string, firstName string) <-chan usecase.ResultUseCase {
-	ret := _m.Called(ctxReq, token, email, firstName)
+// AddEmployee provides a mock function with given fields: ctxReq, token, email, firstName, role
+func (_m *MerchantUseCase) AddEmployee(ctxReq context.Context, token string, email string, firstName string, role string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, token, email, firstName, role)
 
 	var r0 <-chan usecase.ResultUseCase
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan usecase.ResultUseCase); ok {
-		r0 = rf(ctxReq, token, email, firstName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, token, email, firstName, role)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
@@ -66,6 +68,70 @@ func (_m *MerchantUseCase) AddMerchantPIC(ctxReq context.Context, data *model.B2
 	return r0
 }
 
+// ApproveMerchantBank provides a mock function with given fields: ctxReq, merchantID, reason, userAttribute
+func (_m *MerchantUseCase) ApproveMerchantBank(ctxReq context.Context, merchantID string, reason string, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID, reason, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID, reason, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// ApproveMerchantBankChange provides a mock function with given fields: ctxReq, merchantID, changeID, overrideReason, userAttribute
+func (_m *MerchantUseCase) ApproveMerchantBankChange(ctxReq context.Context, merchantID string, changeID int64, overrideReason string, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID, changeID, overrideReason, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID, changeID, overrideReason, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// CancelMerchantBankChange provides a mock function with given fields: ctxReq, changeID, userAttribute
+func (_m *MerchantUseCase) CancelMerchantBankChange(ctxReq context.Context, changeID int64, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, changeID, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, changeID, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// ChangeMerchantEmployeeRole provides a mock function with given fields: ctxReq, memberID, input, userAttribute
+func (_m *MerchantUseCase) ChangeMerchantEmployeeRole(ctxReq context.Context, memberID string, input model.MerchantEmployeeRoleInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, input, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.MerchantEmployeeRoleInput, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, input, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // ChangeMerchantName provides a mock function with given fields: ctxReq, data, userAttribute
 func (_m *MerchantUseCase) ChangeMerchantName(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, userAttribute)
@@ -130,6 +196,22 @@ func (_m *MerchantUseCase) CmsGetAllMerchantEmployee(ctxReq context.Context, tok
 	return r0
 }
 
+// ConfirmMerchantBankChange provides a mock function with given fields: ctxReq, changeID, input, userAttribute
+func (_m *MerchantUseCase) ConfirmMerchantBankChange(ctxReq context.Context, changeID int64, input model.MerchantBankChangeConfirmInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, changeID, input, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.MerchantBankChangeConfirmInput, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, changeID, input, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // CreateMerchant provides a mock function with given fields: ctxReq, data, userAttribute
 func (_m *MerchantUseCase) CreateMerchant(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, userAttribute)
@@ -194,6 +276,54 @@ func (_m *MerchantUseCase) GetListMerchantBank(params *model.ParametersMerchantB
 	return r0
 }
 
+// GetMerchantAccess provides a mock function with given fields: ctxReq, memberID, merchantID
+func (_m *MerchantUseCase) GetMerchantAccess(ctxReq context.Context, memberID string, merchantID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, memberID, merchantID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, memberID, merchantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetMerchantBankChanges provides a mock function with given fields: ctxReq, merchantID
+func (_m *MerchantUseCase) GetMerchantBankChanges(ctxReq context.Context, merchantID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// GetMerchantBankVerification provides a mock function with given fields: ctxReq, merchantID
+func (_m *MerchantUseCase) GetMerchantBankVerification(ctxReq context.Context, merchantID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GetMerchantByID provides a mock function with given fields: ctxReq, id, isAttachment
 func (_m *MerchantUseCase) GetMerchantByID(ctxReq context.Context, id string, isAttachment string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, id, isAttachment)
@@ -258,6 +388,22 @@ func (_m *MerchantUseCase) GetMerchantEmployee(ctxReq context.Context, token str
 	return r0
 }
 
+// GetMerchantStatusHistory provides a mock function with given fields: ctxReq, merchantID
+func (_m *MerchantUseCase) GetMerchantStatusHistory(ctxReq context.Context, merchantID string) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // GetMerchants provides a mock function with given fields: ctxReq, params
 func (_m *MerchantUseCase) GetMerchants(ctxReq context.Context, params *model.QueryParameters) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, params)
@@ -290,6 +436,22 @@ func (_m *MerchantUseCase) GetMerchantsPublic(ctxReq context.Context, params *mo
 	return r0
 }
 
+// GetOwnMerchantBankChanges provides a mock function with given fields: ctxReq, userAttribute
+func (_m *MerchantUseCase) GetOwnMerchantBankChanges(ctxReq context.Context, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // InsertLogMerchant provides a mock function with given fields: ctxReq, old, new, action
 func (_m *MerchantUseCase) InsertLogMerchant(ctxReq context.Context, old model.B2CMerchantDataV2, new model.B2CMerchantDataV2, action string) error {
 	ret := _m.Called(ctxReq, old, new, action)
@@ -304,6 +466,54 @@ func (_m *MerchantUseCase) InsertLogMerchant(ctxReq context.Context, old model.B
 	return r0
 }
 
+// PollPendingBankVerifications provides a mock function with given fields: ctxReq, before
+func (_m *MerchantUseCase) PollPendingBankVerifications(ctxReq context.Context, before time.Time) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, before)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// ProcessBankChanges provides a mock function with given fields: ctxReq, now
+func (_m *MerchantUseCase) ProcessBankChanges(ctxReq context.Context, now time.Time) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, now)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// ProcessDocumentExpiry provides a mock function with given fields: ctxReq, now, reminder
+func (_m *MerchantUseCase) ProcessDocumentExpiry(ctxReq context.Context, now time.Time, reminder time.Duration) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, now, reminder)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, now, reminder)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // PublishToKafkaMerchant provides a mock function with given fields: ctxReq, data, eventType
 func (_m *MerchantUseCase) PublishToKafkaMerchant(ctxReq context.Context, data model.B2CMerchantDataV2, eventType string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, eventType)
@@ -320,6 +530,22 @@ func (_m *MerchantUseCase) PublishToKafkaMerchant(ctxReq context.Context, data m
 	return r0
 }
 
+// RejectMerchantBankChange provides a mock function with given fields: ctxReq, merchantID, changeID, reason, userAttribute
+func (_m *MerchantUseCase) RejectMerchantBankChange(ctxReq context.Context, merchantID string, changeID int64, reason string, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID, changeID, reason, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID, changeID, reason, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // RejectMerchantRegistration provides a mock function with given fields: ctxReq, merchantID, userAttribute
 func (_m *MerchantUseCase) RejectMerchantRegistration(ctxReq context.Context, merchantID string, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, merchantID, userAttribute)
@@ -352,6 +578,22 @@ func (_m *MerchantUseCase) RejectMerchantUpgrade(ctxReq context.Context, merchan
 	return r0
 }
 
+// ReviewMerchantDocument provides a mock function with given fields: ctxReq, input, userAttribute
+func (_m *MerchantUseCase) ReviewMerchantDocument(ctxReq context.Context, input model.B2CMerchantDocumentReviewInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, input, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.B2CMerchantDocumentReviewInput, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, input, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SelfUpdateMerchant provides a mock function with given fields: ctxReq, data, userAttribute
 func (_m *MerchantUseCase) SelfUpdateMerchant(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, userAttribute)
@@ -448,6 +690,38 @@ func (_m *MerchantUseCase) SendEmailMerchantAdd(ctxReq context.Context, data mod
 	return r0
 }
 
+// SendEmailMerchantBankChange provides a mock function with given fields: ctxReq, merchant, change
+func (_m *MerchantUseCase) SendEmailMerchantBankChange(ctxReq context.Context, merchant model.B2CMerchantDataV2, change *model.MerchantBankChange) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchant, change)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.B2CMerchantDataV2, *model.MerchantBankChange) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchant, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// SendEmailMerchantDocumentExpiry provides a mock function with given fields: ctxReq, merchant, documents
+func (_m *MerchantUseCase) SendEmailMerchantDocumentExpiry(ctxReq context.Context, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchant, documents)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.B2CMerchantDataV2, []model.B2CMerchantDocumentData) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchant, documents)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SendEmailMerchantEmployeeLogin provides a mock function with given fields: ctxReq, dataMerchant, dataMember
 func (_m *MerchantUseCase) SendEmailMerchantEmployeeLogin(ctxReq context.Context, dataMerchant model.B2CMerchantDataV2, dataMember v1model.Member) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, dataMerchant, dataMember)
@@ -480,6 +754,22 @@ func (_m *MerchantUseCase) SendEmailMerchantEmployeeRegister(ctxReq context.Cont
 	return r0
 }
 
+// SendEmailMerchantOwnershipTransfer provides a mock function with given fields: ctxReq, merchant, transfer
+func (_m *MerchantUseCase) SendEmailMerchantOwnershipTransfer(ctxReq context.Context, merchant model.B2CMerchantDataV2, transfer *model.MerchantOwnershipTransfer) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchant, transfer)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.B2CMerchantDataV2, *model.MerchantOwnershipTransfer) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchant, transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SendEmailMerchantRejectRegistration provides a mock function with given fields: ctxReq, data, memberName
 func (_m *MerchantUseCase) SendEmailMerchantRejectRegistration(ctxReq context.Context, data model.B2CMerchantDataV2, memberName string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, memberName)
@@ -512,6 +802,22 @@ func (_m *MerchantUseCase) SendEmailMerchantRejectUpgrade(ctxReq context.Context
 	return r0
 }
 
+// SendEmailMerchantRestricted provides a mock function with given fields: ctxReq, merchant, documents
+func (_m *MerchantUseCase) SendEmailMerchantRestricted(ctxReq context.Context, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchant, documents)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.B2CMerchantDataV2, []model.B2CMerchantDocumentData) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchant, documents)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // SendEmailMerchantUpgrade provides a mock function with given fields: ctxReq, data, memberName
 func (_m *MerchantUseCase) SendEmailMerchantUpgrade(ctxReq context.Context, data model.B2CMerchantDataV2, memberName string) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, memberName)
@@ -528,6 +834,54 @@ func (_m *MerchantUseCase) SendEmailMerchantUpgrade(ctxReq context.Context, data
 	return r0
 }
 
+// SendMerchantBankChangeOTP provides a mock function with given fields: ctxReq, changeID, input, userAttribute
+func (_m *MerchantUseCase) SendMerchantBankChangeOTP(ctxReq context.Context, changeID int64, input model.MerchantBankChangeOTPInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, changeID, input, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, int64, model.MerchantBankChangeOTPInput, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, changeID, input, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// SendMerchantOwnershipOTP provides a mock function with given fields: ctxReq, input, userAttribute
+func (_m *MerchantUseCase) SendMerchantOwnershipOTP(ctxReq context.Context, input model.MerchantOwnershipOTPInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, input, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.MerchantOwnershipOTPInput, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, input, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
+// TransferMerchantOwnership provides a mock function with given fields: ctxReq, input, userAttribute
+func (_m *MerchantUseCase) TransferMerchantOwnership(ctxReq context.Context, input model.MerchantOwnershipInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, input, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, model.MerchantOwnershipInput, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, input, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
+
 // UpdateMerchant provides a mock function with given fields: ctxReq, data, userAttribute
 func (_m *MerchantUseCase) UpdateMerchant(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
 	ret := _m.Called(ctxReq, data, userAttribute)
@@ -575,3 +929,19 @@ func (_m *MerchantUseCase) UpgradeMerchant(ctxReq context.Context, data *model.B
 
 	return r0
 }
+
+// VerifyMerchantBank provides a mock function with given fields: ctxReq, merchantID, userAttribute
+func (_m *MerchantUseCase) VerifyMerchantBank(ctxReq context.Context, merchantID string, userAttribute *model.MerchantUserAttribute) <-chan usecase.ResultUseCase {
+	ret := _m.Called(ctxReq, merchantID, userAttribute)
+
+	var r0 <-chan usecase.ResultUseCase
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.MerchantUserAttribute) <-chan usecase.ResultUseCase); ok {
+		r0 = rf(ctxReq, merchantID, userAttribute)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecase.ResultUseCase)
+		}
+	}
+
+	return r0
+}
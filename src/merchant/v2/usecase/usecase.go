@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
 	"github.com/Bhinneka/user-service/src/merchant/v2/model"
@@ -29,6 +30,10 @@ type MerchantUseCase interface {
 	SelfUpdateMerchantPartial(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
 	ChangeMerchantName(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
 	ClearRejectUpgrade(ctxReq context.Context, memberID string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	GetOwnMerchantBankChanges(ctxReq context.Context, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	SendMerchantBankChangeOTP(ctxReq context.Context, changeID int64, input model.MerchantBankChangeOTPInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	ConfirmMerchantBankChange(ctxReq context.Context, changeID int64, input model.MerchantBankChangeConfirmInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	CancelMerchantBankChange(ctxReq context.Context, changeID int64, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
 
 	// CMS Related
 	UpdateMerchant(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
@@ -40,6 +45,14 @@ type MerchantUseCase interface {
 	RejectMerchantUpgrade(ctxReq context.Context, merchantID string, userAttribute *model.MerchantUserAttribute, reasonReject string) <-chan ResultUseCase
 	AddMerchantPIC(ctxReq context.Context, data *model.B2CMerchantCreateInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
 	GetMerchantByVanityURL(ctxReq context.Context, vanityURL string) <-chan ResultUseCase
+	GetMerchantStatusHistory(ctxReq context.Context, merchantID string) <-chan ResultUseCase
+	ReviewMerchantDocument(ctxReq context.Context, input model.B2CMerchantDocumentReviewInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	VerifyMerchantBank(ctxReq context.Context, merchantID string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	ApproveMerchantBank(ctxReq context.Context, merchantID, reason string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	GetMerchantBankVerification(ctxReq context.Context, merchantID string) <-chan ResultUseCase
+	GetMerchantBankChanges(ctxReq context.Context, merchantID string) <-chan ResultUseCase
+	ApproveMerchantBankChange(ctxReq context.Context, merchantID string, changeID int64, overrideReason string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	RejectMerchantBankChange(ctxReq context.Context, merchantID string, changeID int64, reason string, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
 
 	//Public Related
 	GetMerchantsPublic(ctxReq context.Context, params *model.QueryParametersPublic) <-chan ResultUseCase
@@ -55,12 +68,25 @@ type MerchantUseCase interface {
 	SendEmailMerchantUpgrade(ctxReq context.Context, data model.B2CMerchantDataV2, memberName string) <-chan ResultUseCase
 	SendEmailMerchantEmployeeLogin(ctxReq context.Context, dataMerchant model.B2CMerchantDataV2, dataMember memberModel.Member) <-chan ResultUseCase
 	SendEmailMerchantEmployeeRegister(ctxReq context.Context, dataMerchant model.B2CMerchantDataV2, dataMember memberModel.Member) <-chan ResultUseCase
+	SendEmailMerchantDocumentExpiry(ctxReq context.Context, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan ResultUseCase
+	SendEmailMerchantRestricted(ctxReq context.Context, merchant model.B2CMerchantDataV2, documents []model.B2CMerchantDocumentData) <-chan ResultUseCase
+	SendEmailMerchantBankChange(ctxReq context.Context, merchant model.B2CMerchantDataV2, change *model.MerchantBankChange) <-chan ResultUseCase
+	SendEmailMerchantOwnershipTransfer(ctxReq context.Context, merchant model.B2CMerchantDataV2, transfer *model.MerchantOwnershipTransfer) <-chan ResultUseCase
+
+	// Scheduled job related
+	ProcessDocumentExpiry(ctxReq context.Context, now time.Time, reminder time.Duration) <-chan ResultUseCase
+	ProcessBankChanges(ctxReq context.Context, now time.Time) <-chan ResultUseCase
+	PollPendingBankVerifications(ctxReq context.Context, before time.Time) <-chan ResultUseCase
 
 	// merchant employee
-	AddEmployee(ctxReq context.Context, token, email, firstName string) <-chan ResultUseCase
+	AddEmployee(ctxReq context.Context, token, email, firstName, role string) <-chan ResultUseCase
 	GetAllMerchantEmployee(ctxReq context.Context, token string, params *model.QueryMerchantEmployeeParameters) <-chan ResultUseCase
 	GetMerchantEmployee(ctxReq context.Context, token string, params *model.QueryMerchantEmployeeParameters) <-chan ResultUseCase
 	UpdateMerchantEmployee(ctxReq context.Context, token string, params *model.QueryMerchantEmployeeParameters) <-chan ResultUseCase
+	GetMerchantAccess(ctxReq context.Context, memberID, merchantID string) <-chan ResultUseCase
+	ChangeMerchantEmployeeRole(ctxReq context.Context, memberID string, input model.MerchantEmployeeRoleInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	SendMerchantOwnershipOTP(ctxReq context.Context, input model.MerchantOwnershipOTPInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
+	TransferMerchantOwnership(ctxReq context.Context, input model.MerchantOwnershipInput, userAttribute *model.MerchantUserAttribute) <-chan ResultUseCase
 
 	// CMS merchant employee
 	CmsGetAllMerchantEmployee(ctxReq context.Context, token string, params *model.QueryCmsMerchantEmployeeParameters) <-chan ResultUseCase
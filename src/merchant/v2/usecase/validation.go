@@ -12,6 +12,7 @@ import (
 	stringLib "github.com/Bhinneka/golib/string"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/Bhinneka/user-service/src/merchant/v2/model"
+	"gopkg.in/guregu/null.v4"
 )
 
 var (
@@ -22,6 +23,38 @@ var (
 	errUnableToRejectUpgrade           = errors.New("cannot reject request, merchant upgrade already approved")
 	errUnableToRejectRegistration      = errors.New("merchant is already active")
 	errBankNotExist                    = errors.New("bank doesn't exists")
+	errMerchantNotActive               = errors.New("your merchant is not active")
+	errMerchantBankRequired            = errors.New("merchant bank account is not complete")
+	errMerchantBankNotVerified         = errors.New("merchant bank account is not verified")
+	errBankVerificationNotFound        = errors.New("merchant bank account has not been verified yet")
+	errBankApprovalReason              = errors.New("reason is required to verify a bank account by hand")
+	errBankChangeNotFound              = errors.New("bank account change not found")
+	errBankChangeStatus                = errors.New("bank account change can not be processed in its current status")
+	errBankChangeReviewer              = errors.New("bank account change must be approved by someone other than the requester")
+	errBankChangeReason                = errors.New("reason is required to reject a bank account change")
+	errOwnerMFARequired                = errors.New("multi factor authentication must be enabled to change the bank account or the owner of the merchant")
+	errBankChangeStale                 = errors.New("merchant bank account changed since the request")
+	errBankChangeExpired               = errors.New("bank account change was not confirmed in time")
+	errBankChangeUnverified            = errors.New("new bank account is not verified, a reason is required to approve it")
+	errMerchantDocumentRequired        = errors.New("merchant KTP and NPWP documents are required")
+	errMerchantDocumentNotVerified     = errors.New("merchant KTP and NPWP documents are not verified or already expired")
+	errMerchantDocumentNotLapsed       = errors.New("merchant KTP and NPWP documents are still valid")
+	errMerchantDocumentNotFound        = errors.New("merchant document not found")
+	errDocumentReviewReason            = errors.New("reason is required to reject a document")
+	errDocumentReviewStatus            = errors.New("document review status must be VERIFIED or REJECTED")
+	errDocumentExpirationDate          = errors.New("expiration date must use format YYYY-MM-DD")
+	errDocumentExpired                 = errors.New("document expiration date has passed")
+	errMerchantNotRegister             = errors.New(ErrorMerchantNotRegister)
+	errMerchantAccessDenied            = errors.New("you are not an active employee of the merchant")
+	errMerchantAccessAmbiguous         = errors.New("you are an employee of several merchants, choose one with the X-Merchant-Id header")
+	errEmployeeNotFound                = errors.New("employee not found")
+	errEmployeeRole                    = fmt.Errorf("role must be one of %s", strings.Join(model.EmployeeRoles, ", "))
+	errEmployeeManage                  = errors.New("your role does not allow managing this employee")
+	errEmployeeRevoked                 = errors.New("employee access is already revoked")
+	errOwnershipTarget                 = errors.New("memberId of another employee is required")
+	errOwnershipNotActive              = errors.New("merchant can only be transferred to an active employee")
+	errOwnershipTaken                  = errors.New("employee already owns a merchant")
+	errOwnershipTransferNotFound       = errors.New("ownership transfer has no owner to notify")
 	timeFormat                         = "2006-01-02T15:04:05Z07:00"
 )
 
@@ -579,3 +612,30 @@ func (m *MerchantUseCaseImpl) validateKTPAndNPWPFolder(data *model.B2CMerchantCr
 
 	return nil
 }
+
+// validateDocumentReview function for validating a document review from CMS and parsing its expiration date
+func validateDocumentReview(input *model.B2CMerchantDocumentReviewInput, now time.Time) (null.Time, error) {
+	input.Reason = strings.TrimSpace(input.Reason)
+	switch input.Status {
+	case model.DocumentStatusVerified:
+	case model.DocumentStatusRejected:
+		if input.Reason == "" {
+			return null.Time{}, errDocumentReviewReason
+		}
+		return null.Time{}, nil
+	default:
+		return null.Time{}, errDocumentReviewStatus
+	}
+
+	if input.ExpirationDate == "" {
+		return null.Time{}, nil
+	}
+	expirationDate, err := time.ParseInLocation(helper.FormatDateDB, input.ExpirationDate, now.Location())
+	if err != nil {
+		return null.Time{}, errDocumentExpirationDate
+	}
+	if !expirationDate.After(now) {
+		return null.Time{}, errDocumentExpired
+	}
+	return null.TimeFrom(expirationDate), nil
+}
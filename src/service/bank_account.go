@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Bhinneka/user-service/helper"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/labstack/echo"
+)
+
+const disbursementInquiryPath = "/disbursement/bank-account-inquiry"
+
+// BankAccountInquiryService disbursement provider looking up the holder name of a bank account
+type BankAccountInquiryService struct {
+	BaseURL   string
+	SecretKey string
+}
+
+// bankAccountInquiryResponse name inquiry response of the disbursement provider
+type bankAccountInquiryResponse struct {
+	BankCode      string `json:"bank_code"`
+	AccountNumber string `json:"account_number"`
+	AccountHolder string `json:"account_holder"`
+	Status        string `json:"status"`
+}
+
+// NewBankAccountVerifier function for initializing the bank account verifier
+func NewBankAccountVerifier(provider, baseURL, secretKey string) (BankAccountVerifier, error) {
+	switch provider {
+	case serviceModel.BankVerifierProviderFake:
+		return NewBankAccountFakeService(), nil
+	case serviceModel.BankVerifierProviderDisbursement:
+	default:
+		return nil, fmt.Errorf("unknown bank verifier provider %s", provider)
+	}
+
+	if secretKey == "" {
+		return nil, errors.New("please specify BANK_VERIFIER_SECRET")
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid bank verifier url %s", baseURL)
+	}
+
+	return &BankAccountInquiryService{BaseURL: strings.TrimSuffix(baseURL, "/"), SecretKey: secretKey}, nil
+}
+
+// InquireAccount function for looking up a bank account with the provider
+func (s *BankAccountInquiryService) InquireAccount(ctxReq context.Context, inquiry serviceModel.BankAccountInquiry) (serviceModel.BankAccountInquiryResult, error) {
+	form := url.Values{}
+	form.Set("bank_code", strings.ToLower(inquiry.BankCode))
+	form.Set("account_number", inquiry.AccountNumber)
+
+	headers := map[string]string{
+		echo.HeaderContentType:   echo.MIMEApplicationForm,
+		echo.HeaderAuthorization: "Basic " + base64.StdEncoding.EncodeToString([]byte(s.SecretKey+":")),
+	}
+
+	resp := bankAccountInquiryResponse{}
+	if err := helper.GetHTTPNewRequest(ctxReq, http.MethodPost, s.BaseURL+disbursementInquiryPath, strings.NewReader(form.Encode()), &resp, headers); err != nil {
+		return serviceModel.BankAccountInquiryResult{}, err
+	}
+	if resp.Status == "" {
+		return serviceModel.BankAccountInquiryResult{}, errors.New("empty bank account inquiry response")
+	}
+
+	result := serviceModel.BankAccountInquiryResult{
+		AccountHolderName: resp.AccountHolder,
+		Code:              resp.Status,
+	}
+	switch resp.Status {
+	case "SUCCESS":
+		result.Status = serviceModel.BankInquirySuccess
+	case "PENDING":
+		result.Status = serviceModel.BankInquiryPending
+	default:
+		// INVALID_ACCOUNT_NUMBER, SUSPECTED_ACCOUNT, BLACKLISTED
+		result.Status = serviceModel.BankInquiryInvalidAccount
+	}
+	return result, nil
+}
+
+// BankAccountFakeService local bank account verifier, every account but the fake ones belongs to the stated holder
+type BankAccountFakeService struct{}
+
+// NewBankAccountFakeService function for initializing local bank account verifier
+func NewBankAccountFakeService() *BankAccountFakeService {
+	return &BankAccountFakeService{}
+}
+
+// InquireAccount function for pretending to look up a bank account
+func (s *BankAccountFakeService) InquireAccount(ctxReq context.Context, inquiry serviceModel.BankAccountInquiry) (serviceModel.BankAccountInquiryResult, error) {
+	switch inquiry.AccountNumber {
+	case "", serviceModel.BankFakeInvalidAccount:
+		return serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquiryInvalidAccount}, nil
+	case serviceModel.BankFakePendingAccount:
+		return serviceModel.BankAccountInquiryResult{Status: serviceModel.BankInquiryPending}, nil
+	}
+
+	return serviceModel.BankAccountInquiryResult{
+		Status:            serviceModel.BankInquirySuccess,
+		AccountHolderName: strings.ToUpper(inquiry.AccountHolderName),
+	}, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBankAccountVerifier(t *testing.T) {
+	verifier, err := NewBankAccountVerifier(serviceModel.BankVerifierProviderFake, "", "")
+	assert.NoError(t, err)
+	assert.IsType(t, &BankAccountFakeService{}, verifier)
+
+	verifier, err = NewBankAccountVerifier(serviceModel.BankVerifierProviderDisbursement, "https://bigflip.id/api/v2/", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bigflip.id/api/v2", verifier.(*BankAccountInquiryService).BaseURL)
+
+	_, err = NewBankAccountVerifier("unknown", "https://bigflip.id/api/v2", "secret")
+	assert.Error(t, err)
+	_, err = NewBankAccountVerifier(serviceModel.BankVerifierProviderDisbursement, "https://bigflip.id/api/v2", "")
+	assert.Error(t, err)
+	_, err = NewBankAccountVerifier(serviceModel.BankVerifierProviderDisbursement, "not a url", "secret")
+	assert.Error(t, err)
+}
+
+func TestBankAccountInquiryServiceInquireAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		wantStatus string
+		wantName   string
+		wantErr    bool
+	}{
+		{
+			name:       "Case 1: account found",
+			response:   `{"bank_code":"bca","account_number":"5465327020","account_holder":"PT BHINNEKA MENTARIDIMENSI","status":"SUCCESS"}`,
+			wantStatus: serviceModel.BankInquirySuccess,
+			wantName:   "PT BHINNEKA MENTARIDIMENSI",
+		},
+		{
+			name:       "Case 2: inquiry still running",
+			response:   `{"bank_code":"bca","account_number":"5465327020","account_holder":"","status":"PENDING"}`,
+			wantStatus: serviceModel.BankInquiryPending,
+		},
+		{
+			name:       "Case 3: blacklisted account",
+			response:   `{"bank_code":"bca","account_number":"5465327020","account_holder":"","status":"BLACKLISTED"}`,
+			wantStatus: serviceModel.BankInquiryInvalidAccount,
+		},
+		{
+			name:     "Case 4: error response",
+			response: `{"code":"VALIDATION_ERROR","errors":[{"attribute":"bank_code","code":1032}]}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, disbursementInquiryPath, r.URL.Path)
+				username, _, _ := r.BasicAuth()
+				assert.Equal(t, "secret", username)
+				assert.Equal(t, "bca", r.FormValue("bank_code"))
+				assert.Equal(t, "5465327020", r.FormValue("account_number"))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			verifier, err := NewBankAccountVerifier(serviceModel.BankVerifierProviderDisbursement, server.URL, "secret")
+			assert.NoError(t, err)
+
+			result, err := verifier.InquireAccount(context.Background(), serviceModel.BankAccountInquiry{BankCode: "BCA", AccountNumber: "5465327020"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantName, result.AccountHolderName)
+		})
+	}
+}
+
+func TestBankAccountFakeServiceInquireAccount(t *testing.T) {
+	fake := NewBankAccountFakeService()
+
+	result, err := fake.InquireAccount(context.Background(), serviceModel.BankAccountInquiry{AccountNumber: "5465327020", AccountHolderName: "Budi Santoso"})
+	assert.NoError(t, err)
+	assert.Equal(t, serviceModel.BankInquirySuccess, result.Status)
+	assert.Equal(t, "BUDI SANTOSO", result.AccountHolderName)
+
+	result, _ = fake.InquireAccount(context.Background(), serviceModel.BankAccountInquiry{AccountNumber: serviceModel.BankFakeInvalidAccount})
+	assert.Equal(t, serviceModel.BankInquiryInvalidAccount, result.Status)
+
+	result, _ = fake.InquireAccount(context.Background(), serviceModel.BankAccountInquiry{AccountNumber: serviceModel.BankFakePendingAccount})
+	assert.Equal(t, serviceModel.BankInquiryPending, result.Status)
+}
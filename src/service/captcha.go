@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/labstack/echo"
+)
+
+// CaptchaService captcha provider verifying tokens with its siteverify endpoint,
+// reCAPTCHA, hCaptcha and turnstile take the same form request and answer alike
+type CaptchaService struct {
+	Provider  string
+	Secret    string
+	VerifyURL string
+}
+
+// captchaVerifyResponse siteverify response of every provider
+type captchaVerifyResponse struct {
+	Success     bool     `json:"success"`
+	Score       *float64 `json:"score"`
+	Action      string   `json:"action"`
+	ChallengeTs string   `json:"challenge_ts"`
+	HostName    string   `json:"hostname"`
+	ErrorCodes  []string `json:"error-codes"`
+}
+
+// NewCaptchaVerifier function for initializing the captcha provider, verifyURL is optional and replaces
+// the siteverify endpoint of the provider
+func NewCaptchaVerifier(provider, secret, verifyURL string) (CaptchaVerifier, error) {
+	if provider == serviceModel.CaptchaProviderFake {
+		return NewCaptchaFakeService(), nil
+	}
+
+	defaultURL, ok := serviceModel.CaptchaVerifyURLs[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown captcha provider %s", provider)
+	}
+	if secret == "" {
+		return nil, errors.New("please specify CAPTCHA_SECRET")
+	}
+	if verifyURL == "" {
+		verifyURL = defaultURL
+	}
+	if _, err := url.ParseRequestURI(verifyURL); err != nil {
+		return nil, fmt.Errorf("invalid captcha verify url %s", verifyURL)
+	}
+
+	return &CaptchaService{Provider: provider, Secret: secret, VerifyURL: verifyURL}, nil
+}
+
+// Verify function for checking a captcha token with the provider
+func (s *CaptchaService) Verify(ctxReq context.Context, captcha serviceModel.Captcha) (serviceModel.CaptchaResult, error) {
+	form := url.Values{}
+	form.Set("secret", s.Secret)
+	form.Set("response", captcha.Response)
+	if captcha.RemoteIP != "" {
+		form.Set("remoteip", captcha.RemoteIP)
+	}
+
+	headers := map[string]string{
+		echo.HeaderContentType: echo.MIMEApplicationForm,
+	}
+
+	resp := captchaVerifyResponse{}
+	if err := helper.GetHTTPNewRequest(ctxReq, http.MethodPost, s.VerifyURL, strings.NewReader(form.Encode()), &resp, headers); err != nil {
+		return serviceModel.CaptchaResult{}, err
+	}
+
+	return s.result(resp, captcha.Action), nil
+}
+
+// result function for turning the provider response into a result with a human score
+func (s *CaptchaService) result(resp captchaVerifyResponse, action string) serviceModel.CaptchaResult {
+	result := serviceModel.CaptchaResult{
+		Success:    resp.Success,
+		Action:     resp.Action,
+		HostName:   resp.HostName,
+		ErrorCodes: resp.ErrorCodes,
+	}
+	result.ChallengeTs, _ = time.Parse(time.RFC3339, resp.ChallengeTs)
+
+	switch {
+	case !resp.Success:
+		result.Score = 0
+	case resp.Score == nil:
+		result.Score = 1
+	case s.Provider == serviceModel.CaptchaProviderHCaptcha:
+		// hCaptcha enterprise scores the risk, 0 is safe and 1 is a bot
+		result.Score = 1 - *resp.Score
+	default:
+		result.Score = *resp.Score
+	}
+
+	if result.Success && action != "" && resp.Action != "" && resp.Action != action {
+		result.Success = false
+		result.ErrorCodes = append(result.ErrorCodes, serviceModel.CaptchaErrorActionMismatch)
+	}
+	return result
+}
+
+// CaptchaFakeService local captcha provider, every token but `fail` is solved with Score
+type CaptchaFakeService struct {
+	Score float64
+}
+
+// NewCaptchaFakeService function for initializing local captcha provider
+func NewCaptchaFakeService() *CaptchaFakeService {
+	return &CaptchaFakeService{Score: 1}
+}
+
+// Verify function for pretending to check a captcha token
+func (s *CaptchaFakeService) Verify(ctxReq context.Context, captcha serviceModel.Captcha) (serviceModel.CaptchaResult, error) {
+	switch captcha.Response {
+	case "":
+		return serviceModel.CaptchaResult{ErrorCodes: []string{serviceModel.CaptchaErrorMissingResponse}}, nil
+	case serviceModel.CaptchaFakeFailResponse:
+		return serviceModel.CaptchaResult{ErrorCodes: []string{serviceModel.CaptchaErrorInvalidResponse}}, nil
+	}
+
+	return serviceModel.CaptchaResult{
+		Success:     true,
+		Score:       s.Score,
+		Action:      captcha.Action,
+		HostName:    "localhost",
+		ChallengeTs: time.Now(),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCaptchaVerifier(t *testing.T) {
+	verifier, err := NewCaptchaVerifier(serviceModel.CaptchaProviderFake, "", "")
+	assert.NoError(t, err)
+	assert.IsType(t, &CaptchaFakeService{}, verifier)
+
+	verifier, err = NewCaptchaVerifier(serviceModel.CaptchaProviderTurnstile, "secret", "")
+	assert.NoError(t, err)
+	assert.Equal(t, serviceModel.CaptchaVerifyURLs[serviceModel.CaptchaProviderTurnstile], verifier.(*CaptchaService).VerifyURL)
+
+	_, err = NewCaptchaVerifier("unknown", "secret", "")
+	assert.Error(t, err)
+	_, err = NewCaptchaVerifier(serviceModel.CaptchaProviderHCaptcha, "", "")
+	assert.Error(t, err)
+	_, err = NewCaptchaVerifier(serviceModel.CaptchaProviderRecaptchaV3, "secret", "not a url")
+	assert.Error(t, err)
+}
+
+func TestCaptchaServiceVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		provider    string
+		response    string
+		wantSuccess bool
+		wantScore   float64
+		wantErrors  []string
+	}{
+		{
+			name:        "Case 1: reCAPTCHA v2 without score",
+			provider:    serviceModel.CaptchaProviderRecaptchaV2,
+			response:    `{"success":true,"challenge_ts":"2021-05-01T10:00:00Z","hostname":"bhinneka.com"}`,
+			wantSuccess: true,
+			wantScore:   1,
+		},
+		{
+			name:        "Case 2: reCAPTCHA v3 score",
+			provider:    serviceModel.CaptchaProviderRecaptchaV3,
+			response:    `{"success":true,"score":0.7,"action":"login"}`,
+			wantSuccess: true,
+			wantScore:   0.7,
+		},
+		{
+			name:       "Case 3: reCAPTCHA v3 token of another action",
+			provider:   serviceModel.CaptchaProviderRecaptchaV3,
+			response:   `{"success":true,"score":0.9,"action":"checkout"}`,
+			wantScore:  0.9,
+			wantErrors: []string{serviceModel.CaptchaErrorActionMismatch},
+		},
+		{
+			name:        "Case 4: hCaptcha risk score",
+			provider:    serviceModel.CaptchaProviderHCaptcha,
+			response:    `{"success":true,"score":0.2}`,
+			wantSuccess: true,
+			wantScore:   0.8,
+		},
+		{
+			name:       "Case 5: Turnstile refused token",
+			provider:   serviceModel.CaptchaProviderTurnstile,
+			response:   `{"success":false,"error-codes":["invalid-input-response"]}`,
+			wantErrors: []string{serviceModel.CaptchaErrorInvalidResponse},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "secret", r.FormValue("secret"))
+				assert.Equal(t, "token", r.FormValue("response"))
+				assert.Equal(t, "10.0.0.1", r.FormValue("remoteip"))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			verifier, err := NewCaptchaVerifier(tt.provider, "secret", server.URL)
+			assert.NoError(t, err)
+
+			result, err := verifier.Verify(context.Background(), serviceModel.Captcha{Response: "token", RemoteIP: "10.0.0.1", Action: "login"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSuccess, result.Success)
+			assert.InDelta(t, tt.wantScore, result.Score, 0.0001)
+			assert.Equal(t, tt.wantErrors, result.ErrorCodes)
+		})
+	}
+}
+
+func TestCaptchaFakeServiceVerify(t *testing.T) {
+	fake := NewCaptchaFakeService()
+
+	result, err := fake.Verify(context.Background(), serviceModel.Captcha{Response: "solved", Action: "register"})
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, 1.0, result.Score)
+	assert.Equal(t, "register", result.Action)
+
+	result, _ = fake.Verify(context.Background(), serviceModel.Captcha{Response: serviceModel.CaptchaFakeFailResponse})
+	assert.False(t, result.Success)
+
+	result, _ = fake.Verify(context.Background(), serviceModel.Captcha{})
+	assert.Equal(t, []string{serviceModel.CaptchaErrorMissingResponse}, result.ErrorCodes)
+}
@@ -0,0 +1,37 @@
+package model
+
+const (
+	// BankVerifierProviderDisbursement disbursement provider answering a name inquiry of bank code and account number,
+	// the provider checks the account with its bank or a penny drop
+	BankVerifierProviderDisbursement = "disbursement"
+	// BankVerifierProviderFake local provider for development and tests, nothing is sent
+	BankVerifierProviderFake = "fake"
+
+	// BankInquirySuccess the account exists and the holder name is known
+	BankInquirySuccess = "SUCCESS"
+	// BankInquiryPending the provider still checks the account, ask again later
+	BankInquiryPending = "PENDING"
+	// BankInquiryInvalidAccount the account does not exist or cannot receive transfers
+	BankInquiryInvalidAccount = "INVALID_ACCOUNT"
+
+	// BankFakeInvalidAccount account number the fake provider does not know
+	BankFakeInvalidAccount = "0000000000"
+	// BankFakePendingAccount account number the fake provider keeps checking
+	BankFakePendingAccount = "1111111111"
+)
+
+// BankAccountInquiry data structure of a bank account to look up
+type BankAccountInquiry struct {
+	BankCode      string
+	AccountNumber string
+	// AccountHolderName name stated by the merchant, only answered back by the fake provider
+	AccountHolderName string
+}
+
+// BankAccountInquiryResult data structure of a looked up bank account
+type BankAccountInquiryResult struct {
+	Status            string `json:"status"`
+	AccountHolderName string `json:"accountHolderName"`
+	// Code raw status of the provider
+	Code string `json:"code,omitempty"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+const (
+	// CaptchaProviderRecaptchaV2 google reCAPTCHA v2 checkbox or invisible, no score
+	CaptchaProviderRecaptchaV2 = "recaptcha-v2"
+	// CaptchaProviderRecaptchaV3 google reCAPTCHA v3, scored from 0 (bot) to 1 (human)
+	CaptchaProviderRecaptchaV3 = "recaptcha-v3"
+	// CaptchaProviderHCaptcha hCaptcha, the enterprise risk score is turned into a human score
+	CaptchaProviderHCaptcha = "hcaptcha"
+	// CaptchaProviderTurnstile cloudflare turnstile, no score
+	CaptchaProviderTurnstile = "turnstile"
+	// CaptchaProviderFake local provider for development and tests, nothing is sent
+	CaptchaProviderFake = "fake"
+
+	// CaptchaFakeFailResponse token the fake provider refuses
+	CaptchaFakeFailResponse = "fail"
+
+	// CaptchaErrorActionMismatch error code when the token was made for another action
+	CaptchaErrorActionMismatch = "action-mismatch"
+	// CaptchaErrorMissingResponse error code when no token is sent
+	CaptchaErrorMissingResponse = "missing-input-response"
+	// CaptchaErrorInvalidResponse error code when the token is wrong or expired
+	CaptchaErrorInvalidResponse = "invalid-input-response"
+)
+
+// CaptchaVerifyURLs siteverify endpoint of each provider
+var CaptchaVerifyURLs = map[string]string{
+	CaptchaProviderRecaptchaV2: "https://www.google.com/recaptcha/api/siteverify",
+	CaptchaProviderRecaptchaV3: "https://www.google.com/recaptcha/api/siteverify",
+	CaptchaProviderHCaptcha:    "https://api.hcaptcha.com/siteverify",
+	CaptchaProviderTurnstile:   "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// Captcha data structure of a token solved by the client
+type Captcha struct {
+	Response string
+	RemoteIP string
+	// Action expected action of reCAPTCHA v3 and turnstile tokens, not checked when empty
+	Action string
+}
+
+// CaptchaResult data structure of a verified token
+type CaptchaResult struct {
+	Success bool `json:"success"`
+	// Score likelihood of a human from 0 to 1, 1 for providers without score
+	Score       float64   `json:"score"`
+	Action      string    `json:"action,omitempty"`
+	HostName    string    `json:"hostName,omitempty"`
+	ChallengeTs time.Time `json:"challengeTs,omitempty"`
+	ErrorCodes  []string  `json:"errorCodes,omitempty"`
+}
@@ -23,6 +23,12 @@ type Attachment struct {
 	Content string `json:"content"`
 }
 
+// SMS data structure
+type SMS struct {
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
 // Email data structure
 type Email struct {
 	From        string       `json:"from"`
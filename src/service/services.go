@@ -29,6 +29,21 @@ type NotificationServices interface {
 	SendEmail(ctxReq context.Context, email serviceModel.Email) (string, error)
 }
 
+// SMSServices interface, sms provider abstraction
+type SMSServices interface {
+	SendSMS(ctxReq context.Context, sms serviceModel.SMS) error
+}
+
+// CaptchaVerifier interface, captcha provider abstraction
+type CaptchaVerifier interface {
+	Verify(ctxReq context.Context, captcha serviceModel.Captcha) (serviceModel.CaptchaResult, error)
+}
+
+// BankAccountVerifier interface, bank account name inquiry abstraction
+type BankAccountVerifier interface {
+	InquireAccount(ctxReq context.Context, inquiry serviceModel.BankAccountInquiry) (serviceModel.BankAccountInquiryResult, error)
+}
+
 //MerchantServices interface, publisher interface abstraction
 type MerchantServices interface {
 	FindMerchantServiceByID(ctxReq context.Context, id, token, merchantID string) <-chan serviceModel.ServiceResult
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Bhinneka/golib/tracer"
+	"github.com/Bhinneka/user-service/helper"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// SMSFakeService local sms provider, messages are kept in memory and logged instead of sent
+type SMSFakeService struct {
+	mu       sync.Mutex
+	messages []serviceModel.SMS
+}
+
+// NewSMSFakeService function for initializing local sms provider
+func NewSMSFakeService() *SMSFakeService {
+	return &SMSFakeService{}
+}
+
+// SendSMS function for pretending to send sms
+func (s *SMSFakeService) SendSMS(ctxReq context.Context, sms serviceModel.SMS) error {
+	ctx := "SMSFakeService-SendSMS"
+
+	tr := tracer.StartTrace(ctxReq, ctx)
+	defer tr.Finish(map[string]interface{}{helper.TextParameter: sms.To})
+
+	if sms.To == "" {
+		return errors.New("sms recipient is required")
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, sms)
+	s.mu.Unlock()
+
+	helper.Log(log.InfoLevel, sms.Message, ctx, sms.To)
+	return nil
+}
+
+// Messages function for getting every sms sent so far
+func (s *SMSFakeService) Messages() []serviceModel.SMS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]serviceModel.SMS, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
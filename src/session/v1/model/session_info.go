@@ -34,6 +34,7 @@ type SessionInfoResponse struct {
 	UserAgent  *string    `json:"userAgent"`
 	DeviceID   *string    `json:"deviceId"`
 	CreatedAt  *time.Time `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
 }
 
 // SessionInfoList data structure
@@ -237,7 +237,7 @@ func (qp *SessionInfoQueryPostgres) GetDetailSessionInfo(ctxReq context.Context,
 		}
 
 		q := fmt.Sprintf(`SELECT id, "userId", "userName", ip, "userAgent",
-						"deviceId", "clientType", "grantType", jti, "createdAt" 
+						"deviceId", "clientType", "grantType", jti, "createdAt", "lastUsedAt"
 						from session_info %s ORDER BY "createdAt" DESC LIMIT 1`, queryParam)
 
 		tags[helper.TextQuery] = q
@@ -265,6 +265,7 @@ func (qp *SessionInfoQueryPostgres) GetDetailSessionInfo(ctxReq context.Context,
 			&session.GrantType,
 			&session.JTI,
 			&session.CreatedAt,
+			&session.LastUsedAt,
 		)
 
 		if err != nil {
@@ -11,4 +11,5 @@ type ResultRepository struct {
 // SessionInfoRepository interface abstraction
 type SessionInfoRepository interface {
 	SaveSessionInfo(params *model.SessionInfoRequest) <-chan ResultRepository
+	UpdateLastUsed(params *model.SessionInfoRequest) <-chan ResultRepository
 }
@@ -41,7 +41,7 @@ func (qp *SessionInfoRepoPostgres) SaveSessionInfo(params *model.SessionInfoRequ
 			params.IP = splitted[0]
 		}
 
-		q := `INSERT INTO session_info("userId","userName",ip,"userAgent","deviceId","clientType","grantType",jti,"createdAt") VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+		q := `INSERT INTO session_info("userId","userName",ip,"userAgent","deviceId","clientType","grantType",jti,"createdAt","lastUsedAt") VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$9)`
 
 		stmt, err := tx.Prepare(q)
 		if err != nil {
@@ -66,3 +66,34 @@ func (qp *SessionInfoRepoPostgres) SaveSessionInfo(params *model.SessionInfoRequ
 
 	return output
 }
+
+// UpdateLastUsed function for moving the last used time of the latest login of a device to now, e.g. on token refresh
+func (qp *SessionInfoRepoPostgres) UpdateLastUsed(params *model.SessionInfoRequest) <-chan ResultRepository {
+	ctx := "SessionInfoQuery-UpdateLastUsed"
+	output := make(chan ResultRepository)
+	go func() {
+		defer close(output)
+
+		q := `UPDATE session_info SET "lastUsedAt" = $4
+			WHERE id = (SELECT id FROM session_info WHERE "userId" = $1 AND "deviceId" = $2 AND "clientType" = $3
+				ORDER BY "createdAt" DESC LIMIT 1)`
+
+		stmt, err := qp.db.Prepare(q)
+		if err != nil {
+			helper.SendErrorLog(context.Background(), ctx, helper.TextPrepareDatabase, err, q)
+			output <- ResultRepository{Error: err}
+			return
+		}
+		defer stmt.Close()
+
+		if _, err = stmt.Exec(params.UserID, params.DeviceID, params.DeviceLogin, time.Now().Format(time.RFC3339)); err != nil {
+			helper.SendErrorLog(context.Background(), ctx, helper.TextQueryDatabase, err, q)
+			output <- ResultRepository{Error: err}
+			return
+		}
+
+		output <- ResultRepository{Error: nil}
+	}()
+
+	return output
+}
@@ -0,0 +1,191 @@
+// Package captcha decides when a login or a registration has to solve a captcha and verifies it with the provider,
+// a captcha is required after too many failed attempts and a token scored below the policy is refused.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/service"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+)
+
+const (
+	// ActionLogin expected action of login tokens
+	ActionLogin = "login"
+	// ActionRegister expected action of registration tokens
+	ActionRegister = "register"
+
+	// FailureKeyRedis redis key prefix of failed attempts counted by ip, format: `CAPTCHA:register:10.0.0.1`
+	FailureKeyRedis = "CAPTCHA"
+)
+
+var (
+	// ErrRequired no captcha token while one is required
+	ErrRequired = errors.New("captcha is required")
+	// ErrInvalid the provider refused the captcha token
+	ErrInvalid = errors.New("invalid captcha, please try again")
+	// ErrLowScore the provider scored the request as a bot
+	ErrLowScore = errors.New("captcha score is too low, please try again")
+	// ErrUnavailable the provider can not be reached while a captcha is required
+	ErrUnavailable = errors.New("captcha can not be verified, please try again later")
+)
+
+// Policy when a captcha is required and which score is accepted
+type Policy struct {
+	// AfterFailures failed attempts after which a captcha is required, 0 always requires one
+	AfterFailures int
+	// MinScore lowest accepted score from 0 (bot) to 1 (human), 0 accepts any solved captcha
+	MinScore float64
+	// FailureAge how long failed attempts counted by the guard are kept
+	FailureAge time.Duration
+	// Scored minScore is set in CAPTCHA_POLICY, registrations then always need a token as a score
+	// only tells bots apart when every request is scored
+	Scored bool
+}
+
+// DefaultPolicy policy used when CAPTCHA_POLICY is not set
+var DefaultPolicy = Policy{
+	AfterFailures: 3,
+	MinScore:      0.5,
+	FailureAge:    time.Hour,
+}
+
+// policyConfig json form of Policy
+type policyConfig struct {
+	AfterFailures *int     `json:"afterFailures"`
+	MinScore      *float64 `json:"minScore"`
+	FailureAge    string   `json:"failureAge"`
+}
+
+// ParsePolicy function for reading CAPTCHA_POLICY, e.g. `{"afterFailures":0,"minScore":0.7,"failureAge":"30m"}`,
+// fields left out keep the default
+func ParsePolicy(raw string) (Policy, error) {
+	policy := DefaultPolicy
+	if strings.TrimSpace(raw) == "" {
+		return policy, nil
+	}
+
+	config := policyConfig{}
+	if err := json.Unmarshal([]byte(raw), &config); err != nil {
+		return Policy{}, fmt.Errorf("invalid captcha policy: %s", err.Error())
+	}
+
+	if config.AfterFailures != nil {
+		if *config.AfterFailures < 0 {
+			return Policy{}, errors.New("invalid captcha policy: negative afterFailures")
+		}
+		policy.AfterFailures = *config.AfterFailures
+	}
+	if config.MinScore != nil {
+		if *config.MinScore < 0 || *config.MinScore > 1 {
+			return Policy{}, errors.New("invalid captcha policy: minScore is not between 0 and 1")
+		}
+		policy.MinScore = *config.MinScore
+		policy.Scored = policy.MinScore > 0
+	}
+	if config.FailureAge != "" {
+		age, err := time.ParseDuration(config.FailureAge)
+		if err != nil || age <= 0 {
+			return Policy{}, fmt.Errorf("invalid captcha policy: failureAge %s", config.FailureAge)
+		}
+		policy.FailureAge = age
+	}
+	return policy, nil
+}
+
+// Guard data structure
+type Guard struct {
+	Verifier service.CaptchaVerifier
+	Counters authRepo.AttemptRepository
+	Policy   Policy
+}
+
+// NewGuard function for initializing captcha guard
+func NewGuard(verifier service.CaptchaVerifier, counters authRepo.AttemptRepository, policy Policy) *Guard {
+	return &Guard{
+		Verifier: verifier,
+		Counters: counters,
+		Policy:   policy,
+	}
+}
+
+// Check function for verifying the captcha of a request after failures failed attempts. Without token the request goes on
+// until the captcha is required, a sent token is always verified and its score checked. When the provider can not be
+// reached the request only goes on while the captcha is not required
+func (g *Guard) Check(ctxReq context.Context, captcha serviceModel.Captcha, failures int) error {
+	ctx := "CaptchaGuard-Check"
+	required := failures >= g.Policy.AfterFailures
+	if captcha.Response == "" {
+		if required {
+			return ErrRequired
+		}
+		return nil
+	}
+
+	result, err := g.Verifier.Verify(ctxReq, captcha)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "verify_captcha", err, captcha.RemoteIP)
+		if required {
+			return ErrUnavailable
+		}
+		return nil
+	}
+
+	if !result.Success {
+		return ErrInvalid
+	}
+	if result.Score < g.Policy.MinScore {
+		return ErrLowScore
+	}
+	return nil
+}
+
+// Failures function for getting the failed attempts counted for key, a missing or unreadable key means zero
+func (g *Guard) Failures(ctxReq context.Context, key string) int {
+	countResult := <-g.Counters.Load(ctxReq, key)
+	if countResult.Error != nil {
+		return 0
+	}
+
+	counter, _ := countResult.Result.(authModel.LoginAttempt)
+	count, _ := strconv.Atoi(counter.Attempt)
+	return count
+}
+
+// RegisterFailure function for counting a failed attempt for key, the count is kept for FailureAge after the last one
+func (g *Guard) RegisterFailure(ctxReq context.Context, key string) {
+	counter := &authModel.LoginAttempt{
+		Key:             key,
+		Attempt:         strconv.Itoa(g.Failures(ctxReq, key) + 1),
+		LoginAttemptAge: g.Policy.FailureAge,
+	}
+	<-g.Counters.Save(ctxReq, counter)
+}
+
+// HTTPStatus function for mapping guard error into http status
+func HTTPStatus(err error) int {
+	switch err {
+	case ErrRequired:
+		return http.StatusPreconditionRequired
+	case ErrInvalid, ErrLowScore:
+		return http.StatusBadRequest
+	case ErrUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// GetFailureKey redis key format: `CAPTCHA:register:10.0.0.1`
+func GetFailureKey(action, ip string) string {
+	return strings.Join([]string{FailureKeyRedis, action, ip}, ":")
+}
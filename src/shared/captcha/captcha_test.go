@@ -0,0 +1,146 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/service"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/Bhinneka/user-service/src/shared/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// unreachableVerifier fails like a provider that can not be reached
+type unreachableVerifier struct{}
+
+func (unreachableVerifier) Verify(ctxReq context.Context, captcha serviceModel.Captcha) (serviceModel.CaptchaResult, error) {
+	return serviceModel.CaptchaResult{}, errors.New("connection refused")
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPolicy, policy)
+
+	policy, err = ParsePolicy(`{"afterFailures":0,"failureAge":"30m"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, policy.AfterFailures)
+	assert.Equal(t, DefaultPolicy.MinScore, policy.MinScore)
+	assert.Equal(t, 30*time.Minute, policy.FailureAge)
+	assert.False(t, policy.Scored)
+
+	policy, err = ParsePolicy(`{"minScore":0.7}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.7, policy.MinScore)
+	assert.True(t, policy.Scored)
+
+	invalidPolicies := []string{
+		`{`,
+		`{"afterFailures":-1}`,
+		`{"minScore":1.5}`,
+		`{"failureAge":"soon"}`,
+	}
+	for _, invalid := range invalidPolicies {
+		_, err = ParsePolicy(invalid)
+		assert.Error(t, err)
+	}
+}
+
+func TestGuardCheck(t *testing.T) {
+	lowScore := service.NewCaptchaFakeService()
+	lowScore.Score = 0.3
+
+	tests := []struct {
+		name     string
+		verifier service.CaptchaVerifier
+		response string
+		failures int
+		wantErr  error
+	}{
+		{
+			name:     "Case 1: No captcha before failures",
+			verifier: service.NewCaptchaFakeService(),
+			failures: 2,
+		},
+		{
+			name:     "Case 2: Captcha required after failures",
+			verifier: service.NewCaptchaFakeService(),
+			failures: 3,
+			wantErr:  ErrRequired,
+		},
+		{
+			name:     "Case 3: Solved captcha",
+			verifier: service.NewCaptchaFakeService(),
+			response: "solved",
+			failures: 5,
+		},
+		{
+			name:     "Case 4: Refused captcha is checked before failures",
+			verifier: service.NewCaptchaFakeService(),
+			response: serviceModel.CaptchaFakeFailResponse,
+			wantErr:  ErrInvalid,
+		},
+		{
+			name:     "Case 5: Score below the policy",
+			verifier: lowScore,
+			response: "solved",
+			wantErr:  ErrLowScore,
+		},
+		{
+			name:     "Case 6: Provider down before failures",
+			verifier: unreachableVerifier{},
+			response: "solved",
+		},
+		{
+			name:     "Case 7: Provider down after failures",
+			verifier: unreachableVerifier{},
+			response: "solved",
+			failures: 3,
+			wantErr:  ErrUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := NewGuard(tt.verifier, nil, DefaultPolicy)
+			err := guard.Check(context.Background(), serviceModel.Captcha{Response: tt.response, Action: ActionLogin}, tt.failures)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestGuardFailures(t *testing.T) {
+	values := map[string]string{}
+	fakeRedis := &mocks.FakeRedis{
+		GetFunc: func(key string) (string, error) {
+			value, ok := values[key]
+			if !ok {
+				return "", errors.New("redis: nil")
+			}
+			return value, nil
+		},
+		SetFunc: func(key, value string, exp time.Duration) (string, error) {
+			assert.Equal(t, time.Hour, exp)
+			values[key] = value
+			return "OK", nil
+		},
+	}
+
+	guard := NewGuard(service.NewCaptchaFakeService(), authRepo.NewAttemptRepositoryRedis(fakeRedis), DefaultPolicy)
+	key := GetFailureKey(ActionRegister, "10.0.0.1")
+	assert.Equal(t, "CAPTCHA:register:10.0.0.1", key)
+	assert.Equal(t, 0, guard.Failures(context.Background(), key))
+
+	guard.RegisterFailure(context.Background(), key)
+	guard.RegisterFailure(context.Background(), key)
+	assert.Equal(t, 2, guard.Failures(context.Background(), key))
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, http.StatusPreconditionRequired, HTTPStatus(ErrRequired))
+	assert.Equal(t, http.StatusBadRequest, HTTPStatus(ErrLowScore))
+	assert.Equal(t, http.StatusServiceUnavailable, HTTPStatus(ErrUnavailable))
+}
@@ -0,0 +1,328 @@
+// Package connector signs members in with any OpenID Connect identity provider added by configuration.
+// Providers are found through their discovery document, id tokens are verified against the provider JWKS,
+// and the state and nonce of an authorization are kept in redis until the provider sends the member back.
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Bhinneka/user-service/config/rsa"
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// discovery documents and keys are fetched again after cacheAge
+	cacheAge = 24 * time.Hour
+	// keys are fetched again for an unknown `kid` at most once per keysRefreshInterval
+	keysRefreshInterval = time.Minute
+)
+
+var (
+	// ErrUnknownConnector no identity provider is configured with the name
+	ErrUnknownConnector = errors.New("unknown identity provider")
+	// ErrInvalidState the state is unknown, expired or already used
+	ErrInvalidState = errors.New("login with the identity provider has expired, please try again")
+	// ErrRedirectURI the code is redeemed with another redirect uri than the authorization
+	ErrRedirectURI = errors.New("redirect uri does not match the authorization")
+	// ErrInvalidIDToken the id token is not signed by the provider or not issued for this login
+	ErrInvalidIDToken = errors.New("invalid id token")
+	// ErrProvider the identity provider can not be reached or refused the code
+	ErrProvider = errors.New("identity provider is unavailable, please try again")
+
+	connectorName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+)
+
+// ClaimMapping names of the id token claims holding the member data
+type ClaimMapping struct {
+	Subject       string `json:"subject"`
+	Email         string `json:"email"`
+	EmailVerified string `json:"emailVerified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"givenName"`
+	FamilyName    string `json:"familyName"`
+}
+
+// DefaultClaims standard openid connect claims, used for every claim left out of the mapping
+var DefaultClaims = ClaimMapping{
+	Subject:       "sub",
+	Email:         "email",
+	EmailVerified: "email_verified",
+	Name:          "name",
+	GivenName:     "given_name",
+	FamilyName:    "family_name",
+}
+
+// DefaultScopes scopes asked when the connector does not set any
+var DefaultScopes = []string{authModel.ScopeOpenID, authModel.ScopeEmail, authModel.ScopeProfile}
+
+// Config identity provider added by configuration
+type Config struct {
+	// Name identifies the connector in urls, grants and linked identities, e.g. `google`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	// Issuer of the id tokens, the discovery document is read from `<issuer>/.well-known/openid-configuration`
+	Issuer       string       `json:"issuer"`
+	ClientID     string       `json:"clientId"`
+	ClientSecret string       `json:"clientSecret"`
+	Scopes       []string     `json:"scopes"`
+	Claims       ClaimMapping `json:"claims"`
+	// TrustEmail accepts the email of a provider that does not send the email verified claim
+	TrustEmail bool `json:"trustEmail"`
+	// StaffDomain members with a verified email of the domain are staff, e.g. `bhinneka.com`
+	StaffDomain string `json:"staffDomain"`
+}
+
+// ParseConfigs function for reading OIDC_CONNECTORS, a json array of connectors, e.g.
+// `[{"name":"google","issuer":"https://accounts.google.com","clientId":"...","clientSecret":"..."}]`
+func ParseConfigs(raw string) ([]Config, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	configs := []Config{}
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf("invalid oidc connectors: %s", err.Error())
+	}
+
+	names := map[string]bool{}
+	for i := range configs {
+		config := &configs[i]
+		if !connectorName.MatchString(config.Name) {
+			return nil, fmt.Errorf("invalid oidc connector name: %q", config.Name)
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate oidc connector: %s", config.Name)
+		}
+		names[config.Name] = true
+
+		issuer, err := url.Parse(config.Issuer)
+		if err != nil || (issuer.Scheme != "https" && issuer.Scheme != "http") || issuer.Host == "" {
+			return nil, fmt.Errorf("invalid issuer of oidc connector %s", config.Name)
+		}
+		if config.ClientID == "" {
+			return nil, fmt.Errorf("client id of oidc connector %s is required", config.Name)
+		}
+
+		config.setDefaults()
+	}
+	return configs, nil
+}
+
+func (c *Config) setDefaults() {
+	if c.DisplayName == "" {
+		c.DisplayName = c.Name
+	}
+	if len(c.Scopes) == 0 {
+		c.Scopes = DefaultScopes
+	}
+	if !helper.StringInSlice(authModel.ScopeOpenID, c.Scopes) {
+		c.Scopes = append([]string{authModel.ScopeOpenID}, c.Scopes...)
+	}
+
+	claims := &c.Claims
+	for _, claim := range []struct {
+		value      *string
+		defaultKey string
+	}{
+		{&claims.Subject, DefaultClaims.Subject},
+		{&claims.Email, DefaultClaims.Email},
+		{&claims.EmailVerified, DefaultClaims.EmailVerified},
+		{&claims.Name, DefaultClaims.Name},
+		{&claims.GivenName, DefaultClaims.GivenName},
+		{&claims.FamilyName, DefaultClaims.FamilyName},
+	} {
+		if *claim.value == "" {
+			*claim.value = claim.defaultKey
+		}
+	}
+}
+
+// Identity member data of the identity provider, read from a verified id token
+type Identity struct {
+	Provider      string `json:"provider"`
+	Subject       string `json:"subject"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"givenName"`
+	FamilyName    string `json:"familyName"`
+	Staff         bool   `json:"-"`
+}
+
+// Connector openid connect relying party of one identity provider
+type Connector struct {
+	Config
+
+	mu            sync.Mutex
+	discovery     *authModel.OpenIDConfiguration
+	discoveredAt  time.Time
+	keys          *rsa.KeySet
+	keysFetchedAt time.Time
+}
+
+// NewConnector function for initializing connector, the provider is discovered on first use
+func NewConnector(config Config) *Connector {
+	config.setDefaults()
+	return &Connector{Config: config}
+}
+
+// Discover function for getting the discovery document of the provider, cached for a day
+func (c *Connector) Discover(ctxReq context.Context) (authModel.OpenIDConfiguration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil && time.Since(c.discoveredAt) < cacheAge {
+		return *c.discovery, nil
+	}
+
+	discovery := authModel.OpenIDConfiguration{}
+	err := helper.GetHTTPNewRequest(ctxReq, "GET", strings.TrimSuffix(c.Issuer, "/")+discoveryPath, nil, &discovery)
+	if err != nil {
+		return discovery, err
+	}
+
+	// openid connect discovery section 4.3: the issuer must be identical to the configured one
+	if discovery.Issuer != c.Issuer || discovery.AuthorizationEndpoint == "" ||
+		discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return discovery, fmt.Errorf("invalid discovery document of oidc connector %s", c.Name)
+	}
+
+	c.discovery = &discovery
+	c.discoveredAt = time.Now()
+	c.keys = nil
+	return discovery, nil
+}
+
+// AuthCodeURL function for building the authorization url the member is redirected to
+func (c *Connector) AuthCodeURL(ctxReq context.Context, redirectURI, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := c.Discover(ctxReq)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", authModel.ResponseTypeCode)
+	query.Set("client_id", c.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", strings.Join(c.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", authModel.CodeChallengeMethodS256)
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// VerifyIDToken function for verifying signature, issuer, audience, expiry and nonce of an id token
+func (c *Connector) VerifyIDToken(ctxReq context.Context, idToken, nonce string) (Identity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		keyID, _ := token.Header["kid"].(string)
+		keys, err := c.verifyKeys(ctxReq, keyID)
+		if err != nil {
+			return nil, err
+		}
+		return keys.Keyfunc(token)
+	})
+	if err != nil {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	if !claims.VerifyIssuer(c.Issuer, true) || !claims.VerifyAudience(c.ClientID, true) {
+		return Identity{}, ErrInvalidIDToken
+	}
+	if tokenNonce, _ := claims["nonce"].(string); nonce == "" || tokenNonce != nonce {
+		return Identity{}, ErrInvalidIDToken
+	}
+
+	identity := c.identity(claims)
+	if identity.Subject == "" {
+		return Identity{}, ErrInvalidIDToken
+	}
+	return identity, nil
+}
+
+// identity function for reading member data with the claim mapping of the connector
+func (c *Connector) identity(claims jwt.MapClaims) Identity {
+	identity := Identity{
+		Provider:   c.Name,
+		Subject:    stringClaim(claims, c.Claims.Subject),
+		Email:      strings.ToLower(stringClaim(claims, c.Claims.Email)),
+		Name:       stringClaim(claims, c.Claims.Name),
+		GivenName:  stringClaim(claims, c.Claims.GivenName),
+		FamilyName: stringClaim(claims, c.Claims.FamilyName),
+	}
+
+	// some providers send email_verified as string
+	switch verified := claims[c.Claims.EmailVerified].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	default:
+		identity.EmailVerified = c.TrustEmail
+	}
+	if identity.Email == "" {
+		identity.EmailVerified = false
+	}
+	identity.Staff = c.StaffDomain != "" && identity.EmailVerified &&
+		strings.HasSuffix(identity.Email, "@"+strings.ToLower(c.StaffDomain))
+
+	if identity.Name == "" {
+		identity.Name = strings.TrimSpace(identity.GivenName + " " + identity.FamilyName)
+	}
+	return identity
+}
+
+// verifyKeys function for getting the provider keys, fetched again when the token is signed by an unknown key
+func (c *Connector) verifyKeys(ctxReq context.Context, keyID string) (*rsa.KeySet, error) {
+	discovery, err := c.Discover(ctxReq)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.keys != nil {
+		_, err := c.keys.VerifyKey(keyID)
+		fresh := time.Since(c.keysFetchedAt) < cacheAge
+		if (err == nil && fresh) || time.Since(c.keysFetchedAt) < keysRefreshInterval {
+			return c.keys, nil
+		}
+	}
+
+	jwks := rsa.JSONWebKeySet{}
+	if err := helper.GetHTTPNewRequest(ctxReq, "GET", discovery.JWKSURI, nil, &jwks); err != nil {
+		if c.keys != nil {
+			return c.keys, nil
+		}
+		return nil, err
+	}
+
+	c.keys = rsa.NewJWKSKeySet(jwks)
+	c.keysFetchedAt = time.Now()
+	return c.keys, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
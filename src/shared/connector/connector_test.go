@@ -0,0 +1,279 @@
+package connector
+
+import (
+	"context"
+	"crypto/rand"
+	cryptoRSA "crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	keyRSA "github.com/Bhinneka/user-service/config/rsa"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	"github.com/Bhinneka/user-service/src/shared/mocks"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRedirectURI = "https://www.bhinneka.com/login/callback"
+
+// testProvider openid connect provider serving discovery, keys and the token endpoint
+type testProvider struct {
+	server        *httptest.Server
+	key           *cryptoRSA.PrivateKey
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	key, err := cryptoRSA.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	provider := &testProvider{key: key}
+
+	keys := keyRSA.NewKeySet()
+	keys.AddVerifyKey("key-1", &key.PublicKey)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(authModel.OpenIDConfiguration{
+			Issuer:                provider.server.URL,
+			AuthorizationEndpoint: provider.server.URL + "/authorize",
+			TokenEndpoint:         provider.server.URL + "/token",
+			JWKSURI:               provider.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(keys.JWKS())
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "code" || r.FormValue("client_secret") != "secret" ||
+			!authModel.VerifyCodeChallenge(r.FormValue("code_verifier"), provider.codeChallenge, authModel.CodeChallengeMethodS256) {
+			json.NewEncoder(w).Encode(tokenResponse{Error: "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(tokenResponse{IDToken: provider.sign(t, "key-1", provider.claims)})
+	})
+	provider.server = httptest.NewServer(mux)
+	return provider
+}
+
+func (p *testProvider) sign(t *testing.T, keyID string, claims jwt.MapClaims) string {
+	return signIDToken(t, p.key, keyID, claims)
+}
+
+func signIDToken(t *testing.T, key *cryptoRSA.PrivateKey, keyID string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return idToken
+}
+
+func (p *testProvider) idTokenClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            p.server.URL,
+		"aud":            "client",
+		"sub":            "110248495921238986420",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "John.Doe@bhinneka.com",
+		"email_verified": true,
+		"given_name":     "John",
+		"family_name":    "Doe",
+	}
+}
+
+func newTestStates() authRepo.LoginSessionRepository {
+	values := map[string]string{}
+	return authRepo.NewLoginSessionRepositoryRedis(&mocks.FakeRedis{
+		GetFunc: func(key string) (string, error) {
+			value, ok := values[key]
+			if !ok {
+				return "", errors.New("redis: nil")
+			}
+			return value, nil
+		},
+		SetFunc: func(key, value string, exp time.Duration) (string, error) {
+			values[key] = value
+			return "OK", nil
+		},
+		DelFunc: func(key string) (int64, error) {
+			delete(values, key)
+			return 1, nil
+		},
+	})
+}
+
+func TestParseConfigs(t *testing.T) {
+	configs, err := ParseConfigs("")
+	assert.NoError(t, err)
+	assert.Nil(t, configs)
+
+	configs, err = ParseConfigs(`[{"name":"azure","issuer":"https://login.microsoftonline.com/tenant/v2.0","clientId":"client",
+		"scopes":["email"],"claims":{"email":"preferred_username"},"trustEmail":true}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, "azure", configs[0].DisplayName)
+	assert.Equal(t, []string{authModel.ScopeOpenID, "email"}, configs[0].Scopes)
+	assert.Equal(t, "preferred_username", configs[0].Claims.Email)
+	assert.Equal(t, DefaultClaims.Subject, configs[0].Claims.Subject)
+
+	invalidConfigs := []string{
+		`{`,
+		`[{"name":"Google","issuer":"https://accounts.google.com","clientId":"client"}]`,
+		`[{"name":"google","issuer":"accounts.google.com","clientId":"client"}]`,
+		`[{"name":"google","issuer":"https://accounts.google.com"}]`,
+		`[{"name":"google","issuer":"https://accounts.google.com","clientId":"client"},
+			{"name":"google","issuer":"https://accounts.google.com","clientId":"client"}]`,
+	}
+	for _, invalid := range invalidConfigs {
+		_, err = ParseConfigs(invalid)
+		assert.Error(t, err)
+	}
+}
+
+func TestRegistryLogin(t *testing.T) {
+	provider := newTestProvider(t)
+	defer provider.server.Close()
+
+	registry := NewRegistry([]Config{{
+		Name:         "google",
+		Issuer:       provider.server.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		StaffDomain:  "bhinneka.com",
+	}}, newTestStates())
+	assert.Equal(t, []Info{{Name: "google", DisplayName: "google"}}, registry.List())
+
+	_, err := registry.Authorize(context.Background(), "facebook", testRedirectURI)
+	assert.Equal(t, ErrUnknownConnector, err)
+
+	authorize := func() string {
+		authorization, err := registry.Authorize(context.Background(), "google", testRedirectURI)
+		assert.NoError(t, err)
+
+		authURL, err := url.Parse(authorization.AuthorizationURL)
+		assert.NoError(t, err)
+		query := authURL.Query()
+		assert.Equal(t, "/authorize", authURL.Path)
+		assert.Equal(t, "client", query.Get("client_id"))
+		assert.Equal(t, authorization.State, query.Get("state"))
+		assert.Equal(t, "openid email profile", query.Get("scope"))
+
+		provider.codeChallenge = query.Get("code_challenge")
+		provider.claims = provider.idTokenClaims(query.Get("nonce"))
+		return authorization.State
+	}
+
+	t.Run("code exchanged once", func(t *testing.T) {
+		state := authorize()
+		identity, err := registry.Exchange(context.Background(), "google", "code", state, testRedirectURI)
+		assert.NoError(t, err)
+		assert.Equal(t, Identity{
+			Provider:      "google",
+			Subject:       "110248495921238986420",
+			Email:         "john.doe@bhinneka.com",
+			EmailVerified: true,
+			Name:          "John Doe",
+			GivenName:     "John",
+			FamilyName:    "Doe",
+			Staff:         true,
+		}, identity)
+
+		_, err = registry.Exchange(context.Background(), "google", "code", state, testRedirectURI)
+		assert.Equal(t, ErrInvalidState, err)
+	})
+
+	t.Run("other redirect uri", func(t *testing.T) {
+		state := authorize()
+		_, err := registry.Exchange(context.Background(), "google", "code", state, "https://evil.example/callback")
+		assert.Equal(t, ErrRedirectURI, err)
+	})
+
+	t.Run("refused code", func(t *testing.T) {
+		state := authorize()
+		_, err := registry.Exchange(context.Background(), "google", "other-code", state, testRedirectURI)
+		assert.Equal(t, ErrProvider, err)
+	})
+
+	t.Run("id token of another login", func(t *testing.T) {
+		state := authorize()
+		provider.claims["nonce"] = "replayed"
+		_, err := registry.Exchange(context.Background(), "google", "code", state, testRedirectURI)
+		assert.Equal(t, ErrInvalidIDToken, err)
+	})
+}
+
+func TestConnectorVerifyIDToken(t *testing.T) {
+	provider := newTestProvider(t)
+	defer provider.server.Close()
+
+	connector := NewConnector(Config{Name: "apple", Issuer: provider.server.URL, ClientID: "client"})
+	otherKey, err := cryptoRSA.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		claims  func(jwt.MapClaims)
+		keyID   string
+		key     *cryptoRSA.PrivateKey
+		wantErr error
+	}{
+		{
+			name:   "Case 1: Valid id token with email verified as string",
+			claims: func(claims jwt.MapClaims) { claims["email_verified"] = "true" },
+		},
+		{
+			name:    "Case 2: Other audience",
+			claims:  func(claims jwt.MapClaims) { claims["aud"] = []interface{}{"other"} },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "Case 3: Other issuer",
+			claims:  func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "Case 4: Expired",
+			claims:  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "Case 5: Signed by another key",
+			key:     otherKey,
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "Case 6: Unknown key id",
+			keyID:   "key-2",
+			wantErr: ErrInvalidIDToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := provider.idTokenClaims("nonce")
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+			key, keyID := provider.key, "key-1"
+			if tt.key != nil {
+				key = tt.key
+			}
+			if tt.keyID != "" {
+				keyID = tt.keyID
+			}
+			idToken := signIDToken(t, key, keyID, claims)
+
+			identity, err := connector.VerifyIDToken(context.Background(), idToken, "nonce")
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.True(t, identity.EmailVerified)
+				assert.False(t, identity.Staff)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package connector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+)
+
+const (
+	// StateKeyRedis redis key prefix of a pending authorization, format: `OIDC-STATE:<state>`
+	StateKeyRedis = "OIDC-STATE"
+	// StateAge time the member has to come back from the identity provider
+	StateAge = 10 * time.Minute
+
+	randomLength = 48
+)
+
+// Registry configured identity providers, by name
+type Registry struct {
+	States     authRepo.LoginSessionRepository
+	connectors map[string]*Connector
+}
+
+// Info public data of a connector shown on the login page
+type Info struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// Authorization the url the member is redirected to and the state the provider sends back
+type Authorization struct {
+	Provider         string `json:"provider"`
+	AuthorizationURL string `json:"authorizationUrl"`
+	State            string `json:"state"`
+}
+
+// pendingAuthorization data kept in redis under the state until the code is redeemed
+type pendingAuthorization struct {
+	Provider     string `json:"provider"`
+	RedirectURI  string `json:"redirectUri"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+}
+
+// tokenResponse rfc 6749 token response of the identity provider
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewRegistry function for initializing registry of the configured connectors
+func NewRegistry(configs []Config, states authRepo.LoginSessionRepository) *Registry {
+	registry := &Registry{States: states, connectors: map[string]*Connector{}}
+	for _, config := range configs {
+		registry.connectors[config.Name] = NewConnector(config)
+	}
+	return registry
+}
+
+// Get function for getting connector by name
+func (r *Registry) Get(name string) (*Connector, bool) {
+	if r == nil {
+		return nil, false
+	}
+	connector, ok := r.connectors[name]
+	return connector, ok
+}
+
+// List function for listing connectors ordered by name
+func (r *Registry) List() []Info {
+	infos := []Info{}
+	if r == nil {
+		return infos
+	}
+	for _, connector := range r.connectors {
+		infos = append(infos, Info{Name: connector.Name, DisplayName: connector.DisplayName})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// Authorize function for starting a login with the provider, the state, nonce and PKCE verifier
+// are kept in redis and the code can only be redeemed once with the same redirect uri
+func (r *Registry) Authorize(ctxReq context.Context, name, redirectURI string) (Authorization, error) {
+	ctx := "ConnectorRegistry-Authorize"
+	connector, ok := r.Get(name)
+	if !ok {
+		return Authorization{}, ErrUnknownConnector
+	}
+
+	pending := pendingAuthorization{
+		Provider:     name,
+		RedirectURI:  redirectURI,
+		Nonce:        helper.RandomStringBase64(randomLength),
+		CodeVerifier: helper.RandomStringBase64(randomLength),
+	}
+	state := helper.RandomStringBase64(randomLength)
+	if state == "" || pending.Nonce == "" || pending.CodeVerifier == "" {
+		return Authorization{}, fmt.Errorf("failed to generate state of oidc connector %s", name)
+	}
+
+	sum := sha256.Sum256([]byte(pending.CodeVerifier))
+	authURL, err := connector.AuthCodeURL(ctxReq, redirectURI, state, pending.Nonce, base64.RawURLEncoding.EncodeToString(sum[:]))
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "discover_provider", err, name)
+		return Authorization{}, ErrProvider
+	}
+
+	payload, _ := json.Marshal(pending)
+	paramRedis := &authModel.LoginSessionRedis{
+		Key:         GetStateKey(state),
+		Token:       string(payload),
+		ExpiredTime: StateAge,
+	}
+	if saveResult := <-r.States.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		return Authorization{}, saveResult.Error
+	}
+
+	return Authorization{Provider: name, AuthorizationURL: authURL, State: state}, nil
+}
+
+// Exchange function for redeeming the code the provider sent back with the state,
+// returns the identity read from the verified id token
+func (r *Registry) Exchange(ctxReq context.Context, name, code, state, redirectURI string) (Identity, error) {
+	ctx := "ConnectorRegistry-Exchange"
+	connector, ok := r.Get(name)
+	if !ok {
+		return Identity{}, ErrUnknownConnector
+	}
+
+	pending, err := r.consumeState(ctxReq, state)
+	if err != nil || pending.Provider != name {
+		return Identity{}, ErrInvalidState
+	}
+	if pending.RedirectURI != redirectURI {
+		return Identity{}, ErrRedirectURI
+	}
+
+	discovery, err := connector.Discover(ctxReq)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "discover_provider", err, name)
+		return Identity{}, ErrProvider
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", authModel.AuthTypeAuthorizationCode)
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", connector.ClientID)
+	form.Set("client_secret", connector.ClientSecret)
+	form.Set("code_verifier", pending.CodeVerifier)
+
+	token := tokenResponse{}
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+		"Accept":       "application/json",
+	}
+	if err := helper.GetHTTPNewRequest(ctxReq, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()), &token, headers); err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "exchange_code", err, name)
+		return Identity{}, ErrProvider
+	}
+	if token.Error != "" || token.IDToken == "" {
+		helper.SendErrorLog(ctxReq, ctx, "exchange_code", fmt.Errorf("%s %s", token.Error, token.ErrorDescription), name)
+		return Identity{}, ErrProvider
+	}
+
+	return connector.VerifyIDToken(ctxReq, token.IDToken, pending.Nonce)
+}
+
+// consumeState function for loading a pending authorization and removing it, states are single use
+func (r *Registry) consumeState(ctxReq context.Context, state string) (pendingAuthorization, error) {
+	pending := pendingAuthorization{}
+	if state == "" {
+		return pending, ErrInvalidState
+	}
+
+	key := GetStateKey(state)
+	stateResult := <-r.States.Load(ctxReq, key)
+	saved, _ := stateResult.Result.(authModel.LoginSessionRedis)
+	if stateResult.Error != nil || saved.Token == "" {
+		return pending, ErrInvalidState
+	}
+	<-r.States.Delete(ctxReq, key)
+
+	if err := json.Unmarshal([]byte(saved.Token), &pending); err != nil {
+		return pending, ErrInvalidState
+	}
+	return pending, nil
+}
+
+// HTTPStatus function for mapping connector error into http status
+func HTTPStatus(err error) int {
+	switch err {
+	case ErrUnknownConnector:
+		return http.StatusNotFound
+	case ErrInvalidState, ErrRedirectURI:
+		return http.StatusBadRequest
+	case ErrInvalidIDToken:
+		return http.StatusUnauthorized
+	case ErrProvider:
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+// GetStateKey function for getting redis key of a pending authorization
+func GetStateKey(state string) string {
+	return fmt.Sprintf("%s:%s", StateKeyRedis, state)
+}
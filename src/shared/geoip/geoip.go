@@ -0,0 +1,124 @@
+// Package geoip looks up the approximate coordinates of an ip address in an offline database file,
+// the file has `<cidr>,<latitude>,<longitude>,<location>` lines, e.g. `36.72.0.0/16,-6.2088,106.8456,Jakarta, ID`.
+package geoip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ErrorDatabaseLine error message for a line that can not be read
+	ErrorDatabaseLine = "invalid geoip database at line %d"
+
+	// earthRadius mean radius of the earth in kilometers
+	earthRadius = 6371.0
+)
+
+// Location coordinates and name of a network
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name"`
+}
+
+// Database networks loaded from a file, the most specific network comes first
+type Database struct {
+	networks []network
+}
+
+type network struct {
+	network  *net.IPNet
+	location Location
+}
+
+// Load function for reading a geoip database file, empty lines and lines starting with `#` are skipped
+func Load(reader io.Reader) (*Database, error) {
+	database := &Database{}
+
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		split := strings.SplitN(text, ",", 4)
+		if len(split) != 4 {
+			return nil, fmt.Errorf(ErrorDatabaseLine, line)
+		}
+
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(split[0]))
+		if err != nil {
+			return nil, fmt.Errorf(ErrorDatabaseLine, line)
+		}
+		latitude, errLatitude := strconv.ParseFloat(strings.TrimSpace(split[1]), 64)
+		longitude, errLongitude := strconv.ParseFloat(strings.TrimSpace(split[2]), 64)
+		if errLatitude != nil || errLongitude != nil || math.Abs(latitude) > 90 || math.Abs(longitude) > 180 {
+			return nil, fmt.Errorf(ErrorDatabaseLine, line)
+		}
+
+		database.networks = append(database.networks, network{
+			network:  ipNet,
+			location: Location{Latitude: latitude, Longitude: longitude, Name: strings.TrimSpace(split[3])},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(database.networks, func(i, j int) bool {
+		sizeI, _ := database.networks[i].network.Mask.Size()
+		sizeJ, _ := database.networks[j].network.Mask.Size()
+		return sizeI > sizeJ
+	})
+	return database, nil
+}
+
+// Lookup function for getting the location of an ip address, false when it is unknown
+func (d *Database) Lookup(ip string) (Location, bool) {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if d == nil || parsed == nil {
+		return Location{}, false
+	}
+
+	for _, network := range d.networks {
+		if network.network.Contains(parsed) {
+			return network.location, true
+		}
+	}
+	return Location{}, false
+}
+
+// Locate function for getting the location name of an ip address, empty when it is unknown
+func (d *Database) Locate(ip string) string {
+	location, _ := d.Lookup(ip)
+	return location.Name
+}
+
+// Len function for getting the number of networks
+func (d *Database) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.networks)
+}
+
+// Distance function for getting the great circle distance between two locations in kilometers
+func Distance(from, to Location) float64 {
+	radians := func(degree float64) float64 { return degree * math.Pi / 180 }
+
+	deltaLatitude := radians(to.Latitude - from.Latitude)
+	deltaLongitude := radians(to.Longitude - from.Longitude)
+	a := math.Sin(deltaLatitude/2)*math.Sin(deltaLatitude/2) +
+		math.Cos(radians(from.Latitude))*math.Cos(radians(to.Latitude))*math.Sin(deltaLongitude/2)*math.Sin(deltaLongitude/2)
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
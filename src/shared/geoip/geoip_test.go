@@ -0,0 +1,64 @@
+package geoip
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	file := "# network,latitude,longitude,location\n" +
+		"36.64.0.0/11,-2.5489,118.0149,Indonesia\n" +
+		"36.72.0.0/16,-6.2088,106.8456,Jakarta, ID\n" +
+		"2001:db8::/32,0,0,Documentation\n"
+	database, err := Load(strings.NewReader(file))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, database.Len())
+
+	tests := []struct {
+		name      string
+		ip        string
+		want      string
+		wantFound bool
+	}{
+		{name: "Case 1: Most specific network", ip: "36.72.10.1", want: "Jakarta, ID", wantFound: true},
+		{name: "Case 2: Wider network", ip: "36.80.1.1", want: "Indonesia", wantFound: true},
+		{name: "Case 3: IPv6", ip: "2001:db8::1", want: "Documentation", wantFound: true},
+		{name: "Case 4: Unknown network", ip: "10.0.0.1"},
+		{name: "Case 5: Invalid ip", ip: "localhost"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, found := database.Lookup(tt.ip)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.want, location.Name)
+			assert.Equal(t, tt.want, database.Locate(tt.ip))
+		})
+	}
+
+	invalidFiles := []string{
+		"36.64.0.0/33,-2.5,118.0,Indonesia\n",
+		"36.64.0.0/11,-2.5,Indonesia\n",
+		"36.64.0.0/11,-95,118.0,Indonesia\n",
+	}
+	for _, invalid := range invalidFiles {
+		_, err = Load(strings.NewReader(invalid))
+		assert.Error(t, err)
+	}
+
+	var empty *Database
+	assert.Equal(t, "", empty.Locate("36.72.10.1"))
+	assert.Equal(t, 0, empty.Len())
+}
+
+func TestDistance(t *testing.T) {
+	jakarta := Location{Latitude: -6.2088, Longitude: 106.8456}
+	surabaya := Location{Latitude: -7.2575, Longitude: 112.7521}
+	amsterdam := Location{Latitude: 52.3676, Longitude: 4.9041}
+
+	assert.InDelta(t, 0, Distance(jakarta, jakarta), 0.001)
+	assert.InDelta(t, 663, Distance(jakarta, surabaya), 10)
+	assert.InDelta(t, 11330, Distance(jakarta, amsterdam), 50)
+	assert.InDelta(t, Distance(jakarta, amsterdam), Distance(amsterdam, jakarta), 0.001)
+}
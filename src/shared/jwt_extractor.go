@@ -1,10 +1,10 @@
 package shared
 
 import (
-	"crypto/rsa"
 	"errors"
 	"fmt"
 
+	"github.com/Bhinneka/user-service/config/rsa"
 	"github.com/golang-jwt/jwt"
 )
 
@@ -19,17 +19,11 @@ type BearerClaims struct {
 	jwt.StandardClaims
 }
 
-// JWTExtract function for extract token
-func JWTExtract(rsaPublicKey *rsa.PublicKey, accessToken string) (*BearerClaims, error) {
+// JWTExtract function for extract token, the verification key is selected by `kid` header
+func JWTExtract(keys *rsa.KeySet, accessToken string) (*BearerClaims, error) {
 	var errorStr error
 
-	token, err := jwt.ParseWithClaims(accessToken, &BearerClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			errorStr = fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			return nil, errorStr
-		}
-		return rsaPublicKey, nil
-	})
+	token, err := jwt.ParseWithClaims(accessToken, &BearerClaims{}, keys.Keyfunc)
 
 	if token == nil {
 		errorStr = errors.New("invalid old token")
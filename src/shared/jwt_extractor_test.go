@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	rsaKey "github.com/Bhinneka/user-service/config/rsa"
 	"github.com/Bhinneka/user-service/helper"
 	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
@@ -48,7 +49,7 @@ func TestJWTExtractor(t *testing.T) {
 
 	for _, tt := range test {
 		verifyKey, _ := getPublicKey()
-		claims, err := JWTExtract(verifyKey, tt.token)
+		claims, err := JWTExtract(rsaKey.NewVerifyKeySet(verifyKey), tt.token)
 		if !tt.wantError {
 			assert.NoError(t, err)
 			assert.Equal(t, "USR181101", claims.Subject)
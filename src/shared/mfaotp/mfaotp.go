@@ -0,0 +1,184 @@
+// Package mfaotp sends one time codes by email or sms and verifies them,
+// codes, resend counters and wrong attempt counters live in redis.
+package mfaotp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	"github.com/Bhinneka/user-service/src/service"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+)
+
+var (
+	// ErrMethod unknown one time code method
+	ErrMethod = errors.New(memberModel.ErrorMFAMethod)
+	// ErrResendLimit too many codes sent within the resend window
+	ErrResendLimit = errors.New(memberModel.ErrorMFAOTPResendLimit)
+	// ErrAttempt too many wrong codes, the code is burned
+	ErrAttempt = errors.New(memberModel.ErrorMFAOTPAttempt)
+	// ErrInvalidCode wrong or expired code
+	ErrInvalidCode = errors.New(memberModel.ErrorMFAOTP)
+	// ErrSend the code could not be delivered
+	ErrSend = errors.New(memberModel.ErrorMFAOTPSend)
+)
+
+// Issuer data structure
+type Issuer struct {
+	Codes    authRepo.LoginSessionRepository
+	Counters authRepo.AttemptRepository
+	Email    service.NotificationServices
+	SMS      service.SMSServices
+	Policies map[string]memberModel.MFAOTPPolicy
+}
+
+// NewIssuer function for initializing one time code issuer with the default policies
+func NewIssuer(codes authRepo.LoginSessionRepository, counters authRepo.AttemptRepository,
+	email service.NotificationServices, sms service.SMSServices) *Issuer {
+	return &Issuer{
+		Codes:    codes,
+		Counters: counters,
+		Email:    email,
+		SMS:      sms,
+		Policies: memberModel.MFAOTPPolicies,
+	}
+}
+
+// Send function for generating a code and sending it to target, a new code replaces the previous one of the same scope
+// and keeps its wrong attempts, so resending does not give more guesses
+func (i *Issuer) Send(ctxReq context.Context, method, memberID, scope, target string) error {
+	ctx := "MFAOTPIssuer-Send"
+	policy, ok := i.Policies[method]
+	if !ok {
+		return ErrMethod
+	}
+
+	resendKey := memberModel.GetMFAOTPResendKey(method, memberID)
+	sent, err := i.increment(ctxReq, resendKey, policy.ResendWindow)
+	if err != nil {
+		return err
+	}
+	if sent > policy.ResendLimit {
+		return ErrResendLimit
+	}
+
+	code, err := memberModel.GenerateMFAOTP(policy.CodeLength)
+	if err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "generate_code", err, memberID)
+		return ErrSend
+	}
+
+	paramRedis := &authModel.LoginSessionRedis{
+		Key:         memberModel.GetMFAOTPCodeKey(method, memberID, scope),
+		Token:       helper.HashSHA256(code),
+		ExpiredTime: policy.CodeAge,
+	}
+	if saveResult := <-i.Codes.Save(ctxReq, paramRedis); saveResult.Error != nil {
+		return saveResult.Error
+	}
+
+	if err := i.deliver(ctxReq, method, target, code, policy); err != nil {
+		helper.SendErrorLog(ctxReq, ctx, "deliver_code", err, memberID)
+		return ErrSend
+	}
+
+	return nil
+}
+
+// Verify function for checking a code, the code is single use and burned after too many wrong attempts.
+// An attempt is counted before the code is compared so concurrent guesses can not pass the limit
+func (i *Issuer) Verify(ctxReq context.Context, method, memberID, scope, code string) error {
+	policy, ok := i.Policies[method]
+	if !ok {
+		return ErrMethod
+	}
+
+	codeKey := memberModel.GetMFAOTPCodeKey(method, memberID, scope)
+	codeResult := <-i.Codes.Load(ctxReq, codeKey)
+	saved, _ := codeResult.Result.(authModel.LoginSessionRedis)
+	if codeResult.Error != nil || saved.Token == "" {
+		return ErrInvalidCode
+	}
+
+	attemptKey := memberModel.GetMFAOTPAttemptKey(method, memberID, scope)
+	attempts, err := i.increment(ctxReq, attemptKey, attemptAge(policy))
+	if err != nil {
+		return err
+	}
+	if attempts > policy.MaxAttempts {
+		<-i.Codes.Delete(ctxReq, codeKey)
+		return ErrAttempt
+	}
+
+	if subtle.ConstantTimeCompare([]byte(saved.Token), []byte(helper.HashSHA256(code))) != 1 {
+		if attempts >= policy.MaxAttempts {
+			<-i.Codes.Delete(ctxReq, codeKey)
+			return ErrAttempt
+		}
+		return ErrInvalidCode
+	}
+
+	<-i.Codes.Delete(ctxReq, codeKey)
+	<-i.Counters.Delete(ctxReq, attemptKey)
+	return nil
+}
+
+// HTTPStatus function for mapping issuer error into http status
+func HTTPStatus(err error) int {
+	switch err {
+	case ErrMethod, ErrInvalidCode:
+		return http.StatusBadRequest
+	case ErrResendLimit, ErrAttempt:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
+
+func (i *Issuer) deliver(ctxReq context.Context, method, target, code string, policy memberModel.MFAOTPPolicy) error {
+	minutes := int(policy.CodeAge / time.Minute)
+	if method == memberModel.MFAMethodSMS {
+		return i.SMS.SendSMS(ctxReq, serviceModel.SMS{
+			To:      target,
+			Message: fmt.Sprintf(memberModel.ContentMFAOTPSMS, code, minutes),
+		})
+	}
+
+	email := serviceModel.Email{}
+	email.From = serviceModel.NoReply
+	email.FromName = serviceModel.NoReplyName
+	email.To = []string{target}
+	email.ToName = []string{target}
+	email.Subject = memberModel.SubjectMFAOTP
+	email.Content = fmt.Sprintf(memberModel.ContentMFAOTPEmail, code, minutes)
+	_, err := i.Email.SendEmail(ctxReq, email)
+	return err
+}
+
+// increment function for counting one more use of key and getting the count
+func (i *Issuer) increment(ctxReq context.Context, key string, age time.Duration) (int, error) {
+	countResult := <-i.Counters.Increment(ctxReq, key, age)
+	if countResult.Error != nil {
+		return 0, countResult.Error
+	}
+
+	counter, _ := countResult.Result.(authModel.LoginAttempt)
+	count, _ := strconv.Atoi(counter.Attempt)
+	return count, nil
+}
+
+// attemptAge function for getting how long wrong attempts are kept, they outlive the codes resent within the window
+func attemptAge(policy memberModel.MFAOTPPolicy) time.Duration {
+	if policy.ResendWindow > policy.CodeAge {
+		return policy.ResendWindow
+	}
+	return policy.CodeAge
+}
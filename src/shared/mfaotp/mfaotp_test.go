@@ -0,0 +1,244 @@
+package mfaotp
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Bhinneka/user-service/helper"
+	authModel "github.com/Bhinneka/user-service/src/auth/v1/model"
+	authRepo "github.com/Bhinneka/user-service/src/auth/v1/repo"
+	memberModel "github.com/Bhinneka/user-service/src/member/v1/model"
+	serviceModel "github.com/Bhinneka/user-service/src/service/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMemberID = "USR123"
+
+// memoryRedis keeps codes and counters in memory, expiry is ignored
+type memoryRedis struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newMemoryRedis() *memoryRedis {
+	return &memoryRedis{values: map[string]string{}}
+}
+
+func (m *memoryRedis) result(r authRepo.ResultRepository) <-chan authRepo.ResultRepository {
+	output := make(chan authRepo.ResultRepository, 1)
+	output <- r
+	close(output)
+	return output
+}
+
+func (m *memoryRedis) get(key string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *memoryRedis) set(key, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+}
+
+func (m *memoryRedis) Delete(ctxReq context.Context, key string) <-chan authRepo.ResultRepository {
+	m.mu.Lock()
+	delete(m.values, key)
+	m.mu.Unlock()
+	return m.result(authRepo.ResultRepository{})
+}
+
+type memoryCodes struct{ *memoryRedis }
+
+func (m memoryCodes) Save(ctxReq context.Context, data *authModel.LoginSessionRedis) <-chan authRepo.ResultRepository {
+	m.set(data.Key, data.Token)
+	return m.result(authRepo.ResultRepository{Result: data})
+}
+
+func (m memoryCodes) Load(ctxReq context.Context, key string) <-chan authRepo.ResultRepository {
+	value, ok := m.get(key)
+	if !ok {
+		return m.result(authRepo.ResultRepository{Error: errors.New(helper.ErrorRedis)})
+	}
+	return m.result(authRepo.ResultRepository{Result: authModel.LoginSessionRedis{Key: key, Token: value}})
+}
+
+func (m memoryCodes) GetLoginActive(ctxReq context.Context, key string) <-chan authRepo.ResultRepository {
+	return m.result(authRepo.ResultRepository{})
+}
+
+type memoryCounters struct{ *memoryRedis }
+
+func (m memoryCounters) Save(ctxReq context.Context, data *authModel.LoginAttempt) <-chan authRepo.ResultRepository {
+	m.set(data.Key, data.Attempt)
+	return m.result(authRepo.ResultRepository{Result: data})
+}
+
+func (m memoryCounters) Increment(ctxReq context.Context, key string, age time.Duration) <-chan authRepo.ResultRepository {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count, _ := strconv.Atoi(m.values[key])
+	m.values[key] = strconv.Itoa(count + 1)
+	return m.result(authRepo.ResultRepository{Result: authModel.LoginAttempt{Key: key, Attempt: m.values[key]}})
+}
+
+func (m memoryCounters) Load(ctxReq context.Context, key string) <-chan authRepo.ResultRepository {
+	value, ok := m.get(key)
+	if !ok {
+		return m.result(authRepo.ResultRepository{Error: errors.New(helper.ErrorRedis)})
+	}
+	return m.result(authRepo.ResultRepository{Result: authModel.LoginAttempt{Attempt: value}})
+}
+
+type fakeEmail struct{ sent []serviceModel.Email }
+
+func (f *fakeEmail) GetTemplateByID(ctxReq context.Context, templateID, envKey string) <-chan serviceModel.ServiceResult {
+	return nil
+}
+
+func (f *fakeEmail) SendEmail(ctxReq context.Context, email serviceModel.Email) (string, error) {
+	f.sent = append(f.sent, email)
+	return "sent", nil
+}
+
+type fakeSMS struct {
+	sent []serviceModel.SMS
+	err  error
+}
+
+func (f *fakeSMS) SendSMS(ctxReq context.Context, sms serviceModel.SMS) error {
+	f.sent = append(f.sent, sms)
+	return f.err
+}
+
+var codePattern = regexp.MustCompile(`\d{6}`)
+
+func newTestIssuer() (*Issuer, *fakeEmail, *fakeSMS) {
+	redis := newMemoryRedis()
+	email, sms := &fakeEmail{}, &fakeSMS{}
+	return NewIssuer(memoryCodes{redis}, memoryCounters{redis}, email, sms), email, sms
+}
+
+func TestIssuerSendAndVerify(t *testing.T) {
+	ctx := context.Background()
+	issuer, email, sms := newTestIssuer()
+
+	assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+	assert.Len(t, email.sent, 1)
+	assert.Equal(t, []string{"john@bhinneka.com"}, email.sent[0].To)
+	code := codePattern.FindString(email.sent[0].Content)
+
+	// the code belongs to its scope only
+	assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "XYZ-WEB", code))
+	assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodSMS, testMemberID, "ABC-WEB", code))
+	assert.NoError(t, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", code))
+
+	// single use
+	assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", code))
+
+	assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodSMS, testMemberID, "ABC-WEB", "08123456789"))
+	assert.Len(t, sms.sent, 1)
+	assert.Equal(t, "08123456789", sms.sent[0].To)
+	assert.NoError(t, issuer.Verify(ctx, memberModel.MFAMethodSMS, testMemberID, "ABC-WEB", codePattern.FindString(sms.sent[0].Message)))
+}
+
+func TestIssuerLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Case 1: Error unknown method", func(t *testing.T) {
+		issuer, _, _ := newTestIssuer()
+		err := issuer.Send(ctx, memberModel.MFAMethodTOTP, testMemberID, "ABC-WEB", "john@bhinneka.com")
+		assert.Equal(t, ErrMethod, err)
+	})
+
+	t.Run("Case 2: Error resend limit", func(t *testing.T) {
+		issuer, _, sms := newTestIssuer()
+		limit := memberModel.MFAOTPPolicies[memberModel.MFAMethodSMS].ResendLimit
+		for i := 0; i < limit; i++ {
+			assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodSMS, testMemberID, "ABC-WEB", "08123456789"))
+		}
+
+		// the limit is per member, another scope does not get a fresh quota
+		err := issuer.Send(ctx, memberModel.MFAMethodSMS, testMemberID, memberModel.MFAOTPScopeEnrol, "08123456789")
+		assert.Equal(t, ErrResendLimit, err)
+		assert.Len(t, sms.sent, limit)
+	})
+
+	t.Run("Case 3: Error too many wrong codes burns the code", func(t *testing.T) {
+		issuer, email, _ := newTestIssuer()
+		assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+		code := codePattern.FindString(email.sent[0].Content)
+
+		maxAttempts := memberModel.MFAOTPPolicies[memberModel.MFAMethodEmail].MaxAttempts
+		for i := 1; i < maxAttempts; i++ {
+			assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "wrong"))
+		}
+		assert.Equal(t, ErrAttempt, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "wrong"))
+		assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", code))
+	})
+
+	t.Run("Case 4: Error wrong codes are kept across resends", func(t *testing.T) {
+		issuer, email, _ := newTestIssuer()
+		maxAttempts := memberModel.MFAOTPPolicies[memberModel.MFAMethodEmail].MaxAttempts
+		for i := 1; i < maxAttempts; i++ {
+			assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+			assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "wrong"))
+		}
+
+		assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+		assert.Equal(t, ErrAttempt, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "wrong"))
+		code := codePattern.FindString(email.sent[len(email.sent)-1].Content)
+		assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", code))
+	})
+
+	t.Run("Case 5: Error concurrent wrong codes", func(t *testing.T) {
+		issuer, email, _ := newTestIssuer()
+		assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+		code := codePattern.FindString(email.sent[0].Content)
+
+		maxAttempts := memberModel.MFAOTPPolicies[memberModel.MFAMethodEmail].MaxAttempts
+		var wg sync.WaitGroup
+		for i := 0; i < maxAttempts*2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "wrong")
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, ErrInvalidCode, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", code))
+	})
+
+	t.Run("Case 6: Error delivery", func(t *testing.T) {
+		issuer, _, sms := newTestIssuer()
+		sms.err = errors.New("provider down")
+		err := issuer.Send(ctx, memberModel.MFAMethodSMS, testMemberID, "ABC-WEB", "08123456789")
+		assert.Equal(t, ErrSend, err)
+	})
+
+	t.Run("Case 7: Policy of the method", func(t *testing.T) {
+		issuer, email, _ := newTestIssuer()
+		issuer.Policies = map[string]memberModel.MFAOTPPolicy{
+			memberModel.MFAMethodEmail: {CodeLength: 8, CodeAge: time.Minute, ResendLimit: 1, ResendWindow: time.Hour, MaxAttempts: 1},
+		}
+		assert.NoError(t, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+		assert.Regexp(t, `<b>\d{8}</b>`, email.sent[0].Content)
+		assert.Equal(t, ErrResendLimit, issuer.Send(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "john@bhinneka.com"))
+		assert.Equal(t, ErrAttempt, issuer.Verify(ctx, memberModel.MFAMethodEmail, testMemberID, "ABC-WEB", "wrong"))
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	assert.Equal(t, 400, HTTPStatus(ErrInvalidCode))
+	assert.Equal(t, 429, HTTPStatus(ErrResendLimit))
+	assert.Equal(t, 429, HTTPStatus(ErrAttempt))
+	assert.Equal(t, 500, HTTPStatus(ErrSend))
+}
@@ -15,6 +15,7 @@ type FakeRedis struct {
 	DelFunc  func(string) (int64, error)
 	PingFunc func() (string, error)
 	ExpFunc  func(string, time.Duration) (bool, error)
+	EvalFunc func(string, []string, ...interface{}) (interface{}, error)
 }
 
 func (r *FakeRedis) Get(key string) (string, error) {
@@ -57,3 +58,10 @@ func (r *FakeRedis) Keys(key string) ([]string, error) {
 	}
 	return []string{""}, fmt.Errorf("Exp %s Error", key)
 }
+
+func (r *FakeRedis) Eval(script string, keys []string, args ...interface{}) (interface{}, error) {
+	if r.EvalFunc != nil {
+		return r.EvalFunc(script, keys, args...)
+	}
+	return nil, fmt.Errorf("Eval %v Error", keys)
+}
@@ -0,0 +1,482 @@
+// Package saml lets corporate accounts sign their contacts in with their own SAML 2.0 identity provider.
+// It reads the metadata of the identity provider, builds the authentication request of the HTTP-Redirect binding
+// and validates the signed response of the HTTP-POST binding. Only service provider initiated logins are accepted.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	// BindingRedirect HTTP-Redirect binding, used for the authentication request
+	BindingRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	// BindingPOST HTTP-POST binding, used for the response sent to the assertion consumer service
+	BindingPOST = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	// NameIDFormatEmail name id format asked to the identity provider
+	NameIDFormatEmail = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+	statusSuccess             = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	subjectConfirmationBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// MaxClockSkew difference allowed between the clocks of the identity provider and the service provider
+	MaxClockSkew = 3 * time.Minute
+	// MaxResponseSize largest encoded response accepted
+	MaxResponseSize = 512 * 1024
+)
+
+var (
+	// ErrMetadata the metadata is not the metadata of a SAML 2.0 identity provider
+	ErrMetadata = errors.New("invalid identity provider metadata")
+	// ErrResponse the response can not be read
+	ErrResponse = errors.New("invalid saml response")
+	// ErrStatus the identity provider did not authenticate the user
+	ErrStatus = errors.New("the identity provider did not authenticate the user")
+	// ErrUnsigned neither the response nor the assertion is signed
+	ErrUnsigned = errors.New("saml assertion is not signed")
+	// ErrInvalidSignature the signature does not match a certificate of the identity provider
+	ErrInvalidSignature = errors.New("invalid saml signature")
+	// ErrEncrypted encrypted assertions are not supported
+	ErrEncrypted = errors.New("encrypted saml assertions are not supported")
+	// ErrInvalidAssertion the assertion is not issued for this login
+	ErrInvalidAssertion = errors.New("saml assertion is not valid for this login")
+	// ErrExpired the assertion is not valid yet or anymore
+	ErrExpired = errors.New("saml assertion has expired")
+)
+
+// ServiceProvider the service provider of a corporate account
+type ServiceProvider struct {
+	EntityID string
+	ACSURL   string
+}
+
+// NewServiceProvider function for getting the service provider of an account, every account has its own entity id
+// so identity providers can tell the accounts apart. baseURL is the public url of this service
+func NewServiceProvider(baseURL, accountID string) ServiceProvider {
+	base := strings.TrimRight(baseURL, "/") + "/api/v2/auth/saml/" + url.PathEscape(accountID)
+	return ServiceProvider{EntityID: base + "/metadata", ACSURL: base + "/acs"}
+}
+
+// IdentityProvider identity provider read from its metadata
+type IdentityProvider struct {
+	EntityID     string
+	SSOURL       string
+	Certificates []*x509.Certificate
+}
+
+// Assertion data of the authenticated user
+type Assertion struct {
+	NameID       string
+	SessionIndex string
+	Attributes   map[string][]string
+}
+
+// Attribute function for getting the first value of an attribute, by name or friendly name
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+type metadataXML struct {
+	XMLName          xml.Name
+	EntityID         string        `xml:"entityID,attr"`
+	IDPSSODescriptor []idpXML      `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+	EntityDescriptor []metadataXML `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+}
+
+type idpXML struct {
+	KeyDescriptor []struct {
+		Use          string   `xml:"use,attr"`
+		Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleSignOnService []struct {
+		Binding  string `xml:"Binding,attr"`
+		Location string `xml:"Location,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+// ParseMetadata function for reading the entity id, the HTTP-Redirect single sign on url and the signing
+// certificates of an identity provider, an EntitiesDescriptor may hold exactly one identity provider
+func ParseMetadata(raw []byte) (IdentityProvider, error) {
+	idp := IdentityProvider{}
+	if _, err := parseDocument(raw); err != nil {
+		return idp, ErrMetadata
+	}
+
+	metadata := metadataXML{}
+	if err := xml.Unmarshal(raw, &metadata); err != nil {
+		return idp, ErrMetadata
+	}
+
+	entities := []metadataXML{metadata}
+	if metadata.XMLName.Local == "EntitiesDescriptor" {
+		entities = metadata.EntityDescriptor
+	}
+	var entity *metadataXML
+	for i := range entities {
+		if entities[i].XMLName.Space == namespaceMetadata && len(entities[i].IDPSSODescriptor) > 0 {
+			if entity != nil {
+				return idp, fmt.Errorf("%s: more than one identity provider", ErrMetadata.Error())
+			}
+			entity = &entities[i]
+		}
+	}
+	if entity == nil || entity.EntityID == "" {
+		return idp, ErrMetadata
+	}
+	idp.EntityID = entity.EntityID
+
+	descriptor := entity.IDPSSODescriptor[0]
+	for _, service := range descriptor.SingleSignOnService {
+		if service.Binding == BindingRedirect {
+			idp.SSOURL = service.Location
+		}
+	}
+	if location, err := url.Parse(idp.SSOURL); err != nil || location.Scheme != "https" || location.Host == "" {
+		return idp, fmt.Errorf("%s: https single sign on service with the HTTP-Redirect binding is required", ErrMetadata.Error())
+	}
+
+	for _, key := range descriptor.KeyDescriptor {
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+		for _, encoded := range key.Certificates {
+			der, err := decodeBase64(encoded)
+			if err != nil {
+				return idp, fmt.Errorf("%s: invalid certificate", ErrMetadata.Error())
+			}
+			certificate, err := x509.ParseCertificate(der)
+			if err != nil {
+				return idp, fmt.Errorf("%s: invalid certificate", ErrMetadata.Error())
+			}
+			idp.Certificates = append(idp.Certificates, certificate)
+		}
+	}
+	if len(idp.Certificates) == 0 {
+		return idp, fmt.Errorf("%s: signing certificate is required", ErrMetadata.Error())
+	}
+	return idp, nil
+}
+
+// NewRequestID function for generating the id of an authentication request, ids have to start with a letter or `_`
+func NewRequestID() (string, error) {
+	random := make([]byte, 20)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return "_" + hex.EncodeToString(random), nil
+}
+
+type authnRequestXML struct {
+	XMLName                     xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	Destination                 string   `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	Issuer                      struct {
+		Value string `xml:",chardata"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy struct {
+		Format      string `xml:"Format,attr"`
+		AllowCreate bool   `xml:"AllowCreate,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy"`
+}
+
+// AuthnRequestURL function for getting the url the user is redirected to, the request is deflated
+// and sent with the relay state as query of the HTTP-Redirect binding
+func (sp ServiceProvider) AuthnRequestURL(idp IdentityProvider, requestID, relayState string, now time.Time) (string, error) {
+	request := authnRequestXML{
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                now.UTC().Format(time.RFC3339),
+		Destination:                 idp.SSOURL,
+		AssertionConsumerServiceURL: sp.ACSURL,
+		ProtocolBinding:             BindingPOST,
+	}
+	request.Issuer.Value = sp.EntityID
+	request.NameIDPolicy.Format = NameIDFormatEmail
+	request.NameIDPolicy.AllowCreate = true
+
+	raw, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	var deflated bytes.Buffer
+	writer, _ := flate.NewWriter(&deflated, flate.BestCompression)
+	if _, err := writer.Write(raw); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	location, err := url.Parse(idp.SSOURL)
+	if err != nil {
+		return "", err
+	}
+	query := location.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	query.Set("RelayState", relayState)
+	location.RawQuery = query.Encode()
+	return location.String(), nil
+}
+
+type spMetadataXML struct {
+	XMLName         xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string   `xml:"entityID,attr"`
+	SPSSODescriptor struct {
+		AuthnRequestsSigned        bool   `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool   `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string `xml:"protocolSupportEnumeration,attr"`
+		NameIDFormat               string `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat"`
+		AssertionConsumerService   struct {
+			Binding   string `xml:"Binding,attr"`
+			Location  string `xml:"Location,attr"`
+			Index     int    `xml:"index,attr"`
+			IsDefault bool   `xml:"isDefault,attr"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+}
+
+// Metadata function for getting the metadata given to the identity provider of the account
+func (sp ServiceProvider) Metadata() ([]byte, error) {
+	metadata := spMetadataXML{EntityID: sp.EntityID}
+	metadata.SPSSODescriptor.WantAssertionsSigned = true
+	metadata.SPSSODescriptor.ProtocolSupportEnumeration = namespaceProtocol
+	metadata.SPSSODescriptor.NameIDFormat = NameIDFormatEmail
+	metadata.SPSSODescriptor.AssertionConsumerService.Binding = BindingPOST
+	metadata.SPSSODescriptor.AssertionConsumerService.Location = sp.ACSURL
+	metadata.SPSSODescriptor.AssertionConsumerService.IsDefault = true
+
+	raw, err := xml.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), raw...), nil
+}
+
+type assertionXML struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	Issuer  string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject struct {
+		NameID               string `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				InResponseTo string `xml:"InResponseTo,attr"`
+				Recipient    string `xml:"Recipient,attr"`
+				NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+			} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions *struct {
+		NotBefore            string `xml:"NotBefore,attr"`
+		NotOnOrAfter         string `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AuthnStatements []struct {
+		SessionIndex string `xml:"SessionIndex,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AuthnStatement"`
+	Attributes []struct {
+		Name         string   `xml:"Name,attr"`
+		FriendlyName string   `xml:"FriendlyName,attr"`
+		Values       []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement>Attribute"`
+}
+
+type responseXML struct {
+	XMLName    xml.Name       `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	Assertions []assertionXML `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+}
+
+// ParseResponse function for validating the base64 response posted to the assertion consumer service.
+// The response or its only assertion has to be signed by the identity provider, the assertion has to be issued
+// for the service provider in answer to requestID and be valid at now. Data is only read from the signed xml
+func (sp ServiceProvider) ParseResponse(idp IdentityProvider, encoded, requestID string, now time.Time) (*Assertion, error) {
+	if len(encoded) > MaxResponseSize {
+		return nil, ErrResponse
+	}
+	raw, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, ErrResponse
+	}
+	response, err := parseDocument(raw)
+	if err != nil || !response.is(namespaceProtocol, "Response") {
+		return nil, ErrResponse
+	}
+
+	if destination := response.attr("Destination"); destination != "" && destination != sp.ACSURL {
+		return nil, ErrInvalidAssertion
+	}
+	if err := checkStatus(response); err != nil {
+		return nil, err
+	}
+
+	if len(response.childElements(namespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, ErrEncrypted
+	}
+	assertion, err := response.child(namespaceAssertion, "Assertion")
+	if err != nil {
+		return nil, ErrResponse
+	}
+
+	// the signature of the assertion is preferred, a signed response covers its assertion as well
+	signed := assertion
+	if len(assertion.childElements(namespaceDSig, "Signature")) == 0 {
+		signed = response
+	}
+	canonical, err := verifySignature(signed, idp.Certificates)
+	if err != nil {
+		return nil, err
+	}
+
+	data := assertionXML{}
+	if signed == assertion {
+		err = xml.Unmarshal(canonical, &data)
+	} else {
+		signedResponse := responseXML{}
+		if err = xml.Unmarshal(canonical, &signedResponse); err == nil && len(signedResponse.Assertions) == 1 {
+			data = signedResponse.Assertions[0]
+		} else if err == nil {
+			err = ErrResponse
+		}
+	}
+	if err != nil {
+		return nil, ErrResponse
+	}
+
+	return sp.checkAssertion(idp, data, requestID, now)
+}
+
+func checkStatus(response *element) error {
+	status, err := response.child(namespaceProtocol, "Status")
+	if err != nil {
+		return ErrResponse
+	}
+	code, err := status.child(namespaceProtocol, "StatusCode")
+	if err != nil {
+		return ErrResponse
+	}
+	if code.attr("Value") != statusSuccess {
+		return ErrStatus
+	}
+	return nil
+}
+
+// checkAssertion function for checking issuer, audience, validity and bearer subject confirmation of the assertion
+func (sp ServiceProvider) checkAssertion(idp IdentityProvider, data assertionXML, requestID string, now time.Time) (*Assertion, error) {
+	if strings.TrimSpace(data.Issuer) != idp.EntityID {
+		return nil, ErrInvalidAssertion
+	}
+
+	if data.Conditions == nil || len(data.Conditions.AudienceRestrictions) == 0 {
+		return nil, ErrInvalidAssertion
+	}
+	for _, restriction := range data.Conditions.AudienceRestrictions {
+		if !containsString(restriction.Audiences, sp.EntityID) {
+			return nil, ErrInvalidAssertion
+		}
+	}
+	if !withinValidity(data.Conditions.NotBefore, data.Conditions.NotOnOrAfter, now) {
+		return nil, ErrExpired
+	}
+
+	confirmed := false
+	for _, confirmation := range data.Subject.SubjectConfirmations {
+		if confirmation.Method != subjectConfirmationBearer {
+			continue
+		}
+		if confirmation.Data.Recipient != sp.ACSURL || confirmation.Data.InResponseTo != requestID || requestID == "" {
+			continue
+		}
+		if confirmation.Data.NotOnOrAfter == "" || !withinValidity("", confirmation.Data.NotOnOrAfter, now) {
+			continue
+		}
+		confirmed = true
+	}
+	if !confirmed {
+		return nil, ErrInvalidAssertion
+	}
+
+	assertion := &Assertion{NameID: strings.TrimSpace(data.Subject.NameID), Attributes: map[string][]string{}}
+	if assertion.NameID == "" {
+		return nil, ErrInvalidAssertion
+	}
+	if len(data.AuthnStatements) > 0 {
+		assertion.SessionIndex = data.AuthnStatements[0].SessionIndex
+	}
+	for _, attribute := range data.Attributes {
+		values := make([]string, 0, len(attribute.Values))
+		for _, value := range attribute.Values {
+			values = append(values, strings.TrimSpace(value))
+		}
+		for _, name := range []string{attribute.Name, attribute.FriendlyName} {
+			if name != "" {
+				assertion.Attributes[name] = append(assertion.Attributes[name], values...)
+			}
+		}
+	}
+	return assertion, nil
+}
+
+// withinValidity function for checking now is within the validity, allowing MaxClockSkew both ways
+func withinValidity(notBefore, notOnOrAfter string, now time.Time) bool {
+	if notBefore != "" {
+		start, err := time.Parse(time.RFC3339Nano, notBefore)
+		if err != nil || now.Add(MaxClockSkew).Before(start) {
+			return false
+		}
+	}
+	if notOnOrAfter != "" {
+		end, err := time.Parse(time.RFC3339Nano, notOnOrAfter)
+		if err != nil || !now.Add(-MaxClockSkew).Before(end) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPStatus function for mapping saml error into http status
+func HTTPStatus(err error) int {
+	switch err {
+	case ErrMetadata, ErrResponse, ErrEncrypted:
+		return http.StatusBadRequest
+	case ErrStatus, ErrUnsigned, ErrInvalidSignature, ErrInvalidAssertion, ErrExpired:
+		return http.StatusUnauthorized
+	}
+	if err != nil && strings.HasPrefix(err.Error(), ErrMetadata.Error()) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
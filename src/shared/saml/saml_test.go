@@ -0,0 +1,417 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testIdPEntityID = "https://idp.example.com/saml"
+	testRequestID   = "_request1"
+	testAssertionID = "_assertion1"
+	testResponseID  = "_response1"
+)
+
+var testNow = time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+
+type testIdP struct {
+	key         *rsa.PrivateKey
+	certificate *x509.Certificate
+	encodedCert string
+}
+
+func newTestIdP(t *testing.T) testIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     testNow.Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return testIdP{key: key, certificate: certificate, encodedCert: base64.StdEncoding.EncodeToString(der)}
+}
+
+func (idp testIdP) provider() IdentityProvider {
+	return IdentityProvider{EntityID: testIdPEntityID, SSOURL: "https://idp.example.com/sso", Certificates: []*x509.Certificate{idp.certificate}}
+}
+
+// signatureTemplate signature of the element with the given id, the values are filled by sign
+func signatureTemplate(id string) string {
+	return `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"/>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/></ds:Transforms>` +
+		`<ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>` +
+		`<ds:DigestValue>DIGEST</ds:DigestValue></ds:Reference></ds:SignedInfo>` +
+		`<ds:SignatureValue>SIGNATURE</ds:SignatureValue></ds:Signature>`
+}
+
+// sign function for filling the digest and signature value of the signature of the element with the given id,
+// the document must hold a single signature template
+func (idp testIdP) sign(t *testing.T, doc, id string) string {
+	root, err := parseDocument([]byte(doc))
+	assert.NoError(t, err)
+	signed := findByID(root, id)
+	signature, err := signed.child(namespaceDSig, "Signature")
+	assert.NoError(t, err)
+
+	digest := sha256.Sum256(canonicalize(signed, signature, nil))
+	doc = strings.Replace(doc, "DIGEST", base64.StdEncoding.EncodeToString(digest[:]), 1)
+
+	root, err = parseDocument([]byte(doc))
+	assert.NoError(t, err)
+	signature, err = findByID(root, id).child(namespaceDSig, "Signature")
+	assert.NoError(t, err)
+	signedInfo, err := signature.child(namespaceDSig, "SignedInfo")
+	assert.NoError(t, err)
+
+	sum := sha256.Sum256(canonicalize(signedInfo, nil, nil))
+	value, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, sum[:])
+	assert.NoError(t, err)
+	return strings.Replace(doc, "SIGNATURE", base64.StdEncoding.EncodeToString(value), 1)
+}
+
+func findByID(el *element, id string) *element {
+	if el.attr("ID") == id {
+		return el
+	}
+	for _, child := range el.children {
+		if childElement, ok := child.(*element); ok {
+			if found := findByID(childElement, id); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+type testResponse struct {
+	audience      string
+	recipient     string
+	inResponseTo  string
+	notOnOrAfter  time.Time
+	status        string
+	signAssertion bool
+	signResponse  bool
+}
+
+func defaultTestResponse(sp ServiceProvider) testResponse {
+	return testResponse{
+		audience:      sp.EntityID,
+		recipient:     sp.ACSURL,
+		inResponseTo:  testRequestID,
+		notOnOrAfter:  testNow.Add(5 * time.Minute),
+		status:        statusSuccess,
+		signAssertion: true,
+	}
+}
+
+func (r testResponse) assertion() string {
+	signature := ""
+	if r.signAssertion {
+		signature = signatureTemplate(testAssertionID)
+	}
+	return `<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="` + testAssertionID + `" Version="2.0" IssueInstant="2021-03-01T10:00:00Z">` +
+		`<saml:Issuer>` + testIdPEntityID + `</saml:Issuer>` + signature +
+		`<saml:Subject><saml:NameID Format="` + NameIDFormatEmail + `">john@corp.example.com</saml:NameID>` +
+		`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">` +
+		`<saml:SubjectConfirmationData InResponseTo="` + r.inResponseTo + `" Recipient="` + r.recipient + `" NotOnOrAfter="` + r.notOnOrAfter.Format(time.RFC3339) + `"/>` +
+		`</saml:SubjectConfirmation></saml:Subject>` +
+		`<saml:Conditions NotBefore="2021-03-01T09:59:00Z" NotOnOrAfter="` + r.notOnOrAfter.Format(time.RFC3339) + `">` +
+		`<saml:AudienceRestriction><saml:Audience>` + r.audience + `</saml:Audience></saml:AudienceRestriction></saml:Conditions>` +
+		`<saml:AuthnStatement AuthnInstant="2021-03-01T10:00:00Z" SessionIndex="_session1"/>` +
+		`<saml:AttributeStatement>` +
+		`<saml:Attribute Name="urn:oid:2.5.4.42" FriendlyName="givenName"><saml:AttributeValue>John</saml:AttributeValue></saml:Attribute>` +
+		`<saml:Attribute Name="mail"><saml:AttributeValue> john@corp.example.com </saml:AttributeValue></saml:Attribute>` +
+		`</saml:AttributeStatement></saml:Assertion>`
+}
+
+func (r testResponse) document(sp ServiceProvider, assertion string) string {
+	signature := ""
+	if r.signResponse {
+		signature = signatureTemplate(testResponseID)
+	}
+	return `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="` + testResponseID + `" Version="2.0" ` +
+		`IssueInstant="2021-03-01T10:00:00Z" Destination="` + sp.ACSURL + `" InResponseTo="` + r.inResponseTo + `">` +
+		`<saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">` + testIdPEntityID + `</saml:Issuer>` + signature +
+		`<samlp:Status><samlp:StatusCode Value="` + r.status + `"/></samlp:Status>` + assertion + `</samlp:Response>`
+}
+
+func (r testResponse) build(t *testing.T, idp testIdP, sp ServiceProvider) string {
+	assertion := r.assertion()
+	if r.signAssertion {
+		assertion = idp.sign(t, assertion, testAssertionID)
+	}
+	doc := r.document(sp, assertion)
+	if r.signResponse {
+		doc = idp.sign(t, doc, testResponseID)
+	}
+	return doc
+}
+
+// forgedAssertion unsigned assertion an attacker could add to a response
+func forgedAssertion(sp ServiceProvider) string {
+	forged := defaultTestResponse(sp)
+	forged.signAssertion = false
+	return strings.Replace(forged.assertion(), testAssertionID, "_forged", 1)
+}
+
+func TestCanonicalize(t *testing.T) {
+	root, err := parseDocument([]byte(`<?xml version="1.0"?><a:root xmlns:a="urn:a" xmlns:b="urn:b" xmlns="urn:d" z="1" b:y="2" a:x="3">` +
+		`<child/><a:leaf xmlns:a="urn:a">&lt;t&gt; &amp; "q"</a:leaf><!-- c --></a:root>`))
+	assert.NoError(t, err)
+	assert.Equal(t, `<a:root xmlns:a="urn:a" xmlns:b="urn:b" z="1" a:x="3" b:y="2"><child xmlns="urn:d"></child>`+
+		`<a:leaf>&lt;t&gt; &amp; "q"</a:leaf></a:root>`, string(canonicalize(root, nil, nil)))
+
+	leaf := findLeaf(root)
+	assert.Equal(t, `<a:leaf xmlns:a="urn:a">&lt;t&gt; &amp; "q"</a:leaf>`, string(canonicalize(leaf, nil, nil)))
+	assert.Equal(t, `<a:leaf xmlns="urn:d" xmlns:a="urn:a">&lt;t&gt; &amp; "q"</a:leaf>`, string(canonicalize(leaf, nil, []string{""})))
+
+	_, err = parseDocument([]byte(`<!DOCTYPE r [<!ENTITY e "x">]><r>&e;</r>`))
+	assert.Error(t, err)
+	_, err = parseDocument([]byte(`<r></s>`))
+	assert.Error(t, err)
+}
+
+func findLeaf(root *element) *element {
+	for _, child := range root.children {
+		if el, ok := child.(*element); ok && el.local == "leaf" {
+			return el
+		}
+	}
+	return nil
+}
+
+func TestServiceProvider_ParseResponse(t *testing.T) {
+	idp := newTestIdP(t)
+	sp := NewServiceProvider("https://accounts.bhinneka.com/", "ACC1")
+
+	tests := []struct {
+		name    string
+		change  func(r *testResponse)
+		tamper  func(doc string) string
+		wantErr error
+	}{
+		{
+			name: "Case 1: signed assertion",
+		},
+		{
+			name: "Case 2: signed response",
+			change: func(r *testResponse) {
+				r.signAssertion = false
+				r.signResponse = true
+			},
+		},
+		{
+			name: "Case 3: tampered assertion",
+			tamper: func(doc string) string {
+				return strings.Replace(doc, "<saml:AttributeValue>John", "<saml:AttributeValue>Jane", 1)
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "Case 4: unsigned",
+			change:  func(r *testResponse) { r.signAssertion = false },
+			wantErr: ErrUnsigned,
+		},
+		{
+			name:    "Case 5: other audience",
+			change:  func(r *testResponse) { r.audience = "https://other.example.com" },
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name:    "Case 6: other request",
+			change:  func(r *testResponse) { r.inResponseTo = "_request2" },
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name:    "Case 7: other recipient",
+			change:  func(r *testResponse) { r.recipient = "https://other.example.com/acs" },
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name:    "Case 8: expired",
+			change:  func(r *testResponse) { r.notOnOrAfter = testNow.Add(-5 * time.Minute) },
+			wantErr: ErrExpired,
+		},
+		{
+			name:    "Case 9: failed status",
+			change:  func(r *testResponse) { r.status = "urn:oasis:names:tc:SAML:2.0:status:Requester" },
+			wantErr: ErrStatus,
+		},
+		{
+			name: "Case 10: wrapped assertion next to the signed one",
+			tamper: func(doc string) string {
+				forged := forgedAssertion(sp)
+				return strings.Replace(doc, "</samlp:Response>", forged+"</samlp:Response>", 1)
+			},
+			wantErr: ErrResponse,
+		},
+		{
+			name: "Case 11: signed assertion moved under a forged one",
+			tamper: func(doc string) string {
+				start := strings.Index(doc, "<saml:Assertion ")
+				end := strings.Index(doc, "</samlp:Response>")
+				signed := doc[start:end]
+				forged := forgedAssertion(sp)
+				forged = strings.Replace(forged, "</saml:Assertion>", "<saml:Advice>"+signed+"</saml:Advice></saml:Assertion>", 1)
+				forged = strings.Replace(forged, "<saml:AttributeValue>John", "<saml:AttributeValue>Mallory", 1)
+				return doc[:start] + forged + doc[end:]
+			},
+			wantErr: ErrUnsigned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := defaultTestResponse(sp)
+			if tt.change != nil {
+				tt.change(&response)
+			}
+			doc := response.build(t, idp, sp)
+			if tt.tamper != nil {
+				doc = tt.tamper(doc)
+			}
+
+			assertion, err := sp.ParseResponse(idp.provider(), base64.StdEncoding.EncodeToString([]byte(doc)), testRequestID, testNow)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, assertion)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "john@corp.example.com", assertion.NameID)
+			assert.Equal(t, "_session1", assertion.SessionIndex)
+			assert.Equal(t, "John", assertion.Attribute("givenName"))
+			assert.Equal(t, "John", assertion.Attribute("urn:oid:2.5.4.42"))
+			assert.Equal(t, "john@corp.example.com", assertion.Attribute("mail"))
+		})
+	}
+}
+
+func TestServiceProvider_ParseResponse_OtherCertificate(t *testing.T) {
+	idp := newTestIdP(t)
+	other := newTestIdP(t)
+	sp := NewServiceProvider("https://accounts.bhinneka.com", "ACC1")
+
+	doc := defaultTestResponse(sp).build(t, other, sp)
+	_, err := sp.ParseResponse(idp.provider(), base64.StdEncoding.EncodeToString([]byte(doc)), testRequestID, testNow)
+	assert.Equal(t, ErrInvalidSignature, err)
+}
+
+func TestParseMetadata(t *testing.T) {
+	idp := newTestIdP(t)
+	entity := func(sso string) string {
+		return `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="` + testIdPEntityID + `">` +
+			`<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` +
+			`<md:KeyDescriptor use="encryption"><ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:X509Data><ds:X509Certificate>AAAA</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+			`<md:KeyDescriptor use="signing"><ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:X509Data><ds:X509Certificate>` +
+			idp.encodedCert + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+			`<md:SingleSignOnService Binding="` + BindingPOST + `" Location="https://idp.example.com/sso/post"/>` +
+			`<md:SingleSignOnService Binding="` + BindingRedirect + `" Location="` + sso + `"/>` +
+			`</md:IDPSSODescriptor></md:EntityDescriptor>`
+	}
+
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr bool
+	}{
+		{
+			name: "Case 1: entity descriptor",
+			raw:  entity("https://idp.example.com/sso"),
+		},
+		{
+			name: "Case 2: entities descriptor with one identity provider",
+			raw:  `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata">` + entity("https://idp.example.com/sso") + `</md:EntitiesDescriptor>`,
+		},
+		{
+			name:    "Case 3: entities descriptor with two identity providers",
+			raw:     `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata">` + entity("https://idp.example.com/sso") + entity("https://idp.example.com/sso") + `</md:EntitiesDescriptor>`,
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: single sign on service without https",
+			raw:     entity("http://idp.example.com/sso"),
+			wantErr: true,
+		},
+		{
+			name:    "Case 5: not metadata",
+			raw:     `<html></html>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := ParseMetadata([]byte(tt.raw))
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, 400, HTTPStatus(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testIdPEntityID, provider.EntityID)
+			assert.Equal(t, "https://idp.example.com/sso", provider.SSOURL)
+			assert.Len(t, provider.Certificates, 1)
+		})
+	}
+}
+
+func TestServiceProvider_AuthnRequestURL(t *testing.T) {
+	idp := newTestIdP(t)
+	sp := NewServiceProvider("https://accounts.bhinneka.com", "ACC1")
+	assert.Equal(t, "https://accounts.bhinneka.com/api/v2/auth/saml/ACC1/metadata", sp.EntityID)
+	assert.Equal(t, "https://accounts.bhinneka.com/api/v2/auth/saml/ACC1/acs", sp.ACSURL)
+
+	provider := idp.provider()
+	provider.SSOURL = "https://idp.example.com/sso?tenant=corp"
+	location, err := sp.AuthnRequestURL(provider, testRequestID, "relay-state", testNow)
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(location)
+	assert.NoError(t, err)
+	assert.Equal(t, "idp.example.com", parsed.Host)
+	assert.Equal(t, "corp", parsed.Query().Get("tenant"))
+	assert.Equal(t, "relay-state", parsed.Query().Get("RelayState"))
+
+	deflated, err := base64.StdEncoding.DecodeString(parsed.Query().Get("SAMLRequest"))
+	assert.NoError(t, err)
+	raw, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	assert.NoError(t, err)
+	request, err := parseDocument(raw)
+	assert.NoError(t, err)
+	assert.True(t, request.is(namespaceProtocol, "AuthnRequest"))
+	assert.Equal(t, testRequestID, request.attr("ID"))
+	assert.Equal(t, sp.ACSURL, request.attr("AssertionConsumerServiceURL"))
+	issuer, err := request.child(namespaceAssertion, "Issuer")
+	assert.NoError(t, err)
+	assert.Equal(t, sp.EntityID, issuer.text())
+
+	metadata, err := sp.Metadata()
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(metadata), fmt.Sprintf(`entityID="%s"`, sp.EntityID)))
+	assert.True(t, strings.Contains(string(metadata), fmt.Sprintf(`Location="%s"`, sp.ACSURL)))
+}
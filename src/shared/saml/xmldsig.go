@@ -0,0 +1,440 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	namespaceXML   = "http://www.w3.org/XML/1998/namespace"
+	namespaceDSig  = "http://www.w3.org/2000/09/xmldsig#"
+	namespaceExcNS = "http://www.w3.org/2001/10/xml-exc-c14n#"
+
+	algorithmExcC14N     = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmEnveloped   = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	algorithmSHA256      = "http://www.w3.org/2001/04/xmlenc#sha256"
+	algorithmSHA512      = "http://www.w3.org/2001/04/xmlenc#sha512"
+	algorithmRSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	algorithmRSASHA512   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	defaultNamespaceName = "#default"
+)
+
+// digestAlgorithms sha1 is left out on purpose, signatures using it are refused
+var digestAlgorithms = map[string]crypto.Hash{
+	algorithmSHA256: crypto.SHA256,
+	algorithmSHA512: crypto.SHA512,
+}
+
+var signatureAlgorithms = map[string]crypto.Hash{
+	algorithmRSASHA256: crypto.SHA256,
+	algorithmRSASHA512: crypto.SHA512,
+}
+
+// element node of a parsed document, the prefixes are kept as written so it can be canonicalized
+type element struct {
+	prefix   string
+	local    string
+	space    string
+	attrs    []attribute
+	children []interface{}
+	parent   *element
+	// namespaces declared on the element, the default namespace has an empty prefix
+	namespaces map[string]string
+}
+
+type attribute struct {
+	prefix string
+	local  string
+	space  string
+	value  string
+}
+
+// parseDocument function for reading xml into elements, doctypes are refused so no entity is ever expanded
+func parseDocument(raw []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if root != nil && current == nil {
+				return nil, errors.New("xml has more than one root element")
+			}
+			el, err := newElement(t, current)
+			if err != nil {
+				return nil, err
+			}
+			if current == nil {
+				root = el
+			} else {
+				current.children = append(current.children, el)
+			}
+			current = el
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.prefix || t.Name.Local != current.local {
+				return nil, errors.New("xml end element does not match")
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			}
+		case xml.Directive:
+			return nil, errors.New("xml directives are not allowed")
+		}
+	}
+
+	if root == nil || current != nil {
+		return nil, errors.New("xml document is incomplete")
+	}
+	return root, nil
+}
+
+func newElement(start xml.StartElement, parent *element) (*element, error) {
+	el := &element{
+		prefix:     start.Name.Space,
+		local:      start.Name.Local,
+		parent:     parent,
+		namespaces: map[string]string{},
+	}
+
+	seen := map[string]bool{}
+	for _, attr := range start.Attr {
+		name := attr.Name.Space + ":" + attr.Name.Local
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate xml attribute %s", attr.Name.Local)
+		}
+		seen[name] = true
+
+		switch {
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			el.namespaces[""] = attr.Value
+		case attr.Name.Space == "xmlns":
+			el.namespaces[attr.Name.Local] = attr.Value
+		default:
+			el.attrs = append(el.attrs, attribute{prefix: attr.Name.Space, local: attr.Name.Local, value: attr.Value})
+		}
+	}
+
+	var ok bool
+	if el.space, ok = el.lookupNamespace(el.prefix); !ok {
+		return nil, fmt.Errorf("undeclared xml namespace prefix %s", el.prefix)
+	}
+	for i := range el.attrs {
+		if el.attrs[i].prefix == "" {
+			continue
+		}
+		if el.attrs[i].space, ok = el.lookupNamespace(el.attrs[i].prefix); !ok {
+			return nil, fmt.Errorf("undeclared xml namespace prefix %s", el.attrs[i].prefix)
+		}
+	}
+	return el, nil
+}
+
+// lookupNamespace function for resolving a prefix in scope of the element, the default namespace may be empty
+func (el *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return namespaceXML, true
+	}
+	for e := el; e != nil; e = e.parent {
+		if space, ok := e.namespaces[prefix]; ok {
+			return space, true
+		}
+	}
+	return "", prefix == ""
+}
+
+func (el *element) is(space, local string) bool {
+	return el.space == space && el.local == local
+}
+
+func (el *element) attr(local string) string {
+	for _, attr := range el.attrs {
+		if attr.prefix == "" && attr.local == local {
+			return attr.value
+		}
+	}
+	return ""
+}
+
+// childElements function for listing direct child elements with the name
+func (el *element) childElements(space, local string) []*element {
+	children := []*element{}
+	for _, child := range el.children {
+		if c, ok := child.(*element); ok && c.is(space, local) {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// child function for getting the only direct child element with the name
+func (el *element) child(space, local string) (*element, error) {
+	children := el.childElements(space, local)
+	if len(children) != 1 {
+		return nil, fmt.Errorf("expected one %s in %s", local, el.local)
+	}
+	return children[0], nil
+}
+
+func (el *element) text() string {
+	var sb strings.Builder
+	for _, child := range el.children {
+		if s, ok := child.(string); ok {
+			sb.WriteString(s)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// canonicalize function for exclusive xml canonicalization without comments of the element,
+// the excluded element and everything in it is left out. Prefixes of inclusive are rendered whenever in scope
+func canonicalize(el *element, excluded *element, inclusive []string) []byte {
+	var buf bytes.Buffer
+	writeCanonical(&buf, el, excluded, inclusive, map[string]string{})
+	return buf.Bytes()
+}
+
+func writeCanonical(buf *bytes.Buffer, el *element, excluded *element, inclusive []string, rendered map[string]string) {
+	// the namespaces visibly used by the element and its attributes, plus the inclusive ones
+	prefixes := map[string]bool{el.prefix: true}
+	for _, attr := range el.attrs {
+		if attr.prefix != "" && attr.prefix != "xml" {
+			prefixes[attr.prefix] = true
+		}
+	}
+	for _, prefix := range inclusive {
+		if _, ok := el.lookupNamespace(prefix); ok && prefix != "xml" {
+			prefixes[prefix] = true
+		}
+	}
+
+	declared := map[string]string{}
+	for prefix := range prefixes {
+		space, _ := el.lookupNamespace(prefix)
+		current, found := rendered[prefix]
+		if found && current == space {
+			continue
+		}
+		// an empty default namespace is only rendered to undo the default namespace of an output ancestor
+		if !found && prefix == "" && space == "" {
+			continue
+		}
+		declared[prefix] = space
+	}
+
+	names := make([]string, 0, len(declared))
+	for prefix := range declared {
+		names = append(names, prefix)
+	}
+	sort.Strings(names)
+
+	attrs := append([]attribute(nil), el.attrs...)
+	sort.Slice(attrs, func(i, j int) bool {
+		if attrs[i].space != attrs[j].space {
+			return attrs[i].space < attrs[j].space
+		}
+		return attrs[i].local < attrs[j].local
+	})
+
+	buf.WriteString("<" + qualifiedName(el.prefix, el.local))
+	for _, prefix := range names {
+		if prefix == "" {
+			buf.WriteString(` xmlns="`)
+		} else {
+			buf.WriteString(" xmlns:" + prefix + `="`)
+		}
+		buf.WriteString(escapeAttr(declared[prefix]) + `"`)
+	}
+	for _, attr := range attrs {
+		buf.WriteString(" " + qualifiedName(attr.prefix, attr.local) + `="` + escapeAttr(attr.value) + `"`)
+	}
+	buf.WriteString(">")
+
+	inner := rendered
+	if len(declared) > 0 {
+		inner = make(map[string]string, len(rendered)+len(declared))
+		for prefix, space := range rendered {
+			inner[prefix] = space
+		}
+		for prefix, space := range declared {
+			inner[prefix] = space
+		}
+	}
+
+	for _, child := range el.children {
+		switch c := child.(type) {
+		case *element:
+			if c != excluded {
+				writeCanonical(buf, c, excluded, inclusive, inner)
+			}
+		case string:
+			buf.WriteString(escapeText(c))
+		}
+	}
+	buf.WriteString("</" + qualifiedName(el.prefix, el.local) + ">")
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+// verifySignature function for checking the enveloped signature of the element against the certificates,
+// returns the canonical form of the signed element, the only bytes the caller should read data from.
+// The signature has to be a direct child and reference the element by its ID, which rules out wrapping attacks
+func verifySignature(el *element, certificates []*x509.Certificate) ([]byte, error) {
+	signature, err := el.child(namespaceDSig, "Signature")
+	if err != nil {
+		return nil, ErrUnsigned
+	}
+	signedInfo, err := signature.child(namespaceDSig, "SignedInfo")
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	c14nMethod, err := signedInfo.child(namespaceDSig, "CanonicalizationMethod")
+	if err != nil || c14nMethod.attr("Algorithm") != algorithmExcC14N {
+		return nil, ErrInvalidSignature
+	}
+	signatureMethod, err := signedInfo.child(namespaceDSig, "SignatureMethod")
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	signatureHash, ok := signatureAlgorithms[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+
+	reference, err := signedInfo.child(namespaceDSig, "Reference")
+	if err != nil || el.attr("ID") == "" || reference.attr("URI") != "#"+el.attr("ID") {
+		return nil, ErrInvalidSignature
+	}
+
+	inclusive, err := referenceTransforms(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	digestMethod, err := reference.child(namespaceDSig, "DigestMethod")
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	digestHash, ok := digestAlgorithms[digestMethod.attr("Algorithm")]
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+	digestValue, err := reference.child(namespaceDSig, "DigestValue")
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	expectedDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	signed := canonicalize(el, signature, inclusive)
+	digest := digestHash.New()
+	digest.Write(signed)
+	if subtle.ConstantTimeCompare(digest.Sum(nil), expectedDigest) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	signatureValue, err := signature.child(namespaceDSig, "SignatureValue")
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	value, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	hashed := signatureHash.New()
+	hashed.Write(canonicalize(signedInfo, nil, inclusivePrefixes(c14nMethod)))
+	sum := hashed.Sum(nil)
+	for _, certificate := range certificates {
+		publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+		if ok && rsa.VerifyPKCS1v15(publicKey, signatureHash, sum, value) == nil {
+			return signed, nil
+		}
+	}
+	return nil, ErrInvalidSignature
+}
+
+// referenceTransforms function for checking the reference only uses the enveloped signature and
+// exclusive canonicalization transforms, returns the inclusive prefixes of the canonicalization
+func referenceTransforms(reference *element) ([]string, error) {
+	transforms, err := reference.child(namespaceDSig, "Transforms")
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	enveloped := false
+	var inclusive []string
+	for _, transform := range transforms.childElements(namespaceDSig, "Transform") {
+		switch transform.attr("Algorithm") {
+		case algorithmEnveloped:
+			enveloped = true
+		case algorithmExcC14N:
+			inclusive = inclusivePrefixes(transform)
+		default:
+			return nil, ErrInvalidSignature
+		}
+	}
+	if !enveloped {
+		return nil, ErrInvalidSignature
+	}
+	return inclusive, nil
+}
+
+// inclusivePrefixes function for reading the InclusiveNamespaces PrefixList of an exclusive canonicalization
+func inclusivePrefixes(method *element) []string {
+	var prefixes []string
+	for _, namespaces := range method.childElements(namespaceExcNS, "InclusiveNamespaces") {
+		for _, prefix := range strings.Fields(namespaces.attr("PrefixList")) {
+			if prefix == defaultNamespaceName {
+				prefix = ""
+			}
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// decodeBase64 function for decoding base64 of xml text, which may be wrapped over lines
+func decodeBase64(value string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+}
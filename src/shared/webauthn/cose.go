@@ -0,0 +1,124 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+const (
+	// AlgES256 cose algorithm identifier of ECDSA P-256 with SHA-256
+	AlgES256 = -7
+	// AlgEdDSA cose algorithm identifier of EdDSA over Ed25519
+	AlgEdDSA = -8
+	// AlgRS256 cose algorithm identifier of RSASSA-PKCS1-v1_5 with SHA-256
+	AlgRS256 = -257
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+var (
+	// ErrUnsupportedAlgorithm error when the credential public key uses an algorithm the service cannot verify
+	ErrUnsupportedAlgorithm = errors.New("unsupported credential algorithm")
+	// ErrInvalidSignature error when the assertion signature does not match the credential public key
+	ErrInvalidSignature = errors.New("invalid assertion signature")
+)
+
+// SupportedAlgorithms list of cose algorithms offered on registration, in order of preference
+var SupportedAlgorithms = []int{AlgES256, AlgEdDSA, AlgRS256}
+
+// publicKey data structure of a parsed cose credential public key
+type publicKey struct {
+	Algorithm int
+	key       crypto.PublicKey
+}
+
+// parsePublicKey function for parsing cose encoded credential public key
+func parsePublicKey(coseKey []byte) (*publicKey, error) {
+	item, rest, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, errCBORMalformed
+	}
+
+	params, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, errCBORMalformed
+	}
+
+	kty, _ := params[int64(1)].(int64)
+	alg, _ := params[int64(3)].(int64)
+	pk := &publicKey{Algorithm: int(alg)}
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == AlgES256:
+		crv, _ := params[int64(-1)].(int64)
+		x, _ := params[int64(-2)].([]byte)
+		y, _ := params[int64(-3)].([]byte)
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		ecKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !ecKey.Curve.IsOnCurve(ecKey.X, ecKey.Y) {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		pk.key = ecKey
+
+	case kty == coseKeyTypeOKP && alg == AlgEdDSA:
+		crv, _ := params[int64(-1)].(int64)
+		x, _ := params[int64(-2)].([]byte)
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		pk.key = ed25519.PublicKey(x)
+
+	case kty == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := params[int64(-1)].([]byte)
+		e, _ := params[int64(-2)].([]byte)
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) < 256 || !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 3 {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		pk.key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return pk, nil
+}
+
+// verify function for checking the signature of message
+func (pk *publicKey) verify(message, signature []byte) error {
+	switch key := pk.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, message, signature) {
+			return ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+	return nil
+}
@@ -0,0 +1,483 @@
+// Package webauthn implements the relying party side of the WebAuthn registration and assertion ceremonies
+// used for passkeys. Attestation statements are not verified, the service requests `none` attestation and
+// does not make trust decisions based on the authenticator model.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// CeremonyCreate client data type of registration ceremony
+	CeremonyCreate = "webauthn.create"
+	// CeremonyGet client data type of assertion ceremony
+	CeremonyGet = "webauthn.get"
+
+	// CredentialTypePublicKey the only webauthn credential type
+	CredentialTypePublicKey = "public-key"
+
+	// UserVerificationRequired user verification requirement for passwordless login
+	UserVerificationRequired = "required"
+	// UserVerificationPreferred user verification requirement when the passkey is a second factor
+	UserVerificationPreferred = "preferred"
+
+	// ResidentKeyPreferred asks the authenticator for a discoverable credential
+	ResidentKeyPreferred = "preferred"
+
+	// AttestationNone attestation conveyance preference
+	AttestationNone = "none"
+
+	// DefaultTimeout time the member has to finish a ceremony
+	DefaultTimeout = 5 * time.Minute
+
+	challengeLength = 32
+
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+var (
+	// ErrInvalidClientData error when the client data does not belong to the expected ceremony
+	ErrInvalidClientData = errors.New("invalid client data")
+	// ErrChallengeMismatch error when the signed challenge is not the one issued by the service
+	ErrChallengeMismatch = errors.New("challenge does not match")
+	// ErrOriginNotAllowed error when the ceremony ran on an origin outside of the relying party
+	ErrOriginNotAllowed = errors.New("origin is not allowed")
+	// ErrInvalidAuthenticatorData error when authenticator data cannot be parsed or belongs to another relying party
+	ErrInvalidAuthenticatorData = errors.New("invalid authenticator data")
+	// ErrUserNotPresent error when the authenticator did not test user presence
+	ErrUserNotPresent = errors.New("user presence is required")
+	// ErrUserNotVerified error when user verification is required but was not performed
+	ErrUserNotVerified = errors.New("user verification is required")
+	// ErrSignCount error when the signature counter went backwards, the credential may have been cloned
+	ErrSignCount = errors.New("signature counter did not increase")
+)
+
+// RelyingParty data structure of the service identity towards authenticators
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+	Timeout time.Duration
+}
+
+// NewRelyingParty function for initializing relying party, origins is a comma separated list
+func NewRelyingParty(id, name, origins string) *RelyingParty {
+	rp := &RelyingParty{ID: id, Name: name, Timeout: DefaultTimeout}
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			rp.Origins = append(rp.Origins, strings.TrimRight(origin, "/"))
+		}
+	}
+	return rp
+}
+
+// RelyingPartyEntity data structure
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity data structure, id is the base64url encoded user handle
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameter data structure
+type CredentialParameter struct {
+	Type      string `json:"type"`
+	Algorithm int    `json:"alg"`
+}
+
+// CredentialDescriptor data structure, id is the base64url encoded credential id
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection data structure
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions data structure of PublicKeyCredentialCreationOptions in its JSON form
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions data structure of PublicKeyCredentialRequestOptions in its JSON form
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	Timeout          int64                  `json:"timeout"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestationResponse data structure of a registration PublicKeyCredential in its JSON form
+type AttestationResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports,omitempty"`
+	} `json:"response"`
+}
+
+// AssertionResponse data structure of an authentication PublicKeyCredential in its JSON form
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle,omitempty"`
+	} `json:"response"`
+}
+
+// Credential data structure of a verified registration
+type Credential struct {
+	ID           []byte
+	PublicKey    []byte
+	Algorithm    int
+	SignCount    uint32
+	AAGUID       []byte
+	Transports   []string
+	UserVerified bool
+}
+
+// Assertion data structure of a verified assertion
+type Assertion struct {
+	CredentialID []byte
+	UserHandle   []byte
+	SignCount    uint32
+	UserVerified bool
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type authenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+// EncodeBase64 function for encoding binary value the way webauthn JSON does, base64url without padding
+func EncodeBase64(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// DecodeBase64 function for decoding base64url value with or without padding
+func DecodeBase64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+// NewChallenge function for generating a random ceremony challenge
+func NewChallenge() (string, error) {
+	challenge := make([]byte, challengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+	return EncodeBase64(challenge), nil
+}
+
+// CreationOptions function for building registration options,
+// credentials already registered by the user are excluded so one authenticator is registered once
+func (rp *RelyingParty) CreationOptions(challenge string, user UserEntity, exclude []CredentialDescriptor) CreationOptions {
+	params := make([]CredentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, CredentialParameter{Type: CredentialTypePublicKey, Algorithm: alg})
+	}
+
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+
+	return CreationOptions{
+		Challenge:          challenge,
+		RP:                 RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:               user,
+		PubKeyCredParams:   params,
+		Timeout:            rp.Timeout.Milliseconds(),
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      ResidentKeyPreferred,
+			UserVerification: UserVerificationPreferred,
+		},
+		Attestation: AttestationNone,
+	}
+}
+
+// RequestOptions function for building assertion options, an empty allow list lets the authenticator offer
+// any discoverable credential of this relying party
+func (rp *RelyingParty) RequestOptions(challenge string, allow []CredentialDescriptor, userVerification string) RequestOptions {
+	if allow == nil {
+		allow = []CredentialDescriptor{}
+	}
+
+	return RequestOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		Timeout:          rp.Timeout.Milliseconds(),
+		AllowCredentials: allow,
+		UserVerification: userVerification,
+	}
+}
+
+// VerifyRegistration function for verifying the result of navigator.credentials.create against the issued challenge
+func (rp *RelyingParty) VerifyRegistration(challenge string, response AttestationResponse) (*Credential, error) {
+	if response.Type != CredentialTypePublicKey {
+		return nil, ErrInvalidClientData
+	}
+
+	if _, err := rp.verifyClientData(response.Response.ClientDataJSON, CeremonyCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	rawAttestation, err := DecodeBase64(response.Response.AttestationObject)
+	if err != nil {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	item, _, err := decodeCBOR(rawAttestation)
+	if err != nil {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	attestation, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	authData, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+
+	if authData.Flags&flagAttestedData == 0 {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	rawID, err := DecodeBase64(rawCredentialID(response.ID, response.RawID))
+	if err != nil || !bytes.Equal(rawID, authData.CredentialID) {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	pk, err := parsePublicKey(authData.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:           authData.CredentialID,
+		PublicKey:    authData.PublicKey,
+		Algorithm:    pk.Algorithm,
+		SignCount:    authData.SignCount,
+		AAGUID:       authData.AAGUID,
+		Transports:   response.Response.Transports,
+		UserVerified: authData.Flags&flagUserVerified != 0,
+	}, nil
+}
+
+// VerifyAssertion function for verifying the result of navigator.credentials.get against the issued challenge
+// and the stored credential, the returned sign count must be persisted for the next assertion
+func (rp *RelyingParty) VerifyAssertion(challenge string, response AssertionResponse, publicKey []byte, signCount uint32, requireUserVerification bool) (*Assertion, error) {
+	if response.Type != CredentialTypePublicKey {
+		return nil, ErrInvalidClientData
+	}
+
+	rawClientData, err := rp.verifyClientData(response.Response.ClientDataJSON, CeremonyGet, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	rawAuthData, err := DecodeBase64(response.Response.AuthenticatorData)
+	if err != nil {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	authData, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+
+	if requireUserVerification && authData.Flags&flagUserVerified == 0 {
+		return nil, ErrUserNotVerified
+	}
+
+	pk, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := DecodeBase64(response.Response.Signature)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	clientDataHash := sha256.Sum256(rawClientData)
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash[:]...)
+	if err := pk.verify(signed, signature); err != nil {
+		return nil, err
+	}
+
+	// authenticators without a counter always report zero
+	if (authData.SignCount != 0 || signCount != 0) && authData.SignCount <= signCount {
+		return nil, ErrSignCount
+	}
+
+	credentialID, _ := DecodeBase64(rawCredentialID(response.ID, response.RawID))
+	userHandle, _ := DecodeBase64(response.Response.UserHandle)
+
+	return &Assertion{
+		CredentialID: credentialID,
+		UserHandle:   userHandle,
+		SignCount:    authData.SignCount,
+		UserVerified: authData.Flags&flagUserVerified != 0,
+	}, nil
+}
+
+// CredentialID function for getting the base64url encoded id of the asserted credential
+func (r AssertionResponse) CredentialID() string {
+	return rawCredentialID(r.ID, r.RawID)
+}
+
+// Challenge function for reading the challenge claimed by the client, it is not verified
+// and only meant for looking up the challenge issued by the server
+func (r AssertionResponse) Challenge() string {
+	raw, err := DecodeBase64(r.Response.ClientDataJSON)
+	if err != nil {
+		return ""
+	}
+
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return ""
+	}
+	return strings.TrimRight(data.Challenge, "=")
+}
+
+// some clients only send the id, which is the base64url form of rawId
+func rawCredentialID(id, rawID string) string {
+	if rawID == "" {
+		return id
+	}
+	return rawID
+}
+
+func (rp *RelyingParty) verifyClientData(encoded, ceremony, challenge string) ([]byte, error) {
+	raw, err := DecodeBase64(encoded)
+	if err != nil {
+		return nil, ErrInvalidClientData
+	}
+
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil || data.Type != ceremony {
+		return nil, ErrInvalidClientData
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(strings.TrimRight(data.Challenge, "=")), []byte(challenge)) != 1 {
+		return nil, ErrChallengeMismatch
+	}
+
+	if !rp.allowedOrigin(data.Origin) {
+		return nil, ErrOriginNotAllowed
+	}
+
+	return raw, nil
+}
+
+func (rp *RelyingParty) allowedOrigin(origin string) bool {
+	for _, allowed := range rp.Origins {
+		if origin == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (rp *RelyingParty) parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	// rpIdHash (32) + flags (1) + signCount (4)
+	if len(raw) < 37 {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	data := &authenticatorData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(data.RPIDHash, rpIDHash[:]) != 1 {
+		return nil, ErrInvalidAuthenticatorData
+	}
+
+	if data.Flags&flagUserPresent == 0 {
+		return nil, ErrUserNotPresent
+	}
+
+	if data.Flags&flagAttestedData == 0 {
+		return data, nil
+	}
+
+	// aaguid (16) + credentialIdLength (2) + credentialId + credentialPublicKey
+	rest := raw[37:]
+	if len(rest) < 18 {
+		return nil, ErrInvalidAuthenticatorData
+	}
+	data.AAGUID = rest[:16]
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLength == 0 || len(rest) < idLength {
+		return nil, ErrInvalidAuthenticatorData
+	}
+	data.CredentialID = rest[:idLength]
+	rest = rest[idLength:]
+
+	// the public key is followed by the extensions, if any
+	_, extensions, err := decodeCBOR(rest)
+	if err != nil {
+		return nil, ErrInvalidAuthenticatorData
+	}
+	data.PublicKey = rest[:len(rest)-len(extensions)]
+
+	return data, nil
+}
@@ -0,0 +1,314 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRPID   = "bhinneka.com"
+	testOrigin = "https://www.bhinneka.com"
+)
+
+// cborPair keeps map keys in the order an authenticator writes them
+type cborPair struct {
+	key   interface{}
+	value interface{}
+}
+
+func encodeCBORHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 1<<8:
+		return []byte{major<<5 | 24, byte(n)}
+	case n < 1<<16:
+		head := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(head[1:], uint16(n))
+		return head
+	default:
+		head := []byte{major<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(head[1:], uint32(n))
+		return head
+	}
+}
+
+func encodeCBOR(value interface{}) []byte {
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return encodeCBORHead(1, uint64(-1-v))
+		}
+		return encodeCBORHead(0, uint64(v))
+	case []byte:
+		return append(encodeCBORHead(2, uint64(len(v))), v...)
+	case string:
+		return append(encodeCBORHead(3, uint64(len(v))), v...)
+	case []cborPair:
+		out := encodeCBORHead(5, uint64(len(v)))
+		for _, pair := range v {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	}
+	panic("unsupported cbor value")
+}
+
+type testAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	return &testAuthenticator{key: key, credentialID: []byte("credential-0001")}
+}
+
+func (a *testAuthenticator) coseKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	return encodeCBOR([]cborPair{
+		{1, coseKeyTypeEC2},
+		{3, AlgES256},
+		{-1, coseCurveP256},
+		{-2, x},
+		{-3, y},
+	})
+}
+
+func (a *testAuthenticator) authData(rpID string, flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte(nil), rpIDHash[:]...)
+	data = append(data, flags)
+	counter := make([]byte, 4)
+	binary.BigEndian.PutUint32(counter, a.signCount)
+	data = append(data, counter...)
+
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		idLength := make([]byte, 2)
+		binary.BigEndian.PutUint16(idLength, uint16(len(a.credentialID)))
+		data = append(data, idLength...)
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func clientDataJSON(ceremony, challenge, origin string) string {
+	raw, _ := json.Marshal(clientData{Type: ceremony, Challenge: challenge, Origin: origin})
+	return EncodeBase64(raw)
+}
+
+func (a *testAuthenticator) create(challenge, origin string) AttestationResponse {
+	response := AttestationResponse{
+		ID:    EncodeBase64(a.credentialID),
+		RawID: EncodeBase64(a.credentialID),
+		Type:  CredentialTypePublicKey,
+	}
+	response.Response.ClientDataJSON = clientDataJSON(CeremonyCreate, challenge, origin)
+	response.Response.AttestationObject = EncodeBase64(encodeCBOR([]cborPair{
+		{"fmt", AttestationNone},
+		{"attStmt", []cborPair{}},
+		{"authData", a.authData(testRPID, flagUserPresent|flagUserVerified|flagAttestedData, true)},
+	}))
+	response.Response.Transports = []string{"internal"}
+	return response
+}
+
+func (a *testAuthenticator) get(t *testing.T, challenge, origin string, flags byte) AssertionResponse {
+	a.signCount++
+	authData := a.authData(testRPID, flags, false)
+	rawClientData := clientDataJSON(CeremonyGet, challenge, origin)
+	decodedClientData, _ := DecodeBase64(rawClientData)
+	clientDataHash := sha256.Sum256(decodedClientData)
+	digest := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	assert.NoError(t, err)
+
+	response := AssertionResponse{
+		ID:    EncodeBase64(a.credentialID),
+		RawID: EncodeBase64(a.credentialID),
+		Type:  CredentialTypePublicKey,
+	}
+	response.Response.ClientDataJSON = rawClientData
+	response.Response.AuthenticatorData = EncodeBase64(authData)
+	response.Response.Signature = EncodeBase64(signature)
+	response.Response.UserHandle = EncodeBase64([]byte("USR123"))
+	return response
+}
+
+func TestNewRelyingParty(t *testing.T) {
+	rp := NewRelyingParty(testRPID, "Bhinneka", " https://www.bhinneka.com/, https://m.bhinneka.com ,")
+	assert.Equal(t, []string{"https://www.bhinneka.com", "https://m.bhinneka.com"}, rp.Origins)
+	assert.Equal(t, DefaultTimeout, rp.Timeout)
+}
+
+func TestRelyingPartyOptions(t *testing.T) {
+	rp := NewRelyingParty(testRPID, "Bhinneka", testOrigin)
+
+	creation := rp.CreationOptions("challenge", UserEntity{ID: "VVNSMTIz", Name: "me@bhinneka.com"}, nil)
+	assert.Equal(t, testRPID, creation.RP.ID)
+	assert.Equal(t, AttestationNone, creation.Attestation)
+	assert.Len(t, creation.PubKeyCredParams, len(SupportedAlgorithms))
+	assert.NotNil(t, creation.ExcludeCredentials)
+
+	request := rp.RequestOptions("challenge", nil, UserVerificationRequired)
+	assert.Equal(t, testRPID, request.RPID)
+	assert.Equal(t, UserVerificationRequired, request.UserVerification)
+	assert.NotNil(t, request.AllowCredentials)
+}
+
+func TestRelyingPartyVerifyRegistration(t *testing.T) {
+	rp := NewRelyingParty(testRPID, "Bhinneka", testOrigin)
+	authenticator := newTestAuthenticator(t)
+
+	tests := []struct {
+		name      string
+		challenge string
+		response  func() AttestationResponse
+		wantErr   error
+	}{
+		{
+			name:      "Testcase #1: Positive",
+			challenge: "challenge-1",
+			response:  func() AttestationResponse { return authenticator.create("challenge-1", testOrigin) },
+		},
+		{
+			name:      "Testcase #2: Negative, challenge mismatch",
+			challenge: "challenge-2",
+			response:  func() AttestationResponse { return authenticator.create("challenge-1", testOrigin) },
+			wantErr:   ErrChallengeMismatch,
+		},
+		{
+			name:      "Testcase #3: Negative, origin not allowed",
+			challenge: "challenge-1",
+			response:  func() AttestationResponse { return authenticator.create("challenge-1", "https://evil.example") },
+			wantErr:   ErrOriginNotAllowed,
+		},
+		{
+			name:      "Testcase #4: Negative, assertion client data",
+			challenge: "challenge-1",
+			response: func() AttestationResponse {
+				response := authenticator.create("challenge-1", testOrigin)
+				response.Response.ClientDataJSON = clientDataJSON(CeremonyGet, "challenge-1", testOrigin)
+				return response
+			},
+			wantErr: ErrInvalidClientData,
+		},
+		{
+			name:      "Testcase #5: Negative, raw id mismatch",
+			challenge: "challenge-1",
+			response: func() AttestationResponse {
+				response := authenticator.create("challenge-1", testOrigin)
+				response.RawID = EncodeBase64([]byte("another-credential"))
+				return response
+			},
+			wantErr: ErrInvalidAuthenticatorData,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credential, err := rp.VerifyRegistration(tt.challenge, tt.response())
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, authenticator.credentialID, credential.ID)
+			assert.Equal(t, AlgES256, credential.Algorithm)
+			assert.Equal(t, []string{"internal"}, credential.Transports)
+			assert.True(t, credential.UserVerified)
+		})
+	}
+}
+
+func TestRelyingPartyVerifyAssertion(t *testing.T) {
+	rp := NewRelyingParty(testRPID, "Bhinneka", testOrigin)
+	authenticator := newTestAuthenticator(t)
+	publicKey := authenticator.coseKey()
+
+	t.Run("Testcase #1: Positive", func(t *testing.T) {
+		response := authenticator.get(t, "challenge-1", testOrigin, flagUserPresent|flagUserVerified)
+		assertion, err := rp.VerifyAssertion("challenge-1", response, publicKey, 0, true)
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(1), assertion.SignCount)
+		assert.Equal(t, []byte("USR123"), assertion.UserHandle)
+		assert.Equal(t, EncodeBase64(authenticator.credentialID), response.CredentialID())
+		assert.Equal(t, "challenge-1", response.Challenge())
+		assert.Equal(t, "", AssertionResponse{}.Challenge())
+	})
+
+	t.Run("Testcase #2: Negative, user not verified", func(t *testing.T) {
+		response := authenticator.get(t, "challenge-1", testOrigin, flagUserPresent)
+		_, err := rp.VerifyAssertion("challenge-1", response, publicKey, 0, true)
+		assert.Equal(t, ErrUserNotVerified, err)
+
+		// a second factor only needs user presence
+		_, err = rp.VerifyAssertion("challenge-1", response, publicKey, 0, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Testcase #3: Negative, sign count went backwards", func(t *testing.T) {
+		response := authenticator.get(t, "challenge-1", testOrigin, flagUserPresent|flagUserVerified)
+		_, err := rp.VerifyAssertion("challenge-1", response, publicKey, authenticator.signCount, true)
+		assert.Equal(t, ErrSignCount, err)
+	})
+
+	t.Run("Testcase #4: Negative, tampered signature", func(t *testing.T) {
+		response := authenticator.get(t, "challenge-1", testOrigin, flagUserPresent|flagUserVerified)
+		response.Response.Signature = authenticator.get(t, "challenge-2", testOrigin, flagUserPresent|flagUserVerified).Response.Signature
+		_, err := rp.VerifyAssertion("challenge-1", response, publicKey, 0, true)
+		assert.Equal(t, ErrInvalidSignature, err)
+	})
+
+	t.Run("Testcase #5: Negative, another relying party", func(t *testing.T) {
+		other := NewRelyingParty("evil.example", "Evil", testOrigin)
+		response := authenticator.get(t, "challenge-1", testOrigin, flagUserPresent|flagUserVerified)
+		_, err := other.VerifyAssertion("challenge-1", response, publicKey, 0, true)
+		assert.Equal(t, ErrInvalidAuthenticatorData, err)
+	})
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "Testcase #1: Positive, unsigned", data: []byte{0x19, 0x01, 0x00}, want: int64(256)},
+		{name: "Testcase #2: Positive, negative", data: []byte{0x38, 0x18}, want: int64(-25)},
+		{name: "Testcase #3: Positive, text", data: []byte{0x63, 'f', 'm', 't'}, want: "fmt"},
+		{name: "Testcase #4: Positive, half float", data: []byte{0xf9, 0x3c, 0x00}, want: float64(1)},
+		{name: "Testcase #5: Positive, true", data: []byte{0xf5}, want: true},
+		{name: "Testcase #6: Negative, truncated bytes", data: []byte{0x45, 0x01}, wantErr: true},
+		{name: "Testcase #7: Negative, indefinite length", data: []byte{0x5f, 0x41, 0x01, 0xff}, wantErr: true},
+		{name: "Testcase #8: Negative, array key", data: []byte{0xa1, 0x80, 0x01}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := decodeCBOR(tt.data)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}